@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 
 	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	gogogrpc "github.com/gogo/protobuf/grpc"
 	"github.com/gorilla/mux"
 	"github.com/rakyll/statik/fs"
 	"github.com/spf13/cast"
@@ -87,7 +88,12 @@ import (
 	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
 	peggyparams "github.com/cosmos/gravity-bridge/module/app/params"
 	"github.com/cosmos/gravity-bridge/module/x/peggy"
+	peggyante "github.com/cosmos/gravity-bridge/module/x/peggy/ante"
+	peggyclient "github.com/cosmos/gravity-bridge/module/x/peggy/client"
+	peggyibc "github.com/cosmos/gravity-bridge/module/x/peggy/ibc"
 	"github.com/cosmos/gravity-bridge/module/x/peggy/keeper"
+	peggyreflection "github.com/cosmos/gravity-bridge/module/x/peggy/reflection"
+	peggysubscription "github.com/cosmos/gravity-bridge/module/x/peggy/subscription"
 	peggytypes "github.com/cosmos/gravity-bridge/module/x/peggy/types"
 
 	// unnamed import of statik for swagger UI support
@@ -116,6 +122,15 @@ var (
 			distrclient.ProposalHandler,
 			upgradeclient.ProposalHandler,
 			upgradeclient.CancelProposalHandler,
+			peggyclient.ReturnReclaimableDepositProposalHandler,
+			peggyclient.UpdateBridgeContractProposalHandler,
+			peggyclient.AbandonValsetNonceProposalHandler,
+			peggyclient.UpdateParamsProposalHandler,
+			peggyclient.CancelBatchProposalHandler,
+			peggyclient.SetLastObservedEventNonceProposalHandler,
+			peggyclient.PauseTokenProposalHandler,
+			peggyclient.UnpauseTokenProposalHandler,
+			peggyclient.SetCosmosOriginatedERC20ProposalHandler,
 		),
 		params.AppModuleBasic{},
 		crisis.AppModuleBasic{},
@@ -131,14 +146,16 @@ var (
 	// module account permissions
 	// NOTE: We believe that this is giving various modules access to functions of the supply module? We will probably need to use this.
 	maccPerms = map[string][]string{
-		authtypes.FeeCollectorName:     nil,
-		distrtypes.ModuleName:          nil,
-		minttypes.ModuleName:           {authtypes.Minter},
-		stakingtypes.BondedPoolName:    {authtypes.Burner, authtypes.Staking},
-		stakingtypes.NotBondedPoolName: {authtypes.Burner, authtypes.Staking},
-		govtypes.ModuleName:            {authtypes.Burner},
-		ibctransfertypes.ModuleName:    {authtypes.Minter, authtypes.Burner},
-		peggytypes.ModuleName:          {authtypes.Minter, authtypes.Burner},
+		authtypes.FeeCollectorName:       nil,
+		distrtypes.ModuleName:            nil,
+		minttypes.ModuleName:             {authtypes.Minter},
+		stakingtypes.BondedPoolName:      {authtypes.Burner, authtypes.Staking},
+		stakingtypes.NotBondedPoolName:   {authtypes.Burner, authtypes.Staking},
+		govtypes.ModuleName:              {authtypes.Burner},
+		ibctransfertypes.ModuleName:      {authtypes.Minter, authtypes.Burner},
+		peggytypes.ModuleName:            {authtypes.Minter, authtypes.Burner},
+		peggytypes.FeeCollectorName:      {authtypes.Burner},
+		peggytypes.RelayerRewardPoolName: nil,
 	}
 
 	// module accounts that are allowed to receive tokens
@@ -327,6 +344,23 @@ func NewPeggyApp(
 		homePath,
 	)
 
+	app.peggyKeeper = keeper.NewKeeper(
+		appCodec,
+		keys[peggytypes.StoreKey],
+		app.GetSubspace(peggytypes.ModuleName),
+		&stakingKeeper,
+		app.bankKeeper,
+		app.slashingKeeper,
+		app.distrKeeper,
+		authtypes.NewModuleAddress(govtypes.ModuleName).String(),
+	)
+	if cast.ToBool(appOpts.Get(peggy.FlagDebugQueries)) {
+		app.peggyKeeper.EnableDebugQueries()
+	}
+	// peggy hooks for downstream modules have to be registered here, before the keeper is copied
+	// into the router and module manager, e.g. app.peggyKeeper.SetHooks(peggytypes.NewMultiPeggyHooks(...)).
+	// Handlers for custom claim types can be added with app.peggyKeeper.RegisterClaimHandler(...)
+
 	app.stakingKeeper = *stakingKeeper.SetHooks(
 		stakingtypes.NewMultiStakingHooks(
 			app.distrKeeper.Hooks(),
@@ -345,10 +379,11 @@ func NewPeggyApp(
 
 	govRouter := govtypes.NewRouter()
 	govRouter.AddRoute(govtypes.RouterKey, govtypes.ProposalHandler).
-		AddRoute(paramsproposal.RouterKey, params.NewParamChangeProposalHandler(app.paramsKeeper)).
+		AddRoute(paramsproposal.RouterKey, peggy.NewParamChangeProposalHandler(app.peggyKeeper, params.NewParamChangeProposalHandler(app.paramsKeeper))).
 		AddRoute(distrtypes.RouterKey, distr.NewCommunityPoolSpendProposalHandler(app.distrKeeper)).
 		AddRoute(upgradetypes.RouterKey, upgrade.NewSoftwareUpgradeProposalHandler(app.upgradeKeeper)).
-		AddRoute(ibchost.RouterKey, ibcclient.NewClientUpdateProposalHandler(app.ibcKeeper.ClientKeeper))
+		AddRoute(ibchost.RouterKey, ibcclient.NewClientUpdateProposalHandler(app.ibcKeeper.ClientKeeper)).
+		AddRoute(peggytypes.RouterKey, peggy.NewProposalHandler(app.peggyKeeper))
 
 	app.govKeeper = govkeeper.NewKeeper(
 		appCodec,
@@ -360,15 +395,18 @@ func NewPeggyApp(
 		govRouter,
 	)
 
+	// ICS-20 transfers move the tokens between the account and the escrow account of the channel with
+	// plain sends, the peggy send restriction keeps vouchers from leaving through IBC as well
 	app.transferKeeper = ibctransferkeeper.NewKeeper(
 		appCodec, keys[ibctransfertypes.StoreKey], app.GetSubspace(ibctransfertypes.ModuleName),
 		app.ibcKeeper.ChannelKeeper, &app.ibcKeeper.PortKeeper,
-		app.accountKeeper, app.bankKeeper, scopedTransferKeeper,
+		app.accountKeeper, keeper.NewRestrictedBankKeeper(app.bankKeeper, app.peggyKeeper), scopedTransferKeeper,
 	)
 	transferModule := transfer.NewAppModule(app.transferKeeper)
 
 	ibcRouter := porttypes.NewRouter()
-	ibcRouter.AddRoute(ibctransfertypes.ModuleName, transferModule)
+	// transfers with a forward receiver are sent on to Ethereum
+	ibcRouter.AddRoute(ibctransfertypes.ModuleName, peggyibc.NewMiddleware(transferModule, app.peggyKeeper))
 	app.ibcKeeper.SetRouter(ibcRouter)
 
 	evidenceKeeper := evidencekeeper.NewKeeper(
@@ -379,15 +417,6 @@ func NewPeggyApp(
 	)
 	app.evidenceKeeper = *evidenceKeeper
 
-	app.peggyKeeper = keeper.NewKeeper(
-		appCodec,
-		keys[peggytypes.StoreKey],
-		app.GetSubspace(peggytypes.ModuleName),
-		stakingKeeper,
-		app.bankKeeper,
-		app.slashingKeeper,
-	)
-
 	var skipGenesisInvariants = cast.ToBool(appOpts.Get(crisis.FlagSkipGenesisInvariants))
 
 	app.mm = module.NewManager(
@@ -406,9 +435,10 @@ func NewPeggyApp(
 			app.accountKeeper,
 			app.bankKeeper,
 		),
+		// MsgSend and MsgMultiSend of peggy vouchers can be disabled with the peggy params
 		bank.NewAppModule(
 			appCodec,
-			app.bankKeeper,
+			keeper.NewRestrictedBankKeeper(app.bankKeeper, app.peggyKeeper),
 			app.accountKeeper,
 		),
 		capability.NewAppModule(
@@ -498,7 +528,7 @@ func NewPeggyApp(
 
 	app.sm = module.NewSimulationManager(
 		auth.NewAppModule(appCodec, app.accountKeeper, authsims.RandomGenesisAccounts),
-		bank.NewAppModule(appCodec, app.bankKeeper, app.accountKeeper),
+		bank.NewAppModule(appCodec, keeper.NewRestrictedBankKeeper(app.bankKeeper, app.peggyKeeper), app.accountKeeper),
 		capability.NewAppModule(appCodec, *app.capabilityKeeper),
 		gov.NewAppModule(appCodec, app.govKeeper, app.accountKeeper, app.bankKeeper),
 		mint.NewAppModule(appCodec, app.mintKeeper, app.accountKeeper),
@@ -513,6 +543,8 @@ func NewPeggyApp(
 
 	app.sm.RegisterStoreDecoders()
 
+	app.registerUpgradeHandlers()
+
 	app.MountKVStores(keys)
 	app.MountTransientStores(tKeys)
 	app.MountMemoryStores(memKeys)
@@ -520,11 +552,14 @@ func NewPeggyApp(
 	app.SetInitChainer(app.InitChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
 	app.SetAnteHandler(
-		ante.NewAnteHandler(
-			app.accountKeeper,
-			app.bankKeeper,
-			ante.DefaultSigVerificationGasConsumer,
-			encodingConfig.TxConfig.SignModeHandler(),
+		peggyante.NewAnteHandler(
+			ante.NewAnteHandler(
+				app.accountKeeper,
+				app.bankKeeper,
+				ante.DefaultSigVerificationGasConsumer,
+				encodingConfig.TxConfig.SignModeHandler(),
+			),
+			app.peggyKeeper,
 		),
 	)
 	app.SetEndBlocker(app.EndBlocker)
@@ -697,6 +732,17 @@ func (app *Peggy) RegisterTendermintService(clientCtx client.Context) {
 	tmservice.RegisterTendermintService(app.BaseApp.GRPCQueryRouter(), clientCtx, app.interfaceRegistry)
 }
 
+// RegisterGRPCServer implements the Application.RegisterGRPCServer method, next to the query services
+// routed through ABCI it serves the peggy Subscription service that streams to the orchestrators. The
+// proto files of the peggy services are registered for the gRPC server reflection.
+func (app *Peggy) RegisterGRPCServer(clientCtx client.Context, server gogogrpc.Server) {
+	if err := peggyreflection.RegisterFiles(); err != nil {
+		app.Logger().Error("register peggy proto files for gRPC reflection", "err", err)
+	}
+	app.BaseApp.RegisterGRPCServer(clientCtx, server)
+	peggytypes.RegisterSubscriptionServer(server, peggysubscription.NewServer(clientCtx, peggysubscription.DefaultMaxSubscribers))
+}
+
 // GetMaccPerms returns a mapping of the application's module account permissions.
 func GetMaccPerms() map[string][]string {
 	modAccPerms := make(map[string][]string)
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/x/crisis"
+	peggykeeper "github.com/cosmos/gravity-bridge/module/x/peggy/keeper"
+	peggytypes "github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// pruneAppOptions are the options of the app PruneBridgeHistory loads the genesis into, the invariants
+// were asserted when the genesis was exported
+type pruneAppOptions struct{}
+
+func (pruneAppOptions) Get(o string) interface{} {
+	if o == crisis.FlagSkipGenesisInvariants {
+		return true
+	}
+	return nil
+}
+
+// PruneBridgeHistory loads the app state of an exported genesis into an app kept in memory, deletes the
+// bridge history before the event nonce and height from it with the peggy keeper's PruneHistory and
+// replaces the peggy genesis of genDoc with the result. The other modules are left as they are.
+func PruneBridgeHistory(genDoc *tmtypes.GenesisDoc, beforeEventNonce, beforeHeight uint64) (pruned peggykeeper.PrunedHistory, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("load genesis: %v", r)
+		}
+	}()
+
+	encCfg := MakeEncodingConfig()
+	app := NewPeggyApp(log.NewNopLogger(), dbm.NewMemDB(), nil, true, map[int64]bool{}, "", 0, encCfg, pruneAppOptions{})
+	app.InitChain(abci.RequestInitChain{
+		Time:          genDoc.GenesisTime,
+		ChainId:       genDoc.ChainID,
+		AppStateBytes: genDoc.AppState,
+		InitialHeight: genDoc.InitialHeight,
+	})
+	ctx := app.NewContext(false, tmproto.Header{ChainID: genDoc.ChainID, Height: genDoc.InitialHeight, Time: genDoc.GenesisTime})
+
+	pruned = app.peggyKeeper.PruneHistory(ctx, beforeEventNonce, beforeHeight)
+	peggyGenesis := peggykeeper.ExportGenesis(ctx, app.peggyKeeper)
+
+	var appState GenesisState
+	if err := json.Unmarshal(genDoc.AppState, &appState); err != nil {
+		return pruned, err
+	}
+	if appState[peggytypes.ModuleName], err = encCfg.Marshaler.MarshalJSON(&peggyGenesis); err != nil {
+		return pruned, err
+	}
+	if genDoc.AppState, err = json.Marshal(appState); err != nil {
+		return pruned, err
+	}
+	return pruned, nil
+}
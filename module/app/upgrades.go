@@ -0,0 +1,173 @@
+package app
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
+	distrkeeper "github.com/cosmos/cosmos-sdk/x/distribution/keeper"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+
+	peggytypes "github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// UpgradeName is the name of the upgrade plan that migrates the peggy store to the store version of
+// this binary, it changes with the version so that every release with new migrations has its own plan
+var UpgradeName = fmt.Sprintf("gravity-store-v%d", peggytypes.StoreVersion)
+
+// ContractRotationUpgradeName is the name of the upgrade plan that moves the bridge to a new bridge
+// contract, the info of the plan is the JSON encoded peggytypes.ContractRotation
+const ContractRotationUpgradeName = "contract-rotation"
+
+// registerUpgradeHandlers registers the handlers for the upgrade plans known to this binary
+func (app *Peggy) registerUpgradeHandlers() {
+	app.upgradeKeeper.SetUpgradeHandler(UpgradeName, func(ctx sdk.Context, plan upgradetypes.Plan) {
+		if err := app.peggyKeeper.RunStoreMigrations(ctx, app.storeMigrations()); err != nil {
+			panic(err)
+		}
+	})
+	app.upgradeKeeper.SetUpgradeHandler(ContractRotationUpgradeName, func(ctx sdk.Context, plan upgradetypes.Plan) {
+		rotation, err := peggytypes.ParseContractRotation(plan.Info)
+		if err != nil {
+			panic(err)
+		}
+		if err := app.peggyKeeper.RotateBridgeContract(ctx, rotation); err != nil {
+			panic(err)
+		}
+	})
+}
+
+// storeMigrations returns the migrations of the peggy store in order, the migration at index i moves
+// the store from version i to version i+1. Migrations are only ever appended, together with bumping
+// peggytypes.StoreVersion.
+func (app *Peggy) storeMigrations() []func(sdk.Context) error {
+	return []func(sdk.Context) error{
+		// 1: the state written under the peggy.v1 proto package moves to the gravity.v1 type URLs and
+		// the peggy id param to the gravity id. The fee index of the pool is rebuilt in its current
+		// layout first, the migrations reading the pool rely on it.
+		func(ctx sdk.Context) error {
+			if err := app.peggyKeeper.MigrateFeeIndex(ctx); err != nil {
+				return err
+			}
+			if err := app.peggyKeeper.MigrateLegacyTypeURLs(ctx); err != nil {
+				return err
+			}
+			if err := migrateLegacyProposalTypeURLs(ctx, app.keys[govtypes.StoreKey]); err != nil {
+				return err
+			}
+			return app.peggyKeeper.MigrateGravityIDParam(ctx)
+		},
+		// 2: the fees of pending transfers and logic calls move to the peggy fee collector
+		app.peggyKeeper.MigrateFeesToFeeCollector,
+		// 3: the ethereum originated vouchers move from the legacy peggy0x... denoms to gravity0x...
+		func(ctx sdk.Context) error {
+			if err := migrateLegacyVoucherBalances(ctx, app.bankKeeper); err != nil {
+				return err
+			}
+			migrateLegacyCommunityPool(ctx, app.distrKeeper)
+			return app.peggyKeeper.MigrateVoucherDenoms(ctx)
+		},
+		// 4: the attestations move to the keys derived from the claim hashes
+		app.peggyKeeper.MigrateAttestationKeys,
+		// 5: the params move from the params module subspace to the peggy store
+		app.peggyKeeper.MigrateParamsToStore,
+		// 6: the transfers of the batches built before the BatchForTx query are indexed
+		app.peggyKeeper.MigrateBatchedTxIndex,
+		// 7: the registered orchestrators are indexed by validator
+		app.peggyKeeper.MigrateOrchestratorIndex,
+		// 8: the ledger entries are indexed by height for pruning
+		app.peggyKeeper.MigrateAccountActivityHeightIndex,
+		// 9: the staking token escrowed for Ethereum is tracked and stays bridged
+		app.peggyKeeper.MigrateNativeTokenEscrow,
+		// 10: the scheduled transfers are indexed by the height and time they wait for
+		app.peggyKeeper.MigrateScheduledTransferIndex,
+		// 11: the unbatched transfers are indexed by the height and time they expire at
+		app.peggyKeeper.MigrateOutgoingTxExpirationIndex,
+		// 12: the attestations of withdraw claims move to the claim hashes without the eth tx hash
+		app.peggyKeeper.MigrateAttestationKeys,
+		// 13: the pending transfers are indexed by fee for the pending SendToEth query
+		app.peggyKeeper.MigratePendingOutgoingTxFeeIndex,
+	}
+}
+
+// migrateLegacyProposalTypeURLs rewrites the content of governance proposals packed
+// under the legacy peggy.v1 proto package, the gov keeper can't be used for this
+// since it fails to unpack proposals with unknown type URLs.
+func migrateLegacyProposalTypeURLs(ctx sdk.Context, key sdk.StoreKey) error {
+	store := ctx.KVStore(key)
+	iter := sdk.KVStorePrefixIterator(store, govtypes.ProposalsKeyPrefix)
+	defer iter.Close()
+
+	var keys, values [][]byte
+	for ; iter.Valid(); iter.Next() {
+		var proposal govtypes.Proposal
+		if err := proposal.Unmarshal(iter.Value()); err != nil {
+			return err
+		}
+		if proposal.Content == nil {
+			continue
+		}
+		typeURL := peggytypes.MigrateLegacyTypeURL(proposal.Content.TypeUrl)
+		if typeURL == proposal.Content.TypeUrl {
+			continue
+		}
+		proposal.Content.TypeUrl = typeURL
+		bz, err := proposal.Marshal()
+		if err != nil {
+			return err
+		}
+		keys = append(keys, iter.Key())
+		values = append(values, bz)
+	}
+
+	for i := range keys {
+		store.Set(keys[i], values[i])
+	}
+	return nil
+}
+
+// migrateLegacyVoucherBalances translates the balances and the supply of vouchers in the legacy
+// peggy denom format to the gravity denom format
+func migrateLegacyVoucherBalances(ctx sdk.Context, bankKeeper bankkeeper.Keeper) error {
+	var addrs []sdk.AccAddress
+	seen := make(map[string]bool)
+	bankKeeper.IterateAllBalances(ctx, func(addr sdk.AccAddress, coin sdk.Coin) bool {
+		if peggytypes.MigrateLegacyPeggyDenom(coin.Denom) != coin.Denom && !seen[addr.String()] {
+			seen[addr.String()] = true
+			addrs = append(addrs, addr)
+		}
+		return false
+	})
+	for _, addr := range addrs {
+		if err := bankKeeper.SetBalances(ctx, addr, migrateLegacyVoucherCoins(bankKeeper.GetAllBalances(ctx, addr))); err != nil {
+			return err
+		}
+	}
+
+	supply := bankKeeper.GetSupply(ctx)
+	supply.SetTotal(migrateLegacyVoucherCoins(supply.GetTotal()))
+	bankKeeper.SetSupply(ctx, supply)
+	return nil
+}
+
+// migrateLegacyCommunityPool translates the vouchers in the legacy peggy denom format that the bridge fees
+// and reclaimable deposits funded the community pool with, the coins are held by the distribution module
+// account whose balance is translated with the other balances
+func migrateLegacyCommunityPool(ctx sdk.Context, distrKeeper distrkeeper.Keeper) {
+	feePool := distrKeeper.GetFeePool(ctx)
+	pool := sdk.NewDecCoins()
+	for _, coin := range feePool.CommunityPool {
+		pool = pool.Add(sdk.NewDecCoinFromDec(peggytypes.MigrateLegacyPeggyDenom(coin.Denom), coin.Amount))
+	}
+	feePool.CommunityPool = pool
+	distrKeeper.SetFeePool(ctx, feePool)
+}
+
+func migrateLegacyVoucherCoins(coins sdk.Coins) sdk.Coins {
+	out := sdk.NewCoins()
+	for _, coin := range coins {
+		out = out.Add(sdk.NewCoin(peggytypes.MigrateLegacyPeggyDenom(coin.Denom), coin.Amount))
+	}
+	return out
+}
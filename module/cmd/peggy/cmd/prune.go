@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/app"
+	"github.com/spf13/cobra"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+const (
+	flagBeforeNonce  = "before-nonce"
+	flagBeforeHeight = "before-height"
+)
+
+// PruneBridgeHistoryCmd returns a command that deletes old bridge history from an exported genesis
+func PruneBridgeHistoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune-bridge-history [genesis-file]",
+		Short: "Delete old attestations, batch executions, valsets and valset confirms from an exported genesis",
+		Long: `Delete the bridge history an archive operator doesn't need from the peggy state of a genesis
+exported with the export command: the attestations and batch executions of the events before
+--before-nonce and the valsets and their confirms created before --before-height. Events that
+are not observed yet and valsets the bridge still needs are kept. The pruned genesis is written
+to --output-document, or printed.
+
+The state of a running node can't be pruned in place, it would no longer match the app hash of
+the chain.
+`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			beforeNonce, err := cmd.Flags().GetUint64(flagBeforeNonce)
+			if err != nil {
+				return err
+			}
+			beforeHeight, err := cmd.Flags().GetUint64(flagBeforeHeight)
+			if err != nil {
+				return err
+			}
+			if beforeNonce == 0 && beforeHeight == 0 {
+				return errors.New("nothing to prune, set --before-nonce or --before-height")
+			}
+
+			genDoc, err := tmtypes.GenesisDocFromFile(args[0])
+			if err != nil {
+				return err
+			}
+			pruned, err := app.PruneBridgeHistory(genDoc, beforeNonce, beforeHeight)
+			if err != nil {
+				return err
+			}
+			cmd.PrintErrln(fmt.Sprintf("pruned %d attestations, %d batch executions, %d valsets and %d valset confirms",
+				pruned.Attestations, pruned.BatchExecutions, pruned.Valsets, pruned.ValsetConfirms))
+
+			outputDocument, err := cmd.Flags().GetString(flags.FlagOutputDocument)
+			if err != nil {
+				return err
+			}
+			if outputDocument != "" {
+				return genDoc.SaveAs(outputDocument)
+			}
+			bz, err := tmjson.Marshal(genDoc)
+			if err != nil {
+				return err
+			}
+			sortedBz, err := sdk.SortJSON(bz)
+			if err != nil {
+				return err
+			}
+			cmd.Println(string(sortedBz))
+			return nil
+		},
+	}
+
+	cmd.Flags().Uint64(flagBeforeNonce, 0, "Prune the attestations and batch executions of the events before this event nonce")
+	cmd.Flags().Uint64(flagBeforeHeight, 0, "Prune the valsets created before this block height together with their confirms")
+	cmd.Flags().String(flags.FlagOutputDocument, "", "Write the pruned genesis to this file instead of printing it")
+	return cmd
+}
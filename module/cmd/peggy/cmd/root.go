@@ -33,6 +33,7 @@ import (
 
 	"github.com/cosmos/gravity-bridge/module/app"
 	"github.com/cosmos/gravity-bridge/module/app/params"
+	"github.com/cosmos/gravity-bridge/module/x/peggy"
 )
 
 // NewRootCmd creates a new root command for simd. It is called once in the
@@ -97,6 +98,7 @@ func initRootCmd(rootCmd *cobra.Command, encodingConfig params.EncodingConfig) {
 		AddGenesisAccountCmd(app.DefaultNodeHome),
 		tmcli.NewCompletionCmd(rootCmd, true),
 		testnetCmd(app.ModuleBasics, banktypes.GenesisBalancesIterator{}),
+		PruneBridgeHistoryCmd(),
 		debug.Cmd(),
 	)
 
@@ -114,6 +116,7 @@ func initRootCmd(rootCmd *cobra.Command, encodingConfig params.EncodingConfig) {
 
 func addModuleInitFlags(startCmd *cobra.Command) {
 	crisis.AddModuleInitFlags(startCmd)
+	peggy.AddModuleInitFlags(startCmd)
 }
 
 func queryCommand() *cobra.Command {
@@ -1,8 +1,6 @@
 package peggy
 
 import (
-	"sort"
-
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/gravity-bridge/module/x/peggy/keeper"
 	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
@@ -12,10 +10,20 @@ import (
 func EndBlocker(ctx sdk.Context, k keeper.Keeper) {
 	// Question: what here can be epoched?
 	slashing(ctx, k)
-	attestationTally(ctx, k)
-	cleanupTimedOutBatches(ctx, k)
-	cleanupTimedOutLogicCalls(ctx, k)
+	// the work below grows with a backlog, it shares the work budget of the params and
+	// whatever doesn't fit is deferred to the next blocks
+	workCtx := k.WithWorkBudget(ctx)
+	attestationTally(workCtx, k)
+	k.ReportObservedNonceLag(ctx)
+	k.PruneTimedOutAttestations(workCtx)
+	cleanupTimedOutBatches(workCtx, k)
+	cleanupTimedOutLogicCalls(workCtx, k)
+	k.ReleaseScheduledTransfers(workCtx)
+	k.RefundExpiredOutgoingTxs(workCtx)
+	k.PruneOrphanedEntries(workCtx)
+	k.PruneAccountActivities(workCtx)
 	createValsets(ctx, k)
+	k.SweepModuleResidueAtInterval(ctx)
 }
 
 func createValsets(ctx sdk.Context, k keeper.Keeper) {
@@ -26,11 +34,19 @@ func createValsets(ctx sdk.Context, k keeper.Keeper) {
 			   This will make sure the unbonding validator has to provide an attestation to a new Valset
 		       that excludes him before he completely Unbonds.  Otherwise he will be slashed
 			3. If power change between validators of CurrentValset and latest valset request is > 5%
+			4. If a validator was slashed in current block and is no longer in CurrentValset, i.e. it was tombstoned.
+			   This ejects a compromised validator from the bridge right away instead of waiting for the power change
 		**/
 	latestValset := k.GetLatestValset(ctx)
 	lastUnbondingHeight := k.GetLastUnBondingBlockHeight(ctx)
+	lastSlashHeight := k.GetLastSlashBlockHeight(ctx)
 
-	if (latestValset == nil) || (lastUnbondingHeight == uint64(ctx.BlockHeight())) || (types.BridgeValidators(k.GetCurrentValset(ctx).Members).PowerDiff(latestValset.Members) > 0.05) {
+	if latestValset == nil || lastUnbondingHeight == uint64(ctx.BlockHeight()) {
+		k.SetValsetRequest(ctx)
+		return
+	}
+	current := types.BridgeValidators(k.GetCurrentValset(ctx).Members)
+	if current.PowerDiffBasisPoints(latestValset.Members) > types.ValsetPowerDiffThresholdBasisPoints || (lastSlashHeight == uint64(ctx.BlockHeight()) && current.DropsMemberOf(latestValset.Members)) {
 		k.SetValsetRequest(ctx)
 	}
 }
@@ -46,50 +62,29 @@ func slashing(ctx sdk.Context, k keeper.Keeper) {
 
 	// TODO: prune validator sets, older than 6 months, this time is chosen out of an abundance of caution
 	// TODO: prune outgoing tx batches while looping over them above, older than 15h and confirmed
-	// TODO: prune claims, attestations
+	// TODO: prune observed claims, attestations
 }
 
-// Iterate over all attestations currently being voted on in order of nonce and
-// "Observe" those who have passed the threshold. Break the loop once we see
-// an attestation that has not passed the threshold
+// Iterate over the attestations above the last observed event nonce in order of nonce and
+// "Observe" those who have passed the threshold. Break the loop once we see a nonce without
+// an attestation that passed the threshold, or once the work budget is spent
 func attestationTally(ctx sdk.Context, k keeper.Keeper) {
-	attmap := k.GetAttestationMapping(ctx)
-	// We make a slice with all the event nonces that are in the attestation mapping
-	keys := make([]uint64, 0, len(attmap))
-	for k := range attmap {
-		keys = append(keys, k)
-	}
-	// Then we sort it
-	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
-
-	// This iterates over all keys (event nonces) in the attestation mapping. Each value contains
-	// a slice with one or more attestations at that event nonce. There can be multiple attestations
-	// at one event nonce when validators disagree about what event happened at that nonce.
-	for _, nonce := range keys {
-		// This iterates over all attestations at a particular event nonce.
-		// They are ordered by when the first attestation at the event nonce was received.
-		// This order is not important.
-		for _, att := range attmap[nonce] {
-			// We check if the event nonce is exactly 1 higher than the last attestation that was
-			// observed. If it is not, we just move on to the next nonce. This will skip over all
-			// attestations that have already been observed.
-			//
-			// Once we hit an event nonce that is one higher than the last observed event, we stop
-			// skipping over this conditional and start calling tryAttestation (counting votes)
-			// Once an attestation at a given event nonce has enough votes and becomes observed,
-			// every other attestation at that nonce will be skipped, since the lastObservedEventNonce
-			// will be incremented.
-			//
-			// Then we go to the next event nonce in the attestation mapping, if there is one. This
-			// nonce will once again be one higher than the lastObservedEventNonce.
-			// If there is an attestation at this event nonce which has enough votes to be observed,
-			// we skip the other attestations and move on to the next nonce again.
-			// If no attestation becomes observed, when we get to the next nonce, every attestation in
-			// it will be skipped. The same will happen for every nonce after that.
-			if nonce == uint64(k.GetLastObservedEventNonce(ctx))+1 {
+	for tallied := 0; tallied == 0 || !keeper.WorkBudgetExhausted(ctx); tallied++ {
+		nonce := k.GetLastObservedEventNonce(ctx) + 1
+		// There can be multiple attestations at one event nonce when validators disagree about
+		// what event happened at that nonce. They are ordered by claim hash, this order is not
+		// important: once an attestation becomes observed the last observed event nonce is
+		// incremented and the other attestations at the nonce are skipped.
+		for _, att := range k.GetAttestationsByNonce(ctx, nonce) {
+			if nonce == k.GetLastObservedEventNonce(ctx)+1 {
 				k.TryAttestation(ctx, &att)
 			}
 		}
+		// no attestation at this nonce has enough votes yet, every attestation after it
+		// has to wait
+		if k.GetLastObservedEventNonce(ctx) < nonce {
+			return
+		}
 	}
 }
 
@@ -104,12 +99,8 @@ func attestationTally(ctx sdk.Context, k keeper.Keeper) {
 //    AND any deposit or withdraw has occurred to update the Ethereum block height.
 func cleanupTimedOutBatches(ctx sdk.Context, k keeper.Keeper) {
 	ethereumHeight := k.GetLastObservedEthereumBlockHeight(ctx).EthereumBlockHeight
-	batches := k.GetOutgoingTxBatches(ctx)
-	for _, batch := range batches {
-		if batch.BatchTimeout < ethereumHeight {
-			k.CancelOutgoingTXBatch(ctx, batch.TokenContract, batch.BatchNonce)
-		}
-	}
+	k.CancelTimedOutBatches(ctx, ethereumHeight)
+	k.ReleaseTimedOutCancelledBatches(ctx, ethereumHeight)
 }
 
 // cleanupTimedOutBatches deletes logic calls that have passed their expiration on Ethereum
@@ -123,21 +114,17 @@ func cleanupTimedOutBatches(ctx sdk.Context, k keeper.Keeper) {
 //    AND any deposit or withdraw has occurred to update the Ethereum block height.
 func cleanupTimedOutLogicCalls(ctx sdk.Context, k keeper.Keeper) {
 	ethereumHeight := k.GetLastObservedEthereumBlockHeight(ctx).EthereumBlockHeight
-	calls := k.GetOutgoingLogicCalls(ctx)
-	for _, call := range calls {
-		if call.Timeout < ethereumHeight {
-			k.CancelOutgoingLogicCall(ctx, call.InvalidationId, call.InvalidationNonce)
-		}
-	}
+	k.CancelTimedOutLogicCalls(ctx, ethereumHeight)
 }
 
 func ValsetSlashing(ctx sdk.Context, k keeper.Keeper, params types.Params) {
 
 	maxHeight := uint64(0)
 
-	// don't slash in the beginning before there aren't even SignedValsetsWindow blocks yet
-	if uint64(ctx.BlockHeight()) > params.SignedValsetsWindow {
-		maxHeight = uint64(ctx.BlockHeight()) - params.SignedValsetsWindow
+	// don't slash in the beginning before there aren't even SignedValsetsWindow blocks yet, the
+	// ConfirmGracePeriod is added to the window
+	if window := params.ValsetSlashingWindow(); uint64(ctx.BlockHeight()) > window {
+		maxHeight = uint64(ctx.BlockHeight()) - window
 	}
 
 	unslashedValsets := k.GetUnSlashedValsets(ctx, maxHeight)
@@ -166,7 +153,7 @@ func ValsetSlashing(ctx sdk.Context, k keeper.Keeper, params types.Params) {
 				// slash validators for not confirming valsets
 				if !found {
 					cons, _ := val.GetConsAddr()
-					k.StakingKeeper.Slash(ctx, cons, ctx.BlockHeight(), val.ConsensusPower(), params.SlashFractionValset)
+					k.Slash(ctx, cons, val.ConsensusPower(), params.SlashFractionValset)
 					if !val.IsJailed() {
 						k.StakingKeeper.Jail(ctx, cons)
 					}
@@ -195,7 +182,7 @@ func ValsetSlashing(ctx sdk.Context, k keeper.Keeper, params types.Params) {
 				valSigningInfo, exist := k.SlashingKeeper.GetValidatorSigningInfo(ctx, valConsAddr)
 
 				// Only slash validators who joined after valset is created and they are unbonding and UNBOND_SLASHING_WINDOW didn't passed
-				if exist && valSigningInfo.StartHeight < int64(vs.Nonce) && validator.IsUnbonding() && vs.Nonce < uint64(validator.UnbondingHeight)+params.UnbondSlashingValsetsWindow {
+				if exist && valSigningInfo.StartHeight < int64(vs.Nonce) && k.IsUnbondingValsetSigner(ctx, addr, vs.Nonce) {
 					// Check if validator has confirmed valset or not
 					found := false
 					for _, conf := range confirms {
@@ -207,7 +194,7 @@ func ValsetSlashing(ctx sdk.Context, k keeper.Keeper, params types.Params) {
 
 					// slash validators for not confirming valsets
 					if !found {
-						k.StakingKeeper.Slash(ctx, valConsAddr, ctx.BlockHeight(), validator.ConsensusPower(), params.SlashFractionValset)
+						k.Slash(ctx, valConsAddr, validator.ConsensusPower(), params.SlashFractionValset)
 						if !validator.IsJailed() {
 							k.StakingKeeper.Jail(ctx, valConsAddr)
 						}
@@ -227,9 +214,10 @@ func BatchSlashing(ctx sdk.Context, k keeper.Keeper, params types.Params) {
 	// and we slash users who haven't signed a batch confirmation that is >15hrs in blocks old
 	maxHeight := uint64(0)
 
-	// don't slash in the beginning before there aren't even SignedBatchesWindow blocks yet
-	if uint64(ctx.BlockHeight()) > params.SignedBatchesWindow {
-		maxHeight = uint64(ctx.BlockHeight()) - params.SignedBatchesWindow
+	// don't slash in the beginning before there aren't even SignedBatchesWindow blocks yet, the
+	// ConfirmGracePeriod is added to the window
+	if window := params.BatchSlashingWindow(); uint64(ctx.BlockHeight()) > window {
+		maxHeight = uint64(ctx.BlockHeight()) - window
 	}
 
 	unslashedBatches := k.GetUnSlashedBatches(ctx, maxHeight)
@@ -257,7 +245,7 @@ func BatchSlashing(ctx sdk.Context, k keeper.Keeper, params types.Params) {
 			}
 			if !found {
 				cons, _ := val.GetConsAddr()
-				k.StakingKeeper.Slash(ctx, cons, ctx.BlockHeight(), val.ConsensusPower(), params.SlashFractionBatch)
+				k.Slash(ctx, cons, val.ConsensusPower(), params.SlashFractionBatch)
 				if !val.IsJailed() {
 					k.StakingKeeper.Jail(ctx, cons)
 				}
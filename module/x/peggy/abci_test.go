@@ -45,6 +45,38 @@ func TestValsetCreationUponUnbonding(t *testing.T) {
 	assert.Equal(t, uint64(input.Context.BlockHeight()), pk.GetLatestValsetNonce(ctx))
 }
 
+func TestValsetCreationUponTombstoning(t *testing.T) {
+	input, ctx := keeper.SetupFiveValChain(t)
+	pk := input.PeggyKeeper
+	pk.SetValsetRequest(ctx)
+
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	val, found := input.StakingKeeper.GetValidator(ctx, keeper.ValAddrs[0])
+	require.True(t, found)
+	consAddr, err := val.GetConsAddr()
+	require.NoError(t, err)
+
+	// a downtime slash with a small power change doesn't need a new valset
+	input.SlashingKeeper.Slash(ctx, consAddr, sdk.NewDecWithPrec(1, 2), val.ConsensusPower(), ctx.BlockHeight())
+	EndBlocker(ctx, pk)
+	assert.Equal(t, uint64(ctx.BlockHeight()-1), pk.GetLatestValsetNonce(ctx))
+
+	// the evidence module slashes, jails and tombstones a double signer, it is ejected before the
+	// staking endblocker removes it from the bonded validators
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	input.SlashingKeeper.Slash(ctx, consAddr, sdk.NewDecWithPrec(5, 2), val.ConsensusPower(), ctx.BlockHeight())
+	input.SlashingKeeper.Jail(ctx, consAddr)
+	input.SlashingKeeper.Tombstone(ctx, consAddr)
+	EndBlocker(ctx, pk)
+
+	require.Equal(t, uint64(ctx.BlockHeight()), pk.GetLatestValsetNonce(ctx))
+	valset := pk.GetValset(ctx, uint64(ctx.BlockHeight()))
+	require.Len(t, valset.Members, 4)
+	for _, m := range valset.Members {
+		assert.NotEqual(t, keeper.EthAddrs[0].String(), m.EthereumAddress)
+	}
+}
+
 func TestValsetSlashing_ValsetCreated_Before_ValidatorBonded(t *testing.T) {
 	//	Don't slash validators if valset is created before he is bonded.
 
@@ -100,6 +132,33 @@ func TestValsetSlashing_ValsetCreated_After_ValidatorBonded(t *testing.T) {
 
 }
 
+func TestValsetSlashing_ConfirmGracePeriod(t *testing.T) {
+	input, ctx := keeper.SetupFiveValChain(t)
+	pk := input.PeggyKeeper
+	params := pk.GetParams(ctx)
+	params.ConfirmGracePeriod = 10
+	pk.SetParams(ctx, params)
+
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + int64(params.SignedValsetsWindow) + 2)
+	vs := pk.GetCurrentValset(ctx)
+	height := uint64(ctx.BlockHeight()) - (params.SignedValsetsWindow + 1)
+	vs.Height = height
+	vs.Nonce = height
+	pk.StoreValsetUnsafe(ctx, vs)
+
+	// the missing confirm is past the signed window but still in the grace period
+	res, err := pk.PendingWork(sdk.WrapSDKContext(ctx), &types.QueryPendingWorkRequest{Orchestrator: keeper.AccAddrs[0].String()})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{10}, res.ValsetGraceBlocks)
+	EndBlocker(ctx, pk)
+	require.False(t, input.StakingKeeper.Validator(ctx, keeper.ValAddrs[0]).IsJailed())
+
+	// it counts once the grace period is over
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 10)
+	EndBlocker(ctx, pk)
+	require.True(t, input.StakingKeeper.Validator(ctx, keeper.ValAddrs[0]).IsJailed())
+}
+
 func TestValsetSlashing_UnbondingValidator_UnbondWindow_NotExpired(t *testing.T) {
 	//	Slashing Conditions for Unbonding Validator
 
@@ -221,10 +280,10 @@ func TestValsetEmission(t *testing.T) {
 	input, ctx := keeper.SetupFiveValChain(t)
 	pk := input.PeggyKeeper
 
-	// Store a validator set with a power change as the most recent validator set
+	// Store a validator set with a power change above the threshold as the most recent validator set
 	vs := pk.GetCurrentValset(ctx)
 	vs.Nonce = vs.Nonce - 1
-	delta := float64(types.BridgeValidators(vs.Members).TotalPower()) * 0.05
+	delta := float64(types.BridgeValidators(vs.Members).TotalPower()) * 0.06
 	vs.Members[0].Power = uint64(float64(vs.Members[0].Power) - delta/2)
 	vs.Members[1].Power = uint64(float64(vs.Members[1].Power) + delta/2)
 	pk.StoreValset(ctx, vs)
@@ -328,3 +387,47 @@ func TestBatchTimeout(t *testing.T) {
 	gotThirdBatch = input.PeggyKeeper.GetOutgoingTXBatch(ctx, b3.TokenContract, b3.BatchNonce)
 	require.NotNil(t, gotThirdBatch)
 }
+
+func BenchmarkAttestationTally(b *testing.B) {
+	var (
+		orchestrators   []sdk.AccAddress
+		validators      []sdk.ValAddress
+		myCosmosAddr, _ = sdk.AccAddressFromBech32("cosmos16ahjkfqxpp6lvfy9fpfnfjg39xr96qett0alj5")
+	)
+	for i := 0; i < 5; i++ {
+		orchestrators = append(orchestrators, keeper.AccAddrs[i])
+		validators = append(validators, keeper.ValAddrs[i])
+	}
+	input := keeper.CreateTestEnv(b)
+	ctx := input.Context
+	input.PeggyKeeper.StakingKeeper = keeper.NewStakingKeeperMock(validators...)
+	for i := range validators {
+		input.PeggyKeeper.SetOrchestratorValidator(ctx, validators[i], orchestrators[i])
+	}
+	h := NewHandler(input.PeggyKeeper)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// every validator votes for the next event, tallying observes it
+		b.StopTimer()
+		for _, orchestrator := range orchestrators {
+			_, err := h(ctx, &types.MsgDepositClaim{
+				EventNonce:            uint64(i + 1),
+				BlockHeight:           uint64(i + 1),
+				TokenContract:         "0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e",
+				Amount:                sdk.NewInt(100),
+				EthereumSender:        "0xf9613b532673Cc223aBa451dFA8539B87e1F666D",
+				CosmosReceiver:        myCosmosAddr.String(),
+				Orchestrator:          orchestrator.String(),
+				BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+				BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
+			})
+			require.NoError(b, err)
+		}
+		b.StartTimer()
+
+		attestationTally(ctx, input.PeggyKeeper)
+	}
+	b.StopTimer()
+	require.Equal(b, uint64(b.N), input.PeggyKeeper.GetLastObservedEventNonce(ctx))
+}
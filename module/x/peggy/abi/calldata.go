@@ -0,0 +1,180 @@
+package abi
+
+import (
+	"math/big"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// LogicCallArgs is the LogicCallArgs struct of the bridge contract, the fields are matched by name with
+// the components of the tuple in PeggyABIJSON
+type LogicCallArgs struct {
+	TransferAmounts        []*big.Int
+	TransferTokenContracts []gethcommon.Address
+	FeeAmounts             []*big.Int
+	FeeTokenContracts      []gethcommon.Address
+	LogicContractAddress   gethcommon.Address
+	Payload                []byte
+	TimeOut                *big.Int
+	InvalidationId         [32]byte
+	InvalidationNonce      *big.Int
+}
+
+// UpdateValsetCalldata returns the calldata of updateValset switching the bridge contract from the
+// current valset to the new one, with one signature per member of the current valset
+func UpdateValsetCalldata(newValset, currentValset types.ValsetArgs, signatures []types.CheckpointSignature) ([]byte, error) {
+	newValidators, newPowers, err := valsetValues(newValset)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "new valset")
+	}
+	currentValidators, currentPowers, err := valsetValues(currentValset)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "current valset")
+	}
+	v, r, s, err := signatureValues(currentValset, signatures)
+	if err != nil {
+		return nil, err
+	}
+	return pack("updateValset",
+		newValidators,
+		newPowers,
+		new(big.Int).SetUint64(newValset.ValsetNonce),
+		currentValidators,
+		currentPowers,
+		new(big.Int).SetUint64(currentValset.ValsetNonce),
+		v, r, s,
+	)
+}
+
+// SubmitBatchCalldata returns the calldata of submitBatch executing the batch, with one signature per
+// member of the current valset
+func SubmitBatchCalldata(currentValset types.ValsetArgs, signatures []types.CheckpointSignature, batch types.BatchArgs) ([]byte, error) {
+	currentValidators, currentPowers, err := valsetValues(currentValset)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "current valset")
+	}
+	v, r, s, err := signatureValues(currentValset, signatures)
+	if err != nil {
+		return nil, err
+	}
+	if len(batch.Amounts) != len(batch.Destinations) || len(batch.Amounts) != len(batch.Fees) {
+		return nil, sdkerrors.Wrapf(types.ErrInvalid, "%d amounts, %d destinations and %d fees", len(batch.Amounts), len(batch.Destinations), len(batch.Fees))
+	}
+	destinations := make([]gethcommon.Address, len(batch.Destinations))
+	for i, destination := range batch.Destinations {
+		destinations[i] = gethcommon.HexToAddress(destination)
+	}
+	return pack("submitBatch",
+		currentValidators,
+		currentPowers,
+		new(big.Int).SetUint64(currentValset.ValsetNonce),
+		v, r, s,
+		bigInts(batch.Amounts),
+		destinations,
+		bigInts(batch.Fees),
+		new(big.Int).SetUint64(batch.BatchNonce),
+		gethcommon.HexToAddress(batch.TokenContract),
+		new(big.Int).SetUint64(batch.BatchTimeout),
+	)
+}
+
+// SubmitLogicCallCalldata returns the calldata of submitLogicCall executing the logic call, with one
+// signature per member of the current valset. The invalidation id is right padded to 32 bytes like a
+// bytes32 in Solidity.
+func SubmitLogicCallCalldata(currentValset types.ValsetArgs, signatures []types.CheckpointSignature, call types.OutgoingLogicCall) ([]byte, error) {
+	currentValidators, currentPowers, err := valsetValues(currentValset)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "current valset")
+	}
+	v, r, s, err := signatureValues(currentValset, signatures)
+	if err != nil {
+		return nil, err
+	}
+	if len(call.InvalidationId) > 32 {
+		return nil, sdkerrors.Wrap(types.ErrInvalid, "invalidation id longer than 32 bytes")
+	}
+	args := LogicCallArgs{
+		TransferAmounts:        make([]*big.Int, len(call.Transfers)),
+		TransferTokenContracts: make([]gethcommon.Address, len(call.Transfers)),
+		FeeAmounts:             make([]*big.Int, len(call.Fees)),
+		FeeTokenContracts:      make([]gethcommon.Address, len(call.Fees)),
+		LogicContractAddress:   gethcommon.HexToAddress(call.LogicContractAddress),
+		Payload:                append([]byte{}, call.Payload...),
+		TimeOut:                new(big.Int).SetUint64(call.Timeout),
+		InvalidationNonce:      new(big.Int).SetUint64(call.InvalidationNonce),
+	}
+	for i, transfer := range call.Transfers {
+		args.TransferAmounts[i] = transfer.Amount.BigInt()
+		args.TransferTokenContracts[i] = gethcommon.HexToAddress(transfer.Contract)
+	}
+	for i, fee := range call.Fees {
+		args.FeeAmounts[i] = fee.Amount.BigInt()
+		args.FeeTokenContracts[i] = gethcommon.HexToAddress(fee.Contract)
+	}
+	copy(args.InvalidationId[:], call.InvalidationId)
+	return pack("submitLogicCall",
+		currentValidators,
+		currentPowers,
+		new(big.Int).SetUint64(currentValset.ValsetNonce),
+		v, r, s,
+		args,
+	)
+}
+
+func pack(method string, args ...interface{}) ([]byte, error) {
+	contractAbi, err := ethabi.JSON(strings.NewReader(PeggyABIJSON))
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "bad ABI definition in code")
+	}
+	data, err := contractAbi.Pack(method, args...)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(err, "packing %s", method)
+	}
+	return data, nil
+}
+
+func valsetValues(valset types.ValsetArgs) ([]gethcommon.Address, []*big.Int, error) {
+	if len(valset.Validators) != len(valset.Powers) {
+		return nil, nil, sdkerrors.Wrapf(types.ErrInvalid, "%d validators but %d powers", len(valset.Validators), len(valset.Powers))
+	}
+	validators := make([]gethcommon.Address, len(valset.Validators))
+	powers := make([]*big.Int, len(valset.Powers))
+	for i := range valset.Validators {
+		validators[i] = gethcommon.HexToAddress(valset.Validators[i])
+		powers[i] = new(big.Int).SetUint64(valset.Powers[i])
+	}
+	return validators, powers, nil
+}
+
+// signatureValues splits the signatures into the v, r and s arrays the contract takes, the contract
+// expects exactly one signature per member of the valset
+func signatureValues(valset types.ValsetArgs, signatures []types.CheckpointSignature) ([]uint8, [][32]byte, [][32]byte, error) {
+	if len(signatures) != len(valset.Validators) {
+		return nil, nil, nil, sdkerrors.Wrapf(types.ErrInvalid, "%d signatures for %d validators", len(signatures), len(valset.Validators))
+	}
+	v := make([]uint8, len(signatures))
+	r := make([][32]byte, len(signatures))
+	s := make([][32]byte, len(signatures))
+	for i, sig := range signatures {
+		if sig.V > 255 || len(sig.R) != 32 || len(sig.S) != 32 {
+			return nil, nil, nil, sdkerrors.Wrapf(types.ErrInvalid, "signature of %s", valset.Validators[i])
+		}
+		v[i] = uint8(sig.V)
+		copy(r[i][:], sig.R)
+		copy(s[i][:], sig.S)
+	}
+	return v, r, s, nil
+}
+
+func bigInts(amounts []sdk.Int) []*big.Int {
+	out := make([]*big.Int, len(amounts))
+	for i, amount := range amounts {
+		out[i] = amount.BigInt()
+	}
+	return out
+}
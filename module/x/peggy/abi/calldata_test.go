@@ -0,0 +1,154 @@
+package abi
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	ethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	validators = []string{"0xc783df8a850f42e7F7e57013759C285caa701eB6", "0xeAD9C93b79Ae7C1591b1FB5323BD777E86e150d4"}
+	valset     = types.ValsetArgs{Validators: validators, Powers: []uint64{3000000000, 1294967295}, ValsetNonce: 7}
+	signatures = []types.CheckpointSignature{
+		{V: 27, R: bytes.Repeat([]byte{1}, 32), S: bytes.Repeat([]byte{2}, 32)},
+		{R: make([]byte, 32), S: make([]byte, 32)},
+	}
+)
+
+// unpack decodes the calldata with the ABI of the contract, checking that its method id is the one of the
+// canonical Solidity signature
+func unpack(t *testing.T, calldata []byte, signature string) []interface{} {
+	t.Helper()
+	assert.Equal(t, crypto.Keccak256([]byte(signature))[:4], calldata[:4])
+	contractAbi, err := ethabi.JSON(strings.NewReader(PeggyABIJSON))
+	require.NoError(t, err)
+	method, err := contractAbi.MethodById(calldata[:4])
+	require.NoError(t, err)
+	values, err := method.Inputs.UnpackValues(calldata[4:])
+	require.NoError(t, err)
+	return values
+}
+
+func assertValset(t *testing.T, exp types.ValsetArgs, validators, powers, nonce interface{}) {
+	t.Helper()
+	assert.Equal(t, []gethcommon.Address{gethcommon.HexToAddress(exp.Validators[0]), gethcommon.HexToAddress(exp.Validators[1])}, validators)
+	assert.Equal(t, []*big.Int{new(big.Int).SetUint64(exp.Powers[0]), new(big.Int).SetUint64(exp.Powers[1])}, powers)
+	assert.Equal(t, new(big.Int).SetUint64(exp.ValsetNonce), nonce)
+}
+
+func assertSignatures(t *testing.T, v, r, s interface{}) {
+	t.Helper()
+	assert.Equal(t, []uint8{27, 0}, v)
+	assert.Equal(t, [][32]byte{toBytes32(signatures[0].R), {}}, r)
+	assert.Equal(t, [][32]byte{toBytes32(signatures[0].S), {}}, s)
+}
+
+func toBytes32(b []byte) (out [32]byte) {
+	copy(out[:], b)
+	return
+}
+
+func TestUpdateValsetCalldata(t *testing.T) {
+	newValset := types.ValsetArgs{Validators: []string{validators[1], validators[0]}, Powers: []uint64{2147483648, 2147483647}, ValsetNonce: 8}
+	calldata, err := UpdateValsetCalldata(newValset, valset, signatures)
+	require.NoError(t, err)
+
+	values := unpack(t, calldata, "updateValset(address[],uint256[],uint256,address[],uint256[],uint256,uint8[],bytes32[],bytes32[])")
+	require.Len(t, values, 9)
+	assertValset(t, newValset, values[0], values[1], values[2])
+	assertValset(t, valset, values[3], values[4], values[5])
+	assertSignatures(t, values[6], values[7], values[8])
+
+	// the contract takes exactly one signature per member of the current valset
+	_, err = UpdateValsetCalldata(newValset, valset, signatures[:1])
+	assert.True(t, types.ErrInvalid.Is(err), err)
+	_, err = UpdateValsetCalldata(newValset, valset, []types.CheckpointSignature{signatures[0], {R: []byte{1}, S: make([]byte, 32)}})
+	assert.True(t, types.ErrInvalid.Is(err), err)
+	_, err = UpdateValsetCalldata(types.ValsetArgs{Validators: validators, Powers: []uint64{1}}, valset, signatures)
+	assert.True(t, types.ErrInvalid.Is(err), err)
+}
+
+func TestSubmitBatchCalldata(t *testing.T) {
+	batch := types.BatchArgs{
+		Amounts:       []sdk.Int{sdk.NewInt(100), sdk.NewIntFromBigInt(new(big.Int).Lsh(big.NewInt(1), 200))},
+		Destinations:  []string{"0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7", validators[0]},
+		Fees:          []sdk.Int{sdk.NewInt(2), sdk.NewInt(1)},
+		BatchNonce:    3,
+		TokenContract: "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5",
+		BatchTimeout:  12345,
+	}
+	calldata, err := SubmitBatchCalldata(valset, signatures, batch)
+	require.NoError(t, err)
+
+	values := unpack(t, calldata, "submitBatch(address[],uint256[],uint256,uint8[],bytes32[],bytes32[],uint256[],address[],uint256[],uint256,address,uint256)")
+	require.Len(t, values, 12)
+	assertValset(t, valset, values[0], values[1], values[2])
+	assertSignatures(t, values[3], values[4], values[5])
+	assert.Equal(t, []*big.Int{batch.Amounts[0].BigInt(), batch.Amounts[1].BigInt()}, values[6])
+	assert.Equal(t, []gethcommon.Address{gethcommon.HexToAddress(batch.Destinations[0]), gethcommon.HexToAddress(batch.Destinations[1])}, values[7])
+	assert.Equal(t, []*big.Int{big.NewInt(2), big.NewInt(1)}, values[8])
+	assert.Equal(t, big.NewInt(3), values[9])
+	assert.Equal(t, gethcommon.HexToAddress(batch.TokenContract), values[10])
+	assert.Equal(t, big.NewInt(12345), values[11])
+
+	batch.Fees = batch.Fees[:1]
+	_, err = SubmitBatchCalldata(valset, signatures, batch)
+	assert.True(t, types.ErrInvalid.Is(err), err)
+}
+
+func TestSubmitLogicCallCalldata(t *testing.T) {
+	const tokenContract = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+	call := types.OutgoingLogicCall{
+		Transfers:            []*types.ERC20Token{types.NewERC20Token(100, tokenContract)},
+		Fees:                 []*types.ERC20Token{types.NewERC20Token(5, tokenContract)},
+		LogicContractAddress: "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7",
+		Payload:              []byte{0xde, 0xad, 0xbe, 0xef},
+		Timeout:              4766922941000,
+		InvalidationId:       []byte("invalidation id"),
+		InvalidationNonce:    1,
+	}
+	calldata, err := SubmitLogicCallCalldata(valset, signatures, call)
+	require.NoError(t, err)
+
+	values := unpack(t, calldata, "submitLogicCall(address[],uint256[],uint256,uint8[],bytes32[],bytes32[],(uint256[],address[],uint256[],address[],address,bytes,uint256,bytes32,uint256))")
+	require.Len(t, values, 7)
+	assertValset(t, valset, values[0], values[1], values[2])
+	assertSignatures(t, values[3], values[4], values[5])
+
+	// the arguments are decoded by name, so the tuple ends up in the fields of LogicCallArgs
+	var inputs struct {
+		CurrentValidators  []gethcommon.Address
+		CurrentPowers      []*big.Int
+		CurrentValsetNonce *big.Int
+		V                  []uint8
+		R, S               [][32]byte
+		Args               LogicCallArgs
+	}
+	contractAbi, err := ethabi.JSON(strings.NewReader(PeggyABIJSON))
+	require.NoError(t, err)
+	require.NoError(t, contractAbi.Methods["submitLogicCall"].Inputs.Unpack(&inputs, calldata[4:]))
+	assert.Equal(t, LogicCallArgs{
+		TransferAmounts:        []*big.Int{big.NewInt(100)},
+		TransferTokenContracts: []gethcommon.Address{gethcommon.HexToAddress(tokenContract)},
+		FeeAmounts:             []*big.Int{big.NewInt(5)},
+		FeeTokenContracts:      []gethcommon.Address{gethcommon.HexToAddress(tokenContract)},
+		LogicContractAddress:   gethcommon.HexToAddress(call.LogicContractAddress),
+		Payload:                call.Payload,
+		TimeOut:                big.NewInt(4766922941000),
+		InvalidationId:         toBytes32(call.InvalidationId),
+		InvalidationNonce:      big.NewInt(1),
+	}, inputs.Args)
+
+	call.InvalidationId = bytes.Repeat([]byte{1}, 33)
+	_, err = SubmitLogicCallCalldata(valset, signatures, call)
+	assert.True(t, types.ErrInvalid.Is(err), err)
+}
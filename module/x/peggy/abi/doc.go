@@ -0,0 +1,17 @@
+/*
+Package abi builds the calldata of the bridge contract functions a relayer submits to Ethereum.
+
+UpdateValsetCalldata, SubmitBatchCalldata and SubmitLogicCallCalldata pack the arguments of
+updateValset, submitBatch and submitLogicCall with the go-ethereum ABI encoder against PeggyABIJSON,
+the ABI of those functions as the Solidity compiler outputs it. The valset and batch arguments are
+the ones returned by the SubmittableValset and SubmittableBatch queries, which also return the
+calldata built here, so a relayer written in Go can send a query response as the data of an
+Ethereum transaction to the bridge contract:
+
+	res, err := queryClient.SubmittableBatch(ctx, &types.QuerySubmittableBatchRequest{...})
+	data, err := abi.SubmitBatchCalldata(res.CurrentValset, res.Signatures, res.Batch)
+
+The bridge contract rejects the call unless the signatures add up to more than the power threshold,
+so relayers should check the signed power of the response first.
+*/
+package abi
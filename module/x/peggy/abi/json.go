@@ -0,0 +1,73 @@
+package abi
+
+// PeggyABIJSON is the part of the ABI of the Peggy contract holding the functions relayers call, as the
+// Solidity compiler outputs it
+const PeggyABIJSON = `[
+	{
+		"name": "updateValset",
+		"stateMutability": "nonpayable",
+		"type": "function",
+		"inputs": [
+			{ "internalType": "address[]", "name": "_newValidators",     "type": "address[]" },
+			{ "internalType": "uint256[]", "name": "_newPowers",         "type": "uint256[]" },
+			{ "internalType": "uint256",   "name": "_newValsetNonce",    "type": "uint256"   },
+			{ "internalType": "address[]", "name": "_currentValidators", "type": "address[]" },
+			{ "internalType": "uint256[]", "name": "_currentPowers",     "type": "uint256[]" },
+			{ "internalType": "uint256",   "name": "_currentValsetNonce", "type": "uint256"  },
+			{ "internalType": "uint8[]",   "name": "_v",                 "type": "uint8[]"   },
+			{ "internalType": "bytes32[]", "name": "_r",                 "type": "bytes32[]" },
+			{ "internalType": "bytes32[]", "name": "_s",                 "type": "bytes32[]" }
+		],
+		"outputs": []
+	},
+	{
+		"name": "submitBatch",
+		"stateMutability": "nonpayable",
+		"type": "function",
+		"inputs": [
+			{ "internalType": "address[]", "name": "_currentValidators",  "type": "address[]" },
+			{ "internalType": "uint256[]", "name": "_currentPowers",      "type": "uint256[]" },
+			{ "internalType": "uint256",   "name": "_currentValsetNonce", "type": "uint256"   },
+			{ "internalType": "uint8[]",   "name": "_v",                  "type": "uint8[]"   },
+			{ "internalType": "bytes32[]", "name": "_r",                  "type": "bytes32[]" },
+			{ "internalType": "bytes32[]", "name": "_s",                  "type": "bytes32[]" },
+			{ "internalType": "uint256[]", "name": "_amounts",            "type": "uint256[]" },
+			{ "internalType": "address[]", "name": "_destinations",       "type": "address[]" },
+			{ "internalType": "uint256[]", "name": "_fees",               "type": "uint256[]" },
+			{ "internalType": "uint256",   "name": "_batchNonce",         "type": "uint256"   },
+			{ "internalType": "address",   "name": "_tokenContract",      "type": "address"   },
+			{ "internalType": "uint256",   "name": "_batchTimeout",       "type": "uint256"   }
+		],
+		"outputs": []
+	},
+	{
+		"name": "submitLogicCall",
+		"stateMutability": "nonpayable",
+		"type": "function",
+		"inputs": [
+			{ "internalType": "address[]", "name": "_currentValidators",  "type": "address[]" },
+			{ "internalType": "uint256[]", "name": "_currentPowers",      "type": "uint256[]" },
+			{ "internalType": "uint256",   "name": "_currentValsetNonce", "type": "uint256"   },
+			{ "internalType": "uint8[]",   "name": "_v",                  "type": "uint8[]"   },
+			{ "internalType": "bytes32[]", "name": "_r",                  "type": "bytes32[]" },
+			{ "internalType": "bytes32[]", "name": "_s",                  "type": "bytes32[]" },
+			{
+				"internalType": "struct LogicCallArgs",
+				"name": "_args",
+				"type": "tuple",
+				"components": [
+					{ "internalType": "uint256[]", "name": "transferAmounts",        "type": "uint256[]" },
+					{ "internalType": "address[]", "name": "transferTokenContracts", "type": "address[]" },
+					{ "internalType": "uint256[]", "name": "feeAmounts",             "type": "uint256[]" },
+					{ "internalType": "address[]", "name": "feeTokenContracts",      "type": "address[]" },
+					{ "internalType": "address",   "name": "logicContractAddress",   "type": "address"   },
+					{ "internalType": "bytes",     "name": "payload",                "type": "bytes"     },
+					{ "internalType": "uint256",   "name": "timeOut",                "type": "uint256"   },
+					{ "internalType": "bytes32",   "name": "invalidationId",         "type": "bytes32"   },
+					{ "internalType": "uint256",   "name": "invalidationNonce",      "type": "uint256"   }
+				]
+			}
+		],
+		"outputs": []
+	}
+]`
@@ -0,0 +1,152 @@
+package ante
+
+import (
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/keeper"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+var _ sdk.AnteDecorator = ConfirmFeeDecorator{}
+
+// ConfirmFeeDecorator drops the minimum gas price of the node for fee exempt transactions, see
+// IsFeeExempt. The minimum gas price is only checked in CheckTx, so the decorator has no effect on
+// the consensus state.
+type ConfirmFeeDecorator struct {
+	keeper keeper.Keeper
+	exempt *exemptTxs
+}
+
+// NewConfirmFeeDecorator returns the decorator exempting the confirms of the orchestrators of the keeper
+func NewConfirmFeeDecorator(k keeper.Keeper) ConfirmFeeDecorator {
+	return ConfirmFeeDecorator{keeper: k, exempt: &exemptTxs{}}
+}
+
+// NewAnteHandler puts the ConfirmFeeDecorator in front of the ante handler of the app
+func NewAnteHandler(anteHandler sdk.AnteHandler, k keeper.Keeper) sdk.AnteHandler {
+	d := NewConfirmFeeDecorator(k)
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		return d.AnteHandle(ctx, tx, simulate, anteHandler)
+	}
+}
+
+// AnteHandle passes fee exempt transactions on without a minimum gas price as long as their signers
+// are below the ConfirmFeeExemptTxs param in the current block, the minimum gas price is restored for
+// the context returned
+func (d ConfirmFeeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	if !ctx.IsCheckTx() || simulate || ctx.MinGasPrices().IsZero() || !d.IsFeeExempt(ctx, tx) {
+		return next(ctx, tx, simulate)
+	}
+	signers := txSigners(tx)
+	if !d.exempt.allowed(ctx.BlockHeight(), signers, d.keeper.GetParams(ctx).ConfirmFeeExemptTxs) {
+		return next(ctx, tx, simulate)
+	}
+	minGasPrices := ctx.MinGasPrices()
+	newCtx, err := next(ctx.WithMinGasPrices(sdk.DecCoins{}), tx, simulate)
+	if err == nil {
+		d.exempt.add(ctx.BlockHeight(), signers)
+	}
+	return newCtx.WithMinGasPrices(minGasPrices), err
+}
+
+// IsFeeExempt returns true if the transaction holds only valset, batch and logic call confirms and
+// Ethereum claims the validators don't have stored yet, all of them signed by orchestrators of bonded
+// and unjailed validators, and its gas limit is at most the ConfirmFeeExemptGas param. The number of
+// exempt transactions per signer and block is capped by the decorator.
+func (d ConfirmFeeDecorator) IsFeeExempt(ctx sdk.Context, tx sdk.Tx) bool {
+	feeTx, ok := tx.(sdk.FeeTx)
+	if !ok {
+		return false
+	}
+	limit := d.keeper.GetParams(ctx).ConfirmFeeExemptGas
+	if limit == 0 || feeTx.GetGas() > limit {
+		return false
+	}
+	msgs := tx.GetMsgs()
+	if len(msgs) == 0 {
+		return false
+	}
+	for _, msg := range msgs {
+		if !isConfirm(msg) {
+			return false
+		}
+		for _, signer := range msg.GetSigners() {
+			validator := d.keeper.GetOrchestratorValidator(ctx, signer)
+			if validator == nil {
+				return false
+			}
+			val := d.keeper.Validator(ctx, validator)
+			if val == nil || !val.IsBonded() || val.IsJailed() {
+				return false
+			}
+			if d.keeper.HasValidatorConfirm(ctx, validator, msg) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// txSigners returns the distinct signers of the messages of the transaction
+func txSigners(tx sdk.Tx) (signers []string) {
+	seen := make(map[string]bool)
+	for _, msg := range tx.GetMsgs() {
+		for _, signer := range msg.GetSigners() {
+			if !seen[signer.String()] {
+				seen[signer.String()] = true
+				signers = append(signers, signer.String())
+			}
+		}
+	}
+	return signers
+}
+
+// exemptTxs counts the fee exempt transactions let into the mempool per signer in the current block.
+// The counts only live in the memory of the node, like the minimum gas price they don't touch the
+// consensus state.
+type exemptTxs struct {
+	mtx    sync.Mutex
+	height int64
+	counts map[string]uint64
+}
+
+// allowed returns true if all signers are below the limit of exempt transactions at the height
+func (e *exemptTxs) allowed(height int64, signers []string, limit uint64) bool {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	e.reset(height)
+	for _, signer := range signers {
+		if e.counts[signer] >= limit {
+			return false
+		}
+	}
+	return true
+}
+
+// add counts an exempt transaction of the signers at the height
+func (e *exemptTxs) add(height int64, signers []string) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+	e.reset(height)
+	for _, signer := range signers {
+		e.counts[signer]++
+	}
+}
+
+func (e *exemptTxs) reset(height int64) {
+	if e.counts == nil || e.height != height {
+		e.height = height
+		e.counts = make(map[string]uint64)
+	}
+}
+
+// isConfirm returns true for the messages the orchestrators send to keep the bridge running
+func isConfirm(msg sdk.Msg) bool {
+	switch msg.(type) {
+	case *types.MsgValsetConfirm, *types.MsgConfirmBatch, *types.MsgConfirmLogicCall, types.EthereumClaim:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,126 @@
+package ante
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authante "github.com/cosmos/cosmos-sdk/x/auth/ante"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/keeper"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// feeTx is a transaction paying no fee
+type feeTx struct {
+	msgs []sdk.Msg
+	gas  uint64
+}
+
+func (tx feeTx) GetMsgs() []sdk.Msg         { return tx.msgs }
+func (tx feeTx) ValidateBasic() error       { return nil }
+func (tx feeTx) GetGas() uint64             { return tx.gas }
+func (tx feeTx) GetFee() sdk.Coins          { return nil }
+func (tx feeTx) FeePayer() sdk.AccAddress   { return tx.msgs[0].GetSigners()[0] }
+func (tx feeTx) FeeGranter() sdk.AccAddress { return nil }
+
+func TestConfirmFeeDecorator(t *testing.T) {
+	input, ctx := keeper.SetupFiveValChain(t)
+	k := input.PeggyKeeper
+	minGasPrices := sdk.NewDecCoins(sdk.NewDecCoinFromDec("stake", sdk.NewDecWithPrec(1, 2)))
+	ctx = ctx.WithIsCheckTx(true).WithMinGasPrices(minGasPrices)
+	orchestrator, other, jailed := keeper.AccAddrs[0], keeper.AccAddrs[1], keeper.AccAddrs[2]
+	k.SetOrchestratorValidator(ctx, keeper.ValAddrs[0], orchestrator)
+	k.SetOrchestratorValidator(ctx, keeper.ValAddrs[2], jailed)
+	consAddr, err := input.StakingKeeper.Validator(ctx, keeper.ValAddrs[2]).GetConsAddr()
+	require.NoError(t, err)
+	input.StakingKeeper.Jail(ctx, consAddr)
+	params := k.GetParams(ctx)
+	params.ConfirmFeeExemptGas = 200000
+	params.ConfirmFeeExemptTxs = 100
+	k.SetParams(ctx, params)
+
+	anteHandler := NewAnteHandler(sdk.ChainAnteDecorators(authante.NewMempoolFeeDecorator()), k)
+	confirm := func(orchestrator sdk.AccAddress) sdk.Msg {
+		return &types.MsgValsetConfirm{Nonce: 1, Orchestrator: orchestrator.String(), EthAddress: keeper.EthAddrs[0].String(), Signature: "00"}
+	}
+	claim := &types.MsgValsetUpdatedClaim{EventNonce: 1, ValsetNonce: 1, Orchestrator: orchestrator.String()}
+	// the validator already confirmed valset 2 and voted for event 2
+	stored := &types.MsgValsetConfirm{Nonce: 2, Orchestrator: orchestrator.String(), EthAddress: keeper.EthAddrs[0].String(), Signature: "00"}
+	k.SetValsetConfirm(ctx, *stored)
+	storedClaim := &types.MsgValsetUpdatedClaim{EventNonce: 2, ValsetNonce: 2, Orchestrator: orchestrator.String()}
+	k.SetAttestation(ctx, storedClaim.EventNonce, storedClaim.ClaimHash(), &types.Attestation{Votes: []string{keeper.ValAddrs[0].String()}})
+	specs := map[string]struct {
+		tx     feeTx
+		exempt bool
+	}{
+		"confirm and claim": {
+			tx:     feeTx{msgs: []sdk.Msg{confirm(orchestrator), claim}, gas: 200000},
+			exempt: true,
+		},
+		"above the exempt gas": {
+			tx: feeTx{msgs: []sdk.Msg{confirm(orchestrator)}, gas: 200001},
+		},
+		"not an orchestrator": {
+			tx: feeTx{msgs: []sdk.Msg{confirm(other)}, gas: 100000},
+		},
+		"jailed validator": {
+			tx: feeTx{msgs: []sdk.Msg{confirm(jailed)}, gas: 100000},
+		},
+		"confirm already stored": {
+			tx: feeTx{msgs: []sdk.Msg{stored}, gas: 100000},
+		},
+		"claim already stored": {
+			tx: feeTx{msgs: []sdk.Msg{storedClaim}, gas: 100000},
+		},
+		"other message": {
+			tx: feeTx{msgs: []sdk.Msg{confirm(orchestrator), types.NewMsgRequestBatch(orchestrator)}, gas: 100000},
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			newCtx, err := anteHandler(ctx, spec.tx, false)
+			if !spec.exempt {
+				assert.ErrorIs(t, err, sdkerrors.ErrInsufficientFee)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, minGasPrices, newCtx.MinGasPrices())
+		})
+	}
+
+	// without the exempt gas param every transaction pays the minimum gas price
+	params.ConfirmFeeExemptGas = 0
+	k.SetParams(ctx, params)
+	_, err = anteHandler(ctx, specs["confirm and claim"].tx, false)
+	assert.ErrorIs(t, err, sdkerrors.ErrInsufficientFee)
+}
+
+func TestConfirmFeeDecoratorTxsPerBlock(t *testing.T) {
+	input, ctx := keeper.SetupFiveValChain(t)
+	k := input.PeggyKeeper
+	minGasPrices := sdk.NewDecCoins(sdk.NewDecCoinFromDec("stake", sdk.NewDecWithPrec(1, 2)))
+	ctx = ctx.WithIsCheckTx(true).WithMinGasPrices(minGasPrices).WithBlockHeight(10)
+	orchestrator := keeper.AccAddrs[0]
+	k.SetOrchestratorValidator(ctx, keeper.ValAddrs[0], orchestrator)
+	params := k.GetParams(ctx)
+	params.ConfirmFeeExemptGas = 200000
+	params.ConfirmFeeExemptTxs = 2
+	k.SetParams(ctx, params)
+
+	anteHandler := NewAnteHandler(sdk.ChainAnteDecorators(authante.NewMempoolFeeDecorator()), k)
+	tx := feeTx{msgs: []sdk.Msg{&types.MsgValsetConfirm{Nonce: 1, Orchestrator: orchestrator.String(), EthAddress: keeper.EthAddrs[0].String(), Signature: "00"}}, gas: 100000}
+
+	// a signer gets the exemption for a limited number of transactions per block
+	for i := 0; i < 2; i++ {
+		_, err := anteHandler(ctx, tx, false)
+		require.NoError(t, err)
+	}
+	_, err := anteHandler(ctx, tx, false)
+	assert.ErrorIs(t, err, sdkerrors.ErrInsufficientFee)
+
+	// the count starts over with the next block
+	_, err = anteHandler(ctx.WithBlockHeight(11), tx, false)
+	require.NoError(t, err)
+}
@@ -0,0 +1,14 @@
+/*
+Package ante keeps the orchestrators from being priced out of the mempool when the chain is congested.
+Missing a valset or batch confirm gets a validator slashed, so a full mempool must not keep its
+orchestrator from getting the confirm included.
+
+ConfirmFeeDecorator lets a transaction in without paying the minimum gas price of the node when all of
+its messages are confirms or claims not stored yet, signed by orchestrators of bonded and unjailed
+validators, and its gas limit is at most the ConfirmFeeExemptGas param. A signer gets at most
+ConfirmFeeExemptTxs such transactions in per block. The fee it does pay is deducted as usual. Put it
+in front of the ante handler of the app with
+
+	app.SetAnteHandler(peggyante.NewAnteHandler(ante.NewAnteHandler(...), app.peggyKeeper))
+*/
+package ante
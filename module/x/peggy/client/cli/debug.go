@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/keeper"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/spf13/cobra"
+)
+
+// CmdDebug groups the commands for comparing the peggy store of two nodes, e.g. after they
+// disagreed on the app hash
+func CmdDebug() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        "debug",
+		Short:                      "Dump and compare the peggy store of nodes",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+	cmd.AddCommand(
+		CmdDebugDump(),
+		CmdDebugDiff(),
+	)
+	return cmd
+}
+
+func CmdDebugDump() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dump [output-file]",
+		Short: "Write every entry of the peggy store to a file",
+		Long:  "Write every entry of the peggy store to a file. Only nodes started with --x-peggy-debug-queries answer, use --height to dump two nodes at the same height.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			res, height, err := clientCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.QuerierRoute, keeper.QueryDebugDump), nil)
+			if err != nil {
+				return err
+			}
+			var dump types.StoreDump
+			if err := dump.Unmarshal(res); err != nil {
+				return err
+			}
+			if err := ioutil.WriteFile(args[0], res, 0o600); err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "wrote %d entries at height %d to %s\n", len(dump.Entries), height, args[0])
+			return err
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdDebugDiff() *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff [dump-a] [dump-b]",
+		Short: "List the entries that were added (+), removed (-) or changed (~) from one peggy store dump to another",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dumps := make([]types.StoreDump, len(args))
+			for i, file := range args {
+				bz, err := ioutil.ReadFile(file)
+				if err != nil {
+					return err
+				}
+				if err := dumps[i].Unmarshal(bz); err != nil {
+					return fmt.Errorf("decode %s: %w", file, err)
+				}
+			}
+
+			out := cmd.OutOrStdout()
+			if dumps[0].Height != dumps[1].Height {
+				fmt.Fprintf(out, "warning: comparing dumps of heights %d and %d\n", dumps[0].Height, dumps[1].Height)
+			}
+			diffs := types.DiffStoreDumps(dumps[0], dumps[1])
+			for _, d := range diffs {
+				e := d.Entry()
+				switch {
+				case d.Old == nil:
+					fmt.Fprintf(out, "+ %s %X %X\n", e.Prefix, e.Key, e.Value)
+				case d.New == nil:
+					fmt.Fprintf(out, "- %s %X %X\n", e.Prefix, e.Key, e.Value)
+				default:
+					fmt.Fprintf(out, "~ %s %X %X -> %X\n", e.Prefix, e.Key, d.Old.Value, d.New.Value)
+				}
+			}
+			_, err := fmt.Fprintf(out, "%d entries differ\n", len(diffs))
+			return err
+		},
+	}
+}
@@ -0,0 +1,489 @@
+package cli
+
+import (
+	"io/ioutil"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govcli "github.com/cosmos/cosmos-sdk/x/gov/client/cli"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/spf13/cobra"
+)
+
+func CmdSubmitReturnReclaimableDepositProposal() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "return-reclaimable-deposit [event-nonce] [destination]",
+		Short: "Submit a proposal to return a deposit with an invalid cosmos receiver from the community pool to destination",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			eventNonce, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return sdkerrors.Wrap(err, "event nonce")
+			}
+			destination, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return sdkerrors.Wrap(err, "destination")
+			}
+
+			title, err := cmd.Flags().GetString(govcli.FlagTitle)
+			if err != nil {
+				return err
+			}
+			description, err := cmd.Flags().GetString(govcli.FlagDescription)
+			if err != nil {
+				return err
+			}
+			depositStr, err := cmd.Flags().GetString(govcli.FlagDeposit)
+			if err != nil {
+				return err
+			}
+			deposit, err := sdk.ParseCoinsNormalized(depositStr)
+			if err != nil {
+				return sdkerrors.Wrap(err, "deposit")
+			}
+
+			content := types.NewReturnReclaimableDepositProposal(title, description, eventNonce, destination)
+			msg, err := govtypes.NewMsgSubmitProposal(content, deposit, cliCtx.GetFromAddress())
+			if err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			// Send it
+			return tx.GenerateOrBroadcastTxCLI(cliCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().String(govcli.FlagTitle, "", "title of proposal")
+	cmd.Flags().String(govcli.FlagDescription, "", "description of proposal")
+	cmd.Flags().String(govcli.FlagDeposit, "", "deposit of proposal")
+	return cmd
+}
+
+func CmdSubmitUpdateBridgeContractProposal() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-bridge-contract [bridge-contract-address] [final-event-nonce] [last-observed-event-nonce]",
+		Short: "Submit a proposal to move the bridge to a freshly deployed contract once the final event of the old contract was observed",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			title, err := cmd.Flags().GetString(govcli.FlagTitle)
+			if err != nil {
+				return err
+			}
+			description, err := cmd.Flags().GetString(govcli.FlagDescription)
+			if err != nil {
+				return err
+			}
+			depositStr, err := cmd.Flags().GetString(govcli.FlagDeposit)
+			if err != nil {
+				return err
+			}
+			deposit, err := sdk.ParseCoinsNormalized(depositStr)
+			if err != nil {
+				return sdkerrors.Wrap(err, "deposit")
+			}
+
+			finalEventNonce, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return sdkerrors.Wrap(err, "final event nonce")
+			}
+			lastObservedEventNonce, err := strconv.ParseUint(args[2], 10, 64)
+			if err != nil {
+				return sdkerrors.Wrap(err, "last observed event nonce")
+			}
+
+			erc20InitCodeHash, err := cmd.Flags().GetString(FlagERC20InitCodeHash)
+			if err != nil {
+				return err
+			}
+
+			content := types.NewUpdateBridgeContractProposal(title, description, args[0], finalEventNonce, lastObservedEventNonce, erc20InitCodeHash)
+			msg, err := govtypes.NewMsgSubmitProposal(content, deposit, cliCtx.GetFromAddress())
+			if err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			// Send it
+			return tx.GenerateOrBroadcastTxCLI(cliCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().String(govcli.FlagTitle, "", "title of proposal")
+	cmd.Flags().String(govcli.FlagDescription, "", "description of proposal")
+	cmd.Flags().String(govcli.FlagDeposit, "", "deposit of proposal")
+	cmd.Flags().String(FlagERC20InitCodeHash, "", "hash of the creation code of the ERC20s the new contract deploys with CREATE2")
+	return cmd
+}
+
+func CmdSubmitAbandonValsetNonceProposal() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "abandon-valset-nonce [valset-nonce]",
+		Short: "Submit a proposal to accept a valset nonce skipped on Ethereum as abandoned",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			valsetNonce, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return sdkerrors.Wrap(err, "valset nonce")
+			}
+
+			title, err := cmd.Flags().GetString(govcli.FlagTitle)
+			if err != nil {
+				return err
+			}
+			description, err := cmd.Flags().GetString(govcli.FlagDescription)
+			if err != nil {
+				return err
+			}
+			depositStr, err := cmd.Flags().GetString(govcli.FlagDeposit)
+			if err != nil {
+				return err
+			}
+			deposit, err := sdk.ParseCoinsNormalized(depositStr)
+			if err != nil {
+				return sdkerrors.Wrap(err, "deposit")
+			}
+
+			content := types.NewAbandonValsetNonceProposal(title, description, valsetNonce)
+			msg, err := govtypes.NewMsgSubmitProposal(content, deposit, cliCtx.GetFromAddress())
+			if err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			// Send it
+			return tx.GenerateOrBroadcastTxCLI(cliCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().String(govcli.FlagTitle, "", "title of proposal")
+	cmd.Flags().String(govcli.FlagDescription, "", "description of proposal")
+	cmd.Flags().String(govcli.FlagDeposit, "", "deposit of proposal")
+	return cmd
+}
+
+func CmdSubmitUpdateParamsProposal() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-peggy-params [params-file]",
+		Short: "Submit a proposal to replace the peggy params with the JSON encoded params of the file",
+		Long: `Submit a proposal to replace the peggy params as a whole. The file holds the complete params
+in the format of the params query, e.g. the output of "peggy params" with the values to change edited.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			bz, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			var params types.Params
+			if err := cliCtx.JSONMarshaler.UnmarshalJSON(bz, &params); err != nil {
+				return sdkerrors.Wrap(err, "params file")
+			}
+
+			title, err := cmd.Flags().GetString(govcli.FlagTitle)
+			if err != nil {
+				return err
+			}
+			description, err := cmd.Flags().GetString(govcli.FlagDescription)
+			if err != nil {
+				return err
+			}
+			depositStr, err := cmd.Flags().GetString(govcli.FlagDeposit)
+			if err != nil {
+				return err
+			}
+			deposit, err := sdk.ParseCoinsNormalized(depositStr)
+			if err != nil {
+				return sdkerrors.Wrap(err, "deposit")
+			}
+
+			content := types.NewUpdateParamsProposal(title, description, params)
+			msg, err := govtypes.NewMsgSubmitProposal(content, deposit, cliCtx.GetFromAddress())
+			if err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			// Send it
+			return tx.GenerateOrBroadcastTxCLI(cliCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().String(govcli.FlagTitle, "", "title of proposal")
+	cmd.Flags().String(govcli.FlagDescription, "", "description of proposal")
+	cmd.Flags().String(govcli.FlagDeposit, "", "deposit of proposal")
+	return cmd
+}
+
+func CmdSubmitCancelBatchProposal() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel-batch [token-contract] [nonce]",
+		Short: "Submit a proposal to cancel a stuck or unprofitable batch",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			nonce, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return sdkerrors.Wrap(err, "nonce")
+			}
+
+			title, err := cmd.Flags().GetString(govcli.FlagTitle)
+			if err != nil {
+				return err
+			}
+			description, err := cmd.Flags().GetString(govcli.FlagDescription)
+			if err != nil {
+				return err
+			}
+			depositStr, err := cmd.Flags().GetString(govcli.FlagDeposit)
+			if err != nil {
+				return err
+			}
+			deposit, err := sdk.ParseCoinsNormalized(depositStr)
+			if err != nil {
+				return sdkerrors.Wrap(err, "deposit")
+			}
+
+			content := types.NewCancelBatchProposal(title, description, args[0], nonce)
+			msg, err := govtypes.NewMsgSubmitProposal(content, deposit, cliCtx.GetFromAddress())
+			if err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			// Send it
+			return tx.GenerateOrBroadcastTxCLI(cliCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().String(govcli.FlagTitle, "", "title of proposal")
+	cmd.Flags().String(govcli.FlagDescription, "", "description of proposal")
+	cmd.Flags().String(govcli.FlagDeposit, "", "deposit of proposal")
+	return cmd
+}
+
+func CmdSubmitSetLastObservedEventNonceProposal() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-last-observed-event-nonce [nonce]",
+		Short: "Submit a proposal to move the last observed event nonce of an oracle stuck on an event that can't be attested",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			nonce, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return sdkerrors.Wrap(err, "nonce")
+			}
+
+			title, err := cmd.Flags().GetString(govcli.FlagTitle)
+			if err != nil {
+				return err
+			}
+			description, err := cmd.Flags().GetString(govcli.FlagDescription)
+			if err != nil {
+				return err
+			}
+			depositStr, err := cmd.Flags().GetString(govcli.FlagDeposit)
+			if err != nil {
+				return err
+			}
+			deposit, err := sdk.ParseCoinsNormalized(depositStr)
+			if err != nil {
+				return sdkerrors.Wrap(err, "deposit")
+			}
+
+			content := types.NewSetLastObservedEventNonceProposal(title, description, nonce)
+			msg, err := govtypes.NewMsgSubmitProposal(content, deposit, cliCtx.GetFromAddress())
+			if err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			// Send it
+			return tx.GenerateOrBroadcastTxCLI(cliCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().String(govcli.FlagTitle, "", "title of proposal")
+	cmd.Flags().String(govcli.FlagDescription, "", "description of proposal")
+	cmd.Flags().String(govcli.FlagDeposit, "", "deposit of proposal")
+	return cmd
+}
+
+func CmdSubmitPauseTokenProposal() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pause-token [token-contract]",
+		Short: "Submit a proposal to halt the bridging of a single token in both directions",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			title, err := cmd.Flags().GetString(govcli.FlagTitle)
+			if err != nil {
+				return err
+			}
+			description, err := cmd.Flags().GetString(govcli.FlagDescription)
+			if err != nil {
+				return err
+			}
+			depositStr, err := cmd.Flags().GetString(govcli.FlagDeposit)
+			if err != nil {
+				return err
+			}
+			deposit, err := sdk.ParseCoinsNormalized(depositStr)
+			if err != nil {
+				return sdkerrors.Wrap(err, "deposit")
+			}
+
+			content := types.NewPauseTokenProposal(title, description, args[0])
+			msg, err := govtypes.NewMsgSubmitProposal(content, deposit, cliCtx.GetFromAddress())
+			if err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			// Send it
+			return tx.GenerateOrBroadcastTxCLI(cliCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().String(govcli.FlagTitle, "", "title of proposal")
+	cmd.Flags().String(govcli.FlagDescription, "", "description of proposal")
+	cmd.Flags().String(govcli.FlagDeposit, "", "deposit of proposal")
+	return cmd
+}
+
+func CmdSubmitUnpauseTokenProposal() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unpause-token [token-contract]",
+		Short: "Submit a proposal to resume the bridging of a paused token",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			title, err := cmd.Flags().GetString(govcli.FlagTitle)
+			if err != nil {
+				return err
+			}
+			description, err := cmd.Flags().GetString(govcli.FlagDescription)
+			if err != nil {
+				return err
+			}
+			depositStr, err := cmd.Flags().GetString(govcli.FlagDeposit)
+			if err != nil {
+				return err
+			}
+			deposit, err := sdk.ParseCoinsNormalized(depositStr)
+			if err != nil {
+				return sdkerrors.Wrap(err, "deposit")
+			}
+
+			content := types.NewUnpauseTokenProposal(title, description, args[0])
+			msg, err := govtypes.NewMsgSubmitProposal(content, deposit, cliCtx.GetFromAddress())
+			if err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			// Send it
+			return tx.GenerateOrBroadcastTxCLI(cliCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().String(govcli.FlagTitle, "", "title of proposal")
+	cmd.Flags().String(govcli.FlagDescription, "", "description of proposal")
+	cmd.Flags().String(govcli.FlagDeposit, "", "deposit of proposal")
+	return cmd
+}
+
+func CmdSubmitSetCosmosOriginatedERC20Proposal() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-cosmos-originated-erc20 [denom] [token-contract]",
+		Short: "Submit a proposal to correct the ERC20 of a cosmos originated denom, without a token contract the ERC20 of the denom is removed",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			title, err := cmd.Flags().GetString(govcli.FlagTitle)
+			if err != nil {
+				return err
+			}
+			description, err := cmd.Flags().GetString(govcli.FlagDescription)
+			if err != nil {
+				return err
+			}
+			depositStr, err := cmd.Flags().GetString(govcli.FlagDeposit)
+			if err != nil {
+				return err
+			}
+			deposit, err := sdk.ParseCoinsNormalized(depositStr)
+			if err != nil {
+				return sdkerrors.Wrap(err, "deposit")
+			}
+
+			decimals, err := cmd.Flags().GetUint32(FlagDecimals)
+			if err != nil {
+				return err
+			}
+
+			var tokenContract string
+			if len(args) > 1 {
+				tokenContract = args[1]
+			}
+			content := types.NewSetCosmosOriginatedERC20Proposal(title, description, args[0], tokenContract, decimals)
+			msg, err := govtypes.NewMsgSubmitProposal(content, deposit, cliCtx.GetFromAddress())
+			if err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			// Send it
+			return tx.GenerateOrBroadcastTxCLI(cliCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().String(govcli.FlagTitle, "", "title of proposal")
+	cmd.Flags().String(govcli.FlagDescription, "", "description of proposal")
+	cmd.Flags().String(govcli.FlagDeposit, "", "deposit of proposal")
+	cmd.Flags().Uint32(FlagDecimals, 0, "decimals of the token contract when they differ from the decimals of the denom")
+	return cmd
+}
@@ -1,14 +1,28 @@
 package cli
 
 import (
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
 	"github.com/spf13/cobra"
 )
 
+const (
+	FlagTokenContract = "token-contract"
+	FlagMinFee        = "min-fee"
+	FlagOrderByFee    = "order-by-fee"
+	FlagCSV           = "csv"
+)
+
 func GetQueryCmd() *cobra.Command {
 	peggyQueryCmd := &cobra.Command{
 		Use:                        types.ModuleName,
@@ -18,11 +32,48 @@ func GetQueryCmd() *cobra.Command {
 		RunE:                       client.ValidateCmd,
 	}
 	peggyQueryCmd.AddCommand([]*cobra.Command{
+		CmdGetParams(),
 		CmdGetCurrentValset(),
+		CmdGetValsetDiff(),
 		CmdGetValsetRequest(),
 		CmdGetValsetConfirm(),
 		CmdGetPendingValsetRequest(),
 		CmdGetPendingOutgoingTXBatchRequest(),
+		CmdGetPendingWork(),
+		CmdGetReclaimableDeposits(),
+		CmdGetDepositsByEthSender(),
+		CmdGetAccountBridgeActivity(),
+		CmdGetBatchExecution(),
+		CmdGetAttestationsByNonce(),
+		CmdGetOrchestratorLiveness(),
+		CmdGetUnbatchedTransactions(),
+		CmdGetPendingSendToEth(),
+		CmdGetAllPendingSendToEth(),
+		CmdGetValsetCheckpoint(),
+		CmdGetBatchCheckpoint(),
+		CmdGetLogicCallCheckpoint(),
+		CmdGetSubmittableValset(),
+		CmdGetSubmittableBatch(),
+		CmdGetBridgeSnapshot(),
+		CmdGetBridgeStats(),
+		CmdGetRelayers(),
+		CmdGetConfirmsByOrchestrator(),
+		CmdGetEthereumHeight(),
+		CmdGetModuleStateSize(),
+		CmdGetGrants(),
+		CmdGetOmnibusAccount(),
+		CmdGetTokenQuirks(),
+		CmdGetModuleResidue(),
+		CmdGetPausedTokens(),
+		CmdGetAttestationVotes(),
+		CmdGetClaimDivergence(),
+		CmdGetValidatorAttestationRecord(),
+		CmdGetEventNonceGap(),
+		CmdGetBatchForTx(),
+		CmdGetScheduledTransfers(),
+		CmdSimulateSendToEth(),
+		CmdPredictERC20Address(),
+		CmdDebug(),
 		// CmdGetAllOutgoingTXBatchRequest(),
 		// CmdGetOutgoingTXBatchByNonceRequest(),
 		// CmdGetAllAttestationsRequest(),
@@ -68,6 +119,27 @@ func QueryApproved() *cobra.Command {
 	return testingTxCmd
 }
 
+func CmdGetParams() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "params",
+		Short: "Query the current peggy parameters",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			res, err := queryClient.Params(cmd.Context(), &types.QueryParamsRequest{})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(&res.Params)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
 func CmdGetCurrentValset() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "current-valset",
@@ -91,6 +163,27 @@ func CmdGetCurrentValset() *cobra.Command {
 	return cmd
 }
 
+func CmdGetValsetDiff() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "valset-diff",
+		Short: "Query the power difference in basis points between the current valset and the latest valset request",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			res, err := queryClient.ValsetDiff(cmd.Context(), &types.QueryValsetDiffRequest{})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
 func CmdGetValsetRequest() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "valset-request [nonce]",
@@ -155,14 +248,20 @@ func CmdGetValsetConfirm() *cobra.Command {
 func CmdGetPendingValsetRequest() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "pending-valset-request [bech32 validator address]",
-		Short: "Get the latest valset request which has not been signed by a particular validator",
+		Short: "Get the oldest valset requests which have not been signed by a particular validator",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx := client.GetClientContextFromCmd(cmd)
 			queryClient := types.NewQueryClient(clientCtx)
 
+			limit, err := cmd.Flags().GetUint64(flags.FlagLimit)
+			if err != nil {
+				return err
+			}
+
 			req := &types.QueryLastPendingValsetRequestByAddrRequest{
 				Address: args[0],
+				Limit:   limit,
 			}
 
 			res, err := queryClient.LastPendingValsetRequestByAddr(cmd.Context(), req)
@@ -173,6 +272,32 @@ func CmdGetPendingValsetRequest() *cobra.Command {
 			return clientCtx.PrintProto(res)
 		},
 	}
+	cmd.Flags().Uint64(flags.FlagLimit, 0, "maximum number of valsets to return, defaults to 100")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdGetPendingWork() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pending-work [bech32 orchestrator address]",
+		Short: "Get all valsets, batches and logic calls which have not been signed by a particular orchestrator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryPendingWorkRequest{
+				Orchestrator: args[0],
+			}
+
+			res, err := queryClient.PendingWork(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
 	flags.AddQueryFlagsToCmd(cmd)
 	return cmd
 }
@@ -201,3 +326,949 @@ func CmdGetPendingOutgoingTXBatchRequest() *cobra.Command {
 	flags.AddQueryFlagsToCmd(cmd)
 	return cmd
 }
+
+func CmdGetReclaimableDeposits() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reclaimable-deposits",
+		Short: "Get the deposits sent to the community pool because of an invalid cosmos receiver",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryReclaimableDepositsRequest{}
+
+			res, err := queryClient.ReclaimableDeposits(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdGetOrchestratorLiveness() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "orchestrator-liveness [warning-window]",
+		Short: "Get the missed confirms of every bonded validator and whether they are within warning-window blocks of being slashed",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryOrchestratorLivenessRequest{}
+			if len(args) == 1 {
+				window, err := strconv.ParseUint(args[0], 10, 64)
+				if err != nil {
+					return err
+				}
+				req.WarningWindow = window
+			}
+
+			res, err := queryClient.OrchestratorLiveness(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdGetUnbatchedTransactions() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unbatched-transactions [token-contract]",
+		Short: "Get the transactions waiting in the outgoing pool sorted by fee, for a single token contract if given",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			if len(args) == 0 {
+				res, err := queryClient.AllUnbatchedTransactions(cmd.Context(), &types.QueryAllUnbatchedTransactionsRequest{Pagination: pageReq})
+				if err != nil {
+					return err
+				}
+				return clientCtx.PrintProto(res)
+			}
+
+			req := &types.QueryUnbatchedTransactionsByContractRequest{
+				TokenContract: args[0],
+				Pagination:    pageReq,
+			}
+			res, err := queryClient.UnbatchedTransactionsByContract(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "unbatched-transactions")
+	return cmd
+}
+
+func CmdGetPendingSendToEth() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pending-send-to-eth [bech32 sender address]",
+		Short: "Get the batched and unbatched transfers to Ethereum of a sender that were not executed yet",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+			tokenContract, err := cmd.Flags().GetString(FlagTokenContract)
+			if err != nil {
+				return err
+			}
+			byFee, err := cmd.Flags().GetBool(FlagOrderByFee)
+			if err != nil {
+				return err
+			}
+
+			req := &types.QueryPendingSendToEth{
+				SenderAddress: args[0],
+				TokenContract: tokenContract,
+				Pagination:    pageReq,
+			}
+			if byFee {
+				req.Order = types.PENDING_SEND_TO_ETH_ORDER_FEE
+			}
+			if minFee, _ := cmd.Flags().GetString(FlagMinFee); minFee != "" {
+				amount, ok := sdk.NewIntFromString(minFee)
+				if !ok {
+					return fmt.Errorf("invalid min fee: %s", minFee)
+				}
+				req.MinFee = amount
+			}
+			res, err := queryClient.GetPendingSendToEth(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	cmd.Flags().String(FlagTokenContract, "", "only return transfers of this ERC20 token contract")
+	cmd.Flags().String(FlagMinFee, "", "only return transfers paying at least this fee amount")
+	cmd.Flags().Bool(FlagOrderByFee, false, "order the transfers by fee, highest first, instead of by id")
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "pending-send-to-eth")
+	return cmd
+}
+
+func CmdGetAllPendingSendToEth() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "all-pending-send-to-eth",
+		Short: "Get the batched and unbatched transfers to Ethereum of all senders that were not executed yet",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			res, err := queryClient.AllPendingSendToEth(cmd.Context(), &types.QueryAllPendingSendToEthRequest{Pagination: pageReq})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "all-pending-send-to-eth")
+	return cmd
+}
+
+func CmdGetDepositsByEthSender() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deposits-by-eth-sender [eth-sender]",
+		Short: "Get the deposits observed from an Ethereum address and the Cosmos accounts they funded",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			req := &types.QueryDepositsByEthSenderRequest{
+				EthSender:  args[0],
+				Pagination: pageReq,
+			}
+			res, err := queryClient.DepositsByEthSender(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "deposits-by-eth-sender")
+	return cmd
+}
+
+func CmdGetAccountBridgeActivity() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "account-bridge-activity [address] [from-height] [to-height]",
+		Short: "Get the deposits, withdrawals, refunds and fees of an account between two heights, a to-height of 0 leaves the range open",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			fromHeight, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+			toHeight, err := strconv.ParseUint(args[2], 10, 64)
+			if err != nil {
+				return err
+			}
+			asCSV, err := cmd.Flags().GetBool(FlagCSV)
+			if err != nil {
+				return err
+			}
+			pageReq, err := client.ReadPageRequest(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			req := &types.QueryAccountBridgeActivityRequest{
+				Address:    args[0],
+				FromHeight: fromHeight,
+				ToHeight:   toHeight,
+				Pagination: pageReq,
+			}
+			res, err := queryClient.AccountBridgeActivity(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+			if !asCSV {
+				return clientCtx.PrintProto(res)
+			}
+			w := csv.NewWriter(cmd.OutOrStdout())
+			if err := w.Write([]string{"id", "height", "time", "type", "amount", "denom", "tx_id", "event_nonce", "ethereum_address"}); err != nil {
+				return err
+			}
+			for _, a := range res.Activities {
+				err := w.Write([]string{
+					strconv.FormatUint(a.Id, 10),
+					strconv.FormatUint(a.Height, 10),
+					time.Unix(int64(a.Time), 0).UTC().Format(time.RFC3339),
+					a.Type.String(),
+					a.Amount.Amount.String(),
+					a.Amount.Denom,
+					strconv.FormatUint(a.TxId, 10),
+					strconv.FormatUint(a.EventNonce, 10),
+					a.EthereumAddress,
+				})
+				if err != nil {
+					return err
+				}
+			}
+			w.Flush()
+			return w.Error()
+		},
+	}
+	cmd.Flags().Bool(FlagCSV, false, "print the entries as CSV rows instead of the response")
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "account-bridge-activity")
+	return cmd
+}
+
+func CmdGetBatchExecution() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "batch-execution [token-contract] [nonce]",
+		Short: "Get the Ethereum block height and tx hash at which a batch was executed",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			nonce, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			req := &types.QueryBatchExecutionRequest{
+				TokenContract: args[0],
+				BatchNonce:    nonce,
+			}
+			res, err := queryClient.BatchExecution(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdGetAttestationsByNonce() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "attestations [event-nonce]",
+		Short: "Get the attestations, their claims, votes and observed status for an Ethereum event nonce",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			nonce, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			req := &types.QueryAttestationsByNonceRequest{
+				EventNonce: nonce,
+			}
+			res, err := queryClient.AttestationsByNonce(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdGetValsetCheckpoint() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "valset-checkpoint [nonce]",
+		Short: "Get the checkpoint hash validators sign for the valset with a particular nonce",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			nonce, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			res, err := queryClient.ValsetCheckpoint(cmd.Context(), &types.QueryValsetCheckpointRequest{Nonce: nonce})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdGetBatchCheckpoint() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "batch-checkpoint [token-contract] [nonce]",
+		Short: "Get the checkpoint hash validators sign for the batch of a token contract with a particular nonce",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			nonce, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			req := &types.QueryBatchCheckpointRequest{
+				TokenContract: args[0],
+				Nonce:         nonce,
+			}
+			res, err := queryClient.BatchCheckpoint(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdGetLogicCallCheckpoint() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logic-call-checkpoint [hex invalidation-id] [invalidation-nonce]",
+		Short: "Get the checkpoint hash validators sign for a logic call",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			invalidationID, err := hex.DecodeString(strings.TrimPrefix(args[0], "0x"))
+			if err != nil {
+				return err
+			}
+			nonce, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			req := &types.QueryLogicCallCheckpointRequest{
+				InvalidationId:    invalidationID,
+				InvalidationNonce: nonce,
+			}
+			res, err := queryClient.LogicCallCheckpoint(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdGetSubmittableValset() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "submittable-valset [nonce]",
+		Short: "Get the arguments of updateValset for the valset with a particular nonce, signed by the valset of the bridge contract",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			nonce, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			res, err := queryClient.SubmittableValset(cmd.Context(), &types.QuerySubmittableValsetRequest{Nonce: nonce})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdGetSubmittableBatch() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "submittable-batch [token-contract] [nonce]",
+		Short: "Get the arguments of submitBatch for the batch of a token contract with a particular nonce, signed by the valset of the bridge contract",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			nonce, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			req := &types.QuerySubmittableBatchRequest{
+				TokenContract: args[0],
+				Nonce:         nonce,
+			}
+			res, err := queryClient.SubmittableBatch(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdGetBridgeSnapshot() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bridge-snapshot",
+		Short: "Get the valset, pending transfers, ERC20 mappings and escrowed amounts needed to deploy a fresh bridge contract",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryBridgeSnapshotRequest{}
+
+			res, err := queryClient.BridgeSnapshot(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdGetBridgeStats() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bridge-stats [token-contract]",
+		Short: "Get the total amounts and number of deposits and withdrawals bridged, of every token or a single token contract",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryBridgeStatsRequest{}
+			if len(args) == 1 {
+				req.TokenContract = args[0]
+			}
+
+			res, err := queryClient.BridgeStats(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdGetRelayers() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "relayers",
+		Short: "Get the relayers registered with their ethereum address",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			res, err := queryClient.Relayers(cmd.Context(), &types.QueryRelayersRequest{})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdGetConfirmsByOrchestrator() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "confirms-by-orchestrator [bech32 orchestrator address] [from-nonce]",
+		Short: "Get the valset, batch and logic call confirms stored for an orchestrator, optionally from a nonce on",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryConfirmsByOrchestratorRequest{Address: args[0]}
+			if len(args) == 2 {
+				nonce, err := strconv.ParseUint(args[1], 10, 64)
+				if err != nil {
+					return err
+				}
+				req.FromNonce = nonce
+			}
+
+			res, err := queryClient.ConfirmsByOrchestrator(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdGetEthereumHeight() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ethereum-height",
+		Short: "Get the last observed Ethereum height and the projection of the current Ethereum height used for timeouts",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			res, err := queryClient.EthereumHeight(cmd.Context(), &types.QueryEthereumHeightRequest{})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdGetModuleStateSize() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "module-state-size [prefix-name]",
+		Short: "Count the entries and bytes stored under each prefix of the peggy store, or only the named one",
+		Long:  "Count the entries and bytes stored under each prefix of the peggy store, or only the named one such as OutgoingTXPoolKey. Every key of the module is read, so the query is meant for debugging.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryModuleStateSizeRequest{}
+			if len(args) == 1 {
+				req.Prefix = args[0]
+			}
+			res, err := queryClient.ModuleStateSize(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdGetGrants() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grants [granter] [grantee]",
+		Short: "Get the grants given by an account to execute bridge messages, only those to the grantee if given",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryGrantsRequest{Granter: args[0]}
+			if len(args) == 2 {
+				req.Grantee = args[1]
+			}
+			res, err := queryClient.Grants(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdGetOmnibusAccount() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "omnibus-account [account] [tag]",
+		Short: "Get whether an account is a registered omnibus account and the sub-account of the destination tag if given",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryOmnibusAccountRequest{Account: args[0]}
+			if len(args) == 2 {
+				req.Tag = args[1]
+			}
+			res, err := queryClient.OmnibusAccount(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdGetTokenQuirks() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "token-quirks [token-contract]",
+		Short: "Get the tokens whose deposits moved a different amount than reported, such as tokens taking a fee on transfer",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryTokenQuirksRequest{}
+			if len(args) == 1 {
+				req.TokenContract = args[0]
+			}
+
+			res, err := queryClient.TokenQuirks(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdGetModuleResidue() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "module-residue",
+		Short: "Get the coins of the escrow and the fee collector that no tracked liability accounts for",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			res, err := queryClient.ModuleResidue(cmd.Context(), &types.QueryModuleResidueRequest{})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdGetPausedTokens() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "paused-tokens",
+		Short: "Get the tokens whose bridging is paused",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			res, err := queryClient.PausedTokens(cmd.Context(), &types.QueryPausedTokensRequest{})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdGetAttestationVotes() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "attestation-votes [event-nonce]",
+		Short: "Get which validators voted for which claim hash of an Ethereum event",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			nonce, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			res, err := queryClient.AttestationVotes(cmd.Context(), &types.QueryAttestationVotesRequest{EventNonce: nonce})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdGetClaimDivergence() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "claim-divergence [event-nonce]",
+		Short: "Get the claims of an unobserved Ethereum event with the power backing each and the validators that didn't claim it",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			nonce, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			res, err := queryClient.ClaimDivergence(cmd.Context(), &types.QueryClaimDivergenceRequest{EventNonce: nonce})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdGetValidatorAttestationRecord() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "attestation-record [bech32 validator or orchestrator address] [from-nonce]",
+		Short: "Get the votes of a validator for the stored attestations and the observed events it missed, optionally from an event nonce on",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryValidatorAttestationRecordRequest{Validator: args[0]}
+			if len(args) == 2 {
+				nonce, err := strconv.ParseUint(args[1], 10, 64)
+				if err != nil {
+					return err
+				}
+				req.FromNonce = nonce
+			}
+
+			res, err := queryClient.ValidatorAttestationRecord(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdGetEventNonceGap() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "event-nonce-gap [bech32 orchestrator address]",
+		Short: "Get how many observed events the orchestrator has not claimed yet and the Ethereum heights to re-scan for them",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			res, err := queryClient.EventNonceGap(cmd.Context(), &types.QueryEventNonceGapRequest{Orchestrator: args[0]})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdGetBatchForTx() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "batch-for-tx [tx-id]",
+		Short: "Get the batch an outgoing transfer is in and whether it executed",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			txID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			res, err := queryClient.BatchForTx(cmd.Context(), &types.QueryBatchForTxRequest{TxId: txID})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdGetScheduledTransfers() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "scheduled-transfers [optional sender]",
+		Short: "Get the transfers to Ethereum waiting for their earliest execution",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			req := &types.QueryScheduledTransfersRequest{}
+			if len(args) == 1 {
+				req.Sender = args[0]
+			}
+			res, err := queryClient.ScheduledTransfers(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdSimulateSendToEth() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate-send-to-eth [sender] [eth-dest] [amount] [bridge-fee]",
+		Short: "Check a transfer to Ethereum without sending it and get where it would stand in the outgoing pool",
+		Args:  cobra.ExactArgs(4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			amount, err := sdk.ParseCoinNormalized(args[2])
+			if err != nil {
+				return sdkerrors.Wrap(err, "amount")
+			}
+			bridgeFee, err := sdk.ParseCoinNormalized(args[3])
+			if err != nil {
+				return sdkerrors.Wrap(err, "bridge fee")
+			}
+			res, err := queryClient.SimulateSendToEth(cmd.Context(), &types.QuerySimulateSendToEthRequest{
+				Sender:    args[0],
+				EthDest:   args[1],
+				Amount:    amount,
+				BridgeFee: bridgeFee,
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdPredictERC20Address() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "predict-erc20-address [denom]",
+		Short: "Get the address the bridge contract deploys the ERC20 of a cosmos originated denom at",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			queryClient := types.NewQueryClient(clientCtx)
+
+			res, err := queryClient.PredictERC20Address(cmd.Context(), &types.QueryPredictERC20AddressRequest{
+				Denom: args[0],
+			})
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
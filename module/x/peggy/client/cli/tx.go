@@ -4,7 +4,9 @@ import (
 	"crypto/ecdsa"
 	"encoding/hex"
 	"fmt"
+	"io/ioutil"
 	"log"
+	"strconv"
 
 	"github.com/cosmos/cosmos-sdk/types/errors"
 	ethCrypto "github.com/ethereum/go-ethereum/crypto"
@@ -15,9 +17,27 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/tx"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authclient "github.com/cosmos/cosmos-sdk/x/auth/client"
 	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
 )
 
+const (
+	FlagExpirationHeight   = "expiration-height"
+	FlagExpirationTime     = "expiration-time"
+	FlagExecuteAfterHeight = "execute-after-height"
+	FlagExecuteAfterTime   = "execute-after-time"
+
+	FlagSpendLimit          = "spend-limit"
+	FlagAllowedDestinations = "allowed-destinations"
+	FlagMsgType             = "msg-type"
+
+	FlagDeregister = "deregister"
+
+	FlagERC20InitCodeHash = "erc20-init-code-hash"
+
+	FlagDecimals = "decimals"
+)
+
 func GetTxCmd(storeKey string) *cobra.Command {
 	peggyTxCmd := &cobra.Command{
 		Use:                        types.ModuleName,
@@ -29,8 +49,18 @@ func GetTxCmd(storeKey string) *cobra.Command {
 
 	peggyTxCmd.AddCommand([]*cobra.Command{
 		CmdSendToEth(),
+		CmdMultiSendToEth(),
+		CmdBumpSendToEthFee(),
 		CmdRequestBatch(),
+		CmdCancelBatch(),
 		CmdSetOrchestratorAddress(),
+		CmdRevokeOrchestratorAddress(),
+		CmdRegisterRelayer(),
+		CmdRegisterOmnibusAccount(),
+		CmdSweepOmnibusSubAccounts(),
+		CmdGrant(),
+		CmdRevoke(),
+		CmdExec(),
 		GetUnsafeTestingCmd(),
 	}...)
 
@@ -118,13 +148,72 @@ func CmdSendToEth() *cobra.Command {
 				return fmt.Errorf("coin amounts too long, expecting just 1 coin amount for both amount and bridgeFee")
 			}
 
+			expirationHeight, err := cmd.Flags().GetUint64(FlagExpirationHeight)
+			if err != nil {
+				return err
+			}
+			expirationTime, err := cmd.Flags().GetUint64(FlagExpirationTime)
+			if err != nil {
+				return err
+			}
+			executeAfterHeight, err := cmd.Flags().GetUint64(FlagExecuteAfterHeight)
+			if err != nil {
+				return err
+			}
+			executeAfterTime, err := cmd.Flags().GetUint64(FlagExecuteAfterTime)
+			if err != nil {
+				return err
+			}
+
 			// Make the message
 			msg := types.MsgSendToEth{
-				Sender:    cosmosAddr.String(),
-				EthDest:   args[0],
-				Amount:    amount[0],
-				BridgeFee: bridgeFee[0],
+				Sender:             cosmosAddr.String(),
+				EthDest:            args[0],
+				Amount:             amount[0],
+				BridgeFee:          bridgeFee[0],
+				ExpirationHeight:   expirationHeight,
+				ExpirationTime:     expirationTime,
+				ExecuteAfterHeight: executeAfterHeight,
+				ExecuteAfterTime:   executeAfterTime,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			// Send it
+			return tx.GenerateOrBroadcastTxCLI(cliCtx, cmd.Flags(), &msg)
+		},
+	}
+	cmd.Flags().Uint64(FlagExpirationHeight, 0, "block height at which the transfer is refunded if still unbatched, 0 for none")
+	cmd.Flags().Uint64(FlagExpirationTime, 0, "unix time in seconds at which the transfer is refunded if still unbatched, 0 for none")
+	cmd.Flags().Uint64(FlagExecuteAfterHeight, 0, "earliest block height at which the transfer enters the pool, 0 for none")
+	cmd.Flags().Uint64(FlagExecuteAfterTime, 0, "earliest unix time in seconds at which the transfer enters the pool, 0 for none")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdMultiSendToEth() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "multi-send-to-eth [transfers-file]",
+		Short: "Adds the transfers of the JSON file to the outgoing pool in a single message",
+		Long: `Adds the transfers of the file to the outgoing pool, either all of them or none. The file
+holds the transfers in the JSON format of the message, e.g.
+{"sends": [{"eth_dest": "0x...", "amount": {"denom": "...", "amount": "100"}, "bridge_fee": {"denom": "...", "amount": "1"}}]}`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
 			}
+
+			bz, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			var msg types.MsgMultiSendToEth
+			if err := cliCtx.JSONMarshaler.UnmarshalJSON(bz, &msg); err != nil {
+				return sdkerrors.Wrap(err, "transfers file")
+			}
+			msg.Sender = cliCtx.GetFromAddress().String()
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
@@ -136,6 +225,36 @@ func CmdSendToEth() *cobra.Command {
 	return cmd
 }
 
+func CmdBumpSendToEthFee() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bump-send-to-eth-fee [tx-id] [additional-fee]",
+		Short: "Adds to the bridge fee of one of your transfers that is still waiting in the transaction pool",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			txID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return sdkerrors.Wrap(err, "tx id")
+			}
+			additionalFee, err := sdk.ParseCoinNormalized(args[1])
+			if err != nil {
+				return sdkerrors.Wrap(err, "additional fee")
+			}
+
+			msg := types.NewMsgBumpSendToEthFee(cliCtx.GetFromAddress(), txID, additionalFee)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(cliCtx, cmd.Flags(), msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
 func CmdRequestBatch() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "build-batch [token_contract_address]",
@@ -164,6 +283,112 @@ func CmdRequestBatch() *cobra.Command {
 	return cmd
 }
 
+func CmdCancelBatch() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel-batch [token_contract_address] [nonce]",
+		Short: "Cancel a batch you requested once the BatchCancelGracePeriod passed, returning its transactions to the pool",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			nonce, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return sdkerrors.Wrap(err, "nonce")
+			}
+
+			msg := types.NewMsgCancelBatch(cliCtx.GetFromAddress(), args[0], nonce)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			// Send it
+			return tx.GenerateOrBroadcastTxCLI(cliCtx, cmd.Flags(), msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdRegisterOmnibusAccount() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "register-omnibus-account",
+		Short: "Registers the sending account as an omnibus account, crediting its deposits with a destination tag to sub-accounts",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			deregister, err := cmd.Flags().GetBool(FlagDeregister)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgRegisterOmnibusAccount(cliCtx.GetFromAddress(), deregister)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			// Send it
+			return tx.GenerateOrBroadcastTxCLI(cliCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().Bool(FlagDeregister, false, "remove the registration instead")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdSweepOmnibusSubAccounts() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sweep-omnibus-sub-accounts [tag]...",
+		Short: "Moves the balances of the sub-accounts of the destination tags to the sending omnibus account",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg := types.NewMsgSweepOmnibusSubAccounts(cliCtx.GetFromAddress(), args)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			// Send it
+			return tx.GenerateOrBroadcastTxCLI(cliCtx, cmd.Flags(), msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdRegisterRelayer() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "register-relayer [ethereum-address] [ethereum-signature]",
+		Short: "Registers the ethereum address of a relayer with the sending account",
+		Long: `Registers the ethereum address of a relayer with the sending account. The signature is the hex encoded
+personal_sign signature of the ethereum key over keccak256(gravityID, "registerRelayer", sender account bytes).`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			msg := types.MsgRegisterRelayer{
+				Relayer:      cliCtx.GetFromAddress().String(),
+				EthAddress:   args[0],
+				EthSignature: args[1],
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			// Send it
+			return tx.GenerateOrBroadcastTxCLI(cliCtx, cmd.Flags(), &msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
 func CmdSetOrchestratorAddress() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "set-orchestrator-address [validator-address] [orchestrator-address] [ethereum-address]",
@@ -189,3 +414,154 @@ func CmdSetOrchestratorAddress() *cobra.Command {
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
+
+func CmdRevokeOrchestratorAddress() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke-orchestrator-address [validator-address] [orchestrator-address]",
+		Short: "Removes one of the orchestrators of a validator, the last one can't be removed",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			msg := types.MsgRevokeOrchestratorAddress{
+				Validator:    args[0],
+				Orchestrator: args[1],
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(cliCtx, cmd.Flags(), &msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdGrant() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant [grantee] [send-to-eth|generic]",
+		Short: "Authorize the grantee to execute bridge messages on your behalf",
+		Long: `Authorize the grantee to execute bridge messages on your behalf with exec.
+A send-to-eth grant lets the grantee send your tokens to Ethereum up to --spend-limit, only to the
+--allowed-destinations if given. A generic grant lets the grantee execute any message of the
+--msg-type, such as /gravity.v1.MsgRequestBatch.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return sdkerrors.Wrap(err, "grantee")
+			}
+			expirationTime, err := cmd.Flags().GetUint64(FlagExpirationTime)
+			if err != nil {
+				return err
+			}
+
+			var authorization types.Authorization
+			switch args[1] {
+			case "send-to-eth":
+				limitStr, err := cmd.Flags().GetString(FlagSpendLimit)
+				if err != nil {
+					return err
+				}
+				limit, err := sdk.ParseCoinsNormalized(limitStr)
+				if err != nil {
+					return sdkerrors.Wrap(err, "spend limit")
+				}
+				destinations, err := cmd.Flags().GetStringSlice(FlagAllowedDestinations)
+				if err != nil {
+					return err
+				}
+				authorization = types.NewSendToEthAuthorization(limit, destinations)
+			case "generic":
+				msgType, err := cmd.Flags().GetString(FlagMsgType)
+				if err != nil {
+					return err
+				}
+				authorization = types.NewGenericAuthorization(msgType)
+			default:
+				return fmt.Errorf("unknown authorization %s, expected send-to-eth or generic", args[1])
+			}
+
+			msg, err := types.NewMsgGrant(cliCtx.GetFromAddress(), grantee, authorization, expirationTime)
+			if err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			// Send it
+			return tx.GenerateOrBroadcastTxCLI(cliCtx, cmd.Flags(), msg)
+		},
+	}
+	cmd.Flags().String(FlagSpendLimit, "", "coins the grantee can send to Ethereum, amount and fee together")
+	cmd.Flags().StringSlice(FlagAllowedDestinations, nil, "Ethereum addresses the grantee can send to, any address if empty")
+	cmd.Flags().String(FlagMsgType, "", "type url of the message a generic grant authorizes")
+	cmd.Flags().Uint64(FlagExpirationTime, 0, "unix time in seconds from which the grant can't be used, 0 for none")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdRevoke() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke [grantee] [msg-type-url]",
+		Short: "Remove the authorization of the grantee for a bridge message type",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			grantee, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return sdkerrors.Wrap(err, "grantee")
+			}
+
+			msg := types.NewMsgRevoke(cliCtx.GetFromAddress(), grantee, args[1])
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			// Send it
+			return tx.GenerateOrBroadcastTxCLI(cliCtx, cmd.Flags(), msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func CmdExec() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "exec [tx-json-file]",
+		Short: "Execute the bridge messages of a generated transaction on behalf of their signers",
+		Long: `Execute the bridge messages of a transaction generated with --generate-only on behalf of their
+signers, who have to have granted them to you.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			theTx, err := authclient.ReadTxFromFile(cliCtx, args[0])
+			if err != nil {
+				return err
+			}
+
+			msg, err := types.NewMsgExec(cliCtx.GetFromAddress(), theTx.GetMsgs())
+			if err != nil {
+				return err
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			// Send it
+			return tx.GenerateOrBroadcastTxCLI(cliCtx, cmd.Flags(), msg)
+		},
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
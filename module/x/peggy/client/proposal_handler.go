@@ -0,0 +1,28 @@
+package client
+
+import (
+	govclient "github.com/cosmos/cosmos-sdk/x/gov/client"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/client/cli"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/client/rest"
+)
+
+var (
+	// ReturnReclaimableDepositProposalHandler is the reclaimable deposit return proposal handler
+	ReturnReclaimableDepositProposalHandler = govclient.NewProposalHandler(cli.CmdSubmitReturnReclaimableDepositProposal, rest.ReturnReclaimableDepositProposalRESTHandler)
+	// UpdateBridgeContractProposalHandler is the bridge contract update proposal handler
+	UpdateBridgeContractProposalHandler = govclient.NewProposalHandler(cli.CmdSubmitUpdateBridgeContractProposal, rest.UpdateBridgeContractProposalRESTHandler)
+	// AbandonValsetNonceProposalHandler is the skipped valset nonce abandonment proposal handler
+	AbandonValsetNonceProposalHandler = govclient.NewProposalHandler(cli.CmdSubmitAbandonValsetNonceProposal, rest.AbandonValsetNonceProposalRESTHandler)
+	// UpdateParamsProposalHandler is the params update proposal handler
+	UpdateParamsProposalHandler = govclient.NewProposalHandler(cli.CmdSubmitUpdateParamsProposal, rest.UpdateParamsProposalRESTHandler)
+	// CancelBatchProposalHandler is the batch cancellation proposal handler
+	CancelBatchProposalHandler = govclient.NewProposalHandler(cli.CmdSubmitCancelBatchProposal, rest.CancelBatchProposalRESTHandler)
+	// SetLastObservedEventNonceProposalHandler is the last observed event nonce proposal handler
+	SetLastObservedEventNonceProposalHandler = govclient.NewProposalHandler(cli.CmdSubmitSetLastObservedEventNonceProposal, rest.SetLastObservedEventNonceProposalRESTHandler)
+	// PauseTokenProposalHandler is the token pause proposal handler
+	PauseTokenProposalHandler = govclient.NewProposalHandler(cli.CmdSubmitPauseTokenProposal, rest.PauseTokenProposalRESTHandler)
+	// UnpauseTokenProposalHandler is the token unpause proposal handler
+	UnpauseTokenProposalHandler = govclient.NewProposalHandler(cli.CmdSubmitUnpauseTokenProposal, rest.UnpauseTokenProposalRESTHandler)
+	// SetCosmosOriginatedERC20ProposalHandler is the cosmos originated ERC20 correction proposal handler
+	SetCosmosOriginatedERC20ProposalHandler = govclient.NewProposalHandler(cli.CmdSubmitSetCosmosOriginatedERC20Proposal, rest.SetCosmosOriginatedERC20ProposalRESTHandler)
+)
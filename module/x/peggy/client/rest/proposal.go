@@ -0,0 +1,399 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	govrest "github.com/cosmos/cosmos-sdk/x/gov/client/rest"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+type returnReclaimableDepositProposalReq struct {
+	BaseReq     rest.BaseReq   `json:"base_req"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	EventNonce  uint64         `json:"event_nonce,string"`
+	Destination sdk.AccAddress `json:"destination"`
+	Proposer    sdk.AccAddress `json:"proposer"`
+	Deposit     sdk.Coins      `json:"deposit"`
+}
+
+type updateBridgeContractProposalReq struct {
+	BaseReq                rest.BaseReq   `json:"base_req"`
+	Title                  string         `json:"title"`
+	Description            string         `json:"description"`
+	BridgeContractAddress  string         `json:"bridge_contract_address"`
+	FinalEventNonce        uint64         `json:"final_event_nonce,string"`
+	LastObservedEventNonce uint64         `json:"last_observed_event_nonce,string"`
+	Erc20InitCodeHash      string         `json:"erc20_init_code_hash"`
+	Proposer               sdk.AccAddress `json:"proposer"`
+	Deposit                sdk.Coins      `json:"deposit"`
+}
+
+type cancelBatchProposalReq struct {
+	BaseReq       rest.BaseReq   `json:"base_req"`
+	Title         string         `json:"title"`
+	Description   string         `json:"description"`
+	TokenContract string         `json:"token_contract"`
+	Nonce         uint64         `json:"nonce,string"`
+	Proposer      sdk.AccAddress `json:"proposer"`
+	Deposit       sdk.Coins      `json:"deposit"`
+}
+
+type setLastObservedEventNonceProposalReq struct {
+	BaseReq     rest.BaseReq   `json:"base_req"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Nonce       uint64         `json:"nonce,string"`
+	Proposer    sdk.AccAddress `json:"proposer"`
+	Deposit     sdk.Coins      `json:"deposit"`
+}
+
+// tokenProposalReq is the request of the proposals that only name a token contract
+type tokenProposalReq struct {
+	BaseReq       rest.BaseReq   `json:"base_req"`
+	Title         string         `json:"title"`
+	Description   string         `json:"description"`
+	TokenContract string         `json:"token_contract"`
+	Proposer      sdk.AccAddress `json:"proposer"`
+	Deposit       sdk.Coins      `json:"deposit"`
+}
+
+type setCosmosOriginatedERC20ProposalReq struct {
+	BaseReq       rest.BaseReq   `json:"base_req"`
+	Title         string         `json:"title"`
+	Description   string         `json:"description"`
+	Denom         string         `json:"denom"`
+	TokenContract string         `json:"token_contract"`
+	Decimals      uint32         `json:"decimals"`
+	Proposer      sdk.AccAddress `json:"proposer"`
+	Deposit       sdk.Coins      `json:"deposit"`
+}
+
+type updateParamsProposalReq struct {
+	BaseReq     rest.BaseReq   `json:"base_req"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Params      types.Params   `json:"params"`
+	Proposer    sdk.AccAddress `json:"proposer"`
+	Deposit     sdk.Coins      `json:"deposit"`
+}
+
+type abandonValsetNonceProposalReq struct {
+	BaseReq     rest.BaseReq   `json:"base_req"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	ValsetNonce uint64         `json:"valset_nonce,string"`
+	Proposer    sdk.AccAddress `json:"proposer"`
+	Deposit     sdk.Coins      `json:"deposit"`
+}
+
+// ReturnReclaimableDepositProposalRESTHandler returns the REST handler for submitting a
+// proposal to return a reclaimable deposit
+func ReturnReclaimableDepositProposalRESTHandler(cliCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "return_reclaimable_deposit",
+		Handler:  postReturnReclaimableDepositProposalHandler(cliCtx),
+	}
+}
+
+func postReturnReclaimableDepositProposalHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req returnReclaimableDepositProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.LegacyAmino, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.NewReturnReclaimableDepositProposal(req.Title, req.Description, req.EventNonce, req.Destination)
+		msg, err := govtypes.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+		if rest.CheckBadRequestError(w, msg.ValidateBasic()) {
+			return
+		}
+
+		tx.WriteGeneratedTxResponse(cliCtx, w, req.BaseReq, msg)
+	}
+}
+
+// UpdateBridgeContractProposalRESTHandler returns the REST handler for submitting a
+// proposal to update the bridge contract
+func UpdateBridgeContractProposalRESTHandler(cliCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "update_bridge_contract",
+		Handler:  postUpdateBridgeContractProposalHandler(cliCtx),
+	}
+}
+
+func postUpdateBridgeContractProposalHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req updateBridgeContractProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.LegacyAmino, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.NewUpdateBridgeContractProposal(req.Title, req.Description, req.BridgeContractAddress, req.FinalEventNonce, req.LastObservedEventNonce, req.Erc20InitCodeHash)
+		msg, err := govtypes.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+		if rest.CheckBadRequestError(w, msg.ValidateBasic()) {
+			return
+		}
+
+		tx.WriteGeneratedTxResponse(cliCtx, w, req.BaseReq, msg)
+	}
+}
+
+// AbandonValsetNonceProposalRESTHandler returns the REST handler for submitting a
+// proposal to abandon a skipped valset nonce
+func AbandonValsetNonceProposalRESTHandler(cliCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "abandon_valset_nonce",
+		Handler:  postAbandonValsetNonceProposalHandler(cliCtx),
+	}
+}
+
+func postAbandonValsetNonceProposalHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req abandonValsetNonceProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.LegacyAmino, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.NewAbandonValsetNonceProposal(req.Title, req.Description, req.ValsetNonce)
+		msg, err := govtypes.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+		if rest.CheckBadRequestError(w, msg.ValidateBasic()) {
+			return
+		}
+
+		tx.WriteGeneratedTxResponse(cliCtx, w, req.BaseReq, msg)
+	}
+}
+
+// UpdateParamsProposalRESTHandler returns the REST handler for submitting a
+// proposal to replace the peggy params
+func UpdateParamsProposalRESTHandler(cliCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "update_peggy_params",
+		Handler:  postUpdateParamsProposalHandler(cliCtx),
+	}
+}
+
+func postUpdateParamsProposalHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req updateParamsProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.LegacyAmino, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.NewUpdateParamsProposal(req.Title, req.Description, req.Params)
+		msg, err := govtypes.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+		if rest.CheckBadRequestError(w, msg.ValidateBasic()) {
+			return
+		}
+
+		tx.WriteGeneratedTxResponse(cliCtx, w, req.BaseReq, msg)
+	}
+}
+
+// CancelBatchProposalRESTHandler returns the REST handler for submitting a
+// proposal to cancel a batch
+func CancelBatchProposalRESTHandler(cliCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "cancel_batch",
+		Handler:  postCancelBatchProposalHandler(cliCtx),
+	}
+}
+
+func postCancelBatchProposalHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req cancelBatchProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.LegacyAmino, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.NewCancelBatchProposal(req.Title, req.Description, req.TokenContract, req.Nonce)
+		msg, err := govtypes.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+		if rest.CheckBadRequestError(w, msg.ValidateBasic()) {
+			return
+		}
+
+		tx.WriteGeneratedTxResponse(cliCtx, w, req.BaseReq, msg)
+	}
+}
+
+// SetLastObservedEventNonceProposalRESTHandler returns the REST handler for
+// submitting a proposal to set the last observed event nonce
+func SetLastObservedEventNonceProposalRESTHandler(cliCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "set_last_observed_event_nonce",
+		Handler:  postSetLastObservedEventNonceProposalHandler(cliCtx),
+	}
+}
+
+func postSetLastObservedEventNonceProposalHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req setLastObservedEventNonceProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.LegacyAmino, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.NewSetLastObservedEventNonceProposal(req.Title, req.Description, req.Nonce)
+		msg, err := govtypes.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+		if rest.CheckBadRequestError(w, msg.ValidateBasic()) {
+			return
+		}
+
+		tx.WriteGeneratedTxResponse(cliCtx, w, req.BaseReq, msg)
+	}
+}
+
+// PauseTokenProposalRESTHandler returns the REST handler for
+// submitting a proposal to pause the bridging of a token
+func PauseTokenProposalRESTHandler(cliCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "pause_token",
+		Handler:  postPauseTokenProposalHandler(cliCtx),
+	}
+}
+
+func postPauseTokenProposalHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req tokenProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.LegacyAmino, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.NewPauseTokenProposal(req.Title, req.Description, req.TokenContract)
+		msg, err := govtypes.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+		if rest.CheckBadRequestError(w, msg.ValidateBasic()) {
+			return
+		}
+
+		tx.WriteGeneratedTxResponse(cliCtx, w, req.BaseReq, msg)
+	}
+}
+
+// UnpauseTokenProposalRESTHandler returns the REST handler for
+// submitting a proposal to unpause the bridging of a token
+func UnpauseTokenProposalRESTHandler(cliCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "unpause_token",
+		Handler:  postUnpauseTokenProposalHandler(cliCtx),
+	}
+}
+
+func postUnpauseTokenProposalHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req tokenProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.LegacyAmino, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.NewUnpauseTokenProposal(req.Title, req.Description, req.TokenContract)
+		msg, err := govtypes.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+		if rest.CheckBadRequestError(w, msg.ValidateBasic()) {
+			return
+		}
+
+		tx.WriteGeneratedTxResponse(cliCtx, w, req.BaseReq, msg)
+	}
+}
+
+// SetCosmosOriginatedERC20ProposalRESTHandler returns the REST handler for
+// submitting a proposal to correct the ERC20 of a cosmos originated denom
+func SetCosmosOriginatedERC20ProposalRESTHandler(cliCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "set_cosmos_originated_erc20",
+		Handler:  postSetCosmosOriginatedERC20ProposalHandler(cliCtx),
+	}
+}
+
+func postSetCosmosOriginatedERC20ProposalHandler(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req setCosmosOriginatedERC20ProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.LegacyAmino, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.NewSetCosmosOriginatedERC20Proposal(req.Title, req.Description, req.Denom, req.TokenContract, req.Decimals)
+		msg, err := govtypes.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if rest.CheckBadRequestError(w, err) {
+			return
+		}
+		if rest.CheckBadRequestError(w, msg.ValidateBasic()) {
+			return
+		}
+
+		tx.WriteGeneratedTxResponse(cliCtx, w, req.BaseReq, msg)
+	}
+}
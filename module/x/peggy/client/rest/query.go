@@ -10,12 +10,21 @@ import (
 	"github.com/gorilla/mux"
 )
 
+// customQueryPath returns the querier path of route, asking for the legacy amino JSON
+// instead of the proto-JSON of the gRPC response when the request has ?encoding=amino
+func customQueryPath(r *http.Request, storeName string, route string) string {
+	if r.URL.Query().Get("encoding") == types.QuerierLegacyAmino {
+		return fmt.Sprintf("custom/%s/%s/%s", storeName, types.QuerierLegacyAmino, route)
+	}
+	return fmt.Sprintf("custom/%s/%s", storeName, route)
+}
+
 func getValsetRequestHandler(cliCtx client.Context, storeName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
 		nonce := vars[nonce]
 
-		res, height, err := cliCtx.Query(fmt.Sprintf("custom/%s/valsetRequest/%s", storeName, nonce))
+		res, height, err := cliCtx.Query(customQueryPath(r, storeName, fmt.Sprintf("valsetRequest/%s", nonce)))
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -25,8 +34,6 @@ func getValsetRequestHandler(cliCtx client.Context, storeName string) http.Handl
 			return
 		}
 
-		var out types.Valset
-		cliCtx.JSONMarshaler.MustUnmarshalJSON(res, &out)
 		rest.PostProcessResponse(w, cliCtx.WithHeight(height), res)
 	}
 }
@@ -38,7 +45,7 @@ func batchByNonceHandler(cliCtx client.Context, storeName string) http.HandlerFu
 		nonce := vars[nonce]
 		denom := vars[tokenAddress]
 
-		res, height, err := cliCtx.Query(fmt.Sprintf("custom/%s/batch/%s/%s", storeName, nonce, denom))
+		res, height, err := cliCtx.Query(customQueryPath(r, storeName, fmt.Sprintf("batch/%s/%s", nonce, denom)))
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -48,8 +55,6 @@ func batchByNonceHandler(cliCtx client.Context, storeName string) http.HandlerFu
 			return
 		}
 
-		var out types.OutgoingTxBatch
-		cliCtx.JSONMarshaler.MustUnmarshalJSON(res, &out)
 		rest.PostProcessResponse(w, cliCtx.WithHeight(height), res)
 	}
 }
@@ -58,7 +63,7 @@ func batchByNonceHandler(cliCtx client.Context, storeName string) http.HandlerFu
 func lastBatchesHandler(cliCtx client.Context, storeName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 
-		res, height, err := cliCtx.Query(fmt.Sprintf("custom/%s/lastBatches", storeName))
+		res, height, err := cliCtx.Query(customQueryPath(r, storeName, "lastBatches"))
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -78,7 +83,7 @@ func allValsetConfirmsHandler(cliCtx client.Context, storeName string) http.Hand
 		vars := mux.Vars(r)
 		nonce := vars[nonce]
 
-		res, height, err := cliCtx.Query(fmt.Sprintf("custom/%s/valsetConfirms/%s", storeName, nonce))
+		res, height, err := cliCtx.Query(customQueryPath(r, storeName, fmt.Sprintf("valsetConfirms/%s", nonce)))
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -99,7 +104,7 @@ func allBatchConfirmsHandler(cliCtx client.Context, storeName string) http.Handl
 		nonce := vars[nonce]
 		denom := vars[tokenAddress]
 
-		res, height, err := cliCtx.Query(fmt.Sprintf("custom/%s/batchConfirms/%s/%s", storeName, nonce, denom))
+		res, height, err := cliCtx.Query(customQueryPath(r, storeName, fmt.Sprintf("batchConfirms/%s/%s", nonce, denom)))
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -115,7 +120,7 @@ func allBatchConfirmsHandler(cliCtx client.Context, storeName string) http.Handl
 
 func lastValsetRequestsHandler(cliCtx client.Context, storeName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		res, height, err := cliCtx.Query(fmt.Sprintf("custom/%s/lastValsetRequests", storeName))
+		res, height, err := cliCtx.Query(customQueryPath(r, storeName, "lastValsetRequests"))
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -134,7 +139,7 @@ func lastValsetRequestsByAddressHandler(cliCtx client.Context, storeName string)
 		vars := mux.Vars(r)
 		operatorAddr := vars[bech32ValidatorAddress]
 
-		res, height, err := cliCtx.Query(fmt.Sprintf("custom/%s/lastPendingValsetRequest/%s", storeName, operatorAddr))
+		res, height, err := cliCtx.Query(customQueryPath(r, storeName, fmt.Sprintf("lastPendingValsetRequest/%s", operatorAddr)))
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -144,8 +149,6 @@ func lastValsetRequestsByAddressHandler(cliCtx client.Context, storeName string)
 			return
 		}
 
-		var out types.Valset
-		cliCtx.JSONMarshaler.MustUnmarshalJSON(res, &out)
 		rest.PostProcessResponse(w, cliCtx.WithHeight(height), res)
 	}
 }
@@ -155,7 +158,7 @@ func lastBatchesByAddressHandler(cliCtx client.Context, storeName string) http.H
 		vars := mux.Vars(r)
 		operatorAddr := vars[bech32ValidatorAddress]
 
-		res, height, err := cliCtx.Query(fmt.Sprintf("custom/%s/lastPendingBatchRequest/%s", storeName, operatorAddr))
+		res, height, err := cliCtx.Query(customQueryPath(r, storeName, fmt.Sprintf("lastPendingBatchRequest/%s", operatorAddr)))
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -165,21 +168,17 @@ func lastBatchesByAddressHandler(cliCtx client.Context, storeName string) http.H
 			return
 		}
 
-		var out types.OutgoingTxBatch
-		cliCtx.JSONMarshaler.MustUnmarshalJSON(res, &out)
 		rest.PostProcessResponse(w, cliCtx.WithHeight(height), res)
 	}
 }
 
 func currentValsetHandler(cliCtx client.Context, storeName string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		res, height, err := cliCtx.Query(fmt.Sprintf("custom/%s/currentValset", storeName))
+		res, height, err := cliCtx.Query(customQueryPath(r, storeName, "currentValset"))
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
 		}
-		var out types.Valset
-		cliCtx.JSONMarshaler.MustUnmarshalJSON(res, &out)
 		rest.PostProcessResponse(w, cliCtx.WithHeight(height), res)
 	}
 }
@@ -189,7 +188,7 @@ func denomToERC20Handler(cliCtx client.Context, storeName string) http.HandlerFu
 		vars := mux.Vars(r)
 		denom := vars[denom]
 
-		res, height, err := cliCtx.Query(fmt.Sprintf("custom/%s/DenomToERC20/%s", storeName, denom))
+		res, height, err := cliCtx.Query(customQueryPath(r, storeName, fmt.Sprintf("DenomToERC20/%s", denom)))
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
@@ -203,7 +202,7 @@ func ERC20ToDenomHandler(cliCtx client.Context, storeName string) http.HandlerFu
 		vars := mux.Vars(r)
 		ERC20 := vars[tokenAddress]
 
-		res, height, err := cliCtx.Query(fmt.Sprintf("custom/%s/ERC20ToDenom/%s", storeName, ERC20))
+		res, height, err := cliCtx.Query(customQueryPath(r, storeName, fmt.Sprintf("ERC20ToDenom/%s", ERC20)))
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
@@ -45,8 +45,12 @@ func createValsetConfirmHandler(cliCtx client.Context, storeKey string) http.Han
 			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
 			return
 		}
-		var valset types.Valset
-		cliCtx.JSONMarshaler.MustUnmarshalJSON(res, &valset)
+		var valsetRes types.QueryValsetRequestResponse
+		if err := cliCtx.JSONMarshaler.UnmarshalJSON(res, &valsetRes); err != nil || valsetRes.Valset == nil {
+			rest.WriteErrorResponse(w, http.StatusNotFound, "valset not found")
+			return
+		}
+		valset := valsetRes.Valset
 
 		// TODO: fix this, need to fetch the peggyID from params here
 		checkpoint := valset.GetCheckpoint("fetch-peggy-id-from-params-please-this-should-panic")
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/cosmos/gravity-bridge/module/x/peggy/keeper"
 	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
@@ -55,7 +56,93 @@ func initializeTestingVars(t *testing.T) *testingVars {
 	return &tv
 }
 
-func addDenomToERC20Relation(tv *testingVars) {
+func TestCosmosOriginatedNotDeployed(t *testing.T) {
+	tv := initializeTestingVars(t)
+	setDenomMetadata(tv)
+
+	var (
+		userCosmosAddr, _ = sdk.AccAddressFromBech32("cosmos1990z7dqsvh8gthw9pa5sn4wuy2xrsd80mg5z6y")
+		startingCoins     = sdk.Coins{sdk.NewCoin(tv.denom, sdk.NewInt(150))}
+	)
+	require.NoError(t, tv.input.BankKeeper.MintCoins(tv.ctx, types.ModuleName, startingCoins))
+	require.NoError(t, tv.input.BankKeeper.SendCoinsFromModuleToAccount(tv.ctx, types.ModuleName, userCosmosAddr, startingCoins))
+
+	// without a deployed ERC20 the denom can't be bridged
+	_, _, err := tv.input.PeggyKeeper.DenomToERC20Lookup(tv.ctx, tv.denom)
+	require.True(t, types.ErrNotDeployed.Is(err))
+
+	msg := &types.MsgSendToEth{
+		Sender:    userCosmosAddr.String(),
+		EthDest:   "0x3c9289da00b02dC623d0D8D907619890301D26d4",
+		Amount:    sdk.NewCoin(tv.denom, sdk.NewInt(50)),
+		BridgeFee: sdk.NewCoin(tv.denom, sdk.NewInt(5)),
+	}
+	_, err = tv.h(tv.ctx, msg)
+	require.True(t, types.ErrNotDeployed.Is(err))
+	assert.Equal(t, startingCoins, tv.input.BankKeeper.GetAllBalances(tv.ctx, userCosmosAddr))
+
+	// an observed deployment that doesn't match the denom metadata is not recorded
+	ethClaim := types.MsgERC20DeployedClaim{
+		CosmosDenom:           tv.denom,
+		TokenContract:         tv.erc20,
+		Name:                  "atom",
+		Symbol:                "atom",
+		Decimals:              18,
+		EventNonce:            1,
+		Orchestrator:          tv.myOrchestratorAddr.String(),
+		BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+		BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
+	}
+	_, err = tv.h(tv.ctx, &ethClaim)
+	require.NoError(t, err)
+	EndBlocker(tv.ctx, tv.input.PeggyKeeper)
+
+	a := tv.input.PeggyKeeper.GetAttestation(tv.ctx, 1, ethClaim.ClaimHash())
+	require.NotNil(t, a)
+	assert.True(t, a.Observed)
+
+	_, _, err = tv.input.PeggyKeeper.DenomToERC20Lookup(tv.ctx, tv.denom)
+	require.True(t, types.ErrNotDeployed.Is(err))
+	isCosmosOriginated, _ := tv.input.PeggyKeeper.ERC20ToDenomLookup(tv.ctx, tv.erc20)
+	assert.False(t, isCosmosOriginated)
+}
+
+// Escrowed coins must stay in the module account when a batch of
+// cosmos-originated tokens is executed on Ethereum, only vouchers are burned.
+// The fees paid to the relayer on Ethereum move from the fee collector to the escrow.
+func TestCosmosOriginatedBatchExecuted(t *testing.T) {
+	tv := initializeTestingVars(t)
+	addDenomToERC20Relation(tv)
+	lockCoinsInModule(tv)
+
+	batch, err := tv.input.PeggyKeeper.BuildOutgoingTXBatch(tv.ctx, tv.erc20, 10)
+	require.NoError(t, err)
+	require.NotNil(t, batch)
+
+	ethClaim := types.MsgWithdrawClaim{
+		EventNonce:            2,
+		BatchNonce:            batch.BatchNonce,
+		TokenContract:         tv.erc20,
+		Orchestrator:          tv.myOrchestratorAddr.String(),
+		BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+		BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
+	}
+	_, err = tv.h(tv.ctx, &ethClaim)
+	require.NoError(t, err)
+	EndBlocker(tv.ctx, tv.input.PeggyKeeper)
+
+	assert.Nil(t, tv.input.PeggyKeeper.GetOutgoingTXBatch(tv.ctx, tv.erc20, batch.BatchNonce))
+
+	peggyAddr := tv.input.AccountKeeper.GetModuleAddress(types.ModuleName)
+	assert.Equal(t,
+		sdk.Coins{sdk.NewCoin(tv.denom, sdk.NewInt(55))},
+		tv.input.BankKeeper.GetAllBalances(tv.ctx, peggyAddr),
+	)
+	feeCollectorAddr := tv.input.AccountKeeper.GetModuleAddress(types.FeeCollectorName)
+	assert.True(t, tv.input.BankKeeper.GetAllBalances(tv.ctx, feeCollectorAddr).IsZero())
+}
+
+func setDenomMetadata(tv *testingVars) {
 	tv.input.BankKeeper.SetDenomMetaData(tv.ctx, bank.Metadata{
 		Description: "The native staking token of the Cosmos Hub.",
 		DenomUnits: []*bank.DenomUnit{
@@ -66,19 +153,25 @@ func addDenomToERC20Relation(tv *testingVars) {
 		Base:    "uatom",
 		Display: "atom",
 	})
+}
+
+func addDenomToERC20Relation(tv *testingVars) {
+	setDenomMetadata(tv)
 
 	var (
 		myNonce = uint64(1)
 	)
 
 	ethClaim := types.MsgERC20DeployedClaim{
-		CosmosDenom:   tv.denom,
-		TokenContract: tv.erc20,
-		Name:          "atom",
-		Symbol:        "atom",
-		Decimals:      6,
-		EventNonce:    myNonce,
-		Orchestrator:  tv.myOrchestratorAddr.String(),
+		CosmosDenom:           tv.denom,
+		TokenContract:         tv.erc20,
+		Name:                  "atom",
+		Symbol:                "atom",
+		Decimals:              6,
+		EventNonce:            myNonce,
+		Orchestrator:          tv.myOrchestratorAddr.String(),
+		BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+		BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
 	}
 
 	_, err := tv.h(tv.ctx, &ethClaim)
@@ -136,12 +229,17 @@ func lockCoinsInModule(tv *testingVars) {
 	balance2 := tv.input.BankKeeper.GetAllBalances(tv.ctx, userCosmosAddr)
 	assert.Equal(tv.t, sdk.Coins{sdk.NewCoin(denom, startingCoinAmount.Sub(sendAmount).Sub(feeAmount))}, balance2)
 
-	// Check that peggy balance has gone up
+	// Check that the amount is escrowed and the fee is held by the fee collector
 	peggyAddr := tv.input.AccountKeeper.GetModuleAddress(types.ModuleName)
 	assert.Equal(tv.t,
-		sdk.Coins{sdk.NewCoin(denom, sendAmount.Add(feeAmount))},
+		sdk.Coins{sdk.NewCoin(denom, sendAmount)},
 		tv.input.BankKeeper.GetAllBalances(tv.ctx, peggyAddr),
 	)
+	feeCollectorAddr := tv.input.AccountKeeper.GetModuleAddress(types.FeeCollectorName)
+	assert.Equal(tv.t,
+		sdk.Coins{sdk.NewCoin(denom, feeAmount)},
+		tv.input.BankKeeper.GetAllBalances(tv.ctx, feeCollectorAddr),
+	)
 }
 
 func acceptDepositEvent(tv *testingVars) {
@@ -158,12 +256,14 @@ func acceptDepositEvent(tv *testingVars) {
 	}
 
 	ethClaim := types.MsgDepositClaim{
-		EventNonce:     myNonce,
-		TokenContract:  myErc20.Contract,
-		Amount:         myErc20.Amount,
-		EthereumSender: anyETHAddr,
-		CosmosReceiver: myCosmosAddr.String(),
-		Orchestrator:   myOrchestratorAddr.String(),
+		EventNonce:            myNonce,
+		TokenContract:         myErc20.Contract,
+		Amount:                myErc20.Amount,
+		EthereumSender:        anyETHAddr,
+		CosmosReceiver:        myCosmosAddr.String(),
+		Orchestrator:          myOrchestratorAddr.String(),
+		BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+		BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
 	}
 
 	_, err := tv.h(tv.ctx, &ethClaim)
@@ -179,10 +279,320 @@ func acceptDepositEvent(tv *testingVars) {
 		sdk.Coins{sdk.NewCoin(tv.denom, myErc20.Amount)},
 		tv.input.BankKeeper.GetAllBalances(tv.ctx, myCosmosAddr))
 
-	// Check that peggy balance has gone down
+	// Check that the escrow balance has gone down
 	peggyAddr := tv.input.AccountKeeper.GetModuleAddress(types.ModuleName)
 	assert.Equal(tv.t,
-		sdk.Coins{sdk.NewCoin(tv.denom, sdk.NewIntFromUint64(55).Sub(myErc20.Amount))},
+		sdk.Coins{sdk.NewCoin(tv.denom, sdk.NewIntFromUint64(50).Sub(myErc20.Amount))},
 		tv.input.BankKeeper.GetAllBalances(tv.ctx, peggyAddr),
 	)
 }
+
+// The ethereum height and tx hash reported by the observed withdraw claim are
+// recorded so the batch can be linked to its ethereum transaction.
+func TestBatchExecutionQuery(t *testing.T) {
+	tv := initializeTestingVars(t)
+	addDenomToERC20Relation(tv)
+	lockCoinsInModule(tv)
+
+	batch, err := tv.input.PeggyKeeper.BuildOutgoingTXBatch(tv.ctx, tv.erc20, 10)
+	require.NoError(t, err)
+	require.NotNil(t, batch)
+
+	req := &types.QueryBatchExecutionRequest{TokenContract: tv.erc20, BatchNonce: batch.BatchNonce}
+	_, err = tv.input.PeggyKeeper.BatchExecution(sdk.WrapSDKContext(tv.ctx), req)
+	require.True(t, types.ErrUnknown.Is(err))
+
+	txHash := "0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060"
+	ethClaim := types.MsgWithdrawClaim{
+		EventNonce:            2,
+		BlockHeight:           1234,
+		BatchNonce:            batch.BatchNonce,
+		TokenContract:         tv.erc20,
+		Orchestrator:          tv.myOrchestratorAddr.String(),
+		BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+		BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
+		EthTxHash:             "0xinvalid",
+	}
+	require.Error(t, ethClaim.ValidateBasic())
+
+	ethClaim.EthTxHash = txHash
+	_, err = tv.h(tv.ctx, &ethClaim)
+	require.NoError(t, err)
+	EndBlocker(tv.ctx, tv.input.PeggyKeeper)
+
+	res, err := tv.input.PeggyKeeper.BatchExecution(sdk.WrapSDKContext(tv.ctx), req)
+	require.NoError(t, err)
+	assert.Equal(t, &types.BatchExecution{
+		TokenContract:       tv.erc20,
+		BatchNonce:          batch.BatchNonce,
+		EventNonce:          2,
+		EthereumBlockHeight: 1234,
+		EthTxHash:           txHash,
+	}, res.Execution)
+
+	// the execution is exported with the genesis state
+	genesis := keeper.ExportGenesis(tv.ctx, tv.input.PeggyKeeper)
+	assert.Equal(t, []types.BatchExecution{*res.Execution}, genesis.BatchExecutions)
+}
+
+// Observed deposits and executed batches add up in the bridge stats of their token,
+// the relayer fees are not part of the withdrawn amount.
+func TestBridgeStats(t *testing.T) {
+	tv := initializeTestingVars(t)
+	addDenomToERC20Relation(tv)
+	lockCoinsInModule(tv)
+	acceptDepositEvent(tv)
+
+	batch, err := tv.input.PeggyKeeper.BuildOutgoingTXBatch(tv.ctx, tv.erc20, 10)
+	require.NoError(t, err)
+	require.NotNil(t, batch)
+	ethClaim := types.MsgWithdrawClaim{
+		EventNonce:            3,
+		BlockHeight:           1234,
+		BatchNonce:            batch.BatchNonce,
+		TokenContract:         tv.erc20,
+		Orchestrator:          tv.myOrchestratorAddr.String(),
+		BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+		BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
+		EthTxHash:             "0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060",
+	}
+	_, err = tv.h(tv.ctx, &ethClaim)
+	require.NoError(t, err)
+	EndBlocker(tv.ctx, tv.input.PeggyKeeper)
+
+	exp := types.BridgeStats{
+		TokenContract:        tv.erc20,
+		TotalDeposited:       sdk.NewInt(12),
+		DepositCount:         1,
+		TotalWithdrawn:       sdk.NewInt(50),
+		WithdrawalCount:      1,
+		WithdrawalBatchCount: 1,
+	}
+	res, err := tv.input.PeggyKeeper.BridgeStats(sdk.WrapSDKContext(tv.ctx), &types.QueryBridgeStatsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []types.BridgeStats{exp}, res.Stats)
+
+	res, err = tv.input.PeggyKeeper.BridgeStats(sdk.WrapSDKContext(tv.ctx), &types.QueryBridgeStatsRequest{TokenContract: tv.erc20})
+	require.NoError(t, err)
+	assert.Equal(t, []types.BridgeStats{exp}, res.Stats)
+
+	// a token that was never bridged has zero volume
+	otherToken := "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+	res, err = tv.input.PeggyKeeper.BridgeStats(sdk.WrapSDKContext(tv.ctx), &types.QueryBridgeStatsRequest{TokenContract: otherToken})
+	require.NoError(t, err)
+	assert.Equal(t, []types.BridgeStats{{TokenContract: otherToken, TotalDeposited: sdk.ZeroInt(), TotalWithdrawn: sdk.ZeroInt()}}, res.Stats)
+
+	_, err = tv.input.PeggyKeeper.BridgeStats(sdk.WrapSDKContext(tv.ctx), &types.QueryBridgeStatsRequest{TokenContract: "invalid"})
+	require.True(t, types.ErrInvalid.Is(err))
+
+	// the stats are exported with the genesis state
+	genesis := keeper.ExportGenesis(tv.ctx, tv.input.PeggyKeeper)
+	assert.Equal(t, []types.BridgeStats{exp}, genesis.BridgeStats)
+}
+
+func TestPredictERC20Address(t *testing.T) {
+	tv := initializeTestingVars(t)
+	k := tv.input.PeggyKeeper
+	const initCodeHash = "0x8c55e7d2b8cc5d1a8cc1d8a3a8ef1b6f7e8e13c3d31b4f8a3d1d7fa07e0c47a2"
+
+	// the prediction needs the denom metadata and the init code hash param
+	_, err := k.PredictCosmosOriginatedERC20(tv.ctx, tv.denom)
+	assert.ErrorIs(t, err, types.ErrUnknown)
+	setDenomMetadata(tv)
+	_, err = k.PredictCosmosOriginatedERC20(tv.ctx, tv.denom)
+	assert.ErrorIs(t, err, types.ErrEmpty)
+	params := k.GetParams(tv.ctx)
+	params.Erc20InitCodeHash = initCodeHash
+	k.SetParams(tv.ctx, params)
+
+	res, err := k.PredictCosmosOriginatedERC20(tv.ctx, tv.denom)
+	require.NoError(t, err)
+	expERC20, err := types.PredictCosmosERC20Address(params.BridgeEthereumAddress, initCodeHash, tv.denom, "atom", "atom", 6)
+	require.NoError(t, err)
+	assert.Equal(t, &types.QueryPredictERC20AddressResponse{
+		Erc20:    expERC20,
+		Name:     "atom",
+		Symbol:   "atom",
+		Decimals: 6,
+	}, res)
+
+	// vouchers of ethereum originated tokens have no ERC20 to deploy
+	_, err = k.PredictCosmosOriginatedERC20(tv.ctx, types.PeggyDenom(tv.erc20))
+	assert.ErrorIs(t, err, types.ErrInvalid)
+
+	// once deployed the observed address is returned
+	addDenomToERC20Relation(tv)
+	res, err = k.PredictERC20Address(sdk.WrapSDKContext(tv.ctx), &types.QueryPredictERC20AddressRequest{Denom: tv.denom})
+	require.NoError(t, err)
+	assert.Equal(t, tv.erc20, res.Erc20)
+	assert.True(t, res.Deployed)
+}
+
+func TestDuplicateERC20Deployment(t *testing.T) {
+	tv := initializeTestingVars(t)
+	k := tv.input.PeggyKeeper
+	const otherERC20 = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+	addDenomToERC20Relation(tv)
+
+	// a later deployment of the denom is rejected, the first one stands
+	tv.ctx = tv.ctx.WithEventManager(sdk.NewEventManager())
+	_, err := tv.h(tv.ctx, &types.MsgERC20DeployedClaim{
+		CosmosDenom:           tv.denom,
+		TokenContract:         otherERC20,
+		Name:                  "atom",
+		Symbol:                "atom",
+		Decimals:              6,
+		EventNonce:            2,
+		Orchestrator:          tv.myOrchestratorAddr.String(),
+		BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+		BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
+	})
+	require.NoError(t, err)
+	EndBlocker(tv.ctx, k)
+	_, gotERC20, err := k.DenomToERC20Lookup(tv.ctx, tv.denom)
+	require.NoError(t, err)
+	assert.Equal(t, tv.erc20, gotERC20)
+	var rejected bool
+	for _, event := range tv.ctx.EventManager().Events() {
+		rejected = rejected || event.Type == types.EventTypeERC20DeploymentRejected
+	}
+	assert.True(t, rejected)
+
+	// governance corrects the mapping, the transfers of the old ERC20 in the pool are refunded
+	lockCoinsInModule(tv)
+	userCosmosAddr, _ := sdk.AccAddressFromBech32("cosmos1990z7dqsvh8gthw9pa5sn4wuy2xrsd80mg5z6y")
+	ph := NewProposalHandler(k)
+	correction := types.NewSetCosmosOriginatedERC20Proposal("title", "description", tv.denom, otherERC20, 0)
+	require.NoError(t, correction.ValidateBasic())
+	require.NoError(t, ph(tv.ctx, correction))
+	assert.Empty(t, k.GetPoolTransactions(tv.ctx))
+	assert.Equal(t, sdk.NewInt(150), tv.input.BankKeeper.GetBalance(tv.ctx, userCosmosAddr, tv.denom).Amount)
+	_, gotERC20, err = k.DenomToERC20Lookup(tv.ctx, tv.denom)
+	require.NoError(t, err)
+	assert.Equal(t, otherERC20, gotERC20)
+
+	// the old ERC20 is retired, the escrow backing its supply on Ethereum stays a liability and its
+	// deposits still release the denom
+	isCosmosOriginated, denom := k.ERC20ToDenomLookup(tv.ctx, tv.erc20)
+	assert.True(t, isCosmosOriginated)
+	assert.Equal(t, tv.denom, denom)
+	backing := sdk.NewCoins(sdk.NewInt64Coin(tv.denom, 20))
+	require.NoError(t, tv.input.BankKeeper.MintCoins(tv.ctx, types.ModuleName, backing))
+	escrow, _ := k.GetModuleResidue(tv.ctx)
+	assert.True(t, escrow.IsZero(), escrow)
+	myCosmosAddr, _ := sdk.AccAddressFromBech32("cosmos16ahjkfqxpp6lvfy9fpfnfjg39xr96qett0alj5")
+	_, err = tv.h(tv.ctx, &types.MsgDepositClaim{
+		EventNonce:            3,
+		TokenContract:         tv.erc20,
+		Amount:                sdk.NewInt(12),
+		EthereumSender:        "0xf9613b532673Cc223aBa451dFA8539B87e1F666D",
+		CosmosReceiver:        myCosmosAddr.String(),
+		Orchestrator:          tv.myOrchestratorAddr.String(),
+		BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+		BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
+	})
+	require.NoError(t, err)
+	EndBlocker(tv.ctx, k)
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(tv.denom, 12)), tv.input.BankKeeper.GetAllBalances(tv.ctx, myCosmosAddr))
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(tv.denom, 8)), tv.input.BankKeeper.GetAllBalances(tv.ctx, tv.input.AccountKeeper.GetModuleAddress(types.ModuleName)))
+
+	// the retired ERC20 is part of the genesis
+	genesis := keeper.ExportGenesis(tv.ctx, k)
+	assert.Equal(t, []*types.ERC20ToDenom{{Erc20: tv.erc20, Denom: tv.denom, Retired: true}, {Erc20: otherERC20, Denom: tv.denom}}, genesis.Erc20ToDenoms)
+	restarted := keeper.CreateTestEnv(t)
+	keeper.InitGenesis(restarted.Context, restarted.PeggyKeeper, genesis)
+	_, gotERC20, err = restarted.PeggyKeeper.DenomToERC20Lookup(restarted.Context, tv.denom)
+	require.NoError(t, err)
+	assert.Equal(t, otherERC20, gotERC20)
+	isCosmosOriginated, _ = restarted.PeggyKeeper.ERC20ToDenomLookup(restarted.Context, tv.erc20)
+	assert.True(t, isCosmosOriginated)
+
+	// only the authority can correct a mapping
+	err = k.SetCosmosOriginatedERC20(tv.ctx, userCosmosAddr.String(), tv.denom, "", 0)
+	assert.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+	// the ERC20 of the denom can be set again, an ERC20 with vouchers in circulation can't be used
+	require.NoError(t, k.SetCosmosOriginatedERC20(tv.ctx, k.GetAuthority(), tv.denom, otherERC20, 0))
+	vouchers := sdk.NewCoins(types.NewERC20Token(1, tv.erc20).PeggyCoin())
+	require.NoError(t, tv.input.BankKeeper.MintCoins(tv.ctx, types.ModuleName, vouchers))
+	err = k.SetCosmosOriginatedERC20(tv.ctx, k.GetAuthority(), tv.denom, tv.erc20, 0)
+	assert.ErrorIs(t, err, types.ErrInvalid)
+
+	// without a token contract the mapping is removed so a new deployment can be observed
+	require.NoError(t, ph(tv.ctx, types.NewSetCosmosOriginatedERC20Proposal("title", "description", tv.denom, "", 0)))
+	_, _, err = k.DenomToERC20Lookup(tv.ctx, tv.denom)
+	assert.ErrorIs(t, err, types.ErrNotDeployed)
+	assert.ErrorIs(t, ph(tv.ctx, types.NewSetCosmosOriginatedERC20Proposal("title", "description", tv.denom, "", 0)), types.ErrUnknown)
+}
+
+// A cosmos originated ERC20 governance set with other decimals than its denom has the amounts bridged
+// scaled between the two, in both directions
+func TestCosmosOriginatedERC20Decimals(t *testing.T) {
+	tv := initializeTestingVars(t)
+	k := tv.input.PeggyKeeper
+	setDenomMetadata(tv)
+	require.NoError(t, k.SetCosmosOriginatedERC20(tv.ctx, k.GetAuthority(), tv.denom, tv.erc20, 18))
+
+	var (
+		userCosmosAddr, _ = sdk.AccAddressFromBech32("cosmos1990z7dqsvh8gthw9pa5sn4wuy2xrsd80mg5z6y")
+		startingCoins     = sdk.Coins{sdk.NewCoin(tv.denom, sdk.NewInt(150))}
+		twelveDecimals    = sdk.NewInt(1000000000000)
+	)
+	require.NoError(t, tv.input.BankKeeper.MintCoins(tv.ctx, types.ModuleName, startingCoins))
+	require.NoError(t, tv.input.BankKeeper.SendCoinsFromModuleToAccount(tv.ctx, types.ModuleName, userCosmosAddr, startingCoins))
+
+	// the transfer carries the amount and fee in the decimals of the ERC20
+	txID, err := k.AddToOutgoingPool(tv.ctx, userCosmosAddr, "0x3c9289da00b02dC623d0D8D907619890301D26d4",
+		sdk.NewCoin(tv.denom, sdk.NewInt(50)), sdk.NewCoin(tv.denom, sdk.NewInt(5)))
+	require.NoError(t, err)
+	txs := k.GetPoolTransactions(tv.ctx)
+	require.Len(t, txs, 1)
+	assert.Equal(t, sdk.NewInt(50).Mul(twelveDecimals), txs[0].Erc20Token.Amount)
+	assert.Equal(t, sdk.NewInt(5).Mul(twelveDecimals), txs[0].Erc20Fee.Amount)
+	isCosmosOriginated, coin := k.ERC20ToCoin(tv.ctx, *txs[0].Erc20Token)
+	assert.True(t, isCosmosOriginated)
+	assert.Equal(t, sdk.NewCoin(tv.denom, sdk.NewInt(50)), coin)
+
+	// a refund gives back the amounts in the decimals of the denom
+	require.NoError(t, k.RemoveFromOutgoingPoolAndRefund(tv.ctx, txID, userCosmosAddr))
+	assert.Equal(t, startingCoins, tv.input.BankKeeper.GetAllBalances(tv.ctx, userCosmosAddr))
+
+	// a deposit is credited in the decimals of the denom, the dust stays on Ethereum
+	escrowed := sdk.Coins{sdk.NewCoin(tv.denom, sdk.NewInt(12))}
+	require.NoError(t, tv.input.BankKeeper.MintCoins(tv.ctx, types.ModuleName, escrowed))
+	myCosmosAddr, _ := sdk.AccAddressFromBech32("cosmos16ahjkfqxpp6lvfy9fpfnfjg39xr96qett0alj5")
+	_, err = tv.h(tv.ctx, &types.MsgDepositClaim{
+		EventNonce:            1,
+		TokenContract:         tv.erc20,
+		Amount:                sdk.NewInt(12).Mul(twelveDecimals).AddRaw(1),
+		EthereumSender:        "0xf9613b532673Cc223aBa451dFA8539B87e1F666D",
+		CosmosReceiver:        myCosmosAddr.String(),
+		Orchestrator:          tv.myOrchestratorAddr.String(),
+		BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+		BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
+	})
+	require.NoError(t, err)
+	EndBlocker(tv.ctx, k)
+	assert.Equal(t, sdk.Coins{sdk.NewCoin(tv.denom, sdk.NewInt(12))}, tv.input.BankKeeper.GetAllBalances(tv.ctx, myCosmosAddr))
+
+	// the decimals are part of the genesis
+	genesis := keeper.ExportGenesis(tv.ctx, k)
+	assert.Equal(t, []*types.ERC20ToDenom{{Erc20: tv.erc20, Denom: tv.denom, Decimals: 18}}, genesis.Erc20ToDenoms)
+
+	// an ERC20 with fewer decimals can't take the amounts it can't represent, the part of the fee it
+	// can't represent goes to the community pool
+	const otherERC20 = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+	require.NoError(t, k.SetCosmosOriginatedERC20(tv.ctx, k.GetAuthority(), tv.denom, tv.erc20, 0))
+	assert.Equal(t, []*types.ERC20ToDenom{{Erc20: tv.erc20, Denom: tv.denom}}, keeper.ExportGenesis(tv.ctx, k).Erc20ToDenoms)
+	require.NoError(t, k.SetCosmosOriginatedERC20(tv.ctx, k.GetAuthority(), tv.denom, "", 0))
+	require.NoError(t, k.SetCosmosOriginatedERC20(tv.ctx, k.GetAuthority(), tv.denom, otherERC20, 4))
+	_, err = k.AddToOutgoingPool(tv.ctx, userCosmosAddr, "0x3c9289da00b02dC623d0D8D907619890301D26d4",
+		sdk.NewCoin(tv.denom, sdk.NewInt(55)), sdk.NewCoin(tv.denom, sdk.NewInt(5)))
+	assert.ErrorIs(t, err, types.ErrInvalid)
+	_, err = k.AddToOutgoingPool(tv.ctx, userCosmosAddr, "0x3c9289da00b02dC623d0D8D907619890301D26d4",
+		sdk.NewCoin(tv.denom, sdk.NewInt(100)), sdk.NewCoin(tv.denom, sdk.NewInt(5)))
+	require.NoError(t, err)
+	txs = k.GetPoolTransactions(tv.ctx)
+	require.Len(t, txs, 1)
+	assert.Equal(t, sdk.NewInt(1), txs[0].Erc20Token.Amount)
+	assert.True(t, txs[0].Erc20Fee.Amount.IsZero())
+}
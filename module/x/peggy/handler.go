@@ -10,7 +10,7 @@ import (
 )
 
 // NewHandler returns a handler for "Peggy" type messages.
-func NewHandler(k keeper.Keeper) sdk.Handler {
+func NewHandler(k keeper.PeggyKeeper) sdk.Handler {
 	msgServer := keeper.NewMsgServerImpl(k)
 
 	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
@@ -25,6 +25,12 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 		case *types.MsgSendToEth:
 			res, err := msgServer.SendToEth(sdk.WrapSDKContext(ctx), msg)
 			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgMultiSendToEth:
+			res, err := msgServer.MultiSendToEth(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgBumpSendToEthFee:
+			res, err := msgServer.BumpSendToEthFee(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
 		case *types.MsgRequestBatch:
 			res, err := msgServer.RequestBatch(sdk.WrapSDKContext(ctx), msg)
 			return sdk.WrapServiceResult(ctx, res, err)
@@ -46,6 +52,63 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 		case *types.MsgLogicCallExecutedClaim:
 			res, err := msgServer.LogicCallExecutedClaim(sdk.WrapSDKContext(ctx), msg)
 			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgGenericEventClaim:
+			res, err := msgServer.GenericEventClaim(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgValsetUpdatedClaim:
+			res, err := msgServer.ValsetUpdatedClaim(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+		case *types.MsgUpdateParams:
+			res, err := msgServer.UpdateParams(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+
+		case *types.MsgRegisterRelayer:
+			res, err := msgServer.RegisterRelayer(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+
+		case *types.MsgCancelBatch:
+			res, err := msgServer.CancelBatch(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+
+		case *types.MsgGrant:
+			res, err := msgServer.Grant(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+
+		case *types.MsgRevoke:
+			res, err := msgServer.Revoke(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+
+		case *types.MsgExec:
+			res, err := msgServer.Exec(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+
+		case *types.MsgRegisterOmnibusAccount:
+			res, err := msgServer.RegisterOmnibusAccount(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+
+		case *types.MsgSweepOmnibusSubAccounts:
+			res, err := msgServer.SweepOmnibusSubAccounts(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+
+		case *types.MsgSetLastObservedEventNonce:
+			res, err := msgServer.SetLastObservedEventNonce(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+
+		case *types.MsgPauseToken:
+			res, err := msgServer.PauseToken(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+
+		case *types.MsgUnpauseToken:
+			res, err := msgServer.UnpauseToken(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+
+		case *types.MsgRevokeOrchestratorAddress:
+			res, err := msgServer.RevokeOrchestratorAddress(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
+
+		case *types.MsgSetCosmosOriginatedERC20:
+			res, err := msgServer.SetCosmosOriginatedERC20(sdk.WrapSDKContext(ctx), msg)
+			return sdk.WrapServiceResult(ctx, res, err)
 
 		default:
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, fmt.Sprintf("Unrecognized Peggy Msg type: %v", msg.Type()))
@@ -2,14 +2,26 @@ package peggy
 
 import (
 	"bytes"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strings"
 	"testing"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/cosmos/cosmos-sdk/x/staking"
 	"github.com/cosmos/gravity-bridge/module/x/peggy/keeper"
 	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
 )
 
 func TestHandleMsgSendToEth(t *testing.T) {
@@ -17,7 +29,7 @@ func TestHandleMsgSendToEth(t *testing.T) {
 		userCosmosAddr, _               = sdk.AccAddressFromBech32("cosmos1990z7dqsvh8gthw9pa5sn4wuy2xrsd80mg5z6y")
 		blockTime                       = time.Date(2020, 9, 14, 15, 20, 10, 0, time.UTC)
 		blockHeight           int64     = 200
-		denom                           = "peggy0xB5E9944950C97acab395a324716D186632789712"
+		denom                           = "gravity0xB5E9944950C97acab395a324716D186632789712"
 		startingCoinAmount, _           = sdk.NewIntFromString("150000000000000000000") // 150 ETH worth, required to reach above u64 limit (which is about 18 ETH)
 		sendAmount, _                   = sdk.NewIntFromString("50000000000000000000")  // 50 ETH
 		feeAmount, _                    = sdk.NewIntFromString("5000000000000000000")   // 5 ETH
@@ -74,6 +86,87 @@ func TestHandleMsgSendToEth(t *testing.T) {
 	assert.Equal(t, sdk.Coins{sdk.NewCoin(denom, finalAmount3)}, balance4)
 }
 
+// denyListKeeper wraps the peggy keeper and refuses transfers to some ethereum addresses
+type denyListKeeper struct {
+	keeper.Keeper
+	denied string
+}
+
+func (k denyListKeeper) AddToOutgoingPool(ctx sdk.Context, sender sdk.AccAddress, counterpartReceiver string, amount sdk.Coin, fee sdk.Coin) (uint64, error) {
+	if counterpartReceiver == k.denied {
+		return 0, types.ErrInvalid
+	}
+	return k.Keeper.AddToOutgoingPool(ctx, sender, counterpartReceiver, amount, fee)
+}
+
+func TestHandlerWithWrappedKeeper(t *testing.T) {
+	var (
+		userCosmosAddr, _ = sdk.AccAddressFromBech32("cosmos1990z7dqsvh8gthw9pa5sn4wuy2xrsd80mg5z6y")
+		denom             = "gravity0xB5E9944950C97acab395a324716D186632789712"
+		startingCoins     = sdk.Coins{sdk.NewInt64Coin(denom, 100)}
+		deniedDestination = "0x3c9289da00b02dC623d0D8D907619890301D26d4"
+		otherDestination  = "0xf9613b532673Cc223aBa451dFA8539B87e1F666D"
+	)
+
+	input := keeper.CreateTestEnv(t)
+	ctx := input.Context
+	h := NewHandler(denyListKeeper{Keeper: input.PeggyKeeper, denied: deniedDestination})
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, startingCoins))
+	require.NoError(t, input.BankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, userCosmosAddr, startingCoins))
+
+	msg := &types.MsgSendToEth{
+		Sender:    userCosmosAddr.String(),
+		EthDest:   deniedDestination,
+		Amount:    sdk.NewInt64Coin(denom, 10),
+		BridgeFee: sdk.NewInt64Coin(denom, 1),
+	}
+	_, err := h(ctx, msg)
+	require.True(t, types.ErrInvalid.Is(err))
+
+	msg.EthDest = otherDestination
+	_, err = h(ctx, msg)
+	require.NoError(t, err)
+	assert.Len(t, input.PeggyKeeper.GetPoolTransactions(ctx), 1)
+}
+
+func TestClaimBridgeMismatch(t *testing.T) {
+	var (
+		myOrchestratorAddr sdk.AccAddress = make([]byte, sdk.AddrLen)
+		myValAddr                         = sdk.ValAddress(myOrchestratorAddr)
+	)
+	input := keeper.CreateTestEnv(t)
+	ctx := input.Context
+	input.PeggyKeeper.StakingKeeper = keeper.NewStakingKeeperMock(myValAddr)
+	input.PeggyKeeper.SetOrchestratorValidator(ctx, myValAddr, myOrchestratorAddr)
+	h := NewHandler(input.PeggyKeeper)
+
+	claim := types.MsgWithdrawClaim{
+		EventNonce:            1,
+		BatchNonce:            1,
+		TokenContract:         "0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e",
+		Orchestrator:          myOrchestratorAddr.String(),
+		BridgeContractAddress: "0xf9613b532673Cc223aBa451dFA8539B87e1F666D",
+		BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
+	}
+	// a claim observed on another contract is rejected
+	_, err := h(ctx, &claim)
+	require.Error(t, err)
+
+	// as is a claim observed on another chain
+	claim.BridgeContractAddress = keeper.TestingPeggyParams.BridgeEthereumAddress
+	claim.BridgeChainId = keeper.TestingPeggyParams.BridgeChainId + 1
+	_, err = h(ctx, &claim)
+	require.Error(t, err)
+	assert.Nil(t, input.PeggyKeeper.GetAttestation(ctx, claim.EventNonce, claim.ClaimHash()))
+
+	// the address is compared case insensitive
+	claim.BridgeContractAddress = "0x" + strings.ToUpper(keeper.TestingPeggyParams.BridgeEthereumAddress[2:])
+	claim.BridgeChainId = keeper.TestingPeggyParams.BridgeChainId
+	_, err = h(ctx, &claim)
+	require.NoError(t, err)
+	assert.NotNil(t, input.PeggyKeeper.GetAttestation(ctx, claim.EventNonce, claim.ClaimHash()))
+}
+
 func TestMsgDepositClaimSingleValidator(t *testing.T) {
 	var (
 		myOrchestratorAddr sdk.AccAddress = make([]byte, sdk.AddrLen)
@@ -98,12 +191,14 @@ func TestMsgDepositClaimSingleValidator(t *testing.T) {
 	}
 
 	ethClaim := types.MsgDepositClaim{
-		EventNonce:     myNonce,
-		TokenContract:  myErc20.Contract,
-		Amount:         myErc20.Amount,
-		EthereumSender: anyETHAddr,
-		CosmosReceiver: myCosmosAddr.String(),
-		Orchestrator:   myOrchestratorAddr.String(),
+		EventNonce:            myNonce,
+		TokenContract:         myErc20.Contract,
+		Amount:                myErc20.Amount,
+		EthereumSender:        anyETHAddr,
+		CosmosReceiver:        myCosmosAddr.String(),
+		Orchestrator:          myOrchestratorAddr.String(),
+		BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+		BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
 	}
 
 	// when
@@ -117,26 +212,31 @@ func TestMsgDepositClaimSingleValidator(t *testing.T) {
 	require.NotNil(t, a)
 	// and vouchers added to the account
 	balance := input.BankKeeper.GetAllBalances(ctx, myCosmosAddr)
-	assert.Equal(t, sdk.Coins{sdk.NewCoin("peggy0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e", amountA)}, balance)
+	assert.Equal(t, sdk.Coins{sdk.NewCoin("gravity0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e", amountA)}, balance)
+	// and the voucher metadata registered
+	assert.Equal(t, "gravity0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e", input.BankKeeper.GetDenomMetaData(ctx, "gravity0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e").Base)
 
-	// Test to reject duplicate deposit
+	// Test to accept a resubmitted deposit without crediting it twice
 	// when
 	ctx = ctx.WithBlockTime(myBlockTime)
 	_, err = h(ctx, &ethClaim)
 	EndBlocker(ctx, input.PeggyKeeper)
 	// then
-	require.Error(t, err)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), input.PeggyKeeper.GetClaimResubmissions(ctx, myValAddr))
 	balance = input.BankKeeper.GetAllBalances(ctx, myCosmosAddr)
-	assert.Equal(t, sdk.Coins{sdk.NewCoin("peggy0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e", amountA)}, balance)
+	assert.Equal(t, sdk.Coins{sdk.NewCoin("gravity0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e", amountA)}, balance)
 
 	// Test to reject skipped nonce
 	ethClaim = types.MsgDepositClaim{
-		EventNonce:     uint64(3),
-		TokenContract:  tokenETHAddr,
-		Amount:         amountA,
-		EthereumSender: anyETHAddr,
-		CosmosReceiver: myCosmosAddr.String(),
-		Orchestrator:   myOrchestratorAddr.String(),
+		EventNonce:            uint64(3),
+		TokenContract:         tokenETHAddr,
+		Amount:                amountA,
+		EthereumSender:        anyETHAddr,
+		CosmosReceiver:        myCosmosAddr.String(),
+		Orchestrator:          myOrchestratorAddr.String(),
+		BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+		BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
 	}
 
 	// when
@@ -146,16 +246,18 @@ func TestMsgDepositClaimSingleValidator(t *testing.T) {
 	// then
 	require.Error(t, err)
 	balance = input.BankKeeper.GetAllBalances(ctx, myCosmosAddr)
-	assert.Equal(t, sdk.Coins{sdk.NewCoin("peggy0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e", amountA)}, balance)
+	assert.Equal(t, sdk.Coins{sdk.NewCoin("gravity0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e", amountA)}, balance)
 
 	// Test to finally accept consecutive nonce
 	ethClaim = types.MsgDepositClaim{
-		EventNonce:     uint64(2),
-		Amount:         amountA,
-		TokenContract:  tokenETHAddr,
-		EthereumSender: anyETHAddr,
-		CosmosReceiver: myCosmosAddr.String(),
-		Orchestrator:   myOrchestratorAddr.String(),
+		EventNonce:            uint64(2),
+		Amount:                amountA,
+		TokenContract:         tokenETHAddr,
+		EthereumSender:        anyETHAddr,
+		CosmosReceiver:        myCosmosAddr.String(),
+		Orchestrator:          myOrchestratorAddr.String(),
+		BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+		BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
 	}
 
 	// when
@@ -166,7 +268,685 @@ func TestMsgDepositClaimSingleValidator(t *testing.T) {
 	// then
 	require.NoError(t, err)
 	balance = input.BankKeeper.GetAllBalances(ctx, myCosmosAddr)
-	assert.Equal(t, sdk.Coins{sdk.NewCoin("peggy0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e", amountB)}, balance)
+	assert.Equal(t, sdk.Coins{sdk.NewCoin("gravity0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e", amountB)}, balance)
+}
+
+func TestMsgDepositClaimInvalidReceiver(t *testing.T) {
+	var (
+		myOrchestratorAddr sdk.AccAddress = make([]byte, sdk.AddrLen)
+		myValAddr                         = sdk.ValAddress(myOrchestratorAddr) // revisit when proper mapping is impl in keeper
+		myNonce                           = uint64(1)
+		anyETHAddr                        = "0xf9613b532673Cc223aBa451dFA8539B87e1F666D"
+		tokenETHAddr                      = "0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e"
+		invalidReceiver                   = "cosmos1notavalidaddress"
+		amount                            = sdk.NewInt(12)
+	)
+	input := keeper.CreateTestEnv(t)
+	ctx := input.Context
+	input.PeggyKeeper.StakingKeeper = keeper.NewStakingKeeperMock(myValAddr)
+	input.PeggyKeeper.SetOrchestratorValidator(ctx, myValAddr, myOrchestratorAddr)
+	h := NewHandler(input.PeggyKeeper)
+
+	ethClaim := types.MsgDepositClaim{
+		EventNonce:            myNonce,
+		BlockHeight:           5,
+		TokenContract:         tokenETHAddr,
+		Amount:                amount,
+		EthereumSender:        anyETHAddr,
+		CosmosReceiver:        invalidReceiver,
+		Orchestrator:          myOrchestratorAddr.String(),
+		BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+		BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
+	}
+	require.NoError(t, ethClaim.ValidateBasic())
+
+	// when
+	_, err := h(ctx, &ethClaim)
+	require.NoError(t, err)
+	EndBlocker(ctx, input.PeggyKeeper)
+
+	// then the vouchers end up in the community pool
+	expCoin := sdk.NewCoin("gravity0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e", amount)
+	communityPool := input.DistKeeper.GetFeePoolCommunityCoins(ctx)
+	assert.Equal(t, sdk.NewDecCoinsFromCoins(expCoin), communityPool)
+
+	peggyAddr := input.AccountKeeper.GetModuleAddress(types.ModuleName)
+	assert.True(t, input.BankKeeper.GetAllBalances(ctx, peggyAddr).IsZero())
+
+	// and the deposit is recorded for governance
+	exp := types.ReclaimableDeposit{
+		EventNonce:     myNonce,
+		BlockHeight:    5,
+		TokenContract:  tokenETHAddr,
+		EthereumSender: anyETHAddr,
+		CosmosReceiver: invalidReceiver,
+		Amount:         expCoin,
+	}
+	assert.Equal(t, []types.ReclaimableDeposit{exp}, input.PeggyKeeper.GetReclaimableDeposits(ctx))
+
+	res, err := input.PeggyKeeper.ReclaimableDeposits(sdk.WrapSDKContext(ctx), &types.QueryReclaimableDepositsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []types.ReclaimableDeposit{exp}, res.Deposits)
+}
+
+func TestDepositsByEthSender(t *testing.T) {
+	var (
+		myOrchestratorAddr sdk.AccAddress = make([]byte, sdk.AddrLen)
+		myCosmosAddr, _                   = sdk.AccAddressFromBech32("cosmos16ahjkfqxpp6lvfy9fpfnfjg39xr96qett0alj5")
+		myValAddr                         = sdk.ValAddress(myOrchestratorAddr) // revisit when proper mapping is impl in keeper
+		mySender                          = "0xf9613b532673Cc223aBa451dFA8539B87e1F666D"
+		otherSender                       = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		tokenETHAddr                      = "0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e"
+	)
+	input := keeper.CreateTestEnv(t)
+	ctx := input.Context
+	input.PeggyKeeper.StakingKeeper = keeper.NewStakingKeeperMock(myValAddr)
+	input.PeggyKeeper.SetOrchestratorValidator(ctx, myValAddr, myOrchestratorAddr)
+	h := NewHandler(input.PeggyKeeper)
+
+	// the sender address case doesn't matter
+	for i, sender := range []string{mySender, otherSender, strings.ToLower(mySender)} {
+		ethClaim := types.MsgDepositClaim{
+			EventNonce:            uint64(i + 1),
+			BlockHeight:           uint64(i + 5),
+			TokenContract:         tokenETHAddr,
+			Amount:                sdk.NewInt(int64(i + 10)),
+			EthereumSender:        sender,
+			CosmosReceiver:        myCosmosAddr.String(),
+			Orchestrator:          myOrchestratorAddr.String(),
+			BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+			BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
+		}
+		_, err := h(ctx, &ethClaim)
+		require.NoError(t, err)
+		EndBlocker(ctx, input.PeggyKeeper)
+	}
+
+	res, err := input.PeggyKeeper.DepositsByEthSender(sdk.WrapSDKContext(ctx), &types.QueryDepositsByEthSenderRequest{EthSender: mySender})
+	require.NoError(t, err)
+	require.Len(t, res.Deposits, 2)
+	assert.Equal(t, types.ObservedDeposit{
+		EventNonce:     1,
+		BlockHeight:    5,
+		TokenContract:  tokenETHAddr,
+		EthereumSender: mySender,
+		CosmosReceiver: myCosmosAddr.String(),
+		Amount:         sdk.NewCoin("gravity0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e", sdk.NewInt(10)),
+	}, res.Deposits[0])
+	assert.Equal(t, uint64(3), res.Deposits[1].EventNonce)
+
+	// paginated
+	res, err = input.PeggyKeeper.DepositsByEthSender(sdk.WrapSDKContext(ctx), &types.QueryDepositsByEthSenderRequest{EthSender: mySender, Pagination: &query.PageRequest{Limit: 1}})
+	require.NoError(t, err)
+	require.Len(t, res.Deposits, 1)
+	assert.NotNil(t, res.Pagination.NextKey)
+
+	_, err = input.PeggyKeeper.DepositsByEthSender(sdk.WrapSDKContext(ctx), &types.QueryDepositsByEthSenderRequest{EthSender: "invalid"})
+	require.Error(t, err)
+}
+
+func TestAttestationsByNonce(t *testing.T) {
+	var (
+		myOrchestratorAddr sdk.AccAddress = make([]byte, sdk.AddrLen)
+		myCosmosAddr, _                   = sdk.AccAddressFromBech32("cosmos16ahjkfqxpp6lvfy9fpfnfjg39xr96qett0alj5")
+		myValAddr                         = sdk.ValAddress(myOrchestratorAddr) // revisit when proper mapping is impl in keeper
+		tokenETHAddr                      = "0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e"
+	)
+	input := keeper.CreateTestEnv(t)
+	ctx := input.Context
+	input.PeggyKeeper.StakingKeeper = keeper.NewStakingKeeperMock(myValAddr)
+	input.PeggyKeeper.SetOrchestratorValidator(ctx, myValAddr, myOrchestratorAddr)
+	h := NewHandler(input.PeggyKeeper)
+
+	ethClaim := types.MsgDepositClaim{
+		EventNonce:            1,
+		BlockHeight:           5,
+		TokenContract:         tokenETHAddr,
+		Amount:                sdk.NewInt(10),
+		EthereumSender:        "0xf9613b532673Cc223aBa451dFA8539B87e1F666D",
+		CosmosReceiver:        myCosmosAddr.String(),
+		Orchestrator:          myOrchestratorAddr.String(),
+		BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+		BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
+	}
+	_, err := h(ctx, &ethClaim)
+	require.NoError(t, err)
+	EndBlocker(ctx, input.PeggyKeeper)
+
+	res, err := input.PeggyKeeper.AttestationsByNonce(sdk.WrapSDKContext(ctx), &types.QueryAttestationsByNonceRequest{EventNonce: 1})
+	require.NoError(t, err)
+	require.Len(t, res.Attestations, 1)
+	att := res.Attestations[0]
+	assert.True(t, att.Observed)
+	assert.Equal(t, []string{myValAddr.String()}, att.Votes)
+	claim, err := input.PeggyKeeper.UnpackAttestationClaim(&att)
+	require.NoError(t, err)
+	assert.Equal(t, &ethClaim, claim)
+
+	_, err = input.PeggyKeeper.AttestationsByNonce(sdk.WrapSDKContext(ctx), &types.QueryAttestationsByNonceRequest{EventNonce: 2})
+	require.Error(t, err)
+}
+
+func TestMsgDepositClaimEthereumHeightWindow(t *testing.T) {
+	var (
+		myOrchestratorAddr sdk.AccAddress = make([]byte, sdk.AddrLen)
+		myCosmosAddr, _                   = sdk.AccAddressFromBech32("cosmos16ahjkfqxpp6lvfy9fpfnfjg39xr96qett0alj5")
+		myValAddr                         = sdk.ValAddress(myOrchestratorAddr) // revisit when proper mapping is impl in keeper
+		anyETHAddr                        = "0xf9613b532673Cc223aBa451dFA8539B87e1F666D"
+		tokenETHAddr                      = "0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e"
+	)
+	input := keeper.CreateTestEnv(t)
+	ctx := input.Context
+	input.PeggyKeeper.StakingKeeper = keeper.NewStakingKeeperMock(myValAddr)
+	input.PeggyKeeper.SetOrchestratorValidator(ctx, myValAddr, myOrchestratorAddr)
+	h := NewHandler(input.PeggyKeeper)
+	window := input.PeggyKeeper.GetParams(ctx).EthereumHeightWindow
+
+	claim := func(nonce, ethHeight uint64) *types.MsgDepositClaim {
+		return &types.MsgDepositClaim{
+			EventNonce:            nonce,
+			BlockHeight:           ethHeight,
+			TokenContract:         tokenETHAddr,
+			Amount:                sdk.NewInt(12),
+			EthereumSender:        anyETHAddr,
+			CosmosReceiver:        myCosmosAddr.String(),
+			Orchestrator:          myOrchestratorAddr.String(),
+			BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+			BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
+		}
+	}
+
+	// nothing was observed yet so there is no reference height
+	_, err := h(ctx, claim(1, 1000))
+	require.NoError(t, err)
+	EndBlocker(ctx, input.PeggyKeeper)
+	require.Equal(t, uint64(1000), input.PeggyKeeper.GetLastObservedEthereumBlockHeight(ctx).EthereumBlockHeight)
+
+	// a claim beyond the window is rejected
+	_, err = h(ctx, claim(2, 1000+window+1))
+	require.True(t, types.ErrInvalid.Is(err))
+
+	// one right at the edge of the window is accepted
+	_, err = h(ctx, claim(2, 1000+window))
+	require.NoError(t, err)
+
+	// after a long quiet period Ethereum ran further than the projection from the average block times, the
+	// claimed heights are too old to bound the next claim
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 30*int64(window))
+	_, err = h(ctx, claim(3, 1000+window+13*window))
+	require.NoError(t, err)
+}
+
+func TestMsgDepositClaimEthereumBlockConfirmations(t *testing.T) {
+	var (
+		myOrchestratorAddr sdk.AccAddress = make([]byte, sdk.AddrLen)
+		myCosmosAddr, _                   = sdk.AccAddressFromBech32("cosmos16ahjkfqxpp6lvfy9fpfnfjg39xr96qett0alj5")
+		myValAddr                         = sdk.ValAddress(myOrchestratorAddr) // revisit when proper mapping is impl in keeper
+		anyETHAddr                        = "0xf9613b532673Cc223aBa451dFA8539B87e1F666D"
+		otherETHAddr                      = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		tokenETHAddr                      = "0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e"
+		denom                             = "gravity0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e"
+	)
+	input := keeper.CreateTestEnv(t)
+	ctx := input.Context
+	input.PeggyKeeper.StakingKeeper = keeper.NewStakingKeeperMock(myValAddr)
+	input.PeggyKeeper.SetOrchestratorValidator(ctx, myValAddr, myOrchestratorAddr)
+	params := input.PeggyKeeper.GetParams(ctx)
+	params.EthereumBlockConfirmations = 10
+	input.PeggyKeeper.SetParams(ctx, params)
+	h := NewHandler(input.PeggyKeeper)
+
+	claim := func(nonce, ethHeight uint64, sender string, amount int64) *types.MsgDepositClaim {
+		return &types.MsgDepositClaim{
+			EventNonce:            nonce,
+			BlockHeight:           ethHeight,
+			TokenContract:         tokenETHAddr,
+			Amount:                sdk.NewInt(amount),
+			EthereumSender:        sender,
+			CosmosReceiver:        myCosmosAddr.String(),
+			Orchestrator:          myOrchestratorAddr.String(),
+			BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+			BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
+		}
+	}
+
+	// the claim has all the votes but is not deep enough yet
+	reorged := claim(1, 1000, anyETHAddr, 12)
+	_, err := h(ctx, reorged)
+	require.NoError(t, err)
+	EndBlocker(ctx, input.PeggyKeeper)
+	assert.Equal(t, uint64(0), input.PeggyKeeper.GetLastObservedEventNonce(ctx))
+	assert.Equal(t, uint64(1000), input.PeggyKeeper.GetLatestEthereumBlockHeight(ctx).EthereumBlockHeight)
+
+	// after a reorg the orchestrator moves its vote to the corrected claim
+	corrected := claim(1, 1002, otherETHAddr, 15)
+	_, err = h(ctx, corrected)
+	require.NoError(t, err)
+	assert.Nil(t, input.PeggyKeeper.GetAttestation(ctx, 1, reorged.ClaimHash()))
+	att := input.PeggyKeeper.GetAttestation(ctx, 1, corrected.ClaimHash())
+	require.NotNil(t, att)
+	assert.Equal(t, []string{myValAddr.String()}, att.Votes)
+
+	// claims can't skip ahead of the event the orchestrator last voted for
+	_, err = h(ctx, claim(3, 1003, anyETHAddr, 1))
+	require.True(t, types.ErrNonContiguousEventNonce.Is(err))
+
+	// 36 cosmos blocks of 5s project the ethereum height 12 blocks of 15s past the first
+	// claim, only the tally is run since the staking mock can't be slashed against
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 35)
+	attestationTally(ctx, input.PeggyKeeper)
+	assert.Equal(t, uint64(0), input.PeggyKeeper.GetLastObservedEventNonce(ctx))
+
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	attestationTally(ctx, input.PeggyKeeper)
+	assert.Equal(t, uint64(1), input.PeggyKeeper.GetLastObservedEventNonce(ctx))
+	assert.Equal(t, sdk.Coins{sdk.NewInt64Coin(denom, 15)}, input.BankKeeper.GetAllBalances(ctx, myCosmosAddr))
+
+	// resubmitting the observed claim succeeds without another vote, another claim for the event fails
+	_, err = h(ctx, corrected)
+	require.NoError(t, err)
+	assert.Equal(t, []string{myValAddr.String()}, input.PeggyKeeper.GetAttestation(ctx, 1, corrected.ClaimHash()).Votes)
+	assert.Equal(t, uint64(1), input.PeggyKeeper.GetClaimResubmissions(ctx, myValAddr))
+	assert.Equal(t, sdk.Coins{sdk.NewInt64Coin(denom, 15)}, input.BankKeeper.GetAllBalances(ctx, myCosmosAddr))
+	_, err = h(ctx, reorged)
+	require.True(t, types.ErrNonContiguousEventNonce.Is(err))
+}
+
+func TestAttestationTimeout(t *testing.T) {
+	var (
+		myOrchestratorAddr    sdk.AccAddress = make([]byte, sdk.AddrLen)
+		otherOrchestratorAddr sdk.AccAddress = bytes.Repeat([]byte{1}, sdk.AddrLen)
+		myCosmosAddr, _                      = sdk.AccAddressFromBech32("cosmos16ahjkfqxpp6lvfy9fpfnfjg39xr96qett0alj5")
+		myValAddr                            = sdk.ValAddress(myOrchestratorAddr) // revisit when proper mapping is impl in keeper
+		otherValAddr                         = sdk.ValAddress(otherOrchestratorAddr)
+		tokenETHAddr                         = "0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e"
+	)
+	input := keeper.CreateTestEnv(t)
+	ctx := input.Context
+	// with two validators of equal power a single vote never reaches the threshold
+	input.PeggyKeeper.StakingKeeper = keeper.NewStakingKeeperMock(myValAddr, otherValAddr)
+	input.PeggyKeeper.SetOrchestratorValidator(ctx, myValAddr, myOrchestratorAddr)
+	input.PeggyKeeper.SetOrchestratorValidator(ctx, otherValAddr, otherOrchestratorAddr)
+	params := input.PeggyKeeper.GetParams(ctx)
+	params.AttestationTimeout = 10
+	input.PeggyKeeper.SetParams(ctx, params)
+	h := NewHandler(input.PeggyKeeper)
+
+	claim := func(nonce uint64, orchestrator sdk.AccAddress) *types.MsgDepositClaim {
+		return &types.MsgDepositClaim{
+			EventNonce:            nonce,
+			BlockHeight:           nonce,
+			TokenContract:         tokenETHAddr,
+			Amount:                sdk.NewInt(12),
+			EthereumSender:        "0xf9613b532673Cc223aBa451dFA8539B87e1F666D",
+			CosmosReceiver:        myCosmosAddr.String(),
+			Orchestrator:          orchestrator.String(),
+			BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+			BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
+		}
+	}
+	for _, nonce := range []uint64{1, 2} {
+		_, err := h(ctx, claim(nonce, myOrchestratorAddr))
+		require.NoError(t, err)
+	}
+	created := ctx.BlockHeight()
+
+	ctx = ctx.WithBlockHeight(created + 10)
+	input.PeggyKeeper.PruneTimedOutAttestations(ctx)
+	assert.Len(t, input.PeggyKeeper.GetAttestationMapping(ctx), 2)
+
+	ctx = ctx.WithBlockHeight(created + 11).WithEventManager(sdk.NewEventManager())
+	input.PeggyKeeper.PruneTimedOutAttestations(ctx)
+	assert.Empty(t, input.PeggyKeeper.GetAttestationMapping(ctx))
+	assert.Equal(t, uint64(0), input.PeggyKeeper.GetLastEventNonceByValidator(ctx, myValAddr))
+	var timedOut int
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type == types.EventTypeAttestationTimeout {
+			timedOut++
+		}
+	}
+	assert.Equal(t, 2, timedOut)
+
+	// the event can be claimed again and observed once both validators voted
+	for _, orchestrator := range []sdk.AccAddress{myOrchestratorAddr, otherOrchestratorAddr} {
+		_, err := h(ctx, claim(1, orchestrator))
+		require.NoError(t, err)
+	}
+	attestationTally(ctx, input.PeggyKeeper)
+	assert.Equal(t, uint64(1), input.PeggyKeeper.GetLastObservedEventNonce(ctx))
+}
+
+func TestAttestationTimeoutWithWorkBudget(t *testing.T) {
+	var (
+		myOrchestratorAddr    sdk.AccAddress = make([]byte, sdk.AddrLen)
+		otherOrchestratorAddr sdk.AccAddress = bytes.Repeat([]byte{1}, sdk.AddrLen)
+		myCosmosAddr, _                      = sdk.AccAddressFromBech32("cosmos16ahjkfqxpp6lvfy9fpfnfjg39xr96qett0alj5")
+		myValAddr                            = sdk.ValAddress(myOrchestratorAddr) // revisit when proper mapping is impl in keeper
+		otherValAddr                         = sdk.ValAddress(otherOrchestratorAddr)
+		tokenETHAddr                         = "0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e"
+	)
+	input := keeper.CreateTestEnv(t)
+	ctx := input.Context
+	input.PeggyKeeper.StakingKeeper = keeper.NewStakingKeeperMock(myValAddr, otherValAddr)
+	input.PeggyKeeper.SetOrchestratorValidator(ctx, myValAddr, myOrchestratorAddr)
+	input.PeggyKeeper.SetOrchestratorValidator(ctx, otherValAddr, otherOrchestratorAddr)
+	params := input.PeggyKeeper.GetParams(ctx)
+	params.AttestationTimeout = 10
+	// the smallest budget lets a single unit of every task run per block
+	params.EndBlockerWorkBudget = 1
+	input.PeggyKeeper.SetParams(ctx, params)
+	h := NewHandler(input.PeggyKeeper)
+	k := input.PeggyKeeper
+
+	claim := func(nonce uint64, orchestrator sdk.AccAddress) *types.MsgDepositClaim {
+		return &types.MsgDepositClaim{
+			EventNonce:            nonce,
+			BlockHeight:           nonce,
+			TokenContract:         tokenETHAddr,
+			Amount:                sdk.NewInt(12),
+			EthereumSender:        "0xf9613b532673Cc223aBa451dFA8539B87e1F666D",
+			CosmosReceiver:        myCosmosAddr.String(),
+			Orchestrator:          orchestrator.String(),
+			BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+			BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
+		}
+	}
+	for _, nonce := range []uint64{1, 2} {
+		_, err := h(ctx, claim(nonce, myOrchestratorAddr))
+		require.NoError(t, err)
+	}
+	created := ctx.BlockHeight()
+
+	// one attestation is pruned per block and the votes are only withdrawn once the pass completes
+	ctx = ctx.WithBlockHeight(created + 11)
+	k.PruneTimedOutAttestations(k.WithWorkBudget(ctx))
+	assert.Len(t, k.GetAttestationMapping(ctx), 1)
+	assert.Equal(t, uint64(2), k.GetLastEventNonceByValidator(ctx, myValAddr))
+	k.PruneTimedOutAttestations(k.WithWorkBudget(ctx))
+	assert.Empty(t, k.GetAttestationMapping(ctx))
+	assert.Equal(t, uint64(2), k.GetLastEventNonceByValidator(ctx, myValAddr))
+	k.PruneTimedOutAttestations(k.WithWorkBudget(ctx))
+	assert.Equal(t, uint64(0), k.GetLastEventNonceByValidator(ctx, myValAddr))
+
+	// the tally observes a single nonce per block
+	for _, nonce := range []uint64{1, 2} {
+		for _, orchestrator := range []sdk.AccAddress{myOrchestratorAddr, otherOrchestratorAddr} {
+			_, err := h(ctx, claim(nonce, orchestrator))
+			require.NoError(t, err)
+		}
+	}
+	attestationTally(k.WithWorkBudget(ctx), k)
+	assert.Equal(t, uint64(1), k.GetLastObservedEventNonce(ctx))
+	attestationTally(k.WithWorkBudget(ctx), k)
+	assert.Equal(t, uint64(2), k.GetLastObservedEventNonce(ctx))
+}
+
+// genericEventHooks records the observed generic events
+type genericEventHooks struct {
+	events *[]*types.MsgGenericEventClaim
+}
+
+func (genericEventHooks) AfterDepositObserved(sdk.Context, uint64, string, sdk.AccAddress, sdk.Coin) {
+}
+func (genericEventHooks) AfterWithdrawExecuted(sdk.Context, string, uint64)     {}
+func (genericEventHooks) AfterValsetUpdated(sdk.Context, *types.Valset)         {}
+func (genericEventHooks) AfterBatchCreated(sdk.Context, *types.OutgoingTxBatch) {}
+func (h genericEventHooks) AfterGenericEventObserved(_ sdk.Context, eventNonce uint64, contractAddress string, topic []byte, data []byte) {
+	*h.events = append(*h.events, &types.MsgGenericEventClaim{EventNonce: eventNonce, ContractAddress: contractAddress, Topic: topic, Data: data})
+}
+
+func TestMsgGenericEventClaim(t *testing.T) {
+	var (
+		myOrchestratorAddr sdk.AccAddress = make([]byte, sdk.AddrLen)
+		myValAddr                         = sdk.ValAddress(myOrchestratorAddr) // revisit when proper mapping is impl in keeper
+		contractAddr                      = "0xf9613b532673Cc223aBa451dFA8539B87e1F666D"
+		topic                             = make([]byte, 32)
+		events             []*types.MsgGenericEventClaim
+	)
+	input := keeper.CreateTestEnv(t)
+	ctx := input.Context
+	input.PeggyKeeper.StakingKeeper = keeper.NewStakingKeeperMock(myValAddr)
+	input.PeggyKeeper.SetOrchestratorValidator(ctx, myValAddr, myOrchestratorAddr)
+	input.PeggyKeeper.SetHooks(genericEventHooks{&events})
+	h := NewHandler(input.PeggyKeeper)
+
+	topic[31] = 0x1
+	claim := &types.MsgGenericEventClaim{
+		EventNonce:            1,
+		BlockHeight:           100,
+		ContractAddress:       contractAddr,
+		Topic:                 topic,
+		Data:                  []byte{0xde, 0xad, 0xbe, 0xef},
+		Orchestrator:          myOrchestratorAddr.String(),
+		BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+		BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
+	}
+	require.NoError(t, claim.ValidateBasic())
+	_, err := h(ctx, claim)
+	require.NoError(t, err)
+	EndBlocker(ctx, input.PeggyKeeper)
+
+	// the observed event is handed to the hooks
+	require.Len(t, events, 1)
+	assert.Equal(t, uint64(1), events[0].EventNonce)
+	assert.Equal(t, contractAddr, events[0].ContractAddress)
+	assert.Equal(t, topic, events[0].Topic)
+	assert.Equal(t, claim.Data, events[0].Data)
+	assert.Equal(t, uint64(1), input.PeggyKeeper.GetLastObservedEventNonce(ctx))
+
+	// a topic has to be a 32 byte hash
+	invalid := *claim
+	invalid.Topic = topic[1:]
+	require.Error(t, invalid.ValidateBasic())
+}
+
+func TestObservationOrder(t *testing.T) {
+	var (
+		myOrchestratorAddr sdk.AccAddress = make([]byte, sdk.AddrLen)
+		myValAddr                         = sdk.ValAddress(myOrchestratorAddr) // revisit when proper mapping is impl in keeper
+		contractAddr                      = "0xf9613b532673Cc223aBa451dFA8539B87e1F666D"
+		events             []*types.MsgGenericEventClaim
+	)
+	input := keeper.CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	k.StakingKeeper = keeper.NewStakingKeeperMock(myValAddr)
+	k.SetOrchestratorValidator(ctx, myValAddr, myOrchestratorAddr)
+	k.SetHooks(genericEventHooks{&events})
+	h := NewHandler(k)
+
+	claim := func(nonce uint64, data byte) {
+		topic := make([]byte, 32)
+		_, err := h(ctx, &types.MsgGenericEventClaim{
+			EventNonce:            nonce,
+			BlockHeight:           100 + nonce,
+			ContractAddress:       contractAddr,
+			Topic:                 topic,
+			Data:                  []byte{data},
+			Orchestrator:          myOrchestratorAddr.String(),
+			BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+			BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
+		})
+		require.NoError(t, err)
+	}
+	applied := func() (out []uint64) {
+		for _, e := range events {
+			out = append(out, e.EventNonce)
+		}
+		return
+	}
+
+	// events observed in the same block are applied in event nonce order
+	for nonce := uint64(1); nonce <= 3; nonce++ {
+		claim(nonce, 1)
+	}
+	EndBlocker(ctx, k)
+	assert.Equal(t, []uint64{1, 2, 3}, applied())
+	assert.Equal(t, uint64(3), k.GetLastObservedEventNonce(ctx))
+	assert.Equal(t, uint64(3), k.GetLastAppliedEventNonce(ctx))
+
+	// after a rewind a different claim for an applied event is observed but not applied
+	require.NoError(t, k.SetLastObservedEventNonce(ctx, k.GetAuthority(), 1))
+	assert.Equal(t, uint64(3), k.GetLastAppliedEventNonce(ctx))
+	claim(2, 2)
+	claim(3, 1)
+	claim(4, 1)
+	EndBlocker(ctx, k)
+	assert.Equal(t, []uint64{1, 2, 3, 4}, applied())
+	assert.Equal(t, uint64(4), k.GetLastObservedEventNonce(ctx))
+	assert.Equal(t, uint64(4), k.GetLastAppliedEventNonce(ctx))
+
+	// skipping events moves the applied nonce along, the skipped events are never applied
+	require.NoError(t, k.SetLastObservedEventNonce(ctx, k.GetAuthority(), 6))
+	assert.Equal(t, uint64(6), k.GetLastAppliedEventNonce(ctx))
+	claim(7, 1)
+	EndBlocker(ctx, k)
+	assert.Equal(t, []uint64{1, 2, 3, 4, 7}, applied())
+}
+
+func TestMsgValsetUpdatedClaim(t *testing.T) {
+	var (
+		myOrchestratorAddr sdk.AccAddress = make([]byte, sdk.AddrLen)
+		myValAddr                         = sdk.ValAddress(myOrchestratorAddr) // revisit when proper mapping is impl in keeper
+	)
+	input := keeper.CreateTestEnv(t)
+	ctx := input.Context
+	input.PeggyKeeper.StakingKeeper = keeper.NewStakingKeeperMock(myValAddr)
+	input.PeggyKeeper.SetOrchestratorValidator(ctx, myValAddr, myOrchestratorAddr)
+	h := NewHandler(input.PeggyKeeper)
+	ph := NewProposalHandler(input.PeggyKeeper)
+	continuity := keeper.ValsetNonceContinuityInvariant(input.PeggyKeeper)
+
+	// valset nonces are the heights the valsets were created at
+	for height := int64(1); height <= 4; height++ {
+		input.PeggyKeeper.SetValsetRequest(ctx.WithBlockHeight(height))
+	}
+	require.Equal(t, uint64(4), input.PeggyKeeper.GetLatestValsetNonce(ctx))
+
+	eventNonce := uint64(0)
+	relay := func(valsetNonce uint64) {
+		eventNonce++
+		claim := &types.MsgValsetUpdatedClaim{
+			EventNonce:            eventNonce,
+			BlockHeight:           100 + eventNonce,
+			ValsetNonce:           valsetNonce,
+			Orchestrator:          myOrchestratorAddr.String(),
+			BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+			BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
+		}
+		require.NoError(t, claim.ValidateBasic())
+		_, err := h(ctx, claim)
+		require.NoError(t, err)
+		attestationTally(ctx, input.PeggyKeeper)
+	}
+
+	// the first observed valset is the starting point
+	relay(1)
+	assert.Equal(t, uint64(1), input.PeggyKeeper.GetLastObservedValsetNonce(ctx))
+	_, broken := continuity(ctx)
+	assert.False(t, broken)
+
+	// a skipped valset is recorded without halting the chain
+	relay(3)
+	assert.Equal(t, uint64(3), input.PeggyKeeper.GetLastObservedValsetNonce(ctx))
+	assert.Equal(t, []uint64{2}, input.PeggyKeeper.GetSkippedValsetNonces(ctx))
+	_, broken = continuity(ctx)
+	assert.False(t, broken)
+
+	// valsets relayed out of order or not known on cosmos are not applied
+	relay(2)
+	relay(5)
+	assert.Equal(t, uint64(3), input.PeggyKeeper.GetLastObservedValsetNonce(ctx))
+
+	// governance abandons the skipped nonce
+	require.Error(t, ph(ctx, types.NewAbandonValsetNonceProposal("title", "description", 1)))
+	proposal := types.NewAbandonValsetNonceProposal("title", "description", 2)
+	require.NoError(t, proposal.ValidateBasic())
+	require.NoError(t, ph(ctx, proposal))
+	assert.Empty(t, input.PeggyKeeper.GetSkippedValsetNonces(ctx))
+	_, broken = continuity(ctx)
+	assert.False(t, broken)
+
+	// the continuity survives a genesis round trip
+	relay(4)
+	input.PeggyKeeper.SetValsetRequest(ctx.WithBlockHeight(7))
+	input.PeggyKeeper.SetValsetRequest(ctx.WithBlockHeight(9))
+	relay(9)
+	genesis := keeper.ExportGenesis(ctx, input.PeggyKeeper)
+	assert.Equal(t, uint64(9), genesis.LastObservedValsetNonce)
+	assert.Equal(t, []uint64{7}, genesis.SkippedValsetNonces)
+
+	// a skipped nonce past the last observed valset nonce is inconsistent
+	genesis.SkippedValsetNonces = append(genesis.SkippedValsetNonces, 10)
+	keeper.InitGenesis(ctx, input.PeggyKeeper, genesis)
+	_, broken = continuity(ctx)
+	assert.True(t, broken)
+}
+
+func TestTokenDenylist(t *testing.T) {
+	var (
+		myOrchestratorAddr sdk.AccAddress = make([]byte, sdk.AddrLen)
+		myCosmosAddr, _                   = sdk.AccAddressFromBech32("cosmos16ahjkfqxpp6lvfy9fpfnfjg39xr96qett0alj5")
+		myValAddr                         = sdk.ValAddress(myOrchestratorAddr) // revisit when proper mapping is impl in keeper
+		anyETHAddr                        = "0xf9613b532673Cc223aBa451dFA8539B87e1F666D"
+		tokenETHAddr                      = "0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e"
+		denom                             = "gravity0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e"
+	)
+	input := keeper.CreateTestEnv(t)
+	ctx := input.Context
+	input.PeggyKeeper.StakingKeeper = keeper.NewStakingKeeperMock(myValAddr)
+	input.PeggyKeeper.SetOrchestratorValidator(ctx, myValAddr, myOrchestratorAddr)
+	h := NewHandler(input.PeggyKeeper)
+
+	params := input.PeggyKeeper.GetParams(ctx)
+	params.TokenDenylist = []string{"0x0bc529c00C6401aEF6D220BE8C6Ea1667F6Ad93e"}
+	input.PeggyKeeper.SetParams(ctx, params)
+	require.False(t, input.PeggyKeeper.IsTokenAllowed(ctx, tokenETHAddr))
+
+	// a deposit of a denied token is not credited, governance can return it
+	ethClaim := types.MsgDepositClaim{
+		EventNonce:            1,
+		BlockHeight:           5,
+		TokenContract:         tokenETHAddr,
+		Amount:                sdk.NewInt(12),
+		EthereumSender:        anyETHAddr,
+		CosmosReceiver:        myCosmosAddr.String(),
+		Orchestrator:          myOrchestratorAddr.String(),
+		BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+		BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
+	}
+	_, err := h(ctx, &ethClaim)
+	require.NoError(t, err)
+	EndBlocker(ctx, input.PeggyKeeper)
+	assert.True(t, input.BankKeeper.GetAllBalances(ctx, myCosmosAddr).IsZero())
+	deposit := input.PeggyKeeper.GetReclaimableDeposit(ctx, 1)
+	require.NotNil(t, deposit)
+	assert.Equal(t, sdk.NewInt64Coin(denom, 12), deposit.Amount)
+
+	// and it can't be sent to Ethereum
+	vouchers := sdk.Coins{sdk.NewInt64Coin(denom, 100)}
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, vouchers))
+	require.NoError(t, input.BankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, myCosmosAddr, vouchers))
+	msg := &types.MsgSendToEth{
+		Sender:    myCosmosAddr.String(),
+		EthDest:   anyETHAddr,
+		Amount:    sdk.NewInt64Coin(denom, 10),
+		BridgeFee: sdk.NewInt64Coin(denom, 1),
+	}
+	_, err = h(ctx, msg)
+	require.True(t, types.ErrUnsupported.Is(err))
+
+	// an allowlist that doesn't contain the token blocks it as well
+	params.TokenDenylist = nil
+	params.TokenAllowlist = []string{anyETHAddr}
+	input.PeggyKeeper.SetParams(ctx, params)
+	_, err = h(ctx, msg)
+	require.True(t, types.ErrUnsupported.Is(err))
+
+	params.TokenAllowlist = []string{"0x0bc529c00C6401aEF6D220BE8C6Ea1667F6Ad93e"}
+	input.PeggyKeeper.SetParams(ctx, params)
+	_, err = h(ctx, msg)
+	require.NoError(t, err)
 }
 
 func TestMsgDepositClaimsMultiValidator(t *testing.T) {
@@ -197,28 +977,34 @@ func TestMsgDepositClaimsMultiValidator(t *testing.T) {
 	}
 
 	ethClaim1 := types.MsgDepositClaim{
-		EventNonce:     myNonce,
-		TokenContract:  myErc20.Contract,
-		Amount:         myErc20.Amount,
-		EthereumSender: anyETHAddr,
-		CosmosReceiver: myCosmosAddr.String(),
-		Orchestrator:   orchestratorAddr1.String(),
+		EventNonce:            myNonce,
+		TokenContract:         myErc20.Contract,
+		Amount:                myErc20.Amount,
+		EthereumSender:        anyETHAddr,
+		CosmosReceiver:        myCosmosAddr.String(),
+		Orchestrator:          orchestratorAddr1.String(),
+		BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+		BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
 	}
 	ethClaim2 := types.MsgDepositClaim{
-		EventNonce:     myNonce,
-		TokenContract:  myErc20.Contract,
-		Amount:         myErc20.Amount,
-		EthereumSender: anyETHAddr,
-		CosmosReceiver: myCosmosAddr.String(),
-		Orchestrator:   orchestratorAddr2.String(),
+		EventNonce:            myNonce,
+		TokenContract:         myErc20.Contract,
+		Amount:                myErc20.Amount,
+		EthereumSender:        anyETHAddr,
+		CosmosReceiver:        myCosmosAddr.String(),
+		Orchestrator:          orchestratorAddr2.String(),
+		BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+		BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
 	}
 	ethClaim3 := types.MsgDepositClaim{
-		EventNonce:     myNonce,
-		TokenContract:  myErc20.Contract,
-		Amount:         myErc20.Amount,
-		EthereumSender: anyETHAddr,
-		CosmosReceiver: myCosmosAddr.String(),
-		Orchestrator:   orchestratorAddr3.String(),
+		EventNonce:            myNonce,
+		TokenContract:         myErc20.Contract,
+		Amount:                myErc20.Amount,
+		EthereumSender:        anyETHAddr,
+		CosmosReceiver:        myCosmosAddr.String(),
+		Orchestrator:          orchestratorAddr3.String(),
+		BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+		BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
 	}
 
 	// when
@@ -231,7 +1017,7 @@ func TestMsgDepositClaimsMultiValidator(t *testing.T) {
 	require.NotNil(t, a1)
 	// and vouchers not yet added to the account
 	balance1 := input.BankKeeper.GetAllBalances(ctx, myCosmosAddr)
-	assert.NotEqual(t, sdk.Coins{sdk.NewInt64Coin("peggy0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e", 12)}, balance1)
+	assert.NotEqual(t, sdk.Coins{sdk.NewInt64Coin("gravity0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e", 12)}, balance1)
 
 	// when
 	ctx = ctx.WithBlockTime(myBlockTime)
@@ -244,7 +1030,7 @@ func TestMsgDepositClaimsMultiValidator(t *testing.T) {
 	require.NotNil(t, a2)
 	// and vouchers now added to the account
 	balance2 := input.BankKeeper.GetAllBalances(ctx, myCosmosAddr)
-	assert.Equal(t, sdk.Coins{sdk.NewInt64Coin("peggy0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e", 12)}, balance2)
+	assert.Equal(t, sdk.Coins{sdk.NewInt64Coin("gravity0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e", 12)}, balance2)
 
 	// when
 	ctx = ctx.WithBlockTime(myBlockTime)
@@ -257,7 +1043,7 @@ func TestMsgDepositClaimsMultiValidator(t *testing.T) {
 	require.NotNil(t, a3)
 	// and no additional added to the account
 	balance3 := input.BankKeeper.GetAllBalances(ctx, myCosmosAddr)
-	assert.Equal(t, sdk.Coins{sdk.NewInt64Coin("peggy0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e", 12)}, balance3)
+	assert.Equal(t, sdk.Coins{sdk.NewInt64Coin("gravity0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e", 12)}, balance3)
 }
 
 func TestMsgSetOrchestratorAddresses(t *testing.T) {
@@ -283,3 +1069,590 @@ func TestMsgSetOrchestratorAddresses(t *testing.T) {
 
 	assert.Equal(t, input.PeggyKeeper.GetOrchestratorValidator(ctx, cosmosAddress), valAddress)
 }
+
+func TestMsgRevokeOrchestratorAddress(t *testing.T) {
+	var (
+		ethAddress                  = "0xb462864E395d88d6bc7C5dd5F3F5eb4cc2599255"
+		hotOrch      sdk.AccAddress = bytes.Repeat([]byte{0x1}, sdk.AddrLen)
+		coldOrch     sdk.AccAddress = bytes.Repeat([]byte{0x3}, sdk.AddrLen)
+		thirdOrch    sdk.AccAddress = bytes.Repeat([]byte{0x4}, sdk.AddrLen)
+		valAddress   sdk.ValAddress = bytes.Repeat([]byte{0x2}, sdk.AddrLen)
+		otherValAddr sdk.ValAddress = bytes.Repeat([]byte{0x5}, sdk.AddrLen)
+	)
+	input := keeper.CreateTestEnv(t)
+	k := input.PeggyKeeper
+	k.StakingKeeper = keeper.NewStakingKeeperMock(valAddress, otherValAddr)
+	ctx := input.Context
+	h := NewHandler(k)
+	params := k.GetParams(ctx)
+	params.MaxOrchestratorsPerValidator = 2
+	k.SetParams(ctx, params)
+
+	// a validator registers a hot and a cold orchestrator but no more
+	_, err := h(ctx, types.NewMsgSetOrchestratorAddress(valAddress, hotOrch, ethAddress))
+	require.NoError(t, err)
+	_, err = h(ctx, types.NewMsgSetOrchestratorAddress(valAddress, coldOrch, ethAddress))
+	require.NoError(t, err)
+	_, err = h(ctx, types.NewMsgSetOrchestratorAddress(valAddress, thirdOrch, ethAddress))
+	assert.True(t, types.ErrInvalid.Is(err))
+	assert.Equal(t, []sdk.AccAddress{hotOrch, coldOrch}, k.GetValidatorOrchestrators(ctx, valAddress))
+	// registering an orchestrator again only updates the eth address
+	_, err = h(ctx, types.NewMsgSetOrchestratorAddress(valAddress, coldOrch, ethAddress))
+	require.NoError(t, err)
+
+	// another validator can't take over an orchestrator
+	_, err = h(ctx, types.NewMsgSetOrchestratorAddress(otherValAddr, coldOrch, ethAddress))
+	assert.True(t, types.ErrDuplicate.Is(err))
+	_, err = h(ctx, types.NewMsgRevokeOrchestratorAddress(otherValAddr, coldOrch))
+	assert.True(t, types.ErrUnknown.Is(err))
+
+	res, err := k.GetDelegateKeyByValidator(sdk.WrapSDKContext(ctx), &types.QueryDelegateKeysByValidatorAddress{ValidatorAddress: valAddress.String()})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{hotOrch.String(), coldOrch.String()}, res.OrchestratorAddresses)
+	assert.Len(t, k.GetDelegateKeys(ctx), 2)
+
+	// the confirms of the hot orchestrator move to the cold one
+	valsetConfirm := types.MsgValsetConfirm{Nonce: 1, Orchestrator: hotOrch.String(), EthAddress: ethAddress}
+	k.SetValsetConfirm(ctx, valsetConfirm)
+	k.SetBatchConfirm(ctx, &types.MsgConfirmBatch{Nonce: 1, TokenContract: ethAddress, Orchestrator: hotOrch.String()})
+	k.SetLogicCallConfirm(ctx, &types.MsgConfirmLogicCall{InvalidationId: "01", InvalidationNonce: 1, Orchestrator: hotOrch.String()})
+
+	_, err = h(ctx, types.NewMsgRevokeOrchestratorAddress(valAddress, hotOrch))
+	require.NoError(t, err)
+	assert.Empty(t, k.GetOrchestratorValidator(ctx, hotOrch))
+	assert.Equal(t, []sdk.AccAddress{coldOrch}, k.GetValidatorOrchestrators(ctx, valAddress))
+	assert.Nil(t, k.GetValsetConfirm(ctx, 1, hotOrch))
+	assert.Nil(t, k.GetBatchConfirm(ctx, 1, ethAddress, hotOrch))
+	assert.Nil(t, k.GetLogicCallConfirm(ctx, []byte{0x1}, 1, hotOrch))
+	valsetConfirm.Orchestrator = coldOrch.String()
+	assert.Equal(t, &valsetConfirm, k.GetValsetConfirm(ctx, 1, coldOrch))
+	assert.NotNil(t, k.GetBatchConfirm(ctx, 1, ethAddress, coldOrch))
+	assert.NotNil(t, k.GetLogicCallConfirm(ctx, []byte{0x1}, 1, coldOrch))
+	assert.True(t, k.HasValidatorConfirm(ctx, valAddress, &types.MsgValsetConfirm{Nonce: 1}))
+
+	// the last orchestrator stays
+	_, err = h(ctx, types.NewMsgRevokeOrchestratorAddress(valAddress, coldOrch))
+	assert.True(t, types.ErrInvalid.Is(err))
+	assert.Equal(t, valAddress, k.GetOrchestratorValidator(ctx, coldOrch))
+}
+
+func TestConfirmSignatureVerification(t *testing.T) {
+	var (
+		myOrchestratorAddr sdk.AccAddress = make([]byte, sdk.AddrLen)
+		myValAddr                         = sdk.ValAddress(myOrchestratorAddr) // revisit when proper mapping is impl in keeper
+		tokenContract                     = "0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e"
+	)
+	input := keeper.CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	k.StakingKeeper = keeper.NewStakingKeeperMock(myValAddr)
+	k.SetOrchestratorValidator(ctx, myValAddr, myOrchestratorAddr)
+	h := NewHandler(k)
+
+	ethKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	ethAddr := crypto.PubkeyToAddress(ethKey.PublicKey).Hex()
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherAddr := crypto.PubkeyToAddress(otherKey.PublicKey).Hex()
+	k.SetEthAddress(ctx, myValAddr, ethAddr)
+
+	valset := types.NewValset(1, 1, types.BridgeValidators{{Power: 1, EthereumAddress: ethAddr}})
+	k.StoreValsetUnsafe(ctx, valset)
+	checkpoint := valset.GetCheckpoint(k.GetGravityID(ctx))
+	sign := func(key *ecdsa.PrivateKey) string {
+		sig, err := types.NewEthereumSignature(checkpoint, key)
+		require.NoError(t, err)
+		return hex.EncodeToString(sig)
+	}
+	// a signature made for a bridge with another gravity id, e.g. a testnet
+	otherBridgeSig, err := types.NewEthereumSignature(valset.GetCheckpoint("othergravityid"), ethKey)
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		ethAddress string
+		signature  string
+		expErr     bool
+	}{
+		"not hex":              {ethAddress: ethAddr, signature: "alksdjhflkasjdf", expErr: true},
+		"garbage":              {ethAddress: ethAddr, signature: hex.EncodeToString(bytes.Repeat([]byte{1}, 65)), expErr: true},
+		"signed by other key":  {ethAddress: otherAddr, signature: sign(otherKey), expErr: true},
+		"other signer claimed": {ethAddress: otherAddr, signature: sign(ethKey), expErr: true},
+		"other gravity id":     {ethAddress: ethAddr, signature: hex.EncodeToString(otherBridgeSig), expErr: true},
+		"all good":             {ethAddress: ethAddr, signature: sign(ethKey)},
+		"lowercase signer":     {ethAddress: strings.ToLower(ethAddr), signature: sign(ethKey)},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			_, err := h(ctx, types.NewMsgValsetConfirm(1, spec.ethAddress, myOrchestratorAddr, spec.signature))
+			if spec.expErr {
+				require.Error(t, err)
+				assert.Nil(t, k.GetValsetConfirm(ctx, 1, myOrchestratorAddr))
+				return
+			}
+			require.NoError(t, err)
+			// the confirm is stored with the registered spelling the slashing compares with
+			confirm := k.GetValsetConfirm(ctx, 1, myOrchestratorAddr)
+			require.NotNil(t, confirm)
+			assert.Equal(t, ethAddr, confirm.EthAddress)
+		})
+	}
+
+	// batch confirms are verified the same way
+	k.StoreBatchUnsafe(ctx, &types.OutgoingTxBatch{BatchNonce: 1, TokenContract: tokenContract})
+	batchCheckpoint, err := k.GetOutgoingTXBatch(ctx, tokenContract, 1).GetCheckpoint(k.GetGravityID(ctx))
+	require.NoError(t, err)
+	otherSig, err := types.NewEthereumSignature(batchCheckpoint, otherKey)
+	require.NoError(t, err)
+	_, err = h(ctx, &types.MsgConfirmBatch{Nonce: 1, TokenContract: tokenContract, EthSigner: otherAddr, Orchestrator: myOrchestratorAddr.String(), Signature: hex.EncodeToString(otherSig)})
+	require.Error(t, err)
+	sig, err := types.NewEthereumSignature(batchCheckpoint, ethKey)
+	require.NoError(t, err)
+	batchConfirm := &types.MsgConfirmBatch{Nonce: 1, TokenContract: tokenContract, EthSigner: strings.ToLower(ethAddr), Orchestrator: myOrchestratorAddr.String(), Signature: hex.EncodeToString(sig)}
+	res, err := h(ctx, batchConfirm)
+	require.NoError(t, err)
+	storedBatchConfirm := k.GetBatchConfirm(ctx, 1, tokenContract, myOrchestratorAddr)
+	require.NotNil(t, storedBatchConfirm)
+	assert.Equal(t, ethAddr, storedBatchConfirm.EthSigner)
+
+	// the confirm carries the normalized power of the signer, the only validator here
+	var confirmEvents []abci.Event
+	for _, event := range res.Events {
+		if event.Type == types.EventTypeBatchConfirm {
+			confirmEvents = append(confirmEvents, event)
+		}
+	}
+	require.Len(t, confirmEvents, 1)
+	assert.Contains(t, confirmEvents[0].Attributes, abci.EventAttribute{Key: []byte(types.AttributeKeyPower), Value: []byte(fmt.Sprint(uint64(math.MaxUint32)))})
+	assert.Contains(t, confirmEvents[0].Attributes, abci.EventAttribute{Key: []byte(types.AttributeKeyValidator), Value: []byte(myValAddr.String())})
+
+	// a second confirm of the same batch is rejected and does not replace the first
+	_, err = h(ctx, batchConfirm)
+	require.True(t, types.ErrDuplicateBatchConfirm.Is(err))
+	assert.Equal(t, batchConfirm.Signature, k.GetBatchConfirm(ctx, 1, tokenContract, myOrchestratorAddr).Signature)
+
+	// the batch is confirmed for the validator, not only for the orchestrator that sent the confirm
+	otherOrchestratorAddr := sdk.AccAddress(bytes.Repeat([]byte{1}, sdk.AddrLen))
+	k.SetOrchestratorValidator(ctx, myValAddr, otherOrchestratorAddr)
+	otherConfirm := *batchConfirm
+	otherConfirm.Orchestrator = otherOrchestratorAddr.String()
+	_, err = h(ctx, &otherConfirm)
+	require.True(t, types.ErrDuplicateBatchConfirm.Is(err))
+	var unsigned []*types.OutgoingTxBatch
+	k.IterateUnsignedBatches(ctx, otherOrchestratorAddr, func(batch *types.OutgoingTxBatch) bool {
+		unsigned = append(unsigned, batch)
+		return false
+	})
+	assert.Empty(t, unsigned)
+}
+
+func TestMsgsFromJailedValidator(t *testing.T) {
+	input, ctx := keeper.SetupFiveValChain(t)
+	k := input.PeggyKeeper
+	h := NewHandler(k)
+	for i := range keeper.ValAddrs {
+		k.SetOrchestratorValidator(ctx, keeper.ValAddrs[i], keeper.AccAddrs[i])
+	}
+	vs := k.SetValsetRequest(ctx)
+
+	claim := func(orchestrator sdk.AccAddress) *types.MsgDepositClaim {
+		return &types.MsgDepositClaim{
+			EventNonce:            1,
+			BlockHeight:           1,
+			TokenContract:         "0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e",
+			Amount:                sdk.NewInt(12),
+			EthereumSender:        "0xf9613b532673Cc223aBa451dFA8539B87e1F666D",
+			CosmosReceiver:        keeper.AccAddrs[0].String(),
+			Orchestrator:          orchestrator.String(),
+			BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+			BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
+		}
+	}
+	_, err := h(ctx, claim(keeper.AccAddrs[0]))
+	require.NoError(t, err)
+
+	// jailing takes effect right away, the validator is only unbonded by the next staking end blocker
+	val := input.StakingKeeper.Validator(ctx, keeper.ValAddrs[1])
+	consAddr, err := val.GetConsAddr()
+	require.NoError(t, err)
+	input.StakingKeeper.Jail(ctx, consAddr)
+	require.True(t, input.StakingKeeper.Validator(ctx, keeper.ValAddrs[1]).IsBonded())
+
+	_, err = h(ctx, claim(keeper.AccAddrs[1]))
+	require.True(t, sdkerrors.ErrorInvalidSigner.Is(err))
+	_, err = h(ctx, types.NewMsgValsetConfirm(vs.Nonce, keeper.EthAddrs[1].String(), keeper.AccAddrs[1], hex.EncodeToString(make([]byte, 65))))
+	require.True(t, sdkerrors.ErrorInvalidSigner.Is(err))
+	assert.Len(t, k.GetAttestationMapping(ctx)[1], 1)
+}
+
+func TestValsetConfirmFromUnbondingValidator(t *testing.T) {
+	input, ctx := keeper.SetupFiveValChain(t)
+	k := input.PeggyKeeper
+	h := NewHandler(k)
+	params := k.GetParams(ctx)
+	ethKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	ethAddr := crypto.PubkeyToAddress(ethKey.PublicKey).Hex()
+	k.SetOrchestratorValidator(ctx, keeper.ValAddrs[0], keeper.AccAddrs[0])
+	k.SetEthAddress(ctx, keeper.ValAddrs[0], ethAddr)
+
+	// the validators signed from the start height on, the valset is created after
+	height := uint64(ctx.BlockHeight()) + 1
+	ctx = ctx.WithBlockHeight(int64(height))
+	vs := k.GetCurrentValset(ctx)
+	vs.Height, vs.Nonce = height, height
+	k.StoreValsetUnsafe(ctx, vs)
+
+	// the two smallest validators drop out of the active set without being jailed
+	ctx = ctx.WithBlockHeight(int64(height + 1))
+	sh := staking.NewHandler(input.StakingKeeper)
+	for _, val := range keeper.ValAddrs[:2] {
+		_, err = sh(ctx, keeper.NewTestMsgUnDelegateValidator(val, keeper.StakingAmount.QuoRaw(2)))
+		require.NoError(t, err)
+	}
+	stakingParams := input.StakingKeeper.GetParams(ctx)
+	stakingParams.MaxValidators = 3
+	input.StakingKeeper.SetParams(ctx, stakingParams)
+	staking.EndBlocker(ctx, input.StakingKeeper)
+	for _, val := range keeper.ValAddrs[:2] {
+		require.True(t, input.StakingKeeper.Validator(ctx, val).IsUnbonding())
+		require.False(t, input.StakingKeeper.Validator(ctx, val).IsJailed())
+	}
+
+	// the first one still confirms the valset it is slashed for missing
+	sig, err := types.NewEthereumSignature(vs.GetCheckpoint(k.GetGravityID(ctx)), ethKey)
+	require.NoError(t, err)
+	_, err = h(ctx, types.NewMsgValsetConfirm(vs.Nonce, ethAddr, keeper.AccAddrs[0], hex.EncodeToString(sig)))
+	require.NoError(t, err)
+
+	EndBlocker(ctx.WithBlockHeight(int64(vs.Nonce+params.ValsetSlashingWindow()+1)), k)
+	assert.False(t, input.StakingKeeper.Validator(ctx, keeper.ValAddrs[0]).IsJailed())
+	assert.True(t, input.StakingKeeper.Validator(ctx, keeper.ValAddrs[1]).IsJailed())
+
+	// a valset past its unbond slashing window can't be confirmed anymore
+	late := *vs
+	late.Nonce = height + 1 + params.UnbondSlashingValsetsWindow
+	k.StoreValsetUnsafe(ctx, &late)
+	_, err = h(ctx, types.NewMsgValsetConfirm(late.Nonce, ethAddr, keeper.AccAddrs[0], hex.EncodeToString(sig)))
+	require.True(t, sdkerrors.ErrorInvalidSigner.Is(err), err)
+}
+
+func TestMsgRequestBatch(t *testing.T) {
+	var (
+		userCosmosAddr, _ = sdk.AccAddressFromBech32("cosmos1990z7dqsvh8gthw9pa5sn4wuy2xrsd80mg5z6y")
+		tokenContract     = "0xB5E9944950C97acab395a324716D186632789712"
+		denom             = types.PeggyDenom(tokenContract)
+		ethDestination    = "0x3c9289da00b02dC623d0D8D907619890301D26d4"
+		startingCoins     = sdk.Coins{sdk.NewInt64Coin(denom, 1000)}
+	)
+	input := keeper.CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	h := NewHandler(k)
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, startingCoins))
+	require.NoError(t, input.BankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, userCosmosAddr, startingCoins))
+
+	params := k.GetParams(ctx)
+	params.BatchRequestMinFee = sdk.NewInt(10)
+	params.BatchRequestCooldown = 5
+	k.SetParams(ctx, params)
+
+	sendToEth := func(fee int64) {
+		_, err := h(ctx, &types.MsgSendToEth{
+			Sender:    userCosmosAddr.String(),
+			EthDest:   ethDestination,
+			Amount:    sdk.NewInt64Coin(denom, 100),
+			BridgeFee: sdk.NewInt64Coin(denom, fee),
+		})
+		require.NoError(t, err)
+	}
+	// the requester does not have to be a validator
+	request := &types.MsgRequestBatch{Orchestrator: userCosmosAddr.String(), Denom: denom}
+
+	// nothing to batch
+	_, err := h(ctx, request)
+	require.True(t, types.ErrEmpty.Is(err))
+
+	// fees below the minimum
+	sendToEth(4)
+	sendToEth(5)
+	_, err = h(ctx, request)
+	require.True(t, types.ErrInvalid.Is(err))
+
+	sendToEth(1)
+	_, err = h(ctx, request)
+	require.NoError(t, err)
+	require.Len(t, k.GetOutgoingTxBatches(ctx), 1)
+
+	// the token contract cools down
+	sendToEth(10)
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 4)
+	_, err = h(ctx, request)
+	require.True(t, types.ErrInvalid.Is(err))
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	_, err = h(ctx, request)
+	require.NoError(t, err)
+	assert.Len(t, k.GetOutgoingTxBatches(ctx), 2)
+}
+
+func TestMsgUpdateParams(t *testing.T) {
+	var (
+		govAddr             = authtypes.NewModuleAddress(govtypes.ModuleName)
+		myCosmosAddr, _     = sdk.AccAddressFromBech32("cosmos16ahjkfqxpp6lvfy9fpfnfjg39xr96qett0alj5")
+		input               = keeper.CreateTestEnv(t)
+		ctx                 = input.Context
+		h                   = NewHandler(input.PeggyKeeper)
+		params              = input.PeggyKeeper.GetParams(ctx)
+		originalBasisPoints = params.BridgeFeeBasisPoints
+	)
+	params.BridgeFeeBasisPoints = originalBasisPoints + 100
+
+	// only the governance module account may update the params
+	_, err := h(ctx, types.NewMsgUpdateParams(myCosmosAddr, params))
+	assert.True(t, sdkerrors.ErrUnauthorized.Is(err), err)
+	assert.Equal(t, originalBasisPoints, input.PeggyKeeper.GetParams(ctx).BridgeFeeBasisPoints)
+
+	// the params are checked as a whole
+	invalid := params
+	invalid.GravityId = ""
+	msg := types.NewMsgUpdateParams(govAddr, invalid)
+	require.Error(t, msg.ValidateBasic())
+	_, err = h(ctx, msg)
+	require.Error(t, err)
+
+	// the ERC20 init code hash only changes with the bridge contract
+	invalid = params
+	invalid.Erc20InitCodeHash = "0x" + strings.Repeat("ab", 32)
+	_, err = h(ctx, types.NewMsgUpdateParams(govAddr, invalid))
+	assert.True(t, types.ErrInvalid.Is(err), err)
+
+	msg = types.NewMsgUpdateParams(govAddr, params)
+	require.NoError(t, msg.ValidateBasic())
+	assert.Equal(t, []sdk.AccAddress{govAddr}, msg.GetSigners())
+	_, err = h(ctx, msg)
+	require.NoError(t, err)
+	assert.Equal(t, params, input.PeggyKeeper.GetParams(ctx))
+}
+
+func TestMsgRegisterRelayer(t *testing.T) {
+	var (
+		relayerAddr, _ = sdk.AccAddressFromBech32("cosmos1990z7dqsvh8gthw9pa5sn4wuy2xrsd80mg5z6y")
+		otherAddr, _   = sdk.AccAddressFromBech32("cosmos16ahjkfqxpp6lvfy9fpfnfjg39xr96qett0alj5")
+		input          = keeper.CreateTestEnv(t)
+		ctx            = input.Context
+		k              = input.PeggyKeeper
+		h              = NewHandler(k)
+		denom          = types.PeggyDenom("0xB5E9944950C97acab395a324716D186632789712")
+	)
+	ethKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	ethAddress := crypto.PubkeyToAddress(ethKey.PublicKey).Hex()
+	sign := func(gravityID string, relayer sdk.AccAddress) []byte {
+		sig, err := types.NewEthereumSignature(types.RelayerRegistrationHash(gravityID, relayer), ethKey)
+		require.NoError(t, err)
+		return sig
+	}
+
+	// the signature has to cover the registering account and the gravity id
+	_, err = h(ctx, types.NewMsgRegisterRelayer(relayerAddr, ethAddress, sign(k.GetGravityID(ctx), otherAddr)))
+	assert.True(t, types.ErrInvalid.Is(err), err)
+	_, err = h(ctx, types.NewMsgRegisterRelayer(relayerAddr, ethAddress, sign("othergravityid", relayerAddr)))
+	assert.True(t, types.ErrInvalid.Is(err), err)
+	assert.Empty(t, k.GetAllRelayers(ctx))
+
+	// batch requests are restricted to registered relayers
+	params := k.GetParams(ctx)
+	params.RelayerAllowlist = true
+	params.RelayerAllowlistBatchRequests = true
+	k.SetParams(ctx, params)
+	request := &types.MsgRequestBatch{Orchestrator: relayerAddr.String(), Denom: denom}
+	_, err = h(ctx, request)
+	assert.True(t, types.ErrRelayerNotRegistered.Is(err), err)
+
+	msg := types.NewMsgRegisterRelayer(relayerAddr, ethAddress, sign(k.GetGravityID(ctx), relayerAddr))
+	require.NoError(t, msg.ValidateBasic())
+	_, err = h(ctx, msg)
+	require.NoError(t, err)
+	assert.Equal(t, []types.RegisteredRelayer{{EthAddress: strings.ToLower(ethAddress), Relayer: relayerAddr.String()}}, k.GetAllRelayers(ctx))
+
+	// once registered the request gets past the allowlist, there is just nothing to batch
+	_, err = h(ctx, request)
+	assert.True(t, types.ErrEmpty.Is(err), err)
+}
+
+func TestMsgCancelBatch(t *testing.T) {
+	var (
+		mySender, _         = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		otherAddr, _        = sdk.AccAddressFromBech32("cosmos16ahjkfqxpp6lvfy9fpfnfjg39xr96qett0alj5")
+		myReceiver          = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		allVouchers         = sdk.Coins{types.NewERC20Token(1000, myTokenContractAddr).PeggyCoin()}
+		input               = keeper.CreateTestEnv(t)
+		ctx                 = input.Context
+		k                   = input.PeggyKeeper
+		h                   = NewHandler(k)
+	)
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, allVouchers))
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+	require.NoError(t, input.BankKeeper.SetBalances(ctx, mySender, allVouchers))
+	amount := types.NewERC20Token(100, myTokenContractAddr).PeggyCoin()
+	_, err := k.AddToOutgoingPool(ctx, mySender, myReceiver, amount, amount)
+	require.NoError(t, err)
+	_, err = h(ctx, &types.MsgRequestBatch{Orchestrator: mySender.String(), Denom: amount.Denom})
+	require.NoError(t, err)
+	batch := k.GetOutgoingTxBatches(ctx)[0]
+	params := k.GetParams(ctx)
+	params.BatchCancelGracePeriod = 10
+	k.SetParams(ctx, params)
+
+	// only the requester can cancel, once the grace period passed
+	_, err = h(ctx, types.NewMsgCancelBatch(otherAddr, myTokenContractAddr, batch.BatchNonce))
+	assert.True(t, sdkerrors.ErrUnauthorized.Is(err), err)
+	_, err = h(ctx, types.NewMsgCancelBatch(mySender, myTokenContractAddr, batch.BatchNonce))
+	assert.True(t, sdkerrors.ErrUnauthorized.Is(err), err)
+
+	msg := types.NewMsgCancelBatch(mySender, myTokenContractAddr, batch.BatchNonce)
+	require.NoError(t, msg.ValidateBasic())
+	_, err = h(ctx.WithBlockHeight(int64(batch.Block+10)), msg)
+	require.NoError(t, err)
+	assert.Empty(t, k.GetOutgoingTxBatches(ctx))
+	assert.Len(t, k.GetPoolTransactions(ctx), 1)
+}
+
+func TestMsgExecSendToEth(t *testing.T) {
+	var (
+		custodian, _   = sdk.AccAddressFromBech32("cosmos1990z7dqsvh8gthw9pa5sn4wuy2xrsd80mg5z6y")
+		hotKey, _      = sdk.AccAddressFromBech32("cosmos16ahjkfqxpp6lvfy9fpfnfjg39xr96qett0alj5")
+		denom          = "gravity0xB5E9944950C97acab395a324716D186632789712"
+		ethDestination = "0x3c9289da00b02dC623d0D8D907619890301D26d4"
+		otherEthDest   = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		startingCoins  = sdk.Coins{sdk.NewInt64Coin(denom, 1000)}
+		input          = keeper.CreateTestEnv(t)
+		ctx            = input.Context
+		k              = input.PeggyKeeper
+		h              = NewHandler(k)
+	)
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, startingCoins))
+	require.NoError(t, input.BankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, custodian, startingCoins))
+	send := func(dest string, amount, fee int64) *types.MsgSendToEth {
+		return &types.MsgSendToEth{
+			Sender:    custodian.String(),
+			EthDest:   dest,
+			Amount:    sdk.NewInt64Coin(denom, amount),
+			BridgeFee: sdk.NewInt64Coin(denom, fee),
+		}
+	}
+	exec := func(msgs ...sdk.Msg) error {
+		msg, err := types.NewMsgExec(hotKey, msgs)
+		require.NoError(t, err)
+		require.NoError(t, msg.ValidateBasic())
+		_, err = h(ctx, msg)
+		return err
+	}
+	sendToEthURL := types.MsgTypeURL(&types.MsgSendToEth{})
+
+	// without a grant the hot key can't send for the custodian
+	err := exec(send(ethDestination, 10, 1))
+	assert.True(t, sdkerrors.ErrUnauthorized.Is(err), err)
+
+	grant, err := types.NewMsgGrant(custodian, hotKey, types.NewSendToEthAuthorization(sdk.Coins{sdk.NewInt64Coin(denom, 100)}, []string{ethDestination}), 0)
+	require.NoError(t, err)
+	require.NoError(t, grant.ValidateBasic())
+	_, err = h(ctx, grant)
+	require.NoError(t, err)
+
+	// the transfers are capped by the spend limit and the destinations
+	err = exec(send(otherEthDest, 10, 1))
+	assert.True(t, sdkerrors.ErrUnauthorized.Is(err), err)
+	require.NoError(t, exec(send(ethDestination, 50, 10)))
+	assert.Len(t, k.GetPoolTransactions(ctx), 1)
+	err = exec(send(ethDestination, 40, 1))
+	assert.True(t, sdkerrors.ErrInsufficientFunds.Is(err), err)
+
+	// the grants survive an export
+	genesis := keeper.ExportGenesis(ctx, k)
+	require.Len(t, genesis.Grants, 1)
+	restarted := keeper.CreateTestEnv(t)
+	keeper.InitGenesis(restarted.Context, restarted.PeggyKeeper, genesis)
+	assert.NotNil(t, restarted.PeggyKeeper.GetGrant(restarted.Context, custodian, hotKey, sendToEthURL))
+
+	// the grant is removed once spent
+	require.NoError(t, exec(send(ethDestination, 30, 10)))
+	assert.Nil(t, k.GetGrant(ctx, custodian, hotKey, sendToEthURL))
+	assert.Equal(t, sdk.Coins{sdk.NewInt64Coin(denom, 900)}, input.BankKeeper.GetAllBalances(ctx, custodian))
+
+	// a generic grant covers any message of its type until it is revoked
+	grant, err = types.NewMsgGrant(custodian, hotKey, types.NewGenericAuthorization(sendToEthURL), 0)
+	require.NoError(t, err)
+	_, err = h(ctx, grant)
+	require.NoError(t, err)
+	require.NoError(t, exec(send(otherEthDest, 10, 1)))
+	res, err := k.Grants(sdk.WrapSDKContext(ctx), &types.QueryGrantsRequest{Granter: custodian.String(), Grantee: hotKey.String()})
+	require.NoError(t, err)
+	assert.Len(t, res.Grants, 1)
+	_, err = h(ctx, types.NewMsgRevoke(custodian, hotKey, sendToEthURL))
+	require.NoError(t, err)
+	err = exec(send(otherEthDest, 10, 1))
+	assert.True(t, sdkerrors.ErrUnauthorized.Is(err), err)
+
+	// an expired grant can't be used
+	grant, err = types.NewMsgGrant(custodian, hotKey, types.NewGenericAuthorization(sendToEthURL), uint64(ctx.BlockTime().Unix()+10))
+	require.NoError(t, err)
+	_, err = h(ctx, grant)
+	require.NoError(t, err)
+	err = exec(send(otherEthDest, 10, 1))
+	require.NoError(t, err)
+	ctx = ctx.WithBlockTime(ctx.BlockTime().Add(10 * time.Second))
+	err = exec(send(otherEthDest, 10, 1))
+	assert.True(t, sdkerrors.ErrUnauthorized.Is(err), err)
+
+	// grants can't be changed through an exec
+	err = exec(types.NewMsgRevoke(hotKey, custodian, sendToEthURL))
+	assert.True(t, sdkerrors.ErrUnknownRequest.Is(err), err)
+}
+
+func TestMsgMultiSendToEth(t *testing.T) {
+	var (
+		mySender, _    = sdk.AccAddressFromBech32("cosmos1990z7dqsvh8gthw9pa5sn4wuy2xrsd80mg5z6y")
+		denomA         = "gravity0xB5E9944950C97acab395a324716D186632789712"
+		denomB         = "gravity0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		ethDestination = "0x3c9289da00b02dC623d0D8D907619890301D26d4"
+		startingCoins  = sdk.NewCoins(sdk.NewInt64Coin(denomA, 100), sdk.NewInt64Coin(denomB, 100))
+		input          = keeper.CreateTestEnv(t)
+		ctx            = input.Context
+		k              = input.PeggyKeeper
+		h              = NewHandler(k)
+	)
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, startingCoins))
+	require.NoError(t, input.BankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, mySender, startingCoins))
+	entry := func(denom string, amount, fee int64) types.SendToEthEntry {
+		return types.SendToEthEntry{
+			EthDest:   ethDestination,
+			Amount:    sdk.NewInt64Coin(denom, amount),
+			BridgeFee: sdk.NewInt64Coin(denom, fee),
+		}
+	}
+
+	invalid := types.NewMsgMultiSendToEth(mySender, []types.SendToEthEntry{entry(denomA, 10, 1), entry(denomA, 0, 1)})
+	assert.Error(t, invalid.ValidateBasic())
+	assert.Error(t, types.NewMsgMultiSendToEth(mySender, nil).ValidateBasic())
+
+	// a transfer the sender can't pay for fails the whole message
+	msg := types.NewMsgMultiSendToEth(mySender, []types.SendToEthEntry{entry(denomA, 50, 1), entry(denomB, 100, 1)})
+	require.NoError(t, msg.ValidateBasic())
+	_, err := h(ctx, msg)
+	require.Error(t, err)
+	assert.Empty(t, k.GetPoolTransactions(ctx))
+	assert.Equal(t, startingCoins, input.BankKeeper.GetAllBalances(ctx, mySender))
+
+	msg = types.NewMsgMultiSendToEth(mySender, []types.SendToEthEntry{entry(denomA, 50, 1), entry(denomB, 20, 2), entry(denomA, 10, 3)})
+	res, err := h(ctx, msg)
+	require.NoError(t, err)
+	var resp types.MsgMultiSendToEthResponse
+	require.NoError(t, resp.Unmarshal(res.Data))
+	require.Len(t, resp.TransactionIds, 3)
+	assert.Len(t, k.GetPoolTransactions(ctx), 3)
+	exp := sdk.NewCoins(sdk.NewInt64Coin(denomA, 36), sdk.NewInt64Coin(denomB, 78))
+	assert.Equal(t, exp, input.BankKeeper.GetAllBalances(ctx, mySender))
+}
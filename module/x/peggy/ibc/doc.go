@@ -0,0 +1,19 @@
+/*
+Package ibc lets other chains withdraw to Ethereum through this chain with a single ICS-20 transfer.
+
+Middleware wraps the ICS-20 transfer module of the IBC router. ICS-20 packets have no memo field yet,
+so a transfer is forwarded to Ethereum when its receiver is a forward receiver
+
+	<receiver>|<eth_dest>|<bridge_fee>
+
+The tokens are credited to receiver like any other transfer and a SendToEth of receiver to eth_dest
+is added to the outgoing pool right away, paying bridge_fee of the received tokens as bridge fee.
+Canceling the SendToEth refunds receiver. If the SendToEth fails, for example because the token is
+not bridged, the packet is acknowledged with an error and nothing is credited, so the sending chain
+refunds the sender. The transfer keeper must be built with keeper.NewRestrictedBankKeeper, so that
+RestrictVoucherTransfers applies to ICS-20 transfers: vouchers can't be sent out through IBC, and
+received ones are neither credited nor forwarded. Wire the middleware in place of the transfer module with
+
+	ibcRouter.AddRoute(ibctransfertypes.ModuleName, peggyibc.NewMiddleware(transferModule, app.peggyKeeper))
+*/
+package ibc
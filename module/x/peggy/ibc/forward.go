@@ -0,0 +1,44 @@
+package ibc
+
+import (
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// forwardSeparator separates the parts of a forward receiver, it can't appear in a bech32 address
+const forwardSeparator = "|"
+
+// Forward is a transfer to Ethereum requested by the receiver of an ICS-20 packet
+type Forward struct {
+	Receiver  sdk.AccAddress
+	EthDest   string
+	BridgeFee sdk.Int
+}
+
+// IsForwardReceiver returns true if the receiver of an ICS-20 packet asks for a transfer to Ethereum
+func IsForwardReceiver(receiver string) bool {
+	return strings.Contains(receiver, forwardSeparator)
+}
+
+// ParseForwardReceiver parses a <receiver>|<eth_dest>|<bridge_fee> receiver
+func ParseForwardReceiver(receiver string) (Forward, error) {
+	parts := strings.Split(receiver, forwardSeparator)
+	if len(parts) != 3 {
+		return Forward{}, sdkerrors.Wrapf(types.ErrInvalid, "forward receiver %q must be <receiver>|<eth_dest>|<bridge_fee>", receiver)
+	}
+	addr, err := sdk.AccAddressFromBech32(parts[0])
+	if err != nil {
+		return Forward{}, sdkerrors.Wrap(err, "receiver")
+	}
+	if err := types.ValidateEthAddress(parts[1]); err != nil {
+		return Forward{}, sdkerrors.Wrap(err, "eth dest")
+	}
+	fee, ok := sdk.NewIntFromString(parts[2])
+	if !ok || fee.IsNegative() {
+		return Forward{}, sdkerrors.Wrapf(types.ErrInvalid, "bridge fee %q", parts[2])
+	}
+	return Forward{Receiver: addr, EthDest: parts[1], BridgeFee: fee}, nil
+}
@@ -0,0 +1,140 @@
+package ibc
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	transfertypes "github.com/cosmos/cosmos-sdk/x/ibc/applications/transfer/types"
+	clienttypes "github.com/cosmos/cosmos-sdk/x/ibc/core/02-client/types"
+	channeltypes "github.com/cosmos/cosmos-sdk/x/ibc/core/04-channel/types"
+	porttypes "github.com/cosmos/cosmos-sdk/x/ibc/core/05-port/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/keeper"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// creditingModule stands in for the transfer module and credits every received transfer from the
+// escrow account of the channel, through the bank keeper the app hands to the transfer keeper
+type creditingModule struct {
+	porttypes.IBCModule
+	bank keeper.RestrictedBankKeeper
+}
+
+func (m creditingModule) OnRecvPacket(ctx sdk.Context, packet channeltypes.Packet) (*sdk.Result, []byte, error) {
+	var data transfertypes.FungibleTokenPacketData
+	transfertypes.ModuleCdc.MustUnmarshalJSON(packet.GetData(), &data)
+	receiver, err := sdk.AccAddressFromBech32(data.Receiver)
+	if err != nil {
+		return &sdk.Result{}, channeltypes.NewErrorAcknowledgement(err.Error()).GetBytes(), nil
+	}
+	coins := sdk.NewCoins(sdk.NewCoin(receivedDenom(packet, data), sdk.NewIntFromUint64(data.Amount)))
+	escrow := transfertypes.GetEscrowAddress(packet.GetDestPort(), packet.GetDestChannel())
+	if err := m.bank.MintCoins(ctx, types.ModuleName, coins); err != nil {
+		return nil, nil, err
+	}
+	if err := m.bank.SendCoinsFromModuleToAccount(ctx, types.ModuleName, escrow, coins); err != nil {
+		return nil, nil, err
+	}
+	if err := m.bank.SendCoins(ctx, escrow, receiver, coins); err != nil {
+		return &sdk.Result{}, channeltypes.NewErrorAcknowledgement(err.Error()).GetBytes(), nil
+	}
+	return &sdk.Result{}, channeltypes.NewResultAcknowledgement([]byte{1}).GetBytes(), nil
+}
+
+func TestParseForwardReceiver(t *testing.T) {
+	receiver := sdk.AccAddress([]byte("receiver____________"))
+	specs := map[string]struct {
+		src    string
+		exp    Forward
+		expErr bool
+	}{
+		"forward": {
+			src: receiver.String() + "|0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7|5",
+			exp: Forward{Receiver: receiver, EthDest: "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7", BridgeFee: sdk.NewInt(5)},
+		},
+		"missing fee": {
+			src:    receiver.String() + "|0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7",
+			expErr: true,
+		},
+		"negative fee": {
+			src:    receiver.String() + "|0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7|-1",
+			expErr: true,
+		},
+		"invalid receiver": {
+			src:    "cosmos1invalid|0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7|5",
+			expErr: true,
+		},
+		"invalid eth dest": {
+			src:    receiver.String() + "|0xinvalid|5",
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			got, err := ParseForwardReceiver(spec.src)
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestMiddlewareOnRecvPacket(t *testing.T) {
+	input := keeper.CreateTestEnv(t)
+	ctx := input.Context
+	bank := keeper.NewRestrictedBankKeeper(input.BankKeeper, input.PeggyKeeper)
+	middleware := NewMiddleware(creditingModule{bank: bank}, input.PeggyKeeper)
+	var (
+		sender        = "osmo1sender"
+		receiver      = sdk.AccAddress([]byte("receiver____________"))
+		ethDest       = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		tokenContract = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		voucher       = types.NewERC20Token(1, tokenContract).PeggyCoin().Denom
+	)
+	// the vouchers left this chain through channel-0 and come back from the counterparty channel-7
+	packet := func(denom string, amount uint64, receiver string) channeltypes.Packet {
+		data := transfertypes.NewFungibleTokenPacketData(denom, amount, sender, receiver)
+		return channeltypes.NewPacket(data.GetBytes(), 1, "transfer", "channel-7", "transfer", "channel-0", clienttypes.NewHeight(0, 100), 0)
+	}
+	recv := func(p channeltypes.Packet) bool {
+		_, ack, err := middleware.OnRecvPacket(ctx, p)
+		require.NoError(t, err)
+		return isSuccessAck(ack)
+	}
+
+	// a plain receiver is credited like any other transfer
+	require.True(t, recv(packet("transfer/channel-7/"+voucher, 10, receiver.String())))
+	assert.Equal(t, sdk.NewInt(10), input.BankKeeper.GetBalance(ctx, receiver, voucher).Amount)
+	assert.Empty(t, input.PeggyKeeper.GetPoolTransactions(ctx))
+
+	// a forward receiver is sent on to Ethereum
+	require.True(t, recv(packet("transfer/channel-7/"+voucher, 100, receiver.String()+"|"+ethDest+"|3")))
+	assert.Equal(t, sdk.NewInt(10), input.BankKeeper.GetBalance(ctx, receiver, voucher).Amount)
+	pool := input.PeggyKeeper.GetPoolTransactions(ctx)
+	require.Len(t, pool, 1)
+	assert.Equal(t, receiver.String(), pool[0].Sender)
+	assert.Equal(t, ethDest, pool[0].DestAddress)
+	assert.Equal(t, sdk.NewInt(97), pool[0].Erc20Token.Amount)
+	assert.Equal(t, sdk.NewInt(3), pool[0].Erc20Fee.Amount)
+
+	// failing forwards are acknowledged with an error and credit nothing
+	assert.False(t, recv(packet("transfer/channel-7/"+voucher, 2, receiver.String()+"|"+ethDest+"|3")))
+	assert.False(t, recv(packet("transfer/channel-7/stake", 100, receiver.String()+"|"+ethDest+"|3")))
+	assert.False(t, recv(packet("transfer/channel-7/"+voucher, 100, receiver.String()+"|"+ethDest)))
+	assert.Equal(t, sdk.NewInt(10), input.BankKeeper.GetBalance(ctx, receiver, voucher).Amount)
+	assert.True(t, input.BankKeeper.GetBalance(ctx, receiver, "stake").IsZero())
+	assert.Len(t, input.PeggyKeeper.GetPoolTransactions(ctx), 1)
+
+	// restricted vouchers are neither credited nor forwarded
+	params := input.PeggyKeeper.GetParams(ctx)
+	params.RestrictVoucherTransfers = true
+	input.PeggyKeeper.SetParams(ctx, params)
+	assert.False(t, recv(packet("transfer/channel-7/"+voucher, 10, receiver.String())))
+	assert.False(t, recv(packet("transfer/channel-7/"+voucher, 100, receiver.String()+"|"+ethDest+"|3")))
+	assert.Equal(t, sdk.NewInt(10), input.BankKeeper.GetBalance(ctx, receiver, voucher).Amount)
+	assert.Len(t, input.PeggyKeeper.GetPoolTransactions(ctx), 1)
+}
@@ -0,0 +1,112 @@
+package ibc
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	transfertypes "github.com/cosmos/cosmos-sdk/x/ibc/applications/transfer/types"
+	channeltypes "github.com/cosmos/cosmos-sdk/x/ibc/core/04-channel/types"
+	porttypes "github.com/cosmos/cosmos-sdk/x/ibc/core/05-port/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/keeper"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+var _ porttypes.IBCModule = Middleware{}
+
+// Middleware wraps the ICS-20 transfer module and adds a SendToEth for every received transfer
+// with a forward receiver, all other callbacks are passed through
+type Middleware struct {
+	porttypes.IBCModule
+	keeper keeper.Keeper
+}
+
+// NewMiddleware returns the middleware wrapping the transfer module
+func NewMiddleware(transfer porttypes.IBCModule, k keeper.Keeper) Middleware {
+	return Middleware{IBCModule: transfer, keeper: k}
+}
+
+// OnRecvPacket credits the transfer to the receiver of a forward receiver and sends it on to
+// Ethereum. Both happen on a cache of the store that is only written when both succeeded, a
+// failing forward is acknowledged with an error so that the sending chain refunds the sender.
+func (m Middleware) OnRecvPacket(ctx sdk.Context, packet channeltypes.Packet) (*sdk.Result, []byte, error) {
+	var data transfertypes.FungibleTokenPacketData
+	if err := transfertypes.ModuleCdc.UnmarshalJSON(packet.GetData(), &data); err != nil || !IsForwardReceiver(data.Receiver) {
+		return m.IBCModule.OnRecvPacket(ctx, packet)
+	}
+	forward, err := ParseForwardReceiver(data.Receiver)
+	if err != nil {
+		return errorAck(ctx, err)
+	}
+
+	xCtx, commit := ctx.CacheContext()
+	data.Receiver = forward.Receiver.String()
+	packet.Data = transfertypes.ModuleCdc.MustMarshalJSON(&data)
+	res, ack, err := m.IBCModule.OnRecvPacket(xCtx, packet)
+	if err != nil || !isSuccessAck(ack) {
+		return res, ack, err
+	}
+
+	denom := receivedDenom(packet, data)
+	amount := sdk.NewIntFromUint64(data.Amount)
+	if forward.BridgeFee.GT(amount) {
+		return errorAck(ctx, sdkerrors.Wrapf(types.ErrInvalid, "bridge fee %s above the transferred %s", forward.BridgeFee, amount))
+	}
+	msg := types.NewMsgSendToEth(forward.Receiver, forward.EthDest,
+		sdk.NewCoin(denom, amount.Sub(forward.BridgeFee)), sdk.NewCoin(denom, forward.BridgeFee))
+	if err := msg.ValidateBasic(); err != nil {
+		return errorAck(ctx, err)
+	}
+	if _, err := keeper.NewMsgServerImpl(m.keeper).SendToEth(sdk.WrapSDKContext(xCtx), msg); err != nil {
+		return errorAck(ctx, err)
+	}
+
+	commit()
+	ctx.EventManager().EmitEvents(xCtx.EventManager().Events())
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeIBCSendToEth,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(sdk.AttributeKeySender, data.Sender),
+			sdk.NewAttribute(types.AttributeKeyReceiver, forward.Receiver.String()),
+			sdk.NewAttribute(types.AttributeKeyEthDest, forward.EthDest),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.String()),
+		),
+	)
+	return &sdk.Result{Events: ctx.EventManager().Events().ToABCIEvents()}, ack, nil
+}
+
+// receivedDenom returns the denom the transfer module credits for the packet, the same as the
+// transfer keeper derives it
+func receivedDenom(packet channeltypes.Packet, data transfertypes.FungibleTokenPacketData) string {
+	if transfertypes.ReceiverChainIsSource(packet.GetSourcePort(), packet.GetSourceChannel(), data.Denom) {
+		voucherPrefix := transfertypes.GetDenomPrefix(packet.GetSourcePort(), packet.GetSourceChannel())
+		denomTrace := transfertypes.ParseDenomTrace(data.Denom[len(voucherPrefix):])
+		if denomTrace.Path == "" {
+			return denomTrace.BaseDenom
+		}
+		return denomTrace.IBCDenom()
+	}
+	prefixedDenom := transfertypes.GetDenomPrefix(packet.GetDestPort(), packet.GetDestChannel()) + data.Denom
+	return transfertypes.ParseDenomTrace(prefixedDenom).IBCDenom()
+}
+
+func isSuccessAck(bz []byte) bool {
+	var ack channeltypes.Acknowledgement
+	if err := channeltypes.SubModuleCdc.UnmarshalJSON(bz, &ack); err != nil {
+		return false
+	}
+	_, ok := ack.Response.(*channeltypes.Acknowledgement_Result)
+	return ok
+}
+
+func errorAck(ctx sdk.Context, err error) (*sdk.Result, []byte, error) {
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeIBCSendToEthFailed,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(types.AttributeKeyError, err.Error()),
+		),
+	)
+	return &sdk.Result{
+		Events: ctx.EventManager().Events().ToABCIEvents(),
+	}, channeltypes.NewErrorAcknowledgement(err.Error()).GetBytes(), nil
+}
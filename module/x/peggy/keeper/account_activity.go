@@ -0,0 +1,152 @@
+package keeper
+
+import (
+	"bytes"
+	"math"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// recordAccountActivity adds an entry to the bridge ledger of an account at the current height, zero
+// amounts aren't recorded
+func (k Keeper) recordAccountActivity(ctx sdk.Context, account sdk.AccAddress, activity types.AccountActivity) {
+	if !activity.Amount.IsPositive() {
+		return
+	}
+	activity.Id = k.autoIncrementID(ctx, types.KeyLastAccountActivityID)
+	activity.Account = account.String()
+	activity.Height = uint64(ctx.BlockHeight())
+	activity.Time = uint64(ctx.BlockTime().Unix())
+	k.setAccountActivity(ctx, account, activity)
+}
+
+// recordAccountActivities records an entry of the given type for each of the coins
+func (k Keeper) recordAccountActivities(ctx sdk.Context, account sdk.AccAddress, activityType types.AccountActivityType, coins sdk.Coins) {
+	for _, coin := range coins {
+		k.recordAccountActivity(ctx, account, types.AccountActivity{Type: activityType, Amount: coin})
+	}
+}
+
+func (k Keeper) setAccountActivity(ctx sdk.Context, account sdk.AccAddress, activity types.AccountActivity) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetAccountActivityKey(account, activity.Height, activity.Id), k.cdc.MustMarshalBinaryBare(&activity))
+	store.Set(types.GetAccountActivityByHeightKey(activity.Height, activity.Id), account.Bytes())
+}
+
+// PruneAccountActivities deletes the ledger entries older than the AccountActivityRetention param, oldest
+// first, until the work budget of the context is spent. A zero retention keeps the entries forever.
+func (k Keeper) PruneAccountActivities(ctx sdk.Context) {
+	retention := k.GetParams(ctx).AccountActivityRetention
+	if retention == 0 || uint64(ctx.BlockHeight()) <= retention {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	end := types.UInt64Bytes(uint64(ctx.BlockHeight()) - retention)
+	for pruned := 0; pruned == 0 || !WorkBudgetExhausted(ctx); pruned++ {
+		iter := prefix.NewStore(store, types.AccountActivityByHeightKey).Iterator(nil, end)
+		if !iter.Valid() {
+			iter.Close()
+			return
+		}
+		key := append([]byte{}, iter.Key()...)
+		account := sdk.AccAddress(append([]byte{}, iter.Value()...))
+		iter.Close()
+
+		height, id := types.UInt64FromBytes(key[:8]), types.UInt64FromBytes(key[8:])
+		store.Delete(types.GetAccountActivityKey(account, height, id))
+		store.Delete(types.GetAccountActivityByHeightKey(height, id))
+	}
+}
+
+// IterateAccountActivities iterates over the ledgers of all accounts, grouped by account and in the
+// order the entries were recorded for each
+func (k Keeper) IterateAccountActivities(ctx sdk.Context, cb func(types.AccountActivity) bool) {
+	iter := prefix.NewStore(ctx.KVStore(k.storeKey), types.AccountActivityKey).Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var activity types.AccountActivity
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &activity)
+		// cb returns true to stop early
+		if cb(activity) {
+			break
+		}
+	}
+}
+
+// GetAccountActivities returns the ledgers of all accounts
+func (k Keeper) GetAccountActivities(ctx sdk.Context) (out []types.AccountActivity) {
+	k.IterateAccountActivities(ctx, func(activity types.AccountActivity) bool {
+		out = append(out, activity)
+		return false
+	})
+	return
+}
+
+// PaginateAccountActivity returns a page of the ledger of an account between the heights, both inclusive,
+// in the order the entries were recorded. A zero toHeight leaves the range open. The page key is the store
+// key of the next entry below the prefix of the account.
+func (k Keeper) PaginateAccountActivity(ctx sdk.Context, account sdk.AccAddress, fromHeight, toHeight uint64, pageReq *query.PageRequest) ([]types.AccountActivity, *query.PageResponse, error) {
+	if pageReq == nil {
+		pageReq = &query.PageRequest{}
+	}
+	if pageReq.Offset > 0 && pageReq.Key != nil {
+		return nil, nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "invalid request, either offset or key is expected, got both")
+	}
+	if toHeight != 0 && toHeight < fromHeight {
+		return nil, nil, sdkerrors.Wrapf(types.ErrInvalid, "to height %d is below from height %d", toHeight, fromHeight)
+	}
+	limit := pageReq.Limit
+	if limit == 0 {
+		limit = query.DefaultLimit
+	}
+	if limit > MaxPageLimit {
+		limit = MaxPageLimit
+	}
+
+	start, end := types.UInt64Bytes(fromHeight), []byte(nil)
+	if toHeight != 0 && toHeight != math.MaxUint64 {
+		end = types.UInt64Bytes(toHeight + 1)
+	}
+	if pageReq.Key != nil {
+		if bytes.Compare(pageReq.Key, start) < 0 || (end != nil && bytes.Compare(pageReq.Key, end) >= 0) {
+			return nil, nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "pagination key out of the height range")
+		}
+		start = pageReq.Key
+	}
+	iter := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetAccountActivityPrefix(account)).Iterator(start, end)
+	defer iter.Close()
+
+	var (
+		activities []types.AccountActivity
+		count      uint64
+		res        = &query.PageResponse{}
+	)
+	for ; iter.Valid(); iter.Next() {
+		count++
+		switch {
+		case count <= pageReq.Offset:
+			continue
+		case uint64(len(activities)) < limit:
+			var activity types.AccountActivity
+			if err := k.cdc.UnmarshalBinaryBare(iter.Value(), &activity); err != nil {
+				return nil, nil, err
+			}
+			activities = append(activities, activity)
+			continue
+		case res.NextKey == nil:
+			res.NextKey = append([]byte{}, iter.Key()...)
+		}
+		// keep counting if the total was requested
+		if !pageReq.CountTotal {
+			break
+		}
+	}
+	if pageReq.CountTotal {
+		res.Total = count
+	}
+	return activities, res, nil
+}
@@ -0,0 +1,138 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountBridgeActivity(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	k := input.PeggyKeeper
+	var (
+		mySender, _         = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		otherSender, _      = sdk.AccAddressFromBech32("cosmos16ahjkfqxpp6lvfy9fpfnfjg39xr96qett0alj5")
+		myReceiver          = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		token               = func(amount uint64) sdk.Coin { return types.NewERC20Token(amount, myTokenContractAddr).PeggyCoin() }
+		blockTime           = time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+		atHeight            = func(height int64) sdk.Context {
+			return input.Context.WithBlockHeight(height).WithBlockTime(blockTime.Add(time.Duration(height) * time.Minute))
+		}
+	)
+
+	// a deposit at height 10 funds the sender
+	claim := &types.MsgDepositClaim{
+		EventNonce:     1,
+		TokenContract:  myTokenContractAddr,
+		Amount:         sdk.NewInt(1000),
+		EthereumSender: myReceiver,
+		CosmosReceiver: mySender.String(),
+	}
+	require.NoError(t, k.AttestationHandler.Handle(atHeight(10), types.Attestation{}, claim))
+	// a transfer at height 20 that is cancelled at height 30
+	txID, err := k.AddToOutgoingPool(atHeight(20), mySender, myReceiver, token(100), token(2))
+	require.NoError(t, err)
+	require.NoError(t, k.RemoveFromOutgoingPoolAndRefund(atHeight(30), txID, mySender))
+
+	ctx := atHeight(40)
+	res, err := k.AccountBridgeActivity(sdk.WrapSDKContext(ctx), &types.QueryAccountBridgeActivityRequest{Address: mySender.String()})
+	require.NoError(t, err)
+	exp := []types.AccountActivity{
+		{Id: 1, Account: mySender.String(), Height: 10, Time: uint64(blockTime.Add(10 * time.Minute).Unix()), Type: types.ACCOUNT_ACTIVITY_TYPE_DEPOSIT, Amount: token(1000), EventNonce: 1, EthereumAddress: myReceiver},
+		{Id: 2, Account: mySender.String(), Height: 20, Time: uint64(blockTime.Add(20 * time.Minute).Unix()), Type: types.ACCOUNT_ACTIVITY_TYPE_WITHDRAWAL, Amount: token(100), TxId: txID, EthereumAddress: myReceiver},
+		{Id: 3, Account: mySender.String(), Height: 20, Time: uint64(blockTime.Add(20 * time.Minute).Unix()), Type: types.ACCOUNT_ACTIVITY_TYPE_FEE, Amount: token(2), TxId: txID, EthereumAddress: myReceiver},
+		{Id: 4, Account: mySender.String(), Height: 30, Time: uint64(blockTime.Add(30 * time.Minute).Unix()), Type: types.ACCOUNT_ACTIVITY_TYPE_REFUND, Amount: token(100), TxId: txID, EthereumAddress: myReceiver},
+		{Id: 5, Account: mySender.String(), Height: 30, Time: uint64(blockTime.Add(30 * time.Minute).Unix()), Type: types.ACCOUNT_ACTIVITY_TYPE_REFUND, Amount: token(2), TxId: txID, EthereumAddress: myReceiver},
+	}
+	assert.Equal(t, exp, res.Activities)
+
+	// the heights bound the range, both inclusive
+	res, err = k.AccountBridgeActivity(sdk.WrapSDKContext(ctx), &types.QueryAccountBridgeActivityRequest{Address: mySender.String(), FromHeight: 20, ToHeight: 20})
+	require.NoError(t, err)
+	assert.Equal(t, exp[1:3], res.Activities)
+	res, err = k.AccountBridgeActivity(sdk.WrapSDKContext(ctx), &types.QueryAccountBridgeActivityRequest{Address: mySender.String(), FromHeight: 11})
+	require.NoError(t, err)
+	assert.Equal(t, exp[1:], res.Activities)
+
+	// pages continue with the next key
+	res, err = k.AccountBridgeActivity(sdk.WrapSDKContext(ctx), &types.QueryAccountBridgeActivityRequest{Address: mySender.String(), Pagination: &query.PageRequest{Limit: 2, CountTotal: true}})
+	require.NoError(t, err)
+	assert.Equal(t, exp[:2], res.Activities)
+	assert.Equal(t, uint64(5), res.Pagination.Total)
+	require.NotNil(t, res.Pagination.NextKey)
+	res, err = k.AccountBridgeActivity(sdk.WrapSDKContext(ctx), &types.QueryAccountBridgeActivityRequest{Address: mySender.String(), Pagination: &query.PageRequest{Key: res.Pagination.NextKey, Limit: 2}})
+	require.NoError(t, err)
+	assert.Equal(t, exp[2:4], res.Activities)
+
+	// other accounts have their own ledger
+	res, err = k.AccountBridgeActivity(sdk.WrapSDKContext(ctx), &types.QueryAccountBridgeActivityRequest{Address: otherSender.String()})
+	require.NoError(t, err)
+	assert.Empty(t, res.Activities)
+
+	_, err = k.AccountBridgeActivity(sdk.WrapSDKContext(ctx), &types.QueryAccountBridgeActivityRequest{Address: mySender.String(), FromHeight: 20, ToHeight: 10})
+	assert.True(t, types.ErrInvalid.Is(err), err)
+	_, err = k.AccountBridgeActivity(sdk.WrapSDKContext(ctx), &types.QueryAccountBridgeActivityRequest{Address: "invalid"})
+	assert.Error(t, err)
+
+	// the ledger is exported in the genesis
+	genesis := ExportGenesis(ctx, k)
+	assert.Equal(t, exp, genesis.AccountActivities)
+}
+
+func TestPruneAccountActivities(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	k := input.PeggyKeeper
+	var (
+		mySender, _    = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		otherSender, _ = sdk.AccAddressFromBech32("cosmos16ahjkfqxpp6lvfy9fpfnfjg39xr96qett0alj5")
+		fee            = sdk.NewInt64Coin("uatom", 1)
+	)
+	for height := int64(1); height <= 4; height++ {
+		ctx := input.Context.WithBlockHeight(height * 10)
+		k.recordAccountActivity(ctx, mySender, types.AccountActivity{Type: types.ACCOUNT_ACTIVITY_TYPE_FEE, Amount: fee})
+		k.recordAccountActivity(ctx, otherSender, types.AccountActivity{Type: types.ACCOUNT_ACTIVITY_TYPE_FEE, Amount: fee})
+	}
+	heights := func() (out []uint64) {
+		for _, activity := range k.GetAccountActivities(input.Context) {
+			out = append(out, activity.Height)
+		}
+		return
+	}
+	params := k.GetParams(input.Context)
+	params.AccountActivityRetention = 15
+	params.EndBlockerWorkBudget = 1
+	k.SetParams(input.Context, params)
+
+	// each block prunes what fits into the work budget, oldest first
+	ctx := input.Context.WithBlockHeight(40)
+	k.PruneAccountActivities(k.WithWorkBudget(ctx))
+	assert.Equal(t, []uint64{10, 20, 30, 40, 20, 30, 40}, heights())
+	k.PruneAccountActivities(k.WithWorkBudget(ctx))
+	k.PruneAccountActivities(k.WithWorkBudget(ctx))
+	k.PruneAccountActivities(k.WithWorkBudget(ctx))
+	assert.Equal(t, []uint64{30, 40, 30, 40}, heights())
+	k.PruneAccountActivities(k.WithWorkBudget(ctx))
+	assert.Equal(t, []uint64{30, 40, 30, 40}, heights())
+
+	// a zero retention keeps the ledgers
+	params.AccountActivityRetention = 0
+	k.SetParams(input.Context, params)
+	k.PruneAccountActivities(ctx.WithBlockHeight(100))
+	assert.Len(t, heights(), 4)
+
+	// the migration indexes the entries recorded before
+	ctx.KVStore(k.storeKey).Delete(types.GetAccountActivityByHeightKey(30, 5))
+	require.NoError(t, k.MigrateAccountActivityHeightIndex(ctx))
+	params.AccountActivityRetention = 5
+	k.SetParams(input.Context, params)
+	k.PruneAccountActivities(ctx.WithBlockHeight(100))
+	assert.Empty(t, heights())
+}
@@ -5,7 +5,9 @@ import (
 	"strconv"
 
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
 )
 
@@ -19,8 +21,29 @@ func (k Keeper) Attest(ctx sdk.Context, claim types.EthereumClaim, anyClaim *cod
 	// We check the event nonce in processAttestation as well, but checking it here gives individual eth signers a chance to retry,
 	// and prevents validators from submitting two claims with the same nonce
 	lastEventNonce := k.GetLastEventNonceByValidator(ctx, valAddr)
+	// An orchestrator retrying a claim of its validator that is already stored, e.g. because it did not see
+	// its transaction included, gets the attestation back instead of an error
+	if claim.GetEventNonce() <= lastEventNonce {
+		if att := k.GetAttestation(ctx, claim.GetEventNonce(), claim.ClaimHash()); att != nil && hasVote(att, valAddr) {
+			k.incrementClaimResubmissions(ctx, valAddr)
+			return att, nil
+		}
+	}
 	if claim.GetEventNonce() != lastEventNonce+1 {
-		return nil, types.ErrNonContiguousEventNonce
+		// After a reorg an orchestrator may resubmit a corrected claim for an event that is not observed yet.
+		// Its votes from that event on are withdrawn, so it has to resubmit the following events as well
+		if claim.GetEventNonce() > lastEventNonce || claim.GetEventNonce() <= k.GetLastObservedEventNonce(ctx) {
+			return nil, types.ErrNonContiguousEventNonce
+		}
+		k.withdrawPendingVotes(ctx, valAddr, claim.GetEventNonce())
+	}
+
+	// The observed Ethereum height only ever moves forward, so a claim far ahead of the Ethereum height the
+	// validators claimed would let a single faulty orchestrator drag it (and with it batch timeouts) along
+	if window := k.GetParams(ctx).EthereumHeightWindow; window != 0 {
+		if median := k.getMedianClaimEthereumHeight(ctx); median != 0 && claim.GetBlockHeight() > median+window {
+			return nil, sdkerrors.Wrapf(types.ErrInvalid, "claim ethereum height %d too far ahead of median claimed height %d", claim.GetBlockHeight(), median)
+		}
 	}
 
 	// Tries to get an attestation with the same eventNonce and claim as the claim that was submitted.
@@ -40,6 +63,7 @@ func (k Keeper) Attest(ctx sdk.Context, claim types.EthereumClaim, anyClaim *cod
 
 	k.SetAttestation(ctx, claim.GetEventNonce(), claim.ClaimHash(), att)
 	k.setLastEventNonceByValidator(ctx, valAddr, claim.GetEventNonce())
+	k.setClaimEthereumHeight(ctx, valAddr, claim.GetBlockHeight())
 
 	return att, nil
 }
@@ -71,29 +95,225 @@ func (k Keeper) TryAttestation(ctx sdk.Context, att *types.Attestation) {
 			// If the power of all the validators that have voted on the attestation is higher or equal to the threshold,
 			// process the attestation, set Observed to true, and break
 			if attestationPower.GTE(requiredPower) {
+				// The claim is only applied once its Ethereum block is deep enough that a reorg is
+				// unlikely to undo it, until then orchestrators can move their votes to a corrected claim
+				k.recordLatestEthereumHeight(ctx, claim)
+				if !k.isClaimConfirmed(ctx, claim) {
+					break
+				}
 				lastEventNonce := k.GetLastObservedEventNonce(ctx)
 				// this check is performed at the next level up so this should never panic
 				// outside of programmer error.
 				if claim.GetEventNonce() != uint64(lastEventNonce)+1 {
 					panic("attempting to apply events to state out of order")
 				}
+				apply := k.markEventApplied(ctx, claim)
 				k.setLastObservedEventNonce(ctx, claim.GetEventNonce())
 				k.SetLastObservedEthereumBlockHeight(ctx, claim.GetBlockHeight())
 
 				att.Observed = true
 				k.SetAttestation(ctx, claim.GetEventNonce(), claim.ClaimHash(), att)
 
-				k.processAttestation(ctx, att, claim)
-				k.emitObservedEvent(ctx, att, claim)
+				if apply {
+					k.processAttestation(ctx, att, claim)
+					k.emitObservedEvent(ctx, att, claim)
+				}
 				break
 			}
 		}
+	} else if lastEventNonce := k.GetLastObservedEventNonce(ctx); claim.GetEventNonce() == lastEventNonce+1 {
+		// The observed event nonce was rewound below an event that was already applied, the event is
+		// observed again without being applied a second time
+		k.setLastObservedEventNonce(ctx, claim.GetEventNonce())
 	} else {
 		// We panic here because this should never happen
 		panic("attempting to process observed attestation")
 	}
 }
 
+// recordLatestEthereumHeight records the Ethereum height of a claim that reached the vote threshold if it
+// is above the latest Ethereum height
+func (k Keeper) recordLatestEthereumHeight(ctx sdk.Context, claim types.EthereumClaim) {
+	if claim.GetBlockHeight() > k.latestEthereumHeight(ctx) {
+		k.setLatestEthereumBlockHeight(ctx, claim.GetBlockHeight())
+	}
+}
+
+// isClaimConfirmed returns true if the claim is at least EthereumBlockConfirmations blocks below the latest
+// Ethereum height, it doesn't write to the store
+func (k Keeper) isClaimConfirmed(ctx sdk.Context, claim types.EthereumClaim) bool {
+	confirmations := k.GetParams(ctx).EthereumBlockConfirmations
+	if confirmations == 0 {
+		return true
+	}
+	current := k.latestEthereumHeight(ctx)
+	if claim.GetBlockHeight() > current {
+		current = claim.GetBlockHeight()
+	}
+	return claim.GetBlockHeight()+confirmations <= current
+}
+
+// latestEthereumHeight returns the latest Ethereum height claimed by an attestation that reached the vote
+// threshold, projected forward so that the last event before a quiet period is still applied once enough
+// time has passed
+func (k Keeper) latestEthereumHeight(ctx sdk.Context) uint64 {
+	latest := k.GetLatestEthereumBlockHeight(ctx)
+	if projected := k.projectEthereumHeight(ctx, latest); projected > latest.EthereumBlockHeight {
+		return projected
+	}
+	return latest.EthereumBlockHeight
+}
+
+// withdrawPendingVotes removes the votes of a validator from the attestations at or above the given
+// event nonce, attestations left without votes are deleted. The event nonce must be above the
+// last observed event nonce so that observed attestations are never touched.
+func (k Keeper) withdrawPendingVotes(ctx sdk.Context, valAddr sdk.ValAddress, eventNonce uint64) {
+	val := valAddr.String()
+	var (
+		claims       []types.EthereumClaim
+		attestations []types.Attestation
+	)
+	k.IterateAttestations(ctx, func(_ []byte, att types.Attestation) bool {
+		claim, err := k.UnpackAttestationClaim(&att)
+		if err != nil {
+			panic("couldn't cast to claim")
+		}
+		if claim.GetEventNonce() < eventNonce {
+			return false
+		}
+		votes := make([]string, 0, len(att.Votes))
+		for _, vote := range att.Votes {
+			if vote != val {
+				votes = append(votes, vote)
+			}
+		}
+		if len(votes) != len(att.Votes) {
+			att.Votes = votes
+			claims = append(claims, claim)
+			attestations = append(attestations, att)
+		}
+		return false
+	})
+
+	for i, claim := range claims {
+		if len(attestations[i].Votes) == 0 {
+			k.DeleteAttestation(ctx, claim.GetEventNonce(), claim.ClaimHash(), &attestations[i])
+			continue
+		}
+		k.SetAttestation(ctx, claim.GetEventNonce(), claim.ClaimHash(), &attestations[i])
+	}
+}
+
+// PruneTimedOutAttestations deletes the attestations that have not been observed within AttestationTimeout
+// blocks of their creation. Attestations above the last observed event nonce still block the bridge, their
+// voters get their votes from that nonce on withdrawn and their last event nonce reset, so that they can
+// claim the event again. Losing attestations at or below the last observed nonce are just deleted.
+//
+// The pass over the attestations is metered by the work budget of the context. The votes are only
+// withdrawn once a pass completes, until then the lowest timed out nonce of every voter is kept with the
+// pending vote resets.
+func (k Keeper) PruneTimedOutAttestations(ctx sdk.Context) {
+	timeout := k.GetParams(ctx).AttestationTimeout
+	if timeout != 0 && uint64(ctx.BlockHeight()) > timeout {
+		maxHeight := uint64(ctx.BlockHeight()) - timeout
+		completed := k.scanWithWorkBudget(ctx, workTaskPruneAttestations, []byte(types.OracleAttestationKey), func(_, value []byte) {
+			var att types.Attestation
+			k.cdc.MustUnmarshalBinaryBare(value, &att)
+			if !att.Observed && att.Height < maxHeight {
+				k.pruneTimedOutAttestation(ctx, att)
+			}
+		})
+		if !completed {
+			return
+		}
+	}
+	k.applyPendingVoteResets(ctx)
+}
+
+// pruneTimedOutAttestation deletes a timed out attestation and records the reset of its voters
+func (k Keeper) pruneTimedOutAttestation(ctx sdk.Context, att types.Attestation) {
+	claim, err := k.UnpackAttestationClaim(&att)
+	if err != nil {
+		panic("couldn't cast to claim")
+	}
+	nonce, hash := claim.GetEventNonce(), claim.ClaimHash()
+	if nonce > k.GetLastObservedEventNonce(ctx) {
+		for _, vote := range att.Votes {
+			valAddr, err := sdk.ValAddressFromBech32(vote)
+			if err != nil {
+				panic(err)
+			}
+			k.setPendingVoteReset(ctx, valAddr, nonce)
+		}
+	}
+	k.DeleteAttestation(ctx, nonce, hash, &att)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeAttestationTimeout,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyAttestationType, claim.GetType().String()),
+		sdk.NewAttribute(types.AttributeKeyAttestationID, string(types.GetAttestationKey(nonce, hash))),
+		sdk.NewAttribute(types.AttributeKeyNonce, fmt.Sprint(nonce)),
+	))
+}
+
+// setPendingVoteReset records that the votes of the validator are withdrawn from the event nonce on,
+// keeping the lowest nonce recorded for the validator
+func (k Keeper) setPendingVoteReset(ctx sdk.Context, valAddr sdk.ValAddress, nonce uint64) {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetPendingVoteResetKey(valAddr)
+	if bz := store.Get(key); bz != nil && types.UInt64FromBytes(bz) <= nonce {
+		return
+	}
+	store.Set(key, types.UInt64Bytes(nonce))
+}
+
+// applyPendingVoteResets withdraws the pending votes of the validators recorded by a completed pass over
+// the timed out attestations and resets their last event nonce. The resets are applied in validator
+// address order until the work budget is spent.
+func (k Keeper) applyPendingVoteResets(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	for processed := 0; processed == 0 || !WorkBudgetExhausted(ctx); processed++ {
+		iter := prefix.NewStore(store, types.PendingVoteResetKey).Iterator(nil, nil)
+		if !iter.Valid() {
+			iter.Close()
+			return
+		}
+		valAddr := sdk.ValAddress(append([]byte{}, iter.Key()...))
+		nonce := types.UInt64FromBytes(iter.Value())
+		iter.Close()
+		store.Delete(types.GetPendingVoteResetKey(valAddr))
+
+		// attestations observed since the reset was recorded are never touched
+		if lastObserved := k.GetLastObservedEventNonce(ctx); nonce <= lastObserved {
+			nonce = lastObserved + 1
+		}
+		k.withdrawPendingVotes(ctx, valAddr, nonce)
+		if k.GetLastEventNonceByValidator(ctx, valAddr) >= nonce {
+			k.setLastEventNonceByValidator(ctx, valAddr, nonce-1)
+		}
+	}
+}
+
+// markEventApplied records the event nonce of an observed claim as applied and returns true if the claim
+// is to be handed to the attestation handler. Claims are applied once per event nonce and strictly in
+// event nonce order, so that the handler and the peggy hooks see the Ethereum events in the order they
+// happened. A claim at or below the last applied event nonce, observed again after the observed event
+// nonce was rewound, is not applied a second time, even if it differs from the claim that was applied.
+func (k Keeper) markEventApplied(ctx sdk.Context, claim types.EthereumClaim) bool {
+	lastApplied := k.GetLastAppliedEventNonce(ctx)
+	switch nonce := claim.GetEventNonce(); {
+	case nonce <= lastApplied:
+		k.logger(ctx).Info("event already applied", "nonce", nonce, "last applied", lastApplied)
+		return false
+	case nonce != lastApplied+1:
+		// the tally observes events in order, so this should never happen outside of programmer error
+		panic(fmt.Sprintf("attempting to apply event %d before event %d", nonce, lastApplied+1))
+	}
+	k.setLastAppliedEventNonce(ctx, claim.GetEventNonce())
+	return true
+}
+
 // processAttestation actually applies the attestation to the consensus state
 func (k Keeper) processAttestation(ctx sdk.Context, att *types.Attestation, claim types.EthereumClaim) {
 	// then execute in a new Tx so that we can store state on failure
@@ -110,6 +330,8 @@ func (k Keeper) processAttestation(ctx sdk.Context, att *types.Attestation, clai
 		)
 	} else {
 		commit() // persist transient storage
+		// the cache context has an event manager of its own, keep the events of the handler
+		ctx.EventManager().EmitEvents(xCtx.EventManager().Events())
 	}
 }
 
@@ -158,7 +380,7 @@ func (k Keeper) DeleteAttestation(ctx sdk.Context, eventNonce uint64, claimHash
 // GetAttestationMapping returns a mapping of eventnonce -> attestations at that nonce
 func (k Keeper) GetAttestationMapping(ctx sdk.Context) (out map[uint64][]types.Attestation) {
 	out = make(map[uint64][]types.Attestation)
-	k.IterateAttestaions(ctx, func(_ []byte, att types.Attestation) bool {
+	k.IterateAttestations(ctx, func(_ []byte, att types.Attestation) bool {
 		claim, err := k.UnpackAttestationClaim(&att)
 		if err != nil {
 			panic("couldn't cast to claim")
@@ -174,8 +396,21 @@ func (k Keeper) GetAttestationMapping(ctx sdk.Context) (out map[uint64][]types.A
 	return
 }
 
-// IterateAttestaions iterates through all attestations
-func (k Keeper) IterateAttestaions(ctx sdk.Context, cb func([]byte, types.Attestation) bool) {
+// GetAttestationsByNonce returns all attestations of an event nonce, one per distinct claim hash
+func (k Keeper) GetAttestationsByNonce(ctx sdk.Context, eventNonce uint64) (out []types.Attestation) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.GetAttestationKey(eventNonce, nil))
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var att types.Attestation
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &att)
+		out = append(out, att)
+	}
+	return out
+}
+
+// IterateAttestations iterates through all attestations
+func (k Keeper) IterateAttestations(ctx sdk.Context, cb func([]byte, types.Attestation) bool) {
 	store := ctx.KVStore(k.storeKey)
 	prefix := []byte(types.OracleAttestationKey)
 	iter := store.Iterator(prefixRange(prefix))
@@ -202,37 +437,97 @@ func (k Keeper) GetLastObservedEventNonce(ctx sdk.Context) uint64 {
 	return types.UInt64FromBytes(bytes)
 }
 
-// GetLastObservedEthereumBlockHeight height gets the block height to of the last observed attestation from
-// the store
-func (k Keeper) GetLastObservedEthereumBlockHeight(ctx sdk.Context) types.LastObservedEthereumBlockHeight {
+// setLastObservedEventNonce sets the latest observed event nonce
+func (k Keeper) setLastObservedEventNonce(ctx sdk.Context, nonce uint64) {
 	store := ctx.KVStore(k.storeKey)
-	bytes := store.Get(types.LastObservedEthereumBlockHeightKey)
+	store.Set(types.LastObservedEventNonceKey, types.UInt64Bytes(nonce))
+}
 
+// GetLastAppliedEventNonce returns the event nonce of the latest claim handed to the attestation handler.
+// It trails the last observed event nonce only after that was rewound. Stores written before the nonce
+// was recorded fall back to the last observed event nonce.
+func (k Keeper) GetLastAppliedEventNonce(ctx sdk.Context) uint64 {
+	bytes := ctx.KVStore(k.storeKey).Get(types.LastAppliedEventNonceKey)
 	if len(bytes) == 0 {
-		return types.LastObservedEthereumBlockHeight{
-			CosmosBlockHeight:   0,
-			EthereumBlockHeight: 0,
-		}
+		return k.GetLastObservedEventNonce(ctx)
 	}
-	height := types.LastObservedEthereumBlockHeight{}
-	k.cdc.MustUnmarshalBinaryBare(bytes, &height)
-	return height
+	return types.UInt64FromBytes(bytes)
 }
 
-// SetLastObservedEthereumBlockHeight sets the block height in the store.
-func (k Keeper) SetLastObservedEthereumBlockHeight(ctx sdk.Context, ethereumHeight uint64) {
+// setLastAppliedEventNonce sets the event nonce of the latest applied claim
+func (k Keeper) setLastAppliedEventNonce(ctx sdk.Context, nonce uint64) {
+	ctx.KVStore(k.storeKey).Set(types.LastAppliedEventNonceKey, types.UInt64Bytes(nonce))
+}
+
+// SetLastObservedEventNonce moves the last observed event nonce to the given nonce when called by the
+// authority of the module, recovering an oracle stuck on an event that can't be attested. The attestations
+// that weren't observed above the lower of the old and the new nonce are deleted and the last event nonce of
+// every validator is set to the new nonce, so that orchestrators continue with the event following it.
+// Observed attestations are kept, an event observed before a rewind is not applied again. Moving the nonce
+// past the last applied event nonce skips the events in between, they are never applied.
+func (k Keeper) SetLastObservedEventNonce(ctx sdk.Context, authority string, nonce uint64) error {
+	if authority != k.authority {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "expected authority %s, got %s", k.authority, authority)
+	}
+	previous := k.GetLastObservedEventNonce(ctx)
+	lowest := previous
+	if nonce < lowest {
+		lowest = nonce
+	}
+
+	var (
+		claims       []types.EthereumClaim
+		attestations []types.Attestation
+	)
+	k.IterateAttestations(ctx, func(_ []byte, att types.Attestation) bool {
+		claim, err := k.UnpackAttestationClaim(&att)
+		if err != nil {
+			panic("couldn't cast to claim")
+		}
+		if !att.Observed && claim.GetEventNonce() > lowest {
+			claims = append(claims, claim)
+			attestations = append(attestations, att)
+		}
+		return false
+	})
+	for i, claim := range claims {
+		k.DeleteAttestation(ctx, claim.GetEventNonce(), claim.ClaimHash(), &attestations[i])
+	}
+
+	// the votes the pending resets would withdraw are deleted already
 	store := ctx.KVStore(k.storeKey)
-	height := types.LastObservedEthereumBlockHeight{
-		EthereumBlockHeight: ethereumHeight,
-		CosmosBlockHeight:   uint64(ctx.BlockHeight()),
+	for _, val := range k.storedValidators(ctx, types.PendingVoteResetKey) {
+		store.Delete(types.GetPendingVoteResetKey(val))
 	}
-	store.Set(types.LastObservedEthereumBlockHeightKey, k.cdc.MustMarshalBinaryBare(&height))
+	for _, val := range k.storedValidators(ctx, types.LastEventNonceByValidatorKey) {
+		k.setLastEventNonceByValidator(ctx, val, nonce)
+	}
+	// stored before the observed nonce moves, a store without it falls back to the observed nonce
+	lastApplied := k.GetLastAppliedEventNonce(ctx)
+	if nonce > lastApplied {
+		lastApplied = nonce
+	}
+	k.setLastAppliedEventNonce(ctx, lastApplied)
+	k.setLastObservedEventNonce(ctx, nonce)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeObservedEventNonceSet,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyPreviousNonce, fmt.Sprint(previous)),
+		sdk.NewAttribute(types.AttributeKeyNonce, fmt.Sprint(nonce)),
+	))
+	k.logger(ctx).Info("set last observed event nonce", "previous", previous, "new", nonce)
+	return nil
 }
 
-// setLastObservedEventNonce sets the latest observed event nonce
-func (k Keeper) setLastObservedEventNonce(ctx sdk.Context, nonce uint64) {
-	store := ctx.KVStore(k.storeKey)
-	store.Set(types.LastObservedEventNonceKey, types.UInt64Bytes(nonce))
+// storedValidators returns the validators with an entry under the store prefix
+func (k Keeper) storedValidators(ctx sdk.Context, keyPrefix []byte) (out []sdk.ValAddress) {
+	iter := prefix.NewStore(ctx.KVStore(k.storeKey), keyPrefix).Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		out = append(out, sdk.ValAddress(append([]byte{}, iter.Key()...)))
+	}
+	return out
 }
 
 // GetLastEventNonceByValidator returns the latest event nonce for a given validator
@@ -287,3 +582,27 @@ func (k Keeper) setLastEventNonceByValidator(ctx sdk.Context, validator sdk.ValA
 	store := ctx.KVStore(k.storeKey)
 	store.Set(types.GetLastEventNonceByValidatorKey(validator), types.UInt64Bytes(nonce))
 }
+
+// hasVote returns true if the validator voted for the attestation
+func hasVote(att *types.Attestation, validator sdk.ValAddress) bool {
+	for _, vote := range att.Votes {
+		if vote == validator.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// GetClaimResubmissions returns the number of claims the orchestrators of a validator resubmitted after
+// they were already stored
+func (k Keeper) GetClaimResubmissions(ctx sdk.Context, validator sdk.ValAddress) uint64 {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetClaimResubmissionsKey(validator))
+	if bz == nil {
+		return 0
+	}
+	return types.UInt64FromBytes(bz)
+}
+
+func (k Keeper) incrementClaimResubmissions(ctx sdk.Context, validator sdk.ValAddress) {
+	ctx.KVStore(k.storeKey).Set(types.GetClaimResubmissionsKey(validator), types.UInt64Bytes(k.GetClaimResubmissions(ctx, validator)+1))
+}
@@ -8,111 +8,220 @@ import (
 	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
 )
 
+// ClaimHandler applies an observed claim of a single ClaimType to the state
+type ClaimHandler func(ctx sdk.Context, k Keeper, claim types.EthereumClaim) error
+
 // AttestationHandler processes `observed` Attestations
 type AttestationHandler struct {
-	keeper     Keeper
-	bankKeeper types.BankKeeper
+	keeper Keeper
 }
 
-// Handle is the entry point for Attestation processing.
-// TODO-JT add handler for ERC20DeployedEvent
+// Handle is the entry point for Attestation processing. The claim is dispatched to the
+// handler registered for its type
 func (a AttestationHandler) Handle(ctx sdk.Context, att types.Attestation, claim types.EthereumClaim) error {
-	switch claim := claim.(type) {
-	case *types.MsgDepositClaim:
-		// Check if coin is Cosmos-originated asset and get denom
-		isCosmosOriginated, denom := a.keeper.ERC20ToDenomLookup(ctx, claim.TokenContract)
-
-		if isCosmosOriginated {
-			// If it is cosmos originated, unlock the coins
-			coins := sdk.Coins{sdk.NewCoin(denom, claim.Amount)}
-
-			addr, err := sdk.AccAddressFromBech32(claim.CosmosReceiver)
-			if err != nil {
-				return sdkerrors.Wrap(err, "invalid reciever address")
-			}
-
-			if err = a.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, addr, coins); err != nil {
-				return sdkerrors.Wrap(err, "transfer vouchers")
-			}
-		} else {
-			// If it is not cosmos originated, mint the coins (aka vouchers)
-			coins := sdk.Coins{sdk.NewCoin(denom, claim.Amount)}
-
-			if err := a.bankKeeper.MintCoins(ctx, types.ModuleName, coins); err != nil {
-				return sdkerrors.Wrapf(err, "mint vouchers coins: %s", coins)
-			}
-
-			addr, err := sdk.AccAddressFromBech32(claim.CosmosReceiver)
-			if err != nil {
-				return sdkerrors.Wrap(err, "invalid reciever address")
-			}
-
-			if err = a.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, addr, coins); err != nil {
-				return sdkerrors.Wrap(err, "transfer vouchers")
-			}
-		}
-	case *types.MsgWithdrawClaim:
-		a.keeper.OutgoingTxBatchExecuted(ctx, claim.TokenContract, claim.BatchNonce)
-	case *types.MsgERC20DeployedClaim:
-		// Check if it already exists
-		existingERC20, exists := a.keeper.GetCosmosOriginatedERC20(ctx, claim.CosmosDenom)
-		if exists {
-			return sdkerrors.Wrap(
-				types.ErrInvalid,
-				fmt.Sprintf("ERC20 %s already exists for denom %s", existingERC20, claim.CosmosDenom))
-		}
+	handler, ok := a.keeper.claimHandlers[claim.GetType()]
+	if !ok {
+		return sdkerrors.Wrapf(types.ErrInvalid, "event type: %s", claim.GetType())
+	}
+	return handler(ctx, a.keeper, claim)
+}
 
-		// Check if denom exists
-		metadata := a.keeper.bankKeeper.GetDenomMetaData(ctx, claim.CosmosDenom)
-		if metadata.Base == "" {
-			return sdkerrors.Wrap(types.ErrUnknown, fmt.Sprintf("denom not found %s", claim.CosmosDenom))
-		}
+// RegisterClaimHandler registers the handler for observed claims of the given type. Chains embedding
+// the module can use it for custom claim types, the built in claim types can not be replaced
+func (k Keeper) RegisterClaimHandler(claimType types.ClaimType, handler ClaimHandler) {
+	if _, exists := k.claimHandlers[claimType]; exists {
+		panic(fmt.Sprintf("claim handler for %s already registered", claimType))
+	}
+	k.claimHandlers[claimType] = handler
+}
 
-		// Check if attributes of ERC20 match Cosmos denom
-		if claim.Name != metadata.Display {
-			return sdkerrors.Wrap(
-				types.ErrInvalid,
-				fmt.Sprintf("ERC20 name %s does not match denom display %s", claim.Name, metadata.Description))
+// registerDefaultClaimHandlers registers the handlers for the claim types of the peggy contract
+func (k Keeper) registerDefaultClaimHandlers() {
+	k.RegisterClaimHandler(types.CLAIM_TYPE_DEPOSIT, handleDepositClaim)
+	k.RegisterClaimHandler(types.CLAIM_TYPE_WITHDRAW, handleWithdrawClaim)
+	k.RegisterClaimHandler(types.CLAIM_TYPE_ERC20_DEPLOYED, handleERC20DeployedClaim)
+	k.RegisterClaimHandler(types.CLAIM_TYPE_LOGIC_CALL_EXECUTED, handleLogicCallExecutedClaim)
+	k.RegisterClaimHandler(types.CLAIM_TYPE_GENERIC_EVENT, handleGenericEventClaim)
+	k.RegisterClaimHandler(types.CLAIM_TYPE_VALSET_UPDATED, handleValsetUpdatedClaim)
+}
+
+func handleDepositClaim(ctx sdk.Context, k Keeper, c types.EthereumClaim) error {
+	claim, ok := c.(*types.MsgDepositClaim)
+	if !ok {
+		return sdkerrors.Wrapf(types.ErrInvalid, "unexpected claim %T", c)
+	}
+	// A token taking a fee on transfer leaves the bridge contract with less than the event reported,
+	// only what was received is credited so the vouchers stay backed
+	amount := claim.Amount
+	if claim.HasDiscrepancy() {
+		k.recordDepositDiscrepancy(ctx, claim)
+		amount = claim.CreditedAmount()
+		if amount.IsZero() {
+			return nil
 		}
+	}
+
+	// Check if coin is Cosmos-originated asset and get the coin the deposit stands for, a cosmos
+	// originated ERC20 with more decimals than its denom leaves the dust it can't represent in the
+	// bridge contract
+	isCosmosOriginated, coin := k.ERC20ToCoin(ctx, *types.NewSDKIntERC20Token(amount, claim.TokenContract))
+	if coin.IsZero() {
+		return nil
+	}
+	coins := sdk.Coins{coin}
 
-		if claim.Symbol != metadata.Display {
-			return sdkerrors.Wrap(
-				types.ErrInvalid,
-				fmt.Sprintf("ERC20 symbol %s does not match denom display %s", claim.Symbol, metadata.Display))
+	// If it is cosmos originated the coins are already escrowed in the module,
+	// if not, mint the coins (aka vouchers)
+	if !isCosmosOriginated {
+		if err := k.bankKeeper.MintCoins(ctx, types.ModuleName, coins); err != nil {
+			return sdkerrors.Wrapf(err, "mint vouchers coins: %s", coins)
 		}
+		k.registerVoucherMetadata(ctx, claim.TokenContract)
+	} else {
+		k.subNativeTokenEscrow(ctx, coins)
+	}
+
+	k.recordObservedDeposit(ctx, claim, coin)
+	k.recordDepositStats(ctx, claim.TokenContract, amount)
 
-		// ERC20 tokens use a very simple mechanism to tell you where to display the decimal point.
-		// The "decimals" field simply tells you how many decimal places there will be.
-		// Cosmos denoms have a system that is much more full featured, with enterprise-ready token denominations.
-		// There is a DenomUnits array that tells you what the name of each denomination of the
-		// token is.
-		// To correlate this with an ERC20 "decimals" field, we have to search through the DenomUnits array
-		// to find the DenomUnit which matches up to the main token "display" value. Then we take the
-		// "exponent" from this DenomUnit.
-		// If the correct DenomUnit is not found, it will default to 0. This will result in there being no decimal places
-		// in the token's ERC20 on Ethereum. So, for example, if this happened with Atom, 1 Atom would appear on Ethereum
-		// as 1 million Atoms, having 6 extra places before the decimal point.
-		// This will only happen with a Denom Metadata which is for all intents and purposes invalid, but I am not sure
-		// this is checked for at any other point.
-		decimals := uint32(0)
-		for _, denomUnit := range metadata.DenomUnits {
-			if denomUnit.Denom == metadata.Display {
-				decimals = denomUnit.Exponent
-				break
-			}
+	// Deposits of a token that is not allowed on the bridge or paused aren't credited either, the tokens
+	// are locked in the bridge contract already, so governance returns them once it looked into them
+	if !k.IsTokenAllowed(ctx, claim.TokenContract) || k.IsTokenPaused(ctx, claim.TokenContract) {
+		if err := k.fundReclaimableDeposit(ctx, claim, coin); err != nil {
+			return sdkerrors.Wrap(err, "fund community pool")
 		}
+		return nil
+	}
 
-		if decimals != uint32(claim.Decimals) {
-			return sdkerrors.Wrap(
-				types.ErrInvalid,
-				fmt.Sprintf("ERC20 decimals %d does not match denom decimals %d", claim.Decimals, decimals))
+	addr, err := k.ParseCosmosReceiver(ctx, claim.CosmosReceiver)
+	if err != nil {
+		// The deposit has already happened on Ethereum, so rather than losing the funds
+		// we send them to the community pool where governance can return them
+		if err := k.fundReclaimableDeposit(ctx, claim, coin); err != nil {
+			return sdkerrors.Wrap(err, "fund community pool")
 		}
+		return nil
+	}
+	addr = k.DepositDestination(ctx, addr, claim.DestinationTag)
+
+	// A plain send credits the receiver with spendable balance, also when it is a vesting account:
+	// its schedule only locks the original vesting amount, which the deposit doesn't add to. The
+	// deposit must not be credited with DelegateCoins or by creating a vesting account, either
+	// would lock the bridged funds under a schedule the depositor never agreed to.
+	if err = k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, addr, coins); err != nil {
+		return sdkerrors.Wrap(err, "transfer vouchers")
+	}
+	k.recordAccountActivity(ctx, addr, types.AccountActivity{
+		Type:            types.ACCOUNT_ACTIVITY_TYPE_DEPOSIT,
+		Amount:          coin,
+		EventNonce:      claim.EventNonce,
+		EthereumAddress: claim.EthereumSender,
+	})
+	k.AfterDepositObserved(ctx, claim.EventNonce, claim.EthereumSender, addr, coin)
+	return nil
+}
+
+func handleWithdrawClaim(ctx sdk.Context, k Keeper, c types.EthereumClaim) error {
+	claim, ok := c.(*types.MsgWithdrawClaim)
+	if !ok {
+		return sdkerrors.Wrapf(types.ErrInvalid, "unexpected claim %T", c)
+	}
+	// the batch is deleted once executed
+	batch := k.GetOutgoingTXBatch(ctx, claim.TokenContract, claim.BatchNonce)
+	if batch == nil {
+		batch = k.GetCancelledBatch(ctx, claim.TokenContract, claim.BatchNonce)
+	}
+	if err := k.OutgoingTxBatchExecuted(ctx, claim.TokenContract, claim.BatchNonce); err != nil {
+		return err
+	}
+	k.recordWithdrawalStats(ctx, batch)
+	k.SetBatchExecution(ctx, types.BatchExecution{
+		TokenContract:       claim.TokenContract,
+		BatchNonce:          claim.BatchNonce,
+		EventNonce:          claim.EventNonce,
+		EthereumBlockHeight: claim.BlockHeight,
+		EthTxHash:           claim.EthTxHash,
+	})
+	k.AfterWithdrawExecuted(ctx, claim.TokenContract, claim.BatchNonce)
+	return nil
+}
+
+func handleERC20DeployedClaim(ctx sdk.Context, k Keeper, c types.EthereumClaim) error {
+	claim, ok := c.(*types.MsgERC20DeployedClaim)
+	if !ok {
+		return sdkerrors.Wrapf(types.ErrInvalid, "unexpected claim %T", c)
+	}
+	// The first observed deployment of a denom stands, anyone can deploy an ERC20 for a denom so later
+	// deployments are only reported. Governance corrects a wrong mapping with SetCosmosOriginatedERC20.
+	existingERC20, erc20Exists := k.GetCosmosOriginatedERC20(ctx, claim.CosmosDenom)
+	existingDenom, denomExists := k.GetCosmosOriginatedDenom(ctx, claim.TokenContract)
+	if erc20Exists || denomExists {
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeERC20DeploymentRejected,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(types.AttributeKeyNonce, fmt.Sprint(claim.EventNonce)),
+			sdk.NewAttribute(types.AttributeKeyCosmosDenom, claim.CosmosDenom),
+			sdk.NewAttribute(types.AttributeKeyTokenContract, claim.TokenContract),
+			sdk.NewAttribute(types.AttributeKeyExistingERC20, existingERC20),
+			sdk.NewAttribute(types.AttributeKeyExistingDenom, existingDenom),
+		))
+		return nil
+	}
 
-		// Add to denom-erc20 mapping
-		a.keeper.setCosmosOriginatedDenomToERC20(ctx, claim.CosmosDenom, claim.TokenContract)
+	// Check if denom exists
+	metadata := k.bankKeeper.GetDenomMetaData(ctx, claim.CosmosDenom)
+	if metadata.Base == "" {
+		return sdkerrors.Wrap(types.ErrUnknown, fmt.Sprintf("denom not found %s", claim.CosmosDenom))
+	}
 
-	default:
-		return sdkerrors.Wrapf(types.ErrInvalid, "event type: %s", claim.GetType())
+	// Check if attributes of ERC20 match Cosmos denom
+	if claim.Name != metadata.Display {
+		return sdkerrors.Wrap(
+			types.ErrInvalid,
+			fmt.Sprintf("ERC20 name %s does not match denom display %s", claim.Name, metadata.Display))
+	}
+
+	if claim.Symbol != metadata.Display {
+		return sdkerrors.Wrap(
+			types.ErrInvalid,
+			fmt.Sprintf("ERC20 symbol %s does not match denom display %s", claim.Symbol, metadata.Display))
+	}
+
+	decimals := erc20Decimals(metadata)
+	if decimals != uint32(claim.Decimals) {
+		return sdkerrors.Wrap(
+			types.ErrInvalid,
+			fmt.Sprintf("ERC20 decimals %d does not match denom decimals %d", claim.Decimals, decimals))
+	}
+
+	// Add to denom-erc20 mapping
+	k.setCosmosOriginatedDenomToERC20(ctx, claim.CosmosDenom, claim.TokenContract)
+	return nil
+}
+
+func handleLogicCallExecutedClaim(ctx sdk.Context, k Keeper, c types.EthereumClaim) error {
+	claim, ok := c.(*types.MsgLogicCallExecutedClaim)
+	if !ok {
+		return sdkerrors.Wrapf(types.ErrInvalid, "unexpected claim %T", c)
 	}
+	return k.OutgoingLogicCallExecuted(ctx, claim.InvalidationId, claim.InvalidationNonce)
+}
+
+// handleGenericEventClaim has no effect on the bridge itself, the observed event is
+// only handed to the peggy hooks
+func handleGenericEventClaim(ctx sdk.Context, k Keeper, c types.EthereumClaim) error {
+	claim, ok := c.(*types.MsgGenericEventClaim)
+	if !ok {
+		return sdkerrors.Wrapf(types.ErrInvalid, "unexpected claim %T", c)
+	}
+	k.AfterGenericEventObserved(ctx, claim.EventNonce, claim.ContractAddress, claim.Topic, claim.Data)
 	return nil
 }
+
+func handleValsetUpdatedClaim(ctx sdk.Context, k Keeper, c types.EthereumClaim) error {
+	claim, ok := c.(*types.MsgValsetUpdatedClaim)
+	if !ok {
+		return sdkerrors.Wrapf(types.ErrInvalid, "unexpected claim %T", c)
+	}
+	return k.ValsetUpdatedObserved(ctx, claim.ValsetNonce)
+}
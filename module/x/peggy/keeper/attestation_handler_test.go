@@ -0,0 +1,232 @@
+package keeper
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestexported "github.com/cosmos/cosmos-sdk/x/auth/vesting/exported"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const customClaimType types.ClaimType = 100
+
+// customClaim is a claim of a type that is unknown to the module
+type customClaim struct {
+	*types.MsgDepositClaim
+}
+
+func (c customClaim) GetType() types.ClaimType { return customClaimType }
+
+func TestRegisterClaimHandler(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	claim := customClaim{&types.MsgDepositClaim{EventNonce: 1}}
+
+	// unknown claim types are rejected
+	err := k.AttestationHandler.Handle(ctx, types.Attestation{}, claim)
+	require.Error(t, err)
+
+	var handled []uint64
+	k.RegisterClaimHandler(customClaimType, func(_ sdk.Context, _ Keeper, c types.EthereumClaim) error {
+		handled = append(handled, c.GetEventNonce())
+		return nil
+	})
+	// the registry is shared with the copy of the keeper held by the attestation handler
+	require.NoError(t, k.AttestationHandler.Handle(ctx, types.Attestation{}, claim))
+	assert.Equal(t, []uint64{1}, handled)
+
+	// built in and already registered handlers can not be replaced
+	assert.Panics(t, func() {
+		k.RegisterClaimHandler(types.CLAIM_TYPE_DEPOSIT, func(sdk.Context, Keeper, types.EthereumClaim) error { return nil })
+	})
+	assert.Panics(t, func() {
+		k.RegisterClaimHandler(customClaimType, func(sdk.Context, Keeper, types.EthereumClaim) error { return nil })
+	})
+}
+
+func TestClaimHandlerEvents(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	var fail bool
+	k.RegisterClaimHandler(customClaimType, func(ctx sdk.Context, _ Keeper, c types.EthereumClaim) error {
+		ctx.EventManager().EmitEvent(sdk.NewEvent("custom_claim", sdk.NewAttribute(types.AttributeKeyNonce, fmt.Sprint(c.GetEventNonce()))))
+		if fail {
+			return types.ErrInvalid
+		}
+		return nil
+	})
+
+	// the handler runs on a cache context, its events are kept along with its state changes
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	k.processAttestation(ctx, &types.Attestation{}, customClaim{&types.MsgDepositClaim{EventNonce: 1}})
+	require.Len(t, ctx.EventManager().Events(), 1)
+	assert.Equal(t, sdk.NewEvent("custom_claim", sdk.NewAttribute(types.AttributeKeyNonce, "1")), ctx.EventManager().Events()[0])
+
+	// and dropped with them when it fails
+	fail = true
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	k.processAttestation(ctx, &types.Attestation{}, customClaim{&types.MsgDepositClaim{EventNonce: 2}})
+	assert.Empty(t, ctx.EventManager().Events())
+}
+
+// Deposits into a vesting account are credited as spendable balance, the vesting schedule only
+// locks the coins the account was created with
+func TestDepositClaimIntoVestingAccount(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context.WithBlockTime(time.Unix(1000, 0))
+	k := input.PeggyKeeper
+	var (
+		tokenContract   = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		myReceiver      = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		originalVesting = sdk.Coins{sdk.NewInt64Coin("stake", 1000)}
+		halfVesting     = sdk.Coins{sdk.NewInt64Coin("stake", 500)}
+		deposit         = types.NewERC20Token(100, tokenContract).PeggyCoin()
+		start, end      = ctx.BlockTime().Unix(), ctx.BlockTime().Unix() + 1000
+	)
+	accounts := []authtypes.AccountI{
+		vestingtypes.NewContinuousVestingAccount(authtypes.NewBaseAccountWithAddress(AccAddrs[0]), originalVesting, start, end),
+		vestingtypes.NewPeriodicVestingAccount(authtypes.NewBaseAccountWithAddress(AccAddrs[1]), originalVesting, start,
+			vestingtypes.Periods{{Length: 500, Amount: halfVesting}, {Length: 500, Amount: halfVesting}}),
+	}
+	for i, acc := range accounts {
+		input.AccountKeeper.SetAccount(ctx, acc)
+		require.NoError(t, input.BankKeeper.SetBalances(ctx, acc.GetAddress(), originalVesting))
+
+		claim := &types.MsgDepositClaim{
+			EventNonce:     uint64(i + 1),
+			TokenContract:  tokenContract,
+			Amount:         deposit.Amount,
+			EthereumSender: myReceiver,
+			CosmosReceiver: acc.GetAddress().String(),
+		}
+		require.NoError(t, k.AttestationHandler.Handle(ctx, types.Attestation{}, claim))
+
+		// the vouchers can be spent right away while the vested coins stay locked
+		vacc := input.AccountKeeper.GetAccount(ctx, acc.GetAddress()).(vestexported.VestingAccount)
+		assert.Equal(t, originalVesting, vacc.GetOriginalVesting())
+		assert.Equal(t, originalVesting, vacc.LockedCoins(ctx.BlockTime()))
+		assert.Equal(t, sdk.Coins{deposit}, input.BankKeeper.SpendableCoins(ctx, acc.GetAddress()))
+		_, err := k.AddToOutgoingPool(ctx, acc.GetAddress(), myReceiver, deposit, sdk.NewCoin(deposit.Denom, sdk.ZeroInt()))
+		require.NoError(t, err)
+
+		// and the schedule goes on as before
+		halfway := ctx.BlockTime().Add(500 * time.Second)
+		assert.Equal(t, halfVesting, vacc.LockedCoins(halfway))
+	}
+}
+
+func TestDepositClaimHexReceiver(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	var (
+		tokenContract = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		ethSender     = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		hexReceiver   = "0x" + hex.EncodeToString(AccAddrs[0].Bytes())
+		deposit       = types.NewERC20Token(100, tokenContract).PeggyCoin()
+	)
+	claim := func(nonce uint64) *types.MsgDepositClaim {
+		return &types.MsgDepositClaim{
+			EventNonce:     nonce,
+			TokenContract:  tokenContract,
+			Amount:         deposit.Amount,
+			EthereumSender: ethSender,
+			CosmosReceiver: hexReceiver,
+		}
+	}
+
+	// by default a hex receiver is invalid and the deposit becomes reclaimable
+	require.NoError(t, k.AttestationHandler.Handle(ctx, types.Attestation{}, claim(1)))
+	assert.NotNil(t, k.GetReclaimableDeposit(ctx, 1))
+	assert.True(t, input.BankKeeper.GetAllBalances(ctx, AccAddrs[0]).IsZero())
+
+	// once enabled it is credited to the account with the same bytes
+	params := k.GetParams(ctx)
+	params.HexCosmosReceivers = true
+	k.SetParams(ctx, params)
+	require.NoError(t, k.AttestationHandler.Handle(ctx, types.Attestation{}, claim(2)))
+	assert.Nil(t, k.GetReclaimableDeposit(ctx, 2))
+	assert.Equal(t, sdk.Coins{deposit}, input.BankKeeper.GetAllBalances(ctx, AccAddrs[0]))
+
+	addr, err := k.ParseCosmosReceiver(ctx, AccAddrs[1].String())
+	require.NoError(t, err)
+	assert.Equal(t, AccAddrs[1], addr)
+	_, err = k.ParseCosmosReceiver(ctx, "0x1234")
+	assert.Error(t, err)
+}
+
+func TestDepositClaimFeeOnTransfer(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	var (
+		tokenContract = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		ethSender     = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		receiver      = AccAddrs[0]
+		denom         = types.NewERC20Token(1, tokenContract).PeggyCoin().Denom
+	)
+	claim := func(nonce uint64, amount, received int64) *types.MsgDepositClaim {
+		receivedAmount := sdk.NewInt(received)
+		return &types.MsgDepositClaim{
+			EventNonce:     nonce,
+			TokenContract:  tokenContract,
+			Amount:         sdk.NewInt(amount),
+			EthereumSender: ethSender,
+			CosmosReceiver: receiver.String(),
+			ReceivedAmount: &receivedAmount,
+		}
+	}
+	balance := func() sdk.Int {
+		return input.BankKeeper.GetBalance(ctx, receiver, denom).Amount
+	}
+
+	// a deposit received in full flags nothing
+	require.NoError(t, k.AttestationHandler.Handle(ctx, types.Attestation{}, claim(1, 100, 100)))
+	assert.Equal(t, sdk.NewInt(100), balance())
+	assert.Nil(t, k.GetTokenQuirk(ctx, tokenContract))
+
+	// only the received amount is credited and the token is flagged
+	require.NoError(t, k.AttestationHandler.Handle(ctx, types.Attestation{}, claim(2, 100, 98)))
+	assert.Equal(t, sdk.NewInt(198), balance())
+	assert.Equal(t, sdk.NewInt(198), k.GetBridgeStats(ctx, tokenContract).TotalDeposited)
+	exp := types.TokenQuirk{
+		TokenContract:    tokenContract,
+		FeeOnTransfer:    true,
+		DiscrepancyCount: 1,
+		LastEventNonce:   2,
+		Shortfall:        sdk.NewInt(2),
+	}
+	assert.Equal(t, &exp, k.GetTokenQuirk(ctx, tokenContract))
+
+	// more than the amount is flagged but never credited, nothing received credits nothing
+	require.NoError(t, k.AttestationHandler.Handle(ctx, types.Attestation{}, claim(3, 100, 105)))
+	require.NoError(t, k.AttestationHandler.Handle(ctx, types.Attestation{}, claim(4, 10, 0)))
+	assert.Equal(t, sdk.NewInt(298), balance())
+	exp.Rebasing = true
+	exp.DiscrepancyCount = 3
+	exp.LastEventNonce = 4
+	exp.Shortfall = sdk.NewInt(12)
+	assert.Equal(t, &exp, k.GetTokenQuirk(ctx, tokenContract))
+
+	res, err := k.TokenQuirks(sdk.WrapSDKContext(ctx), &types.QueryTokenQuirksRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []types.TokenQuirk{exp}, res.Quirks)
+	res, err = k.TokenQuirks(sdk.WrapSDKContext(ctx), &types.QueryTokenQuirksRequest{TokenContract: ethSender})
+	require.NoError(t, err)
+	assert.Empty(t, res.Quirks)
+	assert.Equal(t, []types.TokenQuirk{exp}, ExportGenesis(ctx, k).TokenQuirks)
+}
@@ -0,0 +1,138 @@
+package keeper
+
+import (
+	"bytes"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// GetAttestationVotes returns the validators that voted for each claim hash of an event nonce
+func (k Keeper) GetAttestationVotes(ctx sdk.Context, eventNonce uint64) (out []types.ClaimVotes) {
+	for _, att := range k.GetAttestationsByNonce(ctx, eventNonce) {
+		_, votes := k.claimVotes(att)
+		out = append(out, votes)
+	}
+	return out
+}
+
+// GetClaimDivergence returns the claims of an event that is not observed yet with the current power of
+// the validators backing each, ordered by power, and the bonded validators that didn't claim the event.
+// The power is counted like TryAttestation does, so a claim is observed once its power reaches the
+// required power.
+func (k Keeper) GetClaimDivergence(ctx sdk.Context, eventNonce uint64) (*types.QueryClaimDivergenceResponse, error) {
+	claims := k.GetAttestationVotes(ctx, eventNonce)
+	if len(claims) == 0 {
+		return nil, sdkerrors.Wrapf(types.ErrUnknown, "no attestation for event nonce %d", eventNonce)
+	}
+	totalPower := k.StakingKeeper.GetLastTotalPower(ctx)
+	res := &types.QueryClaimDivergenceResponse{
+		TotalPower:    totalPower.Int64(),
+		RequiredPower: types.AttestationVotesPowerThreshold.Mul(totalPower).Quo(sdk.NewInt(100)).Int64(),
+	}
+	voted := make(map[string]bool)
+	for _, claim := range claims {
+		if claim.Observed {
+			return nil, sdkerrors.Wrapf(types.ErrOutdated, "event nonce %d is observed", eventNonce)
+		}
+		divergent := types.DivergentClaim{
+			ClaimHash:  claim.ClaimHash,
+			ClaimType:  claim.ClaimType,
+			Validators: claim.Validators,
+		}
+		for _, validator := range claim.Validators {
+			val, err := sdk.ValAddressFromBech32(validator)
+			if err != nil {
+				panic(err)
+			}
+			divergent.Power += k.StakingKeeper.GetLastValidatorPower(ctx, val)
+			voted[validator] = true
+		}
+		res.Claims = append(res.Claims, divergent)
+	}
+	sort.SliceStable(res.Claims, func(i, j int) bool {
+		return res.Claims[i].Power > res.Claims[j].Power
+	})
+	for _, val := range k.StakingKeeper.GetBondedValidatorsByPower(ctx) {
+		if voted[val.GetOperator().String()] {
+			continue
+		}
+		res.MissingValidators = append(res.MissingValidators, val.GetOperator().String())
+		res.MissingPower += k.StakingKeeper.GetLastValidatorPower(ctx, val.GetOperator())
+	}
+	return res, nil
+}
+
+// claimVotes returns the event nonce of an attestation and the votes for its claim
+func (k Keeper) claimVotes(att types.Attestation) (uint64, types.ClaimVotes) {
+	claim, err := k.UnpackAttestationClaim(&att)
+	if err != nil {
+		panic("couldn't cast to claim")
+	}
+	return claim.GetEventNonce(), types.ClaimVotes{
+		ClaimHash:  claim.ClaimHash(),
+		ClaimType:  claim.GetType(),
+		Observed:   att.Observed,
+		Validators: att.Votes,
+	}
+}
+
+// IterateValidatorAttestationRecord calls cb in event nonce order with the vote of a validator for the
+// stored attestations from the event nonce on, or with the nonce of an observed event it did not vote for
+func (k Keeper) IterateValidatorAttestationRecord(ctx sdk.Context, validator sdk.ValAddress, fromNonce uint64, cb func(vote *types.ValidatorAttestationVote, missedNonce uint64)) {
+	store := ctx.KVStore(k.storeKey)
+	iter := store.Iterator(types.GetAttestationKey(fromNonce, nil), sdk.PrefixEndBytes(types.OracleAttestationKey))
+	defer iter.Close()
+
+	// the attestations of a nonce are adjacent, they are collected and then evaluated together
+	var (
+		nonce  uint64
+		claims []types.ClaimVotes
+	)
+	flush := func() {
+		if len(claims) == 0 {
+			return
+		}
+		var (
+			vote     *types.ValidatorAttestationVote
+			observed []byte
+		)
+		for _, claim := range claims {
+			if claim.Observed {
+				observed = claim.ClaimHash
+			}
+			for _, v := range claim.Validators {
+				if v == validator.String() {
+					vote = &types.ValidatorAttestationVote{
+						EventNonce: nonce,
+						ClaimHash:  claim.ClaimHash,
+						ClaimType:  claim.ClaimType,
+						Observed:   claim.Observed,
+					}
+				}
+			}
+		}
+		switch {
+		case vote != nil:
+			vote.Dissenting = observed != nil && !bytes.Equal(observed, vote.ClaimHash)
+			cb(vote, 0)
+		case observed != nil:
+			cb(nil, nonce)
+		}
+		claims = nil
+	}
+
+	for ; iter.Valid(); iter.Next() {
+		var att types.Attestation
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &att)
+		eventNonce, votes := k.claimVotes(att)
+		if eventNonce != nonce {
+			flush()
+			nonce = eventNonce
+		}
+		claims = append(claims, votes)
+	}
+	flush()
+}
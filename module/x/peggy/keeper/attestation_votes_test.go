@@ -0,0 +1,132 @@
+package keeper
+
+import (
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttestationVotes(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	attest := func(nonce uint64, amount int64, observed bool, validators ...sdk.ValAddress) *types.MsgDepositClaim {
+		claim := &types.MsgDepositClaim{
+			EventNonce:     nonce,
+			TokenContract:  TokenContractAddrs[0],
+			Amount:         sdk.NewInt(amount),
+			EthereumSender: EthAddrs[0].String(),
+			CosmosReceiver: AccAddrs[0].String(),
+		}
+		any, err := codectypes.NewAnyWithValue(claim)
+		require.NoError(t, err)
+		var votes []string
+		for _, val := range validators {
+			votes = append(votes, val.String())
+		}
+		k.SetAttestation(ctx, nonce, claim.ClaimHash(), &types.Attestation{
+			Observed: observed,
+			Votes:    votes,
+			Claim:    any,
+		})
+		return claim
+	}
+	// validator 1 disagrees on event 1, misses event 2 and is the only one on event 3 so far
+	agreed := attest(1, 100, true, ValAddrs[0], ValAddrs[2])
+	dissent := attest(1, 101, false, ValAddrs[1])
+	attest(2, 100, true, ValAddrs[0], ValAddrs[2])
+	pending := attest(3, 100, false, ValAddrs[1])
+	k.setLastEventNonceByValidator(ctx, ValAddrs[1], 3)
+
+	res, err := k.AttestationVotes(sdk.WrapSDKContext(ctx), &types.QueryAttestationVotesRequest{EventNonce: 1})
+	require.NoError(t, err)
+	require.Len(t, res.Claims, 2)
+	byHash := make(map[string]types.ClaimVotes)
+	for _, claim := range res.Claims {
+		byHash[string(claim.ClaimHash)] = claim
+	}
+	assert.Equal(t, types.ClaimVotes{
+		ClaimHash:  agreed.ClaimHash(),
+		ClaimType:  types.CLAIM_TYPE_DEPOSIT,
+		Observed:   true,
+		Validators: []string{ValAddrs[0].String(), ValAddrs[2].String()},
+	}, byHash[string(agreed.ClaimHash())])
+	assert.Equal(t, []string{ValAddrs[1].String()}, byHash[string(dissent.ClaimHash())].Validators)
+	_, err = k.AttestationVotes(sdk.WrapSDKContext(ctx), &types.QueryAttestationVotesRequest{EventNonce: 4})
+	assert.Error(t, err)
+
+	record, err := k.ValidatorAttestationRecord(sdk.WrapSDKContext(ctx), &types.QueryValidatorAttestationRecordRequest{Validator: ValAddrs[1].String()})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), record.LastEventNonce)
+	assert.Equal(t, []types.ValidatorAttestationVote{
+		{EventNonce: 1, ClaimHash: dissent.ClaimHash(), ClaimType: types.CLAIM_TYPE_DEPOSIT, Dissenting: true},
+		{EventNonce: 3, ClaimHash: pending.ClaimHash(), ClaimType: types.CLAIM_TYPE_DEPOSIT},
+	}, record.Votes)
+	assert.Equal(t, []uint64{2}, record.MissedNonces)
+
+	// the orchestrator address of the validator works as well, and the record starts at the nonce
+	k.SetOrchestratorValidator(ctx, ValAddrs[1], AccAddrs[1])
+	record, err = k.ValidatorAttestationRecord(sdk.WrapSDKContext(ctx), &types.QueryValidatorAttestationRecordRequest{Validator: AccAddrs[1].String(), FromNonce: 2})
+	require.NoError(t, err)
+	require.Len(t, record.Votes, 1)
+	assert.Equal(t, uint64(3), record.Votes[0].EventNonce)
+	assert.Equal(t, []uint64{2}, record.MissedNonces)
+
+	_, err = k.ValidatorAttestationRecord(sdk.WrapSDKContext(ctx), &types.QueryValidatorAttestationRecordRequest{Validator: AccAddrs[4].String()})
+	assert.Error(t, err)
+}
+
+func TestClaimDivergence(t *testing.T) {
+	t.Parallel()
+	input, ctx := SetupFiveValChain(t)
+	k := input.PeggyKeeper
+	attest := func(nonce uint64, amount int64, observed bool, validators ...sdk.ValAddress) *types.MsgDepositClaim {
+		claim := &types.MsgDepositClaim{
+			EventNonce:     nonce,
+			TokenContract:  TokenContractAddrs[0],
+			Amount:         sdk.NewInt(amount),
+			EthereumSender: EthAddrs[0].String(),
+			CosmosReceiver: AccAddrs[0].String(),
+		}
+		any, err := codectypes.NewAnyWithValue(claim)
+		require.NoError(t, err)
+		var votes []string
+		for _, val := range validators {
+			votes = append(votes, val.String())
+		}
+		k.SetAttestation(ctx, nonce, claim.ClaimHash(), &types.Attestation{Observed: observed, Votes: votes, Claim: any})
+		return claim
+	}
+	power := input.StakingKeeper.GetLastValidatorPower(ctx, ValAddrs[0])
+	require.Positive(t, power)
+
+	// event 2 is split 1 to 2 and validators 3 and 4 didn't claim it
+	attest(1, 100, true, ValAddrs[0], ValAddrs[1], ValAddrs[2], ValAddrs[3])
+	minority := attest(2, 101, false, ValAddrs[0])
+	majority := attest(2, 100, false, ValAddrs[1], ValAddrs[2])
+
+	res, err := k.ClaimDivergence(sdk.WrapSDKContext(ctx), &types.QueryClaimDivergenceRequest{EventNonce: 2})
+	require.NoError(t, err)
+	assert.Equal(t, &types.QueryClaimDivergenceResponse{
+		Claims: []types.DivergentClaim{
+			{ClaimHash: majority.ClaimHash(), ClaimType: types.CLAIM_TYPE_DEPOSIT, Validators: []string{ValAddrs[1].String(), ValAddrs[2].String()}, Power: 2 * power},
+			{ClaimHash: minority.ClaimHash(), ClaimType: types.CLAIM_TYPE_DEPOSIT, Validators: []string{ValAddrs[0].String()}, Power: power},
+		},
+		MissingValidators: res.MissingValidators,
+		MissingPower:      2 * power,
+		TotalPower:        5 * power,
+		RequiredPower:     5 * power * 66 / 100,
+	}, res)
+	assert.ElementsMatch(t, []string{ValAddrs[3].String(), ValAddrs[4].String()}, res.MissingValidators)
+
+	// observed events don't diverge anymore
+	_, err = k.ClaimDivergence(sdk.WrapSDKContext(ctx), &types.QueryClaimDivergenceRequest{EventNonce: 1})
+	assert.True(t, types.ErrOutdated.Is(err), err)
+	_, err = k.ClaimDivergence(sdk.WrapSDKContext(ctx), &types.QueryClaimDivergenceRequest{EventNonce: 3})
+	assert.True(t, types.ErrUnknown.Is(err), err)
+}
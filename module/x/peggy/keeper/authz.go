@@ -0,0 +1,127 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// unpackAuthorization returns the authorization of a grant
+func (k Keeper) unpackAuthorization(grant types.Grant) (types.Authorization, error) {
+	var authorization types.Authorization
+	if err := k.cdc.UnpackAny(grant.Authorization, &authorization); err != nil {
+		return nil, sdkerrors.Wrap(err, "authorization")
+	}
+	return authorization, nil
+}
+
+// SaveGrant stores a grant of the granter to the grantee, replacing an earlier grant between the
+// accounts for the same message type
+func (k Keeper) SaveGrant(ctx sdk.Context, granter, grantee sdk.AccAddress, grant types.Grant) error {
+	if grant.IsExpired(ctx.BlockTime().Unix()) {
+		return sdkerrors.Wrap(types.ErrInvalid, "expiration time in the past")
+	}
+	return k.setGrant(ctx, granter, grantee, grant)
+}
+
+func (k Keeper) setGrant(ctx sdk.Context, granter, grantee sdk.AccAddress, grant types.Grant) error {
+	authorization, err := k.unpackAuthorization(grant)
+	if err != nil {
+		return err
+	}
+	value := types.GrantAuthorization{Granter: granter.String(), Grantee: grantee.String(), Grant: grant}
+	ctx.KVStore(k.storeKey).Set(types.GetGrantKey(granter, grantee, authorization.MsgTypeURL()), k.cdc.MustMarshalBinaryBare(&value))
+	return nil
+}
+
+// GetGrant returns the grant of the granter to the grantee for the message type, nil if there is none
+func (k Keeper) GetGrant(ctx sdk.Context, granter, grantee sdk.AccAddress, msgTypeURL string) *types.GrantAuthorization {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetGrantKey(granter, grantee, msgTypeURL))
+	if bz == nil {
+		return nil
+	}
+	var grant types.GrantAuthorization
+	k.cdc.MustUnmarshalBinaryBare(bz, &grant)
+	return &grant
+}
+
+// DeleteGrant removes the grant of the granter to the grantee for the message type
+func (k Keeper) DeleteGrant(ctx sdk.Context, granter, grantee sdk.AccAddress, msgTypeURL string) error {
+	key := types.GetGrantKey(granter, grantee, msgTypeURL)
+	store := ctx.KVStore(k.storeKey)
+	if !store.Has(key) {
+		return sdkerrors.Wrapf(types.ErrUnknown, "grant of %s to %s for %s", granter, grantee, msgTypeURL)
+	}
+	store.Delete(key)
+	return nil
+}
+
+// IterateGrants iterates over the grants given by the granter ordered by grantee and message type,
+// a nil granter iterates over all grants
+func (k Keeper) IterateGrants(ctx sdk.Context, granter sdk.AccAddress, cb func(types.GrantAuthorization) bool) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), append(types.GrantKey, granter.Bytes()...))
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var grant types.GrantAuthorization
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &grant)
+		// cb returns true to stop early
+		if cb(grant) {
+			break
+		}
+	}
+}
+
+// GetAllGrants returns all stored grants, expired ones included
+func (k Keeper) GetAllGrants(ctx sdk.Context) (out []types.GrantAuthorization) {
+	k.IterateGrants(ctx, nil, func(grant types.GrantAuthorization) bool {
+		out = append(out, grant)
+		return false
+	})
+	return
+}
+
+// AuthorizeMsg checks that the grantee may execute the message on behalf of its signer and updates the
+// grant it was executed under, a message signed by the grantee itself needs no grant
+func (k Keeper) AuthorizeMsg(ctx sdk.Context, grantee sdk.AccAddress, msg sdk.Msg) error {
+	signers := msg.GetSigners()
+	if len(signers) != 1 {
+		return sdkerrors.Wrap(types.ErrInvalid, "message must have a single signer")
+	}
+	granter := signers[0]
+	if granter.Equals(grantee) {
+		return nil
+	}
+
+	msgTypeURL := types.MsgTypeURL(msg)
+	grant := k.GetGrant(ctx, granter, grantee, msgTypeURL)
+	if grant == nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "no grant of %s to %s for %s", granter, grantee, msgTypeURL)
+	}
+	if grant.Grant.IsExpired(ctx.BlockTime().Unix()) {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "grant of %s to %s for %s expired", granter, grantee, msgTypeURL)
+	}
+	authorization, err := k.unpackAuthorization(grant.Grant)
+	if err != nil {
+		return err
+	}
+	res, err := authorization.Accept(ctx, msg)
+	if err != nil {
+		return err
+	}
+	if !res.Accept {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "grant of %s to %s for %s", granter, grantee, msgTypeURL)
+	}
+
+	switch {
+	case res.Delete:
+		return k.DeleteGrant(ctx, granter, grantee, msgTypeURL)
+	case res.Updated != nil:
+		updated, err := types.NewGrant(res.Updated, grant.Grant.ExpirationTime)
+		if err != nil {
+			return err
+		}
+		return k.SaveGrant(ctx, granter, grantee, updated)
+	}
+	return nil
+}
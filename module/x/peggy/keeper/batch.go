@@ -14,13 +14,27 @@ const OutgoingTxBatchSize = 100
 
 // BuildOutgoingTXBatch starts the following process chain:
 // - find bridged denominator for given voucher type
-// - select available transactions from the outgoing transaction pool sorted by fee desc
+// - select available transactions from the outgoing transaction pool sorted by fee desc, as many as fit under TargetBatchGas
 // - persist an outgoing batch object with an incrementing ID = nonce
 // - emit an event
 func (k Keeper) BuildOutgoingTXBatch(ctx sdk.Context, contractAddress string, maxElements int) (*types.OutgoingTxBatch, error) {
+	return k.buildOutgoingTXBatch(ctx, contractAddress, maxElements, "")
+}
+
+// buildOutgoingTXBatch builds a batch recording the account that requested it, empty if the module built it
+func (k Keeper) buildOutgoingTXBatch(ctx sdk.Context, contractAddress string, maxElements int, requester string) (*types.OutgoingTxBatch, error) {
 	if maxElements == 0 {
 		return nil, sdkerrors.Wrap(types.ErrInvalid, "max elements value")
 	}
+	if k.IsTokenPaused(ctx, contractAddress) {
+		return nil, sdkerrors.Wrap(types.ErrTokenPaused, contractAddress)
+	}
+	if limit := k.getBatchTxLimit(ctx, contractAddress); limit < maxElements {
+		if limit == 0 {
+			return nil, sdkerrors.Wrapf(types.ErrInvalid, "no transfer of %s fits under the target batch gas", contractAddress)
+		}
+		maxElements = limit
+	}
 	selectedTx, err := k.pickUnbatchedTX(ctx, contractAddress, maxElements)
 	if len(selectedTx) == 0 || err != nil {
 		return nil, err
@@ -31,8 +45,10 @@ func (k Keeper) BuildOutgoingTXBatch(ctx sdk.Context, contractAddress string, ma
 		BatchTimeout:  k.getBatchTimeoutHeight(ctx),
 		Transactions:  selectedTx,
 		TokenContract: contractAddress,
+		Requester:     requester,
 	}
 	k.StoreBatch(ctx, batch)
+	k.AfterBatchCreated(ctx, batch)
 
 	batchEvent := sdk.NewEvent(
 		types.EventTypeOutgoingBatch,
@@ -46,37 +62,91 @@ func (k Keeper) BuildOutgoingTXBatch(ctx sdk.Context, contractAddress string, ma
 	return batch, nil
 }
 
-/// This gets the batch timeout height in Ethereum blocks.
-func (k Keeper) getBatchTimeoutHeight(ctx sdk.Context) uint64 {
+// RequestOutgoingTXBatch builds a batch on behalf of any account. To make this safe against griefing
+// the total fee of the batch has to reach BatchRequestMinFee and a token contract can only be
+// requested once every BatchRequestCooldown blocks
+func (k Keeper) RequestOutgoingTXBatch(ctx sdk.Context, contractAddress string, requester sdk.AccAddress) (*types.OutgoingTxBatch, error) {
 	params := k.GetParams(ctx)
-	currentCosmosHeight := ctx.BlockHeight()
-	// we store the last observed Cosmos and Ethereum heights, we do not concern ourselves if these values
-	// are zero because no batch can be produced if the last Ethereum block height is not first populated by a deposit event.
-	heights := k.GetLastObservedEthereumBlockHeight(ctx)
-	if heights.CosmosBlockHeight == 0 || heights.EthereumBlockHeight == 0 {
-		return 0
+	cooldown := params.BatchRequestCooldown
+	if last, found := k.GetLastBatchRequestHeight(ctx, contractAddress); found && uint64(ctx.BlockHeight()) < last+cooldown {
+		return nil, sdkerrors.Wrapf(types.ErrInvalid, "batch for %s already requested at height %d, cooldown %d blocks", contractAddress, last, cooldown)
+	}
+
+	minFee := params.BatchRequestMinFee
+	limit := k.getBatchTxLimit(ctx, contractAddress)
+	totalFee := sdk.ZeroInt()
+	count := 0
+	k.IterateOutgoingPoolByFee(ctx, contractAddress, func(_ uint64, tx *types.OutgoingTransferTx) bool {
+		totalFee = totalFee.Add(tx.Erc20Fee.Amount)
+		count++
+		return count >= limit
+	})
+	if count == 0 {
+		return nil, sdkerrors.Wrapf(types.ErrEmpty, "no transactions for %s", contractAddress)
+	}
+	if totalFee.LT(minFee) {
+		return nil, sdkerrors.Wrapf(types.ErrInvalid, "batch fee %s below minimum %s", totalFee, minFee)
+	}
+
+	batch, err := k.buildOutgoingTXBatch(ctx, contractAddress, OutgoingTxBatchSize, requester.String())
+	if err != nil {
+		return nil, err
+	}
+	k.setLastBatchRequestHeight(ctx, contractAddress, uint64(ctx.BlockHeight()))
+	return batch, nil
+}
+
+// GetLastBatchRequestHeight returns the height at which a batch was last requested for the token contract
+func (k Keeper) GetLastBatchRequestHeight(ctx sdk.Context, contractAddress string) (uint64, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetLastBatchRequestHeightKey(contractAddress))
+	if bz == nil {
+		return 0, false
 	}
-	// we project how long it has been in milliseconds since the last Ethereum block height was observed
-	projected_millis := (uint64(currentCosmosHeight) - heights.CosmosBlockHeight) * params.AverageBlockTime
-	// we convert that projection into the current Ethereum height using the average Ethereum block time in millis
-	projected_current_ethereum_height := (projected_millis / params.AverageEthereumBlockTime) + heights.EthereumBlockHeight
-	// we convert our target time for block timeouts (lets say 12 hours) into a number of blocks to
-	// place on top of our projection of the current Ethereum block height.
-	blocks_to_add := params.TargetBatchTimeout / params.AverageEthereumBlockTime
-	return projected_current_ethereum_height + blocks_to_add
+	return types.UInt64FromBytes(bz), true
+}
+
+func (k Keeper) setLastBatchRequestHeight(ctx sdk.Context, contractAddress string, height uint64) {
+	ctx.KVStore(k.storeKey).Set(types.GetLastBatchRequestHeightKey(contractAddress), types.UInt64Bytes(height))
 }
 
 // OutgoingTxBatchExecuted is run when the Cosmos chain detects that a batch has been executed on Ethereum
 // It frees all the transactions in the batch, then cancels all earlier batches
 func (k Keeper) OutgoingTxBatchExecuted(ctx sdk.Context, tokenContract string, nonce uint64) error {
 	b := k.GetOutgoingTXBatch(ctx, tokenContract, nonce)
+	if b == nil {
+		// a cancelled batch can still be executed with the confirms it collected
+		b = k.GetCancelledBatch(ctx, tokenContract, nonce)
+	}
 	if b == nil {
 		return sdkerrors.Wrap(types.ErrUnknown, "nonce")
 	}
 
+	// a transfer can only ever be executed once, if one of them was already processed we are
+	// looking at a replayed batch or a bug in reorg handling and must not touch the pool again
+	for _, tx := range b.Transactions {
+		if k.IsTxProcessed(ctx, tx.Id) {
+			k.logger(ctx).Error("batch contains already processed tx", "nonce", nonce, "token", tokenContract, "tx", tx.Id)
+			return sdkerrors.Wrapf(types.ErrDuplicate, "tx %d in batch %d already processed", tx.Id, nonce)
+		}
+	}
+
 	// cleanup outgoing TX pool
+	cosmosOriginatedFees, ethereumOriginatedFees := sdk.NewCoins(), sdk.NewCoins()
 	for _, tx := range b.Transactions {
 		k.removePoolEntry(ctx, tx.Id)
+		k.SetTxProcessed(ctx, tx.Id)
+		k.deleteBatchedTx(ctx, tx.Id)
+
+		isCosmosOriginated, fee := k.ERC20ToCoin(ctx, *tx.Erc20Fee)
+		if isCosmosOriginated {
+			cosmosOriginatedFees = cosmosOriginatedFees.Add(fee)
+		} else {
+			ethereumOriginatedFees = ethereumOriginatedFees.Add(fee)
+		}
+	}
+	// the relayer was paid the fees on Ethereum
+	if err := k.releaseFees(ctx, cosmosOriginatedFees, ethereumOriginatedFees); err != nil {
+		return sdkerrors.Wrap(err, "release fees")
 	}
 
 	// Iterate through remaining batches
@@ -90,9 +160,48 @@ func (k Keeper) OutgoingTxBatchExecuted(ctx sdk.Context, tokenContract string, n
 
 	// Delete batch since it is finished
 	k.DeleteBatch(ctx, *b)
+	k.deleteCancelledBatch(ctx, tokenContract, nonce)
+	k.releaseInvalidatedCancelledBatches(ctx, tokenContract, nonce)
+	// the earlier batches can't be executed anymore, drop the confirms left behind by batches that
+	// were deleted before this change
+	k.deleteBatchConfirms(ctx, tokenContract, 0, nonce)
 	return nil
 }
 
+// SetTxProcessed marks the outgoing transfer with the given id as executed on Ethereum
+func (k Keeper) SetTxProcessed(ctx sdk.Context, txID uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetProcessedTxIDKey(txID), []byte{0x1})
+}
+
+// IsTxProcessed returns true if the outgoing transfer with the given id was executed on Ethereum
+func (k Keeper) IsTxProcessed(ctx sdk.Context, txID uint64) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.GetProcessedTxIDKey(txID))
+}
+
+// IterateProcessedTxIDs iterates through all processed outgoing transfer ids in ASC order
+func (k Keeper) IterateProcessedTxIDs(ctx sdk.Context, cb func(txID uint64) bool) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.ProcessedTxIDKey)
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		// cb returns true to stop early
+		if cb(types.UInt64FromBytes(iter.Key())) {
+			break
+		}
+	}
+}
+
+// GetProcessedTxIDs returns the ids of all outgoing transfers executed on Ethereum
+func (k Keeper) GetProcessedTxIDs(ctx sdk.Context) (out []uint64) {
+	k.IterateProcessedTxIDs(ctx, func(txID uint64) bool {
+		out = append(out, txID)
+		return false
+	})
+	return
+}
+
 // StoreBatch stores a transaction batch
 func (k Keeper) StoreBatch(ctx sdk.Context, batch *types.OutgoingTxBatch) {
 	store := ctx.KVStore(k.storeKey)
@@ -103,6 +212,7 @@ func (k Keeper) StoreBatch(ctx sdk.Context, batch *types.OutgoingTxBatch) {
 
 	blockKey := types.GetOutgoingTxBatchBlockKey(batch.Block)
 	store.Set(blockKey, k.cdc.MustMarshalBinaryBare(batch))
+	k.setBatchedTxs(ctx, batch)
 }
 
 // StoreBatchUnsafe stores a transaction batch w/o setting the height
@@ -113,30 +223,57 @@ func (k Keeper) StoreBatchUnsafe(ctx sdk.Context, batch *types.OutgoingTxBatch)
 
 	blockKey := types.GetOutgoingTxBatchBlockKey(batch.Block)
 	store.Set(blockKey, k.cdc.MustMarshalBinaryBare(batch))
+	k.setBatchedTxs(ctx, batch)
 }
 
-// DeleteBatch deletes an outgoing transaction batch
+// DeleteBatch deletes an outgoing transaction batch and its confirmations
 func (k Keeper) DeleteBatch(ctx sdk.Context, batch types.OutgoingTxBatch) {
 	store := ctx.KVStore(k.storeKey)
 	store.Delete(types.GetOutgoingTxBatchKey(batch.TokenContract, batch.BatchNonce))
 	store.Delete(types.GetOutgoingTxBatchBlockKey(batch.Block))
+	k.deleteBatchConfirms(ctx, batch.TokenContract, batch.BatchNonce, batch.BatchNonce)
+}
+
+// deleteBatchConfirms deletes the confirmations of the batches of a token contract with a nonce
+// between fromNonce and toNonce, both inclusive
+func (k Keeper) deleteBatchConfirms(ctx sdk.Context, tokenContract string, fromNonce, toNonce uint64) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetBatchConfirmContractPrefix(tokenContract))
+	iter := prefixStore.Iterator(types.UInt64Bytes(fromNonce), types.UInt64Bytes(toNonce+1))
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+	// delete outside of the iteration to not modify the store while iterating it
+	for _, key := range keys {
+		prefixStore.Delete(key)
+	}
 }
 
 // pickUnbatchedTX find TX in pool and remove from "available" second index
 func (k Keeper) pickUnbatchedTX(ctx sdk.Context, contractAddress string, maxElements int) ([]*types.OutgoingTransferTx, error) {
 	var selectedTx []*types.OutgoingTransferTx
-	var err error
-	k.IterateOutgoingPoolByFee(ctx, contractAddress, func(txID uint64, tx *types.OutgoingTransferTx) bool {
+	// the index is fee ordered so only the top entries are read
+	k.IterateOutgoingPoolByFee(ctx, contractAddress, func(_ uint64, tx *types.OutgoingTransferTx) bool {
 		if tx != nil && tx.Erc20Fee != nil {
+			// expired txs are refunded by the EndBlocker
+			if tx.IsExpired(ctx.BlockHeight(), ctx.BlockTime()) {
+				return false
+			}
 			selectedTx = append(selectedTx, tx)
-			err = k.removeFromUnbatchedTXIndex(ctx, *tx.Erc20Fee, txID)
-			return err != nil || len(selectedTx) == maxElements
+			return len(selectedTx) == maxElements
 		} else {
 			// we found a nil, exit
 			return true
 		}
 	})
-	return selectedTx, err
+	// remove outside of the iteration to not modify the store while iterating it
+	for _, tx := range selectedTx {
+		if err := k.removeFromUnbatchedTXIndex(ctx, tx); err != nil {
+			return nil, err
+		}
+	}
+	return selectedTx, nil
 }
 
 // GetOutgoingTXBatch loads a batch object. Returns nil when not exists.
@@ -163,8 +300,13 @@ func (k Keeper) CancelOutgoingTXBatch(ctx sdk.Context, tokenContract string, non
 		return types.ErrUnknown
 	}
 	for _, tx := range batch.Transactions {
+		if k.IsTxProcessed(ctx, tx.Id) {
+			k.logger(ctx).Error("not releasing already processed tx", "nonce", nonce, "token", tokenContract, "tx", tx.Id)
+			continue
+		}
 		tx.Erc20Fee.Contract = tokenContract
-		k.prependToUnbatchedTXIndex(ctx, tokenContract, *tx.Erc20Fee, tx.Id)
+		k.addToUnbatchedTXIndex(ctx, tx)
+		k.deleteBatchedTx(ctx, tx.Id)
 	}
 
 	// Delete batch since it is finished
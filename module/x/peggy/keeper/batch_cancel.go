@@ -0,0 +1,148 @@
+package keeper
+
+import (
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// CancelBatch cancels a batch before it timed out, when the sender is the authority of the module or the
+// requester of the batch once BatchCancelGracePeriod blocks passed since the batch was created. The batch
+// and its confirms are deleted so the orchestrators and relayers stop working on it. A batch without
+// confirms can't be executed on Ethereum, its transactions return to the pool right away. Once confirmed
+// we can't tell whether the signatures are enough for the valset of the bridge contract, so the batch is
+// held as cancelled and its transactions only return to the pool once it can't be executed anymore: when
+// it timed out or a later batch of the token was executed. Returning them earlier would let the same
+// transactions be paid out twice, by the cancelled batch and by the batch they end up in next.
+func (k Keeper) CancelBatch(ctx sdk.Context, sender string, tokenContract string, nonce uint64) error {
+	batch := k.GetOutgoingTXBatch(ctx, tokenContract, nonce)
+	if batch == nil {
+		return sdkerrors.Wrapf(types.ErrUnknown, "batch %s/%d", tokenContract, nonce)
+	}
+	if sender != k.authority {
+		if batch.Requester == "" || batch.Requester != sender {
+			return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "only the authority %s or the requester of the batch can cancel it", k.authority)
+		}
+		grace := k.GetParams(ctx).BatchCancelGracePeriod
+		if grace == 0 {
+			return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "requesters can't cancel batches")
+		}
+		if uint64(ctx.BlockHeight()) < batch.Block+grace {
+			return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "the requester can cancel the batch from height %d", batch.Block+grace)
+		}
+	}
+
+	if !k.hasBatchConfirms(ctx, tokenContract, nonce) {
+		return k.CancelOutgoingTXBatch(ctx, tokenContract, nonce)
+	}
+	k.DeleteBatch(ctx, *batch)
+	k.setCancelledBatch(ctx, batch)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeOutgoingBatchCanceled,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyContract, k.GetBridgeContractAddress(ctx)),
+		sdk.NewAttribute(types.AttributeKeyBridgeChainID, strconv.Itoa(int(k.GetBridgeChainID(ctx)))),
+		sdk.NewAttribute(types.AttributeKeyOutgoingBatchID, fmt.Sprint(nonce)),
+		sdk.NewAttribute(types.AttributeKeyNonce, fmt.Sprint(nonce)),
+	))
+	return nil
+}
+
+// hasBatchConfirms returns true if any orchestrator confirmed the batch
+func (k Keeper) hasBatchConfirms(ctx sdk.Context, tokenContract string, nonce uint64) (found bool) {
+	k.IterateBatchConfirmByNonceAndTokenContract(ctx, nonce, tokenContract, func(_ []byte, _ types.MsgConfirmBatch) bool {
+		found = true
+		return true
+	})
+	return
+}
+
+// GetCancelledBatch returns a cancelled batch that may still be executed on Ethereum, nil if there is none
+func (k Keeper) GetCancelledBatch(ctx sdk.Context, tokenContract string, nonce uint64) *types.OutgoingTxBatch {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetCancelledBatchKey(tokenContract, nonce))
+	if bz == nil {
+		return nil
+	}
+	var batch types.OutgoingTxBatch
+	k.cdc.MustUnmarshalBinaryBare(bz, &batch)
+	return &batch
+}
+
+func (k Keeper) setCancelledBatch(ctx sdk.Context, batch *types.OutgoingTxBatch) {
+	ctx.KVStore(k.storeKey).Set(types.GetCancelledBatchKey(batch.TokenContract, batch.BatchNonce), k.cdc.MustMarshalBinaryBare(batch))
+}
+
+func (k Keeper) deleteCancelledBatch(ctx sdk.Context, tokenContract string, nonce uint64) {
+	ctx.KVStore(k.storeKey).Delete(types.GetCancelledBatchKey(tokenContract, nonce))
+}
+
+// IterateCancelledBatches iterates over the cancelled batches of a token contract in ascending nonce
+// order, an empty token contract iterates over the batches of all token contracts
+func (k Keeper) IterateCancelledBatches(ctx sdk.Context, tokenContract string, cb func(*types.OutgoingTxBatch) bool) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), append(types.CancelledBatchKey, []byte(tokenContract)...))
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var batch types.OutgoingTxBatch
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &batch)
+		// cb returns true to stop early
+		if cb(&batch) {
+			break
+		}
+	}
+}
+
+// GetCancelledBatches returns the cancelled batches that may still be executed on Ethereum
+func (k Keeper) GetCancelledBatches(ctx sdk.Context) (out []types.OutgoingTxBatch) {
+	k.IterateCancelledBatches(ctx, "", func(batch *types.OutgoingTxBatch) bool {
+		out = append(out, *batch)
+		return false
+	})
+	return
+}
+
+// releaseCancelledBatch returns the transactions of a cancelled batch that can't be executed on Ethereum
+// anymore to the pool
+func (k Keeper) releaseCancelledBatch(ctx sdk.Context, batch *types.OutgoingTxBatch) {
+	for _, tx := range batch.Transactions {
+		if k.IsTxProcessed(ctx, tx.Id) {
+			k.logger(ctx).Error("not releasing already processed tx", "nonce", batch.BatchNonce, "token", batch.TokenContract, "tx", tx.Id)
+			continue
+		}
+		tx.Erc20Fee.Contract = batch.TokenContract
+		k.addToUnbatchedTXIndex(ctx, tx)
+		k.deleteBatchedTx(ctx, tx.Id)
+	}
+	k.deleteCancelledBatch(ctx, batch.TokenContract, batch.BatchNonce)
+}
+
+// releaseInvalidatedCancelledBatches releases the cancelled batches of the token contract with a nonce
+// below the executed one, the bridge contract rejects them from now on
+func (k Keeper) releaseInvalidatedCancelledBatches(ctx sdk.Context, tokenContract string, executedNonce uint64) {
+	var invalidated []*types.OutgoingTxBatch
+	k.IterateCancelledBatches(ctx, tokenContract, func(batch *types.OutgoingTxBatch) bool {
+		if batch.BatchNonce >= executedNonce {
+			return true
+		}
+		invalidated = append(invalidated, batch)
+		return false
+	})
+	for _, batch := range invalidated {
+		k.releaseCancelledBatch(ctx, batch)
+	}
+}
+
+// ReleaseTimedOutCancelledBatches releases the cancelled batches with a timeout below the given Ethereum height
+func (k Keeper) ReleaseTimedOutCancelledBatches(ctx sdk.Context, ethereumHeight uint64) {
+	k.scanWithWorkBudget(ctx, workTaskReleaseCancelledBatches, types.CancelledBatchKey, func(_, value []byte) {
+		var batch types.OutgoingTxBatch
+		k.cdc.MustUnmarshalBinaryBare(value, &batch)
+		if batch.BatchTimeout < ethereumHeight {
+			k.releaseCancelledBatch(ctx, &batch)
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancelBatch(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	var (
+		mySender, _         = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		myReceiver          = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		myOrchestrator      = AccAddrs[0]
+		allVouchers         = sdk.Coins{types.NewERC20Token(99999, myTokenContractAddr).PeggyCoin()}
+	)
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, allVouchers))
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+	require.NoError(t, input.BankKeeper.SetBalances(ctx, mySender, allVouchers))
+	for i := uint64(1); i <= 4; i++ {
+		amount := types.NewERC20Token(100, myTokenContractAddr).PeggyCoin()
+		fee := types.NewERC20Token(i, myTokenContractAddr).PeggyCoin()
+		_, err := k.AddToOutgoingPool(ctx, mySender, myReceiver, amount, fee)
+		require.NoError(t, err)
+	}
+	unbatched := func() int {
+		return len(k.GetPoolTransactions(ctx))
+	}
+	confirm := func(nonce uint64) {
+		k.SetBatchConfirm(ctx, &types.MsgConfirmBatch{
+			Nonce:         nonce,
+			TokenContract: myTokenContractAddr,
+			Orchestrator:  myOrchestrator.String(),
+		})
+	}
+
+	// only the authority or the requester can cancel, the requester once the grace period passed
+	batch, err := k.RequestOutgoingTXBatch(ctx, myTokenContractAddr, mySender)
+	require.NoError(t, err)
+	assert.Equal(t, mySender.String(), batch.Requester)
+	err = k.CancelBatch(ctx, AccAddrs[1].String(), myTokenContractAddr, batch.BatchNonce)
+	assert.True(t, sdkerrors.ErrUnauthorized.Is(err), err)
+	err = k.CancelBatch(ctx, mySender.String(), myTokenContractAddr, batch.BatchNonce)
+	assert.True(t, sdkerrors.ErrUnauthorized.Is(err), err)
+	err = k.CancelBatch(ctx, k.GetAuthority(), myTokenContractAddr, 99)
+	assert.True(t, types.ErrUnknown.Is(err), err)
+
+	// a batch without confirms returns its transactions to the pool right away
+	params := k.GetParams(ctx)
+	params.BatchCancelGracePeriod = 100
+	k.SetParams(ctx, params)
+	err = k.CancelBatch(ctx.WithBlockHeight(int64(batch.Block+99)), mySender.String(), myTokenContractAddr, batch.BatchNonce)
+	assert.True(t, sdkerrors.ErrUnauthorized.Is(err), err)
+	require.NoError(t, k.CancelBatch(ctx.WithBlockHeight(int64(batch.Block+100)), mySender.String(), myTokenContractAddr, batch.BatchNonce))
+	assert.Nil(t, k.GetOutgoingTXBatch(ctx, myTokenContractAddr, batch.BatchNonce))
+	assert.Nil(t, k.GetCancelledBatch(ctx, myTokenContractAddr, batch.BatchNonce))
+	assert.Equal(t, 4, unbatched())
+
+	// a confirmed batch is held until it can't be executed anymore
+	first, err := k.BuildOutgoingTXBatch(ctx, myTokenContractAddr, 2)
+	require.NoError(t, err)
+	confirm(first.BatchNonce)
+	require.NoError(t, k.CancelBatch(ctx, k.GetAuthority(), myTokenContractAddr, first.BatchNonce))
+	assert.Nil(t, k.GetOutgoingTXBatch(ctx, myTokenContractAddr, first.BatchNonce))
+	assert.Nil(t, k.GetBatchConfirm(ctx, first.BatchNonce, myTokenContractAddr, myOrchestrator))
+	assert.Equal(t, first, k.GetCancelledBatch(ctx, myTokenContractAddr, first.BatchNonce))
+	assert.Equal(t, 2, unbatched())
+
+	// the cancelled batches survive an export
+	genesis := ExportGenesis(ctx, k)
+	assert.Equal(t, []types.OutgoingTxBatch{*first}, genesis.CancelledBatches)
+	restarted := CreateTestEnv(t)
+	InitGenesis(restarted.Context, restarted.PeggyKeeper, genesis)
+	assert.Equal(t, first, restarted.PeggyKeeper.GetCancelledBatch(restarted.Context, myTokenContractAddr, first.BatchNonce))
+
+	// released once the Ethereum height passed its timeout
+	k.ReleaseTimedOutCancelledBatches(ctx, first.BatchTimeout)
+	assert.NotNil(t, k.GetCancelledBatch(ctx, myTokenContractAddr, first.BatchNonce))
+	k.ReleaseTimedOutCancelledBatches(ctx, first.BatchTimeout+1)
+	assert.Nil(t, k.GetCancelledBatch(ctx, myTokenContractAddr, first.BatchNonce))
+	assert.Equal(t, 4, unbatched())
+
+	// or once a later batch of the token is executed
+	second, err := k.BuildOutgoingTXBatch(ctx, myTokenContractAddr, 2)
+	require.NoError(t, err)
+	confirm(second.BatchNonce)
+	require.NoError(t, k.CancelBatch(ctx, k.GetAuthority(), myTokenContractAddr, second.BatchNonce))
+	third, err := k.BuildOutgoingTXBatch(ctx, myTokenContractAddr, 2)
+	require.NoError(t, err)
+	require.NoError(t, k.OutgoingTxBatchExecuted(ctx, myTokenContractAddr, third.BatchNonce))
+	assert.Nil(t, k.GetCancelledBatch(ctx, myTokenContractAddr, second.BatchNonce))
+	assert.Equal(t, 2, unbatched())
+
+	// a cancelled batch executed with the confirms it collected is processed like any other batch
+	fourth, err := k.BuildOutgoingTXBatch(ctx, myTokenContractAddr, 2)
+	require.NoError(t, err)
+	confirm(fourth.BatchNonce)
+	require.NoError(t, k.CancelBatch(ctx, k.GetAuthority(), myTokenContractAddr, fourth.BatchNonce))
+	require.NoError(t, k.OutgoingTxBatchExecuted(ctx, myTokenContractAddr, fourth.BatchNonce))
+	assert.Nil(t, k.GetCancelledBatch(ctx, myTokenContractAddr, fourth.BatchNonce))
+	assert.Empty(t, k.GetPoolTransactions(ctx))
+	assert.Equal(t, 0, unbatched())
+}
@@ -0,0 +1,49 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// SetBatchExecution stores where a batch was executed on Ethereum
+func (k Keeper) SetBatchExecution(ctx sdk.Context, execution types.BatchExecution) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetBatchExecutionKey(execution.TokenContract, execution.BatchNonce), k.cdc.MustMarshalBinaryBare(&execution))
+}
+
+// GetBatchExecution returns where the batch with the given token contract and nonce was
+// executed on Ethereum, or nil if the execution has not been observed
+func (k Keeper) GetBatchExecution(ctx sdk.Context, tokenContract string, nonce uint64) *types.BatchExecution {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetBatchExecutionKey(tokenContract, nonce))
+	if bz == nil {
+		return nil
+	}
+	var execution types.BatchExecution
+	k.cdc.MustUnmarshalBinaryBare(bz, &execution)
+	return &execution
+}
+
+// IterateBatchExecutions iterates over the ethereum executions of all batches
+func (k Keeper) IterateBatchExecutions(ctx sdk.Context, cb func(types.BatchExecution) bool) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.BatchExecutionKey)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var execution types.BatchExecution
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &execution)
+		// cb returns true to stop early
+		if cb(execution) {
+			break
+		}
+	}
+}
+
+// GetBatchExecutions returns the ethereum executions of all batches
+func (k Keeper) GetBatchExecutions(ctx sdk.Context) (out []types.BatchExecution) {
+	k.IterateBatchExecutions(ctx, func(execution types.BatchExecution) bool {
+		out = append(out, execution)
+		return false
+	})
+	return
+}
@@ -0,0 +1,48 @@
+package keeper
+
+import (
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// GetBatchTxGas returns the estimated Ethereum gas of a single transfer of the token in a batch
+func (k Keeper) GetBatchTxGas(ctx sdk.Context, tokenContract string) uint64 {
+	return batchTxGas(k.GetParams(ctx), tokenContract)
+}
+
+// EstimateBatchGas returns the estimated Ethereum gas of submitting a batch of the token with txCount transfers
+func (k Keeper) EstimateBatchGas(ctx sdk.Context, tokenContract string, txCount int) uint64 {
+	params := k.GetParams(ctx)
+	return params.BatchBaseGas + uint64(txCount)*batchTxGas(params, tokenContract)
+}
+
+// getBatchTxLimit returns how many transfers of the token fit in a batch, at most OutgoingTxBatchSize
+// and as many as fit under TargetBatchGas
+func (k Keeper) getBatchTxLimit(ctx sdk.Context, tokenContract string) int {
+	return batchTxLimit(k.GetParams(ctx), tokenContract)
+}
+
+func batchTxGas(params types.Params, tokenContract string) uint64 {
+	for _, token := range params.TokenBatchGas {
+		if strings.EqualFold(token.TokenContract, tokenContract) {
+			return token.TxGas
+		}
+	}
+	return params.BatchTxGas
+}
+
+func batchTxLimit(params types.Params, tokenContract string) int {
+	txGas := batchTxGas(params, tokenContract)
+	if params.TargetBatchGas == 0 || txGas == 0 {
+		return OutgoingTxBatchSize
+	}
+	if params.BatchBaseGas >= params.TargetBatchGas {
+		return 0
+	}
+	if limit := (params.TargetBatchGas - params.BatchBaseGas) / txGas; limit < OutgoingTxBatchSize {
+		return int(limit)
+	}
+	return OutgoingTxBatchSize
+}
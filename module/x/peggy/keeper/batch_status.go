@@ -0,0 +1,67 @@
+package keeper
+
+import (
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// bridgeValset returns the valset the bridge contract checks signatures against, the last one observed
+// on Ethereum or the latest one if it isn't stored
+func (k Keeper) bridgeValset(ctx sdk.Context) *types.Valset {
+	if valset := k.GetValset(ctx, k.GetLastObservedValsetNonce(ctx)); valset != nil {
+		return valset
+	}
+	if valset := k.GetLatestValset(ctx); valset != nil {
+		return valset
+	}
+	return k.GetCurrentValset(ctx)
+}
+
+// GetBatchSignedPower sums the powers of the members of the bridge valset that confirmed the batch
+func (k Keeper) GetBatchSignedPower(ctx sdk.Context, tokenContract string, nonce uint64) uint64 {
+	powers := make(map[string]uint64)
+	for _, member := range k.bridgeValset(ctx).Members {
+		powers[strings.ToLower(member.EthereumAddress)] = member.Power
+	}
+	var signed uint64
+	k.IterateBatchConfirmByNonceAndTokenContract(ctx, nonce, tokenContract, func(_ []byte, confirm types.MsgConfirmBatch) bool {
+		signer := strings.ToLower(confirm.EthSigner)
+		signed += powers[signer]
+		// a key counts once even if it confirmed for several orchestrators
+		delete(powers, signer)
+		return false
+	})
+	return signed
+}
+
+// GetBatchStatus returns the batch with the given token contract and nonce, nil once executed, along with
+// its status and the power that signed it
+func (k Keeper) GetBatchStatus(ctx sdk.Context, tokenContract string, nonce uint64) (*types.OutgoingTxBatch, types.BatchStatus, uint64, error) {
+	batch := k.GetOutgoingTXBatch(ctx, tokenContract, nonce)
+	if batch == nil {
+		if k.GetBatchExecution(ctx, tokenContract, nonce) != nil {
+			return nil, types.BATCH_STATUS_EXECUTED, 0, nil
+		}
+		batch = k.GetCancelledBatch(ctx, tokenContract, nonce)
+		if batch == nil {
+			return nil, types.BATCH_STATUS_UNSPECIFIED, 0, sdkerrors.Wrapf(types.ErrUnknown, "batch %d of %s", nonce, tokenContract)
+		}
+	}
+
+	signed := k.GetBatchSignedPower(ctx, tokenContract, nonce)
+	switch {
+	case k.IsEthereumHeightPassed(ctx, batch.BatchTimeout):
+		return batch, types.BATCH_STATUS_TIMED_OUT, signed, nil
+	case k.GetCancelledBatch(ctx, tokenContract, nonce) != nil:
+		return batch, types.BATCH_STATUS_CANCELLED, signed, nil
+	case signed > types.BridgePowerThreshold:
+		return batch, types.BATCH_STATUS_EXECUTABLE, signed, nil
+	case signed > 0:
+		return batch, types.BATCH_STATUS_PENDING_SIGNATURES, signed, nil
+	default:
+		return batch, types.BATCH_STATUS_SIGNABLE, signed, nil
+	}
+}
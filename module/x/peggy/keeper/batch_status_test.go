@@ -0,0 +1,95 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchStatus(t *testing.T) {
+	t.Parallel()
+	input, ctx := SetupFiveValChain(t)
+	k := input.PeggyKeeper
+	var (
+		mySender            = AccAddrs[0]
+		myReceiver          = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		vouchers            = sdk.NewCoins(types.NewERC20Token(1000, myTokenContractAddr).PeggyCoin())
+	)
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, vouchers))
+	require.NoError(t, input.BankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, mySender, vouchers))
+	for i := 0; i < 2; i++ {
+		amount := types.NewERC20Token(100, myTokenContractAddr).PeggyCoin()
+		fee := types.NewERC20Token(uint64(i+1), myTokenContractAddr).PeggyCoin()
+		_, err := k.AddToOutgoingPool(ctx, mySender, myReceiver, amount, fee)
+		require.NoError(t, err)
+	}
+	batch, err := k.BuildOutgoingTXBatch(ctx, myTokenContractAddr, 2)
+	require.NoError(t, err)
+
+	status := func() (types.BatchStatus, uint64) {
+		res, err := k.BatchRequestByNonce(sdk.WrapSDKContext(ctx), &types.QueryBatchRequestByNonceRequest{
+			Nonce:           batch.BatchNonce,
+			ContractAddress: myTokenContractAddr,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, types.BridgePowerThreshold, res.PowerThreshold)
+		return res.Status, res.SignedPower
+	}
+	confirm := func(i int) {
+		k.SetBatchConfirm(ctx, &types.MsgConfirmBatch{
+			Nonce:         batch.BatchNonce,
+			TokenContract: myTokenContractAddr,
+			EthSigner:     EthAddrs[i].String(),
+			Orchestrator:  AccAddrs[i].String(),
+		})
+	}
+
+	got, signed := status()
+	assert.Equal(t, types.BATCH_STATUS_SIGNABLE, got)
+	assert.Zero(t, signed)
+
+	// three of five equal validators are short of the threshold
+	for i := 0; i < 3; i++ {
+		confirm(i)
+	}
+	got, signed = status()
+	assert.Equal(t, types.BATCH_STATUS_PENDING_SIGNATURES, got)
+	assert.Less(t, signed, types.BridgePowerThreshold)
+
+	// a repeated confirm doesn't add power
+	confirm(0)
+	_, again := status()
+	assert.Equal(t, signed, again)
+
+	confirm(3)
+	got, signed = status()
+	assert.Equal(t, types.BATCH_STATUS_EXECUTABLE, got)
+	assert.Greater(t, signed, types.BridgePowerThreshold)
+
+	// timed out once Ethereum passes the batch timeout
+	timedOut := ctx
+	ctx, _ = ctx.CacheContext()
+	k.SetLastObservedEthereumBlockHeight(ctx, batch.BatchTimeout+1)
+	got, _ = status()
+	assert.Equal(t, types.BATCH_STATUS_TIMED_OUT, got)
+	ctx = timedOut
+
+	// executed batches are pruned but still reported
+	withdraw := &types.MsgWithdrawClaim{
+		EventNonce:    1,
+		TokenContract: myTokenContractAddr,
+		BatchNonce:    batch.BatchNonce,
+	}
+	require.NoError(t, k.AttestationHandler.Handle(ctx, types.Attestation{}, withdraw))
+	gotBatch, got, _, err := k.GetBatchStatus(ctx, myTokenContractAddr, batch.BatchNonce)
+	require.NoError(t, err)
+	assert.Nil(t, gotBatch)
+	assert.Equal(t, types.BATCH_STATUS_EXECUTED, got)
+
+	_, _, _, err = k.GetBatchStatus(ctx, myTokenContractAddr, batch.BatchNonce+1)
+	assert.Error(t, err)
+}
@@ -1,7 +1,10 @@
 package keeper
 
 import (
+	"math/rand"
+	"reflect"
 	"testing"
+	"testing/quick"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -11,6 +14,7 @@ import (
 )
 
 func TestBatches(t *testing.T) {
+	t.Parallel()
 	input := CreateTestEnv(t)
 	ctx := input.Context
 	var (
@@ -193,6 +197,7 @@ func TestBatches(t *testing.T) {
 // tests that batches work with large token amounts, mostly a duplicate of the above
 // tests but using much bigger numbers
 func TestBatchesFullCoins(t *testing.T) {
+	t.Parallel()
 	input := CreateTestEnv(t)
 	ctx := input.Context
 	var (
@@ -376,6 +381,7 @@ func TestBatchesFullCoins(t *testing.T) {
 }
 
 func TestPoolTxRefund(t *testing.T) {
+	t.Parallel()
 	input := CreateTestEnv(t)
 	ctx := input.Context
 	var (
@@ -425,3 +431,327 @@ func TestPoolTxRefund(t *testing.T) {
 	balances := input.BankKeeper.GetAllBalances(ctx, mySender)
 	require.Equal(t, sdk.NewInt(104), balances.AmountOf(myDenom))
 }
+
+func TestBatchExecutionReplay(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	var (
+		mySender, _         = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		myReceiver          = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5" // Pickle
+		allVouchers         = sdk.NewCoins(
+			types.NewERC20Token(99999, myTokenContractAddr).PeggyCoin(),
+		)
+	)
+
+	// mint some voucher first
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, allVouchers))
+	// set senders balance
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+	require.NoError(t, input.BankKeeper.SetBalances(ctx, mySender, allVouchers))
+
+	// add some TX to the pool
+	for i, v := range []uint64{2, 3} {
+		amount := types.NewERC20Token(uint64(i+100), myTokenContractAddr).PeggyCoin()
+		fee := types.NewERC20Token(v, myTokenContractAddr).PeggyCoin()
+		_, err := input.PeggyKeeper.AddToOutgoingPool(ctx, mySender, myReceiver, amount, fee)
+		require.NoError(t, err)
+	}
+
+	batch, err := input.PeggyKeeper.BuildOutgoingTXBatch(ctx, myTokenContractAddr, 2)
+	require.NoError(t, err)
+
+	require.NoError(t, input.PeggyKeeper.OutgoingTxBatchExecuted(ctx, batch.TokenContract, batch.BatchNonce))
+	assert.True(t, input.PeggyKeeper.IsTxProcessed(ctx, 1))
+	assert.True(t, input.PeggyKeeper.IsTxProcessed(ctx, 2))
+	assert.Equal(t, []uint64{1, 2}, input.PeggyKeeper.GetProcessedTxIDs(ctx))
+
+	// replaying the execution of the same batch is rejected
+	input.PeggyKeeper.StoreBatchUnsafe(ctx, batch)
+	err = input.PeggyKeeper.OutgoingTxBatchExecuted(ctx, batch.TokenContract, batch.BatchNonce)
+	require.True(t, types.ErrDuplicate.Is(err))
+
+	// cancelling it does not release the executed txs back into the pool
+	require.NoError(t, input.PeggyKeeper.CancelOutgoingTXBatch(ctx, batch.TokenContract, batch.BatchNonce))
+	var gotUnbatchedTx []*types.OutgoingTransferTx
+	input.PeggyKeeper.IterateOutgoingPoolByFee(ctx, myTokenContractAddr, func(_ uint64, tx *types.OutgoingTransferTx) bool {
+		gotUnbatchedTx = append(gotUnbatchedTx, tx)
+		return false
+	})
+	assert.Empty(t, gotUnbatchedTx)
+}
+
+func TestBatchConfirmPruning(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	var (
+		mySender, _       = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		myOrchestrator, _ = sdk.AccAddressFromBech32("cosmos16ahjkfqxpp6lvfy9fpfnfjg39xr96qett0alj5")
+		myReceiver        = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		tokenContract     = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		otherContract     = "0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e"
+		allVouchers       = sdk.NewCoins(
+			types.NewERC20Token(99999, tokenContract).PeggyCoin(),
+			types.NewERC20Token(99999, otherContract).PeggyCoin(),
+		)
+	)
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, allVouchers))
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+	require.NoError(t, input.BankKeeper.SetBalances(ctx, mySender, allVouchers))
+
+	confirm := func(contract string, nonce uint64) {
+		input.PeggyKeeper.SetBatchConfirm(ctx, &types.MsgConfirmBatch{
+			Nonce:         nonce,
+			TokenContract: contract,
+			Orchestrator:  myOrchestrator.String(),
+		})
+	}
+	countConfirms := func() (n int) {
+		iter := sdk.KVStorePrefixIterator(ctx.KVStore(input.PeggyKeeper.storeKey), types.BatchConfirmKey)
+		defer iter.Close()
+		for ; iter.Valid(); iter.Next() {
+			n++
+		}
+		return n
+	}
+
+	// one confirmed single transfer batch per transfer
+	var batches []*types.OutgoingTxBatch
+	for _, contract := range []string{tokenContract, tokenContract, tokenContract, otherContract} {
+		amount := types.NewERC20Token(100, contract).PeggyCoin()
+		fee := types.NewERC20Token(1, contract).PeggyCoin()
+		_, err := input.PeggyKeeper.AddToOutgoingPool(ctx, mySender, myReceiver, amount, fee)
+		require.NoError(t, err)
+		batch, err := input.PeggyKeeper.BuildOutgoingTXBatch(ctx, contract, 1)
+		require.NoError(t, err)
+		confirm(contract, batch.BatchNonce)
+		batches = append(batches, batch)
+	}
+	require.Equal(t, 4, countConfirms())
+
+	// canceling a batch only drops its own confirms
+	require.NoError(t, input.PeggyKeeper.CancelOutgoingTXBatch(ctx, tokenContract, batches[0].BatchNonce))
+	assert.Nil(t, input.PeggyKeeper.GetBatchConfirm(ctx, batches[0].BatchNonce, tokenContract, myOrchestrator))
+	assert.Equal(t, 3, countConfirms())
+
+	// a late confirm for the canceled batch is left behind until a later batch is executed
+	confirm(tokenContract, batches[0].BatchNonce)
+	require.Equal(t, 4, countConfirms())
+
+	// executing the last batch of the contract cancels the earlier one and drops all their confirms
+	require.NoError(t, input.PeggyKeeper.OutgoingTxBatchExecuted(ctx, tokenContract, batches[2].BatchNonce))
+	assert.Nil(t, input.PeggyKeeper.GetOutgoingTXBatch(ctx, tokenContract, batches[1].BatchNonce))
+	assert.Equal(t, 1, countConfirms())
+	assert.NotNil(t, input.PeggyKeeper.GetBatchConfirm(ctx, batches[3].BatchNonce, otherContract, myOrchestrator))
+}
+
+func BenchmarkBuildOutgoingTXBatch(b *testing.B) {
+	input := CreateTestEnv(b)
+	ctx := input.Context
+	var (
+		mySender, _         = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		myReceiver          = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		allVouchers         = sdk.NewCoins(types.NewERC20Token(10000000, myTokenContractAddr).PeggyCoin())
+	)
+	require.NoError(b, input.BankKeeper.MintCoins(ctx, types.ModuleName, allVouchers))
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+	require.NoError(b, input.BankKeeper.SetBalances(ctx, mySender, allVouchers))
+
+	// a pool of transfers with distinct fees, larger than a batch
+	for i := 0; i < 1000; i++ {
+		amount := types.NewERC20Token(100, myTokenContractAddr).PeggyCoin()
+		fee := types.NewERC20Token(uint64(i+1), myTokenContractAddr).PeggyCoin()
+		_, err := input.PeggyKeeper.AddToOutgoingPool(ctx, mySender, myReceiver, amount, fee)
+		require.NoError(b, err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		batch, err := input.PeggyKeeper.BuildOutgoingTXBatch(ctx, myTokenContractAddr, OutgoingTxBatchSize)
+		require.NoError(b, err)
+
+		// cancel the batch to put its transfers back into the pool
+		b.StopTimer()
+		require.NoError(b, input.PeggyKeeper.CancelOutgoingTXBatch(ctx, myTokenContractAddr, batch.BatchNonce))
+		b.StartTimer()
+	}
+}
+
+// randomPool fills the pool with transfers of the given fees, every transfer sends 100 tokens
+func randomPool(t *testing.T, fees []uint16) (TestInput, sdk.AccAddress, string) {
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	var (
+		mySender, _         = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		myReceiver          = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		allVouchers         = sdk.NewCoins(types.NewERC20Token(10000000, myTokenContractAddr).PeggyCoin())
+	)
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, allVouchers))
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+	require.NoError(t, input.BankKeeper.SetBalances(ctx, mySender, allVouchers))
+	for _, f := range fees {
+		amount := types.NewERC20Token(100, myTokenContractAddr).PeggyCoin()
+		fee := types.NewERC20Token(uint64(f%1000)+1, myTokenContractAddr).PeggyCoin()
+		_, err := input.PeggyKeeper.AddToOutgoingPool(ctx, mySender, myReceiver, amount, fee)
+		require.NoError(t, err)
+	}
+	return input, mySender, myTokenContractAddr
+}
+
+// randomFees generates between 1 and 250 random fees
+func randomFees(values []reflect.Value, r *rand.Rand) {
+	fees := make([]uint16, r.Intn(250)+1)
+	for i := range fees {
+		fees[i] = uint16(r.Intn(1 << 16))
+	}
+	values[0] = reflect.ValueOf(fees)
+}
+
+func propertyConfig() *quick.Config {
+	return &quick.Config{MaxCount: 20, Rand: rand.New(rand.NewSource(1)), Values: randomFees}
+}
+
+func TestBatchSelectsHighestFeesProperty(t *testing.T) {
+	t.Parallel()
+	property := func(fees []uint16) bool {
+		input, _, tokenContract := randomPool(t, fees)
+		ctx := input.Context
+		batch, err := input.PeggyKeeper.BuildOutgoingTXBatch(ctx, tokenContract, OutgoingTxBatchSize)
+		require.NoError(t, err)
+
+		expLen := len(fees)
+		if expLen > OutgoingTxBatchSize {
+			expLen = OutgoingTxBatchSize
+		}
+		if len(batch.Transactions) != expLen {
+			return false
+		}
+		// no transfer left behind pays more than a batched one
+		minBatched := batch.Transactions[0].Erc20Fee.Amount
+		for _, tx := range batch.Transactions {
+			if tx.Erc20Fee.Amount.LT(minBatched) {
+				minBatched = tx.Erc20Fee.Amount
+			}
+		}
+		ok := true
+		input.PeggyKeeper.IterateOutgoingPool(ctx, func(tx *types.OutgoingTransferTx) bool {
+			ok = tx.Erc20Fee.Amount.LTE(minBatched)
+			return !ok
+		})
+		return ok
+	}
+	require.NoError(t, quick.Check(property, propertyConfig()))
+}
+
+func TestRefundRestoresBalanceProperty(t *testing.T) {
+	t.Parallel()
+	property := func(fees []uint16) bool {
+		input, sender, tokenContract := randomPool(t, fees)
+		ctx := input.Context
+		// batch some of the transfers, only unbatched ones can be canceled
+		_, err := input.PeggyKeeper.BuildOutgoingTXBatch(ctx, tokenContract, len(fees)/2+1)
+		require.NoError(t, err)
+
+		before := input.BankKeeper.GetAllBalances(ctx, sender)
+		var refunded []*types.OutgoingTransferTx
+		input.PeggyKeeper.IterateOutgoingPool(ctx, func(tx *types.OutgoingTransferTx) bool {
+			refunded = append(refunded, tx)
+			return false
+		})
+		exp := before
+		for _, tx := range refunded {
+			require.NoError(t, input.PeggyKeeper.RemoveFromOutgoingPoolAndRefund(ctx, tx.Id, sender))
+			exp = exp.Add(sdk.NewCoin(types.PeggyDenom(tokenContract), tx.Erc20Token.Amount.Add(tx.Erc20Fee.Amount)))
+		}
+		return exp.IsEqual(input.BankKeeper.GetAllBalances(ctx, sender))
+	}
+	require.NoError(t, quick.Check(property, propertyConfig()))
+}
+
+func TestBatchCancelReturnsTxsOnceProperty(t *testing.T) {
+	t.Parallel()
+	property := func(fees []uint16) bool {
+		input, _, tokenContract := randomPool(t, fees)
+		ctx := input.Context
+		batch, err := input.PeggyKeeper.BuildOutgoingTXBatch(ctx, tokenContract, OutgoingTxBatchSize)
+		require.NoError(t, err)
+		require.NoError(t, input.PeggyKeeper.CancelOutgoingTXBatch(ctx, tokenContract, batch.BatchNonce))
+
+		// every transfer is back in the fee index exactly once
+		seen := make(map[uint64]int)
+		input.PeggyKeeper.IterateOutgoingPoolByFee(ctx, tokenContract, func(_ uint64, tx *types.OutgoingTransferTx) bool {
+			seen[tx.Id]++
+			return false
+		})
+		if len(seen) != len(fees) {
+			return false
+		}
+		for _, n := range seen {
+			if n != 1 {
+				return false
+			}
+		}
+		return true
+	}
+	require.NoError(t, quick.Check(property, propertyConfig()))
+}
+
+func TestBatchTargetGas(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	var (
+		mySender, _    = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		myReceiver     = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		cheapToken     = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		expensiveToken = "0x7580bFE88Dd3d07947908FAE12d95872a260F2D8"
+	)
+	params := k.GetParams(ctx)
+	params.TargetBatchGas = 1000000
+	params.BatchBaseGas = 200000
+	params.BatchTxGas = 100000
+	params.TokenBatchGas = []types.TokenBatchGas{{TokenContract: expensiveToken, TxGas: 300000}}
+	k.SetParams(ctx, params)
+
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+	for _, token := range []string{cheapToken, expensiveToken} {
+		vouchers := sdk.NewCoins(types.NewERC20Token(99999, token).PeggyCoin())
+		require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, vouchers))
+		require.NoError(t, input.BankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, mySender, vouchers))
+		for i := 0; i < 10; i++ {
+			amount := types.NewERC20Token(100, token).PeggyCoin()
+			fee := types.NewERC20Token(uint64(i+1), token).PeggyCoin()
+			_, err := k.AddToOutgoingPool(ctx, mySender, myReceiver, amount, fee)
+			require.NoError(t, err)
+		}
+	}
+
+	// (1000000 - 200000) / 100000 transfers fit
+	batch, err := k.BuildOutgoingTXBatch(ctx, cheapToken, OutgoingTxBatchSize)
+	require.NoError(t, err)
+	assert.Len(t, batch.Transactions, 8)
+	assert.Equal(t, uint64(1000000), k.EstimateBatchGas(ctx, cheapToken, len(batch.Transactions)))
+
+	// the token override leaves room for only 2 transfers, the highest fees first
+	batch, err = k.BuildOutgoingTXBatch(ctx, expensiveToken, OutgoingTxBatchSize)
+	require.NoError(t, err)
+	require.Len(t, batch.Transactions, 2)
+	assert.Equal(t, sdk.NewInt(10), batch.Transactions[0].Erc20Fee.Amount)
+
+	// the fees reported for batching only cover the transfers that fit
+	for _, fees := range k.CreateBatchFees(ctx) {
+		if fees.Token == expensiveToken {
+			assert.Equal(t, sdk.NewInt(8+7), fees.TopOneHundred)
+		}
+	}
+
+	// a smaller requested size still applies
+	batch, err = k.BuildOutgoingTXBatch(ctx, expensiveToken, 1)
+	require.NoError(t, err)
+	assert.Len(t, batch.Transactions, 1)
+}
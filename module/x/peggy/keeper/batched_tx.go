@@ -0,0 +1,84 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// setBatchedTxs indexes the transfers of a batch by tx id, the index is removed once the batch
+// executed or a cancelled batch releases the transfers into the pool again
+func (k Keeper) setBatchedTxs(ctx sdk.Context, batch *types.OutgoingTxBatch) {
+	for _, tx := range batch.Transactions {
+		k.SetBatchedTx(ctx, types.BatchedTx{
+			TxId:          tx.Id,
+			TokenContract: batch.TokenContract,
+			BatchNonce:    batch.BatchNonce,
+			Sender:        tx.Sender,
+		})
+	}
+}
+
+// SetBatchedTx records the batch of an outgoing transfer
+func (k Keeper) SetBatchedTx(ctx sdk.Context, batched types.BatchedTx) {
+	ctx.KVStore(k.storeKey).Set(types.GetBatchedTxKey(batched.TxId), k.cdc.MustMarshalBinaryBare(&batched))
+}
+
+func (k Keeper) deleteBatchedTx(ctx sdk.Context, txID uint64) {
+	ctx.KVStore(k.storeKey).Delete(types.GetBatchedTxKey(txID))
+}
+
+// GetBatchedTx returns the batch an outgoing transfer was put in, or nil if it was never batched
+func (k Keeper) GetBatchedTx(ctx sdk.Context, txID uint64) *types.BatchedTx {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetBatchedTxKey(txID))
+	if bz == nil {
+		return nil
+	}
+	var batched types.BatchedTx
+	k.cdc.MustUnmarshalBinaryBare(bz, &batched)
+	return &batched
+}
+
+// IterateBatchedTxs iterates over the batched transfers in ascending tx id order
+func (k Keeper) IterateBatchedTxs(ctx sdk.Context, cb func(types.BatchedTx) bool) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.BatchedTxKey)
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var batched types.BatchedTx
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &batched)
+		if cb(batched) {
+			break
+		}
+	}
+}
+
+// GetBatchedTxs returns all batched transfers
+func (k Keeper) GetBatchedTxs(ctx sdk.Context) (out []types.BatchedTx) {
+	k.IterateBatchedTxs(ctx, func(batched types.BatchedTx) bool {
+		out = append(out, batched)
+		return false
+	})
+	return
+}
+
+// GetBatchForTx returns the batch an outgoing transfer is in along with the status of the batch.
+// A transfer still waiting in the pool has no batch, an executed transfer only has the executed status.
+func (k Keeper) GetBatchForTx(ctx sdk.Context, txID uint64) (*types.QueryBatchForTxResponse, error) {
+	batched := k.GetBatchedTx(ctx, txID)
+	if batched == nil {
+		if k.IsTxProcessed(ctx, txID) {
+			return &types.QueryBatchForTxResponse{Status: types.BATCH_STATUS_EXECUTED}, nil
+		}
+		if _, err := k.getPoolEntry(ctx, txID); err != nil {
+			return nil, sdkerrors.Wrap(types.ErrUnknown, "tx is neither batched nor in the pool")
+		}
+		return &types.QueryBatchForTxResponse{InPool: true}, nil
+	}
+	batch, status, _, err := k.GetBatchStatus(ctx, batched.TokenContract, batched.BatchNonce)
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryBatchForTxResponse{BatchedTx: batched, Batch: batch, Status: status}, nil
+}
@@ -0,0 +1,108 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchForTx(t *testing.T) {
+	t.Parallel()
+	input, ctx := SetupFiveValChain(t)
+	k := input.PeggyKeeper
+	var (
+		mySender            = AccAddrs[0]
+		myReceiver          = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		vouchers            = sdk.NewCoins(types.NewERC20Token(1000, myTokenContractAddr).PeggyCoin())
+	)
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, vouchers))
+	require.NoError(t, input.BankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, mySender, vouchers))
+	var txIDs []uint64
+	for i := 0; i < 3; i++ {
+		amount := types.NewERC20Token(100, myTokenContractAddr).PeggyCoin()
+		fee := types.NewERC20Token(uint64(i+1), myTokenContractAddr).PeggyCoin()
+		txID, err := k.AddToOutgoingPool(ctx, mySender, myReceiver, amount, fee)
+		require.NoError(t, err)
+		txIDs = append(txIDs, txID)
+	}
+	query := func(txID uint64) (*types.QueryBatchForTxResponse, error) {
+		return k.BatchForTx(sdk.WrapSDKContext(ctx), &types.QueryBatchForTxRequest{TxId: txID})
+	}
+
+	// a transfer still in the pool has no batch
+	res, err := query(txIDs[2])
+	require.NoError(t, err)
+	assert.True(t, res.InPool)
+	assert.Nil(t, res.BatchedTx)
+
+	// the batch takes the two highest fees
+	batch, err := k.BuildOutgoingTXBatch(ctx, myTokenContractAddr, 2)
+	require.NoError(t, err)
+	res, err = query(txIDs[2])
+	require.NoError(t, err)
+	assert.False(t, res.InPool)
+	assert.Equal(t, &types.BatchedTx{
+		TxId:          txIDs[2],
+		TokenContract: myTokenContractAddr,
+		BatchNonce:    batch.BatchNonce,
+		Sender:        mySender.String(),
+	}, res.BatchedTx)
+	assert.Equal(t, batch, res.Batch)
+	assert.Equal(t, types.BATCH_STATUS_SIGNABLE, res.Status)
+
+	res, err = query(txIDs[0])
+	require.NoError(t, err)
+	assert.True(t, res.InPool)
+
+	// unknown transfers are an error
+	_, err = query(txIDs[2] + 1)
+	assert.Error(t, err)
+
+	// the index is removed once the batch executed
+	withdraw := &types.MsgWithdrawClaim{
+		EventNonce:    1,
+		TokenContract: myTokenContractAddr,
+		BatchNonce:    batch.BatchNonce,
+	}
+	require.NoError(t, k.AttestationHandler.Handle(ctx, types.Attestation{}, withdraw))
+	res, err = query(txIDs[1])
+	require.NoError(t, err)
+	assert.Nil(t, res.BatchedTx)
+	assert.Nil(t, res.Batch)
+	assert.False(t, res.InPool)
+	assert.Equal(t, types.BATCH_STATUS_EXECUTED, res.Status)
+	assert.Empty(t, k.GetBatchedTxs(ctx))
+}
+
+func TestBatchForTxCancelled(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	createTestBatch(t, input)
+
+	batched := k.GetBatchedTxs(ctx)
+	require.Len(t, batched, 2)
+
+	// the transfers released into the pool drop out of the index
+	require.NoError(t, k.CancelOutgoingTXBatch(ctx, "0xAb5801a7D398351b8bE11C439e05C5B3259aeC9B", 1))
+	assert.Empty(t, k.GetBatchedTxs(ctx))
+	for _, b := range batched {
+		res, err := k.GetBatchForTx(ctx, b.TxId)
+		require.NoError(t, err)
+		assert.True(t, res.InPool)
+	}
+
+	// the migration indexes the live batches
+	batch, err := k.BuildOutgoingTXBatch(ctx, "0xAb5801a7D398351b8bE11C439e05C5B3259aeC9B", 2)
+	require.NoError(t, err)
+	for _, tx := range batch.Transactions {
+		k.deleteBatchedTx(ctx, tx.Id)
+	}
+	require.NoError(t, k.MigrateBatchedTxIndex(ctx))
+	assert.Len(t, k.GetBatchedTxs(ctx), 2)
+}
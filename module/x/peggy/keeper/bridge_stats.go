@@ -0,0 +1,70 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// GetBridgeStats returns the volume bridged of the token, zero if nothing was bridged yet
+func (k Keeper) GetBridgeStats(ctx sdk.Context, tokenContract string) types.BridgeStats {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetBridgeStatsKey(tokenContract))
+	if bz == nil {
+		return types.BridgeStats{
+			TokenContract:  tokenContract,
+			TotalDeposited: sdk.ZeroInt(),
+			TotalWithdrawn: sdk.ZeroInt(),
+		}
+	}
+	var stats types.BridgeStats
+	k.cdc.MustUnmarshalBinaryBare(bz, &stats)
+	return stats
+}
+
+// SetBridgeStats stores the volume bridged of a token
+func (k Keeper) SetBridgeStats(ctx sdk.Context, stats types.BridgeStats) {
+	ctx.KVStore(k.storeKey).Set(types.GetBridgeStatsKey(stats.TokenContract), k.cdc.MustMarshalBinaryBare(&stats))
+}
+
+// IterateBridgeStats iterates over the bridged volume of all tokens ordered by token contract
+func (k Keeper) IterateBridgeStats(ctx sdk.Context, cb func(types.BridgeStats) bool) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.BridgeStatsKey)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var stats types.BridgeStats
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &stats)
+		// cb returns true to stop early
+		if cb(stats) {
+			break
+		}
+	}
+}
+
+// GetAllBridgeStats returns the bridged volume of all tokens
+func (k Keeper) GetAllBridgeStats(ctx sdk.Context) (out []types.BridgeStats) {
+	k.IterateBridgeStats(ctx, func(stats types.BridgeStats) bool {
+		out = append(out, stats)
+		return false
+	})
+	return
+}
+
+// recordDepositStats adds an observed deposit to the volume bridged of its token
+func (k Keeper) recordDepositStats(ctx sdk.Context, tokenContract string, amount sdk.Int) {
+	stats := k.GetBridgeStats(ctx, tokenContract)
+	stats.TotalDeposited = stats.TotalDeposited.Add(amount)
+	stats.DepositCount++
+	k.SetBridgeStats(ctx, stats)
+}
+
+// recordWithdrawalStats adds the transfers of an executed batch to the volume bridged of its token,
+// the fees paid to the relayer are not part of the withdrawn amount
+func (k Keeper) recordWithdrawalStats(ctx sdk.Context, batch *types.OutgoingTxBatch) {
+	stats := k.GetBridgeStats(ctx, batch.TokenContract)
+	for _, tx := range batch.Transactions {
+		stats.TotalWithdrawn = stats.TotalWithdrawn.Add(tx.Erc20Token.Amount)
+		stats.WithdrawalCount++
+	}
+	stats.WithdrawalBatchCount++
+	k.SetBridgeStats(ctx, stats)
+}
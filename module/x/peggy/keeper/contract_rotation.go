@@ -0,0 +1,146 @@
+package keeper
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// RotateBridgeContract moves the bridge to a new bridge contract, it is run by the UpdateBridgeContractProposal.
+// The old contract must be retired, e.g. its valset updated to one that can't sign anymore, and its final event
+// observed, the rotation is refused before since nothing the old contract does is observed afterwards. The
+// batches and logic calls built for the old contract, also the cancelled batches held until they time out, are
+// deleted. The transactions of the batches are returned to the pool to be batched again for the new contract,
+// the fees of the logic calls are refunded. The ERC20s of cosmos originated denoms were deployed by the old
+// contract and are retired, the transfers of them are refunded until the new contract deployed their ERC20s,
+// their escrow stays a liability. The ERC20InitCodeHash param is replaced by the one of the new contract.
+// All attestations are deleted and the last observed and applied event nonces
+// and the last event nonce of every validator are set to the given nonce. The state is checked before the
+// rotation completes, the proposal fails if it is inconsistent.
+func (k Keeper) RotateBridgeContract(ctx sdk.Context, rotation types.ContractRotation) error {
+	if err := rotation.ValidateBasic(); err != nil {
+		return err
+	}
+	if nonce := k.GetLastObservedEventNonce(ctx); nonce != rotation.FinalEventNonce {
+		return sdkerrors.Wrapf(types.ErrInvalid, "last observed event nonce %d, the final event nonce %d of the old contract wasn't reached", nonce, rotation.FinalEventNonce)
+	}
+	params := k.GetParams(ctx)
+	previous := params.BridgeEthereumAddress
+	if strings.EqualFold(previous, rotation.BridgeEthereumAddress) {
+		return sdkerrors.Wrapf(types.ErrInvalid, "%s is the bridge contract already", previous)
+	}
+	params.BridgeEthereumAddress = rotation.BridgeEthereumAddress
+	params.Erc20InitCodeHash = rotation.Erc20InitCodeHash
+	if err := params.Validate(); err != nil {
+		return sdkerrors.Wrap(err, "params")
+	}
+
+	for _, batch := range k.GetOutgoingTxBatches(ctx) {
+		if err := k.CancelOutgoingTXBatch(ctx, batch.TokenContract, batch.BatchNonce); err != nil {
+			return sdkerrors.Wrapf(err, "batch %s/%d", batch.TokenContract, batch.BatchNonce)
+		}
+	}
+	for _, batch := range k.GetCancelledBatches(ctx) {
+		batch := batch
+		k.releaseCancelledBatch(ctx, &batch)
+	}
+	for _, call := range k.GetOutgoingLogicCalls(ctx) {
+		if err := k.CancelOutgoingLogicCall(ctx, call.InvalidationId, call.InvalidationNonce); err != nil {
+			return sdkerrors.Wrapf(err, "logic call %x/%d", call.InvalidationId, call.InvalidationNonce)
+		}
+	}
+	var deployed []*types.ERC20ToDenom
+	k.IterateERC20ToDenom(ctx, func(_ []byte, erc20ToDenom *types.ERC20ToDenom) bool {
+		if !erc20ToDenom.Retired {
+			deployed = append(deployed, erc20ToDenom)
+		}
+		return false
+	})
+	for _, erc20ToDenom := range deployed {
+		if err := k.refundCosmosOriginatedERC20Transfers(ctx, erc20ToDenom.Erc20); err != nil {
+			return sdkerrors.Wrapf(err, "ERC20 %s of %s", erc20ToDenom.Erc20, erc20ToDenom.Denom)
+		}
+		k.retireCosmosOriginatedERC20(ctx, erc20ToDenom.Denom)
+	}
+	// the batches are cancelled for the old contract, the params change afterwards
+	k.SetParams(ctx, params)
+	k.resetEventNonce(ctx, rotation.LastObservedEventNonce)
+
+	if err := k.checkContractRotation(ctx, rotation); err != nil {
+		return err
+	}
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeBridgeContractRotated,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyPreviousContract, previous),
+		sdk.NewAttribute(types.AttributeKeyContract, rotation.BridgeEthereumAddress),
+		sdk.NewAttribute(types.AttributeKeyNonce, fmt.Sprint(rotation.LastObservedEventNonce)),
+	))
+	k.logger(ctx).Info("rotated bridge contract", "previous", previous, "new", rotation.BridgeEthereumAddress, "nonce", rotation.LastObservedEventNonce)
+	return nil
+}
+
+// resetEventNonce deletes all attestations and restarts the oracle after the given event nonce. Unlike
+// SetLastObservedEventNonce it also moves the last applied event nonce back, the nonces of the new
+// contract start over.
+func (k Keeper) resetEventNonce(ctx sdk.Context, nonce uint64) {
+	var keys [][]byte
+	k.IterateAttestations(ctx, func(key []byte, _ types.Attestation) bool {
+		keys = append(keys, key)
+		return false
+	})
+	store := ctx.KVStore(k.storeKey)
+	for _, key := range keys {
+		store.Delete(key)
+	}
+	for _, val := range k.storedValidators(ctx, types.PendingVoteResetKey) {
+		store.Delete(types.GetPendingVoteResetKey(val))
+	}
+	for _, val := range k.storedValidators(ctx, types.LastEventNonceByValidatorKey) {
+		k.setLastEventNonceByValidator(ctx, val, nonce)
+	}
+	k.setLastAppliedEventNonce(ctx, nonce)
+	k.setLastObservedEventNonce(ctx, nonce)
+}
+
+// checkContractRotation checks that nothing of the old contract is left and that the escrow and the fee
+// collector still hold what the pool owes
+func (k Keeper) checkContractRotation(ctx sdk.Context, rotation types.ContractRotation) error {
+	var broken []string
+	if address := k.GetBridgeContractAddress(ctx); address != rotation.BridgeEthereumAddress {
+		broken = append(broken, fmt.Sprintf("bridge contract %s", address))
+	}
+	if batches := k.GetOutgoingTxBatches(ctx); len(batches) != 0 {
+		broken = append(broken, fmt.Sprintf("%d batches left", len(batches)))
+	}
+	if batches := k.GetCancelledBatches(ctx); len(batches) != 0 {
+		broken = append(broken, fmt.Sprintf("%d cancelled batches left", len(batches)))
+	}
+	if calls := k.GetOutgoingLogicCalls(ctx); len(calls) != 0 {
+		broken = append(broken, fmt.Sprintf("%d logic calls left", len(calls)))
+	}
+	k.IterateERC20ToDenom(ctx, func(_ []byte, erc20ToDenom *types.ERC20ToDenom) bool {
+		if !erc20ToDenom.Retired {
+			broken = append(broken, fmt.Sprintf("ERC20 %s of %s not retired", erc20ToDenom.Erc20, erc20ToDenom.Denom))
+		}
+		return false
+	})
+	if nonce := k.GetLastObservedEventNonce(ctx); nonce != rotation.LastObservedEventNonce {
+		broken = append(broken, fmt.Sprintf("last observed event nonce %d", nonce))
+	}
+	if attestations := k.GetAttestationMapping(ctx); len(attestations) != 0 {
+		broken = append(broken, fmt.Sprintf("attestations of %d event nonces left", len(attestations)))
+	}
+	for _, invariant := range []sdk.Invariant{PendingFeesInvariant(k), ModuleResidueInvariant(k)} {
+		if msg, stop := invariant(ctx); stop {
+			broken = append(broken, msg)
+		}
+	}
+	if len(broken) != 0 {
+		return sdkerrors.Wrapf(types.ErrInvalid, "contract rotation: %s", strings.Join(broken, "; "))
+	}
+	return nil
+}
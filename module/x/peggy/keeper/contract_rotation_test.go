@@ -0,0 +1,127 @@
+package keeper
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateBridgeContract(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	var (
+		mySender, _         = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		myReceiver          = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		atomContract        = "0x0bc529c00C6401aEF6D220BE8C6Ea1667F6Ad93e"
+		newContract         = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		vouchers            = sdk.Coins{types.NewERC20Token(1000, myTokenContractAddr).PeggyCoin()}
+		atoms               = sdk.NewCoins(sdk.NewInt64Coin("uatom", 1000))
+		invalidationID      = []byte("invalidationId")
+	)
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, vouchers))
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+	require.NoError(t, input.BankKeeper.SetBalances(ctx, mySender, vouchers.Add(atoms...)))
+	for i := 0; i < 4; i++ {
+		amount := types.NewERC20Token(100, myTokenContractAddr).PeggyCoin()
+		fee := types.NewERC20Token(uint64(i+1), myTokenContractAddr).PeggyCoin()
+		_, err := k.AddToOutgoingPool(ctx, mySender, myReceiver, amount, fee)
+		require.NoError(t, err)
+	}
+	// a confirmed batch is held as cancelled, the other one is still in flight
+	cancelled, err := k.BuildOutgoingTXBatch(ctx, myTokenContractAddr, 2)
+	require.NoError(t, err)
+	k.SetBatchConfirm(ctx, &types.MsgConfirmBatch{Nonce: cancelled.BatchNonce, TokenContract: myTokenContractAddr, EthSigner: EthAddrs[0].String(), Orchestrator: AccAddrs[0].String(), Signature: "signature"})
+	require.NoError(t, k.CancelBatch(ctx, k.GetAuthority(), myTokenContractAddr, cancelled.BatchNonce))
+	require.NotNil(t, k.GetCancelledBatch(ctx, myTokenContractAddr, cancelled.BatchNonce))
+	_, err = k.BuildOutgoingTXBatch(ctx, myTokenContractAddr, 2)
+	require.NoError(t, err)
+	require.Empty(t, k.GetPoolTransactions(ctx))
+
+	// the old contract deployed the ERC20 of uatom, a transfer of it waits in the pool
+	k.setCosmosOriginatedDenomToERC20(ctx, "uatom", atomContract)
+	_, err = k.AddToOutgoingPool(ctx, mySender, myReceiver, sdk.NewInt64Coin("uatom", 100), sdk.NewInt64Coin("uatom", 10))
+	require.NoError(t, err)
+	// a logic call is in flight
+	require.NoError(t, k.CreateOutgoingLogicCall(ctx, mySender, &types.OutgoingLogicCall{
+		Fees:              []*types.ERC20Token{types.NewERC20Token(10, myTokenContractAddr)},
+		Timeout:           1000,
+		InvalidationId:    invalidationID,
+		InvalidationNonce: 1,
+	}))
+
+	// event 5 was observed, event 6 is still voted on
+	for nonce := uint64(5); nonce < 7; nonce++ {
+		claim := &types.MsgDepositClaim{EventNonce: nonce, TokenContract: myTokenContractAddr, Amount: sdk.NewInt(1), EthereumSender: EthAddrs[0].String(), CosmosReceiver: AccAddrs[0].String()}
+		any, err := codectypes.NewAnyWithValue(claim)
+		require.NoError(t, err)
+		k.SetAttestation(ctx, nonce, claim.ClaimHash(), &types.Attestation{Observed: nonce == 5, Votes: []string{ValAddrs[0].String()}, Claim: any})
+	}
+	k.setLastObservedEventNonce(ctx, 5)
+	k.setLastAppliedEventNonce(ctx, 5)
+	k.setLastEventNonceByValidator(ctx, ValAddrs[0], 6)
+
+	// the rotation needs a new valid address
+	err = k.RotateBridgeContract(ctx, types.ContractRotation{BridgeEthereumAddress: k.GetBridgeContractAddress(ctx), FinalEventNonce: 5})
+	assert.True(t, types.ErrInvalid.Is(err), err)
+	err = types.ContractRotation{BridgeEthereumAddress: "0x1234"}.ValidateBasic()
+	assert.True(t, types.ErrInvalid.Is(err), err)
+	err = types.ContractRotation{BridgeEthereumAddress: "0xd041c41ea1bf0f006adbb6d2c9ef9d425de5ead7"}.ValidateBasic()
+	assert.True(t, types.ErrInvalid.Is(err), err)
+	err = types.ContractRotation{BridgeEthereumAddress: newContract, Erc20InitCodeHash: "0x1234"}.ValidateBasic()
+	assert.True(t, types.ErrInvalid.Is(err), err)
+	_, err = types.ParseContractRotation(`{"bridge_ethereum_address": "0x1234"}`)
+	assert.True(t, types.ErrInvalid.Is(err), err)
+
+	// and waits for the final event of the old contract, the info of the contract-rotation upgrade plan
+	// carries the same rotation as the proposal
+	initCodeHash := "0x" + strings.Repeat("ab", 32)
+	rotation, err := types.ParseContractRotation(fmt.Sprintf(`{"bridge_ethereum_address": %q, "final_event_nonce": 6, "erc20_init_code_hash": %q}`, newContract, initCodeHash))
+	require.NoError(t, err)
+	assert.Equal(t, types.ContractRotation{BridgeEthereumAddress: newContract, FinalEventNonce: 6, Erc20InitCodeHash: initCodeHash}, rotation)
+	err = k.RotateBridgeContract(ctx, rotation)
+	assert.True(t, types.ErrInvalid.Is(err), err)
+	assert.NotEqual(t, newContract, k.GetBridgeContractAddress(ctx))
+
+	rotation.FinalEventNonce = 5
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	require.NoError(t, k.RotateBridgeContract(ctx, rotation))
+
+	assert.Equal(t, newContract, k.GetBridgeContractAddress(ctx))
+	assert.Equal(t, initCodeHash, k.GetParams(ctx).Erc20InitCodeHash)
+	assert.Empty(t, k.GetOutgoingTxBatches(ctx))
+	assert.Empty(t, k.GetCancelledBatches(ctx))
+	assert.Len(t, k.GetPoolTransactions(ctx), 4)
+	assert.Empty(t, k.GetOutgoingLogicCalls(ctx))
+	// the fees of the logic call and the transfer of the retired ERC20 are refunded
+	assert.Equal(t, int64(590), input.BankKeeper.GetBalance(ctx, mySender, vouchers[0].Denom).Amount.Int64())
+	assert.Equal(t, int64(1000), input.BankKeeper.GetBalance(ctx, mySender, "uatom").Amount.Int64())
+	_, deployed := k.GetCosmosOriginatedERC20(ctx, "uatom")
+	assert.False(t, deployed)
+	denom, cosmosOriginated := k.GetCosmosOriginatedDenom(ctx, atomContract)
+	assert.True(t, cosmosOriginated)
+	assert.Equal(t, "uatom", denom)
+	assert.Empty(t, k.GetAttestationMapping(ctx))
+	assert.Equal(t, uint64(0), k.GetLastObservedEventNonce(ctx))
+	assert.Equal(t, uint64(0), k.GetLastAppliedEventNonce(ctx))
+	assert.Equal(t, uint64(0), k.GetLastEventNonceByValidator(ctx, ValAddrs[0]))
+	assert.True(t, k.markEventApplied(ctx, &types.MsgDepositClaim{EventNonce: 1}))
+	var rotated bool
+	for _, event := range ctx.EventManager().Events() {
+		rotated = rotated || event.Type == types.EventTypeBridgeContractRotated
+	}
+	assert.True(t, rotated)
+
+	// the transfers are batched again for the new contract
+	batch, err := k.BuildOutgoingTXBatch(ctx, myTokenContractAddr, 4)
+	require.NoError(t, err)
+	assert.Len(t, batch.Transactions, 4)
+}
@@ -2,9 +2,12 @@ package keeper
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/cosmos/cosmos-sdk/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
 )
 
@@ -34,10 +37,181 @@ func (k Keeper) setCosmosOriginatedDenomToERC20(ctx sdk.Context, denom string, t
 	store.Set(types.GetERC20ToDenomKey(tokenContract), []byte(denom))
 }
 
+// retireCosmosOriginatedERC20 removes the ERC20 of the denom for transfers to Ethereum, the ERC20 keeps
+// standing for the denom so that deposits of it release the escrow backing its supply on Ethereum
+func (k Keeper) retireCosmosOriginatedERC20(ctx sdk.Context, denom string) {
+	ctx.KVStore(k.storeKey).Delete(types.GetDenomToERC20Key(denom))
+}
+
+// setRetiredCosmosOriginatedERC20 records an ERC20 that only stands for the denom for deposits
+func (k Keeper) setRetiredCosmosOriginatedERC20(ctx sdk.Context, denom string, tokenContract string) {
+	ctx.KVStore(k.storeKey).Set(types.GetERC20ToDenomKey(tokenContract), []byte(denom))
+}
+
+// getERC20Decimals returns the decimals of a cosmos originated ERC20, zero if it has the decimals of its denom
+func (k Keeper) getERC20Decimals(ctx sdk.Context, tokenContract string) uint32 {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetERC20DecimalsKey(tokenContract))
+	if bz == nil {
+		return 0
+	}
+	return uint32(types.UInt64FromBytes(bz))
+}
+
+// setERC20Decimals records the decimals of a cosmos originated ERC20 when they differ from the decimals
+// of its denom, zero decimals mean the ERC20 has the decimals of its denom
+func (k Keeper) setERC20Decimals(ctx sdk.Context, tokenContract string, decimals uint32) {
+	store := ctx.KVStore(k.storeKey)
+	if decimals == 0 {
+		store.Delete(types.GetERC20DecimalsKey(tokenContract))
+		return
+	}
+	store.Set(types.GetERC20DecimalsKey(tokenContract), types.UInt64Bytes(uint64(decimals)))
+}
+
+// tokenDecimals returns the decimals of an ERC20 and of the denom it stands for on Cosmos. They only differ
+// for a cosmos originated ERC20 governance set with other decimals than its denom, the vouchers of ethereum
+// originated ERC20s and the ERC20s deployed for a denom have the same decimals.
+func (k Keeper) tokenDecimals(ctx sdk.Context, tokenContract, denom string, isCosmosOriginated bool) (uint32, uint32) {
+	if !isCosmosOriginated {
+		return 0, 0
+	}
+	decimals := k.getERC20Decimals(ctx, tokenContract)
+	if decimals == 0 {
+		return 0, 0
+	}
+	return decimals, erc20Decimals(k.bankKeeper.GetDenomMetaData(ctx, denom))
+}
+
+// ERC20ToCoin returns whether the ERC20 is cosmos originated and the coin the ERC20 amount stands for on
+// Cosmos, rounded down when the ERC20 has more decimals than its denom
+func (k Keeper) ERC20ToCoin(ctx sdk.Context, token types.ERC20Token) (bool, sdk.Coin) {
+	isCosmosOriginated, denom := k.ERC20ToDenomLookup(ctx, token.Contract)
+	erc20Decimals, denomDecimals := k.tokenDecimals(ctx, token.Contract, denom, isCosmosOriginated)
+	coin, _ := token.ToCoin(denom, erc20Decimals, denomDecimals)
+	return isCosmosOriginated, coin
+}
+
+// CoinToERC20 returns whether the denom of the coin is cosmos originated and the ERC20 amount the coin
+// stands for on Ethereum. It fails when the denom has no ERC20 or the ERC20 can't represent the amount.
+func (k Keeper) CoinToERC20(ctx sdk.Context, coin sdk.Coin) (bool, *types.ERC20Token, error) {
+	isCosmosOriginated, tokenContract, err := k.DenomToERC20Lookup(ctx, coin.Denom)
+	if err != nil {
+		return false, nil, err
+	}
+	erc20Decimals, denomDecimals := k.tokenDecimals(ctx, tokenContract, coin.Denom, isCosmosOriginated)
+	token, err := types.NewERC20TokenFromCoin(coin, tokenContract, erc20Decimals, denomDecimals)
+	if err != nil {
+		return false, nil, err
+	}
+	return isCosmosOriginated, token, nil
+}
+
+// SetCosmosOriginatedERC20 corrects the ERC20 of a cosmos originated denom when called with the authority
+// of the module, for when the first observed deployment of the denom was malicious or has the wrong
+// decimals. The decimals of the ERC20 are only needed when they differ from the decimals of the denom,
+// bridged amounts are then scaled between the two. An empty token contract removes the ERC20 of the
+// denom, so that the next observed deployment of it is applied. The old ERC20 can't have outstanding
+// batches, pause it and cancel them first, its transfers still in the pool or scheduled are refunded to
+// their senders. From then on the old ERC20 is retired: transfers to Ethereum use the new ERC20, but
+// deposits of the old one still release the denom from the escrow that backs its supply on Ethereum.
+func (k Keeper) SetCosmosOriginatedERC20(ctx sdk.Context, authority string, denom string, tokenContract string, decimals uint32) error {
+	if authority != k.authority {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "expected authority %s, got %s", k.authority, authority)
+	}
+	metadata := k.bankKeeper.GetDenomMetaData(ctx, denom)
+	if metadata.Base == "" {
+		return sdkerrors.Wrapf(types.ErrUnknown, "denom not found %s", denom)
+	}
+	if decimals == erc20Decimals(metadata) {
+		decimals = 0
+	}
+	oldERC20, mapped := k.GetCosmosOriginatedERC20(ctx, denom)
+	if tokenContract != "" {
+		// the ERC20 of the denom can be set again to correct its decimals
+		if existingDenom, exists := k.GetCosmosOriginatedDenom(ctx, tokenContract); exists && existingDenom != denom {
+			return sdkerrors.Wrapf(types.ErrDuplicate, "ERC20 %s already represents denom %s", tokenContract, existingDenom)
+		}
+		// vouchers of the token would no longer be backed once the token stands for the denom
+		if supply := k.bankKeeper.GetSupply(ctx).GetTotal().AmountOf(types.PeggyDenom(tokenContract)); !supply.IsZero() {
+			return sdkerrors.Wrapf(types.ErrInvalid, "%s vouchers of ERC20 %s are in circulation", supply, tokenContract)
+		}
+	} else if !mapped {
+		return sdkerrors.Wrapf(types.ErrUnknown, "denom %s has no ERC20", denom)
+	}
+
+	if mapped {
+		if err := k.refundCosmosOriginatedERC20Transfers(ctx, oldERC20); err != nil {
+			return sdkerrors.Wrapf(err, "ERC20 %s", oldERC20)
+		}
+		k.retireCosmosOriginatedERC20(ctx, denom)
+	}
+	if tokenContract != "" {
+		k.setCosmosOriginatedDenomToERC20(ctx, denom, tokenContract)
+		k.setERC20Decimals(ctx, tokenContract, decimals)
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeCosmosOriginatedERC20Set,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyCosmosDenom, denom),
+		sdk.NewAttribute(types.AttributeKeyTokenContract, tokenContract),
+		sdk.NewAttribute(types.AttributeKeyExistingERC20, oldERC20),
+	))
+	return nil
+}
+
+// refundCosmosOriginatedERC20Transfers refunds the transfers of a cosmos originated ERC20 that are in the
+// pool or scheduled, while the ERC20 still stands for its denom so that the senders get the denom back.
+// Transfers in batches can't be refunded as the batches may still be executed.
+func (k Keeper) refundCosmosOriginatedERC20Transfers(ctx sdk.Context, tokenContract string) error {
+	inBatch := false
+	k.IterateOutgoingTXBatches(ctx, func(_ []byte, batch *types.OutgoingTxBatch) bool {
+		inBatch = batch.TokenContract == tokenContract
+		return inBatch
+	})
+	k.IterateCancelledBatches(ctx, tokenContract, func(_ *types.OutgoingTxBatch) bool {
+		inBatch = true
+		return true
+	})
+	if inBatch {
+		return sdkerrors.Wrap(types.ErrInvalid, "batches outstanding")
+	}
+
+	// refund outside of the iteration to not modify the store while iterating it
+	var txs []*types.OutgoingTransferTx
+	k.IterateOutgoingPoolByFee(ctx, tokenContract, func(_ uint64, tx *types.OutgoingTransferTx) bool {
+		txs = append(txs, tx)
+		return false
+	})
+	for _, scheduled := range k.GetScheduledTransfers(ctx) {
+		if scheduled.Tx.Erc20Token.Contract == tokenContract {
+			k.deleteScheduledTransfer(ctx, scheduled)
+			tx := scheduled.Tx
+			txs = append(txs, &tx)
+		}
+	}
+	for _, tx := range txs {
+		sender, err := sdk.AccAddressFromBech32(tx.Sender)
+		if err != nil {
+			return sdkerrors.Wrapf(err, "sender of tx %d", tx.Id)
+		}
+		if err := k.refundOutgoingTx(ctx, tx, sender); err != nil {
+			return sdkerrors.Wrapf(err, "tx %d", tx.Id)
+		}
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeBridgeWithdrawCanceled,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(types.AttributeKeyOutgoingTXID, fmt.Sprint(tx.Id)),
+		))
+	}
+	return nil
+}
+
 // DenomToERC20 returns (bool isCosmosOriginated, string ERC20, err)
 // Using this information, you can see if an asset is native to Cosmos or Ethereum, and get its corresponding ERC20 address
-// This will return an error if it cant parse the denom as a peggy denom, and then also can't find the denom
-// in an index of ERC20 contracts deployed on Ethereum to serve as synthetic Cosmos assets.
+// This will return ErrNotDeployed if it cant parse the denom as a peggy denom, and then also can't find the denom
+// in an index of ERC20 contracts deployed on Ethereum to serve as synthetic Cosmos assets. A denom only enters that
+// index once an ERC20DeployedClaim matching its metadata has been observed.
 func (k Keeper) DenomToERC20Lookup(ctx sdk.Context, denom string) (bool, string, error) {
 	// First try parsing the ERC20 out of the denom
 	tc1, err := types.PeggyDenomToERC20(denom)
@@ -46,7 +220,7 @@ func (k Keeper) DenomToERC20Lookup(ctx sdk.Context, denom string) (bool, string,
 		// Look up ERC20 contract in index and error if it's not in there.
 		tc2, exists := k.GetCosmosOriginatedERC20(ctx, denom)
 		if !exists {
-			return false, "", fmt.Errorf("denom not a peggy voucher coin: %s, and also not in cosmos-originated ERC20 index", err)
+			return false, "", sdkerrors.Wrapf(types.ErrNotDeployed, "denom %s is not a peggy voucher coin and not in cosmos-originated ERC20 index", denom)
 		}
 		// This is a cosmos-originated asset
 		return true, tc2, nil
@@ -56,6 +230,70 @@ func (k Keeper) DenomToERC20Lookup(ctx sdk.Context, denom string) (bool, string,
 	}
 }
 
+// PredictCosmosOriginatedERC20 returns the address the bridge contract deploys the ERC20 of a cosmos
+// originated denom at, derived with CREATE2 from the ERC20InitCodeHash param, together with the name,
+// symbol and decimals the ERC20DeployedClaim of the denom has to match. For a denom whose ERC20 was
+// already observed the observed address is returned. Bridge contracts deploying the ERC20s with CREATE
+// have no init code hash, the prediction fails until the bridge moved to a contract deploying with
+// CREATE2.
+func (k Keeper) PredictCosmosOriginatedERC20(ctx sdk.Context, denom string) (*types.QueryPredictERC20AddressResponse, error) {
+	if _, err := types.PeggyDenomToERC20(denom); err == nil {
+		return nil, sdkerrors.Wrapf(types.ErrInvalid, "%s is ethereum originated", denom)
+	}
+	metadata := k.bankKeeper.GetDenomMetaData(ctx, denom)
+	if metadata.Base == "" {
+		return nil, sdkerrors.Wrapf(types.ErrUnknown, "denom not found %s", denom)
+	}
+	res := &types.QueryPredictERC20AddressResponse{
+		Name:     metadata.Display,
+		Symbol:   metadata.Display,
+		Decimals: erc20Decimals(metadata),
+	}
+	if erc20, exists := k.GetCosmosOriginatedERC20(ctx, denom); exists {
+		res.Erc20 = erc20
+		res.Deployed = true
+		return res, nil
+	}
+	if res.Decimals > math.MaxUint8 {
+		return nil, sdkerrors.Wrapf(types.ErrInvalid, "%d decimals don't fit an ERC20", res.Decimals)
+	}
+
+	params := k.GetParams(ctx)
+	if params.Erc20InitCodeHash == "" {
+		return nil, sdkerrors.Wrap(types.ErrEmpty, "erc20 init code hash param, the bridge contract doesn't deploy ERC20s with CREATE2")
+	}
+	erc20, err := types.PredictCosmosERC20Address(params.BridgeEthereumAddress, params.Erc20InitCodeHash, denom, res.Name, res.Symbol, uint8(res.Decimals))
+	if err != nil {
+		return nil, err
+	}
+	res.Erc20 = erc20
+	return res, nil
+}
+
+// erc20Decimals returns the decimals the ERC20 of a cosmos originated denom has.
+//
+// ERC20 tokens use a very simple mechanism to tell you where to display the decimal point.
+// The "decimals" field simply tells you how many decimal places there will be.
+// Cosmos denoms have a system that is much more full featured, with enterprise-ready token denominations.
+// There is a DenomUnits array that tells you what the name of each denomination of the
+// token is.
+// To correlate this with an ERC20 "decimals" field, we have to search through the DenomUnits array
+// to find the DenomUnit which matches up to the main token "display" value. Then we take the
+// "exponent" from this DenomUnit.
+// If the correct DenomUnit is not found, it will default to 0. This will result in there being no decimal places
+// in the token's ERC20 on Ethereum. So, for example, if this happened with Atom, 1 Atom would appear on Ethereum
+// as 1 million Atoms, having 6 extra places before the decimal point.
+// This will only happen with a Denom Metadata which is for all intents and purposes invalid, but I am not sure
+// this is checked for at any other point.
+func erc20Decimals(metadata banktypes.Metadata) uint32 {
+	for _, denomUnit := range metadata.DenomUnits {
+		if denomUnit.Denom == metadata.Display {
+			return denomUnit.Exponent
+		}
+	}
+	return 0
+}
+
 // ERC20ToDenom returns (bool isCosmosOriginated, string denom, err)
 // Using this information, you can see if an ERC20 address represents an asset is native to Cosmos or Ethereum,
 // and get its corresponding denom
@@ -71,7 +309,16 @@ func (k Keeper) ERC20ToDenomLookup(ctx sdk.Context, tokenContract string) (bool,
 	}
 }
 
-// IterateERC20ToDenom iterates over erc20 to denom relations
+// registerVoucherMetadata registers the bank metadata of the voucher of an ethereum originated ERC20
+// the first time it is minted
+func (k Keeper) registerVoucherMetadata(ctx sdk.Context, tokenContract string) {
+	if k.bankKeeper.GetDenomMetaData(ctx, types.PeggyDenom(tokenContract)).Base != "" {
+		return
+	}
+	k.bankKeeper.SetDenomMetaData(ctx, types.PeggyDenomMetadata(tokenContract))
+}
+
+// IterateERC20ToDenom iterates over erc20 to denom relations, including the retired ERC20s
 func (k Keeper) IterateERC20ToDenom(ctx sdk.Context, cb func([]byte, *types.ERC20ToDenom) bool) {
 	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.ERC20ToDenomKey)
 	iter := prefixStore.Iterator(nil, nil)
@@ -79,9 +326,12 @@ func (k Keeper) IterateERC20ToDenom(ctx sdk.Context, cb func([]byte, *types.ERC2
 
 	for ; iter.Valid(); iter.Next() {
 		erc20ToDenom := types.ERC20ToDenom{
-			Erc20: string(iter.Key()),
-			Denom: string(iter.Value()),
+			Erc20:    string(iter.Key()),
+			Denom:    string(iter.Value()),
+			Decimals: k.getERC20Decimals(ctx, string(iter.Key())),
 		}
+		current, _ := k.GetCosmosOriginatedERC20(ctx, erc20ToDenom.Denom)
+		erc20ToDenom.Retired = current != erc20ToDenom.Erc20
 		// cb returns true to stop early
 		if cb(iter.Key(), &erc20ToDenom) {
 			break
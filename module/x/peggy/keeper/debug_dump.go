@@ -0,0 +1,36 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// EnableDebugQueries makes the legacy querier answer the debug routes, which read the whole peggy
+// store and must not be exposed on public nodes. It has to be called before the keeper is handed
+// to any other module since they hold their own copy of it.
+func (k *Keeper) EnableDebugQueries() *Keeper {
+	k.debugQueries = true
+	return k
+}
+
+// DebugQueriesEnabled returns true if the node was started with the debug queries enabled
+func (k Keeper) DebugQueriesEnabled() bool {
+	return k.debugQueries
+}
+
+// DumpStore returns every entry of the peggy store in key order, each with the name of the
+// registered prefix it is stored under, for comparing the state of two nodes
+func (k Keeper) DumpStore(ctx sdk.Context) *types.StoreDump {
+	dump := &types.StoreDump{Height: ctx.BlockHeight()}
+	iter := ctx.KVStore(k.storeKey).Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		dump.Entries = append(dump.Entries, types.StoreDumpEntry{
+			Prefix: types.KeyPrefixName(iter.Key()),
+			Key:    iter.Key(),
+			Value:  iter.Value(),
+		})
+	}
+	return dump
+}
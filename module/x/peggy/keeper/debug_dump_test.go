@@ -0,0 +1,36 @@
+package keeper
+
+import (
+	"testing"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestDebugDump(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	k.SetEthAddress(ctx, ValAddrs[0], EthAddrs[0].String())
+
+	// the debug routes are off unless enabled with the start flag
+	_, err := NewQuerier(k)(ctx, []string{QueryDebugDump}, abci.RequestQuery{})
+	assert.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+
+	bz, err := NewQuerier(*k.EnableDebugQueries())(ctx, []string{QueryDebugDump}, abci.RequestQuery{})
+	require.NoError(t, err)
+	var dump types.StoreDump
+	require.NoError(t, dump.Unmarshal(bz))
+	assert.Equal(t, ctx.BlockHeight(), dump.Height)
+	assert.Contains(t, dump.Entries, types.StoreDumpEntry{
+		Prefix: "EthAddressKey",
+		Key:    types.GetEthAddressKey(ValAddrs[0]),
+		Value:  []byte(EthAddrs[0].String()),
+	})
+	assert.Equal(t, *k.DumpStore(ctx), dump)
+	assert.Empty(t, types.DiffStoreDumps(dump, *k.DumpStore(ctx)))
+}
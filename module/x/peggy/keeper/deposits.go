@@ -0,0 +1,88 @@
+package keeper
+
+import (
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// recordObservedDeposit indexes an observed deposit claim by its ethereum sender
+func (k Keeper) recordObservedDeposit(ctx sdk.Context, claim *types.MsgDepositClaim, coin sdk.Coin) {
+	k.SetObservedDeposit(ctx, types.ObservedDeposit{
+		EventNonce:     claim.EventNonce,
+		BlockHeight:    claim.BlockHeight,
+		TokenContract:  claim.TokenContract,
+		EthereumSender: claim.EthereumSender,
+		CosmosReceiver: claim.CosmosReceiver,
+		Amount:         coin,
+	})
+}
+
+// ParseCosmosReceiver returns the account a deposit is credited to. The receiver is a bech32 address
+// or, with the HexCosmosReceivers param set, a 0x prefixed 20 byte hex address naming the account with
+// the same bytes, as shown by wallets on chains with eth_secp256k1 accounts.
+func (k Keeper) ParseCosmosReceiver(ctx sdk.Context, receiver string) (sdk.AccAddress, error) {
+	if strings.HasPrefix(receiver, "0x") && common.IsHexAddress(receiver) {
+		if !k.GetParams(ctx).HexCosmosReceivers {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "hex receiver %s is not enabled", receiver)
+		}
+		return sdk.AccAddress(common.HexToAddress(receiver).Bytes()), nil
+	}
+	return sdk.AccAddressFromBech32(receiver)
+}
+
+// SetObservedDeposit stores an observed deposit by its ethereum sender and event nonce
+func (k Keeper) SetObservedDeposit(ctx sdk.Context, deposit types.ObservedDeposit) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetDepositByEthSenderKey(deposit.EthereumSender, deposit.EventNonce), k.cdc.MustMarshalBinaryBare(&deposit))
+}
+
+// IterateObservedDeposits iterates over the observed deposits of all ethereum senders, grouped
+// by sender and in ascending event nonce order for each
+func (k Keeper) IterateObservedDeposits(ctx sdk.Context, cb func(types.ObservedDeposit) bool) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.DepositByEthSenderKey)
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var deposit types.ObservedDeposit
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &deposit)
+		// cb returns true to stop early
+		if cb(deposit) {
+			break
+		}
+	}
+}
+
+// GetObservedDeposits returns all observed deposits
+func (k Keeper) GetObservedDeposits(ctx sdk.Context) (out []types.ObservedDeposit) {
+	k.IterateObservedDeposits(ctx, func(deposit types.ObservedDeposit) bool {
+		out = append(out, deposit)
+		return false
+	})
+	return
+}
+
+// PaginateDepositsByEthSender returns a page of the deposits observed from the given ethereum
+// sender in ascending event nonce order
+func (k Keeper) PaginateDepositsByEthSender(ctx sdk.Context, ethSender string, pageReq *query.PageRequest) ([]types.ObservedDeposit, *query.PageResponse, error) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetDepositByEthSenderPrefix(ethSender))
+	var deposits []types.ObservedDeposit
+	pageRes, err := query.Paginate(prefixStore, limitPageRequest(pageReq), func(_, value []byte) error {
+		var deposit types.ObservedDeposit
+		if err := k.cdc.UnmarshalBinaryBare(value, &deposit); err != nil {
+			return err
+		}
+		deposits = append(deposits, deposit)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return deposits, pageRes, nil
+}
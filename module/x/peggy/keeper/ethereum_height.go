@@ -0,0 +1,158 @@
+package keeper
+
+import (
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// The module has no direct view of Ethereum, it learns the Ethereum block height from the claims of the
+// orchestrators. The height of the last observed claim is stored along with the Cosmos height it was
+// observed at, so the current Ethereum height can be projected from the average block times. Timeouts
+// are set on the projection, so they are in the future even if nothing was observed for a while, but
+// only ever expire on the observed height, a slowdown on Ethereum must never time out a batch that can
+// still be executed.
+
+// GetLastObservedEthereumBlockHeight returns the Ethereum height of the last observed claim along with the
+// Cosmos height it was observed at, both zero if nothing was observed yet
+func (k Keeper) GetLastObservedEthereumBlockHeight(ctx sdk.Context) types.LastObservedEthereumBlockHeight {
+	store := ctx.KVStore(k.storeKey)
+	bytes := store.Get(types.LastObservedEthereumBlockHeightKey)
+
+	height := types.LastObservedEthereumBlockHeight{}
+	if len(bytes) == 0 {
+		return height
+	}
+	k.cdc.MustUnmarshalBinaryBare(bytes, &height)
+	return height
+}
+
+// SetLastObservedEthereumBlockHeight records the Ethereum height of an observed claim along with the
+// current Cosmos height
+func (k Keeper) SetLastObservedEthereumBlockHeight(ctx sdk.Context, ethereumHeight uint64) {
+	store := ctx.KVStore(k.storeKey)
+	height := types.LastObservedEthereumBlockHeight{
+		EthereumBlockHeight: ethereumHeight,
+		CosmosBlockHeight:   uint64(ctx.BlockHeight()),
+	}
+	store.Set(types.LastObservedEthereumBlockHeightKey, k.cdc.MustMarshalBinaryBare(&height))
+}
+
+// GetLatestEthereumBlockHeight returns the highest Ethereum block height claimed by an attestation
+// that reached the vote threshold, along with the Cosmos height it was recorded at
+func (k Keeper) GetLatestEthereumBlockHeight(ctx sdk.Context) types.LastObservedEthereumBlockHeight {
+	store := ctx.KVStore(k.storeKey)
+	bytes := store.Get(types.LatestEthereumBlockHeightKey)
+
+	height := types.LastObservedEthereumBlockHeight{}
+	if len(bytes) == 0 {
+		return height
+	}
+	k.cdc.MustUnmarshalBinaryBare(bytes, &height)
+	return height
+}
+
+// setLatestEthereumBlockHeight sets the highest Ethereum block height claimed by an attestation
+// that reached the vote threshold
+func (k Keeper) setLatestEthereumBlockHeight(ctx sdk.Context, ethereumHeight uint64) {
+	store := ctx.KVStore(k.storeKey)
+	height := types.LastObservedEthereumBlockHeight{
+		EthereumBlockHeight: ethereumHeight,
+		CosmosBlockHeight:   uint64(ctx.BlockHeight()),
+	}
+	store.Set(types.LatestEthereumBlockHeightKey, k.cdc.MustMarshalBinaryBare(&height))
+}
+
+// GetProjectedEthereumHeight estimates the current Ethereum block height from the last observed
+// Ethereum height and the time that has passed on Cosmos since, returns zero if nothing was observed yet
+func (k Keeper) GetProjectedEthereumHeight(ctx sdk.Context) uint64 {
+	return k.projectEthereumHeight(ctx, k.GetLastObservedEthereumBlockHeight(ctx))
+}
+
+// projectEthereumHeight estimates the current Ethereum block height from an Ethereum height and the
+// Cosmos height it was recorded at, returns zero if no height was recorded
+func (k Keeper) projectEthereumHeight(ctx sdk.Context, heights types.LastObservedEthereumBlockHeight) uint64 {
+	params := k.GetParams(ctx)
+	currentCosmosHeight := ctx.BlockHeight()
+	if heights.CosmosBlockHeight == 0 || heights.EthereumBlockHeight == 0 {
+		return 0
+	}
+	// we project how long it has been in milliseconds since the last Ethereum block height was observed
+	projected_millis := (uint64(currentCosmosHeight) - heights.CosmosBlockHeight) * params.AverageBlockTime
+	// we convert that projection into the current Ethereum height using the average Ethereum block time in millis
+	return (projected_millis / params.AverageEthereumBlockTime) + heights.EthereumBlockHeight
+}
+
+// GetEthereumTimeoutHeight converts a timeout in milliseconds into the Ethereum height it expires at on top
+// of the projected Ethereum height, returns zero if nothing was observed yet
+func (k Keeper) GetEthereumTimeoutHeight(ctx sdk.Context, timeoutMillis uint64) uint64 {
+	projected := k.GetProjectedEthereumHeight(ctx)
+	if projected == 0 {
+		return 0
+	}
+	return projected + timeoutMillis/k.GetParams(ctx).AverageEthereumBlockTime
+}
+
+// getBatchTimeoutHeight returns the Ethereum height a batch created now times out at, TargetBatchTimeout
+// after the projected Ethereum height. We do not concern ourselves if the projection is zero because no
+// batch can be produced if the last Ethereum block height is not first populated by a deposit event.
+func (k Keeper) getBatchTimeoutHeight(ctx sdk.Context) uint64 {
+	return k.GetEthereumTimeoutHeight(ctx, k.GetParams(ctx).TargetBatchTimeout)
+}
+
+// IsEthereumHeightPassed returns true once the last observed Ethereum height is beyond the given timeout
+// height, this is never projected so a slowdown on Ethereum can't time out anything too early
+func (k Keeper) IsEthereumHeightPassed(ctx sdk.Context, timeout uint64) bool {
+	return timeout < k.GetLastObservedEthereumBlockHeight(ctx).EthereumBlockHeight
+}
+
+// getClaimEthereumHeight returns the Ethereum height of the last claim of a validator along with the Cosmos
+// height it was claimed at, both zero if the validator did not claim anything yet
+func (k Keeper) getClaimEthereumHeight(ctx sdk.Context, validator sdk.ValAddress) types.LastObservedEthereumBlockHeight {
+	store := ctx.KVStore(k.storeKey)
+	bytes := store.Get(types.GetClaimEthereumHeightByValidatorKey(validator))
+
+	height := types.LastObservedEthereumBlockHeight{}
+	if len(bytes) == 0 {
+		return height
+	}
+	k.cdc.MustUnmarshalBinaryBare(bytes, &height)
+	return height
+}
+
+// setClaimEthereumHeight records the Ethereum height of the last claim of a validator along with the current
+// Cosmos height
+func (k Keeper) setClaimEthereumHeight(ctx sdk.Context, validator sdk.ValAddress, ethereumHeight uint64) {
+	store := ctx.KVStore(k.storeKey)
+	height := types.LastObservedEthereumBlockHeight{
+		EthereumBlockHeight: ethereumHeight,
+		CosmosBlockHeight:   uint64(ctx.BlockHeight()),
+	}
+	store.Set(types.GetClaimEthereumHeightByValidatorKey(validator), k.cdc.MustMarshalBinaryBare(&height))
+}
+
+// getMedianClaimEthereumHeight projects the last claim of every bonded validator to the current Ethereum height
+// and returns the median of the projections, a single faulty orchestrator can't move it. The projection drifts
+// from the real Ethereum height the longer ago the median claim was made, so zero is returned once that is
+// longer ago than EthereumHeightWindow Ethereum blocks, or if none of the validators claimed anything yet.
+func (k Keeper) getMedianClaimEthereumHeight(ctx sdk.Context) uint64 {
+	var claims []types.LastObservedEthereumBlockHeight
+	for _, val := range k.StakingKeeper.GetBondedValidatorsByPower(ctx) {
+		if claim := k.getClaimEthereumHeight(ctx, val.GetOperator()); claim.EthereumBlockHeight != 0 {
+			claims = append(claims, claim)
+		}
+	}
+	if len(claims) == 0 {
+		return 0
+	}
+	sort.Slice(claims, func(i, j int) bool {
+		return k.projectEthereumHeight(ctx, claims[i]) < k.projectEthereumHeight(ctx, claims[j])
+	})
+	median := claims[len(claims)/2]
+	projected := k.projectEthereumHeight(ctx, median)
+	if projected-median.EthereumBlockHeight > k.GetParams(ctx).EthereumHeightWindow {
+		return 0
+	}
+	return projected
+}
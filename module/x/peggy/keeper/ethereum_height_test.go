@@ -0,0 +1,87 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEthereumHeightOracle(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	k := input.PeggyKeeper
+	ctx := input.Context.WithBlockHeight(100)
+	mySender, _ := sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+
+	// nothing observed yet, a logic call can't be given a timeout
+	assert.Zero(t, k.GetProjectedEthereumHeight(ctx))
+	assert.Zero(t, k.GetEthereumTimeoutHeight(ctx, 60000))
+	err := k.CreateOutgoingLogicCall(ctx, mySender, &types.OutgoingLogicCall{InvalidationId: []byte("invalidationId"), InvalidationNonce: 1})
+	assert.True(t, types.ErrInvalid.Is(err), err)
+
+	k.SetLastObservedEthereumBlockHeight(ctx, 1000)
+	assert.Equal(t, types.LastObservedEthereumBlockHeight{CosmosBlockHeight: 100, EthereumBlockHeight: 1000}, k.GetLastObservedEthereumBlockHeight(ctx))
+
+	// 30 blocks of 5 seconds are 10 ethereum blocks of 15 seconds
+	ctx = ctx.WithBlockHeight(130)
+	assert.Equal(t, uint64(1010), k.GetProjectedEthereumHeight(ctx))
+	assert.Equal(t, uint64(1014), k.GetEthereumTimeoutHeight(ctx, 60000))
+	assert.Equal(t, uint64(1014), k.getBatchTimeoutHeight(ctx))
+
+	// timeouts only expire on the observed height
+	assert.False(t, k.IsEthereumHeightPassed(ctx, 1000))
+	assert.True(t, k.IsEthereumHeightPassed(ctx, 999))
+
+	res, err := k.EthereumHeight(sdk.WrapSDKContext(ctx), &types.QueryEthereumHeightRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1000), res.LastObserved.EthereumBlockHeight)
+	assert.Equal(t, uint64(1010), res.ProjectedEthereumHeight)
+
+	// a logic call without a timeout gets the batch timeout, an expired one is rejected
+	call := &types.OutgoingLogicCall{InvalidationId: []byte("invalidationId"), InvalidationNonce: 1}
+	require.NoError(t, k.CreateOutgoingLogicCall(ctx, mySender, call))
+	assert.Equal(t, uint64(1014), k.GetOutgoingLogicCall(ctx, call.InvalidationId, 1).Timeout)
+	expired := &types.OutgoingLogicCall{InvalidationId: []byte("invalidationId"), InvalidationNonce: 2, Timeout: 999}
+	err = k.CreateOutgoingLogicCall(ctx, mySender, expired)
+	assert.True(t, types.ErrTimeout.Is(err), err)
+
+	// the observed height survives an export, recorded at the height the chain restarts at
+	genesis := ExportGenesis(ctx, k)
+	assert.Equal(t, uint64(1000), genesis.LastObservedEthereumHeight.EthereumBlockHeight)
+	restarted := CreateTestEnv(t)
+	InitGenesis(restarted.Context.WithBlockHeight(1), restarted.PeggyKeeper, genesis)
+	assert.Equal(t, types.LastObservedEthereumBlockHeight{CosmosBlockHeight: 1, EthereumBlockHeight: 1000}, restarted.PeggyKeeper.GetLastObservedEthereumBlockHeight(restarted.Context))
+
+	// a logic call without a timeout in the genesis gets the batch timeout as well
+	genesis.LogicCalls = []*types.OutgoingLogicCall{{InvalidationId: []byte("invalidationId"), InvalidationNonce: 3}}
+	restarted = CreateTestEnv(t)
+	InitGenesis(restarted.Context.WithBlockHeight(1), restarted.PeggyKeeper, genesis)
+	assert.Equal(t, uint64(1004), restarted.PeggyKeeper.GetOutgoingLogicCall(restarted.Context, []byte("invalidationId"), 3).Timeout)
+}
+
+func TestIsClaimConfirmed(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	k := input.PeggyKeeper
+	ctx := input.Context.WithBlockHeight(100)
+	params := k.GetParams(ctx)
+	params.EthereumBlockConfirmations = 6
+	k.SetParams(ctx, params)
+
+	// checking a claim leaves the latest height alone, only a claim reaching the threshold records it
+	claim := &types.MsgDepositClaim{EventNonce: 1, BlockHeight: 1000}
+	assert.False(t, k.isClaimConfirmed(ctx, claim))
+	assert.Zero(t, k.GetLatestEthereumBlockHeight(ctx).EthereumBlockHeight)
+	k.recordLatestEthereumHeight(ctx, claim)
+	assert.Equal(t, uint64(1000), k.GetLatestEthereumBlockHeight(ctx).EthereumBlockHeight)
+
+	// a later claim confirms it
+	k.recordLatestEthereumHeight(ctx, &types.MsgDepositClaim{EventNonce: 2, BlockHeight: 1006})
+	assert.True(t, k.isClaimConfirmed(ctx, claim))
+	k.recordLatestEthereumHeight(ctx, claim)
+	assert.Equal(t, uint64(1006), k.GetLatestEthereumBlockHeight(ctx).EthereumBlockHeight)
+}
@@ -0,0 +1,68 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// GetEventNonceGap returns how many observed events the validator has not claimed yet and the Ethereum
+// heights its orchestrator has to re-scan to find them
+func (k Keeper) GetEventNonceGap(ctx sdk.Context, validator sdk.ValAddress) types.QueryEventNonceGapResponse {
+	gap := types.QueryEventNonceGapResponse{
+		LastEventNonce:         k.GetLastEventNonceByValidator(ctx, validator),
+		LastObservedEventNonce: k.GetLastObservedEventNonce(ctx),
+		EndEthereumHeight:      k.GetLastObservedEthereumBlockHeight(ctx).EthereumBlockHeight,
+	}
+	if gap.LastObservedEventNonce > gap.LastEventNonce {
+		gap.MissingEvents = gap.LastObservedEventNonce - gap.LastEventNonce
+	}
+	for _, att := range k.GetAttestationsByNonce(ctx, gap.LastObservedEventNonce) {
+		if att.Observed {
+			claim, err := k.UnpackAttestationClaim(&att)
+			if err != nil {
+				panic("couldn't cast to claim")
+			}
+			gap.EndEthereumHeight = claim.GetBlockHeight()
+		}
+	}
+
+	// the claims of an event may disagree on its height, the lowest is taken so the scan doesn't miss it
+	if height, found := k.lowestClaimedHeight(ctx, gap.LastEventNonce+1); found {
+		gap.StartEthereumHeight, gap.ExactStart = height, true
+		return gap
+	}
+	// events are emitted in nonce order, the first missing event is at or above the last stored one below it
+	store := ctx.KVStore(k.storeKey)
+	iter := store.ReverseIterator(types.OracleAttestationKey, types.GetAttestationKey(gap.LastEventNonce+1, nil))
+	defer iter.Close()
+	if iter.Valid() {
+		var att types.Attestation
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &att)
+		if height, found := k.lowestClaimedHeight(ctx, k.attestationEventNonce(att)); found {
+			gap.StartEthereumHeight = height
+		}
+	}
+	return gap
+}
+
+// lowestClaimedHeight returns the lowest Ethereum height the stored claims of an event nonce report
+func (k Keeper) lowestClaimedHeight(ctx sdk.Context, eventNonce uint64) (height uint64, found bool) {
+	for _, att := range k.GetAttestationsByNonce(ctx, eventNonce) {
+		claim, err := k.UnpackAttestationClaim(&att)
+		if err != nil {
+			panic("couldn't cast to claim")
+		}
+		if !found || claim.GetBlockHeight() < height {
+			height, found = claim.GetBlockHeight(), true
+		}
+	}
+	return height, found
+}
+
+func (k Keeper) attestationEventNonce(att types.Attestation) uint64 {
+	claim, err := k.UnpackAttestationClaim(&att)
+	if err != nil {
+		panic("couldn't cast to claim")
+	}
+	return claim.GetEventNonce()
+}
@@ -0,0 +1,71 @@
+package keeper
+
+import (
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventNonceGap(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	attest := func(nonce, height uint64, observed bool) {
+		claim := &types.MsgDepositClaim{
+			EventNonce:     nonce,
+			BlockHeight:    height,
+			TokenContract:  TokenContractAddrs[0],
+			Amount:         sdk.NewInt(100),
+			EthereumSender: EthAddrs[0].String(),
+			CosmosReceiver: AccAddrs[0].String(),
+		}
+		any, err := codectypes.NewAnyWithValue(claim)
+		require.NoError(t, err)
+		k.SetAttestation(ctx, nonce, claim.ClaimHash(), &types.Attestation{Observed: observed, Claim: any})
+	}
+	k.SetOrchestratorValidator(ctx, ValAddrs[0], AccAddrs[0])
+	query := func() *types.QueryEventNonceGapResponse {
+		res, err := k.EventNonceGap(sdk.WrapSDKContext(ctx), &types.QueryEventNonceGapRequest{Orchestrator: AccAddrs[0].String()})
+		require.NoError(t, err)
+		return res
+	}
+
+	// events 5 to 9 are stored, the validator stopped after event 6
+	for nonce := uint64(5); nonce < 10; nonce++ {
+		attest(nonce, 100+nonce*10, nonce < 9)
+	}
+	k.setLastObservedEventNonce(ctx, 8)
+	k.SetLastObservedEthereumBlockHeight(ctx, 180)
+	k.setLastEventNonceByValidator(ctx, ValAddrs[0], 6)
+	assert.Equal(t, &types.QueryEventNonceGapResponse{
+		LastEventNonce:         6,
+		LastObservedEventNonce: 8,
+		MissingEvents:          2,
+		StartEthereumHeight:    170,
+		EndEthereumHeight:      180,
+		ExactStart:             true,
+	}, query())
+
+	// once event 7 is pruned the height of event 6 bounds the scan
+	att := k.GetAttestationsByNonce(ctx, 7)[0]
+	claim, err := k.UnpackAttestationClaim(&att)
+	require.NoError(t, err)
+	k.DeleteAttestation(ctx, 7, claim.ClaimHash(), &att)
+	res := query()
+	assert.Equal(t, uint64(160), res.StartEthereumHeight)
+	assert.False(t, res.ExactStart)
+
+	// a validator that is up to date has nothing to re-scan
+	k.setLastEventNonceByValidator(ctx, ValAddrs[0], 8)
+	res = query()
+	assert.Zero(t, res.MissingEvents)
+	assert.Equal(t, uint64(190), res.StartEthereumHeight)
+
+	_, err = k.EventNonceGap(sdk.WrapSDKContext(ctx), &types.QueryEventNonceGapRequest{Orchestrator: AccAddrs[1].String()})
+	assert.Error(t, err)
+}
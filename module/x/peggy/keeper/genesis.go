@@ -8,6 +8,14 @@ import (
 // InitGenesis starts a chain from a genesis state
 func InitGenesis(ctx sdk.Context, k Keeper, data types.GenesisState) {
 	k.SetParams(ctx, *data.Params)
+	// a chain started from genesis needs none of the store migrations
+	k.setStoreVersion(ctx, types.StoreVersion)
+	// reset the last observed ethereum height, recorded at the genesis height so the projection restarts
+	// from it, the logic calls without a timeout are defaulted from the projection
+	if data.LastObservedEthereumHeight.EthereumBlockHeight != 0 {
+		k.SetLastObservedEthereumBlockHeight(ctx, data.LastObservedEthereumHeight.EthereumBlockHeight)
+	}
+
 	// reset valsets in state
 	for _, vs := range data.Valsets {
 		// TODO: block height?
@@ -32,6 +40,9 @@ func InitGenesis(ctx sdk.Context, k Keeper, data types.GenesisState) {
 
 	// reset logic calls in state
 	for _, call := range data.LogicCalls {
+		if err := k.defaultLogicCallTimeout(ctx, call); err != nil {
+			panic(err)
+		}
 		k.SetOutgoingLogicCall(ctx, call)
 	}
 
@@ -42,7 +53,10 @@ func InitGenesis(ctx sdk.Context, k Keeper, data types.GenesisState) {
 
 	// reset pool transactions in state
 	for _, tx := range data.UnbatchedTransfers {
-		k.setPoolEntry(ctx, tx)
+		if err := k.setPoolEntry(ctx, tx); err != nil {
+			panic(err)
+		}
+		k.addToUnbatchedTXIndex(ctx, tx)
 	}
 
 	// reset attestations in state
@@ -56,6 +70,9 @@ func InitGenesis(ctx sdk.Context, k Keeper, data types.GenesisState) {
 		k.SetAttestation(ctx, claim.GetEventNonce(), claim.ClaimHash(), &att)
 	}
 	k.setLastObservedEventNonce(ctx, data.LastObservedNonce)
+	if data.LastAppliedEventNonce != 0 {
+		k.setLastAppliedEventNonce(ctx, data.LastAppliedEventNonce)
+	}
 
 	// reset attestation state of specific validators
 	// this must be done after the above to be correct
@@ -101,7 +118,126 @@ func InitGenesis(ctx sdk.Context, k Keeper, data types.GenesisState) {
 
 	// populate state with cosmos originated denom-erc20 mapping
 	for _, item := range data.Erc20ToDenoms {
-		k.setCosmosOriginatedDenomToERC20(ctx, item.Denom, item.Erc20)
+		if item.Retired {
+			k.setRetiredCosmosOriginatedERC20(ctx, item.Denom, item.Erc20)
+		} else {
+			k.setCosmosOriginatedDenomToERC20(ctx, item.Denom, item.Erc20)
+		}
+		k.setERC20Decimals(ctx, item.Erc20, item.Decimals)
+	}
+
+	// reset deposits awaiting reclamation in state
+	for _, deposit := range data.ReclaimableDeposits {
+		k.SetReclaimableDeposit(ctx, deposit)
+	}
+
+	// reset the transfers executed on Ethereum in state
+	for _, txID := range data.ProcessedTxIds {
+		k.SetTxProcessed(ctx, txID)
+	}
+
+	// reset the deposit index by ethereum sender in state
+	for _, deposit := range data.ObservedDeposits {
+		k.SetObservedDeposit(ctx, deposit)
+	}
+
+	// reset the ethereum executions of batches in state
+	for _, execution := range data.BatchExecutions {
+		k.SetBatchExecution(ctx, execution)
+	}
+
+	// reset the valset nonce continuity in state
+	k.setLastObservedValsetNonce(ctx, data.LastObservedValsetNonce)
+	for _, nonce := range data.SkippedValsetNonces {
+		k.setSkippedValsetNonce(ctx, nonce)
+	}
+
+	// reset the bridged volume of the tokens in state
+	for _, stats := range data.BridgeStats {
+		k.SetBridgeStats(ctx, stats)
+	}
+
+	// reset the registered relayers in state
+	for _, relayer := range data.Relayers {
+		acc, err := sdk.AccAddressFromBech32(relayer.Relayer)
+		if err != nil {
+			panic(err)
+		}
+		if err := types.ValidateEthAddress(relayer.EthAddress); err != nil {
+			panic(err)
+		}
+		k.SetRelayer(ctx, acc, relayer.EthAddress)
+	}
+
+	// reset the omnibus accounts in state
+	for _, account := range data.OmnibusAccounts {
+		acc, err := sdk.AccAddressFromBech32(account)
+		if err != nil {
+			panic(err)
+		}
+		k.SetOmnibusAccount(ctx, acc, true)
+	}
+
+	// reset the flagged tokens in state
+	for _, quirk := range data.TokenQuirks {
+		k.SetTokenQuirk(ctx, quirk)
+	}
+
+	// reset the paused tokens in state
+	for _, token := range data.PausedTokens {
+		k.setPausedToken(ctx, token)
+	}
+
+	// reset the cancelled batches that may still be executed in state
+	for i := range data.CancelledBatches {
+		k.setCancelledBatch(ctx, &data.CancelledBatches[i])
+	}
+
+	// reset the batch index of the outgoing transfers, this includes the transfers of executed batches
+	for _, batched := range data.BatchedTxs {
+		k.SetBatchedTx(ctx, batched)
+	}
+
+	// reset the transfers waiting for their earliest execution, their amounts and fees are part
+	// of the exported balances of the module accounts
+	for _, scheduled := range data.ScheduledTransfers {
+		k.setScheduledTransfer(ctx, scheduled)
+		k.indexScheduledTransfer(ctx, scheduled)
+	}
+
+	// reset the grants in state, expired ones are kept as they were exported
+	for _, grant := range data.Grants {
+		granter, err := sdk.AccAddressFromBech32(grant.Granter)
+		if err != nil {
+			panic(err)
+		}
+		grantee, err := sdk.AccAddressFromBech32(grant.Grantee)
+		if err != nil {
+			panic(err)
+		}
+		if err := k.setGrant(ctx, granter, grantee, grant.Grant); err != nil {
+			panic(err)
+		}
+	}
+
+	// reset the bridge ledgers of the accounts in state, new entries continue after the highest id
+	var lastActivityID uint64
+	for _, activity := range data.AccountActivities {
+		acc, err := sdk.AccAddressFromBech32(activity.Account)
+		if err != nil {
+			panic(err)
+		}
+		k.setAccountActivity(ctx, acc, activity)
+		if activity.Id > lastActivityID {
+			lastActivityID = activity.Id
+		}
+	}
+	if lastActivityID != 0 {
+		ctx.KVStore(k.storeKey).Set(types.KeyLastAccountActivityID, sdk.Uint64ToBigEndian(lastActivityID+1))
+	}
+
+	if !data.NativeTokenEscrow.IsNil() {
+		k.setNativeTokenEscrow(ctx, data.NativeTokenEscrow)
 	}
 }
 
@@ -122,6 +258,25 @@ func ExportGenesis(ctx sdk.Context, k Keeper) types.GenesisState {
 		lastobserved        = k.GetLastObservedEventNonce(ctx)
 		erc20ToDenoms       = []*types.ERC20ToDenom{}
 		unbatched_transfers = k.GetPoolTransactions(ctx)
+		reclaimable         = k.GetReclaimableDeposits(ctx)
+		processed           = k.GetProcessedTxIDs(ctx)
+		observedDeposits    = k.GetObservedDeposits(ctx)
+		batchExecutions     = k.GetBatchExecutions(ctx)
+		lastObservedValset  = k.GetLastObservedValsetNonce(ctx)
+		skippedValsets      = k.GetSkippedValsetNonces(ctx)
+		bridgeStats         = k.GetAllBridgeStats(ctx)
+		relayers            = k.GetAllRelayers(ctx)
+		lastObservedHeight  = k.GetLastObservedEthereumBlockHeight(ctx)
+		cancelledBatches    = k.GetCancelledBatches(ctx)
+		grants              = k.GetAllGrants(ctx)
+		tokenQuirks         = k.GetAllTokenQuirks(ctx)
+		pausedTokens        = k.GetPausedTokens(ctx)
+		batchedTxs          = k.GetBatchedTxs(ctx)
+		scheduledTransfers  = k.GetScheduledTransfers(ctx)
+		lastApplied         = k.GetLastAppliedEventNonce(ctx)
+		accountActivities   = k.GetAccountActivities(ctx)
+		nativeTokenEscrow   = k.GetNativeTokenEscrow(ctx)
+		omnibusAccounts     []string
 	)
 
 	// export valset confirmations from state
@@ -154,18 +309,43 @@ func ExportGenesis(ctx sdk.Context, k Keeper) types.GenesisState {
 		return false
 	})
 
+	for _, account := range k.GetOmnibusAccounts(ctx) {
+		omnibusAccounts = append(omnibusAccounts, account.String())
+	}
+
 	return types.GenesisState{
-		Params:             &p,
-		LastObservedNonce:  lastobserved,
-		Valsets:            valsets,
-		ValsetConfirms:     vsconfs,
-		Batches:            batches,
-		BatchConfirms:      batchconfs,
-		LogicCalls:         calls,
-		LogicCallConfirms:  callconfs,
-		Attestations:       attestations,
-		DelegateKeys:       delegates,
-		Erc20ToDenoms:      erc20ToDenoms,
-		UnbatchedTransfers: unbatched_transfers,
+		Params:              &p,
+		LastObservedNonce:   lastobserved,
+		Valsets:             valsets,
+		ValsetConfirms:      vsconfs,
+		Batches:             batches,
+		BatchConfirms:       batchconfs,
+		LogicCalls:          calls,
+		LogicCallConfirms:   callconfs,
+		Attestations:        attestations,
+		DelegateKeys:        delegates,
+		Erc20ToDenoms:       erc20ToDenoms,
+		UnbatchedTransfers:  unbatched_transfers,
+		ReclaimableDeposits: reclaimable,
+		ProcessedTxIds:      processed,
+		ObservedDeposits:    observedDeposits,
+		BatchExecutions:     batchExecutions,
+
+		LastObservedValsetNonce: lastObservedValset,
+		SkippedValsetNonces:     skippedValsets,
+		BridgeStats:             bridgeStats,
+		Relayers:                relayers,
+
+		LastObservedEthereumHeight: lastObservedHeight,
+		CancelledBatches:           cancelledBatches,
+		Grants:                     grants,
+		OmnibusAccounts:            omnibusAccounts,
+		TokenQuirks:                tokenQuirks,
+		PausedTokens:               pausedTokens,
+		BatchedTxs:                 batchedTxs,
+		ScheduledTransfers:         scheduledTransfers,
+		LastAppliedEventNonce:      lastApplied,
+		AccountActivities:          accountActivities,
+		NativeTokenEscrow:          nativeTokenEscrow,
 	}
 }
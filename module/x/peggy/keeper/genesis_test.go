@@ -0,0 +1,100 @@
+package keeper
+
+import (
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenesisRoundTrip(t *testing.T) {
+	t.Parallel()
+	input, ctx := SetupFiveValChain(t)
+	k := input.PeggyKeeper
+	var (
+		mySender, _         = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		myReceiver          = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		invalidationID      = []byte("invalidationId")
+		allVouchers         = sdk.Coins{types.NewERC20Token(99999, myTokenContractAddr).PeggyCoin()}
+	)
+	for i, val := range ValAddrs {
+		k.SetOrchestratorValidator(ctx, val, AccAddrs[i])
+	}
+	params := k.GetParams(ctx)
+	params.BatchCancelGracePeriod = 10
+	params.TokenDenylist = []string{"0x0bc529c00C6401aEF6D220BE8C6Ea1667F6Ad93e"}
+	k.SetParams(ctx, params)
+
+	// a valset, a batch and a logic call with a confirm each
+	valset := k.SetValsetRequest(ctx)
+	k.SetValsetConfirm(ctx, types.MsgValsetConfirm{
+		Nonce:        valset.Nonce,
+		Orchestrator: AccAddrs[0].String(),
+		EthAddress:   EthAddrs[0].String(),
+		Signature:    "00",
+	})
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, allVouchers))
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+	require.NoError(t, input.BankKeeper.SetBalances(ctx, mySender, allVouchers))
+	for i := uint64(1); i <= 4; i++ {
+		amount := types.NewERC20Token(100, myTokenContractAddr).PeggyCoin()
+		fee := types.NewERC20Token(i, myTokenContractAddr).PeggyCoin()
+		_, err := k.AddToOutgoingPool(ctx, mySender, myReceiver, amount, fee)
+		require.NoError(t, err)
+	}
+	batch, err := k.BuildOutgoingTXBatch(ctx, myTokenContractAddr, 2)
+	require.NoError(t, err)
+	k.SetBatchConfirm(ctx, &types.MsgConfirmBatch{
+		Nonce:         batch.BatchNonce,
+		TokenContract: myTokenContractAddr,
+		Orchestrator:  AccAddrs[0].String(),
+		EthSigner:     EthAddrs[0].String(),
+		Signature:     "00",
+	})
+	require.NoError(t, k.CreateOutgoingLogicCall(ctx, mySender, &types.OutgoingLogicCall{
+		Fees:              []*types.ERC20Token{types.NewERC20Token(10, myTokenContractAddr)},
+		Timeout:           1000,
+		InvalidationId:    invalidationID,
+		InvalidationNonce: 1,
+	}))
+	k.SetLogicCallConfirm(ctx, &types.MsgConfirmLogicCall{
+		InvalidationId:    "696e76616c69646174696f6e4964",
+		InvalidationNonce: 1,
+		Orchestrator:      AccAddrs[0].String(),
+		EthSigner:         EthAddrs[0].String(),
+		Signature:         "00",
+	})
+
+	// a deposit claim voted on by a single validator stays unobserved
+	claim := &types.MsgDepositClaim{
+		EventNonce:     1,
+		BlockHeight:    10,
+		TokenContract:  myTokenContractAddr,
+		Amount:         sdk.NewInt(12),
+		EthereumSender: myReceiver,
+		CosmosReceiver: mySender.String(),
+		Orchestrator:   AccAddrs[0].String(),
+	}
+	anyClaim, err := codectypes.NewAnyWithValue(claim)
+	require.NoError(t, err)
+	_, err = k.Attest(ctx, claim, anyClaim)
+	require.NoError(t, err)
+
+	// the registries of the module
+	k.SetRelayer(ctx, AccAddrs[1], myReceiver)
+	k.SetOmnibusAccount(ctx, AccAddrs[2], true)
+	k.SetTokenQuirk(ctx, types.TokenQuirk{TokenContract: myTokenContractAddr, FeeOnTransfer: true, DiscrepancyCount: 1, LastEventNonce: 1, Shortfall: sdk.NewInt(3)})
+	k.setCosmosOriginatedDenomToERC20(ctx, "ustake", "0xB5E9944950C97acab395a324716D186632789712")
+
+	// every exported field survives a restart from the export
+	genesis := ExportGenesis(ctx, k)
+	require.Len(t, genesis.Attestations, 1)
+	require.Len(t, genesis.BatchConfirms, 1)
+	require.Len(t, genesis.UnbatchedTransfers, 2)
+	restarted := CreateTestEnv(t)
+	InitGenesis(restarted.Context, restarted.PeggyKeeper, genesis)
+	require.Equal(t, genesis, ExportGenesis(restarted.Context, restarted.PeggyKeeper))
+}
@@ -5,6 +5,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
 )
 
@@ -12,9 +13,7 @@ var _ types.QueryServer = Keeper{}
 
 // Params queries the params of the peggy module
 func (k Keeper) Params(c context.Context, req *types.QueryParamsRequest) (*types.QueryParamsResponse, error) {
-	var params types.Params
-	k.paramSpace.GetParamSet(sdk.UnwrapSDKContext(c), &params)
-	return &types.QueryParamsResponse{Params: params}, nil
+	return &types.QueryParamsResponse{Params: k.GetParams(sdk.UnwrapSDKContext(c))}, nil
 
 }
 
@@ -23,6 +22,35 @@ func (k Keeper) CurrentValset(c context.Context, req *types.QueryCurrentValsetRe
 	return &types.QueryCurrentValsetResponse{Valset: k.GetCurrentValset(sdk.UnwrapSDKContext(c))}, nil
 }
 
+// ValsetDiff compares the current valset with the latest requested valset
+func (k Keeper) ValsetDiff(c context.Context, req *types.QueryValsetDiffRequest) (*types.QueryValsetDiffResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+	latest := k.GetLatestValset(ctx)
+	if latest == nil {
+		return nil, sdkerrors.Wrap(types.ErrEmpty, "no valset requested yet")
+	}
+	current := types.BridgeValidators(k.GetCurrentValset(ctx).Members)
+	res := &types.QueryValsetDiffResponse{
+		LatestValsetNonce:    latest.Nonce,
+		PowerDiffBasisPoints: current.PowerDiffBasisPoints(latest.Members),
+		ThresholdBasisPoints: types.ValsetPowerDiffThresholdBasisPoints,
+	}
+
+	latestMembers := make(map[string]bool, len(latest.Members))
+	for _, m := range latest.Members {
+		latestMembers[m.EthereumAddress] = true
+	}
+	for _, m := range current {
+		if latestMembers[m.EthereumAddress] {
+			delete(latestMembers, m.EthereumAddress)
+		} else {
+			res.MembersAdded++
+		}
+	}
+	res.MembersRemoved = uint64(len(latestMembers))
+	return res, nil
+}
+
 // ValsetRequest queries the ValsetRequest of the peggy module
 func (k Keeper) ValsetRequest(c context.Context, req *types.QueryValsetRequestRequest) (*types.QueryValsetRequestResponse, error) {
 	return &types.QueryValsetRequestResponse{Valset: k.GetValset(sdk.UnwrapSDKContext(c), req.Nonce)}, nil
@@ -49,15 +77,12 @@ func (k Keeper) ValsetConfirmsByNonce(c context.Context, req *types.QueryValsetC
 
 // LastValsetRequests queries the LastValsetRequests of the peggy module
 func (k Keeper) LastValsetRequests(c context.Context, req *types.QueryLastValsetRequestsRequest) (*types.QueryLastValsetRequestsResponse, error) {
-	valReq := k.GetValsets(sdk.UnwrapSDKContext(c))
-	valReqLen := len(valReq)
-	retLen := 0
-	if valReqLen < maxValsetRequestsReturned {
-		retLen = valReqLen
-	} else {
-		retLen = maxValsetRequestsReturned
-	}
-	return &types.QueryLastValsetRequestsResponse{Valsets: valReq[0:retLen]}, nil
+	var valsets []*types.Valset
+	k.IterateValsets(sdk.UnwrapSDKContext(c), func(_ []byte, val *types.Valset) bool {
+		valsets = append(valsets, val)
+		return len(valsets) == maxValsetRequestsReturned
+	})
+	return &types.QueryLastValsetRequestsResponse{Valsets: valsets}, nil
 }
 
 // LastPendingValsetRequestByAddr queries the LastPendingValsetRequestByAddr of the peggy module
@@ -66,30 +91,33 @@ func (k Keeper) LastPendingValsetRequestByAddr(c context.Context, req *types.Que
 	if err != nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "address invalid")
 	}
-
-	var pendingValsetReq []*types.Valset
-	k.IterateValsets(sdk.UnwrapSDKContext(c), func(_ []byte, val *types.Valset) bool {
-		// foundConfirm is true if the operatorAddr has signed the valset we are currently looking at
-		foundConfirm := k.GetValsetConfirm(sdk.UnwrapSDKContext(c), val.Nonce, addr) != nil
-		// if this valset has NOT been signed by operatorAddr, store it in pendingValsetReq
-		// and exit the loop
-		if !foundConfirm {
-			pendingValsetReq = append(pendingValsetReq, val)
-		}
-		// if we have more than 100 unconfirmed requests in
-		// our array we should exit, TODO pagination
-		if len(pendingValsetReq) > 100 {
-			return true
-		}
-		// return false to continue the loop
-		return false
-	})
-	return &types.QueryLastPendingValsetRequestByAddrResponse{Valsets: pendingValsetReq}, nil
+	limit := req.Limit
+	if limit == 0 || limit > MaxResults {
+		limit = MaxResults
+	}
+	res := &types.QueryLastPendingValsetRequestByAddrResponse{}
+	if limitQuery(sdk.UnwrapSDKContext(c), func(ctx sdk.Context) {
+		k.IterateUnsignedValsets(ctx, addr, func(valset *types.Valset) bool {
+			if uint64(len(res.Valsets)) == limit {
+				res.Truncated = true
+				return true
+			}
+			res.Valsets = append(res.Valsets, valset)
+			return false
+		})
+	}) {
+		res.Truncated = true
+	}
+	return res, nil
 }
 
 // BatchFees queries the batch fees from unbatched pool
 func (k Keeper) BatchFees(c context.Context, req *types.QueryBatchFeeRequest) (*types.QueryBatchFeeResponse, error) {
-	return &types.QueryBatchFeeResponse{BatchFees: k.CreateBatchFees(sdk.UnwrapSDKContext(c))}, nil
+	var fees []*types.BatchFees
+	if limitQuery(sdk.UnwrapSDKContext(c), func(ctx sdk.Context) { fees = k.CreateBatchFees(ctx) }) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrOutOfGas, "outgoing pool too large to sum the fees")
+	}
+	return &types.QueryBatchFeeResponse{BatchFees: fees}, nil
 }
 
 // LastPendingBatchRequestByAddr queries the LastPendingBatchRequestByAddr of the peggy module
@@ -100,14 +128,14 @@ func (k Keeper) LastPendingBatchRequestByAddr(c context.Context, req *types.Quer
 	}
 
 	var pendingBatchReq *types.OutgoingTxBatch
-	k.IterateOutgoingTXBatches(sdk.UnwrapSDKContext(c), func(_ []byte, batch *types.OutgoingTxBatch) bool {
-		foundConfirm := k.GetBatchConfirm(sdk.UnwrapSDKContext(c), batch.BatchNonce, batch.TokenContract, addr) != nil
-		if !foundConfirm {
+	if limitQuery(sdk.UnwrapSDKContext(c), func(ctx sdk.Context) {
+		k.IterateUnsignedBatches(ctx, addr, func(batch *types.OutgoingTxBatch) bool {
 			pendingBatchReq = batch
 			return true
-		}
-		return false
-	})
+		})
+	}) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrOutOfGas, "too many batches to find the unsigned one")
+	}
 
 	return &types.QueryLastPendingBatchRequestByAddrResponse{Batch: pendingBatchReq}, nil
 }
@@ -119,35 +147,93 @@ func (k Keeper) LastPendingLogicCallByAddr(c context.Context, req *types.QueryLa
 	}
 
 	var pendingLogicReq *types.OutgoingLogicCall
-	k.IterateOutgoingLogicCalls(sdk.UnwrapSDKContext(c), func(_ []byte, logic *types.OutgoingLogicCall) bool {
-		foundConfirm := k.GetLogicCallConfirm(sdk.UnwrapSDKContext(c), logic.InvalidationId, logic.InvalidationNonce, addr) != nil
-		if !foundConfirm {
+	if limitQuery(sdk.UnwrapSDKContext(c), func(ctx sdk.Context) {
+		k.IterateUnsignedLogicCalls(ctx, addr, func(logic *types.OutgoingLogicCall) bool {
 			pendingLogicReq = logic
 			return true
-		}
-		return false
-	})
+		})
+	}) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrOutOfGas, "too many logic calls to find the unsigned one")
+	}
 	return &types.QueryLastPendingLogicCallByAddrResponse{Call: pendingLogicReq}, nil
 }
 
+// PendingWork queries the valsets, batches and logic calls the orchestrator has not confirmed yet
+func (k Keeper) PendingWork(c context.Context, req *types.QueryPendingWorkRequest) (*types.QueryPendingWorkResponse, error) {
+	addr, err := sdk.AccAddressFromBech32(req.Orchestrator)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "address invalid")
+	}
+
+	res := &types.QueryPendingWorkResponse{}
+	params := k.GetParams(sdk.UnwrapSDKContext(c))
+	if limitQuery(sdk.UnwrapSDKContext(c), func(ctx sdk.Context) {
+		k.IterateUnsignedValsets(ctx, addr, func(valset *types.Valset) bool {
+			if len(res.Valsets) == MaxResults {
+				res.Truncated = true
+				return true
+			}
+			res.Valsets = append(res.Valsets, valset)
+			res.ValsetGraceBlocks = append(res.ValsetGraceBlocks, confirmGraceBlocks(ctx, valset.Height, params.ValsetSlashingWindow()))
+			return false
+		})
+		k.IterateUnsignedBatches(ctx, addr, func(batch *types.OutgoingTxBatch) bool {
+			if len(res.Batches) == MaxResults {
+				res.Truncated = true
+				return true
+			}
+			res.Batches = append(res.Batches, batch)
+			res.BatchGraceBlocks = append(res.BatchGraceBlocks, confirmGraceBlocks(ctx, batch.Block, params.BatchSlashingWindow()))
+			return false
+		})
+		k.IterateUnsignedLogicCalls(ctx, addr, func(call *types.OutgoingLogicCall) bool {
+			if len(res.Calls) == MaxResults {
+				res.Truncated = true
+				return true
+			}
+			res.Calls = append(res.Calls, call)
+			return false
+		})
+	}) {
+		res.Truncated = true
+	}
+	return res, nil
+}
+
 // OutgoingTxBatches queries the OutgoingTxBatches of the peggy module
 func (k Keeper) OutgoingTxBatches(c context.Context, req *types.QueryOutgoingTxBatchesRequest) (*types.QueryOutgoingTxBatchesResponse, error) {
-	var batches []*types.OutgoingTxBatch
-	k.IterateOutgoingTXBatches(sdk.UnwrapSDKContext(c), func(_ []byte, batch *types.OutgoingTxBatch) bool {
-		batches = append(batches, batch)
-		return len(batches) == MaxResults
-	})
-	return &types.QueryOutgoingTxBatchesResponse{Batches: batches}, nil
+	res := &types.QueryOutgoingTxBatchesResponse{}
+	if limitQuery(sdk.UnwrapSDKContext(c), func(ctx sdk.Context) {
+		k.IterateOutgoingTXBatches(ctx, func(_ []byte, batch *types.OutgoingTxBatch) bool {
+			if len(res.Batches) == MaxResults {
+				res.Truncated = true
+				return true
+			}
+			res.Batches = append(res.Batches, batch)
+			return false
+		})
+	}) {
+		res.Truncated = true
+	}
+	return res, nil
 }
 
 // OutgoingLogicCalls queries the OutgoingLogicCalls of the peggy module
 func (k Keeper) OutgoingLogicCalls(c context.Context, req *types.QueryOutgoingLogicCallsRequest) (*types.QueryOutgoingLogicCallsResponse, error) {
-	var calls []*types.OutgoingLogicCall
-	k.IterateOutgoingLogicCalls(sdk.UnwrapSDKContext(c), func(_ []byte, call *types.OutgoingLogicCall) bool {
-		calls = append(calls, call)
-		return len(calls) == MaxResults
-	})
-	return &types.QueryOutgoingLogicCallsResponse{Calls: calls}, nil
+	res := &types.QueryOutgoingLogicCallsResponse{}
+	if limitQuery(sdk.UnwrapSDKContext(c), func(ctx sdk.Context) {
+		k.IterateOutgoingLogicCalls(ctx, func(_ []byte, call *types.OutgoingLogicCall) bool {
+			if len(res.Calls) == MaxResults {
+				res.Truncated = true
+				return true
+			}
+			res.Calls = append(res.Calls, call)
+			return false
+		})
+	}) {
+		res.Truncated = true
+	}
+	return res, nil
 }
 
 // BatchRequestByNonce queries the BatchRequestByNonce of the peggy module
@@ -155,11 +241,16 @@ func (k Keeper) BatchRequestByNonce(c context.Context, req *types.QueryBatchRequ
 	if err := types.ValidateEthAddress(req.ContractAddress); err != nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, err.Error())
 	}
-	foundBatch := k.GetOutgoingTXBatch(sdk.UnwrapSDKContext(c), req.ContractAddress, req.Nonce)
-	if foundBatch == nil {
+	batch, status, signed, err := k.GetBatchStatus(sdk.UnwrapSDKContext(c), req.ContractAddress, req.Nonce)
+	if err != nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "Can not find tx batch")
 	}
-	return &types.QueryBatchRequestByNonceResponse{Batch: foundBatch}, nil
+	return &types.QueryBatchRequestByNonceResponse{
+		Batch:          batch,
+		Status:         status,
+		SignedPower:    signed,
+		PowerThreshold: types.BridgePowerThreshold,
+	}, nil
 }
 
 // BatchConfirms returns the batch confirmations by nonce and token contract
@@ -199,14 +290,68 @@ func (k Keeper) LastEventNonceByAddr(c context.Context, req *types.QueryLastEven
 	return &ret, nil
 }
 
+// EventNonceGap queries how far the validator of an orchestrator is behind the observed events and the
+// Ethereum heights to re-scan for them
+func (k Keeper) EventNonceGap(c context.Context, req *types.QueryEventNonceGapRequest) (*types.QueryEventNonceGapResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+	addr, err := sdk.AccAddressFromBech32(req.Orchestrator)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, req.Orchestrator)
+	}
+	validator := k.GetOrchestratorValidator(ctx, addr)
+	if validator.Empty() {
+		return nil, sdkerrors.Wrap(types.ErrUnknown, "address")
+	}
+	gap := k.GetEventNonceGap(ctx, validator)
+	return &gap, nil
+}
+
+// BatchForTx queries the batch an outgoing transfer is in and the status of the batch
+func (k Keeper) BatchForTx(c context.Context, req *types.QueryBatchForTxRequest) (*types.QueryBatchForTxResponse, error) {
+	res, err := k.GetBatchForTx(sdk.UnwrapSDKContext(c), req.TxId)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(err, "tx %d", req.TxId)
+	}
+	return res, nil
+}
+
+// ScheduledTransfers queries the transfers waiting for their earliest execution, optionally of a single sender
+func (k Keeper) ScheduledTransfers(c context.Context, req *types.QueryScheduledTransfersRequest) (*types.QueryScheduledTransfersResponse, error) {
+	if req.Sender != "" {
+		if _, err := sdk.AccAddressFromBech32(req.Sender); err != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "address invalid")
+		}
+	}
+	res := &types.QueryScheduledTransfersResponse{}
+	if limitQuery(sdk.UnwrapSDKContext(c), func(ctx sdk.Context) {
+		k.IterateScheduledTransfers(ctx, func(scheduled types.ScheduledTransfer) bool {
+			if req.Sender != "" && scheduled.Tx.Sender != req.Sender {
+				return false
+			}
+			if len(res.Transfers) == MaxResults {
+				res.Truncated = true
+				return true
+			}
+			res.Transfers = append(res.Transfers, scheduled)
+			return false
+		})
+	}) {
+		res.Truncated = true
+	}
+	return res, nil
+}
+
 // DenomToERC20 queries the Cosmos Denom that maps to an Ethereum ERC20
 func (k Keeper) DenomToERC20(c context.Context, req *types.QueryDenomToERC20Request) (*types.QueryDenomToERC20Response, error) {
 	ctx := sdk.UnwrapSDKContext(c)
 	cosmos_originated, erc20, err := k.DenomToERC20Lookup(ctx, req.Denom)
+	if err != nil {
+		return nil, err
+	}
 	var ret types.QueryDenomToERC20Response
 	ret.Erc20 = erc20
 	ret.CosmosOriginated = cosmos_originated
-	return &ret, err
+	return &ret, nil
 }
 
 // ERC20ToDenom queries the ERC20 contract that maps to an Ethereum ERC20 if any
@@ -226,13 +371,20 @@ func (k Keeper) GetDelegateKeyByValidator(c context.Context, req *types.QueryDel
 	if err != nil {
 		return nil, err
 	}
+	var res *types.QueryDelegateKeysByValidatorAddressResponse
 	for _, key := range keys {
-		if req.ValidatorAddress == key.Validator {
-			return &types.QueryDelegateKeysByValidatorAddressResponse{EthAddress: key.EthAddress, OrchestratorAddress: key.Orchestrator}, nil
+		if req.ValidatorAddress != key.Validator {
+			continue
 		}
-
+		if res == nil {
+			res = &types.QueryDelegateKeysByValidatorAddressResponse{EthAddress: key.EthAddress, OrchestratorAddress: key.Orchestrator}
+		}
+		res.OrchestratorAddresses = append(res.OrchestratorAddresses, key.Orchestrator)
 	}
-	return nil, sdkerrors.Wrap(types.ErrInvalid, "No validator")
+	if res == nil {
+		return nil, sdkerrors.Wrap(types.ErrInvalid, "No validator")
+	}
+	return res, nil
 }
 
 func (k Keeper) GetDelegateKeyByOrchestrator(c context.Context, req *types.QueryDelegateKeysByOrchestratorAddress) (*types.QueryDelegateKeysByOrchestratorAddressResponse, error) {
@@ -266,23 +418,425 @@ func (k Keeper) GetDelegateKeyByEth(c context.Context, req *types.QueryDelegateK
 	return nil, sdkerrors.Wrap(types.ErrInvalid, "No validator")
 }
 
+// GetPendingSendToEth queries a page of the pending transfers to ethereum matching the optional filters
 func (k Keeper) GetPendingSendToEth(c context.Context, req *types.QueryPendingSendToEth) (*types.QueryPendingSendToEthResponse, error) {
+	var (
+		inBatches, unbatched []*types.OutgoingTransferTx
+		pageRes              *query.PageResponse
+		err                  error
+	)
+	if limitQuery(sdk.UnwrapSDKContext(c), func(ctx sdk.Context) {
+		inBatches, unbatched, pageRes, err = k.PaginatePendingSendToEth(ctx, req)
+	}) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrOutOfGas, "too many pending transfers, narrow the filters")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryPendingSendToEthResponse{
+		TransfersInBatches: inBatches,
+		UnbatchedTransfers: unbatched,
+		Pagination:         pageRes,
+	}, nil
+}
+
+// AllPendingSendToEth queries a page of the pending transfers to ethereum of all senders
+func (k Keeper) AllPendingSendToEth(c context.Context, req *types.QueryAllPendingSendToEthRequest) (*types.QueryAllPendingSendToEthResponse, error) {
+	var (
+		inBatches, unbatched []*types.OutgoingTransferTx
+		pageRes              *query.PageResponse
+		err                  error
+	)
+	if limitQuery(sdk.UnwrapSDKContext(c), func(ctx sdk.Context) {
+		inBatches, unbatched, pageRes, err = k.PaginatePendingSendToEth(ctx, &types.QueryPendingSendToEth{Pagination: req.Pagination})
+	}) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrOutOfGas, "too many pending transfers, query them by sender or token")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryAllPendingSendToEthResponse{
+		TransfersInBatches: inBatches,
+		UnbatchedTransfers: unbatched,
+		Pagination:         pageRes,
+	}, nil
+}
+
+// ReclaimableDeposits queries the deposits that were sent to the community pool because
+// their cosmos receiver could not be parsed
+func (k Keeper) ReclaimableDeposits(c context.Context, req *types.QueryReclaimableDepositsRequest) (*types.QueryReclaimableDepositsResponse, error) {
+	res := &types.QueryReclaimableDepositsResponse{}
+	if limitQuery(sdk.UnwrapSDKContext(c), func(ctx sdk.Context) {
+		k.IterateReclaimableDeposits(ctx, func(deposit types.ReclaimableDeposit) bool {
+			if len(res.Deposits) == MaxResults {
+				res.Truncated = true
+				return true
+			}
+			res.Deposits = append(res.Deposits, deposit)
+			return false
+		})
+	}) {
+		res.Truncated = true
+	}
+	return res, nil
+}
+
+// OrchestratorLiveness queries how close every bonded validator is to being slashed for
+// missing valset or batch confirmations
+func (k Keeper) OrchestratorLiveness(c context.Context, req *types.QueryOrchestratorLivenessRequest) (*types.QueryOrchestratorLivenessResponse, error) {
+	return &types.QueryOrchestratorLivenessResponse{Validators: k.GetOrchestratorLiveness(sdk.UnwrapSDKContext(c), req.WarningWindow)}, nil
+}
+
+// UnbatchedTransactionsByContract queries the outgoing pool of a token contract sorted by fee
+func (k Keeper) UnbatchedTransactionsByContract(c context.Context, req *types.QueryUnbatchedTransactionsByContractRequest) (*types.QueryUnbatchedTransactionsByContractResponse, error) {
+	if err := types.ValidateEthAddress(req.TokenContract); err != nil {
+		return nil, sdkerrors.Wrap(types.ErrInvalid, err.Error())
+	}
+	var (
+		txs     []*types.OutgoingTransferTx
+		pageRes *query.PageResponse
+		err     error
+	)
+	if limitQuery(sdk.UnwrapSDKContext(c), func(ctx sdk.Context) {
+		txs, pageRes, err = k.PaginateOutgoingPoolByFee(ctx, req.TokenContract, req.Pagination)
+	}) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrOutOfGas, "outgoing pool too large to page through")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryUnbatchedTransactionsByContractResponse{Transactions: txs, Pagination: pageRes}, nil
+}
+
+// DepositsByEthSender queries the observed deposits sent from an ethereum address
+func (k Keeper) DepositsByEthSender(c context.Context, req *types.QueryDepositsByEthSenderRequest) (*types.QueryDepositsByEthSenderResponse, error) {
+	if err := types.ValidateEthAddress(req.EthSender); err != nil {
+		return nil, sdkerrors.Wrap(types.ErrInvalid, err.Error())
+	}
+	deposits, pageRes, err := k.PaginateDepositsByEthSender(sdk.UnwrapSDKContext(c), req.EthSender, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryDepositsByEthSenderResponse{Deposits: deposits, Pagination: pageRes}, nil
+}
+
+// AccountBridgeActivity queries the ledger of the deposits, withdrawals, refunds and fees of an account
+// between two heights
+func (k Keeper) AccountBridgeActivity(c context.Context, req *types.QueryAccountBridgeActivityRequest) (*types.QueryAccountBridgeActivityResponse, error) {
+	addr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, req.Address)
+	}
+	activities, pageRes, err := k.PaginateAccountActivity(sdk.UnwrapSDKContext(c), addr, req.FromHeight, req.ToHeight, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryAccountBridgeActivityResponse{Activities: activities, Pagination: pageRes}, nil
+}
+
+// BatchExecution queries the ethereum block height and tx hash at which a batch was executed
+func (k Keeper) BatchExecution(c context.Context, req *types.QueryBatchExecutionRequest) (*types.QueryBatchExecutionResponse, error) {
+	if err := types.ValidateEthAddress(req.TokenContract); err != nil {
+		return nil, sdkerrors.Wrap(types.ErrInvalid, err.Error())
+	}
+	execution := k.GetBatchExecution(sdk.UnwrapSDKContext(c), req.TokenContract, req.BatchNonce)
+	if execution == nil {
+		return nil, sdkerrors.Wrapf(types.ErrUnknown, "no execution observed for batch %d of %s", req.BatchNonce, req.TokenContract)
+	}
+	return &types.QueryBatchExecutionResponse{Execution: execution}, nil
+}
+
+// AttestationsByNonce queries the attestations, including claim, votes and observed status, of an event nonce
+func (k Keeper) AttestationsByNonce(c context.Context, req *types.QueryAttestationsByNonceRequest) (*types.QueryAttestationsByNonceResponse, error) {
+	attestations := k.GetAttestationsByNonce(sdk.UnwrapSDKContext(c), req.EventNonce)
+	if len(attestations) == 0 {
+		return nil, sdkerrors.Wrapf(types.ErrUnknown, "no attestation for event nonce %d", req.EventNonce)
+	}
+	return &types.QueryAttestationsByNonceResponse{Attestations: attestations}, nil
+}
+
+// AllUnbatchedTransactions queries the whole outgoing pool grouped by token contract and sorted by fee
+func (k Keeper) AllUnbatchedTransactions(c context.Context, req *types.QueryAllUnbatchedTransactionsRequest) (*types.QueryAllUnbatchedTransactionsResponse, error) {
+	var (
+		txs     []*types.OutgoingTransferTx
+		pageRes *query.PageResponse
+		err     error
+	)
+	if limitQuery(sdk.UnwrapSDKContext(c), func(ctx sdk.Context) {
+		txs, pageRes, err = k.PaginateOutgoingPoolByFee(ctx, "", req.Pagination)
+	}) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrOutOfGas, "outgoing pool too large to page through, query it by token")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryAllUnbatchedTransactionsResponse{Transactions: txs, Pagination: pageRes}, nil
+}
+
+// ValsetCheckpoint queries the checkpoint validators sign for the valset with the given nonce
+func (k Keeper) ValsetCheckpoint(c context.Context, req *types.QueryValsetCheckpointRequest) (*types.QueryCheckpointResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+	valset := k.GetValset(ctx, req.Nonce)
+	if valset == nil {
+		return nil, sdkerrors.Wrapf(types.ErrUnknown, "valset %d", req.Nonce)
+	}
+	gravityID := k.GetGravityID(ctx)
+	return &types.QueryCheckpointResponse{Checkpoint: valset.GetCheckpoint(gravityID), GravityId: gravityID}, nil
+}
+
+// BatchCheckpoint queries the checkpoint validators sign for the batch with the given token contract and nonce
+func (k Keeper) BatchCheckpoint(c context.Context, req *types.QueryBatchCheckpointRequest) (*types.QueryCheckpointResponse, error) {
+	if err := types.ValidateEthAddress(req.TokenContract); err != nil {
+		return nil, sdkerrors.Wrap(types.ErrInvalid, err.Error())
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	batch := k.GetOutgoingTXBatch(ctx, req.TokenContract, req.Nonce)
+	if batch == nil {
+		return nil, sdkerrors.Wrapf(types.ErrUnknown, "batch %d", req.Nonce)
+	}
+	gravityID := k.GetGravityID(ctx)
+	checkpoint, err := batch.GetCheckpoint(gravityID)
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryCheckpointResponse{Checkpoint: checkpoint, GravityId: gravityID}, nil
+}
+
+// LogicCallCheckpoint queries the checkpoint validators sign for the logic call with the given invalidation id and nonce
+func (k Keeper) LogicCallCheckpoint(c context.Context, req *types.QueryLogicCallCheckpointRequest) (*types.QueryCheckpointResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+	// GetOutgoingLogicCall returns an empty call when none is stored
+	call := k.GetOutgoingLogicCall(ctx, req.InvalidationId, req.InvalidationNonce)
+	if len(call.InvalidationId) == 0 {
+		return nil, sdkerrors.Wrapf(types.ErrUnknown, "logic call %x %d", req.InvalidationId, req.InvalidationNonce)
+	}
+	gravityID := k.GetGravityID(ctx)
+	checkpoint, err := call.GetCheckpoint(gravityID)
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryCheckpointResponse{Checkpoint: checkpoint, GravityId: gravityID}, nil
+}
+
+// BridgeSnapshot queries the data needed to deploy and fund a fresh bridge contract
+func (k Keeper) BridgeSnapshot(c context.Context, req *types.QueryBridgeSnapshotRequest) (*types.QueryBridgeSnapshotResponse, error) {
+	return &types.QueryBridgeSnapshotResponse{Snapshot: k.ExportBridgeSnapshot(sdk.UnwrapSDKContext(c))}, nil
+}
+
+// BridgeStats queries the volume bridged of every token, or of a single token contract
+func (k Keeper) BridgeStats(c context.Context, req *types.QueryBridgeStatsRequest) (*types.QueryBridgeStatsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+	if req.TokenContract == "" {
+		return &types.QueryBridgeStatsResponse{Stats: k.GetAllBridgeStats(ctx)}, nil
+	}
+	if err := types.ValidateEthAddress(req.TokenContract); err != nil {
+		return nil, sdkerrors.Wrap(types.ErrInvalid, err.Error())
+	}
+	return &types.QueryBridgeStatsResponse{Stats: []types.BridgeStats{k.GetBridgeStats(ctx, req.TokenContract)}}, nil
+}
+
+// TokenQuirks queries the tokens flagged for moving a different amount than their deposits reported, or
+// the quirks of a single token contract
+func (k Keeper) TokenQuirks(c context.Context, req *types.QueryTokenQuirksRequest) (*types.QueryTokenQuirksResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+	if req.TokenContract == "" {
+		return &types.QueryTokenQuirksResponse{Quirks: k.GetAllTokenQuirks(ctx)}, nil
+	}
+	if err := types.ValidateEthAddress(req.TokenContract); err != nil {
+		return nil, sdkerrors.Wrap(types.ErrInvalid, err.Error())
+	}
+	quirk := k.GetTokenQuirk(ctx, req.TokenContract)
+	if quirk == nil {
+		return &types.QueryTokenQuirksResponse{}, nil
+	}
+	return &types.QueryTokenQuirksResponse{Quirks: []types.TokenQuirk{*quirk}}, nil
+}
+
+// ModuleResidue queries the coins of the escrow and of the fee collector that no tracked liability accounts for
+func (k Keeper) ModuleResidue(c context.Context, req *types.QueryModuleResidueRequest) (*types.QueryModuleResidueResponse, error) {
+	escrow, feeCollector := k.GetModuleResidue(sdk.UnwrapSDKContext(c))
+	return &types.QueryModuleResidueResponse{Escrow: escrow, FeeCollector: feeCollector}, nil
+}
+
+// PausedTokens queries the tokens whose bridging is paused
+func (k Keeper) PausedTokens(c context.Context, req *types.QueryPausedTokensRequest) (*types.QueryPausedTokensResponse, error) {
+	return &types.QueryPausedTokensResponse{Tokens: k.GetPausedTokens(sdk.UnwrapSDKContext(c))}, nil
+}
+
+// AttestationVotes queries which validators voted for which claim hash of an event nonce
+func (k Keeper) AttestationVotes(c context.Context, req *types.QueryAttestationVotesRequest) (*types.QueryAttestationVotesResponse, error) {
+	claims := k.GetAttestationVotes(sdk.UnwrapSDKContext(c), req.EventNonce)
+	if len(claims) == 0 {
+		return nil, sdkerrors.Wrapf(types.ErrUnknown, "no attestation for event nonce %d", req.EventNonce)
+	}
+	return &types.QueryAttestationVotesResponse{Claims: claims}, nil
+}
+
+// ClaimDivergence queries the claims of an event that is not observed yet with the power backing each
+func (k Keeper) ClaimDivergence(c context.Context, req *types.QueryClaimDivergenceRequest) (*types.QueryClaimDivergenceResponse, error) {
+	return k.GetClaimDivergence(sdk.UnwrapSDKContext(c), req.EventNonce)
+}
+
+// ValidatorAttestationRecord queries the votes of a validator, given by its operator or orchestrator
+// address, for the stored attestations from an event nonce on
+func (k Keeper) ValidatorAttestationRecord(c context.Context, req *types.QueryValidatorAttestationRecordRequest) (*types.QueryValidatorAttestationRecordResponse, error) {
 	ctx := sdk.UnwrapSDKContext(c)
-	batches := k.GetOutgoingTxBatches(ctx)
-	unbatched_tx := k.GetPoolTransactions(ctx)
-	sender_address := req.SenderAddress
-	var res *types.QueryPendingSendToEthResponse
-	for _, batch := range batches {
-		for _, tx := range batch.Transactions {
-			if tx.Sender == sender_address {
-				res.TransfersInBatches = append(res.TransfersInBatches, tx)
+	validator, err := sdk.ValAddressFromBech32(req.Validator)
+	if err != nil {
+		orchestrator, err := sdk.AccAddressFromBech32(req.Validator)
+		if err != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, req.Validator)
+		}
+		validator = k.GetOrchestratorValidator(ctx, orchestrator)
+		if validator.Empty() {
+			return nil, sdkerrors.Wrapf(types.ErrUnknown, "no validator for orchestrator %s", req.Validator)
+		}
+	}
+	res := &types.QueryValidatorAttestationRecordResponse{
+		LastEventNonce:     k.GetLastEventNonceByValidator(ctx, validator),
+		ClaimResubmissions: k.GetClaimResubmissions(ctx, validator),
+	}
+	if limitQuery(ctx, func(ctx sdk.Context) {
+		k.IterateValidatorAttestationRecord(ctx, validator, req.FromNonce, func(vote *types.ValidatorAttestationVote, missedNonce uint64) {
+			if vote != nil {
+				res.Votes = append(res.Votes, *vote)
+			} else {
+				res.MissedNonces = append(res.MissedNonces, missedNonce)
 			}
+		})
+	}) {
+		res.Truncated = true
+	}
+	return res, nil
+}
+
+// Relayers queries the relayers registered with their Ethereum address
+func (k Keeper) Relayers(c context.Context, req *types.QueryRelayersRequest) (*types.QueryRelayersResponse, error) {
+	return &types.QueryRelayersResponse{Relayers: k.GetAllRelayers(sdk.UnwrapSDKContext(c))}, nil
+}
+
+// ConfirmsByOrchestrator queries the valset, batch and logic call confirms stored for an orchestrator, so
+// it can check its confirms landed and resubmit the missing ones
+func (k Keeper) ConfirmsByOrchestrator(c context.Context, req *types.QueryConfirmsByOrchestratorRequest) (*types.QueryConfirmsByOrchestratorResponse, error) {
+	addr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "address invalid")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	return &types.QueryConfirmsByOrchestratorResponse{
+		ValsetConfirms:    k.GetValsetConfirmsByOrchestrator(ctx, addr, req.FromNonce),
+		BatchConfirms:     k.GetBatchConfirmsByOrchestrator(ctx, addr, req.FromNonce),
+		LogicCallConfirms: k.GetLogicCallConfirmsByOrchestrator(ctx, addr, req.FromNonce),
+	}, nil
+}
+
+// EthereumHeight queries the last observed and latest claimed Ethereum heights and the projection of the
+// current Ethereum height that timeouts are set on
+func (k Keeper) EthereumHeight(c context.Context, req *types.QueryEthereumHeightRequest) (*types.QueryEthereumHeightResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+	return &types.QueryEthereumHeightResponse{
+		LastObserved:            k.GetLastObservedEthereumBlockHeight(ctx),
+		Latest:                  k.GetLatestEthereumBlockHeight(ctx),
+		ProjectedEthereumHeight: k.GetProjectedEthereumHeight(ctx),
+	}, nil
+}
+
+// ModuleStateSize queries the number of entries and bytes stored under the prefixes of the peggy store
+func (k Keeper) ModuleStateSize(c context.Context, req *types.QueryModuleStateSizeRequest) (*types.QueryModuleStateSizeResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+	var prefixes []types.StatePrefixSize
+	if req.Prefix == "" {
+		prefixes = k.GetModuleStateSize(ctx)
+	} else {
+		for _, p := range types.KeyPrefixes {
+			if p.Name == req.Prefix {
+				prefixes = append(prefixes, k.GetStatePrefixSize(ctx, p))
+			}
+		}
+		if len(prefixes) == 0 {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "unknown prefix %s", req.Prefix)
 		}
 	}
-	for _, tx := range unbatched_tx {
-		if tx.Sender == sender_address {
-			res.UnbatchedTransfers = append(res.UnbatchedTransfers, tx)
+
+	res := &types.QueryModuleStateSizeResponse{Prefixes: prefixes}
+	for _, p := range prefixes {
+		res.TotalEntries += p.Entries
+		res.TotalBytes += p.Bytes
+	}
+	return res, nil
+}
+
+// Grants queries the grants given by an account, only those to the grantee if one is given
+func (k Keeper) Grants(c context.Context, req *types.QueryGrantsRequest) (*types.QueryGrantsResponse, error) {
+	granter, err := sdk.AccAddressFromBech32(req.Granter)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "granter invalid")
+	}
+	var grantee sdk.AccAddress
+	if req.Grantee != "" {
+		if grantee, err = sdk.AccAddressFromBech32(req.Grantee); err != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "grantee invalid")
 		}
 	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	var grants []types.GrantAuthorization
+	k.IterateGrants(ctx, granter, func(grant types.GrantAuthorization) bool {
+		if grantee != nil && grant.Grantee != grantee.String() {
+			return false
+		}
+		if !grant.Grant.IsExpired(ctx.BlockTime().Unix()) {
+			grants = append(grants, grant)
+		}
+		return false
+	})
+	return &types.QueryGrantsResponse{Grants: grants}, nil
+}
+
+// OmnibusAccount queries whether an account is a registered omnibus account and the sub-account of a tag
+func (k Keeper) OmnibusAccount(c context.Context, req *types.QueryOmnibusAccountRequest) (*types.QueryOmnibusAccountResponse, error) {
+	account, err := sdk.AccAddressFromBech32(req.Account)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "account invalid")
+	}
+	res := &types.QueryOmnibusAccountResponse{Registered: k.IsOmnibusAccount(sdk.UnwrapSDKContext(c), account)}
+	if req.Tag != "" {
+		if err := types.ValidateDestinationTag(req.Tag); err != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
+		}
+		res.SubAccount = types.OmnibusSubAccount(account, req.Tag).String()
+	}
 	return res, nil
 }
+
+// SimulateSendToEth runs a MsgSendToEth without keeping its effects and returns where the transfer would
+// stand in the outgoing pool
+func (k Keeper) SimulateSendToEth(c context.Context, req *types.QuerySimulateSendToEthRequest) (*types.QuerySimulateSendToEthResponse, error) {
+	msg := types.MsgSendToEth{Sender: req.Sender, EthDest: req.EthDest, Amount: req.Amount, BridgeFee: req.BridgeFee}
+	var (
+		res *types.QuerySimulateSendToEthResponse
+		err error
+	)
+	if limitQuery(sdk.UnwrapSDKContext(c), func(ctx sdk.Context) { res, err = k.simulateSendToEth(ctx, msg) }) {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrOutOfGas, "outgoing pool too large to find the position of the transfer")
+	}
+	return res, err
+}
+
+// PredictERC20Address queries the address the bridge contract deploys the ERC20 of a cosmos originated denom at
+func (k Keeper) PredictERC20Address(c context.Context, req *types.QueryPredictERC20AddressRequest) (*types.QueryPredictERC20AddressResponse, error) {
+	return k.PredictCosmosOriginatedERC20(sdk.UnwrapSDKContext(c), req.Denom)
+}
+
+// SubmittableValset queries the arguments of updateValset for the valset with the given nonce
+func (k Keeper) SubmittableValset(c context.Context, req *types.QuerySubmittableValsetRequest) (*types.QuerySubmittableValsetResponse, error) {
+	return k.GetSubmittableValset(sdk.UnwrapSDKContext(c), req.Nonce)
+}
+
+// SubmittableBatch queries the arguments of submitBatch for the batch with the given token contract and nonce
+func (k Keeper) SubmittableBatch(c context.Context, req *types.QuerySubmittableBatchRequest) (*types.QuerySubmittableBatchResponse, error) {
+	if err := types.ValidateEthAddress(req.TokenContract); err != nil {
+		return nil, sdkerrors.Wrap(types.ErrInvalid, err.Error())
+	}
+	return k.GetSubmittableBatch(sdk.UnwrapSDKContext(c), req.TokenContract, req.Nonce)
+}
@@ -3,6 +3,7 @@ package keeper
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
 )
 
 // Wrapper struct
@@ -33,8 +34,62 @@ func (h Hooks) AfterValidatorBonded(_ sdk.Context, _ sdk.ConsAddress, _ sdk.ValA
 
 func (h Hooks) BeforeDelegationRemoved(_ sdk.Context, _ sdk.AccAddress, _ sdk.ValAddress)        {}
 func (h Hooks) AfterValidatorRemoved(ctx sdk.Context, _ sdk.ConsAddress, valAddr sdk.ValAddress) {}
-func (h Hooks) BeforeValidatorSlashed(ctx sdk.Context, valAddr sdk.ValAddress, fraction sdk.Dec) {}
+
+// BeforeValidatorSlashed persists the block height, the evidence module tombstones a double signing
+// validator right after slashing it. The endblocker then checks whether a member of the latest
+// valset was tombstoned and creates a valset request without it.
+func (h Hooks) BeforeValidatorSlashed(ctx sdk.Context, valAddr sdk.ValAddress, fraction sdk.Dec) {
+	h.k.SetLastSlashBlockHeight(ctx, uint64(ctx.BlockHeight()))
+}
+
 func (h Hooks) BeforeDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
 }
 func (h Hooks) AfterDelegationModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
 }
+
+// SetHooks sets the peggy hooks, it has to be called before the keeper is handed to
+// any other module since they hold their own copy of it
+func (k *Keeper) SetHooks(ph types.PeggyHooks) *Keeper {
+	if k.hooks != nil {
+		panic("cannot set peggy hooks twice")
+	}
+	k.hooks = ph
+	// the attestation handler holds a copy of the keeper as well, refresh it so it sees the hooks
+	k.AttestationHandler = AttestationHandler{keeper: *k}
+	return k
+}
+
+// AfterDepositObserved calls the registered peggy hooks, if any
+func (k Keeper) AfterDepositObserved(ctx sdk.Context, eventNonce uint64, ethereumSender string, cosmosReceiver sdk.AccAddress, amount sdk.Coin) {
+	if k.hooks != nil {
+		k.hooks.AfterDepositObserved(ctx, eventNonce, ethereumSender, cosmosReceiver, amount)
+	}
+}
+
+// AfterWithdrawExecuted calls the registered peggy hooks, if any
+func (k Keeper) AfterWithdrawExecuted(ctx sdk.Context, tokenContract string, batchNonce uint64) {
+	if k.hooks != nil {
+		k.hooks.AfterWithdrawExecuted(ctx, tokenContract, batchNonce)
+	}
+}
+
+// AfterValsetUpdated calls the registered peggy hooks, if any
+func (k Keeper) AfterValsetUpdated(ctx sdk.Context, valset *types.Valset) {
+	if k.hooks != nil {
+		k.hooks.AfterValsetUpdated(ctx, valset)
+	}
+}
+
+// AfterBatchCreated calls the registered peggy hooks, if any
+func (k Keeper) AfterBatchCreated(ctx sdk.Context, batch *types.OutgoingTxBatch) {
+	if k.hooks != nil {
+		k.hooks.AfterBatchCreated(ctx, batch)
+	}
+}
+
+// AfterGenericEventObserved calls the registered peggy hooks, if any
+func (k Keeper) AfterGenericEventObserved(ctx sdk.Context, eventNonce uint64, contractAddress string, topic []byte, data []byte) {
+	if k.hooks != nil {
+		k.hooks.AfterGenericEventObserved(ctx, eventNonce, contractAddress, topic, data)
+	}
+}
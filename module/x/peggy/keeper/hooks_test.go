@@ -0,0 +1,79 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingHooks records the order in which the peggy hooks are called
+type recordingHooks struct {
+	calls *[]string
+}
+
+func (h recordingHooks) AfterDepositObserved(_ sdk.Context, _ uint64, _ string, _ sdk.AccAddress, amount sdk.Coin) {
+	*h.calls = append(*h.calls, "deposit "+amount.String())
+}
+
+func (h recordingHooks) AfterWithdrawExecuted(_ sdk.Context, _ string, _ uint64) {
+	*h.calls = append(*h.calls, "withdraw")
+}
+
+func (h recordingHooks) AfterValsetUpdated(_ sdk.Context, _ *types.Valset) {
+	*h.calls = append(*h.calls, "valset")
+}
+
+func (h recordingHooks) AfterBatchCreated(_ sdk.Context, _ *types.OutgoingTxBatch) {
+	*h.calls = append(*h.calls, "batch")
+}
+
+func (h recordingHooks) AfterGenericEventObserved(_ sdk.Context, _ uint64, _ string, _ []byte, _ []byte) {
+	*h.calls = append(*h.calls, "generic")
+}
+
+func TestPeggyHooks(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	var (
+		calls               []string
+		mySender, _         = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		myReceiver          = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		voucher             = types.NewERC20Token(100, myTokenContractAddr).PeggyCoin()
+	)
+
+	k := input.PeggyKeeper
+	k.SetHooks(types.NewMultiPeggyHooks(recordingHooks{&calls}, recordingHooks{&calls}))
+	require.Panics(t, func() { k.SetHooks(recordingHooks{&calls}) })
+
+	k.SetValsetRequest(ctx)
+
+	// deposits are handled by the attestation handler which needs to see the hooks as well
+	deposit := &types.MsgDepositClaim{
+		EventNonce:     1,
+		TokenContract:  myTokenContractAddr,
+		Amount:         voucher.Amount,
+		EthereumSender: myReceiver,
+		CosmosReceiver: mySender.String(),
+	}
+	require.NoError(t, k.AttestationHandler.Handle(ctx, types.Attestation{}, deposit))
+
+	_, err := k.AddToOutgoingPool(ctx, mySender, myReceiver, types.NewERC20Token(90, myTokenContractAddr).PeggyCoin(), types.NewERC20Token(10, myTokenContractAddr).PeggyCoin())
+	require.NoError(t, err)
+	batch, err := k.BuildOutgoingTXBatch(ctx, myTokenContractAddr, 1)
+	require.NoError(t, err)
+
+	withdraw := &types.MsgWithdrawClaim{
+		EventNonce:    2,
+		TokenContract: myTokenContractAddr,
+		BatchNonce:    batch.BatchNonce,
+	}
+	require.NoError(t, k.AttestationHandler.Handle(ctx, types.Attestation{}, withdraw))
+
+	exp := []string{"valset", "valset", "deposit " + voucher.String(), "deposit " + voucher.String(), "batch", "batch", "withdraw", "withdraw"}
+	assert.Equal(t, exp, calls)
+}
@@ -0,0 +1,101 @@
+package keeper
+
+import (
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// PeggyKeeper is the part of the peggy keeper consumed by the msg server, the handlers, the
+// legacy querier and other modules. Chains that want to add custom logic (fees, allowlists, ...)
+// can embed Keeper in their own type, override the relevant methods and pass that instead
+type PeggyKeeper interface {
+	types.QueryServer
+
+	GetParams(ctx sdk.Context) types.Params
+	GetGravityID(ctx sdk.Context) string
+	Validator(ctx sdk.Context, addr sdk.ValAddress) stakingtypes.ValidatorI
+	IsUnbondingValsetSigner(ctx sdk.Context, addr sdk.ValAddress, nonce uint64) bool
+
+	// delegate keys
+	SetOrchestratorValidator(ctx sdk.Context, val sdk.ValAddress, orch sdk.AccAddress)
+	GetOrchestratorValidator(ctx sdk.Context, orch sdk.AccAddress) sdk.ValAddress
+	DeleteOrchestratorValidator(ctx sdk.Context, orch sdk.AccAddress)
+	GetValidatorOrchestrators(ctx sdk.Context, val sdk.ValAddress) []sdk.AccAddress
+	SetEthAddress(ctx sdk.Context, validator sdk.ValAddress, ethAddr string)
+	GetEthAddress(ctx sdk.Context, validator sdk.ValAddress) string
+
+	// valsets
+	GetCurrentValset(ctx sdk.Context) *types.Valset
+	GetValset(ctx sdk.Context, nonce uint64) *types.Valset
+	IterateValsets(ctx sdk.Context, cb func(key []byte, val *types.Valset) bool)
+	GetUnsignedValsets(ctx sdk.Context, orchestrator sdk.AccAddress, limit uint64) []*types.Valset
+	SetValsetConfirm(ctx sdk.Context, valsetConf types.MsgValsetConfirm) []byte
+	GetValsetConfirm(ctx sdk.Context, nonce uint64, validator sdk.AccAddress) *types.MsgValsetConfirm
+	IterateValsetConfirmByNonce(ctx sdk.Context, nonce uint64, cb func([]byte, types.MsgValsetConfirm) bool)
+
+	// outgoing pool and batches
+	AddToOutgoingPool(ctx sdk.Context, sender sdk.AccAddress, counterpartReceiver string, amount sdk.Coin, fee sdk.Coin) (uint64, error)
+	SetOutgoingTxExpiration(ctx sdk.Context, txID uint64, expirationHeight, expirationTime uint64) error
+	ScheduleOutgoingTx(ctx sdk.Context, sender sdk.AccAddress, counterpartReceiver string, amount sdk.Coin, fee sdk.Coin, executeAfterHeight, executeAfterTime uint64) (uint64, error)
+	GetScheduledTransfer(ctx sdk.Context, txID uint64) *types.ScheduledTransfer
+	CancelScheduledTransfer(ctx sdk.Context, txID uint64, sender sdk.AccAddress) error
+	RemoveFromOutgoingPoolAndRefund(ctx sdk.Context, txId uint64, sender sdk.AccAddress) error
+	BumpOutgoingTxFee(ctx sdk.Context, txID uint64, sender sdk.AccAddress, additionalFee sdk.Coin) error
+	GetPoolTransactions(ctx sdk.Context) []*types.OutgoingTransferTx
+	RequestOutgoingTXBatch(ctx sdk.Context, contractAddress string, requester sdk.AccAddress) (*types.OutgoingTxBatch, error)
+	GetOutgoingTXBatch(ctx sdk.Context, tokenContract string, nonce uint64) *types.OutgoingTxBatch
+	IterateOutgoingTXBatches(ctx sdk.Context, cb func(key []byte, batch *types.OutgoingTxBatch) bool)
+	GetOutgoingTxBatches(ctx sdk.Context) []*types.OutgoingTxBatch
+	CreateBatchFees(ctx sdk.Context) []*types.BatchFees
+	SetBatchConfirm(ctx sdk.Context, batch *types.MsgConfirmBatch) []byte
+	GetBatchConfirm(ctx sdk.Context, nonce uint64, tokenContract string, validator sdk.AccAddress) *types.MsgConfirmBatch
+	IterateBatchConfirmByNonceAndTokenContract(ctx sdk.Context, nonce uint64, tokenContract string, cb func([]byte, types.MsgConfirmBatch) bool)
+
+	// logic calls
+	GetOutgoingLogicCall(ctx sdk.Context, invalidationId []byte, invalidationNonce uint64) *types.OutgoingLogicCall
+	IterateOutgoingLogicCalls(ctx sdk.Context, cb func([]byte, *types.OutgoingLogicCall) bool)
+	SetLogicCallConfirm(ctx sdk.Context, msg *types.MsgConfirmLogicCall)
+	GetLogicCallConfirm(ctx sdk.Context, invalidationId []byte, invalidationNonce uint64, val sdk.AccAddress) *types.MsgConfirmLogicCall
+	IterateLogicConfirmByInvalidationIdAndNonce(ctx sdk.Context, invalidationId []byte, invalidationNonce uint64, cb func([]byte, *types.MsgConfirmLogicCall) bool)
+
+	// ethereum claims
+	Attest(ctx sdk.Context, claim types.EthereumClaim, anyClaim *codectypes.Any) (*types.Attestation, error)
+
+	// cosmos originated assets
+	DenomToERC20Lookup(ctx sdk.Context, denom string) (bool, string, error)
+	ERC20ToDenomLookup(ctx sdk.Context, tokenContract string) (bool, string)
+
+	// relayers
+	SetRelayer(ctx sdk.Context, relayer sdk.AccAddress, ethAddress string)
+	IsRelayerAllowed(ctx sdk.Context, relayer sdk.AccAddress) bool
+
+	// omnibus accounts
+	SetOmnibusAccount(ctx sdk.Context, account sdk.AccAddress, registered bool)
+	SweepOmnibusSubAccounts(ctx sdk.Context, omnibus sdk.AccAddress, tags []string) (sdk.Coins, error)
+
+	// grants
+	SaveGrant(ctx sdk.Context, granter, grantee sdk.AccAddress, grant types.Grant) error
+	DeleteGrant(ctx sdk.Context, granter, grantee sdk.AccAddress, msgTypeURL string) error
+	AuthorizeMsg(ctx sdk.Context, grantee sdk.AccAddress, msg sdk.Msg) error
+
+	// governance
+	GetAuthority() string
+	UpdateParams(ctx sdk.Context, authority string, params types.Params) error
+	CancelBatch(ctx sdk.Context, sender string, tokenContract string, nonce uint64) error
+	SetLastObservedEventNonce(ctx sdk.Context, authority string, nonce uint64) error
+	PauseToken(ctx sdk.Context, authority string, tokenContract string) error
+	UnpauseToken(ctx sdk.Context, authority string, tokenContract string) error
+	SetCosmosOriginatedERC20(ctx sdk.Context, authority string, denom string, tokenContract string, decimals uint32) error
+	ReturnReclaimableDeposit(ctx sdk.Context, eventNonce uint64, destination sdk.AccAddress) error
+	RotateBridgeContract(ctx sdk.Context, rotation types.ContractRotation) error
+	AbandonValsetNonce(ctx sdk.Context, nonce uint64) error
+	ExportBridgeSnapshot(ctx sdk.Context) *types.BridgeSnapshot
+
+	// debugging
+	DebugQueriesEnabled() bool
+	DumpStore(ctx sdk.Context) *types.StoreDump
+}
+
+var _ PeggyKeeper = Keeper{}
@@ -0,0 +1,91 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// RegisterInvariants registers the peggy module invariants
+func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
+	ir.RegisterRoute(types.ModuleName, "pending-fees", PendingFeesInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "valset-nonce-continuity", ValsetNonceContinuityInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "module-residue", ModuleResidueInvariant(k))
+}
+
+// PendingFeesInvariant checks that the fee collector holds the fees of all pending transfers and logic calls
+func PendingFeesInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		pending := k.getPendingFees(ctx)
+
+		feeCollectorAddr := authtypes.NewModuleAddress(types.FeeCollectorName)
+		var msg string
+		broken := false
+		for _, coin := range pending {
+			if balance := k.bankKeeper.GetBalance(ctx, feeCollectorAddr, coin.Denom); balance.IsLT(coin) {
+				broken = true
+				msg += fmt.Sprintf("\tpending fees %s but fee collector holds %s\n", coin, balance)
+			}
+		}
+		return sdk.FormatInvariant(types.ModuleName, "pending fees",
+			fmt.Sprintf("pending fees %s\n%s", pending, msg)), broken
+	}
+}
+
+// ValsetNonceContinuityInvariant checks that the valset nonces skipped on Ethereum precede the last observed
+// valset nonce. Skipped nonces waiting for governance to abandon them are expected and only logged when they
+// are recorded, they don't break it.
+func ValsetNonceContinuityInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		last := k.GetLastObservedValsetNonce(ctx)
+		var msg string
+		broken := false
+		for _, nonce := range k.GetSkippedValsetNonces(ctx) {
+			if nonce >= last {
+				broken = true
+				msg += fmt.Sprintf("\tskipped valset nonce %d not below the last observed valset nonce\n", nonce)
+			}
+		}
+		return sdk.FormatInvariant(types.ModuleName, "valset nonce continuity",
+			fmt.Sprintf("last observed valset nonce %d\n%s", last, msg)), broken
+	}
+}
+
+// getPendingFees sums the fees of the transfers in the pool, scheduled, in batches and in cancelled batches that
+// may still be executed, and of the logic calls with escrowed fees, these are held by the fee collector
+func (k Keeper) getPendingFees(ctx sdk.Context) sdk.Coins {
+	pending := sdk.NewCoins()
+	addTx := func(tx *types.OutgoingTransferTx) {
+		_, fee := k.ERC20ToCoin(ctx, *tx.Erc20Fee)
+		pending = pending.Add(fee)
+	}
+	k.IterateOutgoingPool(ctx, func(tx *types.OutgoingTransferTx) bool {
+		addTx(tx)
+		return false
+	})
+	k.IterateScheduledTransfers(ctx, func(scheduled types.ScheduledTransfer) bool {
+		addTx(&scheduled.Tx)
+		return false
+	})
+	for _, batch := range k.GetOutgoingTxBatches(ctx) {
+		for _, tx := range batch.Transactions {
+			addTx(tx)
+		}
+	}
+	k.IterateCancelledBatches(ctx, "", func(batch *types.OutgoingTxBatch) bool {
+		for _, tx := range batch.Transactions {
+			addTx(tx)
+		}
+		return false
+	})
+	k.IterateOutgoingLogicCalls(ctx, func(_ []byte, call *types.OutgoingLogicCall) bool {
+		if call.Sender != "" {
+			cosmosOriginated, ethereumOriginated := k.logicCallFees(ctx, call)
+			pending = pending.Add(cosmosOriginated...).Add(ethereumOriginated...)
+		}
+		return false
+	})
+	return pending
+}
@@ -6,10 +6,12 @@ import (
 	"math"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
@@ -22,30 +24,40 @@ type Keeper struct {
 
 	storeKey   sdk.StoreKey // Unexposed key to access store from sdk.Context
 	paramSpace paramtypes.Subspace
+	// authority is the account allowed to update the params with MsgUpdateParams
+	authority string
 
 	cdc            codec.BinaryMarshaler // The wire codec for binary encoding/decoding.
 	bankKeeper     types.BankKeeper
 	SlashingKeeper types.SlashingKeeper
+	distKeeper     types.DistributionKeeper
+	hooks          types.PeggyHooks
+	// claimHandlers is shared by all copies of the keeper so handlers can be registered at any time during app setup
+	claimHandlers map[types.ClaimType]ClaimHandler
+	// debugQueries enables the debug querier routes, see EnableDebugQueries
+	debugQueries bool
 
 	AttestationHandler interface {
 		Handle(sdk.Context, types.Attestation, types.EthereumClaim) error
 	}
 }
 
-// NewKeeper returns a new instance of the peggy keeper
-func NewKeeper(cdc codec.BinaryMarshaler, storeKey sdk.StoreKey, paramSpace paramtypes.Subspace, stakingKeeper types.StakingKeeper, bankKeeper types.BankKeeper, slashingKeeper types.SlashingKeeper) Keeper {
+// NewKeeper returns a new instance of the peggy keeper, the params can only be updated by the authority,
+// usually the governance module account
+func NewKeeper(cdc codec.BinaryMarshaler, storeKey sdk.StoreKey, paramSpace paramtypes.Subspace, stakingKeeper types.StakingKeeper, bankKeeper types.BankKeeper, slashingKeeper types.SlashingKeeper, distKeeper types.DistributionKeeper, authority string) Keeper {
 	k := Keeper{
 		cdc:            cdc,
 		paramSpace:     paramSpace,
+		authority:      authority,
 		storeKey:       storeKey,
 		StakingKeeper:  stakingKeeper,
 		bankKeeper:     bankKeeper,
 		SlashingKeeper: slashingKeeper,
+		distKeeper:     distKeeper,
+		claimHandlers:  make(map[types.ClaimType]ClaimHandler),
 	}
-	k.AttestationHandler = AttestationHandler{
-		keeper:     k,
-		bankKeeper: bankKeeper,
-	}
+	k.registerDefaultClaimHandlers()
+	k.AttestationHandler = AttestationHandler{keeper: k}
 
 	// set KeyTable if it has not already been set
 	if !paramSpace.HasKeyTable() {
@@ -64,6 +76,7 @@ func NewKeeper(cdc codec.BinaryMarshaler, storeKey sdk.StoreKey, paramSpace para
 func (k Keeper) SetValsetRequest(ctx sdk.Context) *types.Valset {
 	valset := k.GetCurrentValset(ctx)
 	k.StoreValset(ctx, valset)
+	k.AfterValsetUpdated(ctx, valset)
 
 	ctx.EventManager().EmitEvent(
 		sdk.NewEvent(
@@ -200,6 +213,20 @@ func (k Keeper) GetLastUnBondingBlockHeight(ctx sdk.Context) uint64 {
 	return types.UInt64FromBytes(bytes)
 }
 
+// SetLastSlashBlockHeight sets the last block height a validator was slashed at
+func (k Keeper) SetLastSlashBlockHeight(ctx sdk.Context, slashBlockHeight uint64) {
+	ctx.KVStore(k.storeKey).Set(types.LastSlashBlockHeight, types.UInt64Bytes(slashBlockHeight))
+}
+
+// GetLastSlashBlockHeight returns the last block height a validator was slashed at
+func (k Keeper) GetLastSlashBlockHeight(ctx sdk.Context) uint64 {
+	bz := ctx.KVStore(k.storeKey).Get(types.LastSlashBlockHeight)
+	if len(bz) == 0 {
+		return 0
+	}
+	return types.UInt64FromBytes(bz)
+}
+
 // GetUnSlashedValsets returns all the unslashed validator sets in state
 func (k Keeper) GetUnSlashedValsets(ctx sdk.Context, maxHeight uint64) (out []*types.Valset) {
 	lastSlashedValsetNonce := k.GetLastSlashedValsetNonce(ctx)
@@ -351,10 +378,52 @@ func (k Keeper) GetBatchConfirmByNonceAndTokenContract(ctx sdk.Context, nonce ui
 //    ADDRESS DELEGATION   //
 /////////////////////////////
 
-// SetOrchestratorValidator sets the Orchestrator key for a given validator
+// SetOrchestratorValidator sets the Orchestrator key for a given validator, a validator may have
+// several orchestrators but an orchestrator acts for a single validator
 func (k Keeper) SetOrchestratorValidator(ctx sdk.Context, val sdk.ValAddress, orch sdk.AccAddress) {
 	store := ctx.KVStore(k.storeKey)
+	if previous := k.GetOrchestratorValidator(ctx, orch); !previous.Empty() {
+		store.Delete(types.GetValidatorOrchestratorKey(previous, orch))
+	}
 	store.Set(types.GetOrchestratorAddressKey(orch), val.Bytes())
+	store.Set(types.GetValidatorOrchestratorKey(val, orch), []byte{})
+}
+
+// DeleteOrchestratorValidator removes an orchestrator. Its stored confirms move to the first remaining
+// orchestrator of the validator it acted for so they still count for the validator and can't be sent
+// again, without another orchestrator they are deleted.
+func (k Keeper) DeleteOrchestratorValidator(ctx sdk.Context, orch sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	if val := k.GetOrchestratorValidator(ctx, orch); !val.Empty() {
+		store.Delete(types.GetValidatorOrchestratorKey(val, orch))
+		var successor sdk.AccAddress
+		if remaining := k.GetValidatorOrchestrators(ctx, val); len(remaining) > 0 {
+			successor = remaining[0]
+		}
+		k.moveOrchestratorConfirms(ctx, orch, successor)
+	}
+	store.Delete(types.GetOrchestratorAddressKey(orch))
+}
+
+// GetValidatorOrchestrators returns the orchestrators of a validator ordered by address
+func (k Keeper) GetValidatorOrchestrators(ctx sdk.Context, val sdk.ValAddress) (out []sdk.AccAddress) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.GetValidatorOrchestratorPrefix(val))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		out = append(out, sdk.AccAddress(append([]byte{}, iter.Key()...)))
+	}
+	return
+}
+
+// getSiblingOrchestrators returns the orchestrators of the validator the orchestrator acts for,
+// including the orchestrator itself, or just the orchestrator if it acts for no validator
+func (k Keeper) getSiblingOrchestrators(ctx sdk.Context, orch sdk.AccAddress) []sdk.AccAddress {
+	val := k.GetOrchestratorValidator(ctx, orch)
+	if val.Empty() {
+		return []sdk.AccAddress{orch}
+	}
+	return k.GetValidatorOrchestrators(ctx, val)
 }
 
 // GetOrchestratorValidator returns the validator key associated with an orchestrator key
@@ -373,6 +442,18 @@ func (k Keeper) SetEthAddress(ctx sdk.Context, validator sdk.ValAddress, ethAddr
 	store.Set(types.GetEthAddressKey(validator), []byte(ethAddr))
 }
 
+// IsUnbondingValsetSigner returns true while the validator is unbonding and is still slashed for missing the
+// confirm of the valset with the given nonce, UnbondSlashingValsetsWindow after it started unbonding
+func (k Keeper) IsUnbondingValsetSigner(ctx sdk.Context, addr sdk.ValAddress, nonce uint64) bool {
+	val, found := k.StakingKeeper.GetValidator(ctx, addr)
+	return found && val.IsUnbonding() && nonce < uint64(val.UnbondingHeight)+k.GetParams(ctx).UnbondSlashingValsetsWindow
+}
+
+// Validator returns the validator with the given operator address from the staking keeper, nil if it doesn't exist
+func (k Keeper) Validator(ctx sdk.Context, addr sdk.ValAddress) stakingtypes.ValidatorI {
+	return k.StakingKeeper.Validator(ctx, addr)
+}
+
 // GetEthAddress returns the eth address for a given peggy validator
 func (k Keeper) GetEthAddress(ctx sdk.Context, validator sdk.ValAddress) string {
 	store := ctx.KVStore(k.storeKey)
@@ -392,28 +473,81 @@ func (k Keeper) GetEthAddress(ctx sdk.Context, validator sdk.ValAddress) string
 // implementations are involved.
 func (k Keeper) GetCurrentValset(ctx sdk.Context) *types.Valset {
 	validators := k.StakingKeeper.GetBondedValidatorsByPower(ctx)
-	bridgeValidators := make([]*types.BridgeValidator, len(validators))
-	var totalPower uint64
-	// TODO someone with in depth info on Cosmos staking should determine
-	// if this is doing what I think it's doing
-	for i, validator := range validators {
+	bridgeValidators := make(types.BridgeValidators, 0, len(validators))
+	for _, validator := range validators {
+		// a tombstoned validator stays bonded until the staking endblocker, it is left out right away
+		// so it can't sign any bridge payload with its remaining power
+		if k.isTombstoned(ctx, validator) {
+			continue
+		}
 		val := validator.GetOperator()
 
-		p := uint64(k.StakingKeeper.GetLastValidatorPower(ctx, val))
-		totalPower += p
-
-		bridgeValidators[i] = &types.BridgeValidator{Power: p}
+		bv := &types.BridgeValidator{Power: uint64(k.StakingKeeper.GetLastValidatorPower(ctx, val))}
 		if ethAddr := k.GetEthAddress(ctx, val); ethAddr != "" {
-			bridgeValidators[i].EthereumAddress = ethAddr
+			bv.EthereumAddress = ethAddr
+		}
+		bridgeValidators = append(bridgeValidators, bv)
+	}
+
+	// the power index of the staking module breaks ties by operator address, order by power and then
+	// Ethereum address like the valset does so that validators with equal power are cut deterministically
+	bridgeValidators.Sort()
+	params := k.GetParams(ctx)
+	if params.MaxValsetSize != 0 && uint64(len(bridgeValidators)) > params.MaxValsetSize {
+		bridgeValidators = bridgeValidators[:params.MaxValsetSize]
+	}
+
+	// leave out the validators whose normalized power rounds to zero or is below MinValsetPower, they
+	// cost calldata on Ethereum without adding to the signature threshold and some contracts reject
+	// members without power. The validators are ordered by power, the top ones are kept whatever the
+	// minimum until the members hold the bridge threshold of the power, a stake split over many small
+	// validators would otherwise be left out of the valset.
+	minPower := params.MinValsetPower
+	if minPower == 0 {
+		minPower = 1
+	}
+	totalPower := bridgeValidators.TotalPower()
+	members := bridgeValidators[:0]
+	var membersPower uint64
+	for _, bv := range bridgeValidators {
+		if totalPower == 0 {
+			break
+		}
+		power := normalizeValsetPower(bv.Power, totalPower)
+		if power >= minPower || (power > 0 && membersPower < types.BridgePowerThreshold) {
+			members = append(members, bv)
+			membersPower += power
 		}
 	}
-	// normalize power values
-	for i := range bridgeValidators {
-		bridgeValidators[i].Power = sdk.NewUint(bridgeValidators[i].Power).MulUint64(math.MaxUint32).QuoUint64(totalPower).Uint64()
+
+	// normalize the power of the members against their total power, so the powers of a valset always
+	// sum up to about 2^32 however many validators were left out. The bridge contract checks the
+	// signatures against a fixed share of that sum. Leaving out members only raises the power of the
+	// others, so none of them falls below the minimum again.
+	totalPower = members.TotalPower()
+	for _, bv := range members {
+		bv.Power = normalizeValsetPower(bv.Power, totalPower)
 	}
 
 	// TODO: make the nonce an incrementing one (i.e. fetch last nonce from state, increment, set here)
-	return types.NewValset(uint64(ctx.BlockHeight()), uint64(ctx.BlockHeight()), bridgeValidators)
+	return types.NewValset(uint64(ctx.BlockHeight()), uint64(ctx.BlockHeight()), members)
+}
+
+// normalizeValsetPower scales the power of a member to its share of 2^32
+func normalizeValsetPower(power, totalPower uint64) uint64 {
+	return sdk.NewUint(power).MulUint64(math.MaxUint32).QuoUint64(totalPower).Uint64()
+}
+
+// isTombstoned returns true if the validator was tombstoned for double signing
+func (k Keeper) isTombstoned(ctx sdk.Context, validator stakingtypes.Validator) bool {
+	if validator.ConsensusPubkey == nil {
+		return false
+	}
+	consAddr, err := validator.GetConsAddr()
+	if err != nil {
+		return false
+	}
+	return k.SlashingKeeper.IsTombstoned(ctx, consAddr)
 }
 
 /////////////////////////////
@@ -463,12 +597,16 @@ func (k Keeper) GetOutgoingLogicCalls(ctx sdk.Context) (out []*types.OutgoingLog
 	return
 }
 
-// CancelOutgoingLogicCalls releases all TX in the batch and deletes the batch
+// CancelOutgoingLogicCalls refunds the escrowed fees of the logic call and deletes it
 func (k Keeper) CancelOutgoingLogicCall(ctx sdk.Context, invalidationId []byte, invalidationNonce uint64) error {
-	call := k.GetOutgoingLogicCall(ctx, invalidationId, invalidationNonce)
-	if call == nil {
+	if !k.hasOutgoingLogicCall(ctx, invalidationId, invalidationNonce) {
 		return types.ErrUnknown
 	}
+	call := k.GetOutgoingLogicCall(ctx, invalidationId, invalidationNonce)
+	// the call can no longer be executed, return the escrowed fees
+	if err := k.refundLogicCallFees(ctx, call); err != nil {
+		return sdkerrors.Wrap(err, "refund fees")
+	}
 	// Delete batch since it is finished
 	k.DeleteOutgoingLogicCall(ctx, call.InvalidationId, call.InvalidationNonce)
 
@@ -543,59 +681,114 @@ func (k Keeper) GetLogicConfirmByInvalidationIdAndNonce(ctx sdk.Context, invalid
 //       PARAMETERS        //
 /////////////////////////////
 
-// GetParams returns the parameters from the store
+// GetParams returns the parameters from the store. Until the MigrateParamsToStore migration ran
+// they are read from the legacy peggy subspace of the params module
 func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
-	k.paramSpace.GetParamSet(ctx, &params)
+	bz := ctx.KVStore(k.storeKey).Get(types.ParamsKey)
+	if bz == nil {
+		return k.legacyParams(ctx)
+	}
+	k.cdc.MustUnmarshalBinaryBare(bz, &params)
 	return
 }
 
 // SetParams sets the parameters in the store
 func (k Keeper) SetParams(ctx sdk.Context, ps types.Params) {
-	k.paramSpace.SetParamSet(ctx, &ps)
+	ctx.KVStore(k.storeKey).Set(types.ParamsKey, k.cdc.MustMarshalBinaryBare(&ps))
+}
+
+// GetAuthority returns the account allowed to update the params
+func (k Keeper) GetAuthority() string {
+	return k.authority
+}
+
+// UpdateParams replaces the params after checking that they are updated by the authority and are
+// valid as a whole. The ERC20InitCodeHash param belongs to the bridge contract, it only changes with
+// the contract in RotateBridgeContract.
+func (k Keeper) UpdateParams(ctx sdk.Context, authority string, params types.Params) error {
+	if authority != k.authority {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "expected authority %s, got %s", k.authority, authority)
+	}
+	if err := params.Validate(); err != nil {
+		return sdkerrors.Wrap(err, "params")
+	}
+	if current := k.GetParams(ctx); params.Erc20InitCodeHash != current.Erc20InitCodeHash {
+		return sdkerrors.Wrap(types.ErrInvalid, "the ERC20 init code hash only changes with the bridge contract")
+	}
+	k.SetParams(ctx, params)
+	return nil
+}
+
+// legacyParams reads the params from the peggy subspace of the params module, params missing from
+// the subspace take their default value
+func (k Keeper) legacyParams(ctx sdk.Context) types.Params {
+	params := *types.DefaultParams()
+	for _, pair := range params.ParamSetPairs() {
+		k.paramSpace.GetIfExists(ctx, pair.Key, pair.Value)
+	}
+	return params
+}
+
+// IsTokenAllowed returns false if the token contract is on the token denylist or if there
+// is a token allowlist and the contract is not on it
+func (k Keeper) IsTokenAllowed(ctx sdk.Context, tokenContract string) bool {
+	params := k.GetParams(ctx)
+	allowlist, denylist := params.TokenAllowlist, params.TokenDenylist
+
+	for _, token := range denylist {
+		if strings.EqualFold(token, tokenContract) {
+			return false
+		}
+	}
+	if len(allowlist) == 0 {
+		return true
+	}
+	for _, token := range allowlist {
+		if strings.EqualFold(token, tokenContract) {
+			return true
+		}
+	}
+	return false
 }
 
 // GetBridgeContractAddress returns the bridge contract address on ETH
 func (k Keeper) GetBridgeContractAddress(ctx sdk.Context) string {
-	var a string
-	k.paramSpace.Get(ctx, types.ParamsStoreKeyBridgeContractAddress, &a)
-	return a
+	return k.GetParams(ctx).BridgeEthereumAddress
 }
 
 // GetBridgeChainID returns the chain id of the ETH chain we are running against
 func (k Keeper) GetBridgeChainID(ctx sdk.Context) uint64 {
-	var a uint64
-	k.paramSpace.Get(ctx, types.ParamsStoreKeyBridgeContractChainID, &a)
-	return a
+	return k.GetParams(ctx).BridgeChainId
 }
 
-// GetPeggyID returns the PeggyID the PeggyID is essentially a salt value
+// GetGravityID returns the GravityID the GravityID is essentially a salt value
 // for bridge signatures, provided each chain running Peggy has a unique ID
 // it won't be possible to play back signatures from one bridge onto another
 // even if they share a validator set.
 //
-// The lifecycle of the PeggyID is that it is set in the Genesis file
+// The lifecycle of the GravityID is that it is set in the Genesis file
 // read from the live chain for the contract deployment, once a Peggy contract
-// is deployed the PeggyID CAN NOT BE CHANGED. Meaning that it can't just be the
+// is deployed the GravityID CAN NOT BE CHANGED. Meaning that it can't just be the
 // same as the chain id since the chain id may be changed many times with each
 // successive chain in charge of the same bridge
-func (k Keeper) GetPeggyID(ctx sdk.Context) string {
-	var a string
-	k.paramSpace.Get(ctx, types.ParamsStoreKeyPeggyID, &a)
-	return a
+func (k Keeper) GetGravityID(ctx sdk.Context) string {
+	return k.GetParams(ctx).GravityId
 }
 
-// Set PeggyID sets the PeggyID the PeggyID is essentially a salt value
+// Set GravityID sets the GravityID the GravityID is essentially a salt value
 // for bridge signatures, provided each chain running Peggy has a unique ID
 // it won't be possible to play back signatures from one bridge onto another
 // even if they share a validator set.
 //
-// The lifecycle of the PeggyID is that it is set in the Genesis file
+// The lifecycle of the GravityID is that it is set in the Genesis file
 // read from the live chain for the contract deployment, once a Peggy contract
-// is deployed the PeggyID CAN NOT BE CHANGED. Meaning that it can't just be the
+// is deployed the GravityID CAN NOT BE CHANGED. Meaning that it can't just be the
 // same as the chain id since the chain id may be changed many times with each
 // successive chain in charge of the same bridge
-func (k Keeper) SetPeggyID(ctx sdk.Context, v string) {
-	k.paramSpace.Set(ctx, types.ParamsStoreKeyPeggyID, v)
+func (k Keeper) SetGravityID(ctx sdk.Context, v string) {
+	params := k.GetParams(ctx)
+	params.GravityId = v
+	k.SetParams(ctx, params)
 }
 
 // logger returns a module-specific logger.
@@ -648,38 +841,43 @@ func (k Keeper) GetDelegateKeys(ctx sdk.Context) []*types.MsgSetOrchestratorAddr
 	iter = store.Iterator(prefixRange(prefix))
 	defer iter.Close()
 
-	orchAddresses := make(map[string]string)
+	// a validator may have several orchestrators, each of them gets its own entry
+	orchAddresses := make(map[string][]string)
 
 	for ; iter.Valid(); iter.Next() {
 		key := iter.Key()[len(types.KeyOrchestratorAddress):]
 		value := iter.Value()
 		orchAddress := sdk.AccAddress(key).String()
 		valAddress := sdk.ValAddress(value)
-		orchAddresses[valAddress.String()] = orchAddress
+		orchAddresses[valAddress.String()] = append(orchAddresses[valAddress.String()], orchAddress)
 	}
 
 	var result []*types.MsgSetOrchestratorAddress
 
 	for valAddr, ethAddr := range ethAddresses {
-		orch, ok := orchAddresses[valAddr]
+		orchs, ok := orchAddresses[valAddr]
 		if !ok {
 			// this should never happen unless the store
 			// is somehow inconsistent
 			panic("Can't find address")
 		}
-		result = append(result, &types.MsgSetOrchestratorAddress{
-			Orchestrator: orch,
-			Validator:    valAddr,
-			EthAddress:   ethAddr,
-		})
-
+		for _, orch := range orchs {
+			result = append(result, &types.MsgSetOrchestratorAddress{
+				Orchestrator: orch,
+				Validator:    valAddr,
+				EthAddress:   ethAddr,
+			})
+		}
 	}
 
 	// we iterated over a map, so now we have to sort to ensure the
 	// output here is deterministic, eth address chosen for no particular
-	// reason
+	// reason and the orchestrator for the entries of the same validator
 	sort.Slice(result[:], func(i, j int) bool {
-		return result[i].EthAddress < result[j].EthAddress
+		if result[i].EthAddress != result[j].EthAddress {
+			return result[i].EthAddress < result[j].EthAddress
+		}
+		return result[i].Orchestrator < result[j].Orchestrator
 	})
 
 	return result
@@ -2,16 +2,23 @@ package keeper
 
 import (
 	"bytes"
+	"encoding/hex"
 	"fmt"
+	"math"
+	"math/rand"
 	"testing"
 
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestPrefixRange(t *testing.T) {
+	t.Parallel()
 	cases := map[string]struct {
 		src      []byte
 		expStart []byte
@@ -43,6 +50,7 @@ func TestPrefixRange(t *testing.T) {
 }
 
 func TestCurrentValsetNormalization(t *testing.T) {
+	t.Parallel()
 	specs := map[string]struct {
 		srcPowers []uint64
 		expPowers []uint64
@@ -75,7 +83,117 @@ func TestCurrentValsetNormalization(t *testing.T) {
 	}
 }
 
+func TestCurrentValsetMaxSize(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	params := k.GetParams(ctx)
+	params.MaxValsetSize = 2
+	k.SetParams(ctx, params)
+
+	operators := make([]MockStakingValidatorData, 4)
+	for i, power := range []int64{5, 10, 1, 5} {
+		operators[i] = MockStakingValidatorData{Operator: bytes.Repeat([]byte{byte(i)}, sdk.AddrLen), Power: power}
+		k.SetEthAddress(ctx, operators[i].Operator, EthAddrs[i].String())
+	}
+	k.StakingKeeper = NewStakingKeeperWeightedMock(operators...)
+
+	// the validators with the most power are taken, the tie is broken by the Ethereum address
+	tied := EthAddrs[0].String()
+	if types.EthAddrLessThan(EthAddrs[3].String(), tied) {
+		tied = EthAddrs[3].String()
+	}
+	members := types.BridgeValidators(k.GetCurrentValset(ctx).Members)
+	require.Len(t, members, 2)
+	assert.Equal(t, EthAddrs[1].String(), members[0].EthereumAddress)
+	assert.Equal(t, tied, members[1].EthereumAddress)
+	// the powers are normalized against the members only
+	assert.Equal(t, []uint64{2863311530, 1431655765}, members.GetPowers())
+
+	params.MaxValsetSize = 0
+	k.SetParams(ctx, params)
+	assert.Len(t, k.GetCurrentValset(ctx).Members, 4)
+}
+
+func TestCurrentValsetMinPower(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	setPowers := func(powers ...int64) {
+		operators := make([]MockStakingValidatorData, len(powers))
+		for i, power := range powers {
+			operators[i] = MockStakingValidatorData{Operator: bytes.Repeat([]byte{byte(i)}, sdk.AddrLen), Power: power}
+			k.SetEthAddress(ctx, operators[i].Operator, gethcommon.BytesToAddress([]byte{byte(i + 1)}).Hex())
+		}
+		k.StakingKeeper = NewStakingKeeperWeightedMock(operators...)
+	}
+
+	// a member whose power rounds to zero is left out
+	setPowers(1<<33, 1, 1<<32)
+	assert.Equal(t, []uint64{2863311530, 1431655765}, types.BridgeValidators(k.GetCurrentValset(ctx).Members).GetPowers())
+
+	// as is one below the minimum, the others are normalized without it
+	params := k.GetParams(ctx)
+	params.MinValsetPower = math.MaxUint32 / 8
+	k.SetParams(ctx, params)
+	setPowers(6, 3, 1)
+	assert.Equal(t, []uint64{2863311530, 1431655765}, types.BridgeValidators(k.GetCurrentValset(ctx).Members).GetPowers())
+
+	// the top validators are kept until the members hold the bridge threshold
+	params.MinValsetPower = math.MaxUint32 - types.BridgePowerThreshold
+	k.SetParams(ctx, params)
+	setPowers(3, 3, 2, 2)
+	members := types.BridgeValidators(k.GetCurrentValset(ctx).Members)
+	assert.Len(t, members, 3)
+	assert.Equal(t, []uint64{1610612735, 1610612735, 1073741823}, members.GetPowers())
+
+	// the checkpoints of valsets never hold members without power
+	params.MinValsetPower = 0
+	k.SetParams(ctx, params)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		powers := make([]int64, 1+r.Intn(20))
+		for j := range powers {
+			powers[j] = r.Int63n(1 << uint(r.Intn(40)+1))
+		}
+		setPowers(powers...)
+		valset := k.GetCurrentValset(ctx)
+		if len(valset.Members) == 0 {
+			continue
+		}
+		require.NoError(t, types.BridgeValidators(valset.Members).ValidateBasic(), "powers %v", powers)
+		assert.InDelta(t, uint64(math.MaxUint32), types.BridgeValidators(valset.Members).TotalPower(), float64(len(valset.Members)))
+	}
+}
+
+func TestValsetDiff(t *testing.T) {
+	input, ctx := SetupFiveValChain(t)
+	k := input.PeggyKeeper
+
+	_, err := k.ValsetDiff(sdk.WrapSDKContext(ctx), &types.QueryValsetDiffRequest{})
+	assert.ErrorIs(t, err, types.ErrEmpty)
+
+	k.SetValsetRequest(ctx)
+	res, err := k.ValsetDiff(sdk.WrapSDKContext(ctx), &types.QueryValsetDiffRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, &types.QueryValsetDiffResponse{
+		LatestValsetNonce:    uint64(ctx.BlockHeight()),
+		ThresholdBasisPoints: types.ValsetPowerDiffThresholdBasisPoints,
+	}, res)
+
+	// a validator switching its Ethereum key replaces a fifth of the power
+	k.SetEthAddress(ctx, ValAddrs[0], gethcommon.BytesToAddress([]byte{1}).Hex())
+	res, err = k.ValsetDiff(sdk.WrapSDKContext(ctx), &types.QueryValsetDiffRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4000), res.PowerDiffBasisPoints)
+	assert.Equal(t, uint64(1), res.MembersAdded)
+	assert.Equal(t, uint64(1), res.MembersRemoved)
+}
+
 func TestAttestationIterator(t *testing.T) {
+	t.Parallel()
 	input := CreateTestEnv(t)
 	ctx := input.Context
 	// add some attestations to the store
@@ -108,7 +226,7 @@ func TestAttestationIterator(t *testing.T) {
 	input.PeggyKeeper.SetAttestation(ctx, dep2.EventNonce, dep2.ClaimHash(), att2)
 
 	atts := []types.Attestation{}
-	input.PeggyKeeper.IterateAttestaions(ctx, func(_ []byte, att types.Attestation) bool {
+	input.PeggyKeeper.IterateAttestations(ctx, func(_ []byte, att types.Attestation) bool {
 		atts = append(atts, att)
 		return false
 	})
@@ -116,7 +234,60 @@ func TestAttestationIterator(t *testing.T) {
 	require.Len(t, atts, 2)
 }
 
+func TestSetLastObservedEventNonce(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	valAddr := ValAddrs[0]
+	attest := func(nonce uint64, observed bool) *types.MsgDepositClaim {
+		claim := &types.MsgDepositClaim{
+			EventNonce:     nonce,
+			TokenContract:  TokenContractAddrs[0],
+			Amount:         sdk.NewInt(100),
+			EthereumSender: EthAddrs[0].String(),
+			CosmosReceiver: AccAddrs[0].String(),
+			Orchestrator:   AccAddrs[0].String(),
+		}
+		any, err := codectypes.NewAnyWithValue(claim)
+		require.NoError(t, err)
+		k.SetAttestation(ctx, nonce, claim.ClaimHash(), &types.Attestation{
+			Observed: observed,
+			Votes:    []string{valAddr.String()},
+			Claim:    any,
+		})
+		return claim
+	}
+	observed := attest(3, true)
+	stuck := attest(4, false)
+	attest(5, false)
+	k.setLastObservedEventNonce(ctx, 3)
+	k.setLastEventNonceByValidator(ctx, valAddr, 5)
+	k.setPendingVoteReset(ctx, valAddr, 4)
+
+	err := k.SetLastObservedEventNonce(ctx, AccAddrs[0].String(), 4)
+	assert.True(t, sdkerrors.ErrUnauthorized.Is(err), err)
+
+	// skipping the stuck event clears the pending votes
+	require.NoError(t, k.SetLastObservedEventNonce(ctx, k.GetAuthority(), 4))
+	assert.Equal(t, uint64(4), k.GetLastObservedEventNonce(ctx))
+	assert.Equal(t, uint64(4), k.GetLastEventNonceByValidator(ctx, valAddr))
+	assert.Nil(t, k.GetAttestation(ctx, stuck.EventNonce, stuck.ClaimHash()))
+	assert.Empty(t, k.GetAttestationsByNonce(ctx, 5))
+	assert.Nil(t, ctx.KVStore(k.storeKey).Get(types.GetPendingVoteResetKey(valAddr)))
+
+	// after a rewind an observed event is observed again without being applied twice
+	require.NoError(t, k.SetLastObservedEventNonce(ctx, k.GetAuthority(), 2))
+	att := k.GetAttestation(ctx, observed.EventNonce, observed.ClaimHash())
+	require.NotNil(t, att)
+	k.TryAttestation(ctx, att)
+	assert.Equal(t, uint64(3), k.GetLastObservedEventNonce(ctx))
+	assert.Equal(t, uint64(4), k.GetLastAppliedEventNonce(ctx))
+	assert.True(t, input.BankKeeper.GetAllBalances(ctx, AccAddrs[0]).IsZero())
+}
+
 func TestDelegateKeys(t *testing.T) {
+	t.Parallel()
 	input := CreateTestEnv(t)
 	ctx := input.Context
 	k := input.PeggyKeeper
@@ -147,6 +318,7 @@ func TestDelegateKeys(t *testing.T) {
 }
 
 func TestLastSlashedValsetNonce(t *testing.T) {
+	t.Parallel()
 	input := CreateTestEnv(t)
 	k := input.PeggyKeeper
 	ctx := input.Context
@@ -191,3 +363,272 @@ func TestLastSlashedValsetNonce(t *testing.T) {
 	assert.Equal(t, len(unslashedValsets), 6)
 	fmt.Println("unslashedValsetsRange", unslashedValsets)
 }
+
+func TestOrchestratorLiveness(t *testing.T) {
+	t.Parallel()
+	input, ctx := SetupFiveValChain(t)
+	k := input.PeggyKeeper
+	params := k.GetParams(ctx)
+
+	for i := range ValAddrs {
+		k.SetOrchestratorValidator(ctx, ValAddrs[i], AccAddrs[i])
+	}
+
+	// create a valset after the validators are bonded, only the first one confirms it
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	vs := k.SetValsetRequest(ctx)
+	k.SetValsetConfirm(ctx, types.MsgValsetConfirm{
+		Nonce:        vs.Nonce,
+		Orchestrator: AccAddrs[0].String(),
+		EthAddress:   EthAddrs[0].String(),
+		Signature:    "dummysig",
+	})
+
+	// the valset is slashed once the height is past nonce + SignedValsetsWindow
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + int64(params.SignedValsetsWindow) - 2)
+	liveness := k.GetOrchestratorLiveness(ctx, 2)
+	require.Len(t, liveness, len(ValAddrs))
+
+	for _, l := range liveness {
+		if l.ValidatorAddress == ValAddrs[0].String() {
+			assert.Empty(t, l.MissedValsetNonces)
+			assert.Equal(t, vs.Height, l.LastValsetConfirmHeight)
+			assert.Equal(t, params.SignedValsetsWindow-2, l.BlocksSinceLastValsetConfirm)
+			assert.Equal(t, uint64(0), l.BlocksUntilSlash)
+			assert.False(t, l.JailRisk)
+			continue
+		}
+		assert.Equal(t, []uint64{vs.Nonce}, l.MissedValsetNonces)
+		assert.Equal(t, uint64(0), l.LastValsetConfirmHeight)
+		assert.Equal(t, uint64(3), l.BlocksUntilSlash)
+		assert.False(t, l.JailRisk)
+	}
+
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	for _, l := range k.GetOrchestratorLiveness(ctx, 2) {
+		assert.Equal(t, l.ValidatorAddress != ValAddrs[0].String(), l.JailRisk)
+	}
+}
+
+func TestCheckpointQueries(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	c := sdk.WrapSDKContext(ctx)
+	// the gold hashes below are taken from the types tests and are computed by the bridge contract with peggy id foo
+	k.SetGravityID(ctx, "foo")
+
+	valset := types.NewValset(0xc, 0xc, types.BridgeValidators{{
+		Power:           0xffffffff,
+		EthereumAddress: gethcommon.Address{0xb4, 0x62, 0x86, 0x4e, 0x39, 0x5d, 0x88, 0xd6, 0xbc, 0x7c, 0x5d, 0xd5, 0xf3, 0xf5, 0xeb, 0x4c, 0xc2, 0x59, 0x92, 0x55}.String(),
+	}})
+	k.StoreValsetUnsafe(ctx, valset)
+	res, err := k.ValsetCheckpoint(c, &types.QueryValsetCheckpointRequest{Nonce: 0xc})
+	require.NoError(t, err)
+	assert.Equal(t, "f024ab7404464494d3919e5a7f0d8ac40804fb9bd39ad5d16cdb3e66aa219b64", hex.EncodeToString(res.Checkpoint))
+	assert.Equal(t, "foo", res.GravityId)
+	_, err = k.ValsetCheckpoint(c, &types.QueryValsetCheckpointRequest{Nonce: 0xd})
+	require.True(t, types.ErrUnknown.Is(err))
+
+	senderAddr, err := sdk.AccAddressFromHex("527FBEE652609AB150F0AEE9D61A2F76CFC4A73E")
+	require.NoError(t, err)
+	erc20Addr := "0x835973768750b3ED2D5c3EF5AdcD5eDb44d12aD4"
+	k.StoreBatchUnsafe(ctx, &types.OutgoingTxBatch{
+		BatchNonce:   1,
+		BatchTimeout: 2111,
+		Transactions: []*types.OutgoingTransferTx{{
+			Id:          0x1,
+			Sender:      senderAddr.String(),
+			DestAddress: "0x9FC9C2DfBA3b6cF204C37a5F690619772b926e39",
+			Erc20Token:  types.NewERC20Token(1, erc20Addr),
+			Erc20Fee:    types.NewERC20Token(1, erc20Addr),
+		}},
+		TokenContract: erc20Addr,
+	})
+	res, err = k.BatchCheckpoint(c, &types.QueryBatchCheckpointRequest{TokenContract: erc20Addr, Nonce: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "a3a7ee0a363b8ad2514e7ee8f110d7449c0d88f3b0913c28c1751e6e0079a9b2", hex.EncodeToString(res.Checkpoint))
+	_, err = k.BatchCheckpoint(c, &types.QueryBatchCheckpointRequest{TokenContract: erc20Addr, Nonce: 2})
+	require.True(t, types.ErrUnknown.Is(err))
+
+	payload, err := hex.DecodeString("74657374696e675061796c6f6164000000000000000000000000000000000000")
+	require.NoError(t, err)
+	invalidationID, err := hex.DecodeString("696e76616c69646174696f6e4964000000000000000000000000000000000000")
+	require.NoError(t, err)
+	token := []*types.ERC20Token{types.NewERC20Token(1, "0xC26eFfa98B8A2632141562Ae7E34953Cfe5B4888")}
+	k.SetOutgoingLogicCall(ctx, &types.OutgoingLogicCall{
+		Transfers:            token,
+		Fees:                 token,
+		LogicContractAddress: "0x17c1736CcF692F653c433d7aa2aB45148C016F68",
+		Payload:              payload,
+		Timeout:              4766922941000,
+		InvalidationId:       invalidationID,
+		InvalidationNonce:    1,
+	})
+	res, err = k.LogicCallCheckpoint(c, &types.QueryLogicCallCheckpointRequest{InvalidationId: invalidationID, InvalidationNonce: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "1de95c9ace999f8ec70c6dc8d045942da2612950567c4861aca959c0650194da", hex.EncodeToString(res.Checkpoint))
+	_, err = k.LogicCallCheckpoint(c, &types.QueryLogicCallCheckpointRequest{InvalidationId: invalidationID, InvalidationNonce: 2})
+	require.True(t, types.ErrUnknown.Is(err))
+}
+
+func TestPendingWork(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	c := sdk.WrapSDKContext(ctx)
+	var (
+		orchestrator = AccAddrs[0]
+		erc20Addr    = "0x835973768750b3ED2D5c3EF5AdcD5eDb44d12aD4"
+	)
+
+	for nonce := uint64(1); nonce <= 2; nonce++ {
+		k.StoreValsetUnsafe(ctx, types.NewValset(nonce, nonce, types.BridgeValidators{}))
+		k.StoreBatchUnsafe(ctx, &types.OutgoingTxBatch{BatchNonce: nonce, TokenContract: erc20Addr})
+		k.SetOutgoingLogicCall(ctx, &types.OutgoingLogicCall{InvalidationId: []byte("invalidationId"), InvalidationNonce: nonce})
+	}
+
+	// the orchestrator signed the first of each
+	k.SetValsetConfirm(ctx, types.MsgValsetConfirm{Nonce: 1, Orchestrator: orchestrator.String()})
+	k.SetBatchConfirm(ctx, &types.MsgConfirmBatch{Nonce: 1, TokenContract: erc20Addr, Orchestrator: orchestrator.String()})
+	k.SetLogicCallConfirm(ctx, &types.MsgConfirmLogicCall{
+		InvalidationId:    hex.EncodeToString([]byte("invalidationId")),
+		InvalidationNonce: 1,
+		Orchestrator:      orchestrator.String(),
+	})
+
+	res, err := k.PendingWork(c, &types.QueryPendingWorkRequest{Orchestrator: orchestrator.String()})
+	require.NoError(t, err)
+	require.Len(t, res.Valsets, 1)
+	assert.Equal(t, uint64(2), res.Valsets[0].Nonce)
+	require.Len(t, res.Batches, 1)
+	assert.Equal(t, uint64(2), res.Batches[0].BatchNonce)
+	require.Len(t, res.Calls, 1)
+	assert.Equal(t, uint64(2), res.Calls[0].InvalidationNonce)
+
+	// the combined query agrees with the single item queries
+	valsets, err := k.LastPendingValsetRequestByAddr(c, &types.QueryLastPendingValsetRequestByAddrRequest{Address: orchestrator.String()})
+	require.NoError(t, err)
+	assert.Equal(t, res.Valsets, valsets.Valsets)
+	batch, err := k.LastPendingBatchRequestByAddr(c, &types.QueryLastPendingBatchRequestByAddrRequest{Address: orchestrator.String()})
+	require.NoError(t, err)
+	assert.Equal(t, res.Batches[0], batch.Batch)
+	call, err := k.LastPendingLogicCallByAddr(c, &types.QueryLastPendingLogicCallByAddrRequest{Address: orchestrator.String()})
+	require.NoError(t, err)
+	assert.Equal(t, res.Calls[0], call.Call)
+
+	// another orchestrator still has to sign everything
+	res, err = k.PendingWork(c, &types.QueryPendingWorkRequest{Orchestrator: AccAddrs[1].String()})
+	require.NoError(t, err)
+	assert.Len(t, res.Valsets, 2)
+	assert.Len(t, res.Batches, 2)
+	assert.Len(t, res.Calls, 2)
+
+	_, err = k.PendingWork(c, &types.QueryPendingWorkRequest{Orchestrator: "invalid"})
+	require.Error(t, err)
+}
+
+func TestPendingWorkGraceBlocks(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context.WithBlockHeight(100)
+	k := input.PeggyKeeper
+	params := k.GetParams(ctx)
+	params.SignedValsetsWindow = 10
+	params.SignedBatchesWindow = 20
+	params.ConfirmGracePeriod = 5
+	k.SetParams(ctx, params)
+
+	k.StoreValsetUnsafe(ctx, types.NewValset(80, 80, types.BridgeValidators{}))
+	k.StoreValsetUnsafe(ctx, types.NewValset(95, 95, types.BridgeValidators{}))
+	k.StoreBatchUnsafe(ctx, &types.OutgoingTxBatch{BatchNonce: 1, Block: 90, TokenContract: "0x835973768750b3ED2D5c3EF5AdcD5eDb44d12aD4"})
+
+	res, err := k.PendingWork(sdk.WrapSDKContext(ctx), &types.QueryPendingWorkRequest{Orchestrator: AccAddrs[0].String()})
+	require.NoError(t, err)
+	// the missing confirms of the first valset were slashed already, a confirm of the second one still
+	// counts in block 111 and one of the batch in block 116
+	assert.Equal(t, []uint64{0, 11}, res.ValsetGraceBlocks)
+	assert.Equal(t, []uint64{16}, res.BatchGraceBlocks)
+}
+
+func TestLastPendingValsetRequestOrder(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	c := sdk.WrapSDKContext(ctx)
+	orchestrator := AccAddrs[0]
+
+	for nonce := uint64(1); nonce <= 5; nonce++ {
+		k.StoreValsetUnsafe(ctx, types.NewValset(nonce, nonce, types.BridgeValidators{}))
+	}
+	k.SetValsetConfirm(ctx, types.MsgValsetConfirm{Nonce: 2, Orchestrator: orchestrator.String()})
+
+	nonces := func(valsets []*types.Valset) (out []uint64) {
+		for _, v := range valsets {
+			out = append(out, v.Nonce)
+		}
+		return
+	}
+
+	// the oldest unsigned valsets come first so they are signed in sequence
+	res, err := k.LastPendingValsetRequestByAddr(c, &types.QueryLastPendingValsetRequestByAddrRequest{Address: orchestrator.String()})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 3, 4, 5}, nonces(res.Valsets))
+
+	res, err = k.LastPendingValsetRequestByAddr(c, &types.QueryLastPendingValsetRequestByAddrRequest{Address: orchestrator.String(), Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 3}, nonces(res.Valsets))
+}
+
+func TestConfirmsByOrchestrator(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	c := sdk.WrapSDKContext(ctx)
+	var (
+		orchestrator = AccAddrs[0]
+		other        = AccAddrs[1]
+		erc20Addr    = "0x835973768750b3ED2D5c3EF5AdcD5eDb44d12aD4"
+		invalidation = hex.EncodeToString([]byte("invalidationId"))
+	)
+	for nonce := uint64(1); nonce <= 3; nonce++ {
+		for _, orch := range []sdk.AccAddress{orchestrator, other} {
+			k.SetValsetConfirm(ctx, types.MsgValsetConfirm{Nonce: nonce, Orchestrator: orch.String()})
+			k.SetBatchConfirm(ctx, &types.MsgConfirmBatch{Nonce: nonce, TokenContract: erc20Addr, Orchestrator: orch.String()})
+			k.SetLogicCallConfirm(ctx, &types.MsgConfirmLogicCall{InvalidationId: invalidation, InvalidationNonce: nonce, Orchestrator: orch.String()})
+		}
+	}
+
+	res, err := k.ConfirmsByOrchestrator(c, &types.QueryConfirmsByOrchestratorRequest{Address: orchestrator.String()})
+	require.NoError(t, err)
+	assert.Len(t, res.ValsetConfirms, 3)
+	assert.Len(t, res.BatchConfirms, 3)
+	assert.Len(t, res.LogicCallConfirms, 3)
+	for _, confirm := range res.BatchConfirms {
+		assert.Equal(t, orchestrator.String(), confirm.Orchestrator)
+	}
+
+	// only the confirms from the nonce on are returned
+	res, err = k.ConfirmsByOrchestrator(c, &types.QueryConfirmsByOrchestratorRequest{Address: orchestrator.String(), FromNonce: 2})
+	require.NoError(t, err)
+	require.Len(t, res.ValsetConfirms, 2)
+	assert.Equal(t, uint64(2), res.ValsetConfirms[0].Nonce)
+	require.Len(t, res.BatchConfirms, 2)
+	assert.Equal(t, uint64(2), res.BatchConfirms[0].Nonce)
+	require.Len(t, res.LogicCallConfirms, 2)
+	assert.Equal(t, uint64(2), res.LogicCallConfirms[0].InvalidationNonce)
+
+	// an orchestrator that never confirmed gets nothing
+	res, err = k.ConfirmsByOrchestrator(c, &types.QueryConfirmsByOrchestratorRequest{Address: AccAddrs[2].String()})
+	require.NoError(t, err)
+	assert.Empty(t, res.ValsetConfirms)
+	assert.Empty(t, res.BatchConfirms)
+	assert.Empty(t, res.LogicCallConfirms)
+
+	_, err = k.ConfirmsByOrchestrator(c, &types.QueryConfirmsByOrchestratorRequest{Address: "invalid"})
+	require.Error(t, err)
+}
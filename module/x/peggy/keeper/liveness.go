@@ -0,0 +1,114 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// GetOrchestratorLiveness reports, for every bonded validator, the valsets and batches in the
+// current slashing window its orchestrator has not yet confirmed and how many blocks remain
+// until the oldest of those is slashed by the EndBlocker. A validator is flagged as being at
+// jail risk when it has missed something and the slash is at most warningWindow blocks away.
+//
+// The rules used here mirror ValsetSlashing and BatchSlashing, validators that joined after a
+// valset or batch was created are not expected to confirm it.
+func (k Keeper) GetOrchestratorLiveness(ctx sdk.Context, warningWindow uint64) []types.OrchestratorLiveness {
+	params := k.GetParams(ctx)
+	height := uint64(ctx.BlockHeight())
+
+	orchestrators := make(map[string]string)
+	for _, key := range k.GetDelegateKeys(ctx) {
+		orchestrators[key.Validator] = key.Orchestrator
+	}
+
+	// everything that has not been slashed yet, including the current block
+	valsets := k.GetUnSlashedValsets(ctx, height+1)
+	batches := k.GetUnSlashedBatches(ctx, height+1)
+	allValsets := k.GetValsets(ctx)
+
+	var out []types.OrchestratorLiveness
+	for _, val := range k.StakingKeeper.GetBondedValidatorsByPower(ctx) {
+		ethAddress := k.GetEthAddress(ctx, val.GetOperator())
+		liveness := types.OrchestratorLiveness{
+			ValidatorAddress:    val.GetOperator().String(),
+			OrchestratorAddress: orchestrators[val.GetOperator().String()],
+			EthereumAddress:     ethAddress,
+		}
+
+		startHeight := int64(-1)
+		consAddr, _ := val.GetConsAddr()
+		if info, exist := k.SlashingKeeper.GetValidatorSigningInfo(ctx, consAddr); exist {
+			startHeight = info.StartHeight
+		}
+
+		var slashDeadline uint64
+		missed := func(deadline uint64) {
+			if slashDeadline == 0 || deadline < slashDeadline {
+				slashDeadline = deadline
+			}
+		}
+
+		for _, vs := range valsets {
+			if startHeight < 0 || startHeight >= int64(vs.Nonce) {
+				continue
+			}
+			found := false
+			for _, conf := range k.GetValsetConfirms(ctx, vs.Nonce) {
+				if ethAddress != "" && conf.EthAddress == ethAddress {
+					found = true
+					break
+				}
+			}
+			if !found {
+				liveness.MissedValsetNonces = append(liveness.MissedValsetNonces, vs.Nonce)
+				missed(vs.Nonce + params.SignedValsetsWindow)
+			}
+		}
+
+		for _, batch := range batches {
+			if startHeight > int64(batch.Block) {
+				continue
+			}
+			found := false
+			for _, conf := range k.GetBatchConfirmByNonceAndTokenContract(ctx, batch.BatchNonce, batch.TokenContract) {
+				confVal, _ := sdk.AccAddressFromBech32(conf.Orchestrator)
+				if k.GetOrchestratorValidator(ctx, confVal).Equals(val.GetOperator()) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				liveness.MissedBatchNonces = append(liveness.MissedBatchNonces, batch.BatchNonce)
+				missed(batch.Block + params.SignedBatchesWindow)
+			}
+		}
+
+		if ethAddress != "" {
+			for _, vs := range allValsets {
+				if vs.Height <= liveness.LastValsetConfirmHeight {
+					continue
+				}
+				for _, conf := range k.GetValsetConfirms(ctx, vs.Nonce) {
+					if conf.EthAddress == ethAddress {
+						liveness.LastValsetConfirmHeight = vs.Height
+						break
+					}
+				}
+			}
+		}
+		if liveness.LastValsetConfirmHeight != 0 {
+			liveness.BlocksSinceLastValsetConfirm = height - liveness.LastValsetConfirmHeight
+		}
+
+		if slashDeadline != 0 {
+			// slashing happens once the height is strictly past the deadline
+			if slashDeadline >= height {
+				liveness.BlocksUntilSlash = slashDeadline - height + 1
+			}
+			liveness.JailRisk = liveness.BlocksUntilSlash <= warningWindow
+		}
+
+		out = append(out, liveness)
+	}
+	return out
+}
@@ -0,0 +1,117 @@
+package keeper
+
+import (
+	"bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// CreateOutgoingLogicCall stores a new logic call and moves its fees from the sender to the fee collector.
+// The fees are refunded to the sender if the call times out and released once the call is executed, the
+// bridge contract pays them to the relayer on Ethereum. A call without a timeout expires TargetBatchTimeout
+// after the projected Ethereum height like a batch, a call that already expired is rejected.
+func (k Keeper) CreateOutgoingLogicCall(ctx sdk.Context, sender sdk.AccAddress, call *types.OutgoingLogicCall) error {
+	if k.hasOutgoingLogicCall(ctx, call.InvalidationId, call.InvalidationNonce) {
+		return sdkerrors.Wrapf(types.ErrDuplicate, "logic call %X/%d", call.InvalidationId, call.InvalidationNonce)
+	}
+	if err := k.defaultLogicCallTimeout(ctx, call); err != nil {
+		return err
+	}
+	if k.IsEthereumHeightPassed(ctx, call.Timeout) {
+		return sdkerrors.Wrapf(types.ErrTimeout, "logic call timeout %d already passed", call.Timeout)
+	}
+	cosmosOriginated, ethereumOriginated := k.logicCallFees(ctx, call)
+	fees := cosmosOriginated.Add(ethereumOriginated...)
+	if err := k.collectFees(ctx, sender, fees); err != nil {
+		return sdkerrors.Wrap(err, "collect fees")
+	}
+	k.recordAccountActivities(ctx, sender, types.ACCOUNT_ACTIVITY_TYPE_FEE, fees)
+	call.Sender = sender.String()
+	k.SetOutgoingLogicCall(ctx, call)
+	return nil
+}
+
+// defaultLogicCallTimeout sets the timeout of a call without one to TargetBatchTimeout after the projected
+// Ethereum height. A zero timeout is never kept, it fails while there is no Ethereum height to project from.
+func (k Keeper) defaultLogicCallTimeout(ctx sdk.Context, call *types.OutgoingLogicCall) error {
+	if call.Timeout != 0 {
+		return nil
+	}
+	call.Timeout = k.GetEthereumTimeoutHeight(ctx, k.GetParams(ctx).TargetBatchTimeout)
+	if call.Timeout == 0 {
+		return sdkerrors.Wrap(types.ErrInvalid, "no ethereum height observed to default the logic call timeout from")
+	}
+	return nil
+}
+
+// OutgoingLogicCallExecuted is run when a logic call was executed on Ethereum. The relayer was paid the
+// fees by the bridge contract, so the fees are released from the fee collector. Calls with the same
+// invalidation id and a lower nonce can no longer be executed and are canceled.
+func (k Keeper) OutgoingLogicCallExecuted(ctx sdk.Context, invalidationID []byte, invalidationNonce uint64) error {
+	if !k.hasOutgoingLogicCall(ctx, invalidationID, invalidationNonce) {
+		return sdkerrors.Wrapf(types.ErrUnknown, "logic call %X/%d", invalidationID, invalidationNonce)
+	}
+	call := k.GetOutgoingLogicCall(ctx, invalidationID, invalidationNonce)
+	if call.Sender != "" {
+		cosmosOriginated, ethereumOriginated := k.logicCallFees(ctx, call)
+		if err := k.releaseFees(ctx, cosmosOriginated, ethereumOriginated); err != nil {
+			return sdkerrors.Wrap(err, "release fees")
+		}
+	}
+	k.DeleteOutgoingLogicCall(ctx, invalidationID, invalidationNonce)
+
+	var stale []*types.OutgoingLogicCall
+	k.IterateOutgoingLogicCalls(ctx, func(_ []byte, c *types.OutgoingLogicCall) bool {
+		if bytes.Equal(c.InvalidationId, invalidationID) && c.InvalidationNonce < invalidationNonce {
+			stale = append(stale, c)
+		}
+		return false
+	})
+	for _, c := range stale {
+		if err := k.CancelOutgoingLogicCall(ctx, c.InvalidationId, c.InvalidationNonce); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// refundLogicCallFees returns the escrowed fees of a logic call to its sender
+func (k Keeper) refundLogicCallFees(ctx sdk.Context, call *types.OutgoingLogicCall) error {
+	if call.Sender == "" {
+		return nil
+	}
+	sender, err := sdk.AccAddressFromBech32(call.Sender)
+	if err != nil {
+		return sdkerrors.Wrap(err, "sender")
+	}
+	cosmosOriginated, ethereumOriginated := k.logicCallFees(ctx, call)
+	fees := cosmosOriginated.Add(ethereumOriginated...)
+	if err := k.refundFees(ctx, sender, fees); err != nil {
+		return err
+	}
+	k.recordAccountActivities(ctx, sender, types.ACCOUNT_ACTIVITY_TYPE_REFUND, fees)
+	return nil
+}
+
+// logicCallFees returns the fees of a logic call as coins, split into cosmos originated and ethereum
+// originated tokens
+func (k Keeper) logicCallFees(ctx sdk.Context, call *types.OutgoingLogicCall) (cosmosOriginated, ethereumOriginated sdk.Coins) {
+	cosmosOriginated, ethereumOriginated = sdk.NewCoins(), sdk.NewCoins()
+	for _, fee := range call.Fees {
+		isCosmosOriginated, denom := k.ERC20ToDenomLookup(ctx, fee.Contract)
+		coin := sdk.NewCoin(denom, fee.Amount)
+		if isCosmosOriginated {
+			cosmosOriginated = cosmosOriginated.Add(coin)
+		} else {
+			ethereumOriginated = ethereumOriginated.Add(coin)
+		}
+	}
+	return
+}
+
+// hasOutgoingLogicCall returns true if a logic call with the given invalidation id and nonce is stored
+func (k Keeper) hasOutgoingLogicCall(ctx sdk.Context, invalidationID []byte, invalidationNonce uint64) bool {
+	return ctx.KVStore(k.storeKey).Has(types.GetOutgoingLogicCallKey(invalidationID, invalidationNonce))
+}
@@ -0,0 +1,92 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogicCallFeeEscrow(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	var (
+		mySender, _         = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		invalidationID      = []byte("invalidationId")
+	)
+	allVouchers := sdk.Coins{types.NewERC20Token(1000, myTokenContractAddr).PeggyCoin()}
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, allVouchers))
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+	require.NoError(t, input.BankKeeper.SetBalances(ctx, mySender, allVouchers))
+	denom := allVouchers[0].Denom
+
+	newCall := func(nonce, fee uint64) *types.OutgoingLogicCall {
+		return &types.OutgoingLogicCall{
+			Fees:              []*types.ERC20Token{types.NewERC20Token(fee, myTokenContractAddr)},
+			Timeout:           1000,
+			InvalidationId:    invalidationID,
+			InvalidationNonce: nonce,
+		}
+	}
+	balance := func() int64 {
+		return input.BankKeeper.GetBalance(ctx, mySender, denom).Amount.Int64()
+	}
+
+	// fees are escrowed when the call is created
+	for nonce := uint64(1); nonce <= 3; nonce++ {
+		require.NoError(t, k.CreateOutgoingLogicCall(ctx, mySender, newCall(nonce, 100)))
+	}
+	assert.Equal(t, int64(700), balance())
+	assert.Equal(t, mySender.String(), k.GetOutgoingLogicCall(ctx, invalidationID, 1).Sender)
+	_, broken := PendingFeesInvariant(k)(ctx)
+	assert.False(t, broken)
+
+	// a call can not be created twice
+	err := k.CreateOutgoingLogicCall(ctx, mySender, newCall(1, 100))
+	assert.True(t, types.ErrDuplicate.Is(err))
+	assert.Equal(t, int64(700), balance())
+
+	// the sender can not escrow more than it holds
+	err = k.CreateOutgoingLogicCall(ctx, mySender, newCall(4, 1000))
+	require.Error(t, err)
+	assert.False(t, k.hasOutgoingLogicCall(ctx, invalidationID, 4))
+
+	// a timed out call is refunded
+	require.NoError(t, k.CancelOutgoingLogicCall(ctx, invalidationID, 1))
+	assert.Equal(t, int64(800), balance())
+
+	// execution burns the fee vouchers and cancels calls with a lower nonce
+	supply := input.BankKeeper.GetSupply(ctx).GetTotal().AmountOf(denom)
+	require.NoError(t, k.OutgoingLogicCallExecuted(ctx, invalidationID, 3))
+	assert.Equal(t, int64(900), balance())
+	assert.Equal(t, supply.SubRaw(100), input.BankKeeper.GetSupply(ctx).GetTotal().AmountOf(denom))
+	assert.Empty(t, k.GetOutgoingLogicCalls(ctx))
+	_, broken = PendingFeesInvariant(k)(ctx)
+	assert.False(t, broken)
+
+	err = k.OutgoingLogicCallExecuted(ctx, invalidationID, 3)
+	assert.True(t, types.ErrUnknown.Is(err))
+}
+
+func TestPendingFeesInvariantBroken(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	mySender, _ := sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+
+	// a call claiming escrowed fees that the module never received
+	k.SetOutgoingLogicCall(ctx, &types.OutgoingLogicCall{
+		Fees:              []*types.ERC20Token{types.NewERC20Token(100, "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5")},
+		InvalidationId:    []byte("invalidationId"),
+		InvalidationNonce: 1,
+		Sender:            mySender.String(),
+	})
+	msg, broken := PendingFeesInvariant(k)(ctx)
+	assert.True(t, broken, msg)
+}
@@ -0,0 +1,439 @@
+package keeper
+
+import (
+	"bytes"
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// GetStoreVersion returns the number of store migrations applied to the peggy store
+func (k Keeper) GetStoreVersion(ctx sdk.Context) uint64 {
+	bz := ctx.KVStore(k.storeKey).Get(types.StoreVersionKey)
+	if bz == nil {
+		return 0
+	}
+	return types.UInt64FromBytes(bz)
+}
+
+func (k Keeper) setStoreVersion(ctx sdk.Context, version uint64) {
+	ctx.KVStore(k.storeKey).Set(types.StoreVersionKey, types.UInt64Bytes(version))
+}
+
+// RunStoreMigrations migrates the peggy store to the StoreVersion of this binary. The migrations are
+// given in order, one for every store version, the ones already applied are skipped so that a chain
+// upgrades from any earlier version. The app passes the migrations since some of them touch the
+// stores of other modules.
+func (k Keeper) RunStoreMigrations(ctx sdk.Context, migrations []func(sdk.Context) error) error {
+	if len(migrations) != types.StoreVersion {
+		return sdkerrors.Wrapf(types.ErrInvalid, "%d migrations for store version %d", len(migrations), types.StoreVersion)
+	}
+	version := k.GetStoreVersion(ctx)
+	if version > types.StoreVersion {
+		return sdkerrors.Wrapf(types.ErrInvalid, "store version %d is newer than %d", version, types.StoreVersion)
+	}
+	for ; version < types.StoreVersion; version++ {
+		if err := migrations[version](ctx); err != nil {
+			return sdkerrors.Wrapf(err, "store version %d", version+1)
+		}
+		k.setStoreVersion(ctx, version+1)
+		k.logger(ctx).Info("migrated store", "version", version+1)
+	}
+	return nil
+}
+
+// MigrateLegacyTypeURLs rewrites the claims of stored attestations that were packed
+// under the legacy peggy.v1 proto package to their gravity.v1 type URLs. The raw
+// proto decoding is used since the codec can no longer resolve the legacy type URLs.
+func (k Keeper) MigrateLegacyTypeURLs(ctx sdk.Context) error {
+	store := ctx.KVStore(k.storeKey)
+	iter := store.Iterator(prefixRange(types.OracleAttestationKey))
+	defer iter.Close()
+
+	var keys, values [][]byte
+	for ; iter.Valid(); iter.Next() {
+		var att types.Attestation
+		if err := att.Unmarshal(iter.Value()); err != nil {
+			return sdkerrors.Wrapf(err, "attestation %X", iter.Key())
+		}
+		if att.Claim == nil {
+			continue
+		}
+		typeURL := types.MigrateLegacyTypeURL(att.Claim.TypeUrl)
+		if typeURL == att.Claim.TypeUrl {
+			continue
+		}
+		att.Claim.TypeUrl = typeURL
+		bz, err := att.Marshal()
+		if err != nil {
+			return sdkerrors.Wrapf(err, "attestation %X", iter.Key())
+		}
+		keys = append(keys, iter.Key())
+		values = append(values, bz)
+	}
+
+	for i := range keys {
+		store.Set(keys[i], values[i])
+	}
+	return nil
+}
+
+// MigrateFeeIndex rebuilds the fee index of the unbatched transfers, which used to hold the set of tx ids
+// per token contract and fee amount, with one key per transfer and the fee inverted. The keys of the new
+// layout are longer, they are left as they are.
+func (k Keeper) MigrateFeeIndex(ctx sdk.Context) error {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.SecondIndexOutgoingTXFeeKey)
+	var (
+		keys [][]byte
+		ids  []uint64
+	)
+	for ; iter.Valid(); iter.Next() {
+		if len(iter.Key()) != len(types.SecondIndexOutgoingTXFeeKey)+types.ETHContractAddressLen+32 {
+			continue
+		}
+		var idSet types.IDSet
+		if err := k.cdc.UnmarshalBinaryBare(iter.Value(), &idSet); err != nil {
+			iter.Close()
+			return sdkerrors.Wrapf(err, "fee index %X", iter.Key())
+		}
+		keys = append(keys, iter.Key())
+		ids = append(ids, idSet.Ids...)
+	}
+	iter.Close()
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+	for _, id := range ids {
+		tx, err := k.getPoolEntry(ctx, id)
+		if err != nil {
+			return sdkerrors.Wrapf(err, "tx %d", id)
+		}
+		k.addToUnbatchedTXIndex(ctx, tx)
+	}
+	return nil
+}
+
+// MigrateGravityIDParam moves the id salting the checkpoints from the legacy PeggyID param key
+// to the GravityID key, the value itself must not change since the contract verifies the
+// signatures with it. The param store values are amino JSON, a plain JSON string for this param.
+func (k Keeper) MigrateGravityIDParam(ctx sdk.Context) error {
+	if k.paramSpace.Has(ctx, types.ParamsStoreKeyGravityID) {
+		return nil
+	}
+	var gravityID string
+	if err := json.Unmarshal(k.paramSpace.GetRaw(ctx, types.ParamsStoreKeyLegacyPeggyID), &gravityID); err != nil {
+		return sdkerrors.Wrap(err, "legacy peggy id")
+	}
+	if gravityID == "" {
+		return sdkerrors.Wrap(types.ErrEmpty, "legacy peggy id")
+	}
+	k.paramSpace.Set(ctx, types.ParamsStoreKeyGravityID, gravityID)
+	return nil
+}
+
+// MigrateParamsToStore moves the params from the peggy subspace of the params module to the module
+// store, from then on they are only updated with MsgUpdateParams. Params added after the subspace was
+// last written start at their default value.
+func (k Keeper) MigrateParamsToStore(ctx sdk.Context) error {
+	if ctx.KVStore(k.storeKey).Has(types.ParamsKey) {
+		return nil
+	}
+	params := k.legacyParams(ctx)
+	if err := params.Validate(); err != nil {
+		return sdkerrors.Wrap(err, "legacy params")
+	}
+	k.SetParams(ctx, params)
+	return nil
+}
+
+// MigrateFeesToFeeCollector moves the fees of pending transfers and logic calls from the module account
+// to the fee collector. Fees of transfers used to be escrowed together with the amount, or burned for
+// ethereum originated tokens, those vouchers are minted again since the fee collector only burns them
+// once the transfer is executed. Logic call fees were always escrowed without burning.
+func (k Keeper) MigrateFeesToFeeCollector(ctx sdk.Context) error {
+	escrowed, burned := sdk.NewCoins(), sdk.NewCoins()
+	addTx := func(tx *types.OutgoingTransferTx) {
+		isCosmosOriginated, denom := k.ERC20ToDenomLookup(ctx, tx.Erc20Fee.Contract)
+		fee := sdk.NewCoin(denom, tx.Erc20Fee.Amount)
+		if isCosmosOriginated {
+			escrowed = escrowed.Add(fee)
+		} else {
+			burned = burned.Add(fee)
+		}
+	}
+	k.IterateOutgoingPool(ctx, func(tx *types.OutgoingTransferTx) bool {
+		addTx(tx)
+		return false
+	})
+	for _, batch := range k.GetOutgoingTxBatches(ctx) {
+		for _, tx := range batch.Transactions {
+			addTx(tx)
+		}
+	}
+	k.IterateOutgoingLogicCalls(ctx, func(_ []byte, call *types.OutgoingLogicCall) bool {
+		if call.Sender != "" {
+			cosmosOriginated, ethereumOriginated := k.logicCallFees(ctx, call)
+			escrowed = escrowed.Add(cosmosOriginated...).Add(ethereumOriginated...)
+		}
+		return false
+	})
+
+	if !burned.IsZero() {
+		if err := k.bankKeeper.MintCoins(ctx, types.ModuleName, burned); err != nil {
+			return sdkerrors.Wrap(err, "mint burned fees")
+		}
+	}
+	fees := escrowed.Add(burned...)
+	if fees.IsZero() {
+		return nil
+	}
+	return k.bankKeeper.SendCoinsFromModuleToModule(ctx, types.ModuleName, types.FeeCollectorName, fees)
+}
+
+// MigrateVoucherDenoms translates the vouchers recorded in the module state from the legacy peggy
+// denom format to the gravity denom format and registers their bank metadata. The account balances
+// and the supply are kept by the bank module and are translated by the upgrade handler.
+func (k Keeper) MigrateVoucherDenoms(ctx sdk.Context) error {
+	var legacyDenoms []types.ERC20ToDenom
+	k.IterateERC20ToDenom(ctx, func(_ []byte, erc20ToDenom *types.ERC20ToDenom) bool {
+		if types.MigrateLegacyPeggyDenom(erc20ToDenom.Denom) != erc20ToDenom.Denom {
+			legacyDenoms = append(legacyDenoms, *erc20ToDenom)
+		}
+		return false
+	})
+	store := ctx.KVStore(k.storeKey)
+	for _, erc20ToDenom := range legacyDenoms {
+		denom := types.MigrateLegacyPeggyDenom(erc20ToDenom.Denom)
+		if erc20ToDenom.Retired {
+			k.setRetiredCosmosOriginatedERC20(ctx, denom, erc20ToDenom.Erc20)
+			continue
+		}
+		store.Delete(types.GetDenomToERC20Key(erc20ToDenom.Denom))
+		k.setCosmosOriginatedDenomToERC20(ctx, denom, erc20ToDenom.Erc20)
+	}
+
+	var deposits []types.ObservedDeposit
+	k.IterateObservedDeposits(ctx, func(deposit types.ObservedDeposit) bool {
+		if denom := types.MigrateLegacyPeggyDenom(deposit.Amount.Denom); denom != deposit.Amount.Denom {
+			deposit.Amount.Denom = denom
+			deposits = append(deposits, deposit)
+		}
+		return false
+	})
+	for _, deposit := range deposits {
+		k.SetObservedDeposit(ctx, deposit)
+	}
+
+	var reclaimable []types.ReclaimableDeposit
+	k.IterateReclaimableDeposits(ctx, func(deposit types.ReclaimableDeposit) bool {
+		if denom := types.MigrateLegacyPeggyDenom(deposit.Amount.Denom); denom != deposit.Amount.Denom {
+			deposit.Amount.Denom = denom
+			reclaimable = append(reclaimable, deposit)
+		}
+		return false
+	})
+	for _, deposit := range reclaimable {
+		k.SetReclaimableDeposit(ctx, deposit)
+	}
+
+	// the supply may already be translated, the metadata is registered for vouchers in either format
+	for _, coin := range k.bankKeeper.GetSupply(ctx).GetTotal() {
+		if tokenContract, err := types.PeggyDenomToERC20(types.MigrateLegacyPeggyDenom(coin.Denom)); err == nil {
+			k.migrateVoucherMetadata(ctx, tokenContract)
+		}
+	}
+	return nil
+}
+
+// migrateVoucherMetadata registers the bank metadata of a voucher. The symbol and decimals of the metadata
+// registered for the legacy denom, its display unit and the exponent of that unit, carry over to the
+// gravity denom.
+func (k Keeper) migrateVoucherMetadata(ctx sdk.Context, tokenContract string) {
+	denom := types.PeggyDenom(tokenContract)
+	if k.bankKeeper.GetDenomMetaData(ctx, denom).Base != "" {
+		return
+	}
+	legacyDenom := types.LegacyPeggyDenomPrefix + types.PeggyDenomSeparator + tokenContract
+	legacy := k.bankKeeper.GetDenomMetaData(ctx, legacyDenom)
+	metadata := types.PeggyDenomMetadata(tokenContract)
+	if legacy.Base == "" {
+		k.bankKeeper.SetDenomMetaData(ctx, metadata)
+		return
+	}
+	if legacy.Description != "" {
+		metadata.Description = legacy.Description
+	}
+	for _, unit := range legacy.DenomUnits {
+		if unit.Denom == legacyDenom || unit.Exponent == 0 {
+			continue
+		}
+		metadata.DenomUnits = append(metadata.DenomUnits, unit)
+	}
+	if legacy.Display != legacyDenom {
+		metadata.Display = legacy.Display
+	}
+	k.bankKeeper.SetDenomMetaData(ctx, metadata)
+}
+
+// MigrateAttestationKeys moves the stored attestations to the key derived from the current claim hash,
+// attestations stored under an outdated hash would no longer receive the votes for their event. Attestations
+// whose claims only differed in fields the current hash leaves out end up under the same key, their votes
+// are merged into one attestation that keeps the claim of an observed one.
+func (k Keeper) MigrateAttestationKeys(ctx sdk.Context) error {
+	var oldKeys, newKeys [][]byte
+	merged := make(map[string]*types.Attestation)
+	var err error
+	k.IterateAttestations(ctx, func(key []byte, att types.Attestation) bool {
+		var claim types.EthereumClaim
+		if claim, err = k.UnpackAttestationClaim(&att); err != nil {
+			return true
+		}
+		newKey := types.GetAttestationKey(claim.GetEventNonce(), claim.ClaimHash())
+		if !bytes.Equal(key, newKey) {
+			oldKeys = append(oldKeys, key)
+		}
+		if into, ok := merged[string(newKey)]; ok {
+			mergeAttestation(into, &att)
+			return false
+		}
+		newKeys = append(newKeys, newKey)
+		merged[string(newKey)] = &att
+		return false
+	})
+	if err != nil {
+		return sdkerrors.Wrap(err, "unpack claim")
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	for _, key := range oldKeys {
+		store.Delete(key)
+	}
+	for _, key := range newKeys {
+		store.Set(key, k.cdc.MustMarshalBinaryBare(merged[string(key)]))
+	}
+	return nil
+}
+
+// mergeAttestation adds the votes of att to into, the claim of an observed attestation wins
+func mergeAttestation(into, att *types.Attestation) {
+	voted := make(map[string]bool, len(into.Votes))
+	for _, vote := range into.Votes {
+		voted[vote] = true
+	}
+	for _, vote := range att.Votes {
+		if !voted[vote] {
+			voted[vote] = true
+			into.Votes = append(into.Votes, vote)
+		}
+	}
+	if att.Observed && !into.Observed {
+		into.Observed = true
+		into.Claim = att.Claim
+	}
+	if att.Height < into.Height {
+		into.Height = att.Height
+	}
+}
+
+// MigrateBatchedTxIndex indexes the transfers of the batches built before the batch index was
+// added. Transfers of batches that already executed are not recoverable and stay unindexed.
+func (k Keeper) MigrateBatchedTxIndex(ctx sdk.Context) error {
+	for _, batch := range k.GetOutgoingTxBatches(ctx) {
+		k.setBatchedTxs(ctx, batch)
+	}
+	for _, batch := range k.GetCancelledBatches(ctx) {
+		batch := batch
+		k.setBatchedTxs(ctx, &batch)
+	}
+	return nil
+}
+
+// MigrateAccountActivityHeightIndex indexes the ledger entries recorded before they were pruned by height
+func (k Keeper) MigrateAccountActivityHeightIndex(ctx sdk.Context) error {
+	for _, activity := range k.GetAccountActivities(ctx) {
+		account, err := sdk.AccAddressFromBech32(activity.Account)
+		if err != nil {
+			return sdkerrors.Wrapf(err, "account activity %d", activity.Id)
+		}
+		k.setAccountActivity(ctx, account, activity)
+	}
+	return nil
+}
+
+// MigrateNativeTokenEscrow starts tracking the staking token escrowed for Ethereum at the escrow balance
+// of it, if the staking token has an ERC20, and keeps bridging the staking token on chains that bridged
+// it before the BridgeNativeToken param was added
+func (k Keeper) MigrateNativeTokenEscrow(ctx sdk.Context) error {
+	bondDenom := k.StakingKeeper.GetParams(ctx).BondDenom
+	if _, ok := k.GetCosmosOriginatedERC20(ctx, bondDenom); ok {
+		k.setNativeTokenEscrow(ctx, k.bankKeeper.GetBalance(ctx, authtypes.NewModuleAddress(types.ModuleName), bondDenom).Amount)
+	}
+	params := k.GetParams(ctx)
+	params.BridgeNativeToken = true
+	k.SetParams(ctx, params)
+	return nil
+}
+
+// MigrateScheduledTransferIndex indexes the transfers scheduled before they were released by height and
+// time, and drops the cursor of the scan that released them before
+func (k Keeper) MigrateScheduledTransferIndex(ctx sdk.Context) error {
+	for _, scheduled := range k.GetScheduledTransfers(ctx) {
+		k.indexScheduledTransfer(ctx, scheduled)
+	}
+	ctx.KVStore(k.storeKey).Delete(types.GetWorkQueueKey(workTaskReleaseScheduledTransfers))
+	return nil
+}
+
+// MigrateOutgoingTxExpirationIndex indexes the unbatched txs that expire by the height and time they expire
+// at, and drops the cursor of the scan over the pool that refunded them before
+func (k Keeper) MigrateOutgoingTxExpirationIndex(ctx sdk.Context) error {
+	for _, tx := range k.GetPoolTransactions(ctx) {
+		k.indexOutgoingTxExpiration(ctx, tx)
+	}
+	ctx.KVStore(k.storeKey).Delete(types.GetWorkQueueKey(workTaskRefundExpiredTxs))
+	return nil
+}
+
+// MigratePendingOutgoingTxFeeIndex indexes the batched and unbatched txs that were not executed yet by
+// their fee
+func (k Keeper) MigratePendingOutgoingTxFeeIndex(ctx sdk.Context) error {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.OutgoingTXPoolKey)
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		var tx types.OutgoingTransferTx
+		if err := k.cdc.UnmarshalBinaryBare(iter.Value(), &tx); err != nil {
+			iter.Close()
+			return err
+		}
+		keys = append(keys, types.GetPendingOutgoingTxByFeeKey(tx.Erc20Fee.Amount, tx.Id))
+	}
+	iter.Close()
+	for _, key := range keys {
+		store.Set(key, []byte{})
+	}
+	return nil
+}
+
+// MigrateOrchestratorIndex indexes the orchestrators registered before a validator could have several
+// of them by their validator
+func (k Keeper) MigrateOrchestratorIndex(ctx sdk.Context) error {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.KeyOrchestratorAddress)
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		orch := sdk.AccAddress(iter.Key()[len(types.KeyOrchestratorAddress):])
+		keys = append(keys, types.GetValidatorOrchestratorKey(sdk.ValAddress(iter.Value()), orch))
+	}
+	iter.Close()
+	for _, key := range keys {
+		store.Set(key, []byte{})
+	}
+	return nil
+}
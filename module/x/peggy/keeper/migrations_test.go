@@ -0,0 +1,414 @@
+package keeper
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateLegacyTypeURLs(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+
+	claim := &types.MsgDepositClaim{
+		EventNonce:     1,
+		TokenContract:  "0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e",
+		Amount:         sdk.NewInt(100),
+		EthereumSender: "0x00000000000000000000000000000000000000e1",
+		CosmosReceiver: "cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn",
+		Orchestrator:   "cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn",
+	}
+	claimBz, err := claim.Marshal()
+	require.NoError(t, err)
+	hash := claim.ClaimHash()
+
+	// write an attestation the way it was stored before the proto package rename
+	legacy := types.Attestation{
+		Height: 1,
+		Votes:  []string{"cosmosvaloper1"},
+		Claim:  &codectypes.Any{TypeUrl: "/peggy.v1.MsgDepositClaim", Value: claimBz},
+	}
+	bz, err := legacy.Marshal()
+	require.NoError(t, err)
+	ctx.KVStore(k.storeKey).Set(types.GetAttestationKey(claim.EventNonce, hash), bz)
+
+	require.NoError(t, k.MigrateLegacyTypeURLs(ctx))
+
+	att := k.GetAttestation(ctx, claim.EventNonce, hash)
+	require.NotNil(t, att)
+	assert.Equal(t, "/gravity.v1.MsgDepositClaim", att.Claim.TypeUrl)
+	got, err := k.UnpackAttestationClaim(att)
+	require.NoError(t, err)
+	assert.Equal(t, claim, got)
+
+	// running the migration again is a no-op
+	migrated := ctx.KVStore(k.storeKey).Get(types.GetAttestationKey(claim.EventNonce, hash))
+	require.NoError(t, k.MigrateLegacyTypeURLs(ctx))
+	assert.Equal(t, migrated, ctx.KVStore(k.storeKey).Get(types.GetAttestationKey(claim.EventNonce, hash)))
+}
+
+func TestMigrateFeeIndex(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	tokenContract := TokenContractAddrs[0]
+
+	// transfers 1 and 2 with the same fee share a key of the old layout, 3 is indexed already
+	store := ctx.KVStore(k.storeKey)
+	for id, fee := range map[uint64]int64{1: 5, 2: 5, 3: 7} {
+		tx := &types.OutgoingTransferTx{
+			Id:         id,
+			Erc20Token: types.NewERC20Token(100, tokenContract),
+			Erc20Fee:   types.NewERC20Token(uint64(fee), tokenContract),
+		}
+		require.NoError(t, k.setPoolEntry(ctx, tx))
+	}
+	indexed, err := k.getPoolEntry(ctx, 3)
+	require.NoError(t, err)
+	k.addToUnbatchedTXIndex(ctx, indexed)
+	legacyKey := append(append([]byte{}, types.SecondIndexOutgoingTXFeeKey...), tokenContract...)
+	legacyKey = append(legacyKey, sdk.NewInt(5).BigInt().FillBytes(make([]byte, 32))...)
+	store.Set(legacyKey, k.cdc.MustMarshalBinaryBare(&types.IDSet{Ids: []uint64{1, 2}}))
+
+	require.NoError(t, k.MigrateFeeIndex(ctx))
+	assert.False(t, store.Has(legacyKey))
+	var ids []uint64
+	k.IterateOutgoingPoolByFee(ctx, tokenContract, func(id uint64, _ *types.OutgoingTransferTx) bool {
+		ids = append(ids, id)
+		return false
+	})
+	assert.Equal(t, []uint64{3, 1, 2}, ids)
+}
+
+func TestMigrateGravityIDParam(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	setLegacyParams(input)
+
+	// store the id under the legacy key the way the params subspace did
+	store := prefix.NewStore(ctx.KVStore(input.ParamsKey), []byte(types.DefaultParamspace+"/"))
+	store.Delete(types.ParamsStoreKeyGravityID)
+	store.Set(types.ParamsStoreKeyLegacyPeggyID, []byte(`"mylegacyid"`))
+
+	require.NoError(t, k.MigrateGravityIDParam(ctx))
+	assert.Equal(t, "mylegacyid", k.GetGravityID(ctx))
+	require.NoError(t, k.GetParams(ctx).Validate())
+
+	// running it again doesn't change anything
+	k.SetGravityID(ctx, "othergravityid")
+	require.NoError(t, k.MigrateGravityIDParam(ctx))
+	assert.Equal(t, "othergravityid", k.GetGravityID(ctx))
+}
+
+// setLegacyParams moves the params of the test env back to the params subspace where they were kept
+// before MigrateParamsToStore
+func setLegacyParams(input TestInput) {
+	params := input.PeggyKeeper.GetParams(input.Context)
+	input.PeggyKeeper.paramSpace.SetParamSet(input.Context, &params)
+	input.Context.KVStore(input.PeggyKeeper.storeKey).Delete(types.ParamsKey)
+}
+
+func TestMigrateParamsToStore(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	setLegacyParams(input)
+
+	// params added after the subspace was last written are missing from it and take their defaults
+	added := [][]byte{
+		types.ParamsStoreKeyEthereumHeightWindow,
+		types.ParamsStoreKeyTokenAllowlist,
+		types.ParamsStoreKeyTokenDenylist,
+		types.ParamsStoreKeyBridgeFeeBasisPoints,
+		types.ParamsStoreKeyBatchRequestMinFee,
+		types.ParamsStoreKeyBatchRequestCooldown,
+		types.ParamsStoreKeyEthereumBlockConfirmations,
+		types.ParamsStoreKeyAttestationTimeout,
+		types.ParamsStoreKeySignatureScheme,
+		types.ParamsStoreKeyRestrictVoucherTransfers,
+		types.ParamsStoreKeyEndBlockerWorkBudget,
+		types.ParamsStoreKeyRelayerAllowlist,
+		types.ParamsStoreKeyRelayerAllowlistBatchRequests,
+		types.ParamsStoreKeyBatchCancelGracePeriod,
+		types.ParamsStoreKeyHexCosmosReceivers,
+		types.ParamsStoreKeyResidueSweepInterval,
+		types.ParamsStoreKeyTargetBatchGas,
+		types.ParamsStoreKeyBatchBaseGas,
+		types.ParamsStoreKeyBatchTxGas,
+		types.ParamsStoreKeyTokenBatchGas,
+		types.ParamsStoreKeySlashedFundsRelayerShare,
+		types.ParamsStoreKeyMaxValsetSize,
+		types.ParamsStoreKeyMinValsetPower,
+		types.ParamsStoreKeyConfirmGracePeriod,
+		types.ParamsStoreKeyMaxOrchestratorsPerValidator,
+		types.ParamsStoreKeyERC20InitCodeHash,
+		types.ParamsStoreKeyConfirmFeeExemptGas,
+		types.ParamsStoreKeyBridgeNativeToken,
+		types.ParamsStoreKeyNativeTokenBridgeCap,
+		types.ParamsStoreKeyObservedNonceLagThreshold,
+		types.ParamsStoreKeyConfirmFeeExemptTxs,
+		types.ParamsStoreKeyAccountActivityRetention,
+	}
+	store := prefix.NewStore(ctx.KVStore(input.ParamsKey), []byte(types.DefaultParamspace+"/"))
+	expected, defaults := TestingPeggyParams, types.DefaultParams()
+	defaultPairs := defaults.ParamSetPairs()
+	deleted := 0
+	for i, pair := range expected.ParamSetPairs() {
+		for _, key := range added {
+			if bytes.Equal(pair.Key, key) {
+				store.Delete(key)
+				reflect.ValueOf(pair.Value).Elem().Set(reflect.ValueOf(defaultPairs[i].Value).Elem())
+				deleted++
+			}
+		}
+	}
+	require.Equal(t, len(added), deleted)
+	require.NoError(t, expected.Validate())
+
+	// until the migration the params are read from the subspace
+	assert.Equal(t, expected, k.GetParams(ctx))
+	require.NoError(t, k.MigrateParamsToStore(ctx))
+	assert.True(t, ctx.KVStore(k.storeKey).Has(types.ParamsKey))
+	assert.Equal(t, expected, k.GetParams(ctx))
+
+	// later changes to the subspace are ignored and running the migration again is a no-op
+	store.Set(types.ParamsStoreKeyBridgeFeeBasisPoints, []byte(`"500"`))
+	require.NoError(t, k.MigrateParamsToStore(ctx))
+	assert.Equal(t, expected, k.GetParams(ctx))
+}
+
+func TestMigrateFeesToFeeCollector(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	var (
+		mySender, _         = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		denom               = types.PeggyDenom(myTokenContractAddr)
+	)
+
+	// a transfer whose fee was burned together with the amount
+	tx := &types.OutgoingTransferTx{
+		Id:          1,
+		Sender:      mySender.String(),
+		DestAddress: "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7",
+		Erc20Token:  types.NewERC20Token(100, myTokenContractAddr),
+		Erc20Fee:    types.NewERC20Token(3, myTokenContractAddr),
+	}
+	require.NoError(t, k.setPoolEntry(ctx, tx))
+	k.addToUnbatchedTXIndex(ctx, tx)
+	// a logic call whose fee was escrowed in the module account
+	k.SetOutgoingLogicCall(ctx, &types.OutgoingLogicCall{
+		Fees:              []*types.ERC20Token{types.NewERC20Token(7, myTokenContractAddr)},
+		InvalidationId:    []byte("invalidationId"),
+		InvalidationNonce: 1,
+		Sender:            mySender.String(),
+	})
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, sdk.NewCoins(sdk.NewInt64Coin(denom, 7))))
+	_, broken := PendingFeesInvariant(k)(ctx)
+	require.True(t, broken)
+
+	require.NoError(t, k.MigrateFeesToFeeCollector(ctx))
+
+	_, broken = PendingFeesInvariant(k)(ctx)
+	assert.False(t, broken)
+	feeCollector := input.AccountKeeper.GetModuleAddress(types.FeeCollectorName)
+	assert.Equal(t, sdk.NewInt64Coin(denom, 10), input.BankKeeper.GetBalance(ctx, feeCollector, denom))
+	escrow := input.AccountKeeper.GetModuleAddress(types.ModuleName)
+	assert.True(t, input.BankKeeper.GetBalance(ctx, escrow, denom).IsZero())
+}
+
+func TestMigrateVoucherDenoms(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	var (
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		legacyDenom         = types.LegacyPeggyDenomPrefix + myTokenContractAddr
+		legacyCoin          = sdk.NewInt64Coin(legacyDenom, 10)
+	)
+	k.SetObservedDeposit(ctx, types.ObservedDeposit{EventNonce: 1, TokenContract: myTokenContractAddr, EthereumSender: "0x00000000000000000000000000000000000000e1", Amount: legacyCoin})
+	k.SetReclaimableDeposit(ctx, types.ReclaimableDeposit{EventNonce: 2, TokenContract: myTokenContractAddr, Amount: legacyCoin})
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, sdk.Coins{legacyCoin}))
+	// the legacy voucher had metadata with its symbol and decimals
+	input.BankKeeper.SetDenomMetaData(ctx, banktypes.Metadata{
+		Description: "Tether USD",
+		DenomUnits:  []*banktypes.DenomUnit{{Denom: legacyDenom}, {Denom: "usdt", Exponent: 6, Aliases: []string{"USDT"}}},
+		Base:        legacyDenom,
+		Display:     "usdt",
+	})
+	// a transfer sent to Ethereum before the migration burned the legacy vouchers
+	pooled := &types.OutgoingTransferTx{
+		Id:          1,
+		Sender:      AccAddrs[0].String(),
+		DestAddress: "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7",
+		Erc20Token:  types.NewERC20Token(10, myTokenContractAddr),
+		Erc20Fee:    types.NewERC20Token(0, myTokenContractAddr),
+	}
+	require.NoError(t, k.setPoolEntry(ctx, pooled))
+	k.addToUnbatchedTXIndex(ctx, pooled)
+
+	require.NoError(t, k.MigrateVoucherDenoms(ctx))
+
+	denom := types.PeggyDenom(myTokenContractAddr)
+	assert.Equal(t, denom, k.GetObservedDeposits(ctx)[0].Amount.Denom)
+	assert.Equal(t, denom, k.GetReclaimableDeposit(ctx, 2).Amount.Denom)
+	assert.Equal(t, banktypes.Metadata{
+		Description: "Tether USD",
+		DenomUnits:  []*banktypes.DenomUnit{{Denom: denom}, {Denom: "usdt", Exponent: 6, Aliases: []string{"USDT"}}},
+		Base:        denom,
+		Display:     "usdt",
+	}, input.BankKeeper.GetDenomMetaData(ctx, denom))
+
+	// pool entries and batches hold the ERC20 contract, they are refunded in the gravity denom
+	require.NoError(t, k.RemoveFromOutgoingPoolAndRefund(ctx, pooled.Id, AccAddrs[0]))
+	assert.Equal(t, sdk.NewInt(10), input.BankKeeper.GetBalance(ctx, AccAddrs[0], denom).Amount)
+
+	// a voucher without legacy metadata gets the plain voucher metadata
+	otherContract := "0x0bc529c00C6401aEF6D220BE8C6Ea1667F6Ad93e"
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, sdk.Coins{sdk.NewInt64Coin(types.LegacyPeggyDenomPrefix+otherContract, 1)}))
+	require.NoError(t, k.MigrateVoucherDenoms(ctx))
+	assert.Equal(t, types.PeggyDenomMetadata(otherContract), input.BankKeeper.GetDenomMetaData(ctx, types.PeggyDenom(otherContract)))
+}
+
+func TestMigrateAttestationKeys(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+
+	claim := &types.MsgDepositClaim{
+		EventNonce:     1,
+		TokenContract:  "0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e",
+		Amount:         sdk.NewInt(100),
+		EthereumSender: "0x00000000000000000000000000000000000000e1",
+		CosmosReceiver: "cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn",
+		Orchestrator:   "cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn",
+	}
+	any, err := codectypes.NewAnyWithValue(claim)
+	require.NoError(t, err)
+	att := &types.Attestation{Height: 1, Votes: []string{"cosmosvaloper1"}, Claim: any}
+
+	// store the attestation under a key derived with the old claim hash
+	legacyHash := []byte("legacy claim hash")
+	k.SetAttestation(ctx, claim.EventNonce, legacyHash, att)
+
+	require.NoError(t, k.MigrateAttestationKeys(ctx))
+	assert.Nil(t, k.GetAttestation(ctx, claim.EventNonce, legacyHash))
+	got := k.GetAttestation(ctx, claim.EventNonce, claim.ClaimHash())
+	require.NotNil(t, got)
+	assert.Equal(t, att.Votes, got.Votes)
+
+	// running the migration again is a no-op
+	require.NoError(t, k.MigrateAttestationKeys(ctx))
+	assert.NotNil(t, k.GetAttestation(ctx, claim.EventNonce, claim.ClaimHash()))
+
+	// withdraw claims that only differ in the eth tx hash were attested apart, their votes are merged
+	withdraw := &types.MsgWithdrawClaim{
+		EventNonce:    2,
+		BatchNonce:    1,
+		TokenContract: "0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e",
+		EthTxHash:     "0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060",
+	}
+	reported, err := codectypes.NewAnyWithValue(withdraw)
+	require.NoError(t, err)
+	unreportedClaim := *withdraw
+	unreportedClaim.EthTxHash = ""
+	unreported, err := codectypes.NewAnyWithValue(&unreportedClaim)
+	require.NoError(t, err)
+	k.SetAttestation(ctx, 2, []byte("hash with tx hash"), &types.Attestation{Height: 3, Votes: []string{"cosmosvaloper1", "cosmosvaloper2"}, Claim: unreported})
+	k.SetAttestation(ctx, 2, []byte("hash without tx hash"), &types.Attestation{Observed: true, Height: 2, Votes: []string{"cosmosvaloper2", "cosmosvaloper3"}, Claim: reported})
+
+	require.NoError(t, k.MigrateAttestationKeys(ctx))
+	assert.Nil(t, k.GetAttestation(ctx, 2, []byte("hash with tx hash")))
+	assert.Nil(t, k.GetAttestation(ctx, 2, []byte("hash without tx hash")))
+	got = k.GetAttestation(ctx, 2, withdraw.ClaimHash())
+	require.NotNil(t, got)
+	assert.True(t, got.Observed)
+	assert.Equal(t, uint64(2), got.Height)
+	assert.ElementsMatch(t, []string{"cosmosvaloper1", "cosmosvaloper2", "cosmosvaloper3"}, got.Votes)
+	gotClaim, err := k.UnpackAttestationClaim(got)
+	require.NoError(t, err)
+	assert.Equal(t, withdraw.EthTxHash, gotClaim.(*types.MsgWithdrawClaim).EthTxHash)
+}
+
+func TestMigrateOrchestratorIndex(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+
+	// orchestrators registered before the index only have the orchestrator key
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetOrchestratorAddressKey(AccAddrs[0]), ValAddrs[0])
+	store.Set(types.GetOrchestratorAddressKey(AccAddrs[1]), ValAddrs[1])
+	assert.Empty(t, k.GetValidatorOrchestrators(ctx, ValAddrs[0]))
+
+	require.NoError(t, k.MigrateOrchestratorIndex(ctx))
+	assert.Equal(t, []sdk.AccAddress{AccAddrs[0]}, k.GetValidatorOrchestrators(ctx, ValAddrs[0]))
+	assert.Equal(t, []sdk.AccAddress{AccAddrs[1]}, k.GetValidatorOrchestrators(ctx, ValAddrs[1]))
+}
+
+func TestRunStoreMigrations(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+
+	var ran, all []int
+	migrations := make([]func(sdk.Context) error, types.StoreVersion)
+	for i := range migrations {
+		i := i
+		all = append(all, i+1)
+		migrations[i] = func(sdk.Context) error {
+			ran = append(ran, i+1)
+			return nil
+		}
+	}
+
+	// a store written before the versioning runs all migrations, in order and once
+	assert.Equal(t, uint64(0), k.GetStoreVersion(ctx))
+	require.NoError(t, k.RunStoreMigrations(ctx, migrations))
+	assert.Equal(t, all, ran)
+	ran = nil
+	require.NoError(t, k.RunStoreMigrations(ctx, migrations))
+	assert.Empty(t, ran)
+
+	// a chain started from genesis is at the store version of the binary
+	restarted := CreateTestEnv(t)
+	InitGenesis(restarted.Context, restarted.PeggyKeeper, ExportGenesis(ctx, k))
+	assert.Equal(t, uint64(types.StoreVersion), restarted.PeggyKeeper.GetStoreVersion(restarted.Context))
+
+	// a chain upgrades from any earlier version, a failing migration keeps the version
+	k.setStoreVersion(ctx, types.StoreVersion-2)
+	failing := append([]func(sdk.Context) error{}, migrations...)
+	failing[types.StoreVersion-1] = func(sdk.Context) error { return types.ErrInvalid }
+	assert.True(t, types.ErrInvalid.Is(k.RunStoreMigrations(ctx, failing)))
+	assert.Equal(t, uint64(types.StoreVersion-1), k.GetStoreVersion(ctx))
+	ran = nil
+	k.setStoreVersion(ctx, types.StoreVersion-2)
+	require.NoError(t, k.RunStoreMigrations(ctx, migrations))
+	assert.Equal(t, []int{types.StoreVersion - 1, types.StoreVersion}, ran)
+	assert.Equal(t, uint64(types.StoreVersion), k.GetStoreVersion(ctx))
+
+	// the migrations must match the store version
+	assert.Error(t, k.RunStoreMigrations(ctx, migrations[1:]))
+	k.setStoreVersion(ctx, types.StoreVersion+1)
+	assert.Error(t, k.RunStoreMigrations(ctx, migrations))
+}
@@ -0,0 +1,158 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// The bridge funds are split over three module accounts so each can be audited on its own:
+//  - types.ModuleName escrows the cosmos originated coins backing their ERC20 representation on
+//    Ethereum and is the only account minting and burning ethereum originated vouchers
+//  - types.FeeCollectorName holds the fees of pending transfers and logic calls
+//  - types.RelayerRewardPoolName holds the funds for rewards paid to relayers on Cosmos
+// The methods in this file are the only ones moving coins in and out of these accounts.
+
+// lockOutgoing takes an amount leaving for Ethereum from the sender, cosmos originated coins are
+// escrowed and ethereum originated vouchers are burned
+func (k Keeper) lockOutgoing(ctx sdk.Context, sender sdk.AccAddress, amount sdk.Coin, isCosmosOriginated bool) error {
+	if !amount.IsPositive() {
+		return nil
+	}
+	coins := sdk.Coins{amount}
+	if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, sender, types.ModuleName, coins); err != nil {
+		return err
+	}
+	if isCosmosOriginated {
+		k.addNativeTokenEscrow(ctx, coins)
+		return nil
+	}
+	if err := k.bankKeeper.BurnCoins(ctx, types.ModuleName, coins); err != nil {
+		return sdkerrors.Wrap(err, "burn vouchers")
+	}
+	return nil
+}
+
+// unlockOutgoing gives an amount that did not leave for Ethereum back to the receiver, cosmos
+// originated coins are taken from the escrow and ethereum originated vouchers are minted again
+func (k Keeper) unlockOutgoing(ctx sdk.Context, receiver sdk.AccAddress, amount sdk.Coin, isCosmosOriginated bool) error {
+	if !amount.IsPositive() {
+		return nil
+	}
+	coins := sdk.Coins{amount}
+	if !isCosmosOriginated {
+		if err := k.bankKeeper.MintCoins(ctx, types.ModuleName, coins); err != nil {
+			return sdkerrors.Wrapf(err, "mint vouchers coins: %s", coins)
+		}
+	} else {
+		k.subNativeTokenEscrow(ctx, coins)
+	}
+	return k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, receiver, coins)
+}
+
+// collectFees moves the fees of a transfer or logic call from the sender to the fee collector
+func (k Keeper) collectFees(ctx sdk.Context, sender sdk.AccAddress, fees sdk.Coins) error {
+	if fees.IsZero() {
+		return nil
+	}
+	return k.bankKeeper.SendCoinsFromAccountToModule(ctx, sender, types.FeeCollectorName, fees)
+}
+
+// refundFees gives the fees of a transfer or logic call that will not be executed back from the fee collector
+func (k Keeper) refundFees(ctx sdk.Context, receiver sdk.AccAddress, fees sdk.Coins) error {
+	if fees.IsZero() {
+		return nil
+	}
+	return k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.FeeCollectorName, receiver, fees)
+}
+
+// releaseFees settles the fees of a transfer or logic call executed on Ethereum where the bridge contract
+// paid them to the relayer. The ERC20s of cosmos originated fees left the contract so the coins move to the
+// escrow to back them, ethereum originated vouchers are burned.
+func (k Keeper) releaseFees(ctx sdk.Context, cosmosOriginated, ethereumOriginated sdk.Coins) error {
+	if !cosmosOriginated.IsZero() {
+		if err := k.bankKeeper.SendCoinsFromModuleToModule(ctx, types.FeeCollectorName, types.ModuleName, cosmosOriginated); err != nil {
+			return sdkerrors.Wrap(err, "escrow fees")
+		}
+		k.addNativeTokenEscrow(ctx, cosmosOriginated)
+	}
+	if !ethereumOriginated.IsZero() {
+		if err := k.bankKeeper.BurnCoins(ctx, types.FeeCollectorName, ethereumOriginated); err != nil {
+			return sdkerrors.Wrap(err, "burn fees")
+		}
+	}
+	return nil
+}
+
+// FundRelayerRewardPool moves coins from the depositor into the relayer reward pool
+func (k Keeper) FundRelayerRewardPool(ctx sdk.Context, depositor sdk.AccAddress, amount sdk.Coins) error {
+	if !amount.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, amount.String())
+	}
+	return k.bankKeeper.SendCoinsFromAccountToModule(ctx, depositor, types.RelayerRewardPoolName, amount)
+}
+
+// fundRelayerRewardPoolFromSlash mints the share of slashed tokens paid to the relayers into the
+// relayer reward pool, the staking keeper burned them before
+func (k Keeper) fundRelayerRewardPoolFromSlash(ctx sdk.Context, amount sdk.Coin) error {
+	coins := sdk.Coins{amount}
+	if err := k.bankKeeper.MintCoins(ctx, types.ModuleName, coins); err != nil {
+		return sdkerrors.Wrap(err, "mint slashed funds")
+	}
+	return k.bankKeeper.SendCoinsFromModuleToModule(ctx, types.ModuleName, types.RelayerRewardPoolName, coins)
+}
+
+// PayRelayerReward pays a relayer from the relayer reward pool, with the RelayerAllowlist param set only
+// registered relayers can be paid
+func (k Keeper) PayRelayerReward(ctx sdk.Context, relayer sdk.AccAddress, amount sdk.Coins) error {
+	if !amount.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, amount.String())
+	}
+	if !k.IsRelayerAllowed(ctx, relayer) {
+		return sdkerrors.Wrap(types.ErrRelayerNotRegistered, relayer.String())
+	}
+	return k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.RelayerRewardPoolName, relayer, amount)
+}
+
+// GetRelayerRewardPool returns the funds available for relayer rewards
+func (k Keeper) GetRelayerRewardPool(ctx sdk.Context) sdk.Coins {
+	return k.bankKeeper.GetAllBalances(ctx, authtypes.NewModuleAddress(types.RelayerRewardPoolName))
+}
+
+// GetNativeTokenEscrow returns the amount of the staking token escrowed for Ethereum. It is tracked apart
+// from the balance of the escrow, which also holds coins sent to the module account by other means.
+func (k Keeper) GetNativeTokenEscrow(ctx sdk.Context) sdk.Int {
+	bz := ctx.KVStore(k.storeKey).Get(types.NativeTokenEscrowKey)
+	if bz == nil {
+		return sdk.ZeroInt()
+	}
+	var escrow sdk.Int
+	if err := escrow.Unmarshal(bz); err != nil {
+		panic(err)
+	}
+	return escrow
+}
+
+func (k Keeper) setNativeTokenEscrow(ctx sdk.Context, escrow sdk.Int) {
+	bz, err := escrow.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	ctx.KVStore(k.storeKey).Set(types.NativeTokenEscrowKey, bz)
+}
+
+// addNativeTokenEscrow adds the staking token among the coins entering the escrow to the tracked escrow
+func (k Keeper) addNativeTokenEscrow(ctx sdk.Context, coins sdk.Coins) {
+	if amount := coins.AmountOf(k.StakingKeeper.GetParams(ctx).BondDenom); amount.IsPositive() {
+		k.setNativeTokenEscrow(ctx, k.GetNativeTokenEscrow(ctx).Add(amount))
+	}
+}
+
+// subNativeTokenEscrow takes the staking token among the coins leaving the escrow from the tracked escrow,
+// coins escrowed before it was tracked can take it below zero, it stops at zero
+func (k Keeper) subNativeTokenEscrow(ctx sdk.Context, coins sdk.Coins) {
+	if amount := coins.AmountOf(k.StakingKeeper.GetParams(ctx).BondDenom); amount.IsPositive() {
+		k.setNativeTokenEscrow(ctx, sdk.MaxInt(k.GetNativeTokenEscrow(ctx).Sub(amount), sdk.ZeroInt()))
+	}
+}
@@ -0,0 +1,36 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelayerRewardPool(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	var (
+		depositor, _ = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		relayer, _   = sdk.AccAddressFromBech32("cosmos16ahjkfqxpp6lvfy9fpfnfjg39xr96qett0alj5")
+		funds        = sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+	)
+	input.AccountKeeper.NewAccountWithAddress(ctx, depositor)
+	require.NoError(t, input.BankKeeper.SetBalances(ctx, depositor, funds))
+
+	require.NoError(t, k.FundRelayerRewardPool(ctx, depositor, funds))
+	assert.Equal(t, funds, k.GetRelayerRewardPool(ctx))
+	assert.True(t, input.BankKeeper.GetAllBalances(ctx, depositor).IsZero())
+
+	reward := sdk.NewCoins(sdk.NewInt64Coin("stake", 40))
+	require.NoError(t, k.PayRelayerReward(ctx, relayer, reward))
+	assert.Equal(t, reward, input.BankKeeper.GetAllBalances(ctx, relayer))
+	assert.Equal(t, funds.Sub(reward), k.GetRelayerRewardPool(ctx))
+
+	// the pool can't pay more than it holds
+	assert.Error(t, k.PayRelayerReward(ctx, relayer, funds))
+	assert.Error(t, k.PayRelayerReward(ctx, relayer, sdk.Coins{sdk.Coin{Denom: "stake", Amount: sdk.NewInt(-1)}}))
+}
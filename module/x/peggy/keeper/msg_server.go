@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"strings"
 
+	"github.com/cosmos/cosmos-sdk/codec"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
@@ -13,13 +15,13 @@ import (
 )
 
 type msgServer struct {
-	Keeper
+	PeggyKeeper
 }
 
 // NewMsgServerImpl returns an implementation of the gov MsgServer interface
 // for the provided Keeper.
-func NewMsgServerImpl(keeper Keeper) types.MsgServer {
-	return &msgServer{Keeper: keeper}
+func NewMsgServerImpl(keeper PeggyKeeper) types.MsgServer {
+	return &msgServer{PeggyKeeper: keeper}
 }
 
 var _ types.MsgServer = msgServer{}
@@ -36,7 +38,7 @@ func (k msgServer) SetOrchestratorAddress(c context.Context, msg *types.MsgSetOr
 	orch, _ := sdk.AccAddressFromBech32(msg.Orchestrator)
 
 	// ensure that the validator exists
-	if k.Keeper.StakingKeeper.Validator(ctx, val) == nil {
+	if k.Validator(ctx, val) == nil {
 		return nil, sdkerrors.Wrap(stakingtypes.ErrNoValidatorFound, val.String())
 	}
 
@@ -44,6 +46,17 @@ func (k msgServer) SetOrchestratorAddress(c context.Context, msg *types.MsgSetOr
 	// addresses since no signatures from the private keys of these addresses
 	// are required for this message it could be sent in a hostile way.
 
+	// an orchestrator acts for a single validator, it has to be revoked before another one can use it
+	if owner := k.GetOrchestratorValidator(ctx, orch); !owner.Empty() && !owner.Equals(val) {
+		return nil, sdkerrors.Wrapf(types.ErrDuplicate, "orchestrator %s is registered by validator %s", orch, owner)
+	}
+	if k.GetOrchestratorValidator(ctx, orch).Empty() {
+		max := k.GetParams(ctx).OrchestratorsPerValidator()
+		if uint64(len(k.GetValidatorOrchestrators(ctx, val))) >= max {
+			return nil, sdkerrors.Wrapf(types.ErrInvalid, "validator %s has %d orchestrators, revoke one first", val, max)
+		}
+	}
+
 	// set the orchestrator address
 	k.SetOrchestratorValidator(ctx, val, orch)
 	// set the ethereum address
@@ -61,6 +74,104 @@ func (k msgServer) SetOrchestratorAddress(c context.Context, msg *types.MsgSetOr
 
 }
 
+// RevokeOrchestratorAddress handles MsgRevokeOrchestratorAddress
+func (k msgServer) RevokeOrchestratorAddress(c context.Context, msg *types.MsgRevokeOrchestratorAddress) (*types.MsgRevokeOrchestratorAddressResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	val, _ := sdk.ValAddressFromBech32(msg.Validator)
+	orch, _ := sdk.AccAddressFromBech32(msg.Orchestrator)
+
+	if !k.GetOrchestratorValidator(ctx, orch).Equals(val) {
+		return nil, sdkerrors.Wrapf(types.ErrUnknown, "orchestrator %s of validator %s", orch, val)
+	}
+	// a validator without orchestrator could neither confirm nor claim
+	if len(k.GetValidatorOrchestrators(ctx, val)) <= 1 {
+		return nil, sdkerrors.Wrapf(types.ErrInvalid, "orchestrator %s is the last one of validator %s", orch, val)
+	}
+	k.DeleteOrchestratorValidator(ctx, orch)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeOrchestratorRevoked,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyValidator, val.String()),
+		sdk.NewAttribute(types.AttributeKeyOrchestrator, orch.String()),
+	))
+
+	return &types.MsgRevokeOrchestratorAddressResponse{}, nil
+}
+
+// activeOrchestratorValidator returns the validator the orchestrator acts for, it fails unless the
+// validator is bonded and not jailed so stale orchestrators can't pollute confirms and attestations
+func (k msgServer) activeOrchestratorValidator(ctx sdk.Context, orchestrator sdk.AccAddress) (sdk.ValAddress, error) {
+	validator := k.GetOrchestratorValidator(ctx, orchestrator)
+	if validator == nil {
+		return nil, sdkerrors.Wrap(types.ErrUnknown, "validator")
+	}
+	val := k.Validator(ctx, validator)
+	if val == nil || !val.IsBonded() || val.IsJailed() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrorInvalidSigner, "validator not in active set")
+	}
+	return validator, nil
+}
+
+// valsetConfirmValidator returns the validator the orchestrator confirms a valset for. Besides the active
+// validators, an unbonding validator is still slashed for the valsets it misses until UnbondSlashingValsetsWindow
+// after it started unbonding, so it may confirm those, jailed or not.
+func (k msgServer) valsetConfirmValidator(ctx sdk.Context, orchestrator sdk.AccAddress, nonce uint64) (sdk.ValAddress, error) {
+	validator := k.GetOrchestratorValidator(ctx, orchestrator)
+	if validator == nil {
+		return nil, sdkerrors.Wrap(types.ErrUnknown, "validator")
+	}
+	if k.IsUnbondingValsetSigner(ctx, validator, nonce) {
+		return validator, nil
+	}
+	return k.activeOrchestratorValidator(ctx, orchestrator)
+}
+
+// registeredEthSigner returns the Ethereum address registered for the validator, failing unless it is the
+// signer named in the confirm. The signer is what relayers use to match the signature to the validator
+// set, so it has to be the registered key the signature is checked against. The case of the signer may
+// differ, the confirm is stored with the registered address the slashing compares with.
+func (k msgServer) registeredEthSigner(ctx sdk.Context, validator sdk.ValAddress, signer string) (string, error) {
+	ethAddress := k.GetEthAddress(ctx, validator)
+	if ethAddress == "" {
+		return "", sdkerrors.Wrap(types.ErrEmpty, "eth address")
+	}
+	if !strings.EqualFold(signer, ethAddress) {
+		return "", sdkerrors.Wrapf(types.ErrInvalid, "signer %s does not match registered eth address %s", signer, ethAddress)
+	}
+	return ethAddress, nil
+}
+
+// verifyCheckpointSignature checks the signature over the checkpoint was made by the Ethereum address
+// in the signature scheme of the params
+func (k msgServer) verifyCheckpointSignature(ctx sdk.Context, checkpoint, signature []byte, ethAddress string) error {
+	decoder, err := types.NewSignatureDecoder(k.GetParams(ctx))
+	if err != nil {
+		return err
+	}
+	return types.VerifyCheckpointSignature(decoder, checkpoint, signature, ethAddress)
+}
+
+// checkClaimBridge fails unless the claim was observed on the bridge contract and Ethereum chain
+// configured in the params
+func (k msgServer) checkClaimBridge(ctx sdk.Context, claim types.EthereumClaim) error {
+	params := k.GetParams(ctx)
+	if params.BridgeEthereumAddress == "" {
+		return sdkerrors.Wrap(types.ErrInvalid, "bridge contract address not set")
+	}
+	if !strings.EqualFold(claim.GetBridgeContractAddress(), params.BridgeEthereumAddress) {
+		return sdkerrors.Wrapf(types.ErrInvalid, "bridge contract address %s does not match %s", claim.GetBridgeContractAddress(), params.BridgeEthereumAddress)
+	}
+	if claim.GetBridgeChainId() != params.BridgeChainId {
+		return sdkerrors.Wrapf(types.ErrInvalid, "bridge chain id %d does not match %d", claim.GetBridgeChainId(), params.BridgeChainId)
+	}
+	return nil
+}
+
 // ValsetConfirm handles MsgValsetConfirm
 // TODO: check msgValsetConfirm to have an Orchestrator field instead of a Validator field
 func (k msgServer) ValsetConfirm(c context.Context, msg *types.MsgValsetConfirm) (*types.MsgValsetConfirmResponse, error) {
@@ -70,8 +181,8 @@ func (k msgServer) ValsetConfirm(c context.Context, msg *types.MsgValsetConfirm)
 		return nil, sdkerrors.Wrap(types.ErrInvalid, "couldn't find valset")
 	}
 
-	peggyID := k.GetPeggyID(ctx)
-	checkpoint := valset.GetCheckpoint(peggyID)
+	gravityID := k.GetGravityID(ctx)
+	checkpoint := valset.GetCheckpoint(gravityID)
 
 	sigBytes, err := hex.DecodeString(msg.Signature)
 	if err != nil {
@@ -79,24 +190,27 @@ func (k msgServer) ValsetConfirm(c context.Context, msg *types.MsgValsetConfirm)
 	}
 
 	orchaddr, _ := sdk.AccAddressFromBech32(msg.Orchestrator)
-	validator := k.GetOrchestratorValidator(ctx, orchaddr)
-	if validator == nil {
-		return nil, sdkerrors.Wrap(types.ErrUnknown, "validator")
+	validator, err := k.valsetConfirmValidator(ctx, orchaddr, msg.Nonce)
+	if err != nil {
+		return nil, err
 	}
 
-	ethAddress := k.GetEthAddress(ctx, validator)
-	if ethAddress == "" {
-		return nil, sdkerrors.Wrap(types.ErrEmpty, "eth address")
+	ethAddress, err := k.registeredEthSigner(ctx, validator, msg.EthAddress)
+	if err != nil {
+		return nil, err
 	}
 
-	if err = types.ValidateEthereumSignature(checkpoint, sigBytes, ethAddress); err != nil {
-		return nil, sdkerrors.Wrap(types.ErrInvalid, fmt.Sprintf("signature verification failed expected sig by %s with peggy-id %s with checkpoint %s found %s", ethAddress, peggyID, hex.EncodeToString(checkpoint), msg.Signature))
+	if err = k.verifyCheckpointSignature(ctx, checkpoint, sigBytes, ethAddress); err != nil {
+		return nil, sdkerrors.Wrap(types.ErrInvalid, fmt.Sprintf("signature verification failed expected sig by %s with gravity-id %s with checkpoint %s found %s", ethAddress, gravityID, hex.EncodeToString(checkpoint), msg.Signature))
 	}
 
-	// persist signature
-	if k.GetValsetConfirm(ctx, msg.Nonce, orchaddr) != nil {
-		return nil, sdkerrors.Wrap(types.ErrDuplicate, "signature duplicate")
+	// persist signature, a validator confirms once whichever of its orchestrators sent the confirm
+	for _, orch := range k.GetValidatorOrchestrators(ctx, validator) {
+		if k.GetValsetConfirm(ctx, msg.Nonce, orch) != nil {
+			return nil, sdkerrors.Wrap(types.ErrDuplicate, "signature duplicate")
+		}
 	}
+	msg.EthAddress = ethAddress
 	key := k.SetValsetConfirm(ctx, *msg)
 
 	ctx.EventManager().EmitEvent(
@@ -117,10 +231,20 @@ func (k msgServer) SendToEth(c context.Context, msg *types.MsgSendToEth) (*types
 	if err != nil {
 		return nil, err
 	}
-	txID, err := k.AddToOutgoingPool(ctx, sender, msg.EthDest, msg.Amount, msg.BridgeFee)
+	var txID uint64
+	if msg.ExecuteAfterHeight != 0 || msg.ExecuteAfterTime != 0 {
+		txID, err = k.ScheduleOutgoingTx(ctx, sender, msg.EthDest, msg.Amount, msg.BridgeFee, msg.ExecuteAfterHeight, msg.ExecuteAfterTime)
+	} else {
+		txID, err = k.AddToOutgoingPool(ctx, sender, msg.EthDest, msg.Amount, msg.BridgeFee)
+	}
 	if err != nil {
 		return nil, err
 	}
+	if msg.ExpirationHeight != 0 || msg.ExpirationTime != 0 {
+		if err := k.SetOutgoingTxExpiration(ctx, txID, msg.ExpirationHeight, msg.ExpirationTime); err != nil {
+			return nil, err
+		}
+	}
 
 	ctx.EventManager().EmitEvent(
 		sdk.NewEvent(
@@ -133,10 +257,50 @@ func (k msgServer) SendToEth(c context.Context, msg *types.MsgSendToEth) (*types
 	return &types.MsgSendToEthResponse{}, nil
 }
 
-// RequestBatch handles MsgRequestBatch
+// MultiSendToEth adds every transfer of the message to the outgoing pool, an error in any of them
+// fails the message so that none is added
+func (k msgServer) MultiSendToEth(c context.Context, msg *types.MsgMultiSendToEth) (*types.MsgMultiSendToEthResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+	sender, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, err
+	}
+	// the transfers are added on a cache of the store which is only written once all of them succeeded
+	xCtx, commit := ctx.CacheContext()
+	txIDs := make([]uint64, len(msg.Sends))
+	for i, send := range msg.Sends {
+		if txIDs[i], err = k.AddToOutgoingPool(xCtx, sender, send.EthDest, send.Amount, send.BridgeFee); err != nil {
+			return nil, sdkerrors.Wrapf(err, "transfer %d", i)
+		}
+	}
+	commit()
+
+	for _, txID := range txIDs {
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				sdk.EventTypeMessage,
+				sdk.NewAttribute(sdk.AttributeKeyModule, msg.Type()),
+				sdk.NewAttribute(types.AttributeKeyOutgoingTXID, fmt.Sprint(txID)),
+			),
+		)
+	}
+
+	return &types.MsgMultiSendToEthResponse{TransactionIds: txIDs}, nil
+}
+
+// RequestBatch handles MsgRequestBatch, it can be sent by any account
 func (k msgServer) RequestBatch(c context.Context, msg *types.MsgRequestBatch) (*types.MsgRequestBatchResponse, error) {
 	ctx := sdk.UnwrapSDKContext(c)
 
+	requester, err := sdk.AccAddressFromBech32(msg.Orchestrator)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "requester")
+	}
+	// with the allowlist restricting batch requests only registered relayers may request one
+	if k.GetParams(ctx).RelayerAllowlistBatchRequests && !k.IsRelayerAllowed(ctx, requester) {
+		return nil, sdkerrors.Wrap(types.ErrRelayerNotRegistered, msg.Orchestrator)
+	}
+
 	// Check if the denom is a peggy coin, if not, check if there is a deployed ERC20 representing it.
 	// If not, error out
 	_, tokenContract, err := k.DenomToERC20Lookup(ctx, msg.Denom)
@@ -144,32 +308,16 @@ func (k msgServer) RequestBatch(c context.Context, msg *types.MsgRequestBatch) (
 		return nil, err
 	}
 
-	batchID, err := k.BuildOutgoingTXBatch(ctx, tokenContract, OutgoingTxBatchSize)
+	batch, err := k.RequestOutgoingTXBatch(ctx, tokenContract, requester)
 	if err != nil {
 		return nil, err
 	}
 
-	// question: is this right? If i can delegate my voting power to a different key then this would fail each time i call it
-	valaddr, _ := sdk.AccAddressFromBech32(msg.Orchestrator)
-	validator := k.GetOrchestratorValidator(ctx, valaddr)
-
-	// a validator request can be sent from a delegate key or a validator
-	// key directly
-	if validator == nil {
-		sval := k.StakingKeeper.Validator(ctx, sdk.ValAddress(valaddr))
-		if sval == nil {
-			return nil, sdkerrors.Wrap(types.ErrUnknown, "validator")
-		}
-	}
-
-	// TODO later make sure that Demon matches a list of tokens already
-	// in the bridge to send
-
 	ctx.EventManager().EmitEvent(
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
 			sdk.NewAttribute(sdk.AttributeKeyModule, msg.Type()),
-			sdk.NewAttribute(types.AttributeKeyBatchNonce, fmt.Sprint(batchID.BatchNonce)),
+			sdk.NewAttribute(types.AttributeKeyBatchNonce, fmt.Sprint(batch.BatchNonce)),
 		),
 	)
 
@@ -186,8 +334,8 @@ func (k msgServer) ConfirmBatch(c context.Context, msg *types.MsgConfirmBatch) (
 		return nil, sdkerrors.Wrap(types.ErrInvalid, "couldn't find batch")
 	}
 
-	peggyID := k.GetPeggyID(ctx)
-	checkpoint, err := batch.GetCheckpoint(peggyID)
+	gravityID := k.GetGravityID(ctx)
+	checkpoint, err := batch.GetCheckpoint(gravityID)
 	if err != nil {
 		return nil, sdkerrors.Wrap(types.ErrInvalid, "checkpoint generation")
 	}
@@ -198,34 +346,55 @@ func (k msgServer) ConfirmBatch(c context.Context, msg *types.MsgConfirmBatch) (
 	}
 
 	orchaddr, _ := sdk.AccAddressFromBech32(msg.Orchestrator)
-	validator := k.GetOrchestratorValidator(ctx, orchaddr)
-	if validator == nil {
-		return nil, sdkerrors.Wrap(types.ErrUnknown, "validator")
+	validator, err := k.activeOrchestratorValidator(ctx, orchaddr)
+	if err != nil {
+		return nil, err
 	}
 
-	ethAddress := k.GetEthAddress(ctx, validator)
-	if ethAddress == "" {
-		return nil, sdkerrors.Wrap(types.ErrEmpty, "eth address")
+	// a confirm is never replaced, not even by a confirm with another valid signature or
+	// from another orchestrator of the validator
+	for _, orch := range k.GetValidatorOrchestrators(ctx, validator) {
+		if k.GetBatchConfirm(ctx, msg.Nonce, msg.TokenContract, orch) != nil {
+			return nil, sdkerrors.Wrapf(types.ErrDuplicateBatchConfirm, "orchestrator %s already confirmed batch %d of %s", orch, msg.Nonce, msg.TokenContract)
+		}
 	}
 
-	err = types.ValidateEthereumSignature(checkpoint, sigBytes, ethAddress)
+	ethAddress, err := k.registeredEthSigner(ctx, validator, msg.EthSigner)
 	if err != nil {
-		return nil, sdkerrors.Wrap(types.ErrInvalid, fmt.Sprintf("signature verification failed expected sig by %s with peggy-id %s with checkpoint %s found %s", ethAddress, peggyID, hex.EncodeToString(checkpoint), msg.Signature))
+		return nil, err
 	}
 
-	// check if we already have this confirm
-	if k.GetBatchConfirm(ctx, msg.Nonce, msg.TokenContract, orchaddr) != nil {
-		return nil, sdkerrors.Wrap(types.ErrDuplicate, "duplicate signature")
+	err = k.verifyCheckpointSignature(ctx, checkpoint, sigBytes, ethAddress)
+	if err != nil {
+		return nil, sdkerrors.Wrap(types.ErrInvalid, fmt.Sprintf("signature verification failed expected sig by %s with gravity-id %s with checkpoint %s found %s", ethAddress, gravityID, hex.EncodeToString(checkpoint), msg.Signature))
 	}
+
+	msg.EthSigner = ethAddress
 	key := k.SetBatchConfirm(ctx, msg)
 
-	ctx.EventManager().EmitEvent(
+	var power uint64
+	for _, member := range k.GetCurrentValset(ctx).Members {
+		if strings.EqualFold(member.EthereumAddress, ethAddress) {
+			power = member.Power
+		}
+	}
+	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
 			sdk.NewAttribute(sdk.AttributeKeyModule, msg.Type()),
 			sdk.NewAttribute(types.AttributeKeyBatchConfirmKey, string(key)),
 		),
-	)
+		// the normalized power of the signer, out of a total of 2^32, lets indexers tell when
+		// a batch reached the signing threshold
+		sdk.NewEvent(
+			types.EventTypeBatchConfirm,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(types.AttributeKeyTokenContract, msg.TokenContract),
+			sdk.NewAttribute(types.AttributeKeyBatchNonce, fmt.Sprint(msg.Nonce)),
+			sdk.NewAttribute(types.AttributeKeyValidator, validator.String()),
+			sdk.NewAttribute(types.AttributeKeyPower, fmt.Sprint(power)),
+		),
+	})
 
 	return nil, nil
 }
@@ -238,14 +407,14 @@ func (k msgServer) ConfirmLogicCall(c context.Context, msg *types.MsgConfirmLogi
 		return nil, sdkerrors.Wrap(types.ErrInvalid, "invalidation id encoding")
 	}
 
-	// fetch the outgoing logic given the nonce
+	// fetch the outgoing logic given the nonce, an empty call is returned when none is stored
 	logic := k.GetOutgoingLogicCall(ctx, invalidationIdBytes, msg.InvalidationNonce)
-	if logic == nil {
+	if len(logic.InvalidationId) == 0 {
 		return nil, sdkerrors.Wrap(types.ErrInvalid, "couldn't find logic")
 	}
 
-	peggyID := k.GetPeggyID(ctx)
-	checkpoint, err := logic.GetCheckpoint(peggyID)
+	gravityID := k.GetGravityID(ctx)
+	checkpoint, err := logic.GetCheckpoint(gravityID)
 	if err != nil {
 		return nil, sdkerrors.Wrap(types.ErrInvalid, "checkpoint generation")
 	}
@@ -256,26 +425,29 @@ func (k msgServer) ConfirmLogicCall(c context.Context, msg *types.MsgConfirmLogi
 	}
 
 	orchaddr, _ := sdk.AccAddressFromBech32(msg.Orchestrator)
-	validator := k.GetOrchestratorValidator(ctx, orchaddr)
-	if validator == nil {
-		return nil, sdkerrors.Wrap(types.ErrUnknown, "validator")
+	validator, err := k.activeOrchestratorValidator(ctx, orchaddr)
+	if err != nil {
+		return nil, err
 	}
 
-	ethAddress := k.GetEthAddress(ctx, validator)
-	if ethAddress == "" {
-		return nil, sdkerrors.Wrap(types.ErrEmpty, "eth address")
+	ethAddress, err := k.registeredEthSigner(ctx, validator, msg.EthSigner)
+	if err != nil {
+		return nil, err
 	}
 
-	err = types.ValidateEthereumSignature(checkpoint, sigBytes, ethAddress)
+	err = k.verifyCheckpointSignature(ctx, checkpoint, sigBytes, ethAddress)
 	if err != nil {
-		return nil, sdkerrors.Wrap(types.ErrInvalid, fmt.Sprintf("signature verification failed expected sig by %s with peggy-id %s with checkpoint %s found %s", ethAddress, peggyID, hex.EncodeToString(checkpoint), msg.Signature))
+		return nil, sdkerrors.Wrap(types.ErrInvalid, fmt.Sprintf("signature verification failed expected sig by %s with gravity-id %s with checkpoint %s found %s", ethAddress, gravityID, hex.EncodeToString(checkpoint), msg.Signature))
 	}
 
-	// check if we already have this confirm
-	if k.GetLogicCallConfirm(ctx, invalidationIdBytes, msg.InvalidationNonce, orchaddr) != nil {
-		return nil, sdkerrors.Wrap(types.ErrDuplicate, "duplicate signature")
+	// check if we already have this confirm from any orchestrator of the validator
+	for _, orch := range k.GetValidatorOrchestrators(ctx, validator) {
+		if k.GetLogicCallConfirm(ctx, invalidationIdBytes, msg.InvalidationNonce, orch) != nil {
+			return nil, sdkerrors.Wrap(types.ErrDuplicate, "duplicate signature")
+		}
 	}
 
+	msg.EthSigner = ethAddress
 	k.SetLogicCallConfirm(ctx, msg)
 
 	ctx.EventManager().EmitEvent(
@@ -296,15 +468,11 @@ func (k msgServer) DepositClaim(c context.Context, msg *types.MsgDepositClaim) (
 	ctx := sdk.UnwrapSDKContext(c)
 
 	orchaddr, _ := sdk.AccAddressFromBech32(msg.Orchestrator)
-	validator := k.GetOrchestratorValidator(ctx, orchaddr)
-	if validator == nil {
-		return nil, sdkerrors.Wrap(types.ErrUnknown, "validator")
+	if _, err := k.activeOrchestratorValidator(ctx, orchaddr); err != nil {
+		return nil, err
 	}
-
-	// return an error if the validator isn't in the active set
-	val := k.StakingKeeper.Validator(ctx, validator)
-	if val == nil || !val.IsBonded() {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrorInvalidSigner, "validator not in active set")
+	if err := k.checkClaimBridge(ctx, msg); err != nil {
+		return nil, err
 	}
 
 	any, err := codectypes.NewAnyWithValue(msg)
@@ -339,15 +507,11 @@ func (k msgServer) WithdrawClaim(c context.Context, msg *types.MsgWithdrawClaim)
 	ctx := sdk.UnwrapSDKContext(c)
 
 	orchaddr, _ := sdk.AccAddressFromBech32(msg.Orchestrator)
-	validator := k.GetOrchestratorValidator(ctx, orchaddr)
-	if validator == nil {
-		return nil, sdkerrors.Wrap(types.ErrUnknown, "validator")
+	if _, err := k.activeOrchestratorValidator(ctx, orchaddr); err != nil {
+		return nil, err
 	}
-
-	// return an error if the validator isn't in the active set
-	val := k.StakingKeeper.Validator(ctx, validator)
-	if val == nil || !val.IsBonded() {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrorInvalidSigner, "validator not in acitve set")
+	if err := k.checkClaimBridge(ctx, msg); err != nil {
+		return nil, err
 	}
 
 	any, err := codectypes.NewAnyWithValue(msg)
@@ -379,15 +543,11 @@ func (k msgServer) ERC20DeployedClaim(c context.Context, msg *types.MsgERC20Depl
 	ctx := sdk.UnwrapSDKContext(c)
 
 	orchaddr, _ := sdk.AccAddressFromBech32(msg.Orchestrator)
-	validator := k.GetOrchestratorValidator(ctx, orchaddr)
-	if validator == nil {
-		return nil, sdkerrors.Wrap(types.ErrUnknown, "validator")
+	if _, err := k.activeOrchestratorValidator(ctx, orchaddr); err != nil {
+		return nil, err
 	}
-
-	// return an error if the validator isn't in the active set
-	val := k.StakingKeeper.Validator(ctx, validator)
-	if val == nil || !val.IsBonded() {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrorInvalidSigner, "validator not in acitve set")
+	if err := k.checkClaimBridge(ctx, msg); err != nil {
+		return nil, err
 	}
 
 	any, err := codectypes.NewAnyWithValue(msg)
@@ -419,15 +579,11 @@ func (k msgServer) LogicCallExecutedClaim(c context.Context, msg *types.MsgLogic
 	ctx := sdk.UnwrapSDKContext(c)
 
 	orchaddr, _ := sdk.AccAddressFromBech32(msg.Orchestrator)
-	validator := k.GetOrchestratorValidator(ctx, orchaddr)
-	if validator == nil {
-		return nil, sdkerrors.Wrap(types.ErrUnknown, "validator")
+	if _, err := k.activeOrchestratorValidator(ctx, orchaddr); err != nil {
+		return nil, err
 	}
-
-	// return an error if the validator isn't in the active set
-	val := k.StakingKeeper.Validator(ctx, validator)
-	if val == nil || !val.IsBonded() {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrorInvalidSigner, "validator not in acitve set")
+	if err := k.checkClaimBridge(ctx, msg); err != nil {
+		return nil, err
 	}
 
 	any, err := codectypes.NewAnyWithValue(msg)
@@ -454,13 +610,88 @@ func (k msgServer) LogicCallExecutedClaim(c context.Context, msg *types.MsgLogic
 	return &types.MsgLogicCallExecutedClaimResponse{}, nil
 }
 
+// GenericEventClaim handles claims for arbitrary events emitted by Ethereum contracts
+func (k msgServer) GenericEventClaim(c context.Context, msg *types.MsgGenericEventClaim) (*types.MsgGenericEventClaimResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+
+	orchaddr, _ := sdk.AccAddressFromBech32(msg.Orchestrator)
+	if _, err := k.activeOrchestratorValidator(ctx, orchaddr); err != nil {
+		return nil, err
+	}
+	if err := k.checkClaimBridge(ctx, msg); err != nil {
+		return nil, err
+	}
+
+	any, err := codectypes.NewAnyWithValue(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add the claim to the store
+	_, err = k.Attest(ctx, msg, any)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "create attestation")
+	}
+
+	// Emit the handle message event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, msg.Type()),
+			sdk.NewAttribute(types.AttributeKeyAttestationID, string(types.GetAttestationKey(msg.EventNonce, msg.ClaimHash()))),
+		),
+	)
+
+	return &types.MsgGenericEventClaimResponse{}, nil
+}
+
+// ValsetUpdatedClaim handles claims for the bridge contract switching to a new valset
+func (k msgServer) ValsetUpdatedClaim(c context.Context, msg *types.MsgValsetUpdatedClaim) (*types.MsgValsetUpdatedClaimResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+
+	orchaddr, _ := sdk.AccAddressFromBech32(msg.Orchestrator)
+	if _, err := k.activeOrchestratorValidator(ctx, orchaddr); err != nil {
+		return nil, err
+	}
+	if err := k.checkClaimBridge(ctx, msg); err != nil {
+		return nil, err
+	}
+
+	any, err := codectypes.NewAnyWithValue(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add the claim to the store
+	_, err = k.Attest(ctx, msg, any)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "create attestation")
+	}
+
+	// Emit the handle message event
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, msg.Type()),
+			sdk.NewAttribute(types.AttributeKeyAttestationID, string(types.GetAttestationKey(msg.EventNonce, msg.ClaimHash()))),
+		),
+	)
+
+	return &types.MsgValsetUpdatedClaimResponse{}, nil
+}
+
 func (k msgServer) CancelSendToEth(c context.Context, msg *types.MsgCancelSendToEth) (*types.MsgCancelSendToEthResponse, error) {
 	ctx := sdk.UnwrapSDKContext(c)
 	sender, err := sdk.AccAddressFromBech32(msg.Sender)
 	if err != nil {
 		return nil, err
 	}
-	err = k.RemoveFromOutgoingPoolAndRefund(ctx, msg.TransactionId, sender)
+	// a scheduled transfer is not in the pool yet
+	if k.GetScheduledTransfer(ctx, msg.TransactionId) != nil {
+		err = k.CancelScheduledTransfer(ctx, msg.TransactionId, sender)
+	} else {
+		err = k.RemoveFromOutgoingPoolAndRefund(ctx, msg.TransactionId, sender)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -475,3 +706,363 @@ func (k msgServer) CancelSendToEth(c context.Context, msg *types.MsgCancelSendTo
 
 	return &types.MsgCancelSendToEthResponse{}, nil
 }
+
+// BumpSendToEthFee handles MsgBumpSendToEthFee
+func (k msgServer) BumpSendToEthFee(c context.Context, msg *types.MsgBumpSendToEthFee) (*types.MsgBumpSendToEthFeeResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+	sender, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, err
+	}
+	if err := k.BumpOutgoingTxFee(ctx, msg.TransactionId, sender, msg.AdditionalFee); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, msg.Type()),
+			sdk.NewAttribute(types.AttributeKeyOutgoingTXID, fmt.Sprint(msg.TransactionId)),
+		),
+	)
+
+	return &types.MsgBumpSendToEthFeeResponse{}, nil
+}
+
+// UpdateParams replaces the params when the message is signed by the authority of the module
+func (k msgServer) UpdateParams(c context.Context, msg *types.MsgUpdateParams) (*types.MsgUpdateParamsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+	if err := k.PeggyKeeper.UpdateParams(ctx, msg.Authority, msg.Params); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, msg.Type()),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Authority),
+		),
+	)
+
+	return &types.MsgUpdateParamsResponse{}, nil
+}
+
+// RegisterRelayer binds the Ethereum address of a relayer to the signing account, the Ethereum key has
+// to sign the registration to prove the address is controlled by the relayer
+func (k msgServer) RegisterRelayer(c context.Context, msg *types.MsgRegisterRelayer) (*types.MsgRegisterRelayerResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+	relayer, err := sdk.AccAddressFromBech32(msg.Relayer)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "relayer")
+	}
+	sigBytes, err := hex.DecodeString(msg.EthSignature)
+	if err != nil {
+		return nil, sdkerrors.Wrap(types.ErrInvalid, "signature decoding")
+	}
+	hash := types.RelayerRegistrationHash(k.GetGravityID(ctx), relayer)
+	if err := types.ValidateEthSig(hash, sigBytes, msg.EthAddress); err != nil {
+		return nil, sdkerrors.Wrap(types.ErrInvalid, fmt.Sprintf("signature verification failed expected sig by %s for relayer %s", msg.EthAddress, msg.Relayer))
+	}
+	k.SetRelayer(ctx, relayer, msg.EthAddress)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, msg.Type()),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Relayer),
+		),
+	)
+
+	return &types.MsgRegisterRelayerResponse{}, nil
+}
+
+// CancelBatch cancels a batch when the message is signed by the authority of the module, or by the
+// requester of the batch once the grace period passed
+func (k msgServer) CancelBatch(c context.Context, msg *types.MsgCancelBatch) (*types.MsgCancelBatchResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+	if err := k.PeggyKeeper.CancelBatch(ctx, msg.Sender, msg.TokenContract, msg.Nonce); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, msg.Type()),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
+			sdk.NewAttribute(types.AttributeKeyBatchNonce, fmt.Sprint(msg.Nonce)),
+		),
+	)
+
+	return &types.MsgCancelBatchResponse{}, nil
+}
+
+// SetLastObservedEventNonce moves the last observed event nonce when the message is signed by the
+// authority of the module
+func (k msgServer) SetLastObservedEventNonce(c context.Context, msg *types.MsgSetLastObservedEventNonce) (*types.MsgSetLastObservedEventNonceResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+	if err := k.PeggyKeeper.SetLastObservedEventNonce(ctx, msg.Authority, msg.Nonce); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, msg.Type()),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Authority),
+		),
+	)
+
+	return &types.MsgSetLastObservedEventNonceResponse{}, nil
+}
+
+// RegisterOmnibusAccount registers the signing account as an omnibus account or removes its registration
+func (k msgServer) RegisterOmnibusAccount(c context.Context, msg *types.MsgRegisterOmnibusAccount) (*types.MsgRegisterOmnibusAccountResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+	account, err := sdk.AccAddressFromBech32(msg.Account)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "account")
+	}
+	k.SetOmnibusAccount(ctx, account, !msg.Deregister)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, msg.Type()),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Account),
+		),
+	)
+
+	return &types.MsgRegisterOmnibusAccountResponse{}, nil
+}
+
+// SweepOmnibusSubAccounts moves the balances of sub-accounts to the signing omnibus account
+func (k msgServer) SweepOmnibusSubAccounts(c context.Context, msg *types.MsgSweepOmnibusSubAccounts) (*types.MsgSweepOmnibusSubAccountsResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+	omnibus, err := sdk.AccAddressFromBech32(msg.Omnibus)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "omnibus")
+	}
+	swept, err := k.PeggyKeeper.SweepOmnibusSubAccounts(ctx, omnibus, msg.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, msg.Type()),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Omnibus),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, swept.String()),
+		),
+	)
+
+	return &types.MsgSweepOmnibusSubAccountsResponse{Amount: swept}, nil
+}
+
+// PauseToken halts the bridging of a token when the message is signed by the authority of the module
+func (k msgServer) PauseToken(c context.Context, msg *types.MsgPauseToken) (*types.MsgPauseTokenResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+	if err := k.PeggyKeeper.PauseToken(ctx, msg.Authority, msg.TokenContract); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, msg.Type()),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Authority),
+		),
+	)
+
+	return &types.MsgPauseTokenResponse{}, nil
+}
+
+// UnpauseToken resumes the bridging of a paused token when the message is signed by the authority of
+// the module
+func (k msgServer) UnpauseToken(c context.Context, msg *types.MsgUnpauseToken) (*types.MsgUnpauseTokenResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+	if err := k.PeggyKeeper.UnpauseToken(ctx, msg.Authority, msg.TokenContract); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, msg.Type()),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Authority),
+		),
+	)
+
+	return &types.MsgUnpauseTokenResponse{}, nil
+}
+
+// SetCosmosOriginatedERC20 corrects the ERC20 of a cosmos originated denom when the message is signed by
+// the authority of the module
+func (k msgServer) SetCosmosOriginatedERC20(c context.Context, msg *types.MsgSetCosmosOriginatedERC20) (*types.MsgSetCosmosOriginatedERC20Response, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+	if err := k.PeggyKeeper.SetCosmosOriginatedERC20(ctx, msg.Authority, msg.Denom, msg.TokenContract, msg.Decimals); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, msg.Type()),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Authority),
+		),
+	)
+
+	return &types.MsgSetCosmosOriginatedERC20Response{}, nil
+}
+
+// Grant stores an authorization of the granter for the grantee to execute bridge messages on its behalf
+func (k msgServer) Grant(c context.Context, msg *types.MsgGrant) (*types.MsgGrantResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+	granter, err := sdk.AccAddressFromBech32(msg.Granter)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "granter")
+	}
+	grantee, err := sdk.AccAddressFromBech32(msg.Grantee)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "grantee")
+	}
+	if err := k.SaveGrant(ctx, granter, grantee, msg.Grant); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, msg.Type()),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Granter),
+			sdk.NewAttribute(types.AttributeKeyGrantee, msg.Grantee),
+		),
+	)
+
+	return &types.MsgGrantResponse{}, nil
+}
+
+// Revoke removes an authorization of the granter for the grantee
+func (k msgServer) Revoke(c context.Context, msg *types.MsgRevoke) (*types.MsgRevokeResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+	granter, err := sdk.AccAddressFromBech32(msg.Granter)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "granter")
+	}
+	grantee, err := sdk.AccAddressFromBech32(msg.Grantee)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "grantee")
+	}
+	if err := k.DeleteGrant(ctx, granter, grantee, msg.MsgTypeUrl); err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, msg.Type()),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Granter),
+			sdk.NewAttribute(types.AttributeKeyGrantee, msg.Grantee),
+		),
+	)
+
+	return &types.MsgRevokeResponse{}, nil
+}
+
+// Exec executes bridge messages on behalf of their signers, each message has to be covered by a grant
+// of its signer to the grantee unless the grantee signed it
+func (k msgServer) Exec(c context.Context, msg *types.MsgExec) (*types.MsgExecResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+	grantee, err := sdk.AccAddressFromBech32(msg.Grantee)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "grantee")
+	}
+	msgs, err := msg.GetMessages()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]byte, len(msgs))
+	for i, m := range msgs {
+		if err := k.AuthorizeMsg(ctx, grantee, m); err != nil {
+			return nil, sdkerrors.Wrapf(err, "message %d", i)
+		}
+		res, err := k.dispatch(ctx, m)
+		if err != nil {
+			return nil, sdkerrors.Wrapf(err, "message %d", i)
+		}
+		if results[i], err = res.Marshal(); err != nil {
+			return nil, sdkerrors.Wrapf(err, "message %d result", i)
+		}
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, msg.Type()),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Grantee),
+		),
+	)
+
+	return &types.MsgExecResponse{Results: results}, nil
+}
+
+// dispatch executes a message of the module executed by MsgExec, grants can only be changed by the
+// granter itself and a MsgExec can't be nested
+func (k msgServer) dispatch(ctx sdk.Context, msg sdk.Msg) (codec.ProtoMarshaler, error) {
+	c := sdk.WrapSDKContext(ctx)
+	switch msg := msg.(type) {
+	case *types.MsgSetOrchestratorAddress:
+		return k.SetOrchestratorAddress(c, msg)
+	case *types.MsgValsetConfirm:
+		return k.ValsetConfirm(c, msg)
+	case *types.MsgSendToEth:
+		return k.SendToEth(c, msg)
+	case *types.MsgMultiSendToEth:
+		return k.MultiSendToEth(c, msg)
+	case *types.MsgCancelSendToEth:
+		return k.CancelSendToEth(c, msg)
+	case *types.MsgBumpSendToEthFee:
+		return k.BumpSendToEthFee(c, msg)
+	case *types.MsgRequestBatch:
+		return k.RequestBatch(c, msg)
+	case *types.MsgConfirmBatch:
+		return k.ConfirmBatch(c, msg)
+	case *types.MsgConfirmLogicCall:
+		return k.ConfirmLogicCall(c, msg)
+	case *types.MsgDepositClaim:
+		return k.DepositClaim(c, msg)
+	case *types.MsgWithdrawClaim:
+		return k.WithdrawClaim(c, msg)
+	case *types.MsgERC20DeployedClaim:
+		return k.ERC20DeployedClaim(c, msg)
+	case *types.MsgLogicCallExecutedClaim:
+		return k.LogicCallExecutedClaim(c, msg)
+	case *types.MsgGenericEventClaim:
+		return k.GenericEventClaim(c, msg)
+	case *types.MsgValsetUpdatedClaim:
+		return k.ValsetUpdatedClaim(c, msg)
+	case *types.MsgUpdateParams:
+		return k.UpdateParams(c, msg)
+	case *types.MsgRegisterRelayer:
+		return k.RegisterRelayer(c, msg)
+	case *types.MsgCancelBatch:
+		return k.CancelBatch(c, msg)
+	case *types.MsgSetLastObservedEventNonce:
+		return k.SetLastObservedEventNonce(c, msg)
+	case *types.MsgPauseToken:
+		return k.PauseToken(c, msg)
+	case *types.MsgUnpauseToken:
+		return k.UnpauseToken(c, msg)
+	case *types.MsgSetCosmosOriginatedERC20:
+		return k.SetCosmosOriginatedERC20(c, msg)
+	case *types.MsgRegisterOmnibusAccount:
+		return k.RegisterOmnibusAccount(c, msg)
+	case *types.MsgSweepOmnibusSubAccounts:
+		return k.SweepOmnibusSubAccounts(c, msg)
+	case *types.MsgRevokeOrchestratorAddress:
+		return k.RevokeOrchestratorAddress(c, msg)
+	default:
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "%s can't be executed on behalf of another account", types.MsgTypeURL(msg))
+	}
+}
@@ -0,0 +1,50 @@
+package keeper
+
+import (
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// GetHighestClaimedEventNonce returns the highest event nonce any validator has claimed
+func (k Keeper) GetHighestClaimedEventNonce(ctx sdk.Context) uint64 {
+	iter := prefix.NewStore(ctx.KVStore(k.storeKey), types.LastEventNonceByValidatorKey).Iterator(nil, nil)
+	defer iter.Close()
+	var highest uint64
+	for ; iter.Valid(); iter.Next() {
+		if nonce := types.UInt64FromBytes(iter.Value()); nonce > highest {
+			highest = nonce
+		}
+	}
+	return highest
+}
+
+// ReportObservedNonceLag sets the telemetry gauge of how many event nonces the highest claimed nonce is
+// ahead of the last observed one and emits an observed_nonce_lag event while the lag exceeds the
+// ObservedNonceLagThreshold param. A lag that keeps growing means the orchestrators are split on an
+// event or stalled, in both cases no further deposit is applied until it's resolved.
+func (k Keeper) ReportObservedNonceLag(ctx sdk.Context) uint64 {
+	highest := k.GetHighestClaimedEventNonce(ctx)
+	lastObserved := k.GetLastObservedEventNonce(ctx)
+	var lag uint64
+	if highest > lastObserved {
+		lag = highest - lastObserved
+	}
+	telemetry.SetGauge(float32(lag), types.ModuleName, "observed_nonce_lag")
+
+	threshold := k.GetParams(ctx).ObservedNonceLagThreshold
+	if threshold != 0 && lag > threshold {
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeObservedNonceLag,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(types.AttributeKeyHighestClaimedNonce, strconv.FormatUint(highest, 10)),
+			sdk.NewAttribute(types.AttributeKeyLastObservedNonce, strconv.FormatUint(lastObserved, 10)),
+			sdk.NewAttribute(types.AttributeKeyNonceLag, strconv.FormatUint(lag, 10)),
+			sdk.NewAttribute(types.AttributeKeyThreshold, strconv.FormatUint(threshold, 10)),
+		))
+	}
+	return lag
+}
@@ -0,0 +1,64 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportObservedNonceLag(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	k := input.PeggyKeeper
+	params := k.GetParams(input.Context)
+	params.ObservedNonceLagThreshold = 3
+	k.SetParams(input.Context, params)
+	lagEvents := func(ctx sdk.Context) (out []sdk.Event) {
+		for _, event := range ctx.EventManager().Events() {
+			if event.Type == types.EventTypeObservedNonceLag {
+				out = append(out, event)
+			}
+		}
+		return out
+	}
+
+	// nothing claimed yet
+	ctx := input.Context.WithEventManager(sdk.NewEventManager())
+	assert.Equal(t, uint64(0), k.ReportObservedNonceLag(ctx))
+	assert.Empty(t, lagEvents(ctx))
+
+	// one validator is ahead, within the threshold
+	k.setLastObservedEventNonce(ctx, 10)
+	k.setLastEventNonceByValidator(ctx, ValAddrs[0], 10)
+	k.setLastEventNonceByValidator(ctx, ValAddrs[1], 13)
+	assert.Equal(t, uint64(13), k.GetHighestClaimedEventNonce(ctx))
+	assert.Equal(t, uint64(3), k.ReportObservedNonceLag(ctx))
+	assert.Empty(t, lagEvents(ctx))
+
+	// beyond the threshold the event is emitted
+	k.setLastEventNonceByValidator(ctx, ValAddrs[2], 14)
+	assert.Equal(t, uint64(4), k.ReportObservedNonceLag(ctx))
+	events := lagEvents(ctx)
+	if assert.Len(t, events, 1) {
+		attrs := make(map[string]string)
+		for _, attr := range events[0].Attributes {
+			attrs[string(attr.Key)] = string(attr.Value)
+		}
+		assert.Equal(t, map[string]string{
+			sdk.AttributeKeyModule:                types.ModuleName,
+			types.AttributeKeyHighestClaimedNonce: "14",
+			types.AttributeKeyLastObservedNonce:   "10",
+			types.AttributeKeyNonceLag:            "4",
+			types.AttributeKeyThreshold:           "3",
+		}, attrs)
+	}
+
+	// a threshold of zero disables the event
+	params.ObservedNonceLagThreshold = 0
+	k.SetParams(ctx, params)
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	assert.Equal(t, uint64(4), k.ReportObservedNonceLag(ctx))
+	assert.Empty(t, lagEvents(ctx))
+}
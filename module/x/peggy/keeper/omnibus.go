@@ -0,0 +1,66 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// SetOmnibusAccount registers the account as an omnibus account or removes the registration
+func (k Keeper) SetOmnibusAccount(ctx sdk.Context, account sdk.AccAddress, registered bool) {
+	store := ctx.KVStore(k.storeKey)
+	if !registered {
+		store.Delete(types.GetOmnibusAccountKey(account))
+		return
+	}
+	store.Set(types.GetOmnibusAccountKey(account), []byte{0x1})
+}
+
+// IsOmnibusAccount returns true if the account is a registered omnibus account
+func (k Keeper) IsOmnibusAccount(ctx sdk.Context, account sdk.AccAddress) bool {
+	return ctx.KVStore(k.storeKey).Has(types.GetOmnibusAccountKey(account))
+}
+
+// GetOmnibusAccounts returns the registered omnibus accounts ordered by address
+func (k Keeper) GetOmnibusAccounts(ctx sdk.Context) (out []sdk.AccAddress) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.OmnibusAccountKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		out = append(out, sdk.AccAddress(iter.Key()[len(types.OmnibusAccountKey):]))
+	}
+	return out
+}
+
+// DepositDestination returns the account a deposit to the receiver is credited to, the sub-account of the
+// tag when the receiver is an omnibus account. A tag of a deposit to any other account is ignored.
+func (k Keeper) DepositDestination(ctx sdk.Context, receiver sdk.AccAddress, tag string) sdk.AccAddress {
+	if tag == "" || !k.IsOmnibusAccount(ctx, receiver) {
+		return receiver
+	}
+	return types.OmnibusSubAccount(receiver, tag)
+}
+
+// SweepOmnibusSubAccounts moves the balances of the sub-accounts of the tags to the omnibus account and
+// returns the amount moved. The funds pass through the module account so that sweeping isn't blocked by
+// RestrictVoucherTransfers. An omnibus account can still sweep after removing its registration.
+func (k Keeper) SweepOmnibusSubAccounts(ctx sdk.Context, omnibus sdk.AccAddress, tags []string) (sdk.Coins, error) {
+	swept := sdk.NewCoins()
+	for _, tag := range tags {
+		subAccount := types.OmnibusSubAccount(omnibus, tag)
+		balance := k.bankKeeper.GetAllBalances(ctx, subAccount)
+		if balance.IsZero() {
+			continue
+		}
+		if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, subAccount, types.ModuleName, balance); err != nil {
+			return nil, sdkerrors.Wrapf(err, "sub-account of tag %s", tag)
+		}
+		swept = swept.Add(balance...)
+	}
+	if swept.IsZero() {
+		return swept, nil
+	}
+	if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, omnibus, swept); err != nil {
+		return nil, sdkerrors.Wrap(err, "omnibus account")
+	}
+	return swept, nil
+}
@@ -0,0 +1,66 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOmnibusDestinationTags(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	var (
+		tokenContract = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		ethSender     = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		omnibus       = AccAddrs[0]
+		deposit       = types.NewERC20Token(100, tokenContract).PeggyCoin()
+		subAccount    = types.OmnibusSubAccount(omnibus, "42")
+	)
+	claim := func(nonce uint64, tag string) *types.MsgDepositClaim {
+		return &types.MsgDepositClaim{
+			EventNonce:     nonce,
+			TokenContract:  tokenContract,
+			Amount:         deposit.Amount,
+			EthereumSender: ethSender,
+			CosmosReceiver: omnibus.String(),
+			DestinationTag: tag,
+		}
+	}
+	balance := func(addr sdk.AccAddress) sdk.Coins {
+		return input.BankKeeper.GetAllBalances(ctx, addr)
+	}
+
+	// the tag of a deposit to an unregistered account is ignored
+	require.NoError(t, k.AttestationHandler.Handle(ctx, types.Attestation{}, claim(1, "42")))
+	assert.Equal(t, sdk.Coins{deposit}, balance(omnibus))
+	assert.True(t, balance(subAccount).IsZero())
+
+	// a registered omnibus account receives tagged deposits in the sub-account of the tag
+	k.SetOmnibusAccount(ctx, omnibus, true)
+	assert.True(t, k.IsOmnibusAccount(ctx, omnibus))
+	require.NoError(t, k.AttestationHandler.Handle(ctx, types.Attestation{}, claim(2, "42")))
+	require.NoError(t, k.AttestationHandler.Handle(ctx, types.Attestation{}, claim(3, "")))
+	assert.Equal(t, sdk.Coins{deposit}, balance(subAccount))
+	assert.Equal(t, sdk.Coins{deposit.Add(deposit)}, balance(omnibus))
+
+	// the registrations survive an export
+	genesis := ExportGenesis(ctx, k)
+	assert.Equal(t, []string{omnibus.String()}, genesis.OmnibusAccounts)
+	restarted := CreateTestEnv(t)
+	InitGenesis(restarted.Context, restarted.PeggyKeeper, genesis)
+	assert.True(t, restarted.PeggyKeeper.IsOmnibusAccount(restarted.Context, omnibus))
+
+	// sweeping moves the balances of the sub-accounts back, also after deregistering
+	k.SetOmnibusAccount(ctx, omnibus, false)
+	assert.Empty(t, k.GetOmnibusAccounts(ctx))
+	swept, err := k.SweepOmnibusSubAccounts(ctx, omnibus, []string{"42", "7"})
+	require.NoError(t, err)
+	assert.Equal(t, sdk.Coins{deposit}, swept)
+	assert.True(t, balance(subAccount).IsZero())
+	assert.Equal(t, sdk.Coins{deposit.Add(deposit).Add(deposit)}, balance(omnibus))
+}
@@ -0,0 +1,127 @@
+package keeper
+
+import (
+	"bytes"
+	"encoding/hex"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// GetValsetConfirmsByOrchestrator returns the stored valset confirms of the orchestrator from the given
+// nonce on, ordered by nonce
+func (k Keeper) GetValsetConfirmsByOrchestrator(ctx sdk.Context, orchestrator sdk.AccAddress, fromNonce uint64) (out []*types.MsgValsetConfirm) {
+	iter := prefix.NewStore(ctx.KVStore(k.storeKey), types.ValsetConfirmKey).Iterator(types.UInt64Bytes(fromNonce), nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		// the confirms are keyed by nonce followed by the orchestrator address
+		if !bytes.HasSuffix(iter.Key(), orchestrator) {
+			continue
+		}
+		var confirm types.MsgValsetConfirm
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &confirm)
+		out = append(out, &confirm)
+	}
+	return
+}
+
+// GetBatchConfirmsByOrchestrator returns the stored batch confirms of the orchestrator for batches from the
+// given nonce on, ordered by token contract and nonce
+func (k Keeper) GetBatchConfirmsByOrchestrator(ctx sdk.Context, orchestrator sdk.AccAddress, fromNonce uint64) (out []*types.MsgConfirmBatch) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.BatchConfirmKey)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		if !bytes.HasSuffix(iter.Key(), orchestrator) {
+			continue
+		}
+		var confirm types.MsgConfirmBatch
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &confirm)
+		if confirm.Nonce >= fromNonce {
+			out = append(out, &confirm)
+		}
+	}
+	return
+}
+
+// GetLogicCallConfirmsByOrchestrator returns the stored logic call confirms of the orchestrator for logic
+// calls from the given invalidation nonce on, ordered by invalidation id and nonce
+func (k Keeper) GetLogicCallConfirmsByOrchestrator(ctx sdk.Context, orchestrator sdk.AccAddress, fromNonce uint64) (out []*types.MsgConfirmLogicCall) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.KeyOutgoingLogicConfirm)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		if !bytes.HasSuffix(iter.Key(), orchestrator) {
+			continue
+		}
+		var confirm types.MsgConfirmLogicCall
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &confirm)
+		if confirm.InvalidationNonce >= fromNonce {
+			out = append(out, &confirm)
+		}
+	}
+	return
+}
+
+// HasValidatorConfirm returns true if the validator already has the confirm or the claim stored, sent by any
+// of its orchestrators, sending it again fails as a duplicate
+func (k Keeper) HasValidatorConfirm(ctx sdk.Context, validator sdk.ValAddress, msg sdk.Msg) bool {
+	switch msg := msg.(type) {
+	case *types.MsgValsetConfirm:
+		for _, orch := range k.GetValidatorOrchestrators(ctx, validator) {
+			if k.GetValsetConfirm(ctx, msg.Nonce, orch) != nil {
+				return true
+			}
+		}
+	case *types.MsgConfirmBatch:
+		for _, orch := range k.GetValidatorOrchestrators(ctx, validator) {
+			if k.GetBatchConfirm(ctx, msg.Nonce, msg.TokenContract, orch) != nil {
+				return true
+			}
+		}
+	case *types.MsgConfirmLogicCall:
+		invalidationID, err := hex.DecodeString(msg.InvalidationId)
+		if err != nil {
+			return false
+		}
+		for _, orch := range k.GetValidatorOrchestrators(ctx, validator) {
+			if k.GetLogicCallConfirm(ctx, invalidationID, msg.InvalidationNonce, orch) != nil {
+				return true
+			}
+		}
+	case types.EthereumClaim:
+		att := k.GetAttestation(ctx, msg.GetEventNonce(), msg.ClaimHash())
+		return att != nil && hasVote(att, validator)
+	}
+	return false
+}
+
+// moveOrchestratorConfirms stores the valset, batch and logic call confirms of an orchestrator under its
+// successor, or deletes them when the successor is empty
+func (k Keeper) moveOrchestratorConfirms(ctx sdk.Context, orchestrator, successor sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	for _, confirm := range k.GetValsetConfirmsByOrchestrator(ctx, orchestrator, 0) {
+		store.Delete(types.GetValsetConfirmKey(confirm.Nonce, orchestrator))
+		if !successor.Empty() {
+			confirm.Orchestrator = successor.String()
+			k.SetValsetConfirm(ctx, *confirm)
+		}
+	}
+	for _, confirm := range k.GetBatchConfirmsByOrchestrator(ctx, orchestrator, 0) {
+		store.Delete(types.GetBatchConfirmKey(confirm.TokenContract, confirm.Nonce, orchestrator))
+		if !successor.Empty() {
+			confirm.Orchestrator = successor.String()
+			k.SetBatchConfirm(ctx, confirm)
+		}
+	}
+	for _, confirm := range k.GetLogicCallConfirmsByOrchestrator(ctx, orchestrator, 0) {
+		invalidationID, _ := hex.DecodeString(confirm.InvalidationId)
+		k.DeleteLogicCallConfirm(ctx, invalidationID, confirm.InvalidationNonce, orchestrator)
+		if !successor.Empty() {
+			confirm.Orchestrator = successor.String()
+			k.SetLogicCallConfirm(ctx, confirm)
+		}
+	}
+}
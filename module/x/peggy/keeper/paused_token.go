@@ -0,0 +1,94 @@
+package keeper
+
+import (
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// IsTokenPaused returns true if the bridging of the token contract is paused
+func (k Keeper) IsTokenPaused(ctx sdk.Context, tokenContract string) bool {
+	return ctx.KVStore(k.storeKey).Has(types.GetPausedTokenKey(tokenContract))
+}
+
+// PauseToken halts the bridging of the token contract in both directions when called with the
+// authority of the module. The outstanding batches of the token are cancelled so the orchestrators
+// stop signing them, the batches that are already confirmed may still be executed on Ethereum.
+func (k Keeper) PauseToken(ctx sdk.Context, authority string, tokenContract string) error {
+	if authority != k.authority {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "expected authority %s, got %s", k.authority, authority)
+	}
+	if k.IsTokenPaused(ctx, tokenContract) {
+		return sdkerrors.Wrapf(types.ErrDuplicate, "token %s already paused", tokenContract)
+	}
+	k.setPausedToken(ctx, types.PausedToken{TokenContract: tokenContract, Height: uint64(ctx.BlockHeight())})
+
+	// cancel outside of the iteration to not modify the store while iterating it
+	var batches []*types.OutgoingTxBatch
+	k.IterateOutgoingTXBatches(ctx, func(_ []byte, batch *types.OutgoingTxBatch) bool {
+		if strings.EqualFold(batch.TokenContract, tokenContract) {
+			batches = append(batches, batch)
+		}
+		return false
+	})
+	for _, batch := range batches {
+		if err := k.CancelBatch(ctx, k.authority, batch.TokenContract, batch.BatchNonce); err != nil {
+			return sdkerrors.Wrapf(err, "cancel batch %d", batch.BatchNonce)
+		}
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeTokenPaused,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(types.AttributeKeyTokenContract, tokenContract),
+		),
+	)
+	return nil
+}
+
+// UnpauseToken resumes the bridging of a paused token contract when called with the authority of
+// the module
+func (k Keeper) UnpauseToken(ctx sdk.Context, authority string, tokenContract string) error {
+	if authority != k.authority {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "expected authority %s, got %s", k.authority, authority)
+	}
+	if !k.IsTokenPaused(ctx, tokenContract) {
+		return sdkerrors.Wrapf(types.ErrUnknown, "token %s not paused", tokenContract)
+	}
+	ctx.KVStore(k.storeKey).Delete(types.GetPausedTokenKey(tokenContract))
+	// the scheduled transfers of the token left the index while it was paused
+	for _, scheduled := range k.GetScheduledTransfers(ctx) {
+		if strings.EqualFold(scheduled.Tx.Erc20Token.Contract, tokenContract) {
+			k.indexScheduledTransfer(ctx, scheduled)
+		}
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeTokenUnpaused,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(types.AttributeKeyTokenContract, tokenContract),
+		),
+	)
+	return nil
+}
+
+func (k Keeper) setPausedToken(ctx sdk.Context, token types.PausedToken) {
+	ctx.KVStore(k.storeKey).Set(types.GetPausedTokenKey(token.TokenContract), k.cdc.MustMarshalBinaryBare(&token))
+}
+
+// GetPausedTokens returns the paused tokens ordered by token contract
+func (k Keeper) GetPausedTokens(ctx sdk.Context) (out []types.PausedToken) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.PausedTokenKey)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var token types.PausedToken
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &token)
+		out = append(out, token)
+	}
+	return
+}
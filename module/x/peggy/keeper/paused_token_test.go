@@ -0,0 +1,86 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPauseToken(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	var (
+		mySender    = AccAddrs[0]
+		myReceiver  = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		pausedToken = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		otherToken  = "0x7580bFE88Dd3d07947908FAE12d95872a260F2D8"
+		authority   = k.GetAuthority()
+	)
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+	for _, token := range []string{pausedToken, otherToken} {
+		vouchers := sdk.NewCoins(types.NewERC20Token(1000, token).PeggyCoin())
+		require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, vouchers))
+		require.NoError(t, input.BankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, mySender, vouchers))
+	}
+	send := func(token string) error {
+		amount := types.NewERC20Token(100, token).PeggyCoin()
+		fee := types.NewERC20Token(1, token).PeggyCoin()
+		_, err := k.AddToOutgoingPool(ctx, mySender, myReceiver, amount, fee)
+		return err
+	}
+	require.NoError(t, send(pausedToken))
+	batch, err := k.BuildOutgoingTXBatch(ctx, pausedToken, OutgoingTxBatchSize)
+	require.NoError(t, err)
+
+	// only the authority may pause
+	err = k.PauseToken(ctx, mySender.String(), pausedToken)
+	assert.True(t, sdkerrors.ErrUnauthorized.Is(err))
+	require.NoError(t, k.PauseToken(ctx, authority, pausedToken))
+	assert.True(t, k.IsTokenPaused(ctx, pausedToken))
+	assert.Equal(t, []types.PausedToken{{TokenContract: pausedToken, Height: uint64(ctx.BlockHeight())}}, k.GetPausedTokens(ctx))
+
+	// the unconfirmed batch is cancelled and its transfer waits in the pool
+	assert.Nil(t, k.GetOutgoingTXBatch(ctx, pausedToken, batch.BatchNonce))
+	assert.Len(t, k.GetPoolTransactions(ctx), 1)
+
+	// nothing leaves for Ethereum
+	assert.True(t, types.ErrTokenPaused.Is(send(pausedToken)))
+	_, err = k.BuildOutgoingTXBatch(ctx, pausedToken, OutgoingTxBatchSize)
+	assert.True(t, types.ErrTokenPaused.Is(err))
+
+	// deposits are held for governance instead of credited
+	deposit := &types.MsgDepositClaim{
+		EventNonce:     1,
+		TokenContract:  pausedToken,
+		Amount:         sdk.NewInt(100),
+		EthereumSender: myReceiver,
+		CosmosReceiver: mySender.String(),
+	}
+	balance := input.BankKeeper.GetAllBalances(ctx, mySender)
+	require.NoError(t, k.AttestationHandler.Handle(ctx, types.Attestation{}, deposit))
+	assert.NotNil(t, k.GetReclaimableDeposit(ctx, 1))
+	assert.Equal(t, balance, input.BankKeeper.GetAllBalances(ctx, mySender))
+
+	// the rest of the bridge keeps operating
+	require.NoError(t, send(otherToken))
+
+	// the pause survives an export
+	restarted := CreateTestEnv(t)
+	InitGenesis(restarted.Context, restarted.PeggyKeeper, ExportGenesis(ctx, k))
+	assert.True(t, restarted.PeggyKeeper.IsTokenPaused(restarted.Context, pausedToken))
+
+	err = k.UnpauseToken(ctx, mySender.String(), pausedToken)
+	assert.True(t, sdkerrors.ErrUnauthorized.Is(err))
+	require.NoError(t, k.UnpauseToken(ctx, authority, pausedToken))
+	assert.False(t, k.IsTokenPaused(ctx, pausedToken))
+	assert.Error(t, k.UnpauseToken(ctx, authority, pausedToken))
+	require.NoError(t, send(pausedToken))
+	_, err = k.BuildOutgoingTXBatch(ctx, pausedToken, OutgoingTxBatchSize)
+	require.NoError(t, err)
+}
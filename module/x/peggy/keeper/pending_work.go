@@ -0,0 +1,103 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// IterateUnsignedValsets iterates over the valsets the orchestrator has not confirmed yet in
+// ascending nonce order, the oldest valset has to be signed first. Valsets confirmed by another
+// orchestrator of the same validator count as confirmed.
+func (k Keeper) IterateUnsignedValsets(ctx sdk.Context, orchestrator sdk.AccAddress, cb func(*types.Valset) bool) {
+	orchestrators := k.getSiblingOrchestrators(ctx, orchestrator)
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.ValsetRequestKey)
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var valset types.Valset
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &valset)
+		if k.hasValsetConfirm(ctx, valset.Nonce, orchestrators) {
+			continue
+		}
+		// cb returns true to stop early
+		if cb(&valset) {
+			break
+		}
+	}
+}
+
+// GetUnsignedValsets returns up to limit of the oldest valsets the orchestrator has not confirmed
+// yet in ascending nonce order, a limit of zero returns up to MaxResults valsets
+func (k Keeper) GetUnsignedValsets(ctx sdk.Context, orchestrator sdk.AccAddress, limit uint64) (out []*types.Valset) {
+	if limit == 0 || limit > MaxResults {
+		limit = MaxResults
+	}
+	k.IterateUnsignedValsets(ctx, orchestrator, func(val *types.Valset) bool {
+		out = append(out, val)
+		return uint64(len(out)) == limit
+	})
+	return
+}
+
+// IterateUnsignedBatches iterates over the batches the orchestrator has not confirmed yet
+func (k Keeper) IterateUnsignedBatches(ctx sdk.Context, orchestrator sdk.AccAddress, cb func(*types.OutgoingTxBatch) bool) {
+	orchestrators := k.getSiblingOrchestrators(ctx, orchestrator)
+	k.IterateOutgoingTXBatches(ctx, func(_ []byte, batch *types.OutgoingTxBatch) bool {
+		if k.hasBatchConfirm(ctx, batch.BatchNonce, batch.TokenContract, orchestrators) {
+			return false
+		}
+		// cb returns true to stop early
+		return cb(batch)
+	})
+}
+
+// IterateUnsignedLogicCalls iterates over the logic calls the orchestrator has not confirmed yet
+func (k Keeper) IterateUnsignedLogicCalls(ctx sdk.Context, orchestrator sdk.AccAddress, cb func(*types.OutgoingLogicCall) bool) {
+	orchestrators := k.getSiblingOrchestrators(ctx, orchestrator)
+	k.IterateOutgoingLogicCalls(ctx, func(_ []byte, call *types.OutgoingLogicCall) bool {
+		if k.hasLogicCallConfirm(ctx, call.InvalidationId, call.InvalidationNonce, orchestrators) {
+			return false
+		}
+		// cb returns true to stop early
+		return cb(call)
+	})
+}
+
+func (k Keeper) hasValsetConfirm(ctx sdk.Context, nonce uint64, orchestrators []sdk.AccAddress) bool {
+	for _, orchestrator := range orchestrators {
+		if k.GetValsetConfirm(ctx, nonce, orchestrator) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (k Keeper) hasBatchConfirm(ctx sdk.Context, nonce uint64, tokenContract string, orchestrators []sdk.AccAddress) bool {
+	for _, orchestrator := range orchestrators {
+		if k.GetBatchConfirm(ctx, nonce, tokenContract, orchestrator) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (k Keeper) hasLogicCallConfirm(ctx sdk.Context, invalidationID []byte, invalidationNonce uint64, orchestrators []sdk.AccAddress) bool {
+	for _, orchestrator := range orchestrators {
+		if k.GetLogicCallConfirm(ctx, invalidationID, invalidationNonce, orchestrator) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// confirmGraceBlocks returns the number of blocks, starting with the next one, a confirm of work
+// created at createdHeight may still be included in. The EndBlocker slashes the missing confirms of
+// work created more than window blocks before the current block.
+func confirmGraceBlocks(ctx sdk.Context, createdHeight, window uint64) uint64 {
+	deadline := createdHeight + window + 1
+	if height := uint64(ctx.BlockHeight()); height < deadline {
+		return deadline - height
+	}
+	return 0
+}
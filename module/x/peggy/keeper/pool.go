@@ -3,12 +3,14 @@ package keeper
 import (
 	"encoding/binary"
 	"fmt"
-	"math/big"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
 )
 
@@ -18,59 +20,122 @@ import (
 // - persists an OutgoingTx
 // - adds the TX to the `available` TX pool via a second index
 func (k Keeper) AddToOutgoingPool(ctx sdk.Context, sender sdk.AccAddress, counterpartReceiver string, amount sdk.Coin, fee sdk.Coin) (uint64, error) {
-	totalAmount := amount.Add(fee)
-	totalInVouchers := sdk.Coins{totalAmount}
+	outgoing, bridgeFee, err := k.lockOutgoingTx(ctx, sender, counterpartReceiver, amount, fee)
+	if err != nil {
+		return 0, err
+	}
+	if err := k.addToOutgoingPool(ctx, outgoing, bridgeFee); err != nil {
+		return 0, err
+	}
+	return outgoing.Id, nil
+}
 
+// lockOutgoingTx takes the amount and fee of a transfer from the sender and returns the transfer with
+// a new tx id, along with the bridge fee cut taken from the fee. The transfer isn't stored.
+func (k Keeper) lockOutgoingTx(ctx sdk.Context, sender sdk.AccAddress, counterpartReceiver string, amount sdk.Coin, fee sdk.Coin) (*types.OutgoingTransferTx, sdk.Coin, error) {
 	// If the coin is a peggy voucher, burn the coins. If not, check if there is a deployed ERC20 contract representing it.
 	// If there is, lock the coins.
 
-	isCosmosOriginated, tokenContract, err := k.DenomToERC20Lookup(ctx, totalAmount.Denom)
+	isCosmosOriginated, tokenContract, err := k.DenomToERC20Lookup(ctx, amount.Denom)
 	if err != nil {
-		return 0, err
+		return nil, sdk.Coin{}, err
+	}
+	if !k.IsTokenAllowed(ctx, tokenContract) {
+		return nil, sdk.Coin{}, sdkerrors.Wrapf(types.ErrUnsupported, "token %s is not allowed on the bridge", tokenContract)
+	}
+	if k.IsTokenPaused(ctx, tokenContract) {
+		return nil, sdk.Coin{}, sdkerrors.Wrap(types.ErrTokenPaused, tokenContract)
+	}
+	if err := k.checkNativeTokenCap(ctx, amount, isCosmosOriginated); err != nil {
+		return nil, sdk.Coin{}, err
 	}
 
-	// If it is a cosmos-originated asset we lock it
-	if isCosmosOriginated {
-		// lock coins in module
-		if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, sender, types.ModuleName, totalInVouchers); err != nil {
-			return 0, err
-		}
-	} else {
-		// If it is an ethereum-originated asset we burn it
-		// send coins to module in prep for burn
-		if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, sender, types.ModuleName, totalInVouchers); err != nil {
-			return 0, err
+	// Take the protocol's cut of the fee before anything is locked or burned, the cut stays on
+	// Cosmos in the community pool and only the remainder is paid to the relayer on Ethereum. The
+	// part of the remainder an ERC20 with fewer decimals than its denom can't represent goes with it.
+	bridgeFee := k.getBridgeFee(ctx, fee)
+	erc20Decimals, denomDecimals := k.tokenDecimals(ctx, tokenContract, amount.Denom, isCosmosOriginated)
+	bridgeFee = bridgeFee.Add(types.ERC20Dust(fee.Sub(bridgeFee), erc20Decimals, denomDecimals))
+	erc20Amount, err := types.NewERC20TokenFromCoin(amount, tokenContract, erc20Decimals, denomDecimals)
+	if err != nil {
+		return nil, sdk.Coin{}, err
+	}
+	erc20Fee, err := types.NewERC20TokenFromCoin(fee.Sub(bridgeFee), tokenContract, erc20Decimals, denomDecimals)
+	if err != nil {
+		return nil, sdk.Coin{}, err
+	}
+	if bridgeFee.IsPositive() {
+		if err := k.distKeeper.FundCommunityPool(ctx, sdk.Coins{bridgeFee}, sender); err != nil {
+			return nil, sdk.Coin{}, sdkerrors.Wrap(err, "bridge fee")
 		}
+		fee = fee.Sub(bridgeFee)
+	}
 
-		// burn vouchers to send them back to ETH
-		if err := k.bankKeeper.BurnCoins(ctx, types.ModuleName, totalInVouchers); err != nil {
-			panic(err)
-		}
+	// the amount is escrowed or burned right away, the fee is held by the fee collector until
+	// the transfer is executed on Ethereum or refunded
+	if err := k.lockOutgoing(ctx, sender, amount, isCosmosOriginated); err != nil {
+		return nil, sdk.Coin{}, err
+	}
+	if err := k.collectFees(ctx, sender, sdk.Coins{fee}); err != nil {
+		return nil, sdk.Coin{}, err
 	}
 
 	// get next tx id from keeper
 	nextID := k.autoIncrementID(ctx, types.KeyLastTXPoolID)
-
-	erc20Fee := types.NewSDKIntERC20Token(fee.Amount, tokenContract)
+	k.recordAccountActivity(ctx, sender, types.AccountActivity{
+		Type:            types.ACCOUNT_ACTIVITY_TYPE_WITHDRAWAL,
+		Amount:          amount,
+		TxId:            nextID,
+		EthereumAddress: counterpartReceiver,
+	})
+	k.recordAccountActivity(ctx, sender, types.AccountActivity{
+		Type:            types.ACCOUNT_ACTIVITY_TYPE_FEE,
+		Amount:          fee.Add(bridgeFee),
+		TxId:            nextID,
+		EthereumAddress: counterpartReceiver,
+	})
 
 	// construct outgoing tx, as part of this process we represent
 	// the token as an ERC20 token since it is preparing to go to ETH
 	// rather than the denom that is the input to this function.
-	outgoing := &types.OutgoingTransferTx{
+	return &types.OutgoingTransferTx{
 		Id:          nextID,
 		Sender:      sender.String(),
 		DestAddress: counterpartReceiver,
-		Erc20Token:  types.NewSDKIntERC20Token(amount.Amount, tokenContract),
+		Erc20Token:  erc20Amount,
 		Erc20Fee:    erc20Fee,
+	}, bridgeFee, nil
+}
+
+// checkNativeTokenCap rejects sending the staking token to Ethereum unless the BridgeNativeToken param
+// is set, and once the tracked escrow would hold more of it than the NativeTokenBridgeCap param
+func (k Keeper) checkNativeTokenCap(ctx sdk.Context, amount sdk.Coin, isCosmosOriginated bool) error {
+	if !isCosmosOriginated || amount.Denom != k.StakingKeeper.GetParams(ctx).BondDenom {
+		return nil
+	}
+	params := k.GetParams(ctx)
+	if !params.BridgeNativeToken {
+		return sdkerrors.Wrapf(types.ErrUnsupported, "staking token %s is not bridged", amount.Denom)
+	}
+	if params.NativeTokenBridgeCap.IsNil() || params.NativeTokenBridgeCap.IsZero() {
+		return nil
+	}
+	escrowed := sdk.NewCoin(amount.Denom, k.GetNativeTokenEscrow(ctx))
+	if escrowed.Amount.Add(amount.Amount).GT(params.NativeTokenBridgeCap) {
+		return sdkerrors.Wrapf(types.ErrInvalid, "escrow of %s would exceed the bridge cap of %s", escrowed.Add(amount), params.NativeTokenBridgeCap)
 	}
+	return nil
+}
 
+// addToOutgoingPool stores a locked transfer in the pool and makes it available for batching
+func (k Keeper) addToOutgoingPool(ctx sdk.Context, outgoing *types.OutgoingTransferTx, bridgeFee sdk.Coin) error {
 	// set the outgoing tx in the pool index
 	if err := k.setPoolEntry(ctx, outgoing); err != nil {
-		return 0, err
+		return err
 	}
 
 	// add a second index with the fee
-	k.appendToUnbatchedTXIndex(ctx, tokenContract, *erc20Fee, nextID)
+	k.addToUnbatchedTXIndex(ctx, outgoing)
 
 	// todo: add second index for sender so that we can easily query: give pending Tx by sender
 	// todo: what about a second index for receiver?
@@ -80,12 +145,53 @@ func (k Keeper) AddToOutgoingPool(ctx sdk.Context, sender sdk.AccAddress, counte
 		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
 		sdk.NewAttribute(types.AttributeKeyContract, k.GetBridgeContractAddress(ctx)),
 		sdk.NewAttribute(types.AttributeKeyBridgeChainID, strconv.Itoa(int(k.GetBridgeChainID(ctx)))),
-		sdk.NewAttribute(types.AttributeKeyOutgoingTXID, strconv.Itoa(int(nextID))),
-		sdk.NewAttribute(types.AttributeKeyNonce, fmt.Sprint(nextID)),
+		sdk.NewAttribute(types.AttributeKeyOutgoingTXID, strconv.Itoa(int(outgoing.Id))),
+		sdk.NewAttribute(types.AttributeKeyNonce, fmt.Sprint(outgoing.Id)),
+		sdk.NewAttribute(types.AttributeKeyBridgeFee, bridgeFee.String()),
 	)
 	ctx.EventManager().EmitEvent(poolEvent)
+	return nil
+}
+
+// getBridgeFee returns the part of the fee that goes to the community pool according
+// to the BridgeFeeBasisPoints param, rounded down
+func (k Keeper) getBridgeFee(ctx sdk.Context, fee sdk.Coin) sdk.Coin {
+	basisPoints := k.GetParams(ctx).BridgeFeeBasisPoints
+	cut := fee.Amount.Mul(sdk.NewIntFromUint64(basisPoints)).Quo(sdk.NewInt(10000))
+	return sdk.NewCoin(fee.Denom, cut)
+}
 
-	return nextID, nil
+// SetOutgoingTxExpiration sets the block height and unix time (in seconds) at which an unbatched tx
+// is refunded by the EndBlocker, zero values mean no expiration. See RefundExpiredOutgoingTxs. The
+// expiration of a scheduled tx applies once it was released into the pool.
+func (k Keeper) SetOutgoingTxExpiration(ctx sdk.Context, txID uint64, expirationHeight, expirationTime uint64) error {
+	if expirationHeight != 0 && expirationHeight <= uint64(ctx.BlockHeight()) {
+		return sdkerrors.Wrapf(types.ErrInvalid, "expiration height %d is not in the future", expirationHeight)
+	}
+	if expirationTime != 0 && expirationTime <= uint64(ctx.BlockTime().Unix()) {
+		return sdkerrors.Wrapf(types.ErrInvalid, "expiration time %d is not in the future", expirationTime)
+	}
+	if scheduled := k.GetScheduledTransfer(ctx, txID); scheduled != nil {
+		scheduled.Tx.ExpirationHeight = expirationHeight
+		scheduled.Tx.ExpirationTime = expirationTime
+		k.setScheduledTransfer(ctx, *scheduled)
+		return nil
+	}
+	tx, err := k.getPoolEntry(ctx, txID)
+	if err != nil {
+		return sdkerrors.Wrapf(err, "tx %d", txID)
+	}
+	// the expiration index only holds unbatched txs
+	unbatched := ctx.KVStore(k.storeKey).Has(types.GetFeeSecondIndexKey(*tx.Erc20Fee, txID))
+	if unbatched {
+		k.deleteOutgoingTxExpiration(ctx, tx)
+	}
+	tx.ExpirationHeight = expirationHeight
+	tx.ExpirationTime = expirationTime
+	if unbatched {
+		k.indexOutgoingTxExpiration(ctx, tx)
+	}
+	return k.setPoolEntry(ctx, tx)
 }
 
 // RemoveFromOutgoingPoolAndRefund
@@ -98,6 +204,10 @@ func (k Keeper) RemoveFromOutgoingPoolAndRefund(ctx sdk.Context, txId uint64, se
 	if err != nil {
 		return err
 	}
+	if k.IsTxProcessed(ctx, txId) {
+		k.logger(ctx).Error("refusing to refund already processed tx", "tx", txId)
+		return sdkerrors.Wrapf(types.ErrDuplicate, "Id %d already processed", txId)
+	}
 
 	found := false
 	poolTx := k.GetPoolTransactions(ctx)
@@ -116,102 +226,209 @@ func (k Keeper) RemoveFromOutgoingPoolAndRefund(ctx sdk.Context, txId uint64, se
 		return sdkerrors.Wrapf(types.ErrInvalid, "Inconsistent tokens to cancel!: %s %s", tx.Erc20Fee.Contract, tx.Erc20Token.Contract)
 	}
 
-	// delete this tx from both indexes
-	k.removePoolEntry(ctx, txId)
-	k.removeFromUnbatchedTXIndex(ctx, *tx.Erc20Fee, txId)
+	if err := k.refundOutgoingTx(ctx, tx, sender); err != nil {
+		return err
+	}
 
-	// reissue the amount and the fee
+	poolEvent := sdk.NewEvent(
+		types.EventTypeBridgeWithdrawCanceled,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyContract, k.GetBridgeContractAddress(ctx)),
+		sdk.NewAttribute(types.AttributeKeyBridgeChainID, strconv.Itoa(int(k.GetBridgeChainID(ctx)))),
+	)
+	ctx.EventManager().EmitEvent(poolEvent)
 
-	totalToRefund := tx.Erc20Token.PeggyCoin()
-	totalToRefund.Amount = totalToRefund.Amount.Add(tx.Erc20Fee.Amount)
-	totalToRefundCoins := sdk.NewCoins(totalToRefund)
+	return nil
+}
 
-	isCosmosOriginated, _ := k.ERC20ToDenomLookup(ctx, tx.Erc20Token.Contract)
+// BumpOutgoingTxFee adds to the fee of an unbatched tx, only the sender of the tx can do so. The
+// additional fee is escrowed like the original one (see AddToOutgoingPool), the bridge fee cut is
+// taken from it, and the tx is moved in the fee index so that it is picked earlier for a batch.
+func (k Keeper) BumpOutgoingTxFee(ctx sdk.Context, txID uint64, sender sdk.AccAddress, additionalFee sdk.Coin) error {
+	tx, err := k.getPoolEntry(ctx, txID)
+	if err != nil {
+		return sdkerrors.Wrapf(err, "tx %d", txID)
+	}
+	if tx.Sender != sender.String() {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "tx %d was not sent by %s", txID, sender)
+	}
+	if k.IsTxProcessed(ctx, txID) {
+		return sdkerrors.Wrapf(types.ErrDuplicate, "Id %d already processed", txID)
+	}
+	isCosmosOriginated, tokenContract, err := k.DenomToERC20Lookup(ctx, additionalFee.Denom)
+	if err != nil {
+		return err
+	}
+	if tokenContract != tx.Erc20Fee.Contract {
+		return sdkerrors.Wrapf(types.ErrInvalid, "fee token %s differs from the tx token %s", tokenContract, tx.Erc20Fee.Contract)
+	}
+	// the index entry is keyed by the current fee, it is missing once the tx is in a batch
+	if err := k.removeFromUnbatchedTXIndex(ctx, tx); err != nil {
+		return sdkerrors.Wrapf(types.ErrInvalid, "Id %d is in a batch", txID)
+	}
 
-	// If it is a cosmos-originated the coins are in the module (see AddToOutgoingPool) so we can just take them out
-	if isCosmosOriginated {
-		if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, sender, totalToRefundCoins); err != nil {
+	bridgeFee := k.getBridgeFee(ctx, additionalFee)
+	erc20Decimals, denomDecimals := k.tokenDecimals(ctx, tokenContract, additionalFee.Denom, isCosmosOriginated)
+	bridgeFee = bridgeFee.Add(types.ERC20Dust(additionalFee.Sub(bridgeFee), erc20Decimals, denomDecimals))
+	if bridgeFee.IsPositive() {
+		if err := k.distKeeper.FundCommunityPool(ctx, sdk.Coins{bridgeFee}, sender); err != nil {
+			return sdkerrors.Wrap(err, "bridge fee")
+		}
+		additionalFee = additionalFee.Sub(bridgeFee)
+	}
+	erc20Fee, err := types.NewERC20TokenFromCoin(additionalFee, tokenContract, erc20Decimals, denomDecimals)
+	if err != nil {
+		return err
+	}
+	if additionalFee.IsPositive() {
+		if err := k.collectFees(ctx, sender, sdk.Coins{additionalFee}); err != nil {
 			return err
 		}
-	} else {
-		// If it is an ethereum-originated asset we have to mint it (see Handle in attestation_handler.go)
-		// mint coins in module for prep to send
-		if err := k.bankKeeper.MintCoins(ctx, types.ModuleName, totalToRefundCoins); err != nil {
-			return sdkerrors.Wrapf(err, "mint vouchers coins: %s", totalToRefundCoins)
+	}
+
+	k.recordAccountActivity(ctx, sender, types.AccountActivity{
+		Type:            types.ACCOUNT_ACTIVITY_TYPE_FEE,
+		Amount:          additionalFee.Add(bridgeFee),
+		TxId:            txID,
+		EthereumAddress: tx.DestAddress,
+	})
+
+	tx.Erc20Fee.Amount = tx.Erc20Fee.Amount.Add(erc20Fee.Amount)
+	if err := k.setPoolEntry(ctx, tx); err != nil {
+		return err
+	}
+	k.addToUnbatchedTXIndex(ctx, tx)
+	return nil
+}
+
+// RefundExpiredOutgoingTxs refunds the senders of all unbatched txs that reached their
+// expiration so that a transfer with a fee too low for any relayer doesn't stay stuck
+// in the pool. Txs in a batch are left alone, they come back to the pool if the batch
+// times out and are refunded then. The unbatched txs are indexed by the height and the
+// time they expire at, so only the expired ones are visited. A tx whose refund fails is
+// logged and leaves the index, it stays in the pool for its sender to cancel.
+func (k Keeper) RefundExpiredOutgoingTxs(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	height, now := uint64(ctx.BlockHeight()), uint64(ctx.BlockTime().Unix())
+	for refunded := 0; refunded == 0 || !WorkBudgetExhausted(ctx); refunded++ {
+		// reopen the iterators on every tx, the refund modifies the store
+		key := firstIndexKeyBelow(store, types.OutgoingTxByExpirationHeightKey, height+1)
+		if key == nil {
+			key = firstIndexKeyBelow(store, types.OutgoingTxByExpirationTimeKey, now+1)
+		}
+		if key == nil {
+			return
 		}
-		if err = k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, sender, totalToRefundCoins); err != nil {
-			return sdkerrors.Wrap(err, "transfer vouchers")
+		store.Delete(key)
+		id := types.UInt64FromBytes(key[len(key)-8:])
+		tx, err := k.getPoolEntry(ctx, id)
+		if err != nil {
+			k.logger(ctx).Error("expired tx not in the pool", "tx", id)
+			continue
 		}
+		k.refundExpiredOutgoingTx(ctx, tx)
 	}
+}
 
-	poolEvent := sdk.NewEvent(
-		types.EventTypeBridgeWithdrawCanceled,
+func (k Keeper) refundExpiredOutgoingTx(ctx sdk.Context, tx *types.OutgoingTransferTx) {
+	refundCtx, commit := ctx.CacheContext()
+	sender, err := sdk.AccAddressFromBech32(tx.Sender)
+	if err == nil {
+		err = k.refundOutgoingTx(refundCtx, tx, sender)
+	}
+	if err != nil {
+		k.logger(ctx).Error("refund expired tx", "tx", tx.Id, "cause", err)
+		k.deleteOutgoingTxExpiration(ctx, tx)
+		return
+	}
+	commit()
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeBridgeWithdrawExpired,
 		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
 		sdk.NewAttribute(types.AttributeKeyContract, k.GetBridgeContractAddress(ctx)),
 		sdk.NewAttribute(types.AttributeKeyBridgeChainID, strconv.Itoa(int(k.GetBridgeChainID(ctx)))),
-	)
-	ctx.EventManager().EmitEvent(poolEvent)
+		sdk.NewAttribute(types.AttributeKeyOutgoingTXID, fmt.Sprint(tx.Id)),
+	))
+}
 
+// refundOutgoingTx deletes an unbatched tx from the pool and gives the amount and fee back to the sender
+func (k Keeper) refundOutgoingTx(ctx sdk.Context, tx *types.OutgoingTransferTx, sender sdk.AccAddress) error {
+	// delete this tx from both indexes
+	k.removePoolEntry(ctx, tx.Id)
+	k.removeFromUnbatchedTXIndex(ctx, tx)
+
+	// reissue the amount and give back the fee
+	isCosmosOriginated, amount := k.ERC20ToCoin(ctx, *tx.Erc20Token)
+	if err := k.unlockOutgoing(ctx, sender, amount, isCosmosOriginated); err != nil {
+		return sdkerrors.Wrap(err, "amount")
+	}
+	_, fee := k.ERC20ToCoin(ctx, *tx.Erc20Fee)
+	if err := k.refundFees(ctx, sender, sdk.Coins{fee}); err != nil {
+		return sdkerrors.Wrap(err, "fee")
+	}
+	for _, refund := range []sdk.Coin{amount, fee} {
+		k.recordAccountActivity(ctx, sender, types.AccountActivity{
+			Type:            types.ACCOUNT_ACTIVITY_TYPE_REFUND,
+			Amount:          refund,
+			TxId:            tx.Id,
+			EthereumAddress: tx.DestAddress,
+		})
+	}
 	return nil
 }
 
-// appendToUnbatchedTXIndex add at the end when tx with same fee exists
-func (k Keeper) appendToUnbatchedTXIndex(ctx sdk.Context, tokenContract string, fee types.ERC20Token, txID uint64) {
+// addToUnbatchedTXIndex adds the tx to the fee ordered index making it available for batching, and to
+// the expiration index if it expires
+func (k Keeper) addToUnbatchedTXIndex(ctx sdk.Context, tx *types.OutgoingTransferTx) {
 	store := ctx.KVStore(k.storeKey)
-	idxKey := types.GetFeeSecondIndexKey(fee)
-	var idSet types.IDSet
-	if store.Has(idxKey) {
-		bz := store.Get(idxKey)
-		k.cdc.MustUnmarshalBinaryBare(bz, &idSet)
-	}
-	idSet.Ids = append(idSet.Ids, txID)
-	store.Set(idxKey, k.cdc.MustMarshalBinaryBare(&idSet))
+	store.Set(types.GetFeeSecondIndexKey(*tx.Erc20Fee, tx.Id), []byte{0x1})
+	k.indexOutgoingTxExpiration(ctx, tx)
 }
 
-// appendToUnbatchedTXIndex add at the top when tx with same fee exists
-func (k Keeper) prependToUnbatchedTXIndex(ctx sdk.Context, tokenContract string, fee types.ERC20Token, txID uint64) {
+// removeFromUnbatchedTXIndex removes the tx from the fee and expiration indexes and makes it implicit no
+// available anymore
+func (k Keeper) removeFromUnbatchedTXIndex(ctx sdk.Context, tx *types.OutgoingTransferTx) error {
 	store := ctx.KVStore(k.storeKey)
-	idxKey := types.GetFeeSecondIndexKey(fee)
-	var idSet types.IDSet
-	if store.Has(idxKey) {
-		bz := store.Get(idxKey)
-		k.cdc.MustUnmarshalBinaryBare(bz, &idSet)
-	}
-	idSet.Ids = append([]uint64{txID}, idSet.Ids...)
-	store.Set(idxKey, k.cdc.MustMarshalBinaryBare(&idSet))
+	idxKey := types.GetFeeSecondIndexKey(*tx.Erc20Fee, tx.Id)
+	if !store.Has(idxKey) {
+		return sdkerrors.Wrap(types.ErrUnknown, "tx id")
+	}
+	store.Delete(idxKey)
+	k.deleteOutgoingTxExpiration(ctx, tx)
+	return nil
 }
 
-// removeFromUnbatchedTXIndex removes the tx from the index and makes it implicit no available anymore
-func (k Keeper) removeFromUnbatchedTXIndex(ctx sdk.Context, fee types.ERC20Token, txID uint64) error {
+// indexOutgoingTxExpiration indexes an unbatched tx by the height and the time it expires at, see
+// RefundExpiredOutgoingTxs
+func (k Keeper) indexOutgoingTxExpiration(ctx sdk.Context, tx *types.OutgoingTransferTx) {
 	store := ctx.KVStore(k.storeKey)
-	idxKey := types.GetFeeSecondIndexKey(fee)
-	var idSet types.IDSet
-	bz := store.Get(idxKey)
-	if bz == nil {
-		return sdkerrors.Wrap(types.ErrUnknown, "fee")
-	}
-	k.cdc.MustUnmarshalBinaryBare(bz, &idSet)
-	for i := range idSet.Ids {
-		if idSet.Ids[i] == txID {
-			idSet.Ids = append(idSet.Ids[0:i], idSet.Ids[i+1:]...)
-			if len(idSet.Ids) != 0 {
-				store.Set(idxKey, k.cdc.MustMarshalBinaryBare(&idSet))
-			} else {
-				store.Delete(idxKey)
-			}
-			return nil
-		}
+	if tx.ExpirationHeight != 0 {
+		store.Set(types.GetOutgoingTxByExpirationHeightKey(tx.ExpirationHeight, tx.Id), []byte{})
+	}
+	if tx.ExpirationTime != 0 {
+		store.Set(types.GetOutgoingTxByExpirationTimeKey(tx.ExpirationTime, tx.Id), []byte{})
 	}
-	return sdkerrors.Wrap(types.ErrUnknown, "tx id")
 }
 
+func (k Keeper) deleteOutgoingTxExpiration(ctx sdk.Context, tx *types.OutgoingTransferTx) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetOutgoingTxByExpirationHeightKey(tx.ExpirationHeight, tx.Id))
+	store.Delete(types.GetOutgoingTxByExpirationTimeKey(tx.ExpirationTime, tx.Id))
+}
+
+// setPoolEntry stores a tx that was not executed yet, batched or not, and keeps it in the pending fee index
+// under its current fee
 func (k Keeper) setPoolEntry(ctx sdk.Context, val *types.OutgoingTransferTx) error {
 	bz, err := k.cdc.MarshalBinaryBare(val)
 	if err != nil {
 		return err
 	}
 	store := ctx.KVStore(k.storeKey)
+	if prev, err := k.getPoolEntry(ctx, val.Id); err == nil {
+		store.Delete(types.GetPendingOutgoingTxByFeeKey(prev.Erc20Fee.Amount, prev.Id))
+	}
 	store.Set(types.GetOutgoingTxPoolKey(val.Id), bz)
+	store.Set(types.GetPendingOutgoingTxByFeeKey(val.Erc20Fee.Amount, val.Id), []byte{})
 	return nil
 }
 
@@ -228,50 +445,168 @@ func (k Keeper) getPoolEntry(ctx sdk.Context, id uint64) (*types.OutgoingTransfe
 
 func (k Keeper) removePoolEntry(ctx sdk.Context, id uint64) {
 	store := ctx.KVStore(k.storeKey)
+	if tx, err := k.getPoolEntry(ctx, id); err == nil {
+		store.Delete(types.GetPendingOutgoingTxByFeeKey(tx.Erc20Fee.Amount, id))
+	}
 	store.Delete(types.GetOutgoingTxPoolKey(id))
 }
 
+// IterateOutgoingPool iterates over the unbatched transactions of all token contracts. Transactions
+// stay in the pool store while they are in a batch, so the fee index is used to skip them.
+func (k Keeper) IterateOutgoingPool(ctx sdk.Context, cb func(*types.OutgoingTransferTx) bool) {
+	k.IterateOutgoingPoolByFee(ctx, "", func(_ uint64, tx *types.OutgoingTransferTx) bool {
+		return cb(tx)
+	})
+}
+
 // GetPoolTransactions, grabs all transactions from the tx pool, useful for queries or genesis save/load
 func (k Keeper) GetPoolTransactions(ctx sdk.Context) []*types.OutgoingTransferTx {
-	prefixStore := ctx.KVStore(k.storeKey)
+	var ret []*types.OutgoingTransferTx
 	// we must use the second index key here because transactions are left in the store, but removed
 	// from the tx sorting key, while in batches
-	iter := prefixStore.ReverseIterator(prefixRange([]byte(types.SecondIndexOutgoingTXFeeKey)))
-	var ret []*types.OutgoingTransferTx
-	defer iter.Close()
-	for ; iter.Valid(); iter.Next() {
-		var ids types.IDSet
-		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &ids)
-		for _, id := range ids.Ids {
-			tx, err := k.getPoolEntry(ctx, id)
-			if err != nil {
-				panic("Invalid id in tx index!")
-			}
-			ret = append(ret, tx)
-		}
-	}
+	k.IterateOutgoingPoolByFee(ctx, "", func(_ uint64, tx *types.OutgoingTransferTx) bool {
+		ret = append(ret, tx)
+		return false
+	})
 	return ret
 }
 
-// IterateOutgoingPoolByFee iterates over the outgoing pool which is sorted by fee
+// IterateOutgoingPoolByFee iterates over the outgoing pool of the given contract from the highest to
+// the lowest fee, an empty contract iterates over all contracts in turn
 func (k Keeper) IterateOutgoingPoolByFee(ctx sdk.Context, contract string, cb func(uint64, *types.OutgoingTransferTx) bool) {
 	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.SecondIndexOutgoingTXFeeKey)
-	iter := prefixStore.ReverseIterator(prefixRange([]byte(contract)))
+	iter := prefixStore.Iterator(prefixRange([]byte(contract)))
 	defer iter.Close()
 	for ; iter.Valid(); iter.Next() {
-		var ids types.IDSet
-		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &ids)
+		_, _, id := types.ParseFeeSecondIndexKey(iter.Key())
+		tx, err := k.getPoolEntry(ctx, id)
+		if err != nil {
+			panic("Invalid id in tx index!")
+		}
 		// cb returns true to stop early
-		for _, id := range ids.Ids {
-			tx, err := k.getPoolEntry(ctx, id)
+		if cb(id, tx) {
+			return
+		}
+	}
+}
+
+// PaginateOutgoingPoolByFee returns a page of the outgoing pool for the given contract sorted by fee,
+// an empty contract pages through the whole pool. The page key is the big endian position of the next
+// transaction rather than a store key
+func (k Keeper) PaginateOutgoingPoolByFee(ctx sdk.Context, contract string, pageReq *query.PageRequest) ([]*types.OutgoingTransferTx, *query.PageResponse, error) {
+	if pageReq == nil {
+		pageReq = &query.PageRequest{}
+	}
+	offset, limit, err := parsePositionPageRequest(pageReq)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		txs   []*types.OutgoingTransferTx
+		count uint64
+		more  bool
+	)
+	k.IterateOutgoingPoolByFee(ctx, contract, func(_ uint64, tx *types.OutgoingTransferTx) bool {
+		count++
+		switch {
+		case count <= offset:
+		case uint64(len(txs)) < limit:
+			txs = append(txs, tx)
+		default:
+			more = true
+			// keep counting if the total was requested
+			return !pageReq.CountTotal
+		}
+		return false
+	})
+
+	res := &query.PageResponse{}
+	if more {
+		res.NextKey = types.UInt64Bytes(offset + uint64(len(txs)))
+	}
+	if pageReq.CountTotal {
+		res.Total = count
+	}
+	return txs, res, nil
+}
+
+// parsePositionPageRequest returns the offset and limit of a page whose key is the big endian
+// position of its first entry rather than a store key
+func parsePositionPageRequest(pageReq *query.PageRequest) (offset, limit uint64, err error) {
+	if pageReq.Offset > 0 && pageReq.Key != nil {
+		return 0, 0, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "invalid request, either offset or key is expected, got both")
+	}
+	offset = pageReq.Offset
+	if pageReq.Key != nil {
+		if len(pageReq.Key) != 8 {
+			return 0, 0, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "invalid pagination key")
+		}
+		offset = types.UInt64FromBytes(pageReq.Key)
+	}
+	limit = pageReq.Limit
+	if limit == 0 {
+		limit = query.DefaultLimit
+	}
+	if limit > MaxPageLimit {
+		limit = MaxPageLimit
+	}
+	return offset, limit, nil
+}
+
+// PaginatePendingSendToEth returns a page of the batched and unbatched transfers matching the
+// filters of the request. Transfers stay in the pool store while they are in a batch, so the page
+// is read from the pool store in id order or from the pending fee index in fee order.
+func (k Keeper) PaginatePendingSendToEth(ctx sdk.Context, req *types.QueryPendingSendToEth) (inBatches, unbatched []*types.OutgoingTransferTx, res *query.PageResponse, err error) {
+	if req.TokenContract != "" {
+		if err := types.ValidateEthAddress(req.TokenContract); err != nil {
+			return nil, nil, nil, sdkerrors.Wrap(types.ErrInvalid, err.Error())
+		}
+	}
+	var index []byte
+	switch req.Order {
+	case types.PENDING_SEND_TO_ETH_ORDER_ID:
+		index = types.OutgoingTXPoolKey
+	case types.PENDING_SEND_TO_ETH_ORDER_FEE:
+		index = types.PendingOutgoingTxByFeeKey
+	default:
+		return nil, nil, nil, sdkerrors.Wrapf(types.ErrInvalid, "order %d", req.Order)
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	res, err = query.FilteredPaginate(prefix.NewStore(store, index), limitPageRequest(req.Pagination), func(key, value []byte, accumulate bool) (bool, error) {
+		var tx *types.OutgoingTransferTx
+		if req.Order == types.PENDING_SEND_TO_ETH_ORDER_FEE {
+			tx, err = k.getPoolEntry(ctx, types.UInt64FromBytes(key[32:]))
 			if err != nil {
-				panic("Invalid id in tx index!")
+				return false, err
 			}
-			if cb(id, tx) {
-				return
+		} else {
+			tx = &types.OutgoingTransferTx{}
+			k.cdc.MustUnmarshalBinaryBare(value, tx)
+		}
+		switch {
+		case req.SenderAddress != "" && tx.Sender != req.SenderAddress:
+			return false, nil
+		case req.TokenContract != "" && !strings.EqualFold(tx.Erc20Token.Contract, req.TokenContract):
+			return false, nil
+		case !req.MinFee.IsNil() && tx.Erc20Fee.Amount.LT(req.MinFee):
+			return false, nil
+		}
+		if accumulate {
+			// only the unbatched transfers are in the fee index of their token
+			if store.Has(types.GetFeeSecondIndexKey(*tx.Erc20Fee, tx.Id)) {
+				unbatched = append(unbatched, tx)
+			} else {
+				inBatches = append(inBatches, tx)
 			}
 		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, nil, nil, err
 	}
+	return inBatches, unbatched, res, nil
 }
 
 // CreateBatchFees iterates over the outgoing pool and create batch token fee map
@@ -280,40 +615,28 @@ func (k Keeper) CreateBatchFees(ctx sdk.Context) (batchFees []*types.BatchFees)
 	iter := prefixStore.Iterator(nil, nil)
 	defer iter.Close()
 
+	params := k.GetParams(ctx)
 	batchFeesMap := make(map[string]*types.BatchFees)
 	txCountMap := make(map[string]int)
 
 	for ; iter.Valid(); iter.Next() {
-		var ids types.IDSet
-		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &ids)
-
 		// create a map to store the token contract address and its total fee
-		// Parse the iterator key to get contract address & fee
-		// If len(ids.Ids) > 1, multiply fee amount with len(ids.Ids) and add it to total fee amount
-
-		key := iter.Key()
-		tokenContractBytes := key[:types.ETHContractAddressLen]
-		tokenContractAddr := string(tokenContractBytes)
-
-		feeAmountBytes := key[len(tokenContractBytes):]
-		feeAmount := big.NewInt(0).SetBytes(feeAmountBytes)
+		// Parse the iterator key to get contract address & fee, there is one key per tx
+		tokenContractAddr, feeAmount, _ := types.ParseFeeSecondIndexKey(iter.Key())
 
-		for i := 0; i < len(ids.Ids); i++ {
-			if txCountMap[tokenContractAddr] >= OutgoingTxBatchSize {
-				break
-			} else {
-				// add fee amount
-				if _, ok := batchFeesMap[tokenContractAddr]; ok {
-					batchFeesMap[tokenContractAddr].TopOneHundred = batchFeesMap[tokenContractAddr].TopOneHundred.Add(sdk.NewIntFromBigInt(feeAmount))
-				} else {
-					batchFeesMap[tokenContractAddr] = &types.BatchFees{
-						Token:         tokenContractAddr,
-						TopOneHundred: sdk.NewIntFromBigInt(feeAmount)}
-				}
-
-				txCountMap[tokenContractAddr] = txCountMap[tokenContractAddr] + 1
-			}
+		if txCountMap[tokenContractAddr] >= batchTxLimit(params, tokenContractAddr) {
+			continue
 		}
+		// add fee amount
+		if _, ok := batchFeesMap[tokenContractAddr]; ok {
+			batchFeesMap[tokenContractAddr].TopOneHundred = batchFeesMap[tokenContractAddr].TopOneHundred.Add(feeAmount)
+		} else {
+			batchFeesMap[tokenContractAddr] = &types.BatchFees{
+				Token:         tokenContractAddr,
+				TopOneHundred: feeAmount}
+		}
+
+		txCountMap[tokenContractAddr] = txCountMap[tokenContractAddr] + 1
 	}
 
 	// create array of batchFees
@@ -324,6 +647,8 @@ func (k Keeper) CreateBatchFees(ctx sdk.Context) (batchFees []*types.BatchFees)
 		// }
 		batchFees = append(batchFees, batchFee)
 	}
+	// map iteration order is random, sort by token for a deterministic result
+	sort.Slice(batchFees, func(i, j int) bool { return batchFees[i].Token < batchFees[j].Token })
 
 	return
 }
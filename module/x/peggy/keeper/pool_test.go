@@ -3,14 +3,17 @@ package keeper
 import (
 	"math/big"
 	"testing"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestAddToOutgoingPool(t *testing.T) {
+	t.Parallel()
 	input := CreateTestEnv(t)
 	ctx := input.Context
 	var (
@@ -73,9 +76,568 @@ func TestAddToOutgoingPool(t *testing.T) {
 		},
 	}
 	assert.Equal(t, exp, got)
+
+	// batched transfers are skipped and the callback can stop early
+	_, err = input.PeggyKeeper.BuildOutgoingTXBatch(ctx, myTokenContractAddr, 1)
+	require.NoError(t, err)
+	var ids []uint64
+	input.PeggyKeeper.IterateOutgoingPool(ctx, func(tx *types.OutgoingTransferTx) bool {
+		ids = append(ids, tx.Id)
+		return len(ids) == 2
+	})
+	assert.Equal(t, []uint64{1, 3}, ids)
+}
+
+func TestAddToOutgoingPoolBridgeFee(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	var (
+		mySender, _         = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		myReceiver          = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		allVouchers         = sdk.Coins{types.NewERC20Token(1000, myTokenContractAddr).PeggyCoin()}
+	)
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, allVouchers))
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+	require.NoError(t, input.BankKeeper.SetBalances(ctx, mySender, allVouchers))
+
+	// 2.5% of the fee goes to the community pool
+	params := input.PeggyKeeper.GetParams(ctx)
+	params.BridgeFeeBasisPoints = 250
+	input.PeggyKeeper.SetParams(ctx, params)
+
+	amount := types.NewERC20Token(500, myTokenContractAddr).PeggyCoin()
+	fee := types.NewERC20Token(99, myTokenContractAddr).PeggyCoin()
+	id, err := input.PeggyKeeper.AddToOutgoingPool(ctx, mySender, myReceiver, amount, fee)
+	require.NoError(t, err)
+
+	// the cut is rounded down and only the remainder is paid on Ethereum
+	cut := types.NewERC20Token(2, myTokenContractAddr).PeggyCoin()
+	tx, err := input.PeggyKeeper.getPoolEntry(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, types.NewERC20Token(97, myTokenContractAddr), tx.Erc20Fee)
+	assert.Equal(t, sdk.NewDecCoinsFromCoins(cut), input.DistKeeper.GetFeePoolCommunityCoins(ctx))
+
+	// every voucher that left the sender is either burned, in the community pool or held by the fee collector
+	remaining := allVouchers.Sub(sdk.Coins{amount.Add(fee)})
+	assert.Equal(t, remaining, input.BankKeeper.GetAllBalances(ctx, mySender))
+	assert.Equal(t, sdk.Coins{fee.Sub(cut)}, input.BankKeeper.GetAllBalances(ctx, input.AccountKeeper.GetModuleAddress(types.FeeCollectorName)))
+	assert.Equal(t, remaining.Add(cut).Add(fee.Sub(cut)).AmountOf(cut.Denom), input.BankKeeper.GetSupply(ctx).GetTotal().AmountOf(cut.Denom))
+}
+
+func TestAddToOutgoingPoolNativeTokenCap(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	var (
+		mySender, _         = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		myReceiver          = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		fee                 = sdk.NewInt64Coin("stake", 1)
+	)
+	k.setCosmosOriginatedDenomToERC20(ctx, "stake", myTokenContractAddr)
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+	require.NoError(t, input.BankKeeper.SetBalances(ctx, mySender, sdk.Coins{sdk.NewInt64Coin("stake", 1000)}))
+
+	// the staking token isn't bridged unless the params allow it
+	_, err := k.AddToOutgoingPool(ctx, mySender, myReceiver, sdk.NewInt64Coin("stake", 100), fee)
+	assert.True(t, types.ErrUnsupported.Is(err), err)
+
+	params := k.GetParams(ctx)
+	params.BridgeNativeToken = true
+	params.NativeTokenBridgeCap = sdk.NewInt(250)
+	k.SetParams(ctx, params)
+
+	// the escrow may fill up to the cap but not beyond it
+	_, err = k.AddToOutgoingPool(ctx, mySender, myReceiver, sdk.NewInt64Coin("stake", 200), fee)
+	require.NoError(t, err)
+	_, err = k.AddToOutgoingPool(ctx, mySender, myReceiver, sdk.NewInt64Coin("stake", 51), fee)
+	assert.True(t, types.ErrInvalid.Is(err), err)
+	txID, err := k.AddToOutgoingPool(ctx, mySender, myReceiver, sdk.NewInt64Coin("stake", 50), fee)
+	require.NoError(t, err)
+	assert.Equal(t, sdk.NewInt(250), k.GetNativeTokenEscrow(ctx))
+	_, err = k.AddToOutgoingPool(ctx, mySender, myReceiver, sdk.NewInt64Coin("stake", 1), fee)
+	assert.True(t, types.ErrInvalid.Is(err), err)
+
+	// a refund makes room again, coins the escrow holds by other means don't count
+	require.NoError(t, k.RemoveFromOutgoingPoolAndRefund(ctx, txID, mySender))
+	assert.Equal(t, sdk.NewInt(200), k.GetNativeTokenEscrow(ctx))
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, sdk.Coins{sdk.NewInt64Coin("stake", 500)}))
+	_, err = k.AddToOutgoingPool(ctx, mySender, myReceiver, sdk.NewInt64Coin("stake", 50), fee)
+	require.NoError(t, err)
+
+	// the escrow is exported in the genesis
+	assert.Equal(t, sdk.NewInt(250), ExportGenesis(ctx, k).NativeTokenEscrow)
+
+	// without a cap the escrow can grow freely
+	params.NativeTokenBridgeCap = sdk.ZeroInt()
+	k.SetParams(ctx, params)
+	_, err = k.AddToOutgoingPool(ctx, mySender, myReceiver, sdk.NewInt64Coin("stake", 1), fee)
+	require.NoError(t, err)
+}
+
+func TestMigrateNativeTokenEscrow(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	escrow := sdk.Coins{sdk.NewInt64Coin("stake", 300)}
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, escrow))
+	params := k.GetParams(ctx)
+	params.BridgeNativeToken = false
+	k.SetParams(ctx, params)
+
+	// the staking token stays bridged, it only has an escrow once it has an ERC20
+	require.NoError(t, k.MigrateNativeTokenEscrow(ctx))
+	assert.True(t, k.GetParams(ctx).BridgeNativeToken)
+	assert.True(t, k.GetNativeTokenEscrow(ctx).IsZero())
+
+	k.setCosmosOriginatedDenomToERC20(ctx, "stake", "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5")
+	require.NoError(t, k.MigrateNativeTokenEscrow(ctx))
+	assert.Equal(t, sdk.NewInt(300), k.GetNativeTokenEscrow(ctx))
+}
+
+func TestRefundExpiredOutgoingTxs(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context.WithBlockHeight(10).WithBlockTime(time.Unix(1000, 0))
+	var (
+		mySender, _         = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		myReceiver          = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		allVouchers         = sdk.Coins{types.NewERC20Token(1000, myTokenContractAddr).PeggyCoin()}
+		amount              = types.NewERC20Token(100, myTokenContractAddr).PeggyCoin()
+		fee                 = types.NewERC20Token(1, myTokenContractAddr).PeggyCoin()
+	)
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, allVouchers))
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+	require.NoError(t, input.BankKeeper.SetBalances(ctx, mySender, allVouchers))
+
+	byHeight, err := input.PeggyKeeper.AddToOutgoingPool(ctx, mySender, myReceiver, amount, fee)
+	require.NoError(t, err)
+	byTime, err := input.PeggyKeeper.AddToOutgoingPool(ctx, mySender, myReceiver, amount, fee)
+	require.NoError(t, err)
+	never, err := input.PeggyKeeper.AddToOutgoingPool(ctx, mySender, myReceiver, amount, fee)
+	require.NoError(t, err)
+
+	// expirations must be in the future
+	require.Error(t, input.PeggyKeeper.SetOutgoingTxExpiration(ctx, byHeight, 10, 0))
+	require.Error(t, input.PeggyKeeper.SetOutgoingTxExpiration(ctx, byTime, 0, 1000))
+
+	require.NoError(t, input.PeggyKeeper.SetOutgoingTxExpiration(ctx, byHeight, 12, 0))
+	require.NoError(t, input.PeggyKeeper.SetOutgoingTxExpiration(ctx, byTime, 0, 1100))
+
+	poolIDs := func(ctx sdk.Context) []uint64 {
+		var ids []uint64
+		for _, tx := range input.PeggyKeeper.GetPoolTransactions(ctx) {
+			ids = append(ids, tx.Id)
+		}
+		return ids
+	}
+
+	// nothing expired yet
+	ctx = ctx.WithBlockHeight(11).WithBlockTime(time.Unix(1050, 0))
+	input.PeggyKeeper.RefundExpiredOutgoingTxs(ctx)
+	assert.ElementsMatch(t, []uint64{byHeight, byTime, never}, poolIDs(ctx))
+
+	// the height expiration is reached, expired txs aren't picked for a batch either
+	ctx = ctx.WithBlockHeight(12)
+	txs, err := input.PeggyKeeper.pickUnbatchedTX(ctx.WithMultiStore(ctx.MultiStore().CacheMultiStore()), myTokenContractAddr, OutgoingTxBatchSize)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	input.PeggyKeeper.RefundExpiredOutgoingTxs(ctx)
+	assert.ElementsMatch(t, []uint64{byTime, never}, poolIDs(ctx))
+
+	// then the time expiration
+	ctx = ctx.WithBlockTime(time.Unix(1100, 0))
+	input.PeggyKeeper.RefundExpiredOutgoingTxs(ctx)
+	assert.Equal(t, []uint64{never}, poolIDs(ctx))
+
+	// the expired transfers were refunded in full
+	remaining := allVouchers.Sub(sdk.Coins{amount.Add(fee)})
+	assert.Equal(t, remaining, input.BankKeeper.GetAllBalances(ctx, mySender))
+
+	// a refund that fails doesn't halt the chain, the tx leaves the expiration index and stays in the pool
+	broken := &types.OutgoingTransferTx{
+		Id:               100,
+		Sender:           "invalid",
+		DestAddress:      myReceiver,
+		Erc20Token:       types.NewERC20Token(100, myTokenContractAddr),
+		Erc20Fee:         types.NewERC20Token(1, myTokenContractAddr),
+		ExpirationHeight: 13,
+	}
+	require.NoError(t, input.PeggyKeeper.setPoolEntry(ctx, broken))
+	input.PeggyKeeper.addToUnbatchedTXIndex(ctx, broken)
+	ctx = ctx.WithBlockHeight(13)
+	input.PeggyKeeper.RefundExpiredOutgoingTxs(ctx)
+	assert.ElementsMatch(t, []uint64{never, broken.Id}, poolIDs(ctx))
+	assert.False(t, ctx.KVStore(input.PeggyKeeper.storeKey).Has(types.GetOutgoingTxByExpirationHeightKey(13, broken.Id)))
+}
+
+func TestMigrateOutgoingTxExpirationIndex(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context.WithBlockHeight(10).WithBlockTime(time.Unix(1000, 0))
+	k := input.PeggyKeeper
+	var (
+		mySender, _         = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		allVouchers         = sdk.Coins{types.NewERC20Token(1000, myTokenContractAddr).PeggyCoin()}
+	)
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, allVouchers))
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+	require.NoError(t, input.BankKeeper.SetBalances(ctx, mySender, allVouchers))
+	id, err := k.AddToOutgoingPool(ctx, mySender, "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7",
+		types.NewERC20Token(100, myTokenContractAddr).PeggyCoin(), types.NewERC20Token(1, myTokenContractAddr).PeggyCoin())
+	require.NoError(t, err)
+
+	// a tx that expired before the index and a cursor of the scan that refunded them
+	tx, err := k.getPoolEntry(ctx, id)
+	require.NoError(t, err)
+	tx.ExpirationTime = 1100
+	require.NoError(t, k.setPoolEntry(ctx, tx))
+	ctx.KVStore(k.storeKey).Set(types.GetWorkQueueKey(workTaskRefundExpiredTxs), types.GetOutgoingTxPoolKey(id))
+	k.RefundExpiredOutgoingTxs(ctx.WithBlockTime(time.Unix(1100, 0)))
+	assert.Len(t, k.GetPoolTransactions(ctx), 1)
+
+	require.NoError(t, k.MigrateOutgoingTxExpirationIndex(ctx))
+	assert.False(t, ctx.KVStore(k.storeKey).Has(types.GetWorkQueueKey(workTaskRefundExpiredTxs)))
+	k.RefundExpiredOutgoingTxs(ctx.WithBlockTime(time.Unix(1100, 0)))
+	assert.Empty(t, k.GetPoolTransactions(ctx))
+	assert.Equal(t, allVouchers, input.BankKeeper.GetAllBalances(ctx, mySender))
+}
+
+func TestMigratePendingOutgoingTxFeeIndex(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	var (
+		mySender, _         = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		allVouchers         = sdk.Coins{types.NewERC20Token(1000, myTokenContractAddr).PeggyCoin()}
+	)
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, allVouchers))
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+	require.NoError(t, input.BankKeeper.SetBalances(ctx, mySender, allVouchers))
+	for _, fee := range []uint64{1, 2} {
+		_, err := k.AddToOutgoingPool(ctx, mySender, "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7",
+			types.NewERC20Token(100, myTokenContractAddr).PeggyCoin(), types.NewERC20Token(fee, myTokenContractAddr).PeggyCoin())
+		require.NoError(t, err)
+	}
+	_, err := k.BuildOutgoingTXBatch(ctx, myTokenContractAddr, 1)
+	require.NoError(t, err)
+	byFee := func() []uint64 {
+		res, err := k.GetPendingSendToEth(sdk.WrapSDKContext(ctx), &types.QueryPendingSendToEth{Order: types.PENDING_SEND_TO_ETH_ORDER_FEE})
+		require.NoError(t, err)
+		var ids []uint64
+		for _, tx := range append(res.TransfersInBatches, res.UnbatchedTransfers...) {
+			ids = append(ids, tx.Id)
+		}
+		return ids
+	}
+
+	// the batched and unbatched txs stored before the index
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetPendingOutgoingTxByFeeKey(sdk.NewInt(1), 1))
+	store.Delete(types.GetPendingOutgoingTxByFeeKey(sdk.NewInt(2), 2))
+	assert.Empty(t, byFee())
+
+	require.NoError(t, k.MigratePendingOutgoingTxFeeIndex(ctx))
+	assert.Equal(t, []uint64{2, 1}, byFee())
+}
+
+func TestBumpOutgoingTxFee(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	var (
+		mySender, _         = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		otherSender, _      = sdk.AccAddressFromBech32("cosmos16ahjkfqxpp6lvfy9fpfnfjg39xr96qett0alj5")
+		myReceiver          = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		otherTokenAddr      = "0x0bc529c00C6401aEF6D220BE8C6Ea1667F6Ad93e"
+		allVouchers         = sdk.NewCoins(types.NewERC20Token(1000, myTokenContractAddr).PeggyCoin(), types.NewERC20Token(1000, otherTokenAddr).PeggyCoin())
+		amount              = types.NewERC20Token(100, myTokenContractAddr).PeggyCoin()
+	)
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, allVouchers))
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+	require.NoError(t, input.BankKeeper.SetBalances(ctx, mySender, allVouchers))
+
+	lowFee, err := input.PeggyKeeper.AddToOutgoingPool(ctx, mySender, myReceiver, amount, types.NewERC20Token(1, myTokenContractAddr).PeggyCoin())
+	require.NoError(t, err)
+	highFee, err := input.PeggyKeeper.AddToOutgoingPool(ctx, mySender, myReceiver, amount, types.NewERC20Token(3, myTokenContractAddr).PeggyCoin())
+	require.NoError(t, err)
+
+	bump := types.NewERC20Token(5, myTokenContractAddr).PeggyCoin()
+	// only the sender can bump, with the token of the transfer
+	require.Error(t, input.PeggyKeeper.BumpOutgoingTxFee(ctx, lowFee, otherSender, bump))
+	require.Error(t, input.PeggyKeeper.BumpOutgoingTxFee(ctx, lowFee, mySender, types.NewERC20Token(5, otherTokenAddr).PeggyCoin()))
+	require.Error(t, input.PeggyKeeper.BumpOutgoingTxFee(ctx, 99, mySender, bump))
+
+	// when
+	require.NoError(t, input.PeggyKeeper.BumpOutgoingTxFee(ctx, lowFee, mySender, bump))
+
+	// then the bumped tx comes first in the pool
+	txs := input.PeggyKeeper.GetPoolTransactions(ctx)
+	require.Len(t, txs, 2)
+	assert.Equal(t, lowFee, txs[0].Id)
+	assert.Equal(t, types.NewERC20Token(6, myTokenContractAddr), txs[0].Erc20Fee)
+	assert.Equal(t, highFee, txs[1].Id)
+	// two amounts of 100 and fees of 1, 3 and 5
+	expBalance := allVouchers.Sub(sdk.Coins{types.NewERC20Token(209, myTokenContractAddr).PeggyCoin()})
+	assert.Equal(t, expBalance, input.BankKeeper.GetAllBalances(ctx, mySender))
+
+	// and a cancel refunds the bumped fee too
+	require.NoError(t, input.PeggyKeeper.RemoveFromOutgoingPoolAndRefund(ctx, lowFee, mySender))
+	expBalance = expBalance.Add(types.NewERC20Token(106, myTokenContractAddr).PeggyCoin())
+	assert.Equal(t, expBalance, input.BankKeeper.GetAllBalances(ctx, mySender))
+
+	// batched txs can't be bumped
+	_, err = input.PeggyKeeper.BuildOutgoingTXBatch(ctx, myTokenContractAddr, OutgoingTxBatchSize)
+	require.NoError(t, err)
+	require.Error(t, input.PeggyKeeper.BumpOutgoingTxFee(ctx, highFee, mySender, bump))
+}
+
+func TestUnbatchedTransactionsQueries(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	var (
+		mySender, _     = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		myReceiver      = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		myTokenContract = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		otherContract   = "0x7D1AfA7B718fb893dB30A3aBc0Cfc608AaCfeBB0"
+		allVouchers     = sdk.Coins{
+			types.NewERC20Token(99999, myTokenContract).PeggyCoin(),
+			types.NewERC20Token(99999, otherContract).PeggyCoin(),
+		}
+	)
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, allVouchers))
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+	require.NoError(t, input.BankKeeper.SetBalances(ctx, mySender, allVouchers))
+
+	// ids 1-4 for my token, 5 for the other one
+	for _, v := range []uint64{2, 3, 2, 1} {
+		_, err := input.PeggyKeeper.AddToOutgoingPool(ctx, mySender, myReceiver, types.NewERC20Token(100, myTokenContract).PeggyCoin(), types.NewERC20Token(v, myTokenContract).PeggyCoin())
+		require.NoError(t, err)
+	}
+	_, err := input.PeggyKeeper.AddToOutgoingPool(ctx, mySender, myReceiver, types.NewERC20Token(100, otherContract).PeggyCoin(), types.NewERC20Token(1, otherContract).PeggyCoin())
+	require.NoError(t, err)
+
+	ids := func(txs []*types.OutgoingTransferTx) (out []uint64) {
+		for _, tx := range txs {
+			out = append(out, tx.Id)
+		}
+		return
+	}
+	c := sdk.WrapSDKContext(ctx)
+
+	// first page by offset
+	res, err := input.PeggyKeeper.UnbatchedTransactionsByContract(c, &types.QueryUnbatchedTransactionsByContractRequest{
+		TokenContract: myTokenContract,
+		Pagination:    &query.PageRequest{Limit: 3, CountTotal: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{2, 1, 3}, ids(res.Transactions))
+	assert.Equal(t, uint64(4), res.Pagination.Total)
+	require.NotNil(t, res.Pagination.NextKey)
+
+	// second page by key
+	res, err = input.PeggyKeeper.UnbatchedTransactionsByContract(c, &types.QueryUnbatchedTransactionsByContractRequest{
+		TokenContract: myTokenContract,
+		Pagination:    &query.PageRequest{Key: res.Pagination.NextKey, Limit: 3},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{4}, ids(res.Transactions))
+	assert.Nil(t, res.Pagination.NextKey)
+
+	// the whole pool is grouped by token
+	all, err := input.PeggyKeeper.AllUnbatchedTransactions(c, &types.QueryAllUnbatchedTransactionsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{2, 1, 3, 4, 5}, ids(all.Transactions))
+
+	_, err = input.PeggyKeeper.UnbatchedTransactionsByContract(c, &types.QueryUnbatchedTransactionsByContractRequest{TokenContract: "invalid"})
+	require.Error(t, err)
+}
+
+func TestPendingSendToEthQuery(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	var (
+		mySender, _     = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		myReceiver      = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		myTokenContract = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		otherContract   = "0x7D1AfA7B718fb893dB30A3aBc0Cfc608AaCfeBB0"
+		allVouchers     = sdk.Coins{
+			types.NewERC20Token(99999, myTokenContract).PeggyCoin(),
+			types.NewERC20Token(99999, otherContract).PeggyCoin(),
+		}
+	)
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, allVouchers))
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+	require.NoError(t, input.BankKeeper.SetBalances(ctx, mySender, allVouchers))
+
+	// ids 1-4 for my token, 5 for the other one, 2 and 1 end up in a batch
+	for _, v := range []uint64{2, 3, 2, 1} {
+		_, err := input.PeggyKeeper.AddToOutgoingPool(ctx, mySender, myReceiver, types.NewERC20Token(100, myTokenContract).PeggyCoin(), types.NewERC20Token(v, myTokenContract).PeggyCoin())
+		require.NoError(t, err)
+	}
+	_, err := input.PeggyKeeper.AddToOutgoingPool(ctx, mySender, myReceiver, types.NewERC20Token(100, otherContract).PeggyCoin(), types.NewERC20Token(4, otherContract).PeggyCoin())
+	require.NoError(t, err)
+	_, err = input.PeggyKeeper.BuildOutgoingTXBatch(ctx, myTokenContract, 2)
+	require.NoError(t, err)
+
+	ids := func(txs []*types.OutgoingTransferTx) (out []uint64) {
+		for _, tx := range txs {
+			out = append(out, tx.Id)
+		}
+		return
+	}
+	c := sdk.WrapSDKContext(ctx)
+
+	specs := map[string]struct {
+		req          types.QueryPendingSendToEth
+		expBatched   []uint64
+		expUnbatched []uint64
+		expNextKey   bool
+		expErr       bool
+	}{
+		"all by id": {
+			req:          types.QueryPendingSendToEth{SenderAddress: mySender.String()},
+			expBatched:   []uint64{1, 2},
+			expUnbatched: []uint64{3, 4, 5},
+		},
+		"all by fee": {
+			req:          types.QueryPendingSendToEth{SenderAddress: mySender.String(), Order: types.PENDING_SEND_TO_ETH_ORDER_FEE},
+			expBatched:   []uint64{2, 1},
+			expUnbatched: []uint64{5, 3, 4},
+		},
+		"token contract": {
+			req:          types.QueryPendingSendToEth{SenderAddress: mySender.String(), TokenContract: otherContract},
+			expUnbatched: []uint64{5},
+		},
+		"min fee": {
+			req:          types.QueryPendingSendToEth{SenderAddress: mySender.String(), MinFee: sdk.NewInt(3)},
+			expBatched:   []uint64{2},
+			expUnbatched: []uint64{5},
+		},
+		"page over both kinds": {
+			req:          types.QueryPendingSendToEth{SenderAddress: mySender.String(), Pagination: &query.PageRequest{Offset: 1, Limit: 2}},
+			expBatched:   []uint64{2},
+			expUnbatched: []uint64{3},
+			expNextKey:   true,
+		},
+		"other sender": {
+			req: types.QueryPendingSendToEth{SenderAddress: "cosmos1l2j8vaykh03zenzytntj3cza6zfxwlj68dd0l3"},
+		},
+		"invalid token contract": {
+			req:    types.QueryPendingSendToEth{TokenContract: "invalid"},
+			expErr: true,
+		},
+		"unknown order": {
+			req:    types.QueryPendingSendToEth{Order: 7},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			res, err := input.PeggyKeeper.GetPendingSendToEth(c, &spec.req)
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.expBatched, ids(res.TransfersInBatches))
+			assert.Equal(t, spec.expUnbatched, ids(res.UnbatchedTransfers))
+			assert.Equal(t, spec.expNextKey, res.Pagination.NextKey != nil)
+		})
+	}
+}
+
+func TestAllPendingSendToEth(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	var (
+		mySender, _     = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		otherSender     = AccAddrs[0]
+		myReceiver      = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		myTokenContract = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		allVouchers     = sdk.Coins{types.NewERC20Token(99999, myTokenContract).PeggyCoin()}
+	)
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, allVouchers.Add(allVouchers...)))
+	for _, sender := range []sdk.AccAddress{mySender, otherSender} {
+		input.AccountKeeper.NewAccountWithAddress(ctx, sender)
+		require.NoError(t, input.BankKeeper.SetBalances(ctx, sender, allVouchers))
+	}
+
+	// ids 1-3 from my sender, 4 from the other one, 2 ends up in a batch
+	for i, v := range []uint64{2, 3, 1, 1} {
+		sender := mySender
+		if i == 3 {
+			sender = otherSender
+		}
+		_, err := input.PeggyKeeper.AddToOutgoingPool(ctx, sender, myReceiver, types.NewERC20Token(100, myTokenContract).PeggyCoin(), types.NewERC20Token(v, myTokenContract).PeggyCoin())
+		require.NoError(t, err)
+	}
+	_, err := input.PeggyKeeper.BuildOutgoingTXBatch(ctx, myTokenContract, 1)
+	require.NoError(t, err)
+
+	ids := func(txs []*types.OutgoingTransferTx) (out []uint64) {
+		for _, tx := range txs {
+			out = append(out, tx.Id)
+		}
+		return
+	}
+	c := sdk.WrapSDKContext(ctx)
+
+	// without a sender the transfers of all senders are returned, in pages keyed by the pool store
+	res, err := input.PeggyKeeper.GetPendingSendToEth(c, &types.QueryPendingSendToEth{
+		Pagination: &query.PageRequest{Limit: 3, CountTotal: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{2}, ids(res.TransfersInBatches))
+	assert.Equal(t, []uint64{1, 3}, ids(res.UnbatchedTransfers))
+	assert.Equal(t, uint64(4), res.Pagination.Total)
+	require.NotNil(t, res.Pagination.NextKey)
+
+	res, err = input.PeggyKeeper.GetPendingSendToEth(c, &types.QueryPendingSendToEth{
+		Pagination: &query.PageRequest{Key: res.Pagination.NextKey, Limit: 3},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, res.TransfersInBatches)
+	assert.Equal(t, []uint64{4}, ids(res.UnbatchedTransfers))
+	assert.Nil(t, res.Pagination.NextKey)
+
+	// the all senders query pages the same way
+	all, err := input.PeggyKeeper.AllPendingSendToEth(c, &types.QueryAllPendingSendToEthRequest{
+		Pagination: &query.PageRequest{Limit: 3, CountTotal: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{2}, ids(all.TransfersInBatches))
+	assert.Equal(t, []uint64{1, 3}, ids(all.UnbatchedTransfers))
+	assert.Equal(t, uint64(4), all.Pagination.Total)
+	all, err = input.PeggyKeeper.AllPendingSendToEth(c, &types.QueryAllPendingSendToEthRequest{
+		Pagination: &query.PageRequest{Key: all.Pagination.NextKey, Limit: 3},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{4}, ids(all.UnbatchedTransfers))
+	assert.Nil(t, all.Pagination.NextKey)
+
+	// the fee order follows fee increases
+	require.NoError(t, input.PeggyKeeper.BumpOutgoingTxFee(ctx, 4, otherSender, types.NewERC20Token(5, myTokenContract).PeggyCoin()))
+	res, err = input.PeggyKeeper.GetPendingSendToEth(c, &types.QueryPendingSendToEth{Order: types.PENDING_SEND_TO_ETH_ORDER_FEE})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{2}, ids(res.TransfersInBatches))
+	assert.Equal(t, []uint64{4, 1, 3}, ids(res.UnbatchedTransfers))
 }
 
 func TestTotalBatchFeeInPool(t *testing.T) {
+	t.Parallel()
 	input := CreateTestEnv(t)
 	ctx := input.Context
 
@@ -0,0 +1,139 @@
+package keeper
+
+import (
+	"encoding/hex"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// PrunedHistory counts the entries PruneHistory deleted
+type PrunedHistory struct {
+	Valsets         int
+	ValsetConfirms  int
+	Attestations    int
+	BatchExecutions int
+}
+
+// PruneHistory deletes the bridge history an archive operator may not need: the attestations and batch
+// executions of events before beforeEventNonce and the valsets, with their confirms, created before
+// beforeHeight. A zero bound leaves the entries it applies to alone. Nothing the bridge still works with
+// is deleted whatever the bounds are: events above the last observed event nonce, the valsets that
+// were not slashed for yet, the valset last observed on Ethereum and the latest valset are kept.
+//
+// Deleting state changes the app hash, this is meant for an exported genesis or an upgrade handler and
+// never for the store of a running node.
+func (k Keeper) PruneHistory(ctx sdk.Context, beforeEventNonce, beforeHeight uint64) (pruned PrunedHistory) {
+	if beforeEventNonce != 0 {
+		if lastObserved := k.GetLastObservedEventNonce(ctx); beforeEventNonce > lastObserved+1 {
+			beforeEventNonce = lastObserved + 1
+		}
+		pruned.Attestations, pruned.BatchExecutions = k.pruneEventHistory(ctx, beforeEventNonce)
+	}
+	if beforeHeight != 0 {
+		pruned.Valsets, pruned.ValsetConfirms = k.pruneValsetHistory(ctx, beforeHeight)
+	}
+	return pruned
+}
+
+// pruneEventHistory deletes the attestations and batch executions of the events before beforeEventNonce
+func (k Keeper) pruneEventHistory(ctx sdk.Context, beforeEventNonce uint64) (attestations, executions int) {
+	var (
+		claims []types.EthereumClaim
+		atts   []types.Attestation
+	)
+	k.IterateAttestations(ctx, func(_ []byte, att types.Attestation) bool {
+		claim, err := k.UnpackAttestationClaim(&att)
+		if err != nil {
+			panic("couldn't cast to claim")
+		}
+		if claim.GetEventNonce() < beforeEventNonce {
+			claims = append(claims, claim)
+			atts = append(atts, att)
+		}
+		return false
+	})
+	for i, claim := range claims {
+		k.DeleteAttestation(ctx, claim.GetEventNonce(), claim.ClaimHash(), &atts[i])
+	}
+
+	var keys [][]byte
+	k.IterateBatchExecutions(ctx, func(execution types.BatchExecution) bool {
+		if execution.EventNonce < beforeEventNonce {
+			keys = append(keys, types.GetBatchExecutionKey(execution.TokenContract, execution.BatchNonce))
+		}
+		return false
+	})
+	store := ctx.KVStore(k.storeKey)
+	for _, key := range keys {
+		store.Delete(key)
+	}
+	return len(claims), len(keys)
+}
+
+// pruneValsetHistory deletes the valsets created before beforeHeight and their confirms, except for the
+// valsets the bridge still needs
+func (k Keeper) pruneValsetHistory(ctx sdk.Context, beforeHeight uint64) (valsets, confirms int) {
+	// valsets from keep on are still slashed for, are on Ethereum or are the latest one
+	keep := k.GetLastSlashedValsetNonce(ctx) + 1
+	if observed := k.GetLastObservedValsetNonce(ctx); observed < keep {
+		keep = observed
+	}
+	if latest := k.GetLatestValsetNonce(ctx); latest < keep {
+		keep = latest
+	}
+
+	var nonces []uint64
+	k.IterateValsets(ctx, func(_ []byte, valset *types.Valset) bool {
+		if valset.Nonce < keep && valset.Height < beforeHeight {
+			nonces = append(nonces, valset.Nonce)
+		}
+		return false
+	})
+	confirmStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.ValsetConfirmKey)
+	for _, nonce := range nonces {
+		var keys [][]byte
+		k.IterateValsetConfirmByNonce(ctx, nonce, func(key []byte, _ types.MsgValsetConfirm) bool {
+			keys = append(keys, key)
+			return false
+		})
+		for _, key := range keys {
+			confirmStore.Delete(key)
+		}
+		confirms += len(keys)
+		k.DeleteValset(ctx, nonce)
+	}
+	return len(nonces), confirms
+}
+
+// PruneOrphanedEntries deletes the batch confirms, logic call confirms and batch index entries of the
+// batches and logic calls that are no longer stored, on a running node within the EndBlocker work budget.
+// Each kind of entry is scanned from a cursor of its own that wraps around, so the store is pruned in
+// place while PruneHistory is only applied to an exported genesis.
+func (k Keeper) PruneOrphanedEntries(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	k.scanWithWorkBudget(ctx, workTaskPruneBatchConfirms, types.BatchConfirmKey, func(key, value []byte) {
+		var confirm types.MsgConfirmBatch
+		k.cdc.MustUnmarshalBinaryBare(value, &confirm)
+		if k.GetOutgoingTXBatch(ctx, confirm.TokenContract, confirm.Nonce) == nil {
+			store.Delete(append(append([]byte{}, types.BatchConfirmKey...), key...))
+		}
+	})
+	k.scanWithWorkBudget(ctx, workTaskPruneLogicCallConfirms, types.KeyOutgoingLogicConfirm, func(key, value []byte) {
+		var confirm types.MsgConfirmLogicCall
+		k.cdc.MustUnmarshalBinaryBare(value, &confirm)
+		invalidationID, err := hex.DecodeString(confirm.InvalidationId)
+		if err != nil || !k.hasOutgoingLogicCall(ctx, invalidationID, confirm.InvalidationNonce) {
+			store.Delete(append(append([]byte{}, types.KeyOutgoingLogicConfirm...), key...))
+		}
+	})
+	k.scanWithWorkBudget(ctx, workTaskPruneBatchedTxs, types.BatchedTxKey, func(_, value []byte) {
+		var batched types.BatchedTx
+		k.cdc.MustUnmarshalBinaryBare(value, &batched)
+		if k.GetOutgoingTXBatch(ctx, batched.TokenContract, batched.BatchNonce) == nil &&
+			k.GetCancelledBatch(ctx, batched.TokenContract, batched.BatchNonce) == nil {
+			k.deleteBatchedTx(ctx, batched.TxId)
+		}
+	})
+}
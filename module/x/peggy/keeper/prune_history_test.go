@@ -0,0 +1,87 @@
+package keeper
+
+import (
+	"encoding/hex"
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneHistory(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	tokenContract := TokenContractAddrs[0]
+
+	// valsets 1 to 5 created at their nonce, the ones up to 3 slashed for and 4 on Ethereum
+	for nonce := uint64(1); nonce <= 5; nonce++ {
+		k.StoreValsetUnsafe(ctx, &types.Valset{Nonce: nonce, Height: nonce})
+		k.SetValsetConfirm(ctx, types.MsgValsetConfirm{Nonce: nonce, Orchestrator: AccAddrs[0].String()})
+	}
+	k.SetLastSlashedValsetNonce(ctx, 3)
+	k.setLastObservedValsetNonce(ctx, 4)
+
+	// events 1 to 4, the ones up to 2 observed
+	for nonce := uint64(1); nonce <= 4; nonce++ {
+		claim := &types.MsgDepositClaim{EventNonce: nonce, TokenContract: tokenContract, Amount: sdk.NewInt(1)}
+		any, err := codectypes.NewAnyWithValue(claim)
+		require.NoError(t, err)
+		k.SetAttestation(ctx, nonce, claim.ClaimHash(), &types.Attestation{Observed: nonce <= 2, Claim: any})
+		k.SetBatchExecution(ctx, types.BatchExecution{TokenContract: tokenContract, BatchNonce: nonce, EventNonce: nonce})
+	}
+	k.setLastObservedEventNonce(ctx, 2)
+
+	assert.Equal(t, PrunedHistory{}, k.PruneHistory(ctx, 0, 0))
+
+	// events that are not observed yet and valsets that weren't slashed for are kept
+	pruned := k.PruneHistory(ctx, 10, 10)
+	assert.Equal(t, PrunedHistory{Valsets: 3, ValsetConfirms: 3, Attestations: 2, BatchExecutions: 2}, pruned)
+	for nonce := uint64(1); nonce <= 5; nonce++ {
+		assert.Equal(t, nonce > 3, k.GetValset(ctx, nonce) != nil, nonce)
+		assert.Equal(t, nonce > 3, len(k.GetValsetConfirms(ctx, nonce)) == 1, nonce)
+	}
+	for nonce := uint64(1); nonce <= 4; nonce++ {
+		assert.Equal(t, nonce > 2, len(k.GetAttestationsByNonce(ctx, nonce)) == 1, nonce)
+		assert.Equal(t, nonce > 2, k.GetBatchExecution(ctx, tokenContract, nonce) != nil, nonce)
+	}
+
+	// the bounds hold below the limits
+	k.SetLastSlashedValsetNonce(ctx, 5)
+	k.setLastObservedValsetNonce(ctx, 5)
+	assert.Equal(t, PrunedHistory{}, k.PruneHistory(ctx, 3, 4))
+	assert.Equal(t, PrunedHistory{Valsets: 1, ValsetConfirms: 1}, k.PruneHistory(ctx, 0, 5))
+	// the latest valset is kept
+	assert.NotNil(t, k.GetValset(ctx, 5))
+}
+
+func TestPruneOrphanedEntries(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	tokenContract := TokenContractAddrs[0]
+	invalidationID := []byte("invalidation-id")
+
+	// batch 1 and logic call 1 are stored, the entries of nonce 2 were left behind
+	k.StoreBatchUnsafe(ctx, &types.OutgoingTxBatch{TokenContract: tokenContract, BatchNonce: 1, Block: 1, Transactions: []*types.OutgoingTransferTx{
+		{Id: 1, Erc20Token: types.NewERC20Token(1, tokenContract), Erc20Fee: types.NewERC20Token(1, tokenContract)},
+	}})
+	k.SetOutgoingLogicCall(ctx, &types.OutgoingLogicCall{InvalidationId: invalidationID, InvalidationNonce: 1})
+	for nonce := uint64(1); nonce <= 2; nonce++ {
+		k.SetBatchConfirm(ctx, &types.MsgConfirmBatch{Nonce: nonce, TokenContract: tokenContract, Orchestrator: AccAddrs[0].String()})
+		k.SetLogicCallConfirm(ctx, &types.MsgConfirmLogicCall{InvalidationId: hex.EncodeToString(invalidationID), InvalidationNonce: nonce, Orchestrator: AccAddrs[0].String()})
+	}
+	k.SetBatchedTx(ctx, types.BatchedTx{TxId: 2, TokenContract: tokenContract, BatchNonce: 2})
+
+	k.PruneOrphanedEntries(k.WithWorkBudget(ctx))
+	for nonce := uint64(1); nonce <= 2; nonce++ {
+		assert.Equal(t, nonce == 1, k.GetBatchConfirm(ctx, nonce, tokenContract, AccAddrs[0]) != nil, nonce)
+		assert.Equal(t, nonce == 1, k.GetLogicCallConfirm(ctx, invalidationID, nonce, AccAddrs[0]) != nil, nonce)
+		assert.Equal(t, nonce == 1, k.GetBatchedTx(ctx, nonce) != nil, nonce)
+	}
+}
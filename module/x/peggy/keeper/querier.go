@@ -7,6 +7,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/gogo/protobuf/proto"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
@@ -31,15 +32,21 @@ const (
 	QueryLastPendingValsetRequestByAddr = "lastPendingValsetRequest"
 
 	QueryCurrentValset = "currentValset"
+	// Gets the power difference between the current valset and the latest
+	// valset request, showing how stale the signer set on Ethereum is
+	QueryValsetDiff = "valsetDiff"
 	// TODO remove this, it's not used, getting one confirm at a time
 	// is mostly useless
 	QueryValsetConfirm = "valsetConfirm"
 
-	// used by the contract deployer script. PeggyID is set in the Genesis
+	// used by the contract deployer script. GravityID is set in the Genesis
 	// file, then read by the contract deployer and deployed to Ethereum
-	// a unique PeggyID ensures that even if the same validator set with
+	// a unique GravityID ensures that even if the same validator set with
 	// the same keys is running on two chains these chains can have independent
 	// bridges
+	QueryGravityID = "gravityID"
+	// QueryPeggyID is the name of the QueryGravityID route before the rename,
+	// kept for the contract deployer script
 	QueryPeggyID = "peggyID"
 
 	// Batches
@@ -88,61 +95,85 @@ const (
 
 	// Query pending transactions
 	QueryPendingSendToEth = "PendingSendToEth"
+	// This retrieves the batch an outgoing transfer is in and the status of the batch
+	QueryBatchForTx = "batchForTx"
+
+	// Debugging, only answered by nodes started with the debug queries enabled
+	// This retrieves every entry of the peggy store as a protobuf encoded StoreDump
+	QueryDebugDump = "debugDump"
 )
 
-// NewQuerier is the module level router for state queries
-func NewQuerier(keeper Keeper) sdk.Querier {
+// NewQuerier is the module level router for state queries. Results are the proto-JSON
+// of the matching gRPC response type, so that clients of the legacy REST endpoints see
+// the same field names and integer encodings as gRPC clients. A path starting with
+// types.QuerierLegacyAmino returns the amino JSON of the bare result instead.
+func NewQuerier(keeper PeggyKeeper) sdk.Querier {
 	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err error) {
+		enc := protoJSONEncoder
+		if len(path) > 0 && path[0] == types.QuerierLegacyAmino {
+			enc, path = legacyAminoEncoder, path[1:]
+		}
+		if len(path) == 0 {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unknown %s query endpoint", types.ModuleName)
+		}
 		switch path[0] {
 
 		// Valsets
 		case QueryCurrentValset:
-			return queryCurrentValset(ctx, keeper)
+			return queryCurrentValset(ctx, keeper, enc)
+		case QueryValsetDiff:
+			return queryValsetDiff(ctx, keeper, enc)
 		case QueryValsetRequest:
-			return queryValsetRequest(ctx, path[1:], keeper)
+			return queryValsetRequest(ctx, path[1:], keeper, enc)
 		case QueryValsetConfirm:
-			return queryValsetConfirm(ctx, path[1:], keeper)
+			return queryValsetConfirm(ctx, path[1:], keeper, enc)
 		case QueryValsetConfirmsByNonce:
-			return queryAllValsetConfirms(ctx, path[1], keeper)
+			return queryAllValsetConfirms(ctx, path[1], keeper, enc)
 		case QueryLastValsetRequests:
-			return lastValsetRequests(ctx, keeper)
+			return lastValsetRequests(ctx, keeper, enc)
 		case QueryLastPendingValsetRequestByAddr:
-			return lastPendingValsetRequest(ctx, path[1], keeper)
+			return lastPendingValsetRequest(ctx, path[1], keeper, enc)
 
 		// Batches
 		case QueryBatch:
-			return queryBatch(ctx, path[1], path[2], keeper)
+			return queryBatch(ctx, path[1], path[2], keeper, enc)
 		case QueryBatchConfirms:
-			return queryAllBatchConfirms(ctx, path[1], path[2], keeper)
+			return queryAllBatchConfirms(ctx, path[1], path[2], keeper, enc)
 		case QueryLastPendingBatchRequestByAddr:
-			return lastPendingBatchRequest(ctx, path[1], keeper)
+			return lastPendingBatchRequest(ctx, path[1], keeper, enc)
 		case QueryOutgoingTxBatches:
-			return lastBatchesRequest(ctx, keeper)
+			return lastBatchesRequest(ctx, keeper, enc)
 		case QueryBatchFees:
-			return queryBatchFees(ctx, keeper)
+			return queryBatchFees(ctx, keeper, enc)
 
 		// Logic calls
 		case QueryLogicCall:
-			return queryLogicCall(ctx, path[1], path[2], keeper)
+			return queryLogicCall(ctx, path[1], path[2], keeper, enc)
 		case QueryLogicCallConfirms:
-			return queryAllLogicCallConfirms(ctx, path[1], path[2], keeper)
+			return queryAllLogicCallConfirms(ctx, path[1], path[2], keeper, enc)
 		case QueryLastPendingLogicCallByAddr:
-			return lastPendingLogicCallRequest(ctx, path[1], keeper)
+			return lastPendingLogicCallRequest(ctx, path[1], keeper, enc)
 		case QueryOutgoingLogicCalls:
-			return lastLogicCallRequests(ctx, keeper)
+			return lastLogicCallRequests(ctx, keeper, enc)
 
-		case QueryPeggyID:
-			return queryPeggyID(ctx, keeper)
+		case QueryGravityID, QueryPeggyID:
+			return queryGravityID(ctx, keeper, enc)
 
 		// Token mappings
 		case QueryDenomToERC20:
-			return queryDenomToERC20(ctx, path[1], keeper)
+			return queryDenomToERC20(ctx, path[1], keeper, enc)
 		case QueryERC20ToDenom:
-			return queryERC20ToDenom(ctx, path[1], keeper)
+			return queryERC20ToDenom(ctx, path[1], keeper, enc)
 
 		// Pending transactions
 		case QueryPendingSendToEth:
-			return queryPendingSendToEth(ctx, path[1], keeper)
+			return queryPendingSendToEth(ctx, path[1], req.Data, keeper, enc)
+		case QueryBatchForTx:
+			return queryBatchForTx(ctx, path[1], keeper, enc)
+
+		// Debugging
+		case QueryDebugDump:
+			return queryDebugDump(ctx, keeper)
 
 		default:
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unknown %s query endpoint", types.ModuleName)
@@ -150,7 +181,31 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 	}
 }
 
-func queryValsetRequest(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+// querierEncoder encodes the result of a querier route, legacy is the value the route
+// returned before the gRPC response types and res is the gRPC response
+type querierEncoder func(legacy interface{}, res proto.Message) ([]byte, error)
+
+// protoJSONEncoder encodes the gRPC response with the proto-JSON encoding used by the
+// gRPC gateway
+func protoJSONEncoder(_ interface{}, res proto.Message) ([]byte, error) {
+	bz, err := codec.ProtoMarshalJSON(res, nil)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+// legacyAminoEncoder encodes the legacy value with the module amino codec, which wraps
+// registered types into type/value objects
+func legacyAminoEncoder(legacy interface{}, _ proto.Message) ([]byte, error) {
+	bz, err := codec.MarshalJSONIndent(types.ModuleCdc, legacy)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryValsetRequest(ctx sdk.Context, path []string, keeper PeggyKeeper, enc querierEncoder) ([]byte, error) {
 	nonce, err := types.UInt64FromString(path[0])
 	if err != nil {
 		return nil, err
@@ -160,19 +215,12 @@ func queryValsetRequest(ctx sdk.Context, path []string, keeper Keeper) ([]byte,
 	if valset == nil {
 		return nil, nil
 	}
-	// TODO: replace these with the GRPC response types
-	// TODO: fix the use of module codec here
-	res, err := codec.MarshalJSONIndent(types.ModuleCdc, valset)
-	if err != nil {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
-	}
-
-	return res, nil
+	return enc(valset, &types.QueryValsetRequestResponse{Valset: valset})
 }
 
 // allValsetConfirmsByNonce returns all the confirm messages for a given nonce
 // When nothing found an empty json array is returned. No pagination.
-func queryAllValsetConfirms(ctx sdk.Context, nonceStr string, keeper Keeper) ([]byte, error) {
+func queryAllValsetConfirms(ctx sdk.Context, nonceStr string, keeper PeggyKeeper, enc querierEncoder) ([]byte, error) {
 	nonce, err := types.UInt64FromString(nonceStr)
 	if err != nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
@@ -186,42 +234,34 @@ func queryAllValsetConfirms(ctx sdk.Context, nonceStr string, keeper Keeper) ([]
 	if len(confirms) == 0 {
 		return nil, nil
 	}
-	res, err := codec.MarshalJSONIndent(types.ModuleCdc, confirms)
-	if err != nil {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
-	}
-
-	return res, nil
+	return enc(confirms, &types.QueryValsetConfirmsByNonceResponse{Confirms: confirms})
 }
 
 // allBatchConfirms returns all the confirm messages for a given nonce
 // When nothing found an empty json array is returned. No pagination.
-func queryAllBatchConfirms(ctx sdk.Context, nonceStr string, tokenContract string, keeper Keeper) ([]byte, error) {
+func queryAllBatchConfirms(ctx sdk.Context, nonceStr string, tokenContract string, keeper PeggyKeeper, enc querierEncoder) ([]byte, error) {
 	nonce, err := types.UInt64FromString(nonceStr)
 	if err != nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
 	}
 
 	var confirms []types.MsgConfirmBatch
+	var res types.QueryBatchConfirmsResponse
 	keeper.IterateBatchConfirmByNonceAndTokenContract(ctx, nonce, tokenContract, func(_ []byte, c types.MsgConfirmBatch) bool {
 		confirms = append(confirms, c)
+		res.Confirms = append(res.Confirms, &c)
 		return false
 	})
 	if len(confirms) == 0 {
 		return nil, nil
 	}
-	res, err := codec.MarshalJSONIndent(types.ModuleCdc, confirms)
-	if err != nil {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
-	}
-
-	return res, nil
+	return enc(confirms, &res)
 }
 
 const maxValsetRequestsReturned = 5
 
 // lastValsetRequests returns up to maxValsetRequestsReturned valsets from the store
-func lastValsetRequests(ctx sdk.Context, keeper Keeper) ([]byte, error) {
+func lastValsetRequests(ctx sdk.Context, keeper PeggyKeeper, enc querierEncoder) ([]byte, error) {
 	var counter int
 	var valReq []*types.Valset
 	keeper.IterateValsets(ctx, func(_ []byte, val *types.Valset) bool {
@@ -232,61 +272,44 @@ func lastValsetRequests(ctx sdk.Context, keeper Keeper) ([]byte, error) {
 	if len(valReq) == 0 {
 		return nil, nil
 	}
-	res, err := codec.MarshalJSONIndent(types.ModuleCdc, valReq)
-	if err != nil {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
-	}
-	return res, nil
+	return enc(valReq, &types.QueryLastValsetRequestsResponse{Valsets: valReq})
 }
 
-// lastPendingValsetRequest gets a list of validator sets that this validator has not signed
-// limited by 100 sets per request.
-func lastPendingValsetRequest(ctx sdk.Context, operatorAddr string, keeper Keeper) ([]byte, error) {
+// lastPendingValsetRequest gets the oldest validator sets that this validator has not signed
+// in ascending nonce order, limited by 100 sets per request.
+func lastPendingValsetRequest(ctx sdk.Context, operatorAddr string, keeper PeggyKeeper, enc querierEncoder) ([]byte, error) {
 	addr, err := sdk.AccAddressFromBech32(operatorAddr)
 	if err != nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "address invalid")
 	}
 
-	var pendingValsetReq []*types.Valset
-	keeper.IterateValsets(ctx, func(_ []byte, val *types.Valset) bool {
-		// foundConfirm is true if the operatorAddr has signed the valset we are currently looking at
-		foundConfirm := keeper.GetValsetConfirm(ctx, val.Nonce, addr) != nil
-		// if this valset has NOT been signed by operatorAddr, store it in pendingValsetReq
-		// and exit the loop
-		if !foundConfirm {
-			pendingValsetReq = append(pendingValsetReq, val)
-		}
-		// if we have more than 100 unconfirmed requests in
-		// our array we should exit, TODO pagination
-		if len(pendingValsetReq) > 100 {
-			return true
-		}
-		// return false to continue the loop
-		return false
-	})
-	if len(pendingValsetReq) == 0 {
-		return nil, nil
-	}
-	res, err := codec.MarshalJSONIndent(types.ModuleCdc, pendingValsetReq)
+	res, err := keeper.LastPendingValsetRequestByAddr(sdk.WrapSDKContext(ctx), &types.QueryLastPendingValsetRequestByAddrRequest{Address: addr.String()})
 	if err != nil {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+		return nil, err
+	}
+	if len(res.Valsets) == 0 {
+		return nil, nil
 	}
-	return res, nil
+	return enc(res.Valsets, res)
 }
 
-func queryCurrentValset(ctx sdk.Context, keeper Keeper) ([]byte, error) {
+func queryCurrentValset(ctx sdk.Context, keeper PeggyKeeper, enc querierEncoder) ([]byte, error) {
 	valset := keeper.GetCurrentValset(ctx)
-	res, err := codec.MarshalJSONIndent(types.ModuleCdc, valset)
+	return enc(valset, &types.QueryCurrentValsetResponse{Valset: valset})
+}
+
+// queryValsetDiff returns the power difference between the current valset and the latest valset request
+func queryValsetDiff(ctx sdk.Context, k PeggyKeeper, enc querierEncoder) ([]byte, error) {
+	res, err := k.ValsetDiff(sdk.WrapSDKContext(ctx), &types.QueryValsetDiffRequest{})
 	if err != nil {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+		return nil, err
 	}
-
-	return res, nil
+	return enc(res, res)
 }
 
 // queryValsetConfirm returns the confirm msg for single orchestrator address and nonce
 // When nothing found a nil value is returned
-func queryValsetConfirm(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+func queryValsetConfirm(ctx sdk.Context, path []string, keeper PeggyKeeper, enc querierEncoder) ([]byte, error) {
 	nonce, err := types.UInt64FromString(path[0])
 	if err != nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
@@ -297,16 +320,11 @@ func queryValsetConfirm(ctx sdk.Context, path []string, keeper Keeper) ([]byte,
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
 	}
 
-	valset := keeper.GetValsetConfirm(ctx, nonce, accAddress)
-	if valset == nil {
+	confirm := keeper.GetValsetConfirm(ctx, nonce, accAddress)
+	if confirm == nil {
 		return nil, nil
 	}
-	res, err := codec.MarshalJSONIndent(types.ModuleCdc, *valset)
-	if err != nil {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
-	}
-
-	return res, nil
+	return enc(*confirm, &types.QueryValsetConfirmResponse{Confirm: confirm})
 }
 
 type MultiSigUpdateResponse struct {
@@ -315,78 +333,60 @@ type MultiSigUpdateResponse struct {
 }
 
 // lastPendingBatchRequest gets the latest batch that has NOT been signed by operatorAddr
-func lastPendingBatchRequest(ctx sdk.Context, operatorAddr string, keeper Keeper) ([]byte, error) {
+func lastPendingBatchRequest(ctx sdk.Context, operatorAddr string, keeper PeggyKeeper, enc querierEncoder) ([]byte, error) {
 	addr, err := sdk.AccAddressFromBech32(operatorAddr)
 	if err != nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "address invalid")
 	}
 
-	var pendingBatchReq *types.OutgoingTxBatch
-	keeper.IterateOutgoingTXBatches(ctx, func(_ []byte, batch *types.OutgoingTxBatch) bool {
-		foundConfirm := keeper.GetBatchConfirm(ctx, batch.BatchNonce, batch.TokenContract, addr) != nil
-		if !foundConfirm {
-			pendingBatchReq = batch
-			return true
-		}
-		return false
-	})
-	if pendingBatchReq == nil {
-		return nil, nil
-	}
-	res, err := codec.MarshalJSONIndent(types.ModuleCdc, pendingBatchReq)
+	res, err := keeper.LastPendingBatchRequestByAddr(sdk.WrapSDKContext(ctx), &types.QueryLastPendingBatchRequestByAddrRequest{Address: addr.String()})
 	if err != nil {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+		return nil, err
+	}
+	if res.Batch == nil {
+		return nil, nil
 	}
-	return res, nil
+	return enc(res.Batch, res)
 }
 
-const MaxResults = 100 // todo: impl pagination
+// MaxResults caps the number of entries returned by the queries without pagination, their responses
+// are flagged as truncated when more entries exist
+const MaxResults = 100
 
 // Gets MaxResults batches from store. Does not select by token type or anything
-func lastBatchesRequest(ctx sdk.Context, keeper Keeper) ([]byte, error) {
-	var batches []*types.OutgoingTxBatch
-	keeper.IterateOutgoingTXBatches(ctx, func(_ []byte, batch *types.OutgoingTxBatch) bool {
-		batches = append(batches, batch)
-		return len(batches) == MaxResults
-	})
-	if len(batches) == 0 {
-		return nil, nil
-	}
-	res, err := codec.MarshalJSONIndent(types.ModuleCdc, batches)
+func lastBatchesRequest(ctx sdk.Context, keeper PeggyKeeper, enc querierEncoder) ([]byte, error) {
+	res, err := keeper.OutgoingTxBatches(sdk.WrapSDKContext(ctx), &types.QueryOutgoingTxBatchesRequest{})
 	if err != nil {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+		return nil, err
+	}
+	if len(res.Batches) == 0 {
+		return nil, nil
 	}
-	return res, nil
+	return enc(res.Batches, res)
 }
 
-func queryBatchFees(ctx sdk.Context, keeper Keeper) ([]byte, error) {
-	val := types.QueryBatchFeeResponse{BatchFees: keeper.CreateBatchFees(ctx)}
-	res, err := codec.MarshalJSONIndent(types.ModuleCdc, val)
+func queryBatchFees(ctx sdk.Context, keeper PeggyKeeper, enc querierEncoder) ([]byte, error) {
+	res, err := keeper.BatchFees(sdk.WrapSDKContext(ctx), &types.QueryBatchFeeRequest{})
 	if err != nil {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+		return nil, err
 	}
-	return res, nil
+	return enc(*res, res)
 }
 
 // Gets MaxResults logic calls from store.
-func lastLogicCallRequests(ctx sdk.Context, keeper Keeper) ([]byte, error) {
-	var calls []*types.OutgoingLogicCall
-	keeper.IterateOutgoingLogicCalls(ctx, func(_ []byte, call *types.OutgoingLogicCall) bool {
-		calls = append(calls, call)
-		return len(calls) == MaxResults
-	})
-	if len(calls) == 0 {
-		return nil, nil
-	}
-	res, err := codec.MarshalJSONIndent(types.ModuleCdc, calls)
+func lastLogicCallRequests(ctx sdk.Context, keeper PeggyKeeper, enc querierEncoder) ([]byte, error) {
+	res, err := keeper.OutgoingLogicCalls(sdk.WrapSDKContext(ctx), &types.QueryOutgoingLogicCallsRequest{})
 	if err != nil {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+		return nil, err
 	}
-	return res, nil
+	if len(res.Calls) == 0 {
+		return nil, nil
+	}
+	return enc(res.Calls, res)
 }
 
 // queryBatch gets a batch by tokenContract and nonce
-func queryBatch(ctx sdk.Context, nonce string, tokenContract string, keeper Keeper) ([]byte, error) {
+func queryBatch(ctx sdk.Context, nonce string, tokenContract string, keeper PeggyKeeper, enc querierEncoder) ([]byte, error) {
 	parsedNonce, err := types.UInt64FromString(nonce)
 	if err != nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, err.Error())
@@ -398,41 +398,35 @@ func queryBatch(ctx sdk.Context, nonce string, tokenContract string, keeper Keep
 	if foundBatch == nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "Can not find tx batch")
 	}
-	res, err := codec.MarshalJSONIndent(types.ModuleCdc, foundBatch)
+	res, err := keeper.BatchRequestByNonce(sdk.WrapSDKContext(ctx), &types.QueryBatchRequestByNonceRequest{
+		Nonce:           parsedNonce,
+		ContractAddress: tokenContract,
+	})
 	if err != nil {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, err.Error())
+		return nil, err
 	}
-	return res, nil
+	return enc(foundBatch, res)
 }
 
 // lastPendingLogicCallRequest gets the latest call that has NOT been signed by operatorAddr
-func lastPendingLogicCallRequest(ctx sdk.Context, operatorAddr string, keeper Keeper) ([]byte, error) {
+func lastPendingLogicCallRequest(ctx sdk.Context, operatorAddr string, keeper PeggyKeeper, enc querierEncoder) ([]byte, error) {
 	addr, err := sdk.AccAddressFromBech32(operatorAddr)
 	if err != nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "address invalid")
 	}
 
-	var pendingLogicCalls *types.OutgoingLogicCall
-	keeper.IterateOutgoingLogicCalls(ctx, func(_ []byte, call *types.OutgoingLogicCall) bool {
-		foundConfirm := keeper.GetLogicCallConfirm(ctx, call.InvalidationId, call.InvalidationNonce, addr) != nil
-		if !foundConfirm {
-			pendingLogicCalls = call
-			return true
-		}
-		return false
-	})
-	if pendingLogicCalls == nil {
-		return nil, nil
-	}
-	res, err := codec.MarshalJSONIndent(types.ModuleCdc, pendingLogicCalls)
+	res, err := keeper.LastPendingLogicCallByAddr(sdk.WrapSDKContext(ctx), &types.QueryLastPendingLogicCallByAddrRequest{Address: addr.String()})
 	if err != nil {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+		return nil, err
 	}
-	return res, nil
+	if res.Call == nil {
+		return nil, nil
+	}
+	return enc(res.Call, res)
 }
 
 // queryLogicCall gets a logic call by nonce and invalidation id
-func queryLogicCall(ctx sdk.Context, invalidationId string, invalidationNonce string, keeper Keeper) ([]byte, error) {
+func queryLogicCall(ctx sdk.Context, invalidationId string, invalidationNonce string, keeper PeggyKeeper, enc querierEncoder) ([]byte, error) {
 	nonce, err := types.UInt64FromString(invalidationNonce)
 	if err != nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
@@ -442,16 +436,12 @@ func queryLogicCall(ctx sdk.Context, invalidationId string, invalidationNonce st
 	if foundCall == nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "Can not find logic call")
 	}
-	res, err := codec.MarshalJSONIndent(types.ModuleCdc, foundCall)
-	if err != nil {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, err.Error())
-	}
-	return res, nil
+	return enc(foundCall, &types.QueryLogicCallResponse{Call: foundCall})
 }
 
 // allLogicCallConfirms returns all the confirm messages for a given nonce
 // When nothing found an empty json array is returned. No pagination.
-func queryAllLogicCallConfirms(ctx sdk.Context, invalidationId string, invalidationNonce string, keeper Keeper) ([]byte, error) {
+func queryAllLogicCallConfirms(ctx sdk.Context, invalidationId string, invalidationNonce string, keeper PeggyKeeper, enc querierEncoder) ([]byte, error) {
 	nonce, err := types.UInt64FromString(invalidationNonce)
 	if err != nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
@@ -469,74 +459,72 @@ func queryAllLogicCallConfirms(ctx sdk.Context, invalidationId string, invalidat
 	if len(confirms) == 0 {
 		return nil, nil
 	}
-	res, err := codec.MarshalJSONIndent(types.ModuleCdc, confirms)
-	if err != nil {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
-	}
-
-	return res, nil
+	return enc(confirms, &types.QueryLogicConfirmsResponse{Confirms: confirms})
 }
 
-func queryPeggyID(ctx sdk.Context, keeper Keeper) ([]byte, error) {
-	peggyID := keeper.GetPeggyID(ctx)
-	res, err := codec.MarshalJSONIndent(types.ModuleCdc, peggyID)
-	if err != nil {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
-	} else {
-		return res, nil
-	}
+func queryGravityID(ctx sdk.Context, keeper PeggyKeeper, enc querierEncoder) ([]byte, error) {
+	gravityID := keeper.GetGravityID(ctx)
+	return enc(gravityID, &types.QueryGravityIDResponse{GravityId: gravityID})
 }
 
-func queryDenomToERC20(ctx sdk.Context, denom string, keeper Keeper) ([]byte, error) {
+func queryDenomToERC20(ctx sdk.Context, denom string, keeper PeggyKeeper, enc querierEncoder) ([]byte, error) {
 	cosmos_originated, erc20, err := keeper.DenomToERC20Lookup(ctx, denom)
 	if err != nil {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+		return nil, err
 	}
 	var response types.QueryDenomToERC20Response
 	response.CosmosOriginated = cosmos_originated
 	response.Erc20 = erc20
-	bytes, err := codec.MarshalJSONIndent(types.ModuleCdc, response)
-	if err != nil {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
-	} else {
-		return bytes, nil
-	}
+	return enc(response, &response)
 }
 
-func queryERC20ToDenom(ctx sdk.Context, ERC20 string, keeper Keeper) ([]byte, error) {
+func queryERC20ToDenom(ctx sdk.Context, ERC20 string, keeper PeggyKeeper, enc querierEncoder) ([]byte, error) {
 	cosmos_originated, denom := keeper.ERC20ToDenomLookup(ctx, ERC20)
 	var response types.QueryERC20ToDenomResponse
 	response.CosmosOriginated = cosmos_originated
 	response.Denom = denom
-	bytes, err := codec.MarshalJSONIndent(types.ModuleCdc, response)
+	return enc(response, &response)
+}
+
+// queryPendingSendToEth returns the pending transfers of the sender in the path, the request data
+// may hold a JSON encoded QueryPendingSendToEth with further filters, ordering and pagination
+func queryPendingSendToEth(ctx sdk.Context, senderAddr string, data []byte, k PeggyKeeper, enc querierEncoder) ([]byte, error) {
+	var req types.QueryPendingSendToEth
+	if len(data) != 0 {
+		if err := types.ModuleCdc.UnmarshalJSON(data, &req); err != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+		}
+	}
+	req.SenderAddress = senderAddr
+	res, err := k.GetPendingSendToEth(sdk.WrapSDKContext(ctx), &req)
 	if err != nil {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
-	} else {
-		return bytes, nil
+		return nil, err
 	}
+	return enc(res, res)
 }
 
-func queryPendingSendToEth(ctx sdk.Context, senderAddr string, k Keeper) ([]byte, error) {
-	batches := k.GetOutgoingTxBatches(ctx)
-	unbatched_tx := k.GetPoolTransactions(ctx)
-	sender_address := senderAddr
-	res := types.QueryPendingSendToEthResponse{}
-	for _, batch := range batches {
-		for _, tx := range batch.Transactions {
-			if tx.Sender == sender_address {
-				res.TransfersInBatches = append(res.TransfersInBatches, tx)
-			}
-		}
+// queryBatchForTx returns the batch an outgoing transfer is in and the status of the batch
+func queryBatchForTx(ctx sdk.Context, txIDStr string, k PeggyKeeper, enc querierEncoder) ([]byte, error) {
+	txID, err := types.UInt64FromString(txIDStr)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, err.Error())
 	}
-	for _, tx := range unbatched_tx {
-		if tx.Sender == sender_address {
-			res.UnbatchedTransfers = append(res.UnbatchedTransfers, tx)
-		}
+	res, err := k.BatchForTx(sdk.WrapSDKContext(ctx), &types.QueryBatchForTxRequest{TxId: txID})
+	if err != nil {
+		return nil, err
 	}
-	bytes, err := codec.MarshalJSONIndent(types.ModuleCdc, res)
+	return enc(res, res)
+}
+
+// queryDebugDump returns the protobuf encoding of every entry of the peggy store, the dump is
+// meant to be diffed against the dump of another node so it is not JSON encoded
+func queryDebugDump(ctx sdk.Context, k PeggyKeeper) ([]byte, error) {
+	if !k.DebugQueriesEnabled() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "debug queries are disabled on this node")
+	}
+	bz, err := k.DumpStore(ctx).Marshal()
 	if err != nil {
-		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
-	} else {
-		return bytes, nil
+		return nil, sdkerrors.Wrap(sdkerrors.ErrLogic, err.Error())
 	}
+	return bz, nil
 }
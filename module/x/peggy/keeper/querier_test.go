@@ -13,9 +13,11 @@ import (
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
 )
 
 func TestQueryValsetConfirm(t *testing.T) {
+	t.Parallel()
 	var (
 		nonce                                       = uint64(1)
 		myValidatorCosmosAddr, _                    = sdk.AccAddressFromBech32("cosmos1ees2tqhhhm9ahlhceh2zdguww9lqn2ckukn86l")
@@ -58,7 +60,7 @@ func TestQueryValsetConfirm(t *testing.T) {
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
-			got, err := queryValsetConfirm(ctx, []string{spec.srcNonce, spec.srcAddr}, input.PeggyKeeper)
+			got, err := queryValsetConfirm(ctx, []string{spec.srcNonce, spec.srcAddr}, input.PeggyKeeper, legacyAminoEncoder)
 			if spec.expErr {
 				require.Error(t, err)
 				return
@@ -74,6 +76,7 @@ func TestQueryValsetConfirm(t *testing.T) {
 }
 
 func TestAllValsetConfirmsBynonce(t *testing.T) {
+	t.Parallel()
 	input := CreateTestEnv(t)
 	ctx := input.Context
 
@@ -117,7 +120,7 @@ func TestAllValsetConfirmsBynonce(t *testing.T) {
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
-			got, err := queryAllValsetConfirms(ctx, spec.srcNonce, input.PeggyKeeper)
+			got, err := queryAllValsetConfirms(ctx, spec.srcNonce, input.PeggyKeeper, legacyAminoEncoder)
 			if spec.expErr {
 				require.Error(t, err)
 				return
@@ -134,6 +137,7 @@ func TestAllValsetConfirmsBynonce(t *testing.T) {
 
 // TODO: Check failure modes
 func TestLastValsetRequests(t *testing.T) {
+	t.Parallel()
 	input := CreateTestEnv(t)
 	ctx := input.Context
 	// seed with requests
@@ -270,7 +274,7 @@ func TestLastValsetRequests(t *testing.T) {
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
-			got, err := lastValsetRequests(ctx, input.PeggyKeeper)
+			got, err := lastValsetRequests(ctx, input.PeggyKeeper, legacyAminoEncoder)
 			require.NoError(t, err)
 			assert.JSONEq(t, string(spec.expResp), string(got), string(got))
 		})
@@ -280,6 +284,7 @@ func TestLastValsetRequests(t *testing.T) {
 // TODO: check that it doesn't accidently return a valset that HAS been signed
 // Right now it is basically just testing that any valset comes back
 func TestPendingValsetRequests(t *testing.T) {
+	t.Parallel()
 	input := CreateTestEnv(t)
 	ctx := input.Context
 
@@ -303,60 +308,46 @@ func TestPendingValsetRequests(t *testing.T) {
 		"find valset": {
 			expResp: []byte(`[
                                   {
-                                    "nonce": "105",
+                                    "nonce": "100",
                                     "members": [
                                       {
-                                        "power": "715827882",
+                                        "power": "4294967295",
                                         "ethereum_address": "0x0101010101010101010101010101010101010101"
-                                      },
-                                      {
-                                        "power": "715827882",
-                                        "ethereum_address": "0x0202020202020202020202020202020202020202"
-                                      },
-                                      {
-                                        "power": "715827882",
-                                        "ethereum_address": "0x0303030303030303030303030303030303030303"
-                                      },
-                                      {
-                                        "power": "715827882",
-                                        "ethereum_address": "0x0404040404040404040404040404040404040404"
-                                      },
+                                      }
+                                    ],
+                                    "height": "100"
+                                  },
+                                  {
+                                    "nonce": "101",
+                                    "members": [
                                       {
-                                        "power": "715827882",
-                                        "ethereum_address": "0x0505050505050505050505050505050505050505"
+                                        "power": "2147483647",
+                                        "ethereum_address": "0x0101010101010101010101010101010101010101"
                                       },
                                       {
-                                        "power": "715827882",
-                                        "ethereum_address": "0x0606060606060606060606060606060606060606"
+                                        "power": "2147483647",
+                                        "ethereum_address": "0x0202020202020202020202020202020202020202"
                                       }
                                     ],
-                                    "height": "105"
+                                    "height": "101"
                                   },
                                   {
-                                    "nonce": "104",
+                                    "nonce": "102",
                                     "members": [
                                       {
-                                        "power": "858993459",
+                                        "power": "1431655765",
                                         "ethereum_address": "0x0101010101010101010101010101010101010101"
                                       },
                                       {
-                                        "power": "858993459",
+                                        "power": "1431655765",
                                         "ethereum_address": "0x0202020202020202020202020202020202020202"
                                       },
                                       {
-                                        "power": "858993459",
+                                        "power": "1431655765",
                                         "ethereum_address": "0x0303030303030303030303030303030303030303"
-                                      },
-                                      {
-                                        "power": "858993459",
-                                        "ethereum_address": "0x0404040404040404040404040404040404040404"
-                                      },
-                                      {
-                                        "power": "858993459",
-                                        "ethereum_address": "0x0505050505050505050505050505050505050505"
                                       }
                                     ],
-                                    "height": "104"
+                                    "height": "102"
                                   },
                                   {
                                     "nonce": "103",
@@ -381,46 +372,60 @@ func TestPendingValsetRequests(t *testing.T) {
                                     "height": "103"
                                   },
                                   {
-                                    "nonce": "102",
+                                    "nonce": "104",
                                     "members": [
                                       {
-                                        "power": "1431655765",
+                                        "power": "858993459",
                                         "ethereum_address": "0x0101010101010101010101010101010101010101"
                                       },
                                       {
-                                        "power": "1431655765",
+                                        "power": "858993459",
                                         "ethereum_address": "0x0202020202020202020202020202020202020202"
                                       },
                                       {
-                                        "power": "1431655765",
+                                        "power": "858993459",
                                         "ethereum_address": "0x0303030303030303030303030303030303030303"
+                                      },
+                                      {
+                                        "power": "858993459",
+                                        "ethereum_address": "0x0404040404040404040404040404040404040404"
+                                      },
+                                      {
+                                        "power": "858993459",
+                                        "ethereum_address": "0x0505050505050505050505050505050505050505"
                                       }
                                     ],
-                                    "height": "102"
+                                    "height": "104"
                                   },
                                   {
-                                    "nonce": "101",
+                                    "nonce": "105",
                                     "members": [
                                       {
-                                        "power": "2147483647",
+                                        "power": "715827882",
                                         "ethereum_address": "0x0101010101010101010101010101010101010101"
                                       },
                                       {
-                                        "power": "2147483647",
+                                        "power": "715827882",
                                         "ethereum_address": "0x0202020202020202020202020202020202020202"
-                                      }
-                                    ],
-                                    "height": "101"
-                                  },
-                                  {
-                                    "nonce": "100",
-                                    "members": [
+                                      },
                                       {
-                                        "power": "4294967295",
-                                        "ethereum_address": "0x0101010101010101010101010101010101010101"
+                                        "power": "715827882",
+                                        "ethereum_address": "0x0303030303030303030303030303030303030303"
+                                      },
+                                      {
+                                        "power": "715827882",
+                                        "ethereum_address": "0x0404040404040404040404040404040404040404"
+                                      },
+                                      {
+                                        "power": "715827882",
+                                        "ethereum_address": "0x0505050505050505050505050505050505050505"
+                                      },
+                                      {
+                                        "power": "715827882",
+                                        "ethereum_address": "0x0606060606060606060606060606060606060606"
                                       }
                                     ],
-                                    "height": "100"
+                                    "height": "105"
                                   }
                                 ]`),
 		},
@@ -428,7 +433,7 @@ func TestPendingValsetRequests(t *testing.T) {
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
 			var valAddr sdk.AccAddress = bytes.Repeat([]byte{byte(1)}, sdk.AddrLen)
-			got, err := lastPendingValsetRequest(ctx, valAddr.String(), input.PeggyKeeper)
+			got, err := lastPendingValsetRequest(ctx, valAddr.String(), input.PeggyKeeper, legacyAminoEncoder)
 			require.NoError(t, err)
 			assert.JSONEq(t, string(spec.expResp), string(got), string(got))
 		})
@@ -437,6 +442,7 @@ func TestPendingValsetRequests(t *testing.T) {
 
 // TODO: check that it actually returns a batch that has NOT been signed, not just any batch
 func TestLastPendingBatchRequest(t *testing.T) {
+	t.Parallel()
 	input := CreateTestEnv(t)
 	ctx := input.Context
 
@@ -503,7 +509,7 @@ func TestLastPendingBatchRequest(t *testing.T) {
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
 			var valAddr sdk.AccAddress = bytes.Repeat([]byte{byte(1)}, sdk.AddrLen)
-			got, err := lastPendingBatchRequest(ctx, valAddr.String(), input.PeggyKeeper)
+			got, err := lastPendingBatchRequest(ctx, valAddr.String(), input.PeggyKeeper, legacyAminoEncoder)
 			require.NoError(t, err)
 			assert.JSONEq(t, string(spec.expResp), string(got), string(got))
 		})
@@ -543,6 +549,7 @@ func createTestBatch(t *testing.T, input TestInput) {
 }
 
 func TestQueryAllBatchConfirms(t *testing.T) {
+	t.Parallel()
 	input := CreateTestEnv(t)
 	ctx := input.Context
 
@@ -559,7 +566,7 @@ func TestQueryAllBatchConfirms(t *testing.T) {
 		Signature:     "signature",
 	})
 
-	batchConfirms, err := queryAllBatchConfirms(ctx, "1", tokenContract, input.PeggyKeeper)
+	batchConfirms, err := queryAllBatchConfirms(ctx, "1", tokenContract, input.PeggyKeeper, legacyAminoEncoder)
 	require.NoError(t, err)
 
 	expectedJSON := []byte(`[{"eth_signer":"0xf35e2cc8e6523d683ed44870f5b7cc785051a77d", "nonce":"1", "signature":"signature", "token_contract":"0xAb5801a7D398351b8bE11C439e05C5B3259aeC9B", "orchestrator":"cosmos1mgamdcs9dah0vn0gqupl05up7pedg2mvupe6hh"}]`)
@@ -568,6 +575,7 @@ func TestQueryAllBatchConfirms(t *testing.T) {
 }
 
 func TestQueryLogicCalls(t *testing.T) {
+	t.Parallel()
 	input := CreateTestEnv(t)
 	ctx := input.Context
 	k := input.PeggyKeeper
@@ -613,17 +621,18 @@ func TestQueryLogicCalls(t *testing.T) {
 
 	require.Equal(t, call, *res)
 
-	_, err := lastLogicCallRequests(ctx, k)
+	_, err := lastLogicCallRequests(ctx, k, legacyAminoEncoder)
 	require.NoError(t, err)
 
 	var valAddr sdk.AccAddress = bytes.Repeat([]byte{byte(1)}, sdk.AddrLen)
-	_, err = lastPendingLogicCallRequest(ctx, valAddr.String(), k)
+	_, err = lastPendingLogicCallRequest(ctx, valAddr.String(), k, legacyAminoEncoder)
 	require.NoError(t, err)
 
 	require.NoError(t, err)
 }
 
 func TestQueryLogicCallsConfirms(t *testing.T) {
+	t.Parallel()
 	input := CreateTestEnv(t)
 	ctx := input.Context
 	k := input.PeggyKeeper
@@ -684,6 +693,7 @@ func TestQueryLogicCallsConfirms(t *testing.T) {
 // TODO: test that it gets the correct batch, not just any batch.
 // Check with multiple nonces and tokenContracts
 func TestQueryBatch(t *testing.T) {
+	t.Parallel()
 	input := CreateTestEnv(t)
 	ctx := input.Context
 
@@ -693,7 +703,7 @@ func TestQueryBatch(t *testing.T) {
 
 	createTestBatch(t, input)
 
-	batch, err := queryBatch(ctx, "1", tokenContract, input.PeggyKeeper)
+	batch, err := queryBatch(ctx, "1", tokenContract, input.PeggyKeeper, legacyAminoEncoder)
 	require.NoError(t, err)
 
 	expectedJSON := []byte(`{
@@ -739,13 +749,14 @@ func TestQueryBatch(t *testing.T) {
 }
 
 func TestLastBatchesRequest(t *testing.T) {
+	t.Parallel()
 	input := CreateTestEnv(t)
 	ctx := input.Context
 
 	createTestBatch(t, input)
 	createTestBatch(t, input)
 
-	lastBatches, err := lastBatchesRequest(ctx, input.PeggyKeeper)
+	lastBatches, err := lastBatchesRequest(ctx, input.PeggyKeeper, legacyAminoEncoder)
 	require.NoError(t, err)
 
 	expectedJSON := []byte(`[
@@ -823,6 +834,7 @@ func TestLastBatchesRequest(t *testing.T) {
 
 // tests setting and querying eth address and orchestrator addresses
 func TestQueryCurrentValset(t *testing.T) {
+	t.Parallel()
 	var (
 		ethAddress                = "0xb462864E395d88d6bc7C5dd5F3F5eb4cc2599255"
 		valAddress sdk.ValAddress = bytes.Repeat([]byte{0x2}, sdk.AddrLen)
@@ -840,6 +852,7 @@ func TestQueryCurrentValset(t *testing.T) {
 }
 
 func TestQueryERC20ToDenom(t *testing.T) {
+	t.Parallel()
 	var (
 		erc20 = "0xb462864E395d88d6bc7C5dd5F3F5eb4cc2599255"
 		denom = "uatom"
@@ -852,7 +865,7 @@ func TestQueryERC20ToDenom(t *testing.T) {
 	ctx := input.Context
 	input.PeggyKeeper.setCosmosOriginatedDenomToERC20(ctx, denom, erc20)
 
-	queriedDenom, err := queryERC20ToDenom(ctx, erc20, input.PeggyKeeper)
+	queriedDenom, err := queryERC20ToDenom(ctx, erc20, input.PeggyKeeper, legacyAminoEncoder)
 	require.NoError(t, err)
 	correctBytes, err := codec.MarshalJSONIndent(types.ModuleCdc, response)
 	require.NoError(t, err)
@@ -861,6 +874,7 @@ func TestQueryERC20ToDenom(t *testing.T) {
 }
 
 func TestQueryDenomToERC20(t *testing.T) {
+	t.Parallel()
 	var (
 		erc20 = "0xb462864E395d88d6bc7C5dd5F3F5eb4cc2599255"
 		denom = "uatom"
@@ -873,7 +887,7 @@ func TestQueryDenomToERC20(t *testing.T) {
 	ctx := input.Context
 	input.PeggyKeeper.setCosmosOriginatedDenomToERC20(ctx, denom, erc20)
 
-	queriedERC20, err := queryDenomToERC20(ctx, denom, input.PeggyKeeper)
+	queriedERC20, err := queryDenomToERC20(ctx, denom, input.PeggyKeeper, legacyAminoEncoder)
 	require.NoError(t, err)
 
 	correctBytes, err := codec.MarshalJSONIndent(types.ModuleCdc, response)
@@ -882,7 +896,18 @@ func TestQueryDenomToERC20(t *testing.T) {
 	assert.Equal(t, correctBytes, queriedERC20)
 }
 
+func TestQueryDenomToERC20NotDeployed(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+
+	_, err := queryDenomToERC20(ctx, "uatom", input.PeggyKeeper, legacyAminoEncoder)
+	require.Error(t, err)
+	assert.True(t, types.ErrNotDeployed.Is(err))
+}
+
 func TestQueryPendingSendToEth(t *testing.T) {
+	t.Parallel()
 	input := CreateTestEnv(t)
 	ctx := input.Context
 	var (
@@ -919,34 +944,34 @@ func TestQueryPendingSendToEth(t *testing.T) {
 	_, err := input.PeggyKeeper.BuildOutgoingTXBatch(ctx, myTokenContractAddr, 2)
 	require.NoError(t, err)
 
-	response, err := queryPendingSendToEth(ctx, mySender.String(), input.PeggyKeeper)
+	response, err := queryPendingSendToEth(ctx, mySender.String(), nil, input.PeggyKeeper, legacyAminoEncoder)
 	require.NoError(t, err)
 	expectedJSON := []byte(`{
   "transfers_in_batches": [
     {
-      "id": "2",
+      "id": "1",
       "sender": "cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn",
       "dest_address": "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7",
       "erc20_token": {
         "contract": "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5",
-        "amount": "101"
+        "amount": "100"
       },
       "erc20_fee": {
         "contract": "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5",
-        "amount": "3"
+        "amount": "2"
       }
     },
     {
-      "id": "1",
+      "id": "2",
       "sender": "cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn",
       "dest_address": "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7",
       "erc20_token": {
         "contract": "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5",
-        "amount": "100"
+        "amount": "101"
       },
       "erc20_fee": {
         "contract": "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5",
-        "amount": "2"
+        "amount": "3"
       }
     }
   ],
@@ -977,8 +1002,58 @@ func TestQueryPendingSendToEth(t *testing.T) {
         "amount": "1"
       }
     }
-  ]}
+  ],
+  "pagination": {"total": "4"}}
 	  `)
 
 	assert.JSONEq(t, string(expectedJSON), string(response), "json is equal")
 }
+
+func TestQuerierEncoding(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	querier := NewQuerier(k)
+	tokenContract := "0xAb5801a7D398351b8bE11C439e05C5B3259aeC9B"
+	orchestrator, _ := sdk.AccAddressFromBech32("cosmos1ees2tqhhhm9ahlhceh2zdguww9lqn2ckukn86l")
+	k.SetValsetConfirm(ctx, types.MsgValsetConfirm{
+		Nonce:        1,
+		Orchestrator: orchestrator.String(),
+		EthAddress:   "0x3232323232323232323232323232323232323232",
+		Signature:    "signature",
+	})
+	createTestBatch(t, input)
+
+	// the default encoding is the proto-JSON of the gRPC response
+	got, err := querier(ctx, []string{QueryValsetConfirm, "1", orchestrator.String()}, abci.RequestQuery{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"confirm":{"nonce":"1","orchestrator":"cosmos1ees2tqhhhm9ahlhceh2zdguww9lqn2ckukn86l","eth_address":"0x3232323232323232323232323232323232323232","signature":"signature"}}`, string(got))
+
+	grpcRes, err := k.ValsetConfirm(sdk.WrapSDKContext(ctx), &types.QueryValsetConfirmRequest{Nonce: 1, Address: orchestrator.String()})
+	require.NoError(t, err)
+	exp, err := codec.ProtoMarshalJSON(grpcRes, nil)
+	require.NoError(t, err)
+	assert.Equal(t, exp, got)
+
+	batchRes, err := k.BatchRequestByNonce(sdk.WrapSDKContext(ctx), &types.QueryBatchRequestByNonceRequest{Nonce: 1, ContractAddress: tokenContract})
+	require.NoError(t, err)
+	exp, err = codec.ProtoMarshalJSON(batchRes, nil)
+	require.NoError(t, err)
+	got, err = querier(ctx, []string{QueryBatch, "1", tokenContract}, abci.RequestQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, exp, got)
+
+	// the compatibility flag returns the amino wrapper of the bare value
+	got, err = querier(ctx, []string{types.QuerierLegacyAmino, QueryValsetConfirm, "1", orchestrator.String()}, abci.RequestQuery{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"peggy/MsgValsetConfirm","value":{"nonce":"1","orchestrator":"cosmos1ees2tqhhhm9ahlhceh2zdguww9lqn2ckukn86l","eth_address":"0x3232323232323232323232323232323232323232","signature":"signature"}}`, string(got))
+
+	// routes without results stay empty in both encodings
+	got, err = querier(ctx, []string{QueryValsetConfirmsByNonce, "2"}, abci.RequestQuery{})
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	_, err = querier(ctx, []string{types.QuerierLegacyAmino}, abci.RequestQuery{})
+	require.Error(t, err)
+}
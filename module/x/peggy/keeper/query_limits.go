@@ -0,0 +1,43 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+)
+
+const (
+	// MaxPageLimit caps the page size of the paginated queries, larger pages are shortened and the
+	// next key of the page response continues after the returned entries
+	MaxPageLimit = 1000
+
+	// MaxQueryGas is the gas a query may spend reading the store. Queries run with an infinite gas
+	// meter, without it a query whose iterator skips most entries, like the unsigned work of an
+	// orchestrator, reads a growing store in full on every call of a public node.
+	MaxQueryGas = 20_000_000
+)
+
+// limitQuery runs query with the store reads metered against MaxQueryGas and reports whether it ran
+// out of gas. The results query collected until then are returned flagged as truncated, queries that
+// can't return a part of their result fail instead.
+func limitQuery(ctx sdk.Context, query func(ctx sdk.Context)) (truncated bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(sdk.ErrorOutOfGas); !ok {
+				panic(r)
+			}
+			truncated = true
+		}
+	}()
+	query(ctx.WithGasMeter(sdk.NewGasMeter(MaxQueryGas)))
+	return false
+}
+
+// limitPageRequest returns the page request with its limit capped to MaxPageLimit
+func limitPageRequest(pageReq *query.PageRequest) *query.PageRequest {
+	if pageReq == nil || pageReq.Limit <= MaxPageLimit {
+		return pageReq
+	}
+	limited := *pageReq
+	limited.Limit = MaxPageLimit
+	return &limited
+}
@@ -0,0 +1,79 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitQuery(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+
+	// reading the store until the gas runs out stops the query
+	reads, consumed := 0, ctx.GasMeter().GasConsumed()
+	truncated := limitQuery(ctx, func(ctx sdk.Context) {
+		for {
+			k.GetParams(ctx)
+			reads++
+		}
+	})
+	assert.True(t, truncated)
+	assert.Greater(t, reads, 0)
+	// the gas of the query isn't charged to the caller
+	assert.Equal(t, consumed, ctx.GasMeter().GasConsumed())
+
+	assert.False(t, limitQuery(ctx, func(ctx sdk.Context) { k.GetParams(ctx) }))
+	assert.Panics(t, func() { limitQuery(ctx, func(sdk.Context) { panic("other") }) })
+}
+
+func TestQueryTruncated(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	tokenContract := "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+
+	batches := func() *types.QueryOutgoingTxBatchesResponse {
+		res, err := k.OutgoingTxBatches(sdk.WrapSDKContext(ctx), &types.QueryOutgoingTxBatchesRequest{})
+		require.NoError(t, err)
+		return res
+	}
+	for i := 1; i <= MaxResults; i++ {
+		k.StoreBatch(ctx, &types.OutgoingTxBatch{BatchNonce: uint64(i), TokenContract: tokenContract})
+	}
+	res := batches()
+	assert.Len(t, res.Batches, MaxResults)
+	assert.False(t, res.Truncated)
+
+	k.StoreBatch(ctx, &types.OutgoingTxBatch{BatchNonce: MaxResults + 1, TokenContract: tokenContract})
+	res = batches()
+	assert.Len(t, res.Batches, MaxResults)
+	assert.True(t, res.Truncated)
+
+	// the orchestrator has not signed any of them
+	work, err := k.PendingWork(sdk.WrapSDKContext(ctx), &types.QueryPendingWorkRequest{Orchestrator: AccAddrs[0].String()})
+	require.NoError(t, err)
+	assert.Len(t, work.Batches, MaxResults)
+	assert.True(t, work.Truncated)
+}
+
+func TestLimitPageRequest(t *testing.T) {
+	assert.Nil(t, limitPageRequest(nil))
+	small := &query.PageRequest{Limit: 10}
+	assert.Same(t, small, limitPageRequest(small))
+
+	large := &query.PageRequest{Key: []byte{1}, Limit: MaxPageLimit + 1}
+	assert.Equal(t, &query.PageRequest{Key: []byte{1}, Limit: MaxPageLimit}, limitPageRequest(large))
+	assert.Equal(t, uint64(MaxPageLimit+1), large.Limit)
+
+	_, limit, err := parsePositionPageRequest(&query.PageRequest{Limit: MaxPageLimit * 2})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(MaxPageLimit), limit)
+}
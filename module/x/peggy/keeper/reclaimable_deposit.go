@@ -0,0 +1,105 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// fundReclaimableDeposit moves the coins of a deposit that can't be credited to its
+// cosmos receiver from the module account into the community pool and records them
+// so that governance can later return them
+func (k Keeper) fundReclaimableDeposit(ctx sdk.Context, claim *types.MsgDepositClaim, coin sdk.Coin) error {
+	if err := k.distKeeper.FundCommunityPool(ctx, sdk.Coins{coin}, authtypes.NewModuleAddress(types.ModuleName)); err != nil {
+		return err
+	}
+
+	k.SetReclaimableDeposit(ctx, types.ReclaimableDeposit{
+		EventNonce:     claim.EventNonce,
+		BlockHeight:    claim.BlockHeight,
+		TokenContract:  claim.TokenContract,
+		EthereumSender: claim.EthereumSender,
+		CosmosReceiver: claim.CosmosReceiver,
+		Amount:         coin,
+	})
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeReclaimableDeposit,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(types.AttributeKeyNonce, fmt.Sprint(claim.EventNonce)),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, coin.String()),
+		),
+	)
+	return nil
+}
+
+// ReturnReclaimableDeposit sends the coins of the reclaimable deposit with the given event nonce
+// from the community pool to destination and removes the record
+func (k Keeper) ReturnReclaimableDeposit(ctx sdk.Context, eventNonce uint64, destination sdk.AccAddress) error {
+	deposit := k.GetReclaimableDeposit(ctx, eventNonce)
+	if deposit == nil {
+		return sdkerrors.Wrapf(types.ErrUnknown, "reclaimable deposit %d", eventNonce)
+	}
+
+	if err := k.distKeeper.DistributeFromFeePool(ctx, sdk.Coins{deposit.Amount}, destination); err != nil {
+		return err
+	}
+	k.DeleteReclaimableDeposit(ctx, eventNonce)
+
+	k.logger(ctx).Info("returned reclaimable deposit", "nonce", eventNonce, "amount", deposit.Amount.String(), "destination", destination.String())
+	return nil
+}
+
+// SetReclaimableDeposit stores a reclaimable deposit by its event nonce
+func (k Keeper) SetReclaimableDeposit(ctx sdk.Context, deposit types.ReclaimableDeposit) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetReclaimableDepositKey(deposit.EventNonce), k.cdc.MustMarshalBinaryBare(&deposit))
+}
+
+// GetReclaimableDeposit returns the reclaimable deposit recorded for the given event nonce
+func (k Keeper) GetReclaimableDeposit(ctx sdk.Context, eventNonce uint64) *types.ReclaimableDeposit {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetReclaimableDepositKey(eventNonce))
+	if bz == nil {
+		return nil
+	}
+	var deposit types.ReclaimableDeposit
+	k.cdc.MustUnmarshalBinaryBare(bz, &deposit)
+	return &deposit
+}
+
+// DeleteReclaimableDeposit removes the reclaimable deposit recorded for the given event nonce
+func (k Keeper) DeleteReclaimableDeposit(ctx sdk.Context, eventNonce uint64) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetReclaimableDepositKey(eventNonce))
+}
+
+// IterateReclaimableDeposits iterates over all reclaimable deposits in ascending event nonce order
+func (k Keeper) IterateReclaimableDeposits(ctx sdk.Context, cb func(types.ReclaimableDeposit) bool) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.ReclaimableDepositKey)
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var deposit types.ReclaimableDeposit
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &deposit)
+		// cb returns true to stop early
+		if cb(deposit) {
+			break
+		}
+	}
+}
+
+// GetReclaimableDeposits returns all reclaimable deposits
+func (k Keeper) GetReclaimableDeposits(ctx sdk.Context) (out []types.ReclaimableDeposit) {
+	k.IterateReclaimableDeposits(ctx, func(deposit types.ReclaimableDeposit) bool {
+		out = append(out, deposit)
+		return false
+	})
+	return
+}
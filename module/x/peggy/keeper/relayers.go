@@ -0,0 +1,76 @@
+package keeper
+
+import (
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// SetRelayer binds the Ethereum address of a relayer to its cosmos account, a binding either of them
+// had before is replaced so both stay one to one
+func (k Keeper) SetRelayer(ctx sdk.Context, relayer sdk.AccAddress, ethAddress string) {
+	store := ctx.KVStore(k.storeKey)
+	if prev, found := k.GetRelayer(ctx, ethAddress); found {
+		store.Delete(types.GetRelayerByAccountKey(prev))
+	}
+	if prev, found := k.GetRelayerEthAddress(ctx, relayer); found {
+		store.Delete(types.GetRelayerKey(prev))
+	}
+	store.Set(types.GetRelayerKey(ethAddress), relayer.Bytes())
+	store.Set(types.GetRelayerByAccountKey(relayer), []byte(strings.ToLower(ethAddress)))
+}
+
+// GetRelayer returns the account registered for the Ethereum address of a relayer
+func (k Keeper) GetRelayer(ctx sdk.Context, ethAddress string) (sdk.AccAddress, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetRelayerKey(ethAddress))
+	if bz == nil {
+		return nil, false
+	}
+	return sdk.AccAddress(bz), true
+}
+
+// GetRelayerEthAddress returns the Ethereum address registered for the account of a relayer
+func (k Keeper) GetRelayerEthAddress(ctx sdk.Context, relayer sdk.AccAddress) (string, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetRelayerByAccountKey(relayer))
+	if bz == nil {
+		return "", false
+	}
+	return string(bz), true
+}
+
+// IterateRelayers iterates over the registered relayers ordered by Ethereum address
+func (k Keeper) IterateRelayers(ctx sdk.Context, cb func(types.RegisteredRelayer) bool) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.RelayerKey)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		relayer := types.RegisteredRelayer{
+			EthAddress: string(iter.Key()[len(types.RelayerKey):]),
+			Relayer:    sdk.AccAddress(iter.Value()).String(),
+		}
+		// cb returns true to stop early
+		if cb(relayer) {
+			break
+		}
+	}
+}
+
+// GetAllRelayers returns all registered relayers
+func (k Keeper) GetAllRelayers(ctx sdk.Context) (out []types.RegisteredRelayer) {
+	k.IterateRelayers(ctx, func(relayer types.RegisteredRelayer) bool {
+		out = append(out, relayer)
+		return false
+	})
+	return
+}
+
+// IsRelayerAllowed returns true when the account may be paid for relaying, which is any account unless
+// the RelayerAllowlist param restricts it to the registered relayers
+func (k Keeper) IsRelayerAllowed(ctx sdk.Context, relayer sdk.AccAddress) bool {
+	if !k.GetParams(ctx).RelayerAllowlist {
+		return true
+	}
+	_, found := k.GetRelayerEthAddress(ctx, relayer)
+	return found
+}
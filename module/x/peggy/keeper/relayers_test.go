@@ -0,0 +1,58 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelayerAllowlist(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	var (
+		depositor, _ = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		relayer, _   = sdk.AccAddressFromBech32("cosmos16ahjkfqxpp6lvfy9fpfnfjg39xr96qett0alj5")
+		other, _     = sdk.AccAddressFromBech32("cosmos1990z7dqsvh8gthw9pa5sn4wuy2xrsd80mg5z6y")
+		ethAddress   = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		otherEth     = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		funds        = sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+		reward       = sdk.NewCoins(sdk.NewInt64Coin("stake", 10))
+	)
+	input.AccountKeeper.NewAccountWithAddress(ctx, depositor)
+	require.NoError(t, input.BankKeeper.SetBalances(ctx, depositor, funds))
+	require.NoError(t, k.FundRelayerRewardPool(ctx, depositor, funds))
+
+	// without the allowlist any account can be paid
+	require.NoError(t, k.PayRelayerReward(ctx, other, reward))
+
+	params := k.GetParams(ctx)
+	params.RelayerAllowlist = true
+	k.SetParams(ctx, params)
+	err := k.PayRelayerReward(ctx, relayer, reward)
+	assert.True(t, types.ErrRelayerNotRegistered.Is(err), err)
+
+	k.SetRelayer(ctx, relayer, ethAddress)
+	require.NoError(t, k.PayRelayerReward(ctx, relayer, reward))
+	assert.Equal(t, reward, input.BankKeeper.GetAllBalances(ctx, relayer))
+
+	// the address is looked up regardless of its checksum
+	acc, found := k.GetRelayer(ctx, "0xD041C41EA1BF0F006ADBB6D2C9EF9D425DE5EAD7")
+	require.True(t, found)
+	assert.Equal(t, relayer, acc)
+
+	// registering the ethereum address with another account moves it over
+	k.SetRelayer(ctx, other, ethAddress)
+	assert.False(t, k.IsRelayerAllowed(ctx, relayer))
+	assert.True(t, k.IsRelayerAllowed(ctx, other))
+
+	// and registering another address for the account replaces its previous one
+	k.SetRelayer(ctx, other, otherEth)
+	_, found = k.GetRelayer(ctx, ethAddress)
+	assert.False(t, found)
+	assert.Len(t, k.GetAllRelayers(ctx), 1)
+}
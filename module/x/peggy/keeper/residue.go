@@ -0,0 +1,160 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// The residue of a module account is its balance minus the liabilities tracked for it:
+//  - the escrow is liable for all of its cosmos originated coins with an ERC20 on Ethereum, retired ERC20s
+//    included, the supply of the ERC20 outside of the bridge contract isn't known on Cosmos so the whole
+//    balance backs it. Anything else, such as vouchers or coins without an ERC20, is residue.
+//  - the fee collector is liable for the fees of the pending transfers and logic calls
+// The relayer reward pool is funded on purpose and has no residue. The module-residue invariant checks
+// that the escrow holds the pending cosmos originated transfers it backs, so a sweep never touches them.
+
+// escrowLiabilities returns the cosmos originated coins with an ERC20 held by the escrow, a retired ERC20
+// still has its supply on Ethereum backed by the escrow
+func (k Keeper) escrowLiabilities(ctx sdk.Context, balance sdk.Coins) sdk.Coins {
+	backed := make(map[string]bool)
+	k.IterateERC20ToDenom(ctx, func(_ []byte, erc20ToDenom *types.ERC20ToDenom) bool {
+		backed[erc20ToDenom.Denom] = true
+		return false
+	})
+	liabilities := sdk.NewCoins()
+	for _, coin := range balance {
+		if backed[coin.Denom] {
+			liabilities = liabilities.Add(coin)
+		}
+	}
+	return liabilities
+}
+
+// feeCollectorLiabilities returns the pending fees up to the balance of the fee collector, the
+// pending-fees invariant reports a balance short of them
+func (k Keeper) feeCollectorLiabilities(ctx sdk.Context, balance sdk.Coins) sdk.Coins {
+	liabilities := sdk.NewCoins()
+	for _, coin := range k.getPendingFees(ctx) {
+		held := balance.AmountOf(coin.Denom)
+		liabilities = liabilities.Add(sdk.NewCoin(coin.Denom, sdk.MinInt(held, coin.Amount)))
+	}
+	return liabilities
+}
+
+// GetModuleResidue returns the coins of the escrow and of the fee collector that no tracked liability
+// accounts for
+func (k Keeper) GetModuleResidue(ctx sdk.Context) (escrow, feeCollector sdk.Coins) {
+	balance := k.bankKeeper.GetAllBalances(ctx, authtypes.NewModuleAddress(types.ModuleName))
+	escrow = balance.Sub(k.escrowLiabilities(ctx, balance))
+	balance = k.bankKeeper.GetAllBalances(ctx, authtypes.NewModuleAddress(types.FeeCollectorName))
+	feeCollector = balance.Sub(k.feeCollectorLiabilities(ctx, balance))
+	return escrow, feeCollector
+}
+
+// SweepModuleResidue moves the residue of the escrow and of the fee collector into the community pool and
+// returns the amount moved
+func (k Keeper) SweepModuleResidue(ctx sdk.Context) (sdk.Coins, error) {
+	escrow, feeCollector := k.GetModuleResidue(ctx)
+	for _, residue := range []struct {
+		account string
+		coins   sdk.Coins
+	}{{types.ModuleName, escrow}, {types.FeeCollectorName, feeCollector}} {
+		if residue.coins.IsZero() {
+			continue
+		}
+		if err := k.distKeeper.FundCommunityPool(ctx, residue.coins, authtypes.NewModuleAddress(residue.account)); err != nil {
+			return nil, sdkerrors.Wrapf(err, "sweep %s", residue.account)
+		}
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeModuleResidueSwept,
+				sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+				sdk.NewAttribute(types.AttributeKeyModuleAccount, residue.account),
+				sdk.NewAttribute(sdk.AttributeKeyAmount, residue.coins.String()),
+			),
+		)
+	}
+	return escrow.Add(feeCollector...), nil
+}
+
+// SweepModuleResidueAtInterval sweeps the residue of the module accounts every ResidueSweepInterval blocks,
+// a failing sweep is logged and retried at the next interval
+func (k Keeper) SweepModuleResidueAtInterval(ctx sdk.Context) {
+	interval := k.GetParams(ctx).ResidueSweepInterval
+	if interval == 0 || uint64(ctx.BlockHeight())%interval != 0 {
+		return
+	}
+	xCtx, commit := ctx.CacheContext()
+	swept, err := k.SweepModuleResidue(xCtx)
+	if err != nil {
+		k.logger(ctx).Error("module residue sweep failed", "cause", err.Error())
+		return
+	}
+	commit()
+	ctx.EventManager().EmitEvents(xCtx.EventManager().Events())
+	if !swept.IsZero() {
+		k.logger(ctx).Info("swept module residue", "amount", swept.String())
+	}
+}
+
+// getPendingEscrow sums the cosmos originated amounts of the transfers in the pool, scheduled, in batches and in
+// cancelled batches that may still be executed, these are held by the escrow
+func (k Keeper) getPendingEscrow(ctx sdk.Context) sdk.Coins {
+	pending := sdk.NewCoins()
+	addTx := func(tx *types.OutgoingTransferTx) {
+		if isCosmosOriginated, amount := k.ERC20ToCoin(ctx, *tx.Erc20Token); isCosmosOriginated {
+			pending = pending.Add(amount)
+		}
+	}
+	k.IterateOutgoingPool(ctx, func(tx *types.OutgoingTransferTx) bool {
+		addTx(tx)
+		return false
+	})
+	k.IterateScheduledTransfers(ctx, func(scheduled types.ScheduledTransfer) bool {
+		addTx(&scheduled.Tx)
+		return false
+	})
+	for _, batch := range k.GetOutgoingTxBatches(ctx) {
+		for _, tx := range batch.Transactions {
+			addTx(tx)
+		}
+	}
+	k.IterateCancelledBatches(ctx, "", func(batch *types.OutgoingTxBatch) bool {
+		for _, tx := range batch.Transactions {
+			addTx(tx)
+		}
+		return false
+	})
+	return pending
+}
+
+// ModuleResidueInvariant checks that the liabilities kept out of the residue cover what the escrow and the
+// fee collector must hold, and that liabilities and residue add up to the balance of each account
+func ModuleResidueInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var msg string
+		broken := false
+		check := func(account string, liabilities, required, residue sdk.Coins) {
+			balance := k.bankKeeper.GetAllBalances(ctx, authtypes.NewModuleAddress(account))
+			if !liabilities.IsAllGTE(required) {
+				broken = true
+				msg += fmt.Sprintf("\t%s liabilities %s don't cover %s\n", account, liabilities, required)
+			}
+			if total := liabilities.Add(residue...); !total.IsAllGTE(balance) || !balance.IsAllGTE(total) {
+				broken = true
+				msg += fmt.Sprintf("\t%s liabilities %s and residue %s don't add up to %s\n", account, liabilities, residue, balance)
+			}
+		}
+		escrow, feeCollector := k.GetModuleResidue(ctx)
+		escrowBalance := k.bankKeeper.GetAllBalances(ctx, authtypes.NewModuleAddress(types.ModuleName))
+		check(types.ModuleName, k.escrowLiabilities(ctx, escrowBalance), k.getPendingEscrow(ctx), escrow)
+		feeBalance := k.bankKeeper.GetAllBalances(ctx, authtypes.NewModuleAddress(types.FeeCollectorName))
+		check(types.FeeCollectorName, k.feeCollectorLiabilities(ctx, feeBalance), sdk.NewCoins(), feeCollector)
+		return sdk.FormatInvariant(types.ModuleName, "module residue",
+			fmt.Sprintf("escrow residue %s, fee collector residue %s\n%s", escrow, feeCollector, msg)), broken
+	}
+}
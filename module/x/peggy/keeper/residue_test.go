@@ -0,0 +1,120 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSweepModuleResidue(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	var (
+		mySender, _    = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		myReceiver     = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		atomContract   = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		voucherDust    = sdk.Coins{types.NewERC20Token(3, myReceiver).PeggyCoin()}
+		feeDust        = sdk.NewCoins(sdk.NewInt64Coin("uatom", 2))
+		escrowAddr     = input.AccountKeeper.GetModuleAddress(types.ModuleName)
+		feeCollector   = input.AccountKeeper.GetModuleAddress(types.FeeCollectorName)
+		invariantHolds = func() {
+			msg, broken := ModuleResidueInvariant(k)(ctx)
+			assert.False(t, broken, msg)
+		}
+	)
+	k.setCosmosOriginatedDenomToERC20(ctx, "uatom", atomContract)
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+	require.NoError(t, input.BankKeeper.SetBalances(ctx, mySender, sdk.NewCoins(sdk.NewInt64Coin("uatom", 1000))))
+	_, err := k.AddToOutgoingPool(ctx, mySender, myReceiver, sdk.NewInt64Coin("uatom", 100), sdk.NewInt64Coin("uatom", 10))
+	require.NoError(t, err)
+
+	// the escrowed transfer and its pending fee are liabilities, not residue
+	escrow, fees := k.GetModuleResidue(ctx)
+	assert.True(t, escrow.IsZero())
+	assert.True(t, fees.IsZero())
+	invariantHolds()
+
+	// vouchers left in the escrow and fees no transfer accounts for are
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, voucherDust))
+	require.NoError(t, input.BankKeeper.SendCoinsFromAccountToModule(ctx, mySender, types.FeeCollectorName, feeDust))
+	escrow, fees = k.GetModuleResidue(ctx)
+	assert.Equal(t, voucherDust, escrow)
+	assert.Equal(t, feeDust, fees)
+	invariantHolds()
+
+	// the sweep only runs at its interval
+	params := k.GetParams(ctx)
+	params.ResidueSweepInterval = 10
+	k.SetParams(ctx, params)
+	k.SweepModuleResidueAtInterval(ctx.WithBlockHeight(9))
+	assert.True(t, input.DistKeeper.GetFeePoolCommunityCoins(ctx).IsZero())
+	k.SweepModuleResidueAtInterval(ctx.WithBlockHeight(10))
+	assert.Equal(t, sdk.NewDecCoinsFromCoins(voucherDust.Add(feeDust...)...), input.DistKeeper.GetFeePoolCommunityCoins(ctx))
+	escrow, fees = k.GetModuleResidue(ctx)
+	assert.True(t, escrow.IsZero())
+	assert.True(t, fees.IsZero())
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("uatom", 100)), input.BankKeeper.GetAllBalances(ctx, escrowAddr))
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("uatom", 10)), input.BankKeeper.GetAllBalances(ctx, feeCollector))
+	invariantHolds()
+
+	res, err := k.ModuleResidue(sdk.WrapSDKContext(ctx), &types.QueryModuleResidueRequest{})
+	require.NoError(t, err)
+	assert.True(t, res.Escrow.IsZero())
+	assert.True(t, res.FeeCollector.IsZero())
+
+	// an escrow short of the pending transfers breaks the invariant
+	require.NoError(t, input.BankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, mySender, sdk.NewCoins(sdk.NewInt64Coin("uatom", 1))))
+	_, broken := ModuleResidueInvariant(k)(ctx)
+	assert.True(t, broken)
+}
+
+func TestSweepModuleResidueKeepsCancelledBatches(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	var (
+		mySender, _  = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		myReceiver   = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		atomContract = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		feeCollector = input.AccountKeeper.GetModuleAddress(types.FeeCollectorName)
+	)
+	k.setCosmosOriginatedDenomToERC20(ctx, "uatom", atomContract)
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+	require.NoError(t, input.BankKeeper.SetBalances(ctx, mySender, sdk.NewCoins(sdk.NewInt64Coin("uatom", 1000))))
+	for i := int64(1); i <= 2; i++ {
+		_, err := k.AddToOutgoingPool(ctx, mySender, myReceiver, sdk.NewInt64Coin("uatom", 100), sdk.NewInt64Coin("uatom", i))
+		require.NoError(t, err)
+	}
+	batch, err := k.BuildOutgoingTXBatch(ctx, atomContract, 2)
+	require.NoError(t, err)
+	k.SetBatchConfirm(ctx, &types.MsgConfirmBatch{Nonce: batch.BatchNonce, TokenContract: atomContract, Orchestrator: AccAddrs[0].String()})
+	require.NoError(t, k.CancelBatch(ctx, k.GetAuthority(), atomContract, batch.BatchNonce))
+	require.NotNil(t, k.GetCancelledBatch(ctx, atomContract, batch.BatchNonce))
+
+	// the fees and escrow of the cancelled batch are liabilities, not residue
+	escrow, fees := k.GetModuleResidue(ctx)
+	assert.True(t, escrow.IsZero())
+	assert.True(t, fees.IsZero())
+	swept, err := k.SweepModuleResidue(ctx)
+	require.NoError(t, err)
+	assert.True(t, swept.IsZero())
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("uatom", 3)), input.BankKeeper.GetAllBalances(ctx, feeCollector))
+
+	// once released the transfers can be refunded from the fee collector
+	k.ReleaseTimedOutCancelledBatches(ctx, batch.BatchTimeout+1)
+	assert.Nil(t, k.GetCancelledBatch(ctx, atomContract, batch.BatchNonce))
+	for _, invariant := range []sdk.Invariant{PendingFeesInvariant(k), ModuleResidueInvariant(k)} {
+		msg, broken := invariant(ctx)
+		assert.False(t, broken, msg)
+	}
+	for _, tx := range batch.Transactions {
+		require.NoError(t, k.RemoveFromOutgoingPoolAndRefund(ctx, tx.Id, mySender))
+	}
+	assert.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("uatom", 1000)), input.BankKeeper.GetAllBalances(ctx, mySender))
+}
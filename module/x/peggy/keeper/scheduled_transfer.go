@@ -0,0 +1,201 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// ScheduleOutgoingTx locks the amount and fee of a transfer like AddToOutgoingPool, but keeps the transfer
+// out of the outgoing pool until the chain reached both executeAfterHeight and executeAfterTime (unix
+// seconds), see ReleaseScheduledTransfers. Until then only the sender can cancel it.
+func (k Keeper) ScheduleOutgoingTx(ctx sdk.Context, sender sdk.AccAddress, counterpartReceiver string, amount sdk.Coin, fee sdk.Coin, executeAfterHeight, executeAfterTime uint64) (uint64, error) {
+	if executeAfterHeight <= uint64(ctx.BlockHeight()) && executeAfterTime <= uint64(ctx.BlockTime().Unix()) {
+		return 0, sdkerrors.Wrap(types.ErrInvalid, "earliest execution is not in the future")
+	}
+	outgoing, bridgeFee, err := k.lockOutgoingTx(ctx, sender, counterpartReceiver, amount, fee)
+	if err != nil {
+		return 0, err
+	}
+	scheduled := types.ScheduledTransfer{
+		Tx:                 *outgoing,
+		ExecuteAfterHeight: executeAfterHeight,
+		ExecuteAfterTime:   executeAfterTime,
+	}
+	k.setScheduledTransfer(ctx, scheduled)
+	k.indexScheduledTransfer(ctx, scheduled)
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeBridgeWithdrawScheduled,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyOutgoingTXID, fmt.Sprint(outgoing.Id)),
+		sdk.NewAttribute(types.AttributeKeyBridgeFee, bridgeFee.String()),
+		sdk.NewAttribute(types.AttributeKeyExecuteAfterHeight, fmt.Sprint(executeAfterHeight)),
+		sdk.NewAttribute(types.AttributeKeyExecuteAfterTime, fmt.Sprint(executeAfterTime)),
+	))
+	return outgoing.Id, nil
+}
+
+// ReleaseScheduledTransfers moves the scheduled transfers that reached their earliest execution into the
+// outgoing pool, from then on they are batched, bumped, cancelled and expire like any other transfer. The
+// transfers are indexed by the height they wait for and, once that is reached, by the time they wait for,
+// so only the due transfers are visited. A transfer of a paused token leaves the index until the token is
+// unpaused. A transfer that can't be released is refunded, if the refund fails as well the transfer stays
+// scheduled outside of the index for its sender to cancel.
+func (k Keeper) ReleaseScheduledTransfers(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	height, now := uint64(ctx.BlockHeight()), uint64(ctx.BlockTime().Unix())
+	for released := 0; released == 0 || !WorkBudgetExhausted(ctx); released++ {
+		// reopen the iterators on every transfer, the release modifies the store
+		key := firstIndexKeyBelow(store, types.ScheduledTransferByHeightKey, height+1)
+		if key == nil {
+			key = firstIndexKeyBelow(store, types.ScheduledTransferByTimeKey, now+1)
+		}
+		if key == nil {
+			return
+		}
+		store.Delete(key)
+		scheduled := k.GetScheduledTransfer(ctx, types.UInt64FromBytes(key[len(key)-8:]))
+		if scheduled == nil {
+			continue
+		}
+		k.releaseScheduledTransfer(ctx, *scheduled)
+	}
+}
+
+// firstIndexKeyBelow returns the first key of an index keyed by a uint64 that is below the bound, nil if
+// there is none
+func firstIndexKeyBelow(store sdk.KVStore, index []byte, bound uint64) []byte {
+	iter := store.Iterator(index, append(append([]byte{}, index...), types.UInt64Bytes(bound)...))
+	defer iter.Close()
+	if !iter.Valid() {
+		return nil
+	}
+	return iter.Key()
+}
+
+func (k Keeper) releaseScheduledTransfer(ctx sdk.Context, scheduled types.ScheduledTransfer) {
+	switch {
+	case scheduled.ExecuteAfterTime > uint64(ctx.BlockTime().Unix()):
+		// the height was reached, the transfer waits for its time
+		k.indexScheduledTransfer(ctx, scheduled)
+		return
+	case k.IsTokenPaused(ctx, scheduled.Tx.Erc20Token.Contract):
+		// indexed again by UnpauseToken
+		return
+	}
+
+	// the bridge fee was taken when the transfer was scheduled
+	_, denom := k.ERC20ToDenomLookup(ctx, scheduled.Tx.Erc20Fee.Contract)
+	releaseCtx, commit := ctx.CacheContext()
+	k.deleteScheduledTransfer(releaseCtx, scheduled)
+	err := k.addToOutgoingPool(releaseCtx, &scheduled.Tx, sdk.NewCoin(denom, sdk.ZeroInt()))
+	if err == nil {
+		commit()
+		return
+	}
+	k.logger(ctx).Error("release scheduled tx", "tx", scheduled.Tx.Id, "cause", err)
+
+	refundCtx, commit := ctx.CacheContext()
+	sender, err := sdk.AccAddressFromBech32(scheduled.Tx.Sender)
+	if err == nil {
+		k.deleteScheduledTransfer(refundCtx, scheduled)
+		err = k.refundOutgoingTx(refundCtx, &scheduled.Tx, sender)
+	}
+	if err != nil {
+		k.logger(ctx).Error("refund scheduled tx", "tx", scheduled.Tx.Id, "cause", err)
+		return
+	}
+	commit()
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeBridgeWithdrawCanceled,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyOutgoingTXID, fmt.Sprint(scheduled.Tx.Id)),
+	))
+}
+
+// CancelScheduledTransfer removes a scheduled transfer and refunds its amount and fee, only the sender of
+// the transfer can do so
+func (k Keeper) CancelScheduledTransfer(ctx sdk.Context, txID uint64, sender sdk.AccAddress) error {
+	scheduled := k.GetScheduledTransfer(ctx, txID)
+	if scheduled == nil {
+		return sdkerrors.Wrapf(types.ErrUnknown, "scheduled tx %d", txID)
+	}
+	if scheduled.Tx.Sender != sender.String() {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "tx %d was not sent by %s", txID, sender)
+	}
+	k.deleteScheduledTransfer(ctx, *scheduled)
+	if err := k.refundOutgoingTx(ctx, &scheduled.Tx, sender); err != nil {
+		return err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeBridgeWithdrawCanceled,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyOutgoingTXID, fmt.Sprint(txID)),
+	))
+	return nil
+}
+
+func (k Keeper) setScheduledTransfer(ctx sdk.Context, scheduled types.ScheduledTransfer) {
+	ctx.KVStore(k.storeKey).Set(types.GetScheduledTransferKey(scheduled.Tx.Id), k.cdc.MustMarshalBinaryBare(&scheduled))
+}
+
+// deleteScheduledTransfer removes a scheduled transfer and its index entries
+func (k Keeper) deleteScheduledTransfer(ctx sdk.Context, scheduled types.ScheduledTransfer) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetScheduledTransferKey(scheduled.Tx.Id))
+	store.Delete(types.GetScheduledTransferByHeightKey(scheduled.ExecuteAfterHeight, scheduled.Tx.Id))
+	store.Delete(types.GetScheduledTransferByTimeKey(scheduled.ExecuteAfterTime, scheduled.Tx.Id))
+}
+
+// indexScheduledTransfer indexes a scheduled transfer by the height it waits for, or by the time it waits
+// for once the chain reached the height, see ReleaseScheduledTransfers
+func (k Keeper) indexScheduledTransfer(ctx sdk.Context, scheduled types.ScheduledTransfer) {
+	store := ctx.KVStore(k.storeKey)
+	if scheduled.ExecuteAfterHeight > uint64(ctx.BlockHeight()) {
+		store.Set(types.GetScheduledTransferByHeightKey(scheduled.ExecuteAfterHeight, scheduled.Tx.Id), []byte{})
+		return
+	}
+	store.Delete(types.GetScheduledTransferByHeightKey(scheduled.ExecuteAfterHeight, scheduled.Tx.Id))
+	store.Set(types.GetScheduledTransferByTimeKey(scheduled.ExecuteAfterTime, scheduled.Tx.Id), []byte{})
+}
+
+// GetScheduledTransfer returns a transfer waiting for its earliest execution, or nil if there is none
+// with the tx id
+func (k Keeper) GetScheduledTransfer(ctx sdk.Context, txID uint64) *types.ScheduledTransfer {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetScheduledTransferKey(txID))
+	if bz == nil {
+		return nil
+	}
+	var scheduled types.ScheduledTransfer
+	k.cdc.MustUnmarshalBinaryBare(bz, &scheduled)
+	return &scheduled
+}
+
+// IterateScheduledTransfers iterates over the scheduled transfers in ascending tx id order
+func (k Keeper) IterateScheduledTransfers(ctx sdk.Context, cb func(types.ScheduledTransfer) bool) {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.ScheduledTransferKey)
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var scheduled types.ScheduledTransfer
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &scheduled)
+		// cb returns true to stop early
+		if cb(scheduled) {
+			break
+		}
+	}
+}
+
+// GetScheduledTransfers returns all scheduled transfers
+func (k Keeper) GetScheduledTransfers(ctx sdk.Context) (out []types.ScheduledTransfer) {
+	k.IterateScheduledTransfers(ctx, func(scheduled types.ScheduledTransfer) bool {
+		out = append(out, scheduled)
+		return false
+	})
+	return
+}
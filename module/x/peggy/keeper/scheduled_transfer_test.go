@@ -0,0 +1,121 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledTransfer(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context.WithBlockHeight(10).WithBlockTime(time.Unix(1000, 0))
+	k := input.PeggyKeeper
+	var (
+		mySender            = AccAddrs[0]
+		myReceiver          = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		vouchers            = sdk.NewCoins(types.NewERC20Token(1000, myTokenContractAddr).PeggyCoin())
+		amount              = types.NewERC20Token(100, myTokenContractAddr).PeggyCoin()
+		fee                 = types.NewERC20Token(2, myTokenContractAddr).PeggyCoin()
+	)
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, vouchers))
+	require.NoError(t, input.BankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, mySender, vouchers))
+	msgServer := NewMsgServerImpl(k)
+
+	// an earliest execution in the past is refused
+	_, err := k.ScheduleOutgoingTx(ctx, mySender, myReceiver, amount, fee, 10, 1000)
+	assert.ErrorIs(t, err, types.ErrInvalid)
+
+	// the amount and fee are taken right away but the transfer is kept out of the pool
+	_, err = msgServer.SendToEth(sdk.WrapSDKContext(ctx), &types.MsgSendToEth{
+		Sender:             mySender.String(),
+		EthDest:            myReceiver,
+		Amount:             amount,
+		BridgeFee:          fee,
+		ExpirationHeight:   30,
+		ExecuteAfterHeight: 20,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, sdk.NewInt(898), input.BankKeeper.GetBalance(ctx, mySender, amount.Denom).Amount)
+	assert.Empty(t, k.GetPoolTransactions(ctx))
+	scheduled := k.GetScheduledTransfer(ctx, 1)
+	require.NotNil(t, scheduled)
+	assert.Equal(t, uint64(20), scheduled.ExecuteAfterHeight)
+	assert.Equal(t, uint64(30), scheduled.Tx.ExpirationHeight)
+	_, broken := PendingFeesInvariant(k)(ctx)
+	assert.False(t, broken)
+	_, broken = ModuleResidueInvariant(k)(ctx)
+	assert.False(t, broken)
+
+	// nothing is released before the earliest execution
+	k.ReleaseScheduledTransfers(ctx.WithBlockHeight(19))
+	assert.Empty(t, k.GetPoolTransactions(ctx))
+
+	k.ReleaseScheduledTransfers(ctx.WithBlockHeight(20))
+	assert.Nil(t, k.GetScheduledTransfer(ctx, 1))
+	pool := k.GetPoolTransactions(ctx)
+	require.Len(t, pool, 1)
+	assert.Equal(t, scheduled.Tx, *pool[0])
+
+	// a transfer scheduled by time is cancelled by its sender only
+	txID, err := k.ScheduleOutgoingTx(ctx, mySender, myReceiver, amount, fee, 0, 2000)
+	require.NoError(t, err)
+	err = k.CancelScheduledTransfer(ctx, txID, AccAddrs[1])
+	assert.ErrorIs(t, err, sdkerrors.ErrUnauthorized)
+	_, err = msgServer.CancelSendToEth(sdk.WrapSDKContext(ctx), &types.MsgCancelSendToEth{TransactionId: txID, Sender: mySender.String()})
+	require.NoError(t, err)
+	assert.Nil(t, k.GetScheduledTransfer(ctx, txID))
+	assert.Equal(t, sdk.NewInt(898), input.BankKeeper.GetBalance(ctx, mySender, amount.Denom).Amount)
+	assert.Empty(t, k.GetScheduledTransfers(ctx))
+
+	// once the height is reached a transfer waits for its time
+	txID, err = k.ScheduleOutgoingTx(ctx, mySender, myReceiver, amount, fee, 20, 2000)
+	require.NoError(t, err)
+	k.ReleaseScheduledTransfers(ctx.WithBlockHeight(20))
+	assert.NotNil(t, k.GetScheduledTransfer(ctx, txID))
+	k.ReleaseScheduledTransfers(ctx.WithBlockHeight(20).WithBlockTime(time.Unix(2000, 0)))
+	assert.Nil(t, k.GetScheduledTransfer(ctx, txID))
+	assert.Len(t, k.GetPoolTransactions(ctx), 2)
+
+	// the transfers of a paused token stay scheduled until the token is unpaused
+	txID, err = k.ScheduleOutgoingTx(ctx, mySender, myReceiver, amount, fee, 30, 0)
+	require.NoError(t, err)
+	require.NoError(t, k.PauseToken(ctx, k.GetAuthority(), myTokenContractAddr))
+	k.ReleaseScheduledTransfers(ctx.WithBlockHeight(30))
+	assert.NotNil(t, k.GetScheduledTransfer(ctx, txID))
+	require.NoError(t, k.UnpauseToken(ctx.WithBlockHeight(30), k.GetAuthority(), myTokenContractAddr))
+	k.ReleaseScheduledTransfers(ctx.WithBlockHeight(30))
+	assert.Nil(t, k.GetScheduledTransfer(ctx, txID))
+	assert.Len(t, k.GetPoolTransactions(ctx), 3)
+}
+
+func TestMigrateScheduledTransferIndex(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context.WithBlockHeight(10).WithBlockTime(time.Unix(1000, 0))
+	k := input.PeggyKeeper
+	tx := types.OutgoingTransferTx{
+		Id:          1,
+		Sender:      AccAddrs[0].String(),
+		DestAddress: "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7",
+		Erc20Token:  types.NewERC20Token(100, "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"),
+		Erc20Fee:    types.NewERC20Token(2, "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"),
+	}
+	// a transfer scheduled before the index and a cursor of the scan that released them
+	k.setScheduledTransfer(ctx, types.ScheduledTransfer{Tx: tx, ExecuteAfterHeight: 20})
+	ctx.KVStore(k.storeKey).Set(types.GetWorkQueueKey(workTaskReleaseScheduledTransfers), types.GetScheduledTransferKey(1))
+	k.ReleaseScheduledTransfers(ctx.WithBlockHeight(20))
+	assert.Empty(t, k.GetPoolTransactions(ctx))
+
+	require.NoError(t, k.MigrateScheduledTransferIndex(ctx))
+	assert.False(t, ctx.KVStore(k.storeKey).Has(types.GetWorkQueueKey(workTaskReleaseScheduledTransfers)))
+	k.ReleaseScheduledTransfers(ctx.WithBlockHeight(20))
+	assert.Nil(t, k.GetScheduledTransfer(ctx, 1))
+	assert.Len(t, k.GetPoolTransactions(ctx), 1)
+}
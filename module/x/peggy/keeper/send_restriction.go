@@ -0,0 +1,55 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// SendRestriction returns an error when the coins contain the voucher of an ethereum originated token
+// while the RestrictVoucherTransfers param is set, the vouchers can then only be minted by deposits and
+// burned by SendToEth. Transfers to and from module accounts go through the module account methods of
+// the bank keeper and are not restricted.
+func (k Keeper) SendRestriction(ctx sdk.Context, amt sdk.Coins) error {
+	if !k.GetParams(ctx).RestrictVoucherTransfers {
+		return nil
+	}
+	for _, coin := range amt {
+		if _, err := types.PeggyDenomToERC20(coin.Denom); err == nil {
+			return sdkerrors.Wrapf(types.ErrVoucherTransferDisabled, "denom %s", coin.Denom)
+		}
+	}
+	return nil
+}
+
+// RestrictedBankKeeper is the bank keeper handed to the bank module so MsgSend and MsgMultiSend apply
+// the peggy SendRestriction
+type RestrictedBankKeeper struct {
+	bankkeeper.Keeper
+	peggyKeeper Keeper
+}
+
+// NewRestrictedBankKeeper wraps the bank keeper with the peggy SendRestriction
+func NewRestrictedBankKeeper(bankKeeper bankkeeper.Keeper, peggyKeeper Keeper) RestrictedBankKeeper {
+	return RestrictedBankKeeper{Keeper: bankKeeper, peggyKeeper: peggyKeeper}
+}
+
+// SendCoins transfers the coins between accounts unless the SendRestriction rejects them
+func (k RestrictedBankKeeper) SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) error {
+	if err := k.peggyKeeper.SendRestriction(ctx, amt); err != nil {
+		return err
+	}
+	return k.Keeper.SendCoins(ctx, fromAddr, toAddr, amt)
+}
+
+// InputOutputCoins performs the multi send unless the SendRestriction rejects any of the inputs
+func (k RestrictedBankKeeper) InputOutputCoins(ctx sdk.Context, inputs []banktypes.Input, outputs []banktypes.Output) error {
+	for _, in := range inputs {
+		if err := k.peggyKeeper.SendRestriction(ctx, in.Coins); err != nil {
+			return err
+		}
+	}
+	return k.Keeper.InputOutputCoins(ctx, inputs, outputs)
+}
@@ -0,0 +1,60 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestrictedBankKeeper(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	var (
+		mySender, _         = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		myReceiver          = sdk.AccAddress([]byte("receiver____________"))
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		myEthReceiver       = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+	)
+	vouchers := sdk.Coins{types.NewERC20Token(1000, myTokenContractAddr).PeggyCoin()}
+	native := sdk.Coins{sdk.NewInt64Coin("stake", 1000)}
+	balances := vouchers.Add(native...)
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, balances))
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+	require.NoError(t, input.BankKeeper.SetBalances(ctx, mySender, balances))
+
+	bankKeeper := NewRestrictedBankKeeper(input.BankKeeper, input.PeggyKeeper)
+	oneVoucher := sdk.Coins{types.NewERC20Token(1, myTokenContractAddr).PeggyCoin()}
+	oneNative := sdk.Coins{sdk.NewInt64Coin("stake", 1)}
+	multiSend := func(amt sdk.Coins) error {
+		return bankKeeper.InputOutputCoins(ctx,
+			[]banktypes.Input{banktypes.NewInput(mySender, amt)},
+			[]banktypes.Output{banktypes.NewOutput(myReceiver, amt)},
+		)
+	}
+
+	// vouchers can be transferred by default
+	require.NoError(t, bankKeeper.SendCoins(ctx, mySender, myReceiver, oneVoucher))
+	require.NoError(t, multiSend(oneVoucher))
+
+	params := input.PeggyKeeper.GetParams(ctx)
+	params.RestrictVoucherTransfers = true
+	input.PeggyKeeper.SetParams(ctx, params)
+
+	// when restricted only the vouchers are rejected
+	err := bankKeeper.SendCoins(ctx, mySender, myReceiver, oneVoucher)
+	assert.True(t, types.ErrVoucherTransferDisabled.Is(err), err)
+	err = multiSend(oneVoucher.Add(oneNative...))
+	assert.True(t, types.ErrVoucherTransferDisabled.Is(err), err)
+	require.NoError(t, bankKeeper.SendCoins(ctx, mySender, myReceiver, oneNative))
+	require.NoError(t, multiSend(oneNative))
+	assert.Equal(t, sdk.NewInt(2), input.BankKeeper.GetBalance(ctx, myReceiver, oneVoucher[0].Denom).Amount)
+
+	// and can still be redeemed with SendToEth
+	_, err = input.PeggyKeeper.AddToOutgoingPool(ctx, mySender, myEthReceiver, oneVoucher[0], oneVoucher[0])
+	require.NoError(t, err)
+}
@@ -0,0 +1,59 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// simulateSendToEth adds the transfer to the outgoing pool of a cached context, so it fails for the
+// same reasons a MsgSendToEth would, and returns where the transfer would stand in the pool. Nothing
+// of the cached context is written back.
+func (k Keeper) simulateSendToEth(ctx sdk.Context, msg types.MsgSendToEth) (*types.QuerySimulateSendToEthResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	sender, _ := sdk.AccAddressFromBech32(msg.Sender)
+
+	cacheCtx, _ := ctx.CacheContext()
+	cacheCtx = cacheCtx.WithEventManager(sdk.NewEventManager())
+	txID, err := k.AddToOutgoingPool(cacheCtx, sender, msg.EthDest, msg.Amount, msg.BridgeFee)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := k.getPoolEntry(cacheCtx, txID)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(err, "tx %d", txID)
+	}
+	tokenContract := tx.Erc20Token.Contract
+
+	var position uint64
+	k.IterateOutgoingPoolByFee(cacheCtx, tokenContract, func(id uint64, _ *types.OutgoingTransferTx) bool {
+		position++
+		return id == txID
+	})
+
+	params := k.GetParams(ctx)
+	limit := uint64(k.getBatchTxLimit(ctx, tokenContract))
+	res := &types.QuerySimulateSendToEthResponse{
+		Tx:           *tx,
+		BridgeFee:    k.getBridgeFee(ctx, msg.BridgeFee),
+		PoolPosition: position,
+		BatchTxLimit: limit,
+	}
+	if limit == 0 {
+		// the transfer is taken but no batch of the token can be built until the batch gas params change
+		return res, nil
+	}
+	res.BatchesAhead = (position - 1) / limit
+
+	// the next batch of the token can be requested once the cooldown of the last request passed, every
+	// batch ahead of the one with the transfer takes another cooldown
+	cooldown := params.BatchRequestCooldown
+	if last, found := k.GetLastBatchRequestHeight(ctx, tokenContract); found && last+cooldown > uint64(ctx.BlockHeight()) {
+		res.EstimatedWaitBlocks = last + cooldown - uint64(ctx.BlockHeight())
+	}
+	res.EstimatedWaitBlocks += res.BatchesAhead * cooldown
+	res.EstimatedWaitSeconds = res.EstimatedWaitBlocks * params.AverageBlockTime / 1000
+	return res, nil
+}
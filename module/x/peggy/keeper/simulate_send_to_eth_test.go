@@ -0,0 +1,80 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateSendToEth(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context.WithBlockHeight(100)
+	k := input.PeggyKeeper
+	var (
+		mySender            = AccAddrs[0]
+		myReceiver          = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		vouchers            = sdk.NewCoins(types.NewERC20Token(1000, myTokenContractAddr).PeggyCoin())
+		amount              = types.NewERC20Token(100, myTokenContractAddr).PeggyCoin()
+	)
+	params := k.GetParams(ctx)
+	params.BatchRequestCooldown = 10
+	params.TargetBatchGas = 600000
+	params.BatchBaseGas = 400000
+	params.BatchTxGas = 100000
+	params.BridgeFeeBasisPoints = 1000
+	k.SetParams(ctx, params)
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, vouchers))
+	require.NoError(t, input.BankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, mySender, vouchers))
+
+	// two batches of two transfers each are ahead of a transfer paying less than them
+	for i := 0; i < 4; i++ {
+		_, err := k.AddToOutgoingPool(ctx, mySender, myReceiver, amount, types.NewERC20Token(50, myTokenContractAddr).PeggyCoin())
+		require.NoError(t, err)
+	}
+	k.setLastBatchRequestHeight(ctx, myTokenContractAddr, 95)
+	simulate := func(amount, fee sdk.Coin) (*types.QuerySimulateSendToEthResponse, error) {
+		return k.SimulateSendToEth(sdk.WrapSDKContext(ctx), &types.QuerySimulateSendToEthRequest{
+			Sender:    mySender.String(),
+			EthDest:   myReceiver,
+			Amount:    amount,
+			BridgeFee: fee,
+		})
+	}
+	balance := input.BankKeeper.GetBalance(ctx, mySender, amount.Denom)
+
+	res, err := simulate(amount, types.NewERC20Token(20, myTokenContractAddr).PeggyCoin())
+	require.NoError(t, err)
+	assert.Equal(t, types.NewERC20Token(18, myTokenContractAddr), res.Tx.Erc20Fee)
+	assert.Equal(t, types.NewERC20Token(2, myTokenContractAddr).PeggyCoin(), res.BridgeFee)
+	assert.Equal(t, uint64(5), res.PoolPosition)
+	assert.Equal(t, uint64(2), res.BatchTxLimit)
+	assert.Equal(t, uint64(2), res.BatchesAhead)
+	// 5 blocks left of the cooldown and one cooldown for each batch ahead
+	assert.Equal(t, uint64(25), res.EstimatedWaitBlocks)
+	assert.Equal(t, uint64(125), res.EstimatedWaitSeconds)
+
+	// a transfer paying more than the others goes into the next batch
+	res, err = simulate(amount, types.NewERC20Token(100, myTokenContractAddr).PeggyCoin())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), res.PoolPosition)
+	assert.Equal(t, uint64(5), res.EstimatedWaitBlocks)
+
+	// nothing of the simulation is kept
+	assert.Equal(t, balance, input.BankKeeper.GetBalance(ctx, mySender, amount.Denom))
+	assert.Len(t, k.GetPoolTransactions(ctx), 4)
+
+	// the query fails with the error of the message
+	_, err = simulate(types.NewERC20Token(1000, myTokenContractAddr).PeggyCoin(), types.NewERC20Token(1, myTokenContractAddr).PeggyCoin())
+	assert.ErrorIs(t, err, sdkerrors.ErrInsufficientFunds)
+	_, err = simulate(amount, sdk.NewInt64Coin("stake", 1))
+	assert.ErrorIs(t, err, sdkerrors.ErrInvalidCoins)
+	k.setPausedToken(ctx, types.PausedToken{TokenContract: myTokenContractAddr})
+	_, err = simulate(amount, types.NewERC20Token(1, myTokenContractAddr).PeggyCoin())
+	assert.ErrorIs(t, err, types.ErrTokenPaused)
+}
@@ -0,0 +1,42 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// Slash slashes a validator for bridge misbehaviour through the staking keeper, which burns the
+// slashed tokens. The SlashedFundsRelayerShare of the burned tokens is minted into the relayer
+// reward pool again, so that the validators failing the bridge pay the relayers keeping it live.
+// The burned amount is measured on the supply of the bond denom as the staking keeper doesn't
+// return it, which also covers the tokens slashed from unbonding delegations and redelegations.
+func (k Keeper) Slash(ctx sdk.Context, consAddr sdk.ConsAddress, power int64, slashFraction sdk.Dec) {
+	share := k.GetParams(ctx).SlashedFundsRelayerShare
+	if share.IsNil() || !share.IsPositive() {
+		k.StakingKeeper.Slash(ctx, consAddr, ctx.BlockHeight(), power, slashFraction)
+		return
+	}
+
+	bondDenom := k.StakingKeeper.GetParams(ctx).BondDenom
+	supply := k.bankKeeper.GetSupply(ctx).GetTotal().AmountOf(bondDenom)
+	k.StakingKeeper.Slash(ctx, consAddr, ctx.BlockHeight(), power, slashFraction)
+	burned := supply.Sub(k.bankKeeper.GetSupply(ctx).GetTotal().AmountOf(bondDenom))
+
+	reward := sdk.NewCoin(bondDenom, share.MulInt(burned).TruncateInt())
+	if !reward.IsPositive() {
+		return
+	}
+	if err := k.fundRelayerRewardPoolFromSlash(ctx, reward); err != nil {
+		// the tokens stay burned, the slash itself must not fail
+		k.logger(ctx).Error("slashed funds not paid into relayer reward pool", "amount", reward.String(), "error", err.Error())
+		return
+	}
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeSlashedFundsToRelayers,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(types.AttributeKeyValidator, consAddr.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, reward.String()),
+		),
+	)
+}
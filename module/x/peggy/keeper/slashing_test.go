@@ -0,0 +1,53 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlashRoutesShareToRelayers(t *testing.T) {
+	t.Parallel()
+	input, ctx := SetupFiveValChain(t)
+	k := input.PeggyKeeper
+	bondDenom := input.StakingKeeper.BondDenom(ctx)
+	fraction := sdk.NewDecWithPrec(1, 2)
+
+	slash := func(i int) sdk.Int {
+		val, found := input.StakingKeeper.GetValidator(ctx, ValAddrs[i])
+		require.True(t, found)
+		cons, err := val.GetConsAddr()
+		require.NoError(t, err)
+		supply := input.BankKeeper.GetSupply(ctx).GetTotal().AmountOf(bondDenom)
+		k.Slash(ctx, cons, val.ConsensusPower(), fraction)
+		slashed, _ := input.StakingKeeper.GetValidator(ctx, ValAddrs[i])
+		require.True(t, slashed.GetTokens().LT(val.GetTokens()))
+		return supply.Sub(input.BankKeeper.GetSupply(ctx).GetTotal().AmountOf(bondDenom))
+	}
+
+	// without a share the slashed tokens are burned
+	burned := slash(0)
+	require.True(t, burned.IsPositive())
+	assert.True(t, k.GetRelayerRewardPool(ctx).IsZero())
+
+	// with a share half of them end up in the relayer reward pool, the validators are
+	// equal so the same amount is slashed
+	params := k.GetParams(ctx)
+	params.SlashedFundsRelayerShare = sdk.NewDecWithPrec(5, 1)
+	k.SetParams(ctx, params)
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	netBurned := slash(2)
+	pool := k.GetRelayerRewardPool(ctx).AmountOf(bondDenom)
+	require.True(t, pool.IsPositive())
+	assert.Equal(t, netBurned, pool)
+	assert.Equal(t, burned, pool.Add(netBurned))
+
+	var found bool
+	for _, e := range ctx.EventManager().Events() {
+		found = found || e.Type == types.EventTypeSlashedFundsToRelayers
+	}
+	assert.True(t, found)
+}
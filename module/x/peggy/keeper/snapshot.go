@@ -0,0 +1,91 @@
+package keeper
+
+import (
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// ExportBridgeSnapshot collects the data needed to deploy and fund a fresh bridge contract
+// after an incident on the Ethereum side: the current valset, the transfers that have not
+// been executed on Ethereum yet, the cosmos originated ERC20 mappings and the amount of every
+// token the bridge is accountable for
+func (k Keeper) ExportBridgeSnapshot(ctx sdk.Context) *types.BridgeSnapshot {
+	snapshot := &types.BridgeSnapshot{
+		GravityId:              k.GetGravityID(ctx),
+		BridgeContractAddress:  k.GetBridgeContractAddress(ctx),
+		BridgeChainId:          k.GetBridgeChainID(ctx),
+		LastObservedEventNonce: k.GetLastObservedEventNonce(ctx),
+		Valset:                 k.GetCurrentValset(ctx),
+		Batches:                k.GetOutgoingTxBatches(ctx),
+		UnbatchedTransactions:  k.GetPoolTransactions(ctx),
+	}
+
+	escrowed := make(map[string]sdk.Int)
+	add := func(tokenContract string, amount sdk.Int) {
+		if total, ok := escrowed[tokenContract]; ok {
+			amount = total.Add(amount)
+		}
+		escrowed[tokenContract] = amount
+	}
+
+	// ethereum originated vouchers in circulation
+	for _, coin := range k.bankKeeper.GetSupply(ctx).GetTotal() {
+		if tokenContract, err := types.PeggyDenomToERC20(coin.Denom); err == nil {
+			add(tokenContract, coin.Amount)
+		}
+	}
+	// cosmos originated coins locked in the escrow and the fee collector, these already
+	// include the coins of pending transfers. They are scaled to the decimals of the ERC20,
+	// without what the ERC20 can't represent.
+	escrowAddr := authtypes.NewModuleAddress(types.ModuleName)
+	feeCollectorAddr := authtypes.NewModuleAddress(types.FeeCollectorName)
+	k.IterateERC20ToDenom(ctx, func(_ []byte, erc20ToDenom *types.ERC20ToDenom) bool {
+		// the balance of a denom is counted for its current ERC20 only
+		if erc20ToDenom.Retired {
+			return false
+		}
+		snapshot.Erc20ToDenoms = append(snapshot.Erc20ToDenoms, *erc20ToDenom)
+		erc20Decimals, denomDecimals := k.tokenDecimals(ctx, erc20ToDenom.Erc20, erc20ToDenom.Denom, true)
+		for _, holder := range []sdk.AccAddress{escrowAddr, feeCollectorAddr} {
+			balance := k.bankKeeper.GetBalance(ctx, holder, erc20ToDenom.Denom)
+			balance = balance.Sub(types.ERC20Dust(balance, erc20Decimals, denomDecimals))
+			token, err := types.NewERC20TokenFromCoin(balance, erc20ToDenom.Erc20, erc20Decimals, denomDecimals)
+			if err != nil {
+				panic(err) // can't happen without the dust
+			}
+			add(erc20ToDenom.Erc20, token.Amount)
+		}
+		return false
+	})
+	// burned ethereum originated vouchers of transfers that have not been executed on Ethereum yet,
+	// their fees are still in circulation in the fee collector
+	addPending := func(tx *types.OutgoingTransferTx) {
+		if _, isCosmosOriginated := k.GetCosmosOriginatedDenom(ctx, tx.Erc20Token.Contract); isCosmosOriginated {
+			return
+		}
+		add(tx.Erc20Token.Contract, tx.Erc20Token.Amount)
+	}
+	for _, batch := range snapshot.Batches {
+		for _, tx := range batch.Transactions {
+			addPending(tx)
+		}
+	}
+	for _, tx := range snapshot.UnbatchedTransactions {
+		addPending(tx)
+	}
+	k.IterateScheduledTransfers(ctx, func(scheduled types.ScheduledTransfer) bool {
+		addPending(&scheduled.Tx)
+		return false
+	})
+
+	for tokenContract, amount := range escrowed {
+		snapshot.Escrowed = append(snapshot.Escrowed, types.ERC20Token{Contract: tokenContract, Amount: amount})
+	}
+	sort.Slice(snapshot.Escrowed, func(i, j int) bool {
+		return snapshot.Escrowed[i].Contract < snapshot.Escrowed[j].Contract
+	})
+	return snapshot
+}
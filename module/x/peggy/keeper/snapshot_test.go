@@ -0,0 +1,56 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportBridgeSnapshot(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	var (
+		mySender, _         = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		myReceiver          = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		cosmosTokenContract = "0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e"
+	)
+	// vouchers in circulation
+	vouchers := sdk.Coins{types.NewERC20Token(1000, myTokenContractAddr).PeggyCoin()}
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, vouchers))
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+	require.NoError(t, input.BankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, mySender, vouchers))
+	// and a cosmos originated token locked in the module account
+	k.setCosmosOriginatedDenomToERC20(ctx, "stake", cosmosTokenContract)
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, sdk.Coins{sdk.NewInt64Coin("stake", 50)}))
+
+	// burn some of the vouchers for a batched and an unbatched transfer
+	for _, v := range []uint64{3, 2} {
+		amount := types.NewERC20Token(100, myTokenContractAddr).PeggyCoin()
+		fee := types.NewERC20Token(v, myTokenContractAddr).PeggyCoin()
+		_, err := k.AddToOutgoingPool(ctx, mySender, myReceiver, amount, fee)
+		require.NoError(t, err)
+	}
+	batch, err := k.BuildOutgoingTXBatch(ctx, myTokenContractAddr, 1)
+	require.NoError(t, err)
+
+	snapshot := k.ExportBridgeSnapshot(ctx)
+	assert.Equal(t, k.GetGravityID(ctx), snapshot.GravityId)
+	assert.Equal(t, k.GetBridgeContractAddress(ctx), snapshot.BridgeContractAddress)
+	assert.Equal(t, k.GetCurrentValset(ctx), snapshot.Valset)
+	assert.Equal(t, []*types.OutgoingTxBatch{batch}, snapshot.Batches)
+	require.Len(t, snapshot.UnbatchedTransactions, 1)
+	assert.Equal(t, uint64(2), snapshot.UnbatchedTransactions[0].Id)
+	assert.Equal(t, []types.ERC20ToDenom{{Erc20: cosmosTokenContract, Denom: "stake"}}, snapshot.Erc20ToDenoms)
+	// the burned vouchers of pending transfers are still owed on Ethereum
+	exp := []types.ERC20Token{
+		{Contract: cosmosTokenContract, Amount: sdk.NewInt(50)},
+		{Contract: myTokenContractAddr, Amount: sdk.NewInt(1000)},
+	}
+	assert.Equal(t, exp, snapshot.Escrowed)
+}
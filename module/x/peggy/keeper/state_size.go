@@ -0,0 +1,30 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// GetStatePrefixSize counts the entries stored under a prefix of the peggy store and the bytes of
+// their keys and values. It reads every entry of the prefix so it is only meant for debugging.
+func (k Keeper) GetStatePrefixSize(ctx sdk.Context, keyPrefix types.KeyPrefix) types.StatePrefixSize {
+	size := types.StatePrefixSize{Name: keyPrefix.Name, Prefix: keyPrefix.Prefix}
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), keyPrefix.Prefix)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		size.Entries++
+		size.Bytes += uint64(len(iter.Key()) + len(iter.Value()))
+	}
+	return size
+}
+
+// GetModuleStateSize counts the entries and bytes stored under each registered prefix of the peggy
+// store, in the order of the registry
+func (k Keeper) GetModuleStateSize(ctx sdk.Context) []types.StatePrefixSize {
+	out := make([]types.StatePrefixSize, len(types.KeyPrefixes))
+	for i, p := range types.KeyPrefixes {
+		out[i] = k.GetStatePrefixSize(ctx, p)
+	}
+	return out
+}
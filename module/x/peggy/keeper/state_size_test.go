@@ -0,0 +1,55 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModuleStateSize(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	var (
+		mySender, _         = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		myReceiver          = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		allVouchers         = sdk.Coins{types.NewERC20Token(1000, myTokenContractAddr).PeggyCoin()}
+	)
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, allVouchers))
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+	require.NoError(t, input.BankKeeper.SetBalances(ctx, mySender, allVouchers))
+	amount := types.NewERC20Token(100, myTokenContractAddr).PeggyCoin()
+	for i := 0; i < 3; i++ {
+		_, err := k.AddToOutgoingPool(ctx, mySender, myReceiver, amount, amount)
+		require.NoError(t, err)
+	}
+
+	// every registered prefix is reported and the totals add up
+	res, err := k.ModuleStateSize(sdk.WrapSDKContext(ctx), &types.QueryModuleStateSizeRequest{})
+	require.NoError(t, err)
+	require.Len(t, res.Prefixes, len(types.KeyPrefixes))
+	var entries, bytes uint64
+	for _, p := range res.Prefixes {
+		entries += p.Entries
+		bytes += p.Bytes
+	}
+	assert.Equal(t, entries, res.TotalEntries)
+	assert.Equal(t, bytes, res.TotalBytes)
+
+	// a single prefix can be counted by its name
+	res, err = k.ModuleStateSize(sdk.WrapSDKContext(ctx), &types.QueryModuleStateSizeRequest{Prefix: "OutgoingTXPoolKey"})
+	require.NoError(t, err)
+	require.Len(t, res.Prefixes, 1)
+	assert.Equal(t, uint64(3), res.Prefixes[0].Entries)
+	assert.Equal(t, types.OutgoingTXPoolKey, res.Prefixes[0].Prefix)
+	assert.NotZero(t, res.TotalBytes)
+
+	_, err = k.ModuleStateSize(sdk.WrapSDKContext(ctx), &types.QueryModuleStateSizeRequest{Prefix: "unknown"})
+	assert.True(t, sdkerrors.ErrInvalidRequest.Is(err), err)
+}
@@ -0,0 +1,113 @@
+package keeper
+
+import (
+	"encoding/hex"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/abi"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// GetSubmittableValset returns the arguments and calldata of updateValset for the valset with the given
+// nonce, with the confirms of the members of the valset the bridge contract holds in the order of that
+// valset. Until a valset update was observed the contract is assumed to hold the valset stored before
+// the requested one.
+func (k Keeper) GetSubmittableValset(ctx sdk.Context, nonce uint64) (*types.QuerySubmittableValsetResponse, error) {
+	valset := k.GetValset(ctx, nonce)
+	if valset == nil {
+		return nil, sdkerrors.Wrapf(types.ErrUnknown, "valset %d", nonce)
+	}
+	current := k.GetValset(ctx, k.GetLastObservedValsetNonce(ctx))
+	if current == nil {
+		current = k.valsetBefore(ctx, nonce)
+	}
+	switch {
+	case current == nil:
+		return nil, sdkerrors.Wrapf(types.ErrUnknown, "valset before %d", nonce)
+	case current.Nonce >= nonce:
+		return nil, sdkerrors.Wrapf(types.ErrOutdated, "valset %d is not newer than the valset %d of the bridge", nonce, current.Nonce)
+	}
+
+	signatures := make(map[string][]byte)
+	for _, confirm := range k.GetValsetConfirms(ctx, nonce) {
+		signature, err := hex.DecodeString(confirm.Signature)
+		if err != nil {
+			return nil, sdkerrors.Wrapf(types.ErrInvalid, "signature of %s", confirm.Orchestrator)
+		}
+		signatures[confirm.EthAddress] = signature
+	}
+	sigs, signed, err := types.SubmittableSignatures(*current, signatures)
+	if err != nil {
+		return nil, err
+	}
+	res := &types.QuerySubmittableValsetResponse{
+		NewValset:      valset.Args(),
+		CurrentValset:  current.Args(),
+		Signatures:     sigs,
+		SignedPower:    signed,
+		PowerThreshold: types.BridgePowerThreshold,
+	}
+	res.Calldata, err = abi.UpdateValsetCalldata(res.NewValset, res.CurrentValset, res.Signatures)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetSubmittableBatch returns the arguments and calldata of submitBatch for the batch with the given token
+// contract and nonce, with the confirms of the members of the valset the bridge contract holds in the order
+// of that valset. Until a valset update was observed the contract is assumed to hold the last valset stored
+// before the batch was built.
+func (k Keeper) GetSubmittableBatch(ctx sdk.Context, tokenContract string, nonce uint64) (*types.QuerySubmittableBatchResponse, error) {
+	batch := k.GetOutgoingTXBatch(ctx, tokenContract, nonce)
+	if batch == nil {
+		return nil, sdkerrors.Wrapf(types.ErrUnknown, "batch %d of %s", nonce, tokenContract)
+	}
+	current := k.GetValset(ctx, k.GetLastObservedValsetNonce(ctx))
+	if current == nil {
+		current = k.valsetBefore(ctx, batch.Block+1)
+	}
+	if current == nil {
+		return nil, sdkerrors.Wrapf(types.ErrUnknown, "valset before batch %d of %s", nonce, tokenContract)
+	}
+
+	signatures := make(map[string][]byte)
+	for _, confirm := range k.GetBatchConfirmByNonceAndTokenContract(ctx, nonce, tokenContract) {
+		signature, err := hex.DecodeString(confirm.Signature)
+		if err != nil {
+			return nil, sdkerrors.Wrapf(types.ErrInvalid, "signature of %s", confirm.Orchestrator)
+		}
+		signatures[confirm.EthSigner] = signature
+	}
+	sigs, signed, err := types.SubmittableSignatures(*current, signatures)
+	if err != nil {
+		return nil, err
+	}
+	res := &types.QuerySubmittableBatchResponse{
+		CurrentValset:  current.Args(),
+		Signatures:     sigs,
+		Batch:          batch.Args(),
+		SignedPower:    signed,
+		PowerThreshold: types.BridgePowerThreshold,
+	}
+	res.Calldata, err = abi.SubmitBatchCalldata(res.CurrentValset, res.Signatures, res.Batch)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// valsetBefore returns the latest valset stored with a nonce below the given one, nil if there is none
+func (k Keeper) valsetBefore(ctx sdk.Context, nonce uint64) *types.Valset {
+	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.ValsetRequestKey)
+	iter := prefixStore.ReverseIterator(nil, types.UInt64Bytes(nonce))
+	defer iter.Close()
+	if !iter.Valid() {
+		return nil
+	}
+	var valset types.Valset
+	k.cdc.MustUnmarshalBinaryBare(iter.Value(), &valset)
+	return &valset
+}
@@ -0,0 +1,103 @@
+package keeper
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/abi"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmittablePayloads(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context
+	k := input.PeggyKeeper
+	var (
+		mySender, _         = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		myReceiver          = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		vouchers            = sdk.Coins{types.NewERC20Token(1000, myTokenContractAddr).PeggyCoin()}
+	)
+	members := func(addrs ...int) []*types.BridgeValidator {
+		var out []*types.BridgeValidator
+		for _, i := range addrs {
+			out = append(out, &types.BridgeValidator{Power: uint64(1000 + i), EthereumAddress: EthAddrs[i].String()})
+		}
+		return out
+	}
+	// a fake signature of the i-th key, the confirms aren't verified again
+	signature := func(i int) string {
+		sig := append(bytes.Repeat([]byte{byte(i + 1)}, 64), 1)
+		return hex.EncodeToString(sig)
+	}
+	k.StoreValsetUnsafe(ctx, &types.Valset{Nonce: 1, Members: members(2, 1, 0)})
+	k.StoreValsetUnsafe(ctx, &types.Valset{Nonce: 2, Members: members(3, 2, 1)})
+
+	// the first valset has no valset to approve it
+	_, err := k.SubmittableValset(sdk.WrapSDKContext(ctx), &types.QuerySubmittableValsetRequest{Nonce: 1})
+	assert.True(t, types.ErrUnknown.Is(err), err)
+
+	// the members of the previous valset sign the update, in the order of that valset
+	for _, i := range []int{0, 3} {
+		k.SetValsetConfirm(ctx, types.MsgValsetConfirm{Nonce: 2, Orchestrator: AccAddrs[i].String(), EthAddress: EthAddrs[i].String(), Signature: signature(i)})
+	}
+	valsetRes, err := k.SubmittableValset(sdk.WrapSDKContext(ctx), &types.QuerySubmittableValsetRequest{Nonce: 2})
+	require.NoError(t, err)
+	assert.Equal(t, types.ValsetArgs{Validators: []string{EthAddrs[3].String(), EthAddrs[2].String(), EthAddrs[1].String()}, Powers: []uint64{1003, 1002, 1001}, ValsetNonce: 2}, valsetRes.NewValset)
+	assert.Equal(t, uint64(1), valsetRes.CurrentValset.ValsetNonce)
+	unsigned := types.CheckpointSignature{R: make([]byte, 32), S: make([]byte, 32)}
+	signed := types.CheckpointSignature{V: 28, R: bytes.Repeat([]byte{1}, 32), S: bytes.Repeat([]byte{1}, 32)}
+	assert.Equal(t, []types.CheckpointSignature{unsigned, unsigned, signed}, valsetRes.Signatures)
+	assert.Equal(t, uint64(1000), valsetRes.SignedPower)
+	assert.Equal(t, types.BridgePowerThreshold, valsetRes.PowerThreshold)
+	calldata, err := abi.UpdateValsetCalldata(valsetRes.NewValset, valsetRes.CurrentValset, valsetRes.Signatures)
+	require.NoError(t, err)
+	assert.Equal(t, calldata, valsetRes.Calldata)
+
+	// once the valset is observed the bridge holds it
+	require.NoError(t, k.ValsetUpdatedObserved(ctx, 1))
+	require.NoError(t, k.ValsetUpdatedObserved(ctx, 2))
+	_, err = k.SubmittableValset(sdk.WrapSDKContext(ctx), &types.QuerySubmittableValsetRequest{Nonce: 2})
+	assert.True(t, types.ErrOutdated.Is(err), err)
+
+	// batches are signed by the valset of the bridge
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, vouchers))
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+	require.NoError(t, input.BankKeeper.SetBalances(ctx, mySender, vouchers))
+	for i := 0; i < 2; i++ {
+		amount := types.NewERC20Token(100, myTokenContractAddr).PeggyCoin()
+		fee := types.NewERC20Token(uint64(i+1), myTokenContractAddr).PeggyCoin()
+		_, err := k.AddToOutgoingPool(ctx, mySender, myReceiver, amount, fee)
+		require.NoError(t, err)
+	}
+	batch, err := k.BuildOutgoingTXBatch(ctx, myTokenContractAddr, 2)
+	require.NoError(t, err)
+	k.SetBatchConfirm(ctx, &types.MsgConfirmBatch{Nonce: batch.BatchNonce, TokenContract: myTokenContractAddr, EthSigner: EthAddrs[2].String(), Orchestrator: AccAddrs[2].String(), Signature: signature(2)})
+
+	batchRes, err := k.SubmittableBatch(sdk.WrapSDKContext(ctx), &types.QuerySubmittableBatchRequest{TokenContract: myTokenContractAddr, Nonce: batch.BatchNonce})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), batchRes.CurrentValset.ValsetNonce)
+	assert.Equal(t, batch.Args(), batchRes.Batch)
+	assert.Equal(t, []sdk.Int{sdk.NewInt(100), sdk.NewInt(100)}, batchRes.Batch.Amounts)
+	signed = types.CheckpointSignature{V: 28, R: bytes.Repeat([]byte{3}, 32), S: bytes.Repeat([]byte{3}, 32)}
+	assert.Equal(t, []types.CheckpointSignature{unsigned, signed, unsigned}, batchRes.Signatures)
+	assert.Equal(t, uint64(1002), batchRes.SignedPower)
+	calldata, err = abi.SubmitBatchCalldata(batchRes.CurrentValset, batchRes.Signatures, batchRes.Batch)
+	require.NoError(t, err)
+	assert.Equal(t, calldata, batchRes.Calldata)
+
+	_, err = k.SubmittableBatch(sdk.WrapSDKContext(ctx), &types.QuerySubmittableBatchRequest{TokenContract: myTokenContractAddr, Nonce: batch.BatchNonce + 1})
+	assert.True(t, types.ErrUnknown.Is(err), err)
+
+	// until a valset update is observed the bridge holds the valset stored before the batch
+	k.setLastObservedValsetNonce(ctx, 0)
+	k.StoreValsetUnsafe(ctx, &types.Valset{Nonce: batch.Block + 1, Members: members(0)})
+	batchRes, err = k.SubmittableBatch(sdk.WrapSDKContext(ctx), &types.QuerySubmittableBatchRequest{TokenContract: myTokenContractAddr, Nonce: batch.BatchNonce})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), batchRes.CurrentValset.ValsetNonce)
+}
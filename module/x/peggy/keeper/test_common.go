@@ -180,13 +180,14 @@ var (
 
 	// TestingPeggyParams is a set of peggy params for testing
 	TestingPeggyParams = types.Params{
-		PeggyId:                       "testpeggyid",
+		GravityId:                     "testgravityid",
 		ContractSourceHash:            "62328f7bc12efb28f86111d08c29b39285680a906ea0e524e0209d6f6657b713",
-		BridgeEthereumAddress:         "0x8858eeb3dfffa017d4bce9801d340d36cf895ccf",
+		BridgeEthereumAddress:         "0x8858eeB3DfffA017D4BCE9801D340D36Cf895CCf",
 		BridgeChainId:                 11,
 		SignedBatchesWindow:           10,
 		SignedValsetsWindow:           10,
 		UnbondSlashingValsetsWindow:   15,
+		EthereumHeightWindow:          100,
 		SignedClaimsWindow:            10,
 		TargetBatchTimeout:            60001,
 		AverageBlockTime:              5000,
@@ -195,6 +196,10 @@ var (
 		SlashFractionBatch:            sdk.NewDecWithPrec(1, 2),
 		SlashFractionClaim:            sdk.NewDecWithPrec(1, 2),
 		SlashFractionConflictingClaim: sdk.NewDecWithPrec(1, 2),
+		BatchRequestMinFee:            sdk.ZeroInt(),
+		SlashedFundsRelayerShare:      sdk.ZeroDec(),
+		NativeTokenBridgeCap:          sdk.ZeroInt(),
+		SignatureScheme:               types.SignatureSchemeEIP191,
 	}
 )
 
@@ -207,13 +212,15 @@ type TestInput struct {
 	DistKeeper     distrkeeper.Keeper
 	BankKeeper     bankkeeper.BaseKeeper
 	GovKeeper      govkeeper.Keeper
+	ParamsKeeper   paramskeeper.Keeper
+	ParamsKey      sdk.StoreKey
 	Context        sdk.Context
 	Marshaler      codec.Marshaler
 	LegacyAmino    *codec.LegacyAmino
 }
 
 // SetupFiveValChain does all the initialization for a 5 Validator chain using the keys here
-func SetupFiveValChain(t *testing.T) (TestInput, sdk.Context) {
+func SetupFiveValChain(t testing.TB) (TestInput, sdk.Context) {
 	t.Helper()
 	input := CreateTestEnv(t)
 
@@ -260,7 +267,7 @@ func SetupFiveValChain(t *testing.T) (TestInput, sdk.Context) {
 }
 
 // CreateTestEnv creates the keeper testing environment for peggy
-func CreateTestEnv(t *testing.T) TestInput {
+func CreateTestEnv(t testing.TB) TestInput {
 	t.Helper()
 
 	// Initialize store keys
@@ -293,7 +300,7 @@ func CreateTestEnv(t *testing.T) TestInput {
 	ctx := sdk.NewContext(ms, tmproto.Header{
 		Height: 1234567,
 		Time:   time.Date(2020, time.April, 22, 12, 0, 0, 0, time.UTC),
-	}, false, log.TestingLogger())
+	}, false, log.NewNopLogger())
 
 	cdc := MakeTestCodec()
 	marshaler := MakeTestMarshaler()
@@ -315,6 +322,8 @@ func CreateTestEnv(t *testing.T) TestInput {
 		stakingtypes.NotBondedPoolName: {authtypes.Burner, authtypes.Staking},
 		govtypes.ModuleName:            {authtypes.Burner},
 		types.ModuleName:               {authtypes.Minter, authtypes.Burner},
+		types.FeeCollectorName:         {authtypes.Burner},
+		types.RelayerRewardPoolName:    nil,
 	}
 
 	accountKeeper := authkeeper.NewAccountKeeper(
@@ -396,7 +405,7 @@ func CreateTestEnv(t *testing.T) TestInput {
 		getSubspace(paramsKeeper, slashingtypes.ModuleName).WithKeyTable(slashingtypes.ParamKeyTable()),
 	)
 
-	k := NewKeeper(marshaler, peggyKey, getSubspace(paramsKeeper, types.DefaultParamspace), stakingKeeper, bankKeeper, slashingKeeper)
+	k := NewKeeper(marshaler, peggyKey, getSubspace(paramsKeeper, types.DefaultParamspace), stakingKeeper, bankKeeper, slashingKeeper, distKeeper, authtypes.NewModuleAddress(govtypes.ModuleName).String())
 
 	stakingKeeper = *stakingKeeper.SetHooks(
 		stakingtypes.NewMultiStakingHooks(
@@ -416,6 +425,8 @@ func CreateTestEnv(t *testing.T) TestInput {
 		SlashingKeeper: slashingKeeper,
 		DistKeeper:     distKeeper,
 		GovKeeper:      govKeeper,
+		ParamsKeeper:   paramsKeeper,
+		ParamsKey:      keyParams,
 		Context:        ctx,
 		Marshaler:      marshaler,
 		LegacyAmino:    cdc,
@@ -452,7 +463,7 @@ func MakeTestMarshaler() codec.Marshaler {
 }
 
 // MintVouchersFromAir creates new peggy vouchers given erc20tokens
-func MintVouchersFromAir(t *testing.T, ctx sdk.Context, k Keeper, dest sdk.AccAddress, amount types.ERC20Token) sdk.Coin {
+func MintVouchersFromAir(t testing.TB, ctx sdk.Context, k Keeper, dest sdk.AccAddress, amount types.ERC20Token) sdk.Coin {
 	coin := amount.PeggyCoin()
 	vouchers := sdk.Coins{coin}
 	err := k.bankKeeper.MintCoins(ctx, types.ModuleName, vouchers)
@@ -531,36 +542,6 @@ func (s *StakingKeeperMock) GetLastTotalPower(ctx sdk.Context) (power sdk.Int) {
 	return sdk.NewInt(total)
 }
 
-// IterateValidators staisfies the interface
-func (s *StakingKeeperMock) IterateValidators(ctx sdk.Context, cb func(index int64, validator stakingtypes.ValidatorI) (stop bool)) {
-	for i, val := range s.BondedValidators {
-		stop := cb(int64(i), val)
-		if stop {
-			break
-		}
-	}
-}
-
-// IterateBondedValidatorsByPower staisfies the interface
-func (s *StakingKeeperMock) IterateBondedValidatorsByPower(ctx sdk.Context, cb func(index int64, validator stakingtypes.ValidatorI) (stop bool)) {
-	for i, val := range s.BondedValidators {
-		stop := cb(int64(i), val)
-		if stop {
-			break
-		}
-	}
-}
-
-// IterateLastValidators staisfies the interface
-func (s *StakingKeeperMock) IterateLastValidators(ctx sdk.Context, cb func(index int64, validator stakingtypes.ValidatorI) (stop bool)) {
-	for i, val := range s.BondedValidators {
-		stop := cb(int64(i), val)
-		if stop {
-			break
-		}
-	}
-}
-
 // Validator staisfies the interface
 func (s *StakingKeeperMock) Validator(ctx sdk.Context, addr sdk.ValAddress) stakingtypes.ValidatorI {
 	for _, val := range s.BondedValidators {
@@ -571,26 +552,19 @@ func (s *StakingKeeperMock) Validator(ctx sdk.Context, addr sdk.ValAddress) stak
 	return nil
 }
 
-// ValidatorByConsAddr staisfies the interface
-func (s *StakingKeeperMock) ValidatorByConsAddr(ctx sdk.Context, addr sdk.ConsAddress) stakingtypes.ValidatorI {
-	for _, val := range s.BondedValidators {
-		cons, err := val.GetConsAddr()
-		if err != nil {
-			panic(err)
-		}
-		if cons.Equals(addr) {
-			return val
-		}
-	}
-	return nil
-}
-
+// GetParams returns the staking params of the test env
 func (s *StakingKeeperMock) GetParams(ctx sdk.Context) stakingtypes.Params {
-	panic("unexpected call")
+	return TestingStakeParams
 }
 
+// GetValidator staisfies the interface
 func (s *StakingKeeperMock) GetValidator(ctx sdk.Context, addr sdk.ValAddress) (validator stakingtypes.Validator, found bool) {
-	panic("unexpected call")
+	for _, val := range s.BondedValidators {
+		if val.GetOperator().Equals(addr) {
+			return val, true
+		}
+	}
+	return stakingtypes.Validator{}, false
 }
 
 func (s *StakingKeeperMock) ValidatorQueueIterator(ctx sdk.Context, endTime time.Time, endHeight int64) sdk.Iterator {
@@ -621,40 +595,37 @@ func (s AlwaysPanicStakingMock) GetLastValidatorPower(ctx sdk.Context, operator
 	panic("unexpected call")
 }
 
-// IterateValidators staisfies the interface
-func (s AlwaysPanicStakingMock) IterateValidators(sdk.Context, func(index int64, validator stakingtypes.ValidatorI) (stop bool)) {
+// Validator staisfies the interface
+func (s AlwaysPanicStakingMock) Validator(sdk.Context, sdk.ValAddress) stakingtypes.ValidatorI {
 	panic("unexpected call")
 }
 
-// IterateBondedValidatorsByPower staisfies the interface
-func (s AlwaysPanicStakingMock) IterateBondedValidatorsByPower(sdk.Context, func(index int64, validator stakingtypes.ValidatorI) (stop bool)) {
+// Slash staisfies the interface
+func (s AlwaysPanicStakingMock) Slash(sdk.Context, sdk.ConsAddress, int64, int64, sdk.Dec) {
 	panic("unexpected call")
 }
 
-// IterateLastValidators staisfies the interface
-func (s AlwaysPanicStakingMock) IterateLastValidators(sdk.Context, func(index int64, validator stakingtypes.ValidatorI) (stop bool)) {
+// Jail staisfies the interface
+func (s AlwaysPanicStakingMock) Jail(sdk.Context, sdk.ConsAddress) {
 	panic("unexpected call")
 }
 
-// Validator staisfies the interface
-func (s AlwaysPanicStakingMock) Validator(sdk.Context, sdk.ValAddress) stakingtypes.ValidatorI {
+func (s AlwaysPanicStakingMock) GetParams(ctx sdk.Context) stakingtypes.Params {
 	panic("unexpected call")
 }
 
-// ValidatorByConsAddr staisfies the interface
-func (s AlwaysPanicStakingMock) ValidatorByConsAddr(sdk.Context, sdk.ConsAddress) stakingtypes.ValidatorI {
+func (s AlwaysPanicStakingMock) GetValidator(ctx sdk.Context, addr sdk.ValAddress) (validator stakingtypes.Validator, found bool) {
 	panic("unexpected call")
 }
 
-// Slash staisfies the interface
-func (s AlwaysPanicStakingMock) Slash(sdk.Context, sdk.ConsAddress, int64, int64, sdk.Dec) {
+func (s AlwaysPanicStakingMock) ValidatorQueueIterator(ctx sdk.Context, endTime time.Time, endHeight int64) sdk.Iterator {
 	panic("unexpected call")
 }
 
-// Jail staisfies the interface
-func (s AlwaysPanicStakingMock) Jail(sdk.Context, sdk.ConsAddress) {
-	panic("unexpected call")
-}
+var (
+	_ types.StakingKeeper = &StakingKeeperMock{}
+	_ types.StakingKeeper = AlwaysPanicStakingMock{}
+)
 
 func NewTestMsgCreateValidator(address sdk.ValAddress, pubKey ccrypto.PubKey, amt sdk.Int) *stakingtypes.MsgCreateValidator {
 	commission := stakingtypes.NewCommissionRates(sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec())
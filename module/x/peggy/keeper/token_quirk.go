@@ -0,0 +1,77 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// GetTokenQuirk returns the quirks of the token, nil if none of its deposits showed a discrepancy
+func (k Keeper) GetTokenQuirk(ctx sdk.Context, tokenContract string) *types.TokenQuirk {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetTokenQuirkKey(tokenContract))
+	if bz == nil {
+		return nil
+	}
+	var quirk types.TokenQuirk
+	k.cdc.MustUnmarshalBinaryBare(bz, &quirk)
+	return &quirk
+}
+
+// SetTokenQuirk stores the quirks of a token
+func (k Keeper) SetTokenQuirk(ctx sdk.Context, quirk types.TokenQuirk) {
+	ctx.KVStore(k.storeKey).Set(types.GetTokenQuirkKey(quirk.TokenContract), k.cdc.MustMarshalBinaryBare(&quirk))
+}
+
+// IterateTokenQuirks iterates over the quirks of all flagged tokens ordered by token contract
+func (k Keeper) IterateTokenQuirks(ctx sdk.Context, cb func(types.TokenQuirk) bool) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.TokenQuirkKey)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		var quirk types.TokenQuirk
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &quirk)
+		// cb returns true to stop early
+		if cb(quirk) {
+			break
+		}
+	}
+}
+
+// GetAllTokenQuirks returns the quirks of all flagged tokens
+func (k Keeper) GetAllTokenQuirks(ctx sdk.Context) (out []types.TokenQuirk) {
+	k.IterateTokenQuirks(ctx, func(quirk types.TokenQuirk) bool {
+		out = append(out, quirk)
+		return false
+	})
+	return
+}
+
+// recordDepositDiscrepancy flags the token of a deposit whose bridge contract received a different amount
+// than the deposit event reported
+func (k Keeper) recordDepositDiscrepancy(ctx sdk.Context, claim *types.MsgDepositClaim) {
+	quirk := k.GetTokenQuirk(ctx, claim.TokenContract)
+	if quirk == nil {
+		quirk = &types.TokenQuirk{TokenContract: claim.TokenContract, Shortfall: sdk.ZeroInt()}
+	}
+	if claim.ReceivedAmount.LT(claim.Amount) {
+		quirk.FeeOnTransfer = true
+		quirk.Shortfall = quirk.Shortfall.Add(claim.Amount.Sub(*claim.ReceivedAmount))
+	} else {
+		quirk.Rebasing = true
+	}
+	quirk.DiscrepancyCount++
+	quirk.LastEventNonce = claim.EventNonce
+	k.SetTokenQuirk(ctx, *quirk)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeDepositDiscrepancy,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+			sdk.NewAttribute(types.AttributeKeyNonce, fmt.Sprint(claim.EventNonce)),
+			sdk.NewAttribute(types.AttributeKeyTokenContract, claim.TokenContract),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, claim.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyReceivedAmount, claim.ReceivedAmount.String()),
+		),
+	)
+}
@@ -0,0 +1,90 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// GetLastObservedValsetNonce returns the nonce of the last valset the bridge contract was observed
+// switching to, zero if no valset update was observed yet
+func (k Keeper) GetLastObservedValsetNonce(ctx sdk.Context) uint64 {
+	bz := ctx.KVStore(k.storeKey).Get(types.LastObservedValsetNonceKey)
+	if len(bz) == 0 {
+		return 0
+	}
+	return types.UInt64FromBytes(bz)
+}
+
+func (k Keeper) setLastObservedValsetNonce(ctx sdk.Context, nonce uint64) {
+	ctx.KVStore(k.storeKey).Set(types.LastObservedValsetNonceKey, types.UInt64Bytes(nonce))
+}
+
+// ValsetUpdatedObserved records that the bridge contract switched to the valset with the given nonce.
+// Valsets are expected to be relayed in increasing order without gaps, the valsets stored between the
+// last observed valset and this one are recorded as skipped until governance abandons them. The first
+// observed valset update only sets the starting point since earlier valsets may predate the contract.
+func (k Keeper) ValsetUpdatedObserved(ctx sdk.Context, nonce uint64) error {
+	last := k.GetLastObservedValsetNonce(ctx)
+	switch {
+	case nonce <= last:
+		return sdkerrors.Wrapf(types.ErrOutdated, "valset nonce %d after %d", nonce, last)
+	case !k.HasValsetRequest(ctx, nonce):
+		return sdkerrors.Wrapf(types.ErrUnknown, "valset nonce %d", nonce)
+	}
+	if last != 0 {
+		var skipped []uint64
+		prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.ValsetRequestKey)
+		iter := prefixStore.Iterator(types.UInt64Bytes(last+1), types.UInt64Bytes(nonce))
+		for ; iter.Valid(); iter.Next() {
+			skipped = append(skipped, types.UInt64FromBytes(iter.Key()))
+		}
+		iter.Close()
+		if len(skipped) != 0 {
+			k.logger(ctx).Error("valset nonces skipped on ethereum", "skipped", skipped, "observed", nonce)
+		}
+		for _, skippedNonce := range skipped {
+			k.setSkippedValsetNonce(ctx, skippedNonce)
+			ctx.EventManager().EmitEvent(sdk.NewEvent(
+				types.EventTypeValsetNonceSkipped,
+				sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+				sdk.NewAttribute(types.AttributeKeyValsetNonce, fmt.Sprint(skippedNonce)),
+			))
+		}
+	}
+	k.setLastObservedValsetNonce(ctx, nonce)
+	return nil
+}
+
+func (k Keeper) setSkippedValsetNonce(ctx sdk.Context, nonce uint64) {
+	ctx.KVStore(k.storeKey).Set(types.GetSkippedValsetNonceKey(nonce), []byte{1})
+}
+
+// GetSkippedValsetNonces returns the valset nonces that were skipped on Ethereum and not abandoned yet
+func (k Keeper) GetSkippedValsetNonces(ctx sdk.Context) (nonces []uint64) {
+	iter := prefix.NewStore(ctx.KVStore(k.storeKey), types.SkippedValsetNonceKey).Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		nonces = append(nonces, types.UInt64FromBytes(iter.Key()))
+	}
+	return nonces
+}
+
+// AbandonValsetNonce accepts a skipped valset nonce as abandoned, restoring the valset nonce continuity
+func (k Keeper) AbandonValsetNonce(ctx sdk.Context, nonce uint64) error {
+	store := ctx.KVStore(k.storeKey)
+	key := types.GetSkippedValsetNonceKey(nonce)
+	if !store.Has(key) {
+		return sdkerrors.Wrapf(types.ErrUnknown, "skipped valset nonce %d", nonce)
+	}
+	store.Delete(key)
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeValsetNonceAbandoned,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(types.AttributeKeyValsetNonce, fmt.Sprint(nonce)),
+	))
+	return nil
+}
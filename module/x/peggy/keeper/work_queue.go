@@ -0,0 +1,144 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// The EndBlocker work that piles up with a backlog, tallying attestations, pruning timed out
+// attestations, cancelling timed out batches and logic calls, releasing timed out cancelled batches,
+// releasing scheduled transfers, refunding expired transfers, pruning the confirms and batch index
+// entries left behind and pruning the account ledgers, is metered against the EndBlockerWorkBudget param
+// so that a backlog can't stall block production. A task that runs out of budget leaves a cursor in the
+// work queue and resumes at it in the next block. Every task completes at least one unit of work per
+// block so it can't be starved by the tasks running before it.
+
+const (
+	workTaskPruneAttestations byte = iota + 1
+	workTaskCancelTimedOutBatches
+	workTaskCancelTimedOutLogicCalls
+	// the expired txs are refunded from an index now, the task is only kept to drop its cursor
+	workTaskRefundExpiredTxs
+	workTaskReleaseCancelledBatches
+	// the scheduled transfers are released from an index now, the task is only kept to drop its cursor
+	workTaskReleaseScheduledTransfers
+	workTaskPruneBatchConfirms
+	workTaskPruneLogicCallConfirms
+	workTaskPruneBatchedTxs
+)
+
+// budgetGasMeter counts the gas used by the EndBlocker work against the budget. Unlike the gas meter of a
+// transaction it never panics, the budget is checked between units of work so a unit in progress always
+// completes.
+type budgetGasMeter struct {
+	consumed sdk.Gas
+	limit    sdk.Gas
+}
+
+var _ sdk.GasMeter = &budgetGasMeter{}
+
+func (g *budgetGasMeter) GasConsumed() sdk.Gas {
+	return g.consumed
+}
+
+func (g *budgetGasMeter) GasConsumedToLimit() sdk.Gas {
+	if g.IsPastLimit() {
+		return g.limit
+	}
+	return g.consumed
+}
+
+func (g *budgetGasMeter) Limit() sdk.Gas {
+	return g.limit
+}
+
+func (g *budgetGasMeter) ConsumeGas(amount sdk.Gas, _ string) {
+	if g.consumed+amount < g.consumed {
+		g.consumed = ^sdk.Gas(0)
+		return
+	}
+	g.consumed += amount
+}
+
+func (g *budgetGasMeter) IsPastLimit() bool {
+	return g.consumed > g.limit
+}
+
+func (g *budgetGasMeter) IsOutOfGas() bool {
+	return g.consumed >= g.limit
+}
+
+func (g *budgetGasMeter) String() string {
+	return fmt.Sprintf("BudgetGasMeter:\n  limit: %d\n  consumed: %d", g.limit, g.consumed)
+}
+
+// WithWorkBudget returns a context metering the gas of the deferrable EndBlocker work against the
+// EndBlockerWorkBudget param, without a budget the context is returned unchanged
+func (k Keeper) WithWorkBudget(ctx sdk.Context) sdk.Context {
+	budget := k.GetParams(ctx).EndBlockerWorkBudget
+	if budget == 0 {
+		return ctx
+	}
+	return ctx.WithGasMeter(&budgetGasMeter{limit: budget})
+}
+
+// WorkBudgetExhausted returns true once the work budget of the context is spent, a context without a work
+// budget never runs out
+func WorkBudgetExhausted(ctx sdk.Context) bool {
+	return ctx.GasMeter().IsOutOfGas()
+}
+
+// scanWithWorkBudget calls process for the entries under the store prefix in key order, starting at the
+// cursor the task left in the work queue. process runs outside of the store iteration so it may modify
+// the store. Once the work budget is spent the key following the last processed entry is stored as the
+// cursor of the task. It returns true when the scan reached the end of the prefix.
+func (k Keeper) scanWithWorkBudget(ctx sdk.Context, task byte, storePrefix []byte, process func(key, value []byte)) bool {
+	store := ctx.KVStore(k.storeKey)
+	queueKey := types.GetWorkQueueKey(task)
+	start := store.Get(queueKey)
+	for processed := 0; ; processed++ {
+		if processed > 0 && WorkBudgetExhausted(ctx) {
+			store.Set(queueKey, start)
+			k.logger(ctx).Info("endblocker work deferred", "task", task)
+			return false
+		}
+		iter := prefix.NewStore(store, storePrefix).Iterator(start, nil)
+		if !iter.Valid() {
+			iter.Close()
+			store.Delete(queueKey)
+			return true
+		}
+		key := append([]byte{}, iter.Key()...)
+		value := append([]byte{}, iter.Value()...)
+		iter.Close()
+
+		process(key, value)
+		// the smallest key following the processed one
+		start = append(key, 0)
+	}
+}
+
+// CancelTimedOutBatches cancels the batches with a timeout below the given Ethereum height
+func (k Keeper) CancelTimedOutBatches(ctx sdk.Context, ethereumHeight uint64) {
+	k.scanWithWorkBudget(ctx, workTaskCancelTimedOutBatches, types.OutgoingTXBatchKey, func(_, value []byte) {
+		var batch types.OutgoingTxBatch
+		k.cdc.MustUnmarshalBinaryBare(value, &batch)
+		if batch.BatchTimeout < ethereumHeight {
+			k.CancelOutgoingTXBatch(ctx, batch.TokenContract, batch.BatchNonce)
+		}
+	})
+}
+
+// CancelTimedOutLogicCalls cancels the logic calls with a timeout below the given Ethereum height
+func (k Keeper) CancelTimedOutLogicCalls(ctx sdk.Context, ethereumHeight uint64) {
+	k.scanWithWorkBudget(ctx, workTaskCancelTimedOutLogicCalls, types.KeyOutgoingLogicCall, func(_, value []byte) {
+		var call types.OutgoingLogicCall
+		k.cdc.MustUnmarshalBinaryBare(value, &call)
+		if call.Timeout < ethereumHeight {
+			k.CancelOutgoingLogicCall(ctx, call.InvalidationId, call.InvalidationNonce)
+		}
+	})
+}
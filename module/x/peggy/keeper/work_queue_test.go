@@ -0,0 +1,54 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefundExpiredOutgoingTxsWithWorkBudget(t *testing.T) {
+	t.Parallel()
+	input := CreateTestEnv(t)
+	ctx := input.Context.WithBlockHeight(10).WithBlockTime(time.Unix(1000, 0))
+	var (
+		mySender, _         = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		myReceiver          = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		allVouchers         = sdk.Coins{types.NewERC20Token(1000, myTokenContractAddr).PeggyCoin()}
+		amount              = types.NewERC20Token(100, myTokenContractAddr).PeggyCoin()
+	)
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, allVouchers))
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+	require.NoError(t, input.BankKeeper.SetBalances(ctx, mySender, allVouchers))
+	for i := int64(1); i <= 3; i++ {
+		id, err := input.PeggyKeeper.AddToOutgoingPool(ctx, mySender, myReceiver, amount, types.NewERC20Token(uint64(i), myTokenContractAddr).PeggyCoin())
+		require.NoError(t, err)
+		require.NoError(t, input.PeggyKeeper.SetOutgoingTxExpiration(ctx, id, 11, 0))
+	}
+
+	// the smallest budget lets a single transfer be refunded per block
+	params := input.PeggyKeeper.GetParams(ctx)
+	params.EndBlockerWorkBudget = 1
+	input.PeggyKeeper.SetParams(ctx, params)
+	ctx = ctx.WithBlockHeight(11)
+	for remaining := 2; remaining >= 0; remaining-- {
+		input.PeggyKeeper.RefundExpiredOutgoingTxs(input.PeggyKeeper.WithWorkBudget(ctx))
+		assert.Len(t, input.PeggyKeeper.GetPoolTransactions(ctx), remaining)
+	}
+	assert.Equal(t, allVouchers, input.BankKeeper.GetAllBalances(ctx, mySender))
+
+	// without a budget the whole pool is handled at once
+	params.EndBlockerWorkBudget = 0
+	input.PeggyKeeper.SetParams(ctx, params)
+	for i := 0; i < 3; i++ {
+		id, err := input.PeggyKeeper.AddToOutgoingPool(ctx, mySender, myReceiver, amount, amount)
+		require.NoError(t, err)
+		require.NoError(t, input.PeggyKeeper.SetOutgoingTxExpiration(ctx, id, 12, 0))
+	}
+	input.PeggyKeeper.RefundExpiredOutgoingTxs(input.PeggyKeeper.WithWorkBudget(ctx.WithBlockHeight(12)))
+	assert.Empty(t, input.PeggyKeeper.GetPoolTransactions(ctx))
+}
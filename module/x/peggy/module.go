@@ -33,6 +33,14 @@ var (
 	_ module.AppModuleBasic = AppModuleBasic{}
 )
 
+// FlagDebugQueries is the start flag enabling the debug querier routes of the module
+const FlagDebugQueries = "x-peggy-debug-queries"
+
+// AddModuleInitFlags adds the peggy flags to the start command
+func AddModuleInitFlags(startCmd *cobra.Command) {
+	startCmd.Flags().Bool(FlagDebugQueries, false, "Answer the peggy debug queries, which read the whole peggy store (never enable on public nodes)")
+}
+
 // AppModuleBasic object for module implementation
 type AppModuleBasic struct{}
 
@@ -114,6 +122,7 @@ func (AppModule) Name() string {
 func (am AppModule) RegisterInvariants(ir sdk.InvariantRegistry) {
 	// TODO: make some invariants in the peggy module to ensure that
 	// coins aren't being fraudlently minted etc...
+	keeper.RegisterInvariants(ir, am.keeper)
 }
 
 // Route implements app module
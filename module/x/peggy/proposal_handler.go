@@ -0,0 +1,70 @@
+package peggy
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	paramproposal "github.com/cosmos/cosmos-sdk/x/params/types/proposal"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/keeper"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// NewProposalHandler returns a handler for "Peggy" governance proposals.
+func NewProposalHandler(k keeper.PeggyKeeper) govtypes.Handler {
+	return func(ctx sdk.Context, content govtypes.Content) error {
+		switch c := content.(type) {
+		case *types.ReturnReclaimableDepositProposal:
+			destination, err := sdk.AccAddressFromBech32(c.Destination)
+			if err != nil {
+				return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, c.Destination)
+			}
+			return k.ReturnReclaimableDeposit(ctx, c.EventNonce, destination)
+
+		case *types.UpdateBridgeContractProposal:
+			return k.RotateBridgeContract(ctx, c.Rotation())
+
+		case *types.AbandonValsetNonceProposal:
+			return k.AbandonValsetNonce(ctx, c.ValsetNonce)
+
+		case *types.UpdateParamsProposal:
+			// a passed proposal speaks for the governance module account
+			return k.UpdateParams(ctx, k.GetAuthority(), c.Params)
+
+		case *types.CancelBatchProposal:
+			return k.CancelBatch(ctx, k.GetAuthority(), c.TokenContract, c.Nonce)
+
+		case *types.SetLastObservedEventNonceProposal:
+			return k.SetLastObservedEventNonce(ctx, k.GetAuthority(), c.Nonce)
+
+		case *types.PauseTokenProposal:
+			return k.PauseToken(ctx, k.GetAuthority(), c.TokenContract)
+
+		case *types.UnpauseTokenProposal:
+			return k.UnpauseToken(ctx, k.GetAuthority(), c.TokenContract)
+
+		case *types.SetCosmosOriginatedERC20Proposal:
+			return k.SetCosmosOriginatedERC20(ctx, k.GetAuthority(), c.Denom, c.TokenContract, c.Decimals)
+
+		default:
+			return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized peggy proposal content type: %T", c)
+		}
+	}
+}
+
+// NewParamChangeProposalHandler wraps the params module's proposal handler so
+// that a change touching the peggy subspace is rejected. The peggy params are
+// kept in the module store and only change with MsgUpdateParams or an
+// UpdateParamsProposal, a change to the subspace would be silently ignored.
+// Returning an error makes gov discard the change.
+func NewParamChangeProposalHandler(_ keeper.PeggyKeeper, paramsHandler govtypes.Handler) govtypes.Handler {
+	return func(ctx sdk.Context, content govtypes.Content) error {
+		if c, ok := content.(*paramproposal.ParameterChangeProposal); ok {
+			for _, change := range c.Changes {
+				if change.Subspace == types.DefaultParamspace {
+					return sdkerrors.Wrap(types.ErrUnsupported, "peggy params are updated with MsgUpdateParams or an UpdateParamsProposal")
+				}
+			}
+		}
+		return paramsHandler(ctx, content)
+	}
+}
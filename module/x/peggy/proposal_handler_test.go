@@ -0,0 +1,183 @@
+package peggy
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	paramproposal "github.com/cosmos/cosmos-sdk/x/params/types/proposal"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/keeper"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReturnReclaimableDepositProposal(t *testing.T) {
+	var (
+		myOrchestratorAddr sdk.AccAddress = make([]byte, sdk.AddrLen)
+		myValAddr                         = sdk.ValAddress(myOrchestratorAddr) // revisit when proper mapping is impl in keeper
+		myNonce                           = uint64(1)
+		destination, _                    = sdk.AccAddressFromBech32("cosmos16ahjkfqxpp6lvfy9fpfnfjg39xr96qett0alj5")
+		tokenETHAddr                      = "0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e"
+		amount                            = sdk.NewInt(12)
+	)
+	input := keeper.CreateTestEnv(t)
+	ctx := input.Context
+	input.PeggyKeeper.StakingKeeper = keeper.NewStakingKeeperMock(myValAddr)
+	input.PeggyKeeper.SetOrchestratorValidator(ctx, myValAddr, myOrchestratorAddr)
+	h := NewHandler(input.PeggyKeeper)
+	ph := NewProposalHandler(input.PeggyKeeper)
+
+	ethClaim := types.MsgDepositClaim{
+		EventNonce:            myNonce,
+		TokenContract:         tokenETHAddr,
+		Amount:                amount,
+		EthereumSender:        "0xf9613b532673Cc223aBa451dFA8539B87e1F666D",
+		CosmosReceiver:        "cosmos1fatfingered",
+		Orchestrator:          myOrchestratorAddr.String(),
+		BridgeContractAddress: keeper.TestingPeggyParams.BridgeEthereumAddress,
+		BridgeChainId:         keeper.TestingPeggyParams.BridgeChainId,
+	}
+	_, err := h(ctx, &ethClaim)
+	require.NoError(t, err)
+	EndBlocker(ctx, input.PeggyKeeper)
+	require.NotNil(t, input.PeggyKeeper.GetReclaimableDeposit(ctx, myNonce))
+
+	// unknown deposits can't be returned
+	err = ph(ctx, types.NewReturnReclaimableDepositProposal("title", "description", myNonce+1, destination))
+	require.Error(t, err)
+
+	// when
+	proposal := types.NewReturnReclaimableDepositProposal("title", "description", myNonce, destination)
+	require.NoError(t, proposal.ValidateBasic())
+	require.NoError(t, ph(ctx, proposal))
+
+	// then
+	expCoins := sdk.Coins{sdk.NewCoin("gravity0x0bc529c00c6401aef6d220be8c6ea1667f6ad93e", amount)}
+	assert.Equal(t, expCoins, input.BankKeeper.GetAllBalances(ctx, destination))
+	assert.True(t, input.DistKeeper.GetFeePoolCommunityCoins(ctx).IsZero())
+	assert.Nil(t, input.PeggyKeeper.GetReclaimableDeposit(ctx, myNonce))
+
+	// and the deposit can't be returned twice
+	require.Error(t, ph(ctx, proposal))
+}
+
+func TestUpdateBridgeContractProposal(t *testing.T) {
+	input := keeper.CreateTestEnv(t)
+	ctx := input.Context
+	ph := NewProposalHandler(input.PeggyKeeper)
+
+	invalid := types.NewUpdateBridgeContractProposal("title", "description", "0x1", 0, 0, "")
+	require.Error(t, invalid.ValidateBasic())
+
+	// the final event of the old contract wasn't observed yet
+	proposal := types.NewUpdateBridgeContractProposal("title", "description", "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7", 1, 0, "")
+	require.NoError(t, proposal.ValidateBasic())
+	require.Error(t, ph(ctx, proposal))
+
+	proposal.FinalEventNonce = 0
+	require.NoError(t, ph(ctx, proposal))
+	assert.Equal(t, proposal.BridgeContractAddress, input.PeggyKeeper.GetBridgeContractAddress(ctx))
+}
+
+func TestParamChangeProposal(t *testing.T) {
+	input := keeper.CreateTestEnv(t)
+	ctx := input.Context
+	ph := NewParamChangeProposalHandler(input.PeggyKeeper, params.NewParamChangeProposalHandler(input.ParamsKeeper))
+
+	// the peggy params live in the module store, the subspace can't be changed anymore
+	proposal := paramproposal.NewParameterChangeProposal("title", "description", []paramproposal.ParamChange{
+		paramproposal.NewParamChange(types.DefaultParamspace, string(types.ParamsStoreKeyBridgeFeeBasisPoints), `"500"`),
+	})
+	err := ph(ctx, proposal)
+	assert.True(t, types.ErrUnsupported.Is(err), err)
+	assert.Equal(t, keeper.TestingPeggyParams, input.PeggyKeeper.GetParams(ctx))
+}
+
+func TestUpdateParamsProposal(t *testing.T) {
+	const (
+		tokenA = "0x0bc529c00C6401aEF6D220BE8C6Ea1667F6Ad93e"
+		tokenB = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+	)
+	input := keeper.CreateTestEnv(t)
+	ctx := input.Context
+	ph := NewProposalHandler(input.PeggyKeeper)
+
+	// each value passes on its own but the resulting set is invalid
+	p := input.PeggyKeeper.GetParams(ctx)
+	p.TokenAllowlist = []string{tokenA}
+	p.TokenDenylist = []string{tokenA}
+	proposal := types.NewUpdateParamsProposal("title", "description", p)
+	require.Error(t, proposal.ValidateBasic())
+	require.Error(t, ph(ctx, proposal))
+
+	// a consistent change is applied
+	p.TokenAllowlist = []string{tokenB}
+	proposal = types.NewUpdateParamsProposal("title", "description", p)
+	require.NoError(t, proposal.ValidateBasic())
+	require.NoError(t, ph(ctx, proposal))
+	assert.Equal(t, p, input.PeggyKeeper.GetParams(ctx))
+}
+
+func TestCancelBatchProposal(t *testing.T) {
+	var (
+		mySender, _         = sdk.AccAddressFromBech32("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+		myReceiver          = "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"
+		myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+		allVouchers         = sdk.Coins{types.NewERC20Token(1000, myTokenContractAddr).PeggyCoin()}
+		input               = keeper.CreateTestEnv(t)
+		ctx                 = input.Context
+		k                   = input.PeggyKeeper
+		ph                  = NewProposalHandler(k)
+	)
+	require.NoError(t, input.BankKeeper.MintCoins(ctx, types.ModuleName, allVouchers))
+	input.AccountKeeper.NewAccountWithAddress(ctx, mySender)
+	require.NoError(t, input.BankKeeper.SetBalances(ctx, mySender, allVouchers))
+	amount := types.NewERC20Token(100, myTokenContractAddr).PeggyCoin()
+	_, err := k.AddToOutgoingPool(ctx, mySender, myReceiver, amount, amount)
+	require.NoError(t, err)
+	batch, err := k.BuildOutgoingTXBatch(ctx, myTokenContractAddr, 10)
+	require.NoError(t, err)
+
+	invalid := types.NewCancelBatchProposal("title", "description", myTokenContractAddr, 0)
+	require.Error(t, invalid.ValidateBasic())
+
+	proposal := types.NewCancelBatchProposal("title", "description", myTokenContractAddr, batch.BatchNonce)
+	require.NoError(t, proposal.ValidateBasic())
+	require.NoError(t, ph(ctx, proposal))
+	assert.Nil(t, k.GetOutgoingTXBatch(ctx, myTokenContractAddr, batch.BatchNonce))
+	assert.Len(t, k.GetPoolTransactions(ctx), 1)
+}
+
+func TestSetLastObservedEventNonceProposal(t *testing.T) {
+	var (
+		input = keeper.CreateTestEnv(t)
+		ctx   = input.Context
+		k     = input.PeggyKeeper
+		ph    = NewProposalHandler(k)
+	)
+	proposal := types.NewSetLastObservedEventNonceProposal("title", "description", 7)
+	require.NoError(t, proposal.ValidateBasic())
+	require.NoError(t, ph(ctx, proposal))
+	assert.Equal(t, uint64(7), k.GetLastObservedEventNonce(ctx))
+}
+
+func TestPauseTokenProposal(t *testing.T) {
+	var (
+		input         = keeper.CreateTestEnv(t)
+		ctx           = input.Context
+		k             = input.PeggyKeeper
+		ph            = NewProposalHandler(k)
+		tokenContract = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+	)
+	pause := types.NewPauseTokenProposal("title", "description", tokenContract)
+	require.NoError(t, pause.ValidateBasic())
+	require.NoError(t, ph(ctx, pause))
+	assert.True(t, k.IsTokenPaused(ctx, tokenContract))
+	assert.Error(t, ph(ctx, pause))
+
+	unpause := types.NewUnpauseTokenProposal("title", "description", tokenContract)
+	require.NoError(t, unpause.ValidateBasic())
+	require.NoError(t, ph(ctx, unpause))
+	assert.False(t, k.IsTokenPaused(ctx, tokenContract))
+}
@@ -0,0 +1,127 @@
+package reflection
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	gogoproto "github.com/gogo/protobuf/proto"
+	golangproto "github.com/golang/protobuf/proto"
+	descpb "github.com/golang/protobuf/protoc-gen-go/descriptor"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/runtime/protoimpl"
+)
+
+// serviceFiles are the proto files defining the gRPC services of the module
+var serviceFiles = []string{
+	"gravity/v1/query.proto",
+	"gravity/v1/msgs.proto",
+	"gravity/v1/subscription.proto",
+}
+
+// registeredNames maps the import path of the proto files that gogoproto registers under another
+// name to that name
+var registeredNames = map[string]string{
+	"gogoproto/gogo.proto": "gogo.proto",
+}
+
+// fileDescriptor returns the descriptor of the proto file imported as name, from the gogoproto
+// registry or, for the files generated with the golang plugin like the google api annotations, the
+// golang one
+func fileDescriptor(name string) (*descpb.FileDescriptorProto, error) {
+	registered := name
+	if n, ok := registeredNames[name]; ok {
+		registered = n
+	}
+	gz := gogoproto.FileDescriptor(registered)
+	if gz == nil {
+		gz = golangproto.FileDescriptor(registered)
+	}
+	if gz == nil {
+		return nil, sdkerrors.Wrapf(types.ErrUnknown, "proto file %s is not registered", name)
+	}
+	r, err := gzip.NewReader(bytes.NewReader(gz))
+	if err != nil {
+		return nil, sdkerrors.Wrapf(err, "decompress %s", name)
+	}
+	bz, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(err, "decompress %s", name)
+	}
+	var fd descpb.FileDescriptorProto
+	if err := golangproto.Unmarshal(bz, &fd); err != nil {
+		return nil, sdkerrors.Wrapf(err, "unmarshal %s", name)
+	}
+	// the set refers to the files by their import path
+	fd.Name = &name
+	return &fd, nil
+}
+
+// fileDescriptorSet returns the descriptors of the files and all files they import, imports before
+// the files importing them
+func fileDescriptorSet(files []string) (*descpb.FileDescriptorSet, error) {
+	var (
+		set    descpb.FileDescriptorSet
+		byName = make(map[string]*descpb.FileDescriptorProto)
+		add    func(name string) error
+	)
+	add = func(name string) error {
+		if _, ok := byName[name]; ok {
+			return nil
+		}
+		fd, err := fileDescriptor(name)
+		if err != nil {
+			return err
+		}
+		byName[name] = fd
+		for _, dep := range fd.Dependency {
+			if err := add(dep); err != nil {
+				return err
+			}
+		}
+		set.File = append(set.File, fd)
+		return nil
+	}
+	for _, f := range files {
+		if err := add(f); err != nil {
+			return nil, err
+		}
+	}
+	return &set, nil
+}
+
+// RegisterFiles registers the proto files of the module's services and the files they import with the
+// golang protobuf registry, which the gRPC server reflection reads. Files that are registered already
+// are skipped, so it can be called more than once.
+func RegisterFiles() (err error) {
+	set, err := fileDescriptorSet(serviceFiles)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = sdkerrors.Wrapf(types.ErrInvalid, "register proto files: %v", r)
+		}
+	}()
+	// imports come before the files importing them, they are resolved from the registry
+	for _, fd := range set.File {
+		if _, err := protoregistry.GlobalFiles.FindFileByPath(fd.GetName()); err == nil {
+			continue
+		}
+		// the builder below panics on a malformed descriptor instead of failing
+		if _, err := protodesc.NewFile(fd, protoregistry.GlobalFiles); err != nil {
+			return sdkerrors.Wrapf(err, "descriptor of %s", fd.GetName())
+		}
+		raw, err := golangproto.Marshal(fd)
+		if err != nil {
+			return sdkerrors.Wrapf(err, "marshal %s", fd.GetName())
+		}
+		// unlike the files built by protodesc, the ones built from the raw descriptor keep it, which is
+		// what the gRPC server reflection sends
+		protoimpl.DescBuilder{RawDescriptor: raw}.Build()
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+/*
+Package reflection makes the services of the module visible to the gRPC server reflection the SDK
+registers on the gRPC server of the app, so that generic tooling can call the bridge RPCs without client
+code written for them.
+
+The gRPC server reflection only knows the files registered with the golang protobuf registry, which the
+gogoproto generated types of the module aren't. RegisterFiles registers the proto files of the Query, Msg
+and Subscription services and all their imports there, the app calls it when it registers its gRPC
+services. From then on the services are listed and called with
+
+	grpcurl -plaintext localhost:9090 list gravity.v1.Query
+	grpcurl -plaintext localhost:9090 gravity.v1.Query/Params
+
+The interfaces of the app and their implementations, like the type urls of the messages of the module,
+are listed by the cosmos.base.reflection.v1beta1.ReflectionService of the SDK.
+*/
+package reflection
@@ -0,0 +1,33 @@
+package reflection
+
+import (
+	"testing"
+
+	golangproto "github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+func TestRegisterFiles(t *testing.T) {
+	require.NoError(t, RegisterFiles())
+	// registering again skips the registered files
+	require.NoError(t, RegisterFiles())
+
+	// the gRPC server reflection looks the files up by the name in the metadata of a service
+	for _, file := range serviceFiles {
+		assert.NotNil(t, golangproto.FileDescriptor(file), file)
+	}
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName("gravity.v1.Query")
+	require.NoError(t, err)
+	service, ok := desc.(protoreflect.ServiceDescriptor)
+	require.True(t, ok)
+	params := service.Methods().ByName("Params")
+	require.NotNil(t, params)
+	assert.Equal(t, protoreflect.FullName("gravity.v1.QueryParamsResponse"), params.Output().FullName())
+
+	desc, err = protoregistry.GlobalFiles.FindDescriptorByName("gravity.v1.Subscription")
+	require.NoError(t, err)
+	assert.True(t, desc.(protoreflect.ServiceDescriptor).Methods().ByName("SubscribePendingWork").IsStreamingServer())
+}
@@ -0,0 +1,17 @@
+/*
+Package subscription serves the Subscription gRPC service, which pushes the work an orchestrator has
+to sign instead of having it poll the PendingWork query every block.
+
+Server receives the new blocks from the event bus of the node the gRPC server runs in and queries the
+pending work of each subscribed orchestrator once at the height of the block, locally through ABCI, for
+all subscribers of the orchestrator. Only the valsets, batches and logic calls that were not sent to the
+subscriber before are streamed, and only up to the given number of subscribers are served at once. The
+service isn't part of the module's query service since streams can't be routed through ABCI, register
+it on the gRPC server of the app with
+
+	func (app *Peggy) RegisterGRPCServer(clientCtx client.Context, server gogogrpc.Server) {
+		app.BaseApp.RegisterGRPCServer(clientCtx, server)
+		types.RegisterSubscriptionServer(server, subscription.NewServer(clientCtx, subscription.DefaultMaxSubscribers))
+	}
+*/
+package subscription
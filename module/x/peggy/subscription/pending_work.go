@@ -0,0 +1,44 @@
+package subscription
+
+import (
+	"fmt"
+
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// pendingWork remembers the work sent to a subscriber, so that only new work is sent with the next block
+type pendingWork struct {
+	sent map[string]bool
+}
+
+func newPendingWork() *pendingWork {
+	return &pendingWork{sent: map[string]bool{}}
+}
+
+// update returns the work of res that was not sent yet. Work that is no longer pending, because it was
+// confirmed or pruned, is forgotten so the set stays as small as the pending work.
+func (w *pendingWork) update(res *types.QueryPendingWorkResponse) *types.SubscribePendingWorkResponse {
+	out := &types.SubscribePendingWorkResponse{}
+	pending := make(map[string]bool, len(res.Valsets)+len(res.Batches)+len(res.Calls))
+	isNew := func(key string) bool {
+		pending[key] = true
+		return !w.sent[key]
+	}
+	for _, valset := range res.Valsets {
+		if isNew(fmt.Sprintf("valset/%d", valset.Nonce)) {
+			out.Valsets = append(out.Valsets, valset)
+		}
+	}
+	for _, batch := range res.Batches {
+		if isNew(fmt.Sprintf("batch/%s/%d", batch.TokenContract, batch.BatchNonce)) {
+			out.Batches = append(out.Batches, batch)
+		}
+	}
+	for _, call := range res.Calls {
+		if isNew(fmt.Sprintf("call/%X/%d", call.InvalidationId, call.InvalidationNonce)) {
+			out.Calls = append(out.Calls, call)
+		}
+	}
+	w.sent = pending
+	return out
+}
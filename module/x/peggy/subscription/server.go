@@ -0,0 +1,258 @@
+package subscription
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// blockBuffer is the number of new block events buffered while the pending work of a block is queried
+const blockBuffer = 16
+
+// DefaultMaxSubscribers is the number of streams the Subscription service of the app serves at once
+const DefaultMaxSubscribers = 100
+
+var _ types.SubscriptionServer = &Server{}
+
+// subscriptions numbers the event bus subscriptions, which have to be unique per client
+var subscriptions uint64
+
+// Server implements the Subscription service with the client of the node the gRPC server runs in. A single
+// new block subscription serves all subscribers, the pending work of an orchestrator is queried once per
+// block and fanned out to every subscriber of the orchestrator.
+type Server struct {
+	clientCtx      client.Context
+	maxSubscribers int
+
+	mu sync.Mutex
+	// subscribers holds the subscribers by orchestrator
+	subscribers map[string]map[*subscriber]bool
+	count       int
+	// stop ends the new block subscription, it runs while there are subscribers
+	stop context.CancelFunc
+}
+
+// subscriber receives the pending work of its orchestrator at the latest block. A subscriber that is
+// still sending only gets the latest update, the pending work of a block supersedes the earlier ones.
+type subscriber struct {
+	updates chan update
+}
+
+type update struct {
+	res    *types.QueryPendingWorkResponse
+	height int64
+	err    error
+}
+
+// NewServer returns the Subscription service of the node clientCtx queries, serving up to maxSubscribers
+// streams at once
+func NewServer(clientCtx client.Context, maxSubscribers int) *Server {
+	return &Server{
+		clientCtx:      clientCtx,
+		maxSubscribers: maxSubscribers,
+		subscribers:    map[string]map[*subscriber]bool{},
+	}
+}
+
+// SubscribePendingWork streams the work the orchestrator has not confirmed yet until the client
+// goes away
+func (s *Server) SubscribePendingWork(req *types.SubscribePendingWorkRequest, stream types.Subscription_SubscribePendingWorkServer) error {
+	if _, err := sdk.AccAddressFromBech32(req.Orchestrator); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "address invalid")
+	}
+	sub, err := s.subscribe(req.Orchestrator)
+	if err != nil {
+		return err
+	}
+	defer s.unsubscribe(req.Orchestrator, sub)
+
+	// the first response is sent right away, even without pending work, so the orchestrator knows
+	// the height it is in sync with
+	ctx := stream.Context()
+	w := newPendingWork()
+	res, height, err := s.query(ctx, req.Orchestrator, 0)
+	if err != nil {
+		return err
+	}
+	if err := send(stream, w, res, height, true); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case u := <-sub.updates:
+			if u.err != nil {
+				return u.err
+			}
+			if err := send(stream, w, u.res, u.height, false); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// subscribe adds a subscriber of the orchestrator, the first one starts the new block subscription
+func (s *Server) subscribe(orchestrator string) (*subscriber, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.count >= s.maxSubscribers {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "subscriber limit of %d reached", s.maxSubscribers)
+	}
+	if s.stop == nil {
+		events, ok := s.clientCtx.Client.(rpcclient.EventsClient)
+		if !ok {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrNotSupported, "node client has no event subscriptions")
+		}
+		ctx, stop := context.WithCancel(context.Background())
+		name := fmt.Sprintf("%s-pending-work-%d", types.ModuleName, atomic.AddUint64(&subscriptions, 1))
+		blocks, err := events.Subscribe(ctx, name, tmtypes.QueryForEvent(tmtypes.EventNewBlock).String(), blockBuffer)
+		if err != nil {
+			stop()
+			return nil, sdkerrors.Wrap(err, "subscribe to new blocks")
+		}
+		s.stop = stop
+		go s.fanOut(ctx, events, name, blocks)
+	}
+
+	sub := &subscriber{updates: make(chan update, 1)}
+	if s.subscribers[orchestrator] == nil {
+		s.subscribers[orchestrator] = map[*subscriber]bool{}
+	}
+	s.subscribers[orchestrator][sub] = true
+	s.count++
+	return sub, nil
+}
+
+// unsubscribe removes a subscriber of the orchestrator, the last one ends the new block subscription
+func (s *Server) unsubscribe(orchestrator string, sub *subscriber) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.subscribers[orchestrator], sub)
+	if len(s.subscribers[orchestrator]) == 0 {
+		delete(s.subscribers, orchestrator)
+	}
+	s.count--
+	if s.count == 0 && s.stop != nil {
+		s.stop()
+		s.stop = nil
+	}
+}
+
+// fanOut queries the pending work of every subscribed orchestrator once per new block and hands it to
+// the subscribers of the orchestrator until ctx is cancelled
+func (s *Server) fanOut(ctx context.Context, events rpcclient.EventsClient, name string, blocks <-chan ctypes.ResultEvent) {
+	defer events.UnsubscribeAll(context.Background(), name) //nolint:errcheck
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev, ok := <-blocks:
+			if !ok {
+				s.broadcast(ctx, update{err: sdkerrors.Wrap(sdkerrors.ErrLogic, "new block subscription closed")})
+				return
+			}
+			block, ok := ev.Data.(tmtypes.EventDataNewBlock)
+			if !ok || block.Block == nil {
+				continue
+			}
+			for _, orchestrator := range s.orchestrators() {
+				res, height, err := s.query(ctx, orchestrator, block.Block.Height)
+				s.deliver(orchestrator, update{res: res, height: height, err: err})
+			}
+		}
+	}
+}
+
+// orchestrators returns the orchestrators with subscribers
+func (s *Server) orchestrators() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]string, 0, len(s.subscribers))
+	for orchestrator := range s.subscribers {
+		out = append(out, orchestrator)
+	}
+	return out
+}
+
+// deliver hands the update to the subscribers of the orchestrator, replacing an update they didn't
+// take yet
+func (s *Server) deliver(orchestrator string, u update) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for sub := range s.subscribers[orchestrator] {
+		sub.push(u)
+	}
+}
+
+// broadcast hands the final update of the new block subscription of ctx to all subscribers, the next
+// subscriber starts a new one
+func (s *Server) broadcast(ctx context.Context, u update) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// the subscription ended already if its last subscriber left in the meantime
+	if ctx.Err() != nil {
+		return
+	}
+	for _, subs := range s.subscribers {
+		for sub := range subs {
+			sub.push(u)
+		}
+	}
+	s.stop()
+	s.stop = nil
+}
+
+// push never blocks, the server is the only one filling the channel while holding its lock
+func (sub *subscriber) push(u update) {
+	select {
+	case sub.updates <- u:
+	default:
+		select {
+		case <-sub.updates:
+		default:
+		}
+		sub.updates <- u
+	}
+}
+
+// query returns the pending work of the orchestrator at height, zero being the latest height, and the
+// height it was queried at
+func (s *Server) query(ctx context.Context, orchestrator string, height int64) (*types.QueryPendingWorkResponse, int64, error) {
+	var header metadata.MD
+	queryClient := types.NewQueryClient(s.clientCtx.WithHeight(height))
+	res, err := queryClient.PendingWork(ctx, &types.QueryPendingWorkRequest{Orchestrator: orchestrator}, grpc.Header(&header))
+	if err != nil {
+		return nil, 0, sdkerrors.Wrapf(err, "pending work at height %d", height)
+	}
+	if heights := header.Get(grpctypes.GRPCBlockHeightHeader); len(heights) != 0 {
+		if height, err = strconv.ParseInt(heights[0], 10, 64); err != nil {
+			return nil, 0, sdkerrors.Wrap(err, "block height header")
+		}
+	}
+	return res, height, nil
+}
+
+// send sends the work of res that was not sent before. Nothing is sent when there is no new work, unless
+// always is set.
+func send(stream types.Subscription_SubscribePendingWorkServer, w *pendingWork, res *types.QueryPendingWorkResponse, height int64, always bool) error {
+	out := w.update(res)
+	if !always && len(out.Valsets) == 0 && len(out.Batches) == 0 && len(out.Calls) == 0 {
+		return nil
+	}
+	out.Height = height
+	return stream.Send(out)
+}
@@ -0,0 +1,61 @@
+package subscription
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPendingWorkUpdate(t *testing.T) {
+	var (
+		valset1 = &types.Valset{Nonce: 1}
+		valset2 = &types.Valset{Nonce: 2}
+		batch   = &types.OutgoingTxBatch{BatchNonce: 1, TokenContract: "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"}
+		call    = &types.OutgoingLogicCall{InvalidationId: []byte{1}, InvalidationNonce: 1}
+	)
+	w := newPendingWork()
+
+	// everything is new to a fresh subscriber
+	out := w.update(&types.QueryPendingWorkResponse{Valsets: []*types.Valset{valset1}, Batches: []*types.OutgoingTxBatch{batch}})
+	assert.Equal(t, []*types.Valset{valset1}, out.Valsets)
+	assert.Equal(t, []*types.OutgoingTxBatch{batch}, out.Batches)
+	assert.Empty(t, out.Calls)
+
+	// only the work created since is sent
+	out = w.update(&types.QueryPendingWorkResponse{
+		Valsets: []*types.Valset{valset1, valset2},
+		Batches: []*types.OutgoingTxBatch{batch},
+		Calls:   []*types.OutgoingLogicCall{call},
+	})
+	assert.Equal(t, []*types.Valset{valset2}, out.Valsets)
+	assert.Empty(t, out.Batches)
+	assert.Equal(t, []*types.OutgoingLogicCall{call}, out.Calls)
+
+	// nothing new
+	out = w.update(&types.QueryPendingWorkResponse{Valsets: []*types.Valset{valset2}, Calls: []*types.OutgoingLogicCall{call}})
+	assert.Empty(t, out.Valsets)
+	assert.Empty(t, out.Calls)
+	assert.Len(t, w.sent, 2)
+
+	// work that stopped being pending is sent again when it comes back
+	out = w.update(&types.QueryPendingWorkResponse{Valsets: []*types.Valset{valset1}})
+	assert.Equal(t, []*types.Valset{valset1}, out.Valsets)
+}
+
+func TestSubscriberLimit(t *testing.T) {
+	s := NewServer(client.Context{}, 0)
+	_, err := s.subscribe("cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn")
+	assert.True(t, sdkerrors.ErrInvalidRequest.Is(err), err)
+}
+
+func TestSubscriberPush(t *testing.T) {
+	sub := &subscriber{updates: make(chan update, 1)}
+	sub.push(update{height: 1})
+	// a subscriber that is still sending only gets the latest update
+	sub.push(update{height: 2})
+	assert.Equal(t, int64(2), (<-sub.updates).height)
+	assert.Empty(t, sub.updates)
+}
@@ -1,5 +1,5 @@
 // Code generated by protoc-gen-gogo. DO NOT EDIT.
-// source: peggy/v1/attestation.proto
+// source: gravity/v1/attestation.proto
 
 package types
 
@@ -35,6 +35,8 @@ const (
 	CLAIM_TYPE_WITHDRAW            ClaimType = 2
 	CLAIM_TYPE_ERC20_DEPLOYED      ClaimType = 3
 	CLAIM_TYPE_LOGIC_CALL_EXECUTED ClaimType = 4
+	CLAIM_TYPE_GENERIC_EVENT       ClaimType = 5
+	CLAIM_TYPE_VALSET_UPDATED      ClaimType = 6
 )
 
 var ClaimType_name = map[int32]string{
@@ -43,6 +45,8 @@ var ClaimType_name = map[int32]string{
 	2: "CLAIM_TYPE_WITHDRAW",
 	3: "CLAIM_TYPE_ERC20_DEPLOYED",
 	4: "CLAIM_TYPE_LOGIC_CALL_EXECUTED",
+	5: "CLAIM_TYPE_GENERIC_EVENT",
+	6: "CLAIM_TYPE_VALSET_UPDATED",
 }
 
 var ClaimType_value = map[string]int32{
@@ -51,6 +55,8 @@ var ClaimType_value = map[string]int32{
 	"CLAIM_TYPE_WITHDRAW":            2,
 	"CLAIM_TYPE_ERC20_DEPLOYED":      3,
 	"CLAIM_TYPE_LOGIC_CALL_EXECUTED": 4,
+	"CLAIM_TYPE_GENERIC_EVENT":       5,
+	"CLAIM_TYPE_VALSET_UPDATED":      6,
 }
 
 func (x ClaimType) String() string {
@@ -58,7 +64,7 @@ func (x ClaimType) String() string {
 }
 
 func (ClaimType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_20f100b984cd48a5, []int{0}
+	return fileDescriptor_e3205613bbab7525, []int{0}
 }
 
 // Attestation is an aggregate of `claims` that eventually becomes `observed` by
@@ -87,7 +93,7 @@ func (m *Attestation) Reset()         { *m = Attestation{} }
 func (m *Attestation) String() string { return proto.CompactTextString(m) }
 func (*Attestation) ProtoMessage()    {}
 func (*Attestation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_20f100b984cd48a5, []int{0}
+	return fileDescriptor_e3205613bbab7525, []int{0}
 }
 func (m *Attestation) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -158,7 +164,7 @@ func (m *ERC20Token) Reset()         { *m = ERC20Token{} }
 func (m *ERC20Token) String() string { return proto.CompactTextString(m) }
 func (*ERC20Token) ProtoMessage()    {}
 func (*ERC20Token) Descriptor() ([]byte, []int) {
-	return fileDescriptor_20f100b984cd48a5, []int{1}
+	return fileDescriptor_e3205613bbab7525, []int{1}
 }
 func (m *ERC20Token) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -195,44 +201,45 @@ func (m *ERC20Token) GetContract() string {
 }
 
 func init() {
-	proto.RegisterEnum("peggy.v1.ClaimType", ClaimType_name, ClaimType_value)
-	proto.RegisterType((*Attestation)(nil), "peggy.v1.Attestation")
-	proto.RegisterType((*ERC20Token)(nil), "peggy.v1.ERC20Token")
-}
-
-func init() { proto.RegisterFile("peggy/v1/attestation.proto", fileDescriptor_20f100b984cd48a5) }
-
-var fileDescriptor_20f100b984cd48a5 = []byte{
-	// 449 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x92, 0xc1, 0x6a, 0xdb, 0x30,
-	0x18, 0xc7, 0xad, 0x24, 0x0d, 0x89, 0x7a, 0x31, 0x5a, 0xc8, 0x5c, 0xc3, 0xdc, 0x90, 0xc3, 0x08,
-	0x85, 0x5a, 0x6b, 0xc7, 0x1e, 0xc0, 0xb5, 0xd5, 0xcd, 0xe0, 0x2d, 0xc1, 0x75, 0xe9, 0xba, 0x4b,
-	0xb0, 0x1d, 0x4d, 0x31, 0x8d, 0x2d, 0x63, 0x2b, 0x66, 0x3e, 0xef, 0xb2, 0xe3, 0x5e, 0x61, 0xec,
-	0x65, 0x7a, 0xec, 0x71, 0xec, 0x50, 0x46, 0xf2, 0x22, 0x23, 0x76, 0x96, 0x05, 0x7a, 0x92, 0xfe,
-	0xdf, 0x4f, 0x9f, 0xf4, 0xff, 0xfe, 0x08, 0xaa, 0x29, 0x65, 0xac, 0xc4, 0xc5, 0x19, 0xf6, 0x85,
-	0xa0, 0xb9, 0xf0, 0x45, 0xc4, 0x13, 0x3d, 0xcd, 0xb8, 0xe0, 0xa8, 0x53, 0x31, 0xbd, 0x38, 0x53,
-	0x7b, 0x8c, 0x33, 0x5e, 0x15, 0xf1, 0x66, 0x57, 0x73, 0xf5, 0x88, 0x71, 0xce, 0x16, 0x14, 0x57,
-	0x2a, 0x58, 0x7e, 0xc6, 0x7e, 0x52, 0xd6, 0x68, 0xf8, 0x15, 0xc0, 0x43, 0xe3, 0xff, 0x85, 0x48,
-	0x85, 0x1d, 0x1e, 0xe4, 0x34, 0x2b, 0xe8, 0x4c, 0x01, 0x03, 0x30, 0xea, 0xb8, 0x3b, 0x8d, 0x7a,
-	0xf0, 0xa0, 0xe0, 0x82, 0xe6, 0x4a, 0x63, 0xd0, 0x1c, 0x75, 0xdd, 0x5a, 0xa0, 0x3e, 0x6c, 0xcf,
-	0x69, 0xc4, 0xe6, 0x42, 0x69, 0x0e, 0xc0, 0xa8, 0xe5, 0x6e, 0x15, 0x3a, 0x81, 0x07, 0xe1, 0xc2,
-	0x8f, 0x62, 0xa5, 0x35, 0x00, 0xa3, 0xc3, 0xf3, 0x9e, 0x5e, 0x9b, 0xd0, 0xff, 0x99, 0xd0, 0x8d,
-	0xa4, 0x74, 0xeb, 0x23, 0xc3, 0x14, 0x42, 0xe2, 0x9a, 0xe7, 0xaf, 0x3c, 0x7e, 0x47, 0x2b, 0x0f,
-	0x21, 0x4f, 0x44, 0xe6, 0x87, 0xa2, 0xf2, 0xd0, 0x75, 0x77, 0x1a, 0x5d, 0xc2, 0xb6, 0x1f, 0xf3,
-	0x65, 0x22, 0x94, 0xc6, 0x86, 0x5c, 0xe8, 0xf7, 0x8f, 0xc7, 0xd2, 0xef, 0xc7, 0xe3, 0x97, 0x2c,
-	0x12, 0xf3, 0x65, 0xa0, 0x87, 0x3c, 0xc6, 0x21, 0xcf, 0x63, 0x9e, 0x6f, 0x97, 0xd3, 0x7c, 0x76,
-	0x87, 0x45, 0x99, 0xd2, 0x5c, 0xb7, 0x13, 0xe1, 0x6e, 0xbb, 0x4f, 0x7e, 0x00, 0xd8, 0x35, 0x37,
-	0x6f, 0x7b, 0x65, 0x4a, 0x91, 0x0a, 0xfb, 0xa6, 0x63, 0xd8, 0xef, 0xa7, 0xde, 0xed, 0x84, 0x4c,
-	0xaf, 0x3f, 0x5c, 0x4d, 0x88, 0x69, 0x5f, 0xda, 0xc4, 0x92, 0x25, 0xd4, 0x87, 0x68, 0x8f, 0x59,
-	0x64, 0x32, 0xbe, 0xb2, 0x3d, 0x19, 0xa0, 0xe7, 0xf0, 0xd9, 0x5e, 0xfd, 0xc6, 0xf6, 0xde, 0x59,
-	0xae, 0x71, 0x23, 0x37, 0xd0, 0x0b, 0x78, 0xb4, 0x07, 0xaa, 0xb9, 0x36, 0x6d, 0xce, 0xf8, 0x96,
-	0x58, 0x72, 0x13, 0x0d, 0xa1, 0xb6, 0x87, 0x9d, 0xf1, 0x5b, 0xdb, 0x9c, 0x9a, 0x86, 0xe3, 0x4c,
-	0xc9, 0x47, 0x62, 0x5e, 0x7b, 0xc4, 0x92, 0x5b, 0x6a, 0xeb, 0xdb, 0x4f, 0x4d, 0xba, 0x18, 0xdf,
-	0xaf, 0x34, 0xf0, 0xb0, 0xd2, 0xc0, 0x9f, 0x95, 0x06, 0xbe, 0xaf, 0x35, 0xe9, 0x61, 0xad, 0x49,
-	0xbf, 0xd6, 0x9a, 0xf4, 0xe9, 0xcd, 0xd3, 0x69, 0x59, 0xe6, 0x17, 0x91, 0x28, 0x4f, 0x83, 0x2c,
-	0x9a, 0x31, 0x8a, 0x63, 0x3e, 0x5b, 0x2e, 0x28, 0xfe, 0x82, 0xeb, 0x6f, 0x53, 0x05, 0x10, 0xb4,
-	0xab, 0xec, 0x5f, 0xff, 0x0d, 0x00, 0x00, 0xff, 0xff, 0xbb, 0x1e, 0x44, 0x0e, 0x4c, 0x02, 0x00,
-	0x00,
+	proto.RegisterEnum("gravity.v1.ClaimType", ClaimType_name, ClaimType_value)
+	proto.RegisterType((*Attestation)(nil), "gravity.v1.Attestation")
+	proto.RegisterType((*ERC20Token)(nil), "gravity.v1.ERC20Token")
+}
+
+func init() { proto.RegisterFile("gravity/v1/attestation.proto", fileDescriptor_e3205613bbab7525) }
+
+var fileDescriptor_e3205613bbab7525 = []byte{
+	// 478 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x92, 0x4d, 0x6f, 0xda, 0x30,
+	0x18, 0xc7, 0x63, 0xde, 0x54, 0xdc, 0x4b, 0xe4, 0x21, 0x96, 0xa2, 0x2e, 0x45, 0x1c, 0x26, 0x54,
+	0xa9, 0xf1, 0xda, 0x69, 0x1f, 0x20, 0x4d, 0xdc, 0x2e, 0x52, 0x06, 0x28, 0x84, 0x76, 0xdd, 0x25,
+	0x0a, 0xe0, 0x99, 0xa8, 0x10, 0x23, 0x62, 0xd0, 0x72, 0xde, 0x65, 0xc7, 0x7d, 0x87, 0x7d, 0x99,
+	0x1e, 0x7b, 0x9c, 0x76, 0x40, 0x13, 0x7c, 0x91, 0x29, 0x2f, 0xeb, 0xa2, 0xf5, 0x64, 0xff, 0x9f,
+	0xdf, 0xf3, 0xf8, 0xf9, 0xfb, 0xb1, 0xe1, 0x31, 0x5b, 0xf9, 0x9b, 0x40, 0xc4, 0x78, 0x73, 0x8e,
+	0x7d, 0x21, 0x68, 0x24, 0x7c, 0x11, 0xf0, 0x50, 0x5b, 0xae, 0xb8, 0xe0, 0x08, 0xe6, 0x54, 0xdb,
+	0x9c, 0xb7, 0x1a, 0x8c, 0x33, 0x9e, 0x86, 0x71, 0xb2, 0xcb, 0x32, 0x5a, 0x47, 0x8c, 0x73, 0x36,
+	0xa7, 0x38, 0x55, 0xe3, 0xf5, 0x67, 0xec, 0x87, 0x71, 0x86, 0x3a, 0x5f, 0x01, 0x3c, 0xd4, 0xff,
+	0x1d, 0x89, 0x5a, 0xf0, 0x80, 0x8f, 0x23, 0xba, 0xda, 0xd0, 0xa9, 0x02, 0xda, 0xa0, 0x7b, 0xe0,
+	0x3c, 0x69, 0xd4, 0x80, 0xd5, 0x0d, 0x17, 0x34, 0x52, 0x4a, 0xed, 0x72, 0xb7, 0xee, 0x64, 0x02,
+	0x35, 0x61, 0x6d, 0x46, 0x03, 0x36, 0x13, 0x4a, 0xb9, 0x0d, 0xba, 0x15, 0x27, 0x57, 0xe8, 0x14,
+	0x56, 0x27, 0x73, 0x3f, 0x58, 0x28, 0x95, 0x36, 0xe8, 0x1e, 0x5e, 0x34, 0xb4, 0xcc, 0x84, 0xf6,
+	0xd7, 0x84, 0xa6, 0x87, 0xb1, 0x93, 0xa5, 0x74, 0x96, 0x10, 0x12, 0xc7, 0xb8, 0x78, 0xe3, 0xf2,
+	0x7b, 0x9a, 0x7a, 0x98, 0xf0, 0x50, 0xac, 0xfc, 0x89, 0x48, 0x3d, 0xd4, 0x9d, 0x27, 0x8d, 0xae,
+	0x60, 0xcd, 0x5f, 0xf0, 0x75, 0x28, 0x94, 0x52, 0x42, 0x2e, 0xb5, 0x87, 0xed, 0x89, 0xf4, 0x6b,
+	0x7b, 0xf2, 0x9a, 0x05, 0x62, 0xb6, 0x1e, 0x6b, 0x13, 0xbe, 0xc0, 0x13, 0x1e, 0x2d, 0x78, 0x94,
+	0x2f, 0x67, 0xd1, 0xf4, 0x1e, 0x8b, 0x78, 0x49, 0x23, 0xcd, 0x0a, 0x85, 0x93, 0x57, 0x9f, 0x6e,
+	0x01, 0xac, 0x1b, 0x49, 0x6f, 0x37, 0x5e, 0x52, 0xd4, 0x82, 0x4d, 0xc3, 0xd6, 0xad, 0x0f, 0x9e,
+	0x7b, 0x37, 0x20, 0xde, 0xa8, 0x37, 0x1c, 0x10, 0xc3, 0xba, 0xb2, 0x88, 0x29, 0x4b, 0xa8, 0x09,
+	0x51, 0x81, 0x99, 0x64, 0xd0, 0x1f, 0x5a, 0xae, 0x0c, 0xd0, 0x4b, 0xf8, 0xa2, 0x10, 0xbf, 0xb5,
+	0xdc, 0xf7, 0xa6, 0xa3, 0xdf, 0xca, 0x25, 0xf4, 0x0a, 0x1e, 0x15, 0x40, 0x7a, 0xaf, 0xa4, 0xcc,
+	0xee, 0xdf, 0x11, 0x53, 0x2e, 0xa3, 0x0e, 0x54, 0x0b, 0xd8, 0xee, 0x5f, 0x5b, 0x86, 0x67, 0xe8,
+	0xb6, 0xed, 0x91, 0x8f, 0xc4, 0x18, 0xb9, 0xc4, 0x94, 0x2b, 0xe8, 0x18, 0x2a, 0x85, 0x9c, 0x6b,
+	0xd2, 0x23, 0x8e, 0x65, 0x78, 0xe4, 0x86, 0xf4, 0x5c, 0xb9, 0xfa, 0x5f, 0x83, 0x1b, 0xdd, 0x1e,
+	0x12, 0xd7, 0x1b, 0x0d, 0x4c, 0x3d, 0x29, 0xae, 0xb5, 0x2a, 0xdf, 0x7e, 0xa8, 0xd2, 0x65, 0xff,
+	0x61, 0xa7, 0x82, 0xc7, 0x9d, 0x0a, 0x7e, 0xef, 0x54, 0xf0, 0x7d, 0xaf, 0x4a, 0x8f, 0x7b, 0x55,
+	0xfa, 0xb9, 0x57, 0xa5, 0x4f, 0xef, 0x9e, 0x8f, 0x2a, 0xff, 0x41, 0x67, 0xe3, 0x55, 0x30, 0x65,
+	0x14, 0x2f, 0xf8, 0x74, 0x3d, 0xa7, 0xf8, 0x0b, 0x5e, 0x52, 0xc6, 0xe2, 0x6c, 0x7a, 0xe3, 0x5a,
+	0xfa, 0x70, 0x6f, 0xff, 0x0c, 0x00, 0xeb, 0xec, 0x90, 0x65, 0x8d, 0x02, 0x00, 0x00,
 }
 
 func (m *Attestation) Marshal() (dAtA []byte, err error) {
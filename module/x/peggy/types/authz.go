@@ -0,0 +1,166 @@
+package types
+
+import (
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/gogo/protobuf/proto"
+)
+
+// Authorization is a grant to execute messages on behalf of the granter, the
+// interface matches the one of the SDK authz module
+type Authorization interface {
+	proto.Message
+
+	// MsgTypeURL returns the type url of the message the authorization covers
+	MsgTypeURL() string
+
+	// Accept decides whether the message may be executed under the authorization
+	// and returns the authorization that remains afterwards
+	Accept(ctx sdk.Context, msg sdk.Msg) (AcceptResponse, error)
+
+	// ValidateBasic performs stateless checks
+	ValidateBasic() error
+}
+
+// AcceptResponse is the outcome of Authorization.Accept. If the message is
+// accepted the grant is removed when Delete is set, otherwise a non nil Updated
+// authorization replaces the stored one.
+type AcceptResponse struct {
+	Accept  bool
+	Delete  bool
+	Updated Authorization
+}
+
+// MsgTypeURL returns the type url of a message as used by grants
+func MsgTypeURL(msg proto.Message) string {
+	return "/" + proto.MessageName(msg)
+}
+
+var (
+	_ Authorization = &GenericAuthorization{}
+	_ Authorization = &SendToEthAuthorization{}
+
+	_ types.UnpackInterfacesMessage = &Grant{}
+	_ types.UnpackInterfacesMessage = &GrantAuthorization{}
+)
+
+// NewGenericAuthorization returns an authorization for any message of the type url
+func NewGenericAuthorization(msgTypeURL string) *GenericAuthorization {
+	return &GenericAuthorization{Msg: msgTypeURL}
+}
+
+// MsgTypeURL implements Authorization
+func (a *GenericAuthorization) MsgTypeURL() string { return a.Msg }
+
+// Accept implements Authorization, every message of the type is accepted
+func (a *GenericAuthorization) Accept(_ sdk.Context, _ sdk.Msg) (AcceptResponse, error) {
+	return AcceptResponse{Accept: true}, nil
+}
+
+// ValidateBasic performs stateless checks
+func (a *GenericAuthorization) ValidateBasic() error {
+	if !strings.HasPrefix(a.Msg, TypeURLPrefix) {
+		return sdkerrors.Wrapf(ErrInvalid, "only messages of the bridge can be granted, not %s", a.Msg)
+	}
+	return nil
+}
+
+// NewSendToEthAuthorization returns an authorization to send up to the spend limit to
+// Ethereum, to any address if no destinations are given
+func NewSendToEthAuthorization(spendLimit sdk.Coins, allowedDestinations []string) *SendToEthAuthorization {
+	return &SendToEthAuthorization{SpendLimit: spendLimit, AllowedDestinations: allowedDestinations}
+}
+
+// MsgTypeURL implements Authorization
+func (a *SendToEthAuthorization) MsgTypeURL() string { return MsgTypeURL(&MsgSendToEth{}) }
+
+// Accept implements Authorization. The amount and the fee of the transfer are taken from the
+// spend limit, the authorization is deleted once the limit is spent.
+func (a *SendToEthAuthorization) Accept(_ sdk.Context, msg sdk.Msg) (AcceptResponse, error) {
+	send, ok := msg.(*MsgSendToEth)
+	if !ok {
+		return AcceptResponse{}, sdkerrors.Wrapf(sdkerrors.ErrInvalidType, "expected %s", a.MsgTypeURL())
+	}
+	if len(a.AllowedDestinations) > 0 && !a.isAllowedDestination(send.EthDest) {
+		return AcceptResponse{}, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "destination %s is not allowed", send.EthDest)
+	}
+	limit, negative := a.SpendLimit.SafeSub(sdk.Coins{send.Amount}.Add(send.BridgeFee))
+	if negative {
+		return AcceptResponse{}, sdkerrors.Wrapf(sdkerrors.ErrInsufficientFunds, "spend limit %s", a.SpendLimit)
+	}
+	if limit.IsZero() {
+		return AcceptResponse{Accept: true, Delete: true}, nil
+	}
+	return AcceptResponse{Accept: true, Updated: NewSendToEthAuthorization(limit, a.AllowedDestinations)}, nil
+}
+
+func (a *SendToEthAuthorization) isAllowedDestination(dest string) bool {
+	for _, allowed := range a.AllowedDestinations {
+		if strings.EqualFold(allowed, dest) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateBasic performs stateless checks
+func (a *SendToEthAuthorization) ValidateBasic() error {
+	if !a.SpendLimit.IsValid() || a.SpendLimit.Empty() {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "spend limit %s", a.SpendLimit)
+	}
+	for _, dest := range a.AllowedDestinations {
+		if err := ValidateEthAddress(dest); err != nil {
+			return sdkerrors.Wrap(err, "allowed destination")
+		}
+	}
+	return nil
+}
+
+// NewGrant returns a grant of the authorization, an expiration time of zero never expires
+func NewGrant(authorization Authorization, expirationTime uint64) (Grant, error) {
+	any, err := types.NewAnyWithValue(authorization)
+	if err != nil {
+		return Grant{}, err
+	}
+	return Grant{Authorization: any, ExpirationTime: expirationTime}, nil
+}
+
+// GetAuthorizationValue returns the authorization of the grant, nil if it wasn't unpacked
+func (g Grant) GetAuthorizationValue() Authorization {
+	if g.Authorization == nil {
+		return nil
+	}
+	a, ok := g.Authorization.GetCachedValue().(Authorization)
+	if !ok {
+		return nil
+	}
+	return a
+}
+
+// IsExpired returns true if the grant can't be used at the block time anymore
+func (g Grant) IsExpired(blockTime int64) bool {
+	return g.ExpirationTime != 0 && uint64(blockTime) >= g.ExpirationTime
+}
+
+// ValidateBasic performs stateless checks
+func (g Grant) ValidateBasic() error {
+	a := g.GetAuthorizationValue()
+	if a == nil {
+		return sdkerrors.Wrap(ErrInvalid, "missing authorization")
+	}
+	return a.ValidateBasic()
+}
+
+// UnpackInterfaces implements UnpackInterfacesMessage
+func (g Grant) UnpackInterfaces(unpacker types.AnyUnpacker) error {
+	var a Authorization
+	return unpacker.UnpackAny(g.Authorization, &a)
+}
+
+// UnpackInterfaces implements UnpackInterfacesMessage
+func (g GrantAuthorization) UnpackInterfaces(unpacker types.AnyUnpacker) error {
+	return g.Grant.UnpackInterfaces(unpacker)
+}
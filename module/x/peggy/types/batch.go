@@ -1,135 +1,50 @@
 package types
 
 import (
-	"math/big"
-	"strings"
+	"time"
 
-	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
-	"github.com/ethereum/go-ethereum/accounts/abi"
-	gethcommon "github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
-// GetCheckpoint gets the checkpoint signature from the given outgoing tx batch
-func (b OutgoingTxBatch) GetCheckpoint(peggyIDstring string) ([]byte, error) {
-
-	abi, err := abi.JSON(strings.NewReader(OutgoingBatchTxCheckpointABIJSON))
-	if err != nil {
-		return nil, sdkerrors.Wrap(err, "bad ABI definition in code")
-	}
+// BridgePowerThreshold is the power threshold the bridge contract is deployed with, 66% of the
+// normalized valset power of 2^32. The contract accepts a checkpoint once the powers of its signers
+// exceed it.
+const BridgePowerThreshold uint64 = 2834678415
 
-	// the contract argument is not a arbitrary length array but a fixed length 32 byte
-	// array, therefore we have to utf8 encode the string (the default in this case) and
-	// then copy the variable length encoded data into a fixed length array. This function
-	// will panic if peggyId is too long to fit in 32 bytes
-	peggyID, err := strToFixByteArray(peggyIDstring)
-	if err != nil {
-		panic(err)
+// IsExpired returns true once the transfer reached its expiration height or time,
+// a transfer without expiration never expires
+func (tx OutgoingTransferTx) IsExpired(height int64, blockTime time.Time) bool {
+	if tx.ExpirationHeight != 0 && uint64(height) >= tx.ExpirationHeight {
+		return true
 	}
-
-	// Create the methodName argument which salts the signature
-	methodNameBytes := []uint8("transactionBatch")
-	var batchMethodName [32]uint8
-	copy(batchMethodName[:], methodNameBytes[:])
-
-	// Run through the elements of the batch and serialize them
-	txAmounts := make([]*big.Int, len(b.Transactions))
-	txDestinations := make([]gethcommon.Address, len(b.Transactions))
-	txFees := make([]*big.Int, len(b.Transactions))
-	for i, tx := range b.Transactions {
-		txAmounts[i] = tx.Erc20Token.Amount.BigInt()
-		txDestinations[i] = gethcommon.HexToAddress(tx.DestAddress)
-		txFees[i] = tx.Erc20Fee.Amount.BigInt()
-	}
-
-	// the methodName needs to be the same as the 'name' above in the checkpointAbiJson
-	// but other than that it's a constant that has no impact on the output. This is because
-	// it gets encoded as a function name which we must then discard.
-	abiEncodedBatch, err := abi.Pack("submitBatch",
-		peggyID,
-		batchMethodName,
-		txAmounts,
-		txDestinations,
-		txFees,
-		big.NewInt(int64(b.BatchNonce)),
-		gethcommon.HexToAddress(b.TokenContract),
-		big.NewInt(int64(b.BatchTimeout)),
-	)
-
-	// this should never happen outside of test since any case that could crash on encoding
-	// should be filtered above.
-	if err != nil {
-		return nil, sdkerrors.Wrap(err, "packing checkpoint")
-	}
-
-	// we hash the resulting encoded bytes discarding the first 4 bytes these 4 bytes are the constant
-	// method name 'checkpoint'. If you where to replace the checkpoint constant in this code you would
-	// then need to adjust how many bytes you truncate off the front to get the output of abi.encode()
-	return crypto.Keccak256Hash(abiEncodedBatch[4:]).Bytes(), nil
+	return tx.ExpirationTime != 0 && uint64(blockTime.Unix()) >= tx.ExpirationTime
 }
 
 // GetCheckpoint gets the checkpoint signature from the given outgoing tx batch
-func (c OutgoingLogicCall) GetCheckpoint(peggyIDstring string) ([]byte, error) {
-
-	abi, err := abi.JSON(strings.NewReader(OutgoingLogicCallABIJSON))
-	if err != nil {
-		return nil, sdkerrors.Wrap(err, "bad ABI definition in code")
-	}
-
-	// Create the methodName argument which salts the signature
-	methodNameBytes := []uint8("logicCall")
-	var logicCallMethodName [32]uint8
-	copy(logicCallMethodName[:], methodNameBytes[:])
-
-	// the contract argument is not a arbitrary length array but a fixed length 32 byte
-	// array, therefore we have to utf8 encode the string (the default in this case) and
-	// then copy the variable length encoded data into a fixed length array. This function
-	// will panic if peggyId is too long to fit in 32 bytes
-	peggyID, err := strToFixByteArray(peggyIDstring)
-	if err != nil {
-		panic(err)
-	}
+func (b OutgoingTxBatch) GetCheckpoint(gravityIDstring string) ([]byte, error) {
+	args := b.Args()
+	return BatchCheckpoint(gravityIDstring, args.TokenContract, args.BatchNonce, args.BatchTimeout, args.Amounts, args.Destinations, args.Fees)
+}
 
-	// Run through the elements of the logic call and serialize them
-	transferAmounts := make([]*big.Int, len(c.Transfers))
-	transferTokenContracts := make([]gethcommon.Address, len(c.Transfers))
-	feeAmounts := make([]*big.Int, len(c.Fees))
-	feeTokenContracts := make([]gethcommon.Address, len(c.Fees))
-	for i, tx := range c.Transfers {
-		transferAmounts[i] = tx.Amount.BigInt()
-		transferTokenContracts[i] = gethcommon.HexToAddress(tx.Contract)
+// Args returns the batch as the submitBatch function of the bridge contract takes it
+func (b OutgoingTxBatch) Args() BatchArgs {
+	args := BatchArgs{
+		Amounts:       make([]sdk.Int, len(b.Transactions)),
+		Destinations:  make([]string, len(b.Transactions)),
+		Fees:          make([]sdk.Int, len(b.Transactions)),
+		BatchNonce:    b.BatchNonce,
+		TokenContract: b.TokenContract,
+		BatchTimeout:  b.BatchTimeout,
 	}
-	for i, tx := range c.Fees {
-		feeAmounts[i] = tx.Amount.BigInt()
-		feeTokenContracts[i] = gethcommon.HexToAddress(tx.Contract)
-	}
-	payload := make([]byte, len(c.Payload))
-	copy(payload, c.Payload)
-	var invalidationId [32]byte
-	copy(invalidationId[:], c.InvalidationId[:])
-
-	// the methodName needs to be the same as the 'name' above in the checkpointAbiJson
-	// but other than that it's a constant that has no impact on the output. This is because
-	// it gets encoded as a function name which we must then discard.
-	abiEncodedCall, err := abi.Pack("checkpoint",
-		peggyID,
-		logicCallMethodName,
-		transferAmounts,
-		transferTokenContracts,
-		feeAmounts,
-		feeTokenContracts,
-		gethcommon.HexToAddress(c.LogicContractAddress),
-		payload,
-		big.NewInt(int64(c.Timeout)),
-		invalidationId,
-		big.NewInt(int64(c.InvalidationNonce)),
-	)
-
-	// this should never happen outside of test since any case that could crash on encoding
-	// should be filtered above.
-	if err != nil {
-		return nil, sdkerrors.Wrap(err, "packing checkpoint")
+	for i, tx := range b.Transactions {
+		args.Amounts[i] = tx.Erc20Token.Amount
+		args.Destinations[i] = tx.DestAddress
+		args.Fees[i] = tx.Erc20Fee.Amount
 	}
+	return args
+}
 
-	return crypto.Keccak256Hash(abiEncodedCall[4:]).Bytes(), nil
+// GetCheckpoint gets the checkpoint signature from the given outgoing logic call
+func (c OutgoingLogicCall) GetCheckpoint(gravityIDstring string) ([]byte, error) {
+	return LogicCallCheckpoint(gravityIDstring, c.Transfers, c.Fees, c.LogicContractAddress, c.Payload, c.Timeout, c.InvalidationId, c.InvalidationNonce)
 }
@@ -1,10 +1,11 @@
 // Code generated by protoc-gen-gogo. DO NOT EDIT.
-// source: peggy/v1/batch.proto
+// source: gravity/v1/batch.proto
 
 package types
 
 import (
 	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
 	io "io"
 	math "math"
@@ -29,13 +30,16 @@ type OutgoingTxBatch struct {
 	Transactions  []*OutgoingTransferTx `protobuf:"bytes,3,rep,name=transactions,proto3" json:"transactions,omitempty"`
 	TokenContract string                `protobuf:"bytes,4,opt,name=token_contract,json=tokenContract,proto3" json:"token_contract,omitempty"`
 	Block         uint64                `protobuf:"varint,5,opt,name=block,proto3" json:"block,omitempty"`
+	// the account that requested the batch with MsgRequestBatch, empty for batches
+	// built by the module
+	Requester string `protobuf:"bytes,6,opt,name=requester,proto3" json:"requester,omitempty"`
 }
 
 func (m *OutgoingTxBatch) Reset()         { *m = OutgoingTxBatch{} }
 func (m *OutgoingTxBatch) String() string { return proto.CompactTextString(m) }
 func (*OutgoingTxBatch) ProtoMessage()    {}
 func (*OutgoingTxBatch) Descriptor() ([]byte, []int) {
-	return fileDescriptor_398e85e0d69cec73, []int{0}
+	return fileDescriptor_4453b445b0660cab, []int{0}
 }
 func (m *OutgoingTxBatch) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -99,20 +103,30 @@ func (m *OutgoingTxBatch) GetBlock() uint64 {
 	return 0
 }
 
-// OutgoingTransferTx represents an individual send from Peggy to ETH
+func (m *OutgoingTxBatch) GetRequester() string {
+	if m != nil {
+		return m.Requester
+	}
+	return ""
+}
+
+// OutgoingTransferTx represents an individual send from Peggy to ETH,
+// expiration_height and expiration_time are copied from the MsgSendToEth
 type OutgoingTransferTx struct {
-	Id          uint64      `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Sender      string      `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
-	DestAddress string      `protobuf:"bytes,3,opt,name=dest_address,json=destAddress,proto3" json:"dest_address,omitempty"`
-	Erc20Token  *ERC20Token `protobuf:"bytes,4,opt,name=erc20_token,json=erc20Token,proto3" json:"erc20_token,omitempty"`
-	Erc20Fee    *ERC20Token `protobuf:"bytes,5,opt,name=erc20_fee,json=erc20Fee,proto3" json:"erc20_fee,omitempty"`
+	Id               uint64      `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Sender           string      `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
+	DestAddress      string      `protobuf:"bytes,3,opt,name=dest_address,json=destAddress,proto3" json:"dest_address,omitempty"`
+	Erc20Token       *ERC20Token `protobuf:"bytes,4,opt,name=erc20_token,json=erc20Token,proto3" json:"erc20_token,omitempty"`
+	Erc20Fee         *ERC20Token `protobuf:"bytes,5,opt,name=erc20_fee,json=erc20Fee,proto3" json:"erc20_fee,omitempty"`
+	ExpirationHeight uint64      `protobuf:"varint,6,opt,name=expiration_height,json=expirationHeight,proto3" json:"expiration_height,omitempty"`
+	ExpirationTime   uint64      `protobuf:"varint,7,opt,name=expiration_time,json=expirationTime,proto3" json:"expiration_time,omitempty"`
 }
 
 func (m *OutgoingTransferTx) Reset()         { *m = OutgoingTransferTx{} }
 func (m *OutgoingTransferTx) String() string { return proto.CompactTextString(m) }
 func (*OutgoingTransferTx) ProtoMessage()    {}
 func (*OutgoingTransferTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_398e85e0d69cec73, []int{1}
+	return fileDescriptor_4453b445b0660cab, []int{1}
 }
 func (m *OutgoingTransferTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -176,6 +190,84 @@ func (m *OutgoingTransferTx) GetErc20Fee() *ERC20Token {
 	return nil
 }
 
+func (m *OutgoingTransferTx) GetExpirationHeight() uint64 {
+	if m != nil {
+		return m.ExpirationHeight
+	}
+	return 0
+}
+
+func (m *OutgoingTransferTx) GetExpirationTime() uint64 {
+	if m != nil {
+		return m.ExpirationTime
+	}
+	return 0
+}
+
+// ScheduledTransfer is a transfer to Ethereum sent with an earliest execution,
+// the amount and fee are locked when it is sent but the transfer only enters
+// the outgoing pool once the chain reached both execute_after_height and
+// execute_after_time (unix seconds), a zero value is no restriction
+type ScheduledTransfer struct {
+	Tx                 OutgoingTransferTx `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx"`
+	ExecuteAfterHeight uint64             `protobuf:"varint,2,opt,name=execute_after_height,json=executeAfterHeight,proto3" json:"execute_after_height,omitempty"`
+	ExecuteAfterTime   uint64             `protobuf:"varint,3,opt,name=execute_after_time,json=executeAfterTime,proto3" json:"execute_after_time,omitempty"`
+}
+
+func (m *ScheduledTransfer) Reset()         { *m = ScheduledTransfer{} }
+func (m *ScheduledTransfer) String() string { return proto.CompactTextString(m) }
+func (*ScheduledTransfer) ProtoMessage()    {}
+func (*ScheduledTransfer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4453b445b0660cab, []int{2}
+}
+func (m *ScheduledTransfer) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ScheduledTransfer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ScheduledTransfer.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ScheduledTransfer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScheduledTransfer.Merge(m, src)
+}
+func (m *ScheduledTransfer) XXX_Size() int {
+	return m.Size()
+}
+func (m *ScheduledTransfer) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScheduledTransfer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScheduledTransfer proto.InternalMessageInfo
+
+func (m *ScheduledTransfer) GetTx() OutgoingTransferTx {
+	if m != nil {
+		return m.Tx
+	}
+	return OutgoingTransferTx{}
+}
+
+func (m *ScheduledTransfer) GetExecuteAfterHeight() uint64 {
+	if m != nil {
+		return m.ExecuteAfterHeight
+	}
+	return 0
+}
+
+func (m *ScheduledTransfer) GetExecuteAfterTime() uint64 {
+	if m != nil {
+		return m.ExecuteAfterTime
+	}
+	return 0
+}
+
 // OutgoingLogicCall represents an individual logic call from Peggy to ETH
 type OutgoingLogicCall struct {
 	Transfers            []*ERC20Token `protobuf:"bytes,1,rep,name=transfers,proto3" json:"transfers,omitempty"`
@@ -185,13 +277,16 @@ type OutgoingLogicCall struct {
 	Timeout              uint64        `protobuf:"varint,5,opt,name=timeout,proto3" json:"timeout,omitempty"`
 	InvalidationId       []byte        `protobuf:"bytes,6,opt,name=invalidation_id,json=invalidationId,proto3" json:"invalidation_id,omitempty"`
 	InvalidationNonce    uint64        `protobuf:"varint,7,opt,name=invalidation_nonce,json=invalidationNonce,proto3" json:"invalidation_nonce,omitempty"`
+	// the account the fees were escrowed from, they are refunded to it if the
+	// call times out. Empty for calls created without an escrow
+	Sender string `protobuf:"bytes,8,opt,name=sender,proto3" json:"sender,omitempty"`
 }
 
 func (m *OutgoingLogicCall) Reset()         { *m = OutgoingLogicCall{} }
 func (m *OutgoingLogicCall) String() string { return proto.CompactTextString(m) }
 func (*OutgoingLogicCall) ProtoMessage()    {}
 func (*OutgoingLogicCall) Descriptor() ([]byte, []int) {
-	return fileDescriptor_398e85e0d69cec73, []int{2}
+	return fileDescriptor_4453b445b0660cab, []int{3}
 }
 func (m *OutgoingLogicCall) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -269,48 +364,65 @@ func (m *OutgoingLogicCall) GetInvalidationNonce() uint64 {
 	return 0
 }
 
+func (m *OutgoingLogicCall) GetSender() string {
+	if m != nil {
+		return m.Sender
+	}
+	return ""
+}
+
 func init() {
-	proto.RegisterType((*OutgoingTxBatch)(nil), "peggy.v1.OutgoingTxBatch")
-	proto.RegisterType((*OutgoingTransferTx)(nil), "peggy.v1.OutgoingTransferTx")
-	proto.RegisterType((*OutgoingLogicCall)(nil), "peggy.v1.OutgoingLogicCall")
-}
-
-func init() { proto.RegisterFile("peggy/v1/batch.proto", fileDescriptor_398e85e0d69cec73) }
-
-var fileDescriptor_398e85e0d69cec73 = []byte{
-	// 512 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x53, 0xcf, 0x6e, 0xda, 0x4e,
-	0x10, 0xc6, 0x84, 0x90, 0x30, 0x10, 0xa2, 0xac, 0x50, 0x64, 0x45, 0x3f, 0xf9, 0x47, 0xa9, 0xaa,
-	0x72, 0x09, 0x0e, 0xb4, 0xb9, 0xb7, 0x41, 0xad, 0x54, 0xa9, 0x6a, 0x24, 0x8b, 0x53, 0x2f, 0x68,
-	0xed, 0x1d, 0x9c, 0x55, 0x8c, 0x17, 0x79, 0x17, 0x04, 0x6f, 0xd1, 0x77, 0xe9, 0x4b, 0xb4, 0xb7,
-	0x9c, 0xaa, 0x1e, 0x2b, 0x78, 0x91, 0x6a, 0xc7, 0x98, 0x24, 0xfd, 0x77, 0xf3, 0x7c, 0xdf, 0x37,
-	0x33, 0xdf, 0x8c, 0x67, 0xa1, 0x35, 0xc3, 0x38, 0x5e, 0xf9, 0x8b, 0xbe, 0x1f, 0x72, 0x13, 0xdd,
-	0xf4, 0x66, 0x99, 0x32, 0x8a, 0x1d, 0x12, 0xda, 0x5b, 0xf4, 0xcf, 0xce, 0x76, 0x3c, 0x37, 0x06,
-	0xb5, 0xe1, 0x46, 0xaa, 0x34, 0x57, 0x75, 0xbe, 0x39, 0x70, 0x7c, 0x3d, 0x37, 0xb1, 0x92, 0x69,
-	0x3c, 0x5a, 0x5e, 0xd9, 0x7c, 0xf6, 0x3f, 0xd4, 0xa9, 0xd0, 0x38, 0x55, 0x69, 0x84, 0xae, 0xd3,
-	0x76, 0xba, 0x95, 0x00, 0x08, 0xfa, 0x60, 0x11, 0xf6, 0x14, 0x8e, 0x72, 0x81, 0x91, 0x53, 0x54,
-	0x73, 0xe3, 0x96, 0x49, 0xd2, 0x20, 0x70, 0x94, 0x63, 0xec, 0x15, 0x34, 0x4c, 0xc6, 0x53, 0xcd,
-	0x23, 0xdb, 0x4e, 0xbb, 0x7b, 0xed, 0xbd, 0x6e, 0x7d, 0xf0, 0x5f, 0xaf, 0xb0, 0xd5, 0xdb, 0xb5,
-	0xb5, 0xaa, 0x09, 0x66, 0xa3, 0x65, 0xf0, 0x28, 0x83, 0x3d, 0x83, 0xa6, 0x51, 0xb7, 0x98, 0x8e,
-	0x23, 0x95, 0x9a, 0x8c, 0x47, 0xc6, 0xad, 0xb4, 0x9d, 0x6e, 0x2d, 0x38, 0x22, 0x74, 0xb8, 0x05,
-	0x59, 0x0b, 0xf6, 0xc3, 0x44, 0x45, 0xb7, 0xee, 0x3e, 0xb9, 0xc8, 0x83, 0xce, 0x57, 0x07, 0xd8,
-	0xef, 0x1d, 0x58, 0x13, 0xca, 0x52, 0x6c, 0x47, 0x2a, 0x4b, 0xc1, 0x4e, 0xa1, 0xaa, 0x31, 0x15,
-	0x98, 0xd1, 0x0c, 0xb5, 0x60, 0x1b, 0xb1, 0x27, 0xd0, 0x10, 0xa8, 0xcd, 0x98, 0x0b, 0x91, 0xa1,
-	0xb6, 0xee, 0x2d, 0x5b, 0xb7, 0xd8, 0xeb, 0x1c, 0x62, 0x97, 0x50, 0xc7, 0x2c, 0x1a, 0x5c, 0x8c,
-	0xc9, 0x0e, 0x79, 0xab, 0x0f, 0x5a, 0xf7, 0xf3, 0xbd, 0x09, 0x86, 0x83, 0x8b, 0x91, 0xe5, 0x02,
-	0x20, 0x21, 0x7d, 0xb3, 0x3e, 0xd4, 0xf2, 0xb4, 0x09, 0x22, 0x59, 0xfe, 0x5b, 0xd2, 0x21, 0xc9,
-	0xde, 0x22, 0x76, 0x3e, 0x97, 0xe1, 0xa4, 0x98, 0xe5, 0xbd, 0x8a, 0x65, 0x34, 0xe4, 0x49, 0xc2,
-	0x06, 0x50, 0x33, 0xdb, 0xc1, 0xb4, 0xeb, 0xd0, 0x76, 0xff, 0x5c, 0xe8, 0x5e, 0xc6, 0xba, 0x50,
-	0x99, 0x20, 0x6a, 0xb7, 0xfc, 0x0f, 0x39, 0x29, 0xd8, 0x4b, 0x38, 0x4d, 0x6c, 0xab, 0xdd, 0xf2,
-	0x7f, 0x59, 0x45, 0x8b, 0xd8, 0xe2, 0x27, 0x14, 0x3b, 0x71, 0xe1, 0x60, 0xc6, 0x57, 0x89, 0xe2,
-	0x82, 0xf6, 0xd1, 0x08, 0x8a, 0xd0, 0x32, 0xc5, 0xb5, 0xe4, 0xff, 0xa9, 0x08, 0xd9, 0x73, 0x38,
-	0x96, 0xe9, 0x82, 0x27, 0x52, 0xd0, 0x61, 0x8e, 0xa5, 0x70, 0xab, 0x94, 0xdb, 0x7c, 0x08, 0xbf,
-	0x13, 0xec, 0x1c, 0xd8, 0x23, 0x61, 0x7e, 0x9e, 0x07, 0x54, 0xed, 0xe4, 0x21, 0x43, 0x57, 0x7a,
-	0x75, 0xfd, 0x65, 0xed, 0x39, 0x77, 0x6b, 0xcf, 0xf9, 0xb1, 0xf6, 0x9c, 0x4f, 0x1b, 0xaf, 0x74,
-	0xb7, 0xf1, 0x4a, 0xdf, 0x37, 0x5e, 0xe9, 0xe3, 0x65, 0x2c, 0xcd, 0xcd, 0x3c, 0xec, 0x45, 0x6a,
-	0xea, 0x47, 0x4a, 0x4f, 0x95, 0xf6, 0xe3, 0x8c, 0x2f, 0xa4, 0x59, 0x9d, 0x87, 0x99, 0x14, 0x31,
-	0xfa, 0x53, 0x25, 0xe6, 0x09, 0xfa, 0x4b, 0x3f, 0x7f, 0x3a, 0x66, 0x35, 0x43, 0x1d, 0x56, 0xe9,
-	0xc9, 0xbc, 0xf8, 0x19, 0x00, 0x00, 0xff, 0xff, 0xf6, 0x69, 0xb7, 0xae, 0x70, 0x03, 0x00, 0x00,
+	proto.RegisterType((*OutgoingTxBatch)(nil), "gravity.v1.OutgoingTxBatch")
+	proto.RegisterType((*OutgoingTransferTx)(nil), "gravity.v1.OutgoingTransferTx")
+	proto.RegisterType((*ScheduledTransfer)(nil), "gravity.v1.ScheduledTransfer")
+	proto.RegisterType((*OutgoingLogicCall)(nil), "gravity.v1.OutgoingLogicCall")
+}
+
+func init() { proto.RegisterFile("gravity/v1/batch.proto", fileDescriptor_4453b445b0660cab) }
+
+var fileDescriptor_4453b445b0660cab = []byte{
+	// 647 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0xcd, 0x6e, 0xd3, 0x4c,
+	0x14, 0x8d, 0x9d, 0xf4, 0x27, 0x37, 0x69, 0xfa, 0x65, 0x14, 0x45, 0x56, 0x55, 0xb9, 0xf9, 0x8a,
+	0x10, 0x15, 0xd0, 0xb8, 0x4d, 0x8b, 0x58, 0x37, 0x15, 0x08, 0x24, 0x44, 0x25, 0x93, 0x15, 0x9b,
+	0xc8, 0xb1, 0x6f, 0x9c, 0x51, 0x1d, 0x4f, 0xb0, 0x27, 0x91, 0xf3, 0x16, 0xbc, 0x03, 0x2b, 0xde,
+	0xa4, 0x3b, 0xba, 0x64, 0x85, 0x50, 0xfb, 0x0e, 0xac, 0xd1, 0x5c, 0xdb, 0x75, 0x0a, 0x52, 0xd9,
+	0xcd, 0x9c, 0x7b, 0xae, 0xe7, 0xdc, 0x39, 0x67, 0x0c, 0x6d, 0x3f, 0x72, 0x16, 0x5c, 0x2e, 0xad,
+	0xc5, 0xb1, 0x35, 0x72, 0xa4, 0x3b, 0xe9, 0xce, 0x22, 0x21, 0x05, 0x83, 0x0c, 0xef, 0x2e, 0x8e,
+	0x77, 0x76, 0x57, 0x38, 0x8e, 0x94, 0x18, 0x4b, 0x47, 0x72, 0x11, 0xa6, 0xcc, 0x9d, 0x96, 0x2f,
+	0x7c, 0x41, 0x4b, 0x4b, 0xad, 0x52, 0x74, 0xff, 0x97, 0x06, 0xdb, 0x17, 0x73, 0xe9, 0x0b, 0x1e,
+	0xfa, 0x83, 0xa4, 0xaf, 0xbe, 0xcc, 0xf6, 0xa0, 0x46, 0x47, 0x0c, 0x43, 0x11, 0xba, 0x68, 0x68,
+	0x1d, 0xed, 0xa0, 0x62, 0x03, 0x41, 0xef, 0x15, 0xc2, 0x1e, 0xc1, 0x56, 0x4a, 0x90, 0x7c, 0x8a,
+	0x62, 0x2e, 0x0d, 0x9d, 0x28, 0x75, 0x02, 0x07, 0x29, 0xc6, 0xfa, 0x50, 0x97, 0x91, 0x13, 0xc6,
+	0x8e, 0xab, 0x44, 0xc4, 0x46, 0xb9, 0x53, 0x3e, 0xa8, 0xf5, 0xcc, 0x6e, 0x21, 0xb8, 0x7b, 0x77,
+	0xb0, 0xe2, 0x8d, 0x31, 0x1a, 0x24, 0xf6, 0xbd, 0x1e, 0xf6, 0x18, 0x1a, 0x52, 0x5c, 0x62, 0x38,
+	0x74, 0x45, 0x28, 0x23, 0xc7, 0x95, 0x46, 0xa5, 0xa3, 0x1d, 0x54, 0xed, 0x2d, 0x42, 0xcf, 0x33,
+	0x90, 0xb5, 0x60, 0x6d, 0x14, 0x08, 0xf7, 0xd2, 0x58, 0x23, 0x1d, 0xe9, 0x86, 0xed, 0x42, 0x35,
+	0xc2, 0x4f, 0x73, 0x8c, 0x25, 0x46, 0xc6, 0x3a, 0xf5, 0x15, 0xc0, 0xfe, 0x17, 0x1d, 0xd8, 0xdf,
+	0xe7, 0xb3, 0x06, 0xe8, 0xdc, 0xcb, 0x46, 0xd6, 0xb9, 0xc7, 0xda, 0xb0, 0x1e, 0x63, 0xe8, 0x61,
+	0x44, 0x33, 0x56, 0xed, 0x6c, 0xc7, 0xfe, 0x87, 0xba, 0x87, 0xb1, 0x1c, 0x3a, 0x9e, 0x17, 0x61,
+	0xac, 0xa6, 0x53, 0xd5, 0x9a, 0xc2, 0xce, 0x52, 0x88, 0xbd, 0x84, 0x1a, 0x46, 0x6e, 0xef, 0x68,
+	0x48, 0x62, 0x49, 0x79, 0xad, 0xd7, 0x5e, 0x9d, 0xff, 0x95, 0x7d, 0xde, 0x3b, 0x1a, 0xa8, 0xaa,
+	0x0d, 0x44, 0xa5, 0x35, 0x3b, 0x81, 0x6a, 0xda, 0x38, 0x46, 0x34, 0xd6, 0x1e, 0x6c, 0xdb, 0x24,
+	0xe2, 0x6b, 0x44, 0xf6, 0x0c, 0x9a, 0x98, 0xcc, 0x78, 0x44, 0x96, 0x0f, 0x27, 0xc8, 0xfd, 0x89,
+	0xa4, 0xa9, 0x2b, 0xf6, 0x7f, 0x45, 0xe1, 0x0d, 0xe1, 0xec, 0x09, 0x6c, 0xaf, 0x90, 0x95, 0x8b,
+	0xc6, 0x06, 0x51, 0x1b, 0x05, 0xac, 0x7c, 0xdc, 0xff, 0xaa, 0x41, 0xf3, 0x83, 0x3b, 0x41, 0x6f,
+	0x1e, 0xa0, 0x97, 0x5f, 0x13, 0x3b, 0x05, 0x5d, 0x26, 0x74, 0x49, 0xff, 0x34, 0xb4, 0x5f, 0xb9,
+	0xfa, 0xb1, 0x57, 0xb2, 0x75, 0x99, 0xb0, 0x23, 0x68, 0x61, 0x82, 0xee, 0x5c, 0xe2, 0xd0, 0x19,
+	0x4b, 0x8c, 0x72, 0x91, 0x69, 0x78, 0x58, 0x56, 0x3b, 0x53, 0xa5, 0x4c, 0xe6, 0x73, 0x60, 0xf7,
+	0x3b, 0x48, 0x69, 0x39, 0x1f, 0xaa, 0xe0, 0x93, 0xd6, 0x6f, 0x3a, 0x34, 0x73, 0x01, 0xef, 0x84,
+	0xcf, 0xdd, 0x73, 0x27, 0x08, 0xd8, 0x29, 0x54, 0x65, 0xa6, 0x26, 0x36, 0xb4, 0x4e, 0xf9, 0x81,
+	0xcb, 0x2c, 0x88, 0xec, 0x29, 0x54, 0xc6, 0x88, 0xb1, 0xa1, 0x3f, 0xd8, 0x40, 0x1c, 0x76, 0x0a,
+	0xed, 0x40, 0x1d, 0x77, 0x17, 0xd2, 0x3f, 0x42, 0xd1, 0xa2, 0x6a, 0x1e, 0xd6, 0x3c, 0x1d, 0x06,
+	0x6c, 0xcc, 0x9c, 0x65, 0x20, 0x1c, 0x8f, 0x92, 0x51, 0xb7, 0xf3, 0xad, 0xaa, 0xe4, 0xef, 0x2a,
+	0xcd, 0x73, 0xbe, 0x55, 0xb6, 0xf1, 0x70, 0xe1, 0x04, 0xdc, 0x4b, 0x8d, 0xe3, 0x1e, 0x39, 0x5c,
+	0xb7, 0x1b, 0xab, 0xf0, 0x5b, 0x8f, 0x1d, 0x02, 0xbb, 0x47, 0x4c, 0x1f, 0x72, 0x6a, 0x71, 0x73,
+	0xb5, 0x92, 0xbe, 0xe7, 0x22, 0xe4, 0x9b, 0xab, 0x21, 0xef, 0x5f, 0x5c, 0xdd, 0x98, 0xda, 0xf5,
+	0x8d, 0xa9, 0xfd, 0xbc, 0x31, 0xb5, 0xcf, 0xb7, 0x66, 0xe9, 0xfa, 0xd6, 0x2c, 0x7d, 0xbf, 0x35,
+	0x4b, 0x1f, 0x5f, 0xf8, 0x5c, 0x4e, 0xe6, 0xa3, 0xae, 0x2b, 0xa6, 0x96, 0x2b, 0xe2, 0xa9, 0x88,
+	0xad, 0xec, 0x8a, 0x0e, 0x47, 0x11, 0xf7, 0x7c, 0xb4, 0xa6, 0x42, 0x65, 0xc6, 0x4a, 0xac, 0x19,
+	0xfa, 0xfe, 0xd2, 0x92, 0xcb, 0x19, 0xc6, 0xa3, 0x75, 0xfa, 0xe9, 0x9c, 0xfc, 0x1e, 0x00, 0x3f,
+	0x6b, 0x61, 0x19, 0xce, 0x04, 0x00, 0x00,
 }
 
 func (m *OutgoingTxBatch) Marshal() (dAtA []byte, err error) {
@@ -333,6 +445,13 @@ func (m *OutgoingTxBatch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Requester) > 0 {
+		i -= len(m.Requester)
+		copy(dAtA[i:], m.Requester)
+		i = encodeVarintBatch(dAtA, i, uint64(len(m.Requester)))
+		i--
+		dAtA[i] = 0x32
+	}
 	if m.Block != 0 {
 		i = encodeVarintBatch(dAtA, i, uint64(m.Block))
 		i--
@@ -392,6 +511,16 @@ func (m *OutgoingTransferTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.ExpirationTime != 0 {
+		i = encodeVarintBatch(dAtA, i, uint64(m.ExpirationTime))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.ExpirationHeight != 0 {
+		i = encodeVarintBatch(dAtA, i, uint64(m.ExpirationHeight))
+		i--
+		dAtA[i] = 0x30
+	}
 	if m.Erc20Fee != nil {
 		{
 			size, err := m.Erc20Fee.MarshalToSizedBuffer(dAtA[:i])
@@ -438,6 +567,49 @@ func (m *OutgoingTransferTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ScheduledTransfer) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ScheduledTransfer) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ScheduledTransfer) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ExecuteAfterTime != 0 {
+		i = encodeVarintBatch(dAtA, i, uint64(m.ExecuteAfterTime))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.ExecuteAfterHeight != 0 {
+		i = encodeVarintBatch(dAtA, i, uint64(m.ExecuteAfterHeight))
+		i--
+		dAtA[i] = 0x10
+	}
+	{
+		size, err := m.Tx.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintBatch(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
 func (m *OutgoingLogicCall) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -458,6 +630,13 @@ func (m *OutgoingLogicCall) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Sender) > 0 {
+		i -= len(m.Sender)
+		copy(dAtA[i:], m.Sender)
+		i = encodeVarintBatch(dAtA, i, uint64(len(m.Sender)))
+		i--
+		dAtA[i] = 0x42
+	}
 	if m.InvalidationNonce != 0 {
 		i = encodeVarintBatch(dAtA, i, uint64(m.InvalidationNonce))
 		i--
@@ -556,6 +735,10 @@ func (m *OutgoingTxBatch) Size() (n int) {
 	if m.Block != 0 {
 		n += 1 + sovBatch(uint64(m.Block))
 	}
+	l = len(m.Requester)
+	if l > 0 {
+		n += 1 + l + sovBatch(uint64(l))
+	}
 	return n
 }
 
@@ -584,6 +767,29 @@ func (m *OutgoingTransferTx) Size() (n int) {
 		l = m.Erc20Fee.Size()
 		n += 1 + l + sovBatch(uint64(l))
 	}
+	if m.ExpirationHeight != 0 {
+		n += 1 + sovBatch(uint64(m.ExpirationHeight))
+	}
+	if m.ExpirationTime != 0 {
+		n += 1 + sovBatch(uint64(m.ExpirationTime))
+	}
+	return n
+}
+
+func (m *ScheduledTransfer) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Tx.Size()
+	n += 1 + l + sovBatch(uint64(l))
+	if m.ExecuteAfterHeight != 0 {
+		n += 1 + sovBatch(uint64(m.ExecuteAfterHeight))
+	}
+	if m.ExecuteAfterTime != 0 {
+		n += 1 + sovBatch(uint64(m.ExecuteAfterTime))
+	}
 	return n
 }
 
@@ -623,6 +829,10 @@ func (m *OutgoingLogicCall) Size() (n int) {
 	if m.InvalidationNonce != 0 {
 		n += 1 + sovBatch(uint64(m.InvalidationNonce))
 	}
+	l = len(m.Sender)
+	if l > 0 {
+		n += 1 + l + sovBatch(uint64(l))
+	}
 	return n
 }
 
@@ -784,6 +994,38 @@ func (m *OutgoingTxBatch) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Requester", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBatch
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBatch
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Requester = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipBatch(dAtA[iNdEx:])
@@ -989,6 +1231,165 @@ func (m *OutgoingTransferTx) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpirationHeight", wireType)
+			}
+			m.ExpirationHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpirationHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpirationTime", wireType)
+			}
+			m.ExpirationTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpirationTime |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBatch(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthBatch
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ScheduledTransfer) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBatch
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ScheduledTransfer: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ScheduledTransfer: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tx", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBatch
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBatch
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Tx.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecuteAfterHeight", wireType)
+			}
+			m.ExecuteAfterHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExecuteAfterHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExecuteAfterTime", wireType)
+			}
+			m.ExecuteAfterTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExecuteAfterTime |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipBatch(dAtA[iNdEx:])
@@ -1245,6 +1646,38 @@ func (m *OutgoingLogicCall) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBatch
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBatch
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBatch
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sender = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipBatch(dAtA[iNdEx:])
@@ -0,0 +1,162 @@
+package types
+
+import (
+	"math/big"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// The checkpoint builders below hash their arguments exactly like the bridge contract does, they
+// take plain values so orchestrators and auditors can cross-check a checkpoint without the module
+// types. The contract abi.encode()s the arguments and hashes the result with keccak256, here the
+// arguments are packed as a call of the method in the ABI constant and the 4 byte method id is
+// dropped before hashing, which leaves exactly the output of abi.encode().
+
+// ValsetCheckpoint returns the checkpoint of a validator set as computed by makeCheckpoint in the
+// bridge contract. The members are given by their Ethereum addresses and the matching powers.
+func ValsetCheckpoint(gravityID string, nonce uint64, ethAddresses []string, powers []uint64) ([]byte, error) {
+	if len(ethAddresses) != len(powers) {
+		return nil, sdkerrors.Wrapf(ErrInvalid, "%d addresses but %d powers", len(ethAddresses), len(powers))
+	}
+	contractAbi, err := abi.JSON(strings.NewReader(ValsetCheckpointABIJSON))
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "bad ABI definition in code")
+	}
+	gravityIDBytes, err := checkpointGravityID(gravityID)
+	if err != nil {
+		return nil, err
+	}
+
+	memberAddresses := make([]gethcommon.Address, len(ethAddresses))
+	convertedPowers := make([]*big.Int, len(powers))
+	for i := range ethAddresses {
+		memberAddresses[i] = gethcommon.HexToAddress(ethAddresses[i])
+		convertedPowers[i] = new(big.Int).SetUint64(powers[i])
+	}
+	// the word 'checkpoint' needs to be the same as the 'name' above in the checkpointAbiJson
+	// but other than that it's a constant that has no impact on the output. This is because
+	// it gets encoded as a function name which we must then discard.
+	bytes, err := contractAbi.Pack("checkpoint",
+		gravityIDBytes,
+		checkpointMethodName("checkpoint"),
+		new(big.Int).SetUint64(nonce),
+		memberAddresses,
+		convertedPowers,
+	)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "packing checkpoint")
+	}
+	return crypto.Keccak256Hash(bytes[4:]).Bytes(), nil
+}
+
+// BatchCheckpoint returns the checkpoint of a transaction batch as computed by submitBatch in the
+// bridge contract. The transfers are given by the matching amounts, destinations and fees.
+func BatchCheckpoint(gravityID string, tokenContract string, nonce, timeout uint64, amounts []sdk.Int, destinations []string, fees []sdk.Int) ([]byte, error) {
+	if len(amounts) != len(destinations) || len(amounts) != len(fees) {
+		return nil, sdkerrors.Wrapf(ErrInvalid, "%d amounts, %d destinations and %d fees", len(amounts), len(destinations), len(fees))
+	}
+	contractAbi, err := abi.JSON(strings.NewReader(OutgoingBatchTxCheckpointABIJSON))
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "bad ABI definition in code")
+	}
+	gravityIDBytes, err := checkpointGravityID(gravityID)
+	if err != nil {
+		return nil, err
+	}
+
+	txAmounts := make([]*big.Int, len(amounts))
+	txDestinations := make([]gethcommon.Address, len(destinations))
+	txFees := make([]*big.Int, len(fees))
+	for i := range amounts {
+		txAmounts[i] = amounts[i].BigInt()
+		txDestinations[i] = gethcommon.HexToAddress(destinations[i])
+		txFees[i] = fees[i].BigInt()
+	}
+
+	abiEncodedBatch, err := contractAbi.Pack("submitBatch",
+		gravityIDBytes,
+		checkpointMethodName("transactionBatch"),
+		txAmounts,
+		txDestinations,
+		txFees,
+		new(big.Int).SetUint64(nonce),
+		gethcommon.HexToAddress(tokenContract),
+		new(big.Int).SetUint64(timeout),
+	)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "packing checkpoint")
+	}
+	return crypto.Keccak256Hash(abiEncodedBatch[4:]).Bytes(), nil
+}
+
+// LogicCallCheckpoint returns the checkpoint of a logic call as computed by submitLogicCall in the
+// bridge contract. The invalidation id is right padded to 32 bytes like a bytes32 in Solidity.
+func LogicCallCheckpoint(gravityID string, transfers, fees []*ERC20Token, logicContract string, payload []byte, timeout uint64, invalidationID []byte, invalidationNonce uint64) ([]byte, error) {
+	if len(invalidationID) > 32 {
+		return nil, sdkerrors.Wrap(ErrInvalid, "invalidation id longer than 32 bytes")
+	}
+	contractAbi, err := abi.JSON(strings.NewReader(OutgoingLogicCallABIJSON))
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "bad ABI definition in code")
+	}
+	gravityIDBytes, err := checkpointGravityID(gravityID)
+	if err != nil {
+		return nil, err
+	}
+
+	transferAmounts := make([]*big.Int, len(transfers))
+	transferTokenContracts := make([]gethcommon.Address, len(transfers))
+	feeAmounts := make([]*big.Int, len(fees))
+	feeTokenContracts := make([]gethcommon.Address, len(fees))
+	for i, tx := range transfers {
+		transferAmounts[i] = tx.Amount.BigInt()
+		transferTokenContracts[i] = gethcommon.HexToAddress(tx.Contract)
+	}
+	for i, tx := range fees {
+		feeAmounts[i] = tx.Amount.BigInt()
+		feeTokenContracts[i] = gethcommon.HexToAddress(tx.Contract)
+	}
+	payloadCopy := make([]byte, len(payload))
+	copy(payloadCopy, payload)
+	var invalidationIDBytes [32]byte
+	copy(invalidationIDBytes[:], invalidationID)
+
+	abiEncodedCall, err := contractAbi.Pack("checkpoint",
+		gravityIDBytes,
+		checkpointMethodName("logicCall"),
+		transferAmounts,
+		transferTokenContracts,
+		feeAmounts,
+		feeTokenContracts,
+		gethcommon.HexToAddress(logicContract),
+		payloadCopy,
+		new(big.Int).SetUint64(timeout),
+		invalidationIDBytes,
+		new(big.Int).SetUint64(invalidationNonce),
+	)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "packing checkpoint")
+	}
+	return crypto.Keccak256Hash(abiEncodedCall[4:]).Bytes(), nil
+}
+
+// checkpointGravityID encodes the gravity id as the bytes32 the contract is deployed with
+func checkpointGravityID(gravityID string) ([32]byte, error) {
+	out, err := strToFixByteArray(gravityID)
+	if err != nil {
+		return out, sdkerrors.Wrap(ErrInvalid, "gravity id longer than 32 bytes")
+	}
+	return out, nil
+}
+
+// checkpointMethodName encodes the method name salting a checkpoint as a bytes32
+func checkpointMethodName(name string) [32]byte {
+	var out [32]byte
+	copy(out[:], name)
+	return out
+}
@@ -0,0 +1,116 @@
+package types
+
+import (
+	"encoding/hex"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The gold hashes below are computed by the bridge contract, see /solidity/test for the scripts.
+
+func TestValsetCheckpoint(t *testing.T) {
+	specs := map[string]struct {
+		gravityID    string
+		nonce        uint64
+		ethAddresses []string
+		powers       []uint64
+		exp          string
+		expErr       bool
+	}{
+		"single member": {
+			gravityID:    "foo",
+			nonce:        0xc,
+			ethAddresses: []string{"0xB462864E395d88d6bc7C5dd5F3F5eb4cc2599255"},
+			powers:       []uint64{0xffffffff},
+			exp:          "f024ab7404464494d3919e5a7f0d8ac40804fb9bd39ad5d16cdb3e66aa219b64",
+		},
+		"three members": {
+			gravityID: "foo",
+			ethAddresses: []string{
+				"0xc783df8a850f42e7F7e57013759C285caa701eB6",
+				"0xeAD9C93b79Ae7C1591b1FB5323BD777E86e150d4",
+				"0xE5904695748fe4A84b40b3fc79De2277660BD1D3",
+			},
+			powers: []uint64{3333, 3333, 3333},
+			exp:    "88165860d955aee7dc3e83d9d1156a5864b708841965585d206dbef6e9e1a499",
+		},
+		"lower case addresses": {
+			gravityID:    "foo",
+			nonce:        0xc,
+			ethAddresses: []string{"0xb462864e395d88d6bc7c5dd5f3f5eb4cc2599255"},
+			powers:       []uint64{0xffffffff},
+			exp:          "f024ab7404464494d3919e5a7f0d8ac40804fb9bd39ad5d16cdb3e66aa219b64",
+		},
+		"powers not matching addresses": {
+			gravityID:    "foo",
+			ethAddresses: []string{"0xB462864E395d88d6bc7C5dd5F3F5eb4cc2599255"},
+			expErr:       true,
+		},
+		"gravity id too long": {
+			gravityID:    "a gravity id that does not fit in a bytes32",
+			ethAddresses: []string{"0xB462864E395d88d6bc7C5dd5F3F5eb4cc2599255"},
+			powers:       []uint64{1},
+			expErr:       true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			got, err := ValsetCheckpoint(spec.gravityID, spec.nonce, spec.ethAddresses, spec.powers)
+			if spec.expErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.exp, hex.EncodeToString(got))
+		})
+	}
+}
+
+func TestBatchCheckpoint(t *testing.T) {
+	const erc20Addr = "0x835973768750b3ED2D5c3EF5AdcD5eDb44d12aD4"
+	one := []sdk.Int{sdk.NewInt(1)}
+	dest := []string{"0x9FC9C2DfBA3b6cF204C37a5F690619772b926e39"}
+
+	got, err := BatchCheckpoint("foo", erc20Addr, 1, 2111, one, dest, one)
+	require.NoError(t, err)
+	assert.Equal(t, "a3a7ee0a363b8ad2514e7ee8f110d7449c0d88f3b0913c28c1751e6e0079a9b2", hex.EncodeToString(got))
+
+	// every argument is part of the checkpoint
+	other, err := BatchCheckpoint("foo", erc20Addr, 2, 2111, one, dest, one)
+	require.NoError(t, err)
+	assert.NotEqual(t, got, other)
+	other, err = BatchCheckpoint("bar", erc20Addr, 1, 2111, one, dest, one)
+	require.NoError(t, err)
+	assert.NotEqual(t, got, other)
+
+	_, err = BatchCheckpoint("foo", erc20Addr, 1, 2111, one, dest, nil)
+	assert.Error(t, err)
+}
+
+func TestLogicCallCheckpoint(t *testing.T) {
+	payload, err := hex.DecodeString("74657374696e675061796c6f6164000000000000000000000000000000000000")
+	require.NoError(t, err)
+	token := []*ERC20Token{{
+		Contract: "0xC26eFfa98B8A2632141562Ae7E34953Cfe5B4888",
+		Amount:   sdk.NewIntFromUint64(1),
+	}}
+	const logicContract = "0x17c1736CcF692F653c433d7aa2aB45148C016F68"
+
+	// the invalidation id is padded like a bytes32 so the short and the padded form match
+	for _, invalidationID := range []string{
+		"696e76616c69646174696f6e4964",
+		"696e76616c69646174696f6e4964000000000000000000000000000000000000",
+	} {
+		id, err := hex.DecodeString(invalidationID)
+		require.NoError(t, err)
+		got, err := LogicCallCheckpoint("foo", token, token, logicContract, payload, 4766922941000, id, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "1de95c9ace999f8ec70c6dc8d045942da2612950567c4861aca959c0650194da", hex.EncodeToString(got))
+	}
+
+	_, err = LogicCallCheckpoint("foo", token, token, logicContract, payload, 4766922941000, make([]byte, 33), 1)
+	assert.Error(t, err)
+}
@@ -1,10 +1,21 @@
 package types
 
 import (
+	"strings"
+
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/msgservice"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+const (
+	// LegacyTypeURLPrefix is the type URL prefix of the module's proto types
+	// before the proto package was renamed from peggy.v1 to gravity.v1
+	LegacyTypeURLPrefix = "/peggy.v1."
+	// TypeURLPrefix is the type URL prefix of the module's proto types
+	TypeURLPrefix = "/gravity.v1."
 )
 
 // ModuleCdc is the codec for the module
@@ -27,22 +38,62 @@ func RegisterInterfaces(registry types.InterfaceRegistry) {
 		&MsgERC20DeployedClaim{},
 		&MsgSetOrchestratorAddress{},
 		&MsgLogicCallExecutedClaim{},
+		&MsgGenericEventClaim{},
+		&MsgValsetUpdatedClaim{},
 		&MsgCancelSendToEth{},
+		&MsgBumpSendToEthFee{},
+		&MsgUpdateParams{},
+		&MsgRegisterRelayer{},
+		&MsgCancelBatch{},
+		&MsgGrant{},
+		&MsgRevoke{},
+		&MsgExec{},
+		&MsgSetLastObservedEventNonce{},
+		&MsgMultiSendToEth{},
+		&MsgRegisterOmnibusAccount{},
+		&MsgSweepOmnibusSubAccounts{},
+		&MsgPauseToken{},
+		&MsgUnpauseToken{},
+		&MsgRevokeOrchestratorAddress{},
+		&MsgSetCosmosOriginatedERC20{},
+	)
+
+	registry.RegisterInterface(
+		"gravity.v1.Authorization",
+		(*Authorization)(nil),
+		&GenericAuthorization{},
+		&SendToEthAuthorization{},
 	)
 
 	registry.RegisterInterface(
-		"peggy.v1beta1.EthereumClaim",
+		"gravity.v1.EthereumClaim",
 		(*EthereumClaim)(nil),
 		&MsgDepositClaim{},
 		&MsgWithdrawClaim{},
 		&MsgERC20DeployedClaim{},
 		&MsgLogicCallExecutedClaim{},
+		&MsgGenericEventClaim{},
+		&MsgValsetUpdatedClaim{},
+	)
+
+	registry.RegisterImplementations((*govtypes.Content)(nil),
+		&ReturnReclaimableDepositProposal{},
+		&UpdateBridgeContractProposal{},
+		&AbandonValsetNonceProposal{},
+		&UpdateParamsProposal{},
+		&CancelBatchProposal{},
+		&SetLastObservedEventNonceProposal{},
+		&PauseTokenProposal{},
+		&UnpauseTokenProposal{},
+		&SetCosmosOriginatedERC20Proposal{},
 	)
 
 	msgservice.RegisterMsgServiceDesc(registry, &_Msg_serviceDesc)
 }
 
-// RegisterCodec registers concrete types on the Amino codec
+// RegisterCodec registers concrete types on the Amino codec. The amino names keep
+// the legacy peggy/ prefix so that transactions signed in amino JSON before the
+// proto package rename still verify.
 func RegisterCodec(cdc *codec.LegacyAmino) {
 	cdc.RegisterInterface((*EthereumClaim)(nil), nil)
 	cdc.RegisterConcrete(&MsgSetOrchestratorAddress{}, "peggy/MsgSetOrchestratorAddress", nil)
@@ -56,10 +107,48 @@ func RegisterCodec(cdc *codec.LegacyAmino) {
 	cdc.RegisterConcrete(&MsgWithdrawClaim{}, "peggy/MsgWithdrawClaim", nil)
 	cdc.RegisterConcrete(&MsgERC20DeployedClaim{}, "peggy/MsgERC20DeployedClaim", nil)
 	cdc.RegisterConcrete(&MsgLogicCallExecutedClaim{}, "peggy/MsgLogicCallExecutedClaim", nil)
+	cdc.RegisterConcrete(&MsgGenericEventClaim{}, "peggy/MsgGenericEventClaim", nil)
+	cdc.RegisterConcrete(&MsgValsetUpdatedClaim{}, "peggy/MsgValsetUpdatedClaim", nil)
 	cdc.RegisterConcrete(&MsgCancelSendToEth{}, "peggy/MsgCancelSendToEth", nil)
+	cdc.RegisterConcrete(&MsgBumpSendToEthFee{}, "peggy/MsgBumpSendToEthFee", nil)
+	cdc.RegisterConcrete(&MsgUpdateParams{}, "peggy/MsgUpdateParams", nil)
+	cdc.RegisterConcrete(&MsgRegisterRelayer{}, "peggy/MsgRegisterRelayer", nil)
+	cdc.RegisterConcrete(&MsgCancelBatch{}, "peggy/MsgCancelBatch", nil)
+	cdc.RegisterConcrete(&MsgGrant{}, "peggy/MsgGrant", nil)
+	cdc.RegisterConcrete(&MsgRevoke{}, "peggy/MsgRevoke", nil)
+	cdc.RegisterConcrete(&MsgExec{}, "peggy/MsgExec", nil)
+	cdc.RegisterConcrete(&MsgSetLastObservedEventNonce{}, "peggy/MsgSetLastObservedEventNonce", nil)
+	cdc.RegisterConcrete(&MsgMultiSendToEth{}, "peggy/MsgMultiSendToEth", nil)
+	cdc.RegisterConcrete(&MsgRegisterOmnibusAccount{}, "peggy/MsgRegisterOmnibusAccount", nil)
+	cdc.RegisterConcrete(&MsgSweepOmnibusSubAccounts{}, "peggy/MsgSweepOmnibusSubAccounts", nil)
+	cdc.RegisterConcrete(&MsgPauseToken{}, "peggy/MsgPauseToken", nil)
+	cdc.RegisterConcrete(&MsgUnpauseToken{}, "peggy/MsgUnpauseToken", nil)
+	cdc.RegisterConcrete(&MsgRevokeOrchestratorAddress{}, "peggy/MsgRevokeOrchestratorAddress", nil)
+	cdc.RegisterConcrete(&MsgSetCosmosOriginatedERC20{}, "peggy/MsgSetCosmosOriginatedERC20", nil)
+	cdc.RegisterInterface((*Authorization)(nil), nil)
+	cdc.RegisterConcrete(&GenericAuthorization{}, "peggy/GenericAuthorization", nil)
+	cdc.RegisterConcrete(&SendToEthAuthorization{}, "peggy/SendToEthAuthorization", nil)
 	cdc.RegisterConcrete(&OutgoingTxBatch{}, "peggy/OutgoingTxBatch", nil)
 	cdc.RegisterConcrete(&OutgoingTransferTx{}, "peggy/OutgoingTransferTx", nil)
 	cdc.RegisterConcrete(&ERC20Token{}, "peggy/ERC20Token", nil)
 	cdc.RegisterConcrete(&IDSet{}, "peggy/IDSet", nil)
 	cdc.RegisterConcrete(&Attestation{}, "peggy/Attestation", nil)
+	cdc.RegisterConcrete(&ReturnReclaimableDepositProposal{}, "peggy/ReturnReclaimableDepositProposal", nil)
+	cdc.RegisterConcrete(&UpdateBridgeContractProposal{}, "peggy/UpdateBridgeContractProposal", nil)
+	cdc.RegisterConcrete(&AbandonValsetNonceProposal{}, "peggy/AbandonValsetNonceProposal", nil)
+	cdc.RegisterConcrete(&UpdateParamsProposal{}, "peggy/UpdateParamsProposal", nil)
+	cdc.RegisterConcrete(&CancelBatchProposal{}, "peggy/CancelBatchProposal", nil)
+	cdc.RegisterConcrete(&SetLastObservedEventNonceProposal{}, "peggy/SetLastObservedEventNonceProposal", nil)
+	cdc.RegisterConcrete(&PauseTokenProposal{}, "peggy/PauseTokenProposal", nil)
+	cdc.RegisterConcrete(&UnpauseTokenProposal{}, "peggy/UnpauseTokenProposal", nil)
+	cdc.RegisterConcrete(&SetCosmosOriginatedERC20Proposal{}, "peggy/SetCosmosOriginatedERC20Proposal", nil)
+}
+
+// MigrateLegacyTypeURL maps a type URL of the legacy peggy.v1 proto package to
+// its gravity.v1 equivalent, any other type URL is returned unchanged
+func MigrateLegacyTypeURL(typeURL string) string {
+	if strings.HasPrefix(typeURL, LegacyTypeURLPrefix) {
+		return TypeURLPrefix + strings.TrimPrefix(typeURL, LegacyTypeURLPrefix)
+	}
+	return typeURL
 }
@@ -0,0 +1,43 @@
+package types
+
+import (
+	"encoding/json"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// ContractRotation moves the bridge to a newly deployed bridge contract, it is carried by the
+// UpdateBridgeContractProposal or is the JSON encoded info of the contract-rotation upgrade plan
+// doing the rotation
+type ContractRotation struct {
+	// the address of the new bridge contract
+	BridgeEthereumAddress string `json:"bridge_ethereum_address"`
+	// the nonce of the last event of the old contract, the rotation waits until it was observed
+	FinalEventNonce uint64 `json:"final_event_nonce"`
+	// the event nonce the orchestrators continue after, the nonce of the last event of the new contract
+	// that must not be applied, usually zero for a freshly deployed contract
+	LastObservedEventNonce uint64 `json:"last_observed_event_nonce"`
+	// the hash of the creation code of the ERC20s the new contract deploys with CREATE2, it replaces the
+	// ERC20InitCodeHash param
+	Erc20InitCodeHash string `json:"erc20_init_code_hash"`
+}
+
+// ParseContractRotation decodes the contract rotation from the info of an upgrade plan
+func ParseContractRotation(info string) (ContractRotation, error) {
+	var rotation ContractRotation
+	if err := json.Unmarshal([]byte(info), &rotation); err != nil {
+		return ContractRotation{}, sdkerrors.Wrap(ErrInvalid, err.Error())
+	}
+	return rotation, rotation.ValidateBasic()
+}
+
+// ValidateBasic checks the address of the new bridge contract and the ERC20 init code hash
+func (r ContractRotation) ValidateBasic() error {
+	if err := ValidateChecksumEthAddress(r.BridgeEthereumAddress); err != nil {
+		return sdkerrors.Wrap(ErrInvalid, err.Error())
+	}
+	if err := validateERC20InitCodeHash(r.Erc20InitCodeHash); err != nil {
+		return sdkerrors.Wrap(ErrInvalid, err.Error())
+	}
+	return nil
+}
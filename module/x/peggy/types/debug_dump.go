@@ -0,0 +1,48 @@
+package types
+
+import (
+	"bytes"
+	"sort"
+)
+
+// StoreDumpDiff is an entry that differs between two store dumps. Old is nil for an entry that is
+// only in the second dump and New is nil for one that is only in the first.
+type StoreDumpDiff struct {
+	Old *StoreDumpEntry
+	New *StoreDumpEntry
+}
+
+// Entry returns the new entry of the diff, or the old one if the entry was removed
+func (d StoreDumpDiff) Entry() *StoreDumpEntry {
+	if d.New != nil {
+		return d.New
+	}
+	return d.Old
+}
+
+// DiffStoreDumps returns the entries that were added, removed or changed from dump a to dump b,
+// in key order
+func DiffStoreDumps(a, b StoreDump) []StoreDumpDiff {
+	old := make(map[string]*StoreDumpEntry, len(a.Entries))
+	for i := range a.Entries {
+		old[string(a.Entries[i].Key)] = &a.Entries[i]
+	}
+
+	var out []StoreDumpDiff
+	for i := range b.Entries {
+		e := &b.Entries[i]
+		o, ok := old[string(e.Key)]
+		delete(old, string(e.Key))
+		switch {
+		case !ok:
+			out = append(out, StoreDumpDiff{New: e})
+		case !bytes.Equal(o.Value, e.Value):
+			out = append(out, StoreDumpDiff{Old: o, New: e})
+		}
+	}
+	for _, o := range old {
+		out = append(out, StoreDumpDiff{Old: o})
+	}
+	sort.Slice(out, func(i, j int) bool { return bytes.Compare(out[i].Entry().Key, out[j].Entry().Key) < 0 })
+	return out
+}
@@ -0,0 +1,34 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffStoreDumps(t *testing.T) {
+	a := StoreDump{Height: 10, Entries: []StoreDumpEntry{
+		{Prefix: "EthAddressKey", Key: []byte{0x1, 0x1}, Value: []byte{1}},
+		{Prefix: "EthAddressKey", Key: []byte{0x1, 0x2}, Value: []byte{2}},
+		{Prefix: "ValsetRequestKey", Key: []byte{0x2, 0x1}, Value: []byte{3}},
+	}}
+	assert.Empty(t, DiffStoreDumps(a, a))
+
+	b := StoreDump{Height: 10, Entries: []StoreDumpEntry{
+		{Prefix: "EthAddressKey", Key: []byte{0x1, 0x1}, Value: []byte{1}},
+		{Prefix: "ValsetRequestKey", Key: []byte{0x2, 0x1}, Value: []byte{4}},
+		{Prefix: "ValsetRequestKey", Key: []byte{0x2, 0x2}, Value: []byte{5}},
+	}}
+	assert.Equal(t, []StoreDumpDiff{
+		{Old: &a.Entries[1]},
+		{Old: &a.Entries[2], New: &b.Entries[1]},
+		{New: &b.Entries[2]},
+	}, DiffStoreDumps(a, b))
+
+	// an index entry without a value that is only in one of the dumps
+	c := StoreDump{Entries: []StoreDumpEntry{{Prefix: "EthAddressKey", Key: []byte{0x1, 0x3}}}}
+	diffs := DiffStoreDumps(StoreDump{}, c)
+	assert.Len(t, diffs, 1)
+	assert.Nil(t, diffs[0].Old)
+	assert.Equal(t, []byte{0x1, 0x3}, diffs[0].Entry().Key)
+}
@@ -0,0 +1,59 @@
+package types
+
+import (
+	"encoding/hex"
+	"strings"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// CosmosERC20Salt returns the CREATE2 salt deployERC20 in the bridge contract deploys the ERC20 of a
+// cosmos originated denom with, the keccak256 hash of abi.encode(denom, name, symbol, decimals)
+func CosmosERC20Salt(denom, name, symbol string, decimals uint8) ([32]byte, error) {
+	stringType, err := abi.NewType("string", "", nil)
+	if err != nil {
+		return [32]byte{}, sdkerrors.Wrap(err, "string type")
+	}
+	uint8Type, err := abi.NewType("uint8", "", nil)
+	if err != nil {
+		return [32]byte{}, sdkerrors.Wrap(err, "uint8 type")
+	}
+	args := abi.Arguments{{Type: stringType}, {Type: stringType}, {Type: stringType}, {Type: uint8Type}}
+	encoded, err := args.Pack(denom, name, symbol, decimals)
+	if err != nil {
+		return [32]byte{}, sdkerrors.Wrap(err, "packing salt")
+	}
+	return crypto.Keccak256Hash(encoded), nil
+}
+
+// PredictCosmosERC20Address returns the address the bridge contract deploys the ERC20 of a cosmos
+// originated denom at. The init code of the ERC20 is the same for every token, its hash is given hex
+// encoded.
+func PredictCosmosERC20Address(bridgeContract, initCodeHash, denom, name, symbol string, decimals uint8) (string, error) {
+	if err := ValidateEthAddress(bridgeContract); err != nil {
+		return "", sdkerrors.Wrap(err, "bridge contract")
+	}
+	hash, err := decodeInitCodeHash(initCodeHash)
+	if err != nil {
+		return "", err
+	}
+	salt, err := CosmosERC20Salt(denom, name, symbol, decimals)
+	if err != nil {
+		return "", err
+	}
+	return crypto.CreateAddress2(gethcommon.HexToAddress(bridgeContract), salt, hash).Hex(), nil
+}
+
+func decodeInitCodeHash(s string) ([]byte, error) {
+	hash, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, sdkerrors.Wrap(ErrInvalid, "init code hash is not hex")
+	}
+	if len(hash) != 32 {
+		return nil, sdkerrors.Wrapf(ErrInvalid, "init code hash of %d bytes", len(hash))
+	}
+	return hash, nil
+}
@@ -0,0 +1,58 @@
+package types
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCosmosERC20Salt(t *testing.T) {
+	// abi.encode("uatom", "atom", "atom", 6): the offsets of the three strings and the decimals, then
+	// the length and padded bytes of each string
+	word := func(s string) string { return strings.Repeat("0", 64-len(s)) + s }
+	str := func(s string) string {
+		return word(hex.EncodeToString([]byte{byte(len(s))})) + hex.EncodeToString([]byte(s)) + strings.Repeat("0", 64-2*len(s))
+	}
+	encoded, err := hex.DecodeString(word("80") + word("c0") + word("0100") + word("06") + str("uatom") + str("atom") + str("atom"))
+	require.NoError(t, err)
+
+	salt, err := CosmosERC20Salt("uatom", "atom", "atom", 6)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.Keccak256Hash(encoded), gethcommon.Hash(salt))
+
+	other, err := CosmosERC20Salt("uatom", "atom", "atom", 18)
+	require.NoError(t, err)
+	assert.NotEqual(t, salt, other)
+}
+
+func TestPredictCosmosERC20Address(t *testing.T) {
+	const (
+		bridgeContract = "0x8858eeB3DfffA017D4BCE9801D340D36Cf895CCf"
+		initCodeHash   = "8c55e7d2b8cc5d1a8cc1d8a3a8ef1b6f7e8e13c3d31b4f8a3d1d7fa07e0c47a2"
+	)
+	salt, err := CosmosERC20Salt("uatom", "atom", "atom", 6)
+	require.NoError(t, err)
+	hash, _ := hex.DecodeString(initCodeHash)
+	// keccak256(0xff ++ deployer ++ salt ++ keccak256(init code))[12:]
+	exp := gethcommon.BytesToAddress(crypto.Keccak256(
+		[]byte{0xff}, gethcommon.HexToAddress(bridgeContract).Bytes(), salt[:], hash,
+	)[12:]).Hex()
+
+	for _, h := range []string{initCodeHash, "0x" + initCodeHash} {
+		got, err := PredictCosmosERC20Address(bridgeContract, h, "uatom", "atom", "atom", 6)
+		require.NoError(t, err)
+		assert.Equal(t, exp, got)
+	}
+
+	_, err = PredictCosmosERC20Address(bridgeContract, initCodeHash[:62], "uatom", "atom", "atom", 6)
+	assert.ErrorIs(t, err, ErrInvalid)
+	_, err = PredictCosmosERC20Address(bridgeContract, "0xzz", "uatom", "atom", "atom", 6)
+	assert.ErrorIs(t, err, ErrInvalid)
+	_, err = PredictCosmosERC20Address("0xinvalid", initCodeHash, "uatom", "atom", "atom", 6)
+	assert.Error(t, err)
+}
@@ -14,4 +14,9 @@ var (
 	ErrOutdated                = sdkerrors.Register(ModuleName, 7, "outdated")
 	ErrUnsupported             = sdkerrors.Register(ModuleName, 8, "unsupported")
 	ErrNonContiguousEventNonce = sdkerrors.Register(ModuleName, 9, "non contiguous event nonce")
+	ErrNotDeployed             = sdkerrors.Register(ModuleName, 10, "erc20 not deployed")
+	ErrDuplicateBatchConfirm   = sdkerrors.Register(ModuleName, 11, "duplicate batch confirm")
+	ErrVoucherTransferDisabled = sdkerrors.Register(ModuleName, 12, "voucher transfer disabled")
+	ErrRelayerNotRegistered    = sdkerrors.Register(ModuleName, 13, "relayer not registered")
+	ErrTokenPaused             = sdkerrors.Register(ModuleName, 14, "token paused")
 )
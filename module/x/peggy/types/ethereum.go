@@ -3,16 +3,23 @@ package types
 import (
 	"bytes"
 	"fmt"
+	"math/big"
 	"regexp"
 	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 )
 
 const (
 	// PeggyDenomPrefix indicates the prefix for all assests minted by this module
-	PeggyDenomPrefix = ModuleName
+	PeggyDenomPrefix = "gravity"
+
+	// LegacyPeggyDenomPrefix is the prefix of the vouchers minted before the gravity denom
+	// format, they are translated by MigrateVoucherDenoms
+	LegacyPeggyDenomPrefix = ModuleName
 
 	// PeggyDenomSeparator is the separator for peggy denoms
 	PeggyDenomSeparator = ""
@@ -43,6 +50,29 @@ func ValidateEthAddress(a string) error {
 	return nil
 }
 
+// ValidateEthTxHash validates the hex encoded ethereum transaction hash strings
+func ValidateEthTxHash(h string) error {
+	if h == "" {
+		return fmt.Errorf("empty")
+	}
+	if !regexp.MustCompile("^0x[0-9a-fA-F]{64}$").MatchString(h) {
+		return fmt.Errorf("tx hash(%s) doesn't pass regex", h)
+	}
+	return nil
+}
+
+// ValidateChecksumEthAddress validates the ethereum address string and that it is in
+// its EIP-55 mixed case checksum encoding
+func ValidateChecksumEthAddress(a string) error {
+	if err := ValidateEthAddress(a); err != nil {
+		return err
+	}
+	if checksummed := gethcommon.HexToAddress(a).Hex(); a != checksummed {
+		return fmt.Errorf("address(%s) is not checksummed, expected %s", a, checksummed)
+	}
+	return nil
+}
+
 /////////////////////////
 //     ERC20Token      //
 /////////////////////////
@@ -61,6 +91,50 @@ func (e *ERC20Token) PeggyCoin() sdk.Coin {
 	return sdk.NewCoin(PeggyDenom(e.Contract), e.Amount)
 }
 
+// ToCoin returns the coin of the denom the ERC20 amount is worth, scaled from the decimals of the ERC20
+// to the decimals of the denom. When the ERC20 has more decimals than the denom the amount is rounded
+// down, the remainder is returned as ERC20 amount that could not be represented by the denom.
+func (e *ERC20Token) ToCoin(denom string, erc20Decimals, denomDecimals uint32) (sdk.Coin, sdk.Int) {
+	amount, remainder := scaleDecimals(e.Amount, erc20Decimals, denomDecimals)
+	return sdk.NewCoin(denom, amount), remainder
+}
+
+// NewERC20TokenFromCoin returns the ERC20 amount the coin is worth, scaled from the decimals of the
+// denom to the decimals of the ERC20. It fails when the ERC20 has fewer decimals than the denom and
+// the coin has a remainder the ERC20 can't represent.
+func NewERC20TokenFromCoin(coin sdk.Coin, contract string, erc20Decimals, denomDecimals uint32) (*ERC20Token, error) {
+	amount, remainder := scaleDecimals(coin.Amount, denomDecimals, erc20Decimals)
+	if !remainder.IsZero() {
+		return nil, sdkerrors.Wrapf(ErrInvalid, "%s has more precision than the %d decimals of ERC20 %s", coin, erc20Decimals, contract)
+	}
+	return NewSDKIntERC20Token(amount, contract), nil
+}
+
+// ERC20Dust returns the part of the coin the ERC20 can't represent, it is zero unless the ERC20 has
+// fewer decimals than the denom
+func ERC20Dust(coin sdk.Coin, erc20Decimals, denomDecimals uint32) sdk.Coin {
+	_, remainder := scaleDecimals(coin.Amount, denomDecimals, erc20Decimals)
+	return sdk.NewCoin(coin.Denom, remainder)
+}
+
+// scaleDecimals converts an amount with from decimals to one with to decimals, along with the remainder
+// of the amount that is lost when to has fewer decimals
+func scaleDecimals(amount sdk.Int, from, to uint32) (sdk.Int, sdk.Int) {
+	switch {
+	case from < to:
+		return amount.Mul(decimalsFactor(to - from)), sdk.ZeroInt()
+	case from > to:
+		factor := decimalsFactor(from - to)
+		return amount.Quo(factor), amount.Mod(factor)
+	default:
+		return amount, sdk.ZeroInt()
+	}
+}
+
+func decimalsFactor(decimals uint32) sdk.Int {
+	return sdk.NewIntFromBigInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+}
+
 func PeggyDenom(tokenContract string) string {
 	return fmt.Sprintf("%s%s%s", PeggyDenomPrefix, PeggyDenomSeparator, tokenContract)
 }
@@ -87,8 +161,18 @@ func (e *ERC20Token) Add(o *ERC20Token) *ERC20Token {
 	return NewERC20Token(sum.Uint64(), e.Contract)
 }
 
+// PeggyDenomToERC20 returns the ERC20 contract of an ethereum originated voucher denom
 func PeggyDenomToERC20(denom string) (string, error) {
-	fullPrefix := PeggyDenomPrefix + PeggyDenomSeparator
+	return voucherDenomToERC20(denom, PeggyDenomPrefix)
+}
+
+// LegacyPeggyDenomToERC20 returns the ERC20 contract of a voucher denom in the legacy peggy format
+func LegacyPeggyDenomToERC20(denom string) (string, error) {
+	return voucherDenomToERC20(denom, LegacyPeggyDenomPrefix)
+}
+
+func voucherDenomToERC20(denom, prefix string) (string, error) {
+	fullPrefix := prefix + PeggyDenomSeparator
 	if !strings.HasPrefix(denom, fullPrefix) {
 		return "", fmt.Errorf("denom prefix(%s) not equal to expected(%s)", denom, fullPrefix)
 	}
@@ -97,9 +181,30 @@ func PeggyDenomToERC20(denom string) (string, error) {
 	switch {
 	case err != nil:
 		return "", fmt.Errorf("error(%s) validating ethereum contract address", err)
-	case len(denom) != PeggyDenomLen:
-		return "", fmt.Errorf("len(denom)(%d) not equal to PeggyDenomLen(%d)", len(denom), PeggyDenomLen)
+	case len(denom) != len(fullPrefix)+ETHContractAddressLen:
+		return "", fmt.Errorf("len(denom)(%d) not equal to expected(%d)", len(denom), len(fullPrefix)+ETHContractAddressLen)
 	default:
 		return contract, nil
 	}
 }
+
+// MigrateLegacyPeggyDenom maps a voucher denom in the legacy peggy format to the gravity denom
+// format, any other denom is returned unchanged
+func MigrateLegacyPeggyDenom(denom string) string {
+	tokenContract, err := LegacyPeggyDenomToERC20(denom)
+	if err != nil {
+		return denom
+	}
+	return PeggyDenom(tokenContract)
+}
+
+// PeggyDenomMetadata returns the bank metadata registered for the voucher of an ethereum originated ERC20
+func PeggyDenomMetadata(tokenContract string) bank.Metadata {
+	denom := PeggyDenom(tokenContract)
+	return bank.Metadata{
+		Description: fmt.Sprintf("Gravity Bridge voucher of the ERC20 %s", tokenContract),
+		DenomUnits:  []*bank.DenomUnit{{Denom: denom, Exponent: 0}},
+		Base:        denom,
+		Display:     denom,
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"crypto/ecdsa"
 
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
-	"github.com/ethereum/go-ethereum/crypto"
 )
 
 const (
@@ -13,49 +12,34 @@ const (
 
 // NewEthereumSignature creates a new signuature over a given byte array
 func NewEthereumSignature(hash []byte, privateKey *ecdsa.PrivateKey) ([]byte, error) {
-	if privateKey == nil {
-		return nil, sdkerrors.Wrap(ErrEmpty, "private key")
-	}
-	protectedHash := crypto.Keccak256Hash(append([]uint8(signaturePrefix), hash...))
-	return crypto.Sign(protectedHash.Bytes(), privateKey)
+	return SignCheckpoint(PersonalSignDecoder{}, hash, privateKey)
 }
 
 // ValidateEthereumSignature takes a message, an associated signature and public key and
-// returns an error if the signature isn't valid
+// returns an error if the signature isn't a valid personal_sign signature of the address
 func ValidateEthereumSignature(hash []byte, signature []byte, ethAddress string) error {
-	if len(signature) < 65 {
-		return sdkerrors.Wrap(ErrInvalid, "signature too short")
-	}
-	// To verify signature
-	// - use crypto.SigToPub to get the public key
-	// - use crypto.PubkeyToAddress to get the address
-	// - compare this to the address given.
-
-	// for backwards compatibility reasons  the V value of an Ethereum sig is presented
-	// as 27 or 28, internally though it should be a 0-3 value due to changed formats.
-	// It seems that go-ethereum expects this to be done before sigs actually reach it's
-	// internal validation functions. In order to comply with this requirement we check
-	// the sig an dif it's in standard format we correct it. If it's in go-ethereum's expected
-	// format already we make no changes.
-	//
-	// We could attempt to break or otherwise exit early on obviously invalid values for this
-	// byte, but that's a task best left to go-ethereum
-	if signature[64] == 27 || signature[64] == 28 {
-		signature[64] -= 27
-	}
-
-	protectedHash := crypto.Keccak256Hash(append([]uint8(signaturePrefix), hash...))
+	return VerifyCheckpointSignature(PersonalSignDecoder{}, hash, signature, ethAddress)
+}
 
-	pubkey, err := crypto.SigToPub(protectedHash.Bytes(), signature)
+// EthAddressFromSignature returns the EIP-55 checksummed Ethereum address that personal_signed
+// the hash
+func EthAddressFromSignature(hash []byte, signature []byte) (string, error) {
+	addr, err := RecoverCheckpointSigner(PersonalSignDecoder{}, hash, signature)
 	if err != nil {
-		return sdkerrors.Wrap(err, "signature to public key")
+		return "", err
 	}
+	return addr.Hex(), nil
+}
 
-	addr := crypto.PubkeyToAddress(*pubkey)
-
-	if addr.Hex() != ethAddress {
-		return sdkerrors.Wrap(ErrInvalid, "signature not matching")
+// ValidateEthSig returns an error unless the signature is a personal_sign signature of the 32 byte
+// hash made by the given Ethereum address. Unlike ValidateEthereumSignature the hash length and
+// the address format are checked before recovering the signer.
+func ValidateEthSig(hash []byte, signature []byte, ethAddress string) error {
+	if len(hash) != 32 {
+		return sdkerrors.Wrap(ErrInvalid, "hash must be 32 bytes")
 	}
-
-	return nil
+	if err := ValidateEthAddress(ethAddress); err != nil {
+		return sdkerrors.Wrap(err, "ethereum address")
+	}
+	return ValidateEthereumSignature(hash, signature, ethAddress)
 }
@@ -1,5 +1,5 @@
 // Code generated by protoc-gen-gogo. DO NOT EDIT.
-// source: peggy/v1/ethereum_signer.proto
+// source: gravity/v1/ethereum_signer.proto
 
 package types
 
@@ -43,32 +43,32 @@ var SignType_value = map[string]int32{
 }
 
 func (SignType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_d29cba59614e6f1a, []int{0}
+	return fileDescriptor_005a3d0c6f36c26c, []int{0}
 }
 
 func init() {
-	proto.RegisterEnum("peggy.v1.SignType", SignType_name, SignType_value)
+	proto.RegisterEnum("gravity.v1.SignType", SignType_name, SignType_value)
 }
 
-func init() { proto.RegisterFile("peggy/v1/ethereum_signer.proto", fileDescriptor_d29cba59614e6f1a) }
+func init() { proto.RegisterFile("gravity/v1/ethereum_signer.proto", fileDescriptor_005a3d0c6f36c26c) }
 
-var fileDescriptor_d29cba59614e6f1a = []byte{
-	// 272 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x2b, 0x48, 0x4d, 0x4f,
-	0xaf, 0xd4, 0x2f, 0x33, 0xd4, 0x4f, 0x2d, 0xc9, 0x48, 0x2d, 0x4a, 0x2d, 0xcd, 0x8d, 0x2f, 0xce,
-	0x4c, 0xcf, 0x4b, 0x2d, 0xd2, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x00, 0xcb, 0xeb, 0x95,
-	0x19, 0x4a, 0x89, 0xa4, 0xe7, 0xa7, 0xe7, 0x83, 0x05, 0xf5, 0x41, 0x2c, 0x88, 0xbc, 0xd6, 0x54,
-	0x46, 0x2e, 0x8e, 0xe0, 0xcc, 0xf4, 0xbc, 0x90, 0xca, 0x82, 0x54, 0x21, 0x49, 0x2e, 0xd1, 0x60,
-	0x4f, 0x77, 0xbf, 0xf8, 0x90, 0xc8, 0x00, 0xd7, 0xf8, 0x50, 0xbf, 0xe0, 0x00, 0x57, 0x67, 0x4f,
-	0x37, 0x4f, 0x57, 0x17, 0x01, 0x06, 0x21, 0x23, 0x2e, 0x3d, 0x84, 0x94, 0x7f, 0x90, 0xb3, 0x87,
-	0x6b, 0x70, 0x48, 0x90, 0x63, 0x88, 0x7f, 0x50, 0x3c, 0x48, 0xd8, 0xd5, 0x25, 0xde, 0x37, 0xd4,
-	0x27, 0xc4, 0x13, 0xc4, 0x89, 0x0f, 0x0d, 0x70, 0x71, 0x0c, 0x71, 0x15, 0x60, 0x14, 0x32, 0xe0,
-	0xd2, 0xc1, 0xaf, 0x27, 0xdc, 0x33, 0xc4, 0xc3, 0x25, 0xc8, 0x31, 0x3c, 0xde, 0xc9, 0x31, 0xc4,
-	0xd9, 0x43, 0x80, 0x49, 0x8a, 0xa3, 0x63, 0xb1, 0x1c, 0xc3, 0x8a, 0x25, 0x72, 0x0c, 0x4e, 0xfe,
-	0x27, 0x1e, 0xc9, 0x31, 0x5e, 0x78, 0x24, 0xc7, 0xf8, 0xe0, 0x91, 0x1c, 0xe3, 0x84, 0xc7, 0x72,
-	0x0c, 0x17, 0x1e, 0xcb, 0x31, 0xdc, 0x78, 0x2c, 0xc7, 0x10, 0x65, 0x9a, 0x9e, 0x59, 0x92, 0x51,
-	0x9a, 0xa4, 0x97, 0x9c, 0x9f, 0xab, 0x9f, 0x9c, 0x5f, 0x9c, 0x9b, 0x5f, 0xac, 0x9f, 0x5e, 0x94,
-	0x58, 0x96, 0x59, 0x52, 0xa9, 0x9b, 0x54, 0x94, 0x99, 0x92, 0x9e, 0xaa, 0x9f, 0x9b, 0x9f, 0x52,
-	0x9a, 0x93, 0xaa, 0x5f, 0xa1, 0x0f, 0x09, 0x9b, 0x92, 0xca, 0x82, 0xd4, 0xe2, 0x24, 0x36, 0xb0,
-	0x7f, 0x8d, 0x01, 0x01, 0x00, 0x00, 0xff, 0xff, 0x93, 0xb9, 0x8c, 0xf7, 0x31, 0x01, 0x00, 0x00,
+var fileDescriptor_005a3d0c6f36c26c = []byte{
+	// 270 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0x48, 0x2f, 0x4a, 0x2c,
+	0xcb, 0x2c, 0xa9, 0xd4, 0x2f, 0x33, 0xd4, 0x4f, 0x2d, 0xc9, 0x48, 0x2d, 0x4a, 0x2d, 0xcd, 0x8d,
+	0x2f, 0xce, 0x4c, 0xcf, 0x4b, 0x2d, 0xd2, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x82, 0xaa,
+	0xd0, 0x2b, 0x33, 0x94, 0x12, 0x49, 0xcf, 0x4f, 0xcf, 0x07, 0x0b, 0xeb, 0x83, 0x58, 0x10, 0x15,
+	0x5a, 0x53, 0x19, 0xb9, 0x38, 0x82, 0x33, 0xd3, 0xf3, 0x42, 0x2a, 0x0b, 0x52, 0x85, 0x24, 0xb9,
+	0x44, 0x83, 0x3d, 0xdd, 0xfd, 0xe2, 0x43, 0x22, 0x03, 0x5c, 0xe3, 0x43, 0xfd, 0x82, 0x03, 0x5c,
+	0x9d, 0x3d, 0xdd, 0x3c, 0x5d, 0x5d, 0x04, 0x18, 0x84, 0x8c, 0xb8, 0xf4, 0x10, 0x52, 0xfe, 0x41,
+	0xce, 0x1e, 0xae, 0xc1, 0x21, 0x41, 0x8e, 0x21, 0xfe, 0x41, 0xf1, 0x20, 0x61, 0x57, 0x97, 0x78,
+	0xdf, 0x50, 0x9f, 0x10, 0x4f, 0x10, 0x27, 0x3e, 0x34, 0xc0, 0xc5, 0x31, 0xc4, 0x55, 0x80, 0x51,
+	0xc8, 0x80, 0x4b, 0x07, 0xbf, 0x9e, 0x70, 0xcf, 0x10, 0x0f, 0x97, 0x20, 0xc7, 0xf0, 0x78, 0x27,
+	0xc7, 0x10, 0x67, 0x0f, 0x01, 0x26, 0x29, 0x8e, 0x8e, 0xc5, 0x72, 0x0c, 0x2b, 0x96, 0xc8, 0x31,
+	0x38, 0xf9, 0x9f, 0x78, 0x24, 0xc7, 0x78, 0xe1, 0x91, 0x1c, 0xe3, 0x83, 0x47, 0x72, 0x8c, 0x13,
+	0x1e, 0xcb, 0x31, 0x5c, 0x78, 0x2c, 0xc7, 0x70, 0xe3, 0xb1, 0x1c, 0x43, 0x94, 0x69, 0x7a, 0x66,
+	0x49, 0x46, 0x69, 0x92, 0x5e, 0x72, 0x7e, 0xae, 0x7e, 0x72, 0x7e, 0x71, 0x6e, 0x7e, 0xb1, 0x3e,
+	0xd4, 0x97, 0xba, 0x49, 0x45, 0x99, 0x29, 0xe9, 0xa9, 0xfa, 0xb9, 0xf9, 0x29, 0xa5, 0x39, 0xa9,
+	0xfa, 0x15, 0xfa, 0x05, 0xa9, 0xe9, 0xe9, 0x95, 0xfa, 0x25, 0x95, 0x05, 0xa9, 0xc5, 0x49, 0x6c,
+	0x60, 0xff, 0x1a, 0x03, 0x06, 0x00, 0x57, 0xd5, 0x68, 0x99, 0x35, 0x01, 0x00, 0x00,
 }
@@ -61,6 +61,17 @@ func TestValsetConfirmSig(t *testing.T) {
 			srcETHAddr:   ethAddress,
 			expErr:       true,
 		},
+		"signature too long": {
+			srcHash:      hash,
+			srcSignature: correctSig + "00",
+			srcETHAddr:   ethAddress,
+			expErr:       true,
+		},
+		"lower case eth address": {
+			srcHash:      hash,
+			srcSignature: correctSig,
+			srcETHAddr:   "0xc783df8a850f42e7f7e57013759c285caa701eb6",
+		},
 		"empty eth address": {
 			srcHash:      hash,
 			srcSignature: correctSig,
@@ -88,3 +99,87 @@ func TestValsetConfirmSig(t *testing.T) {
 		})
 	}
 }
+
+func TestEthAddressFromSignature(t *testing.T) {
+	const (
+		// signature of the TestValsetConfirmHash checkpoint by the first member
+		correctSig = "e108a7776de6b87183b0690484a74daef44aa6daf907e91abaf7bbfa426ae7706b12e0bd44ef7b0634710d99c2d81087a2f39e075158212343a3b2948ecf33d01c"
+		hash       = "88165860d955aee7dc3e83d9d1156a5864b708841965585d206dbef6e9e1a499"
+	)
+	hashBytes, err := hex.DecodeString(hash)
+	require.NoError(t, err)
+	sigBytes, err := hex.DecodeString(correctSig)
+	require.NoError(t, err)
+
+	addr, err := EthAddressFromSignature(hashBytes, sigBytes)
+	require.NoError(t, err)
+	assert.Equal(t, "0xc783df8a850f42e7F7e57013759C285caa701eB6", addr)
+
+	// any other hash recovers to another signer
+	addr, err = EthAddressFromSignature(append([]byte{0x1}, hashBytes[1:]...), sigBytes)
+	require.NoError(t, err)
+	assert.NotEqual(t, "0xc783df8a850f42e7F7e57013759C285caa701eB6", addr)
+
+	_, err = EthAddressFromSignature(hashBytes, sigBytes[:64])
+	assert.Error(t, err)
+}
+
+func TestValidateEthSig(t *testing.T) {
+	const (
+		correctSig = "e108a7776de6b87183b0690484a74daef44aa6daf907e91abaf7bbfa426ae7706b12e0bd44ef7b0634710d99c2d81087a2f39e075158212343a3b2948ecf33d01c"
+		ethAddress = "0xc783df8a850f42e7F7e57013759C285caa701eB6"
+		hash       = "88165860d955aee7dc3e83d9d1156a5864b708841965585d206dbef6e9e1a499"
+	)
+
+	specs := map[string]struct {
+		srcHash      string
+		srcSignature string
+		srcETHAddr   string
+		expErr       bool
+	}{
+		"all good": {
+			srcHash:      hash,
+			srcSignature: correctSig,
+			srcETHAddr:   ethAddress,
+		},
+		"hash too short": {
+			srcHash:      hash[:62],
+			srcSignature: correctSig,
+			srcETHAddr:   ethAddress,
+			expErr:       true,
+		},
+		"other signer": {
+			srcHash:      hash,
+			srcSignature: correctSig,
+			srcETHAddr:   "0xeAD9C93b79Ae7C1591b1FB5323BD777E86e150d4",
+			expErr:       true,
+		},
+		"eth address without prefix": {
+			srcHash:      hash,
+			srcSignature: correctSig,
+			srcETHAddr:   ethAddress[2:],
+			expErr:       true,
+		},
+		"invalid signature": {
+			srcHash:      hash,
+			srcSignature: "ff" + correctSig[2:],
+			srcETHAddr:   ethAddress,
+			expErr:       true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			hashBytes, err := hex.DecodeString(spec.srcHash)
+			require.NoError(t, err)
+			sigBytes, err := hex.DecodeString(spec.srcSignature)
+			require.NoError(t, err)
+
+			err = ValidateEthSig(hashBytes, sigBytes, spec.srcETHAddr)
+			if spec.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeggyDenom(t *testing.T) {
+	const tokenContract = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+	denom := PeggyDenom(tokenContract)
+	assert.Equal(t, "gravity"+tokenContract, denom)
+	require.Len(t, denom, PeggyDenomLen)
+
+	got, err := PeggyDenomToERC20(denom)
+	require.NoError(t, err)
+	assert.Equal(t, tokenContract, got)
+
+	specs := map[string]string{
+		"legacy prefix":    "peggy" + tokenContract,
+		"no prefix":        tokenContract,
+		"invalid contract": "gravity0xinvalid",
+		"cosmos denom":     "uatom",
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			_, err := PeggyDenomToERC20(spec)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestMigrateLegacyPeggyDenom(t *testing.T) {
+	const tokenContract = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+	assert.Equal(t, PeggyDenom(tokenContract), MigrateLegacyPeggyDenom("peggy"+tokenContract))
+	assert.Equal(t, PeggyDenom(tokenContract), MigrateLegacyPeggyDenom(PeggyDenom(tokenContract)))
+	assert.Equal(t, "uatom", MigrateLegacyPeggyDenom("uatom"))
+	assert.Equal(t, "peggy0xinvalid", MigrateLegacyPeggyDenom("peggy0xinvalid"))
+
+	metadata := PeggyDenomMetadata(tokenContract)
+	require.NoError(t, metadata.Validate())
+	assert.Equal(t, PeggyDenom(tokenContract), metadata.Base)
+}
+
+func TestERC20TokenCoinConversion(t *testing.T) {
+	const tokenContract = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+	specs := map[string]struct {
+		erc20Decimals, denomDecimals uint32
+		erc20Amount, coinAmount      int64
+		erc20Remainder               int64
+	}{
+		"same decimals":          {erc20Decimals: 6, denomDecimals: 6, erc20Amount: 1234567, coinAmount: 1234567},
+		"more ERC20 decimals":    {erc20Decimals: 18, denomDecimals: 6, erc20Amount: 1500000000000, coinAmount: 1, erc20Remainder: 500000000000},
+		"fewer ERC20 decimals":   {erc20Decimals: 2, denomDecimals: 6, erc20Amount: 12, coinAmount: 120000},
+		"ERC20 remainder":        {erc20Decimals: 8, denomDecimals: 6, erc20Amount: 1234, coinAmount: 12, erc20Remainder: 34},
+		"no decimals on cosmos":  {erc20Decimals: 3, denomDecimals: 0, erc20Amount: 5000, coinAmount: 5},
+		"no decimals on the ERC": {erc20Decimals: 0, denomDecimals: 3, erc20Amount: 5, coinAmount: 5000},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			token := NewERC20Token(uint64(spec.erc20Amount), tokenContract)
+			coin, remainder := token.ToCoin("ustake", spec.erc20Decimals, spec.denomDecimals)
+			assert.Equal(t, sdk.NewInt64Coin("ustake", spec.coinAmount), coin)
+			assert.Equal(t, sdk.NewInt(spec.erc20Remainder), remainder)
+
+			back, err := NewERC20TokenFromCoin(coin, tokenContract, spec.erc20Decimals, spec.denomDecimals)
+			require.NoError(t, err)
+			assert.Equal(t, sdk.NewInt(spec.erc20Amount-spec.erc20Remainder), back.Amount)
+			assert.Equal(t, tokenContract, back.Contract)
+		})
+	}
+
+	// a coin with more precision than the ERC20 can't be bridged, its dust has to be taken off first
+	coin := sdk.NewInt64Coin("ustake", 1234567)
+	_, err := NewERC20TokenFromCoin(coin, tokenContract, 2, 6)
+	assert.ErrorIs(t, err, ErrInvalid)
+	dust := ERC20Dust(coin, 2, 6)
+	assert.Equal(t, sdk.NewInt64Coin("ustake", 4567), dust)
+	token, err := NewERC20TokenFromCoin(coin.Sub(dust), tokenContract, 2, 6)
+	require.NoError(t, err)
+	assert.Equal(t, sdk.NewInt(123), token.Amount)
+	assert.True(t, ERC20Dust(coin, 18, 6).IsZero())
+}
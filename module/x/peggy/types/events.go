@@ -9,20 +9,62 @@ const (
 	EventTypeBridgeWithdrawalReceived  = "withdrawal_received"
 	EventTypeBridgeDepositReceived     = "deposit_received"
 	EventTypeBridgeWithdrawCanceled    = "withdraw_canceled"
+	EventTypeBridgeWithdrawExpired     = "withdraw_expired"
+	EventTypeBridgeWithdrawScheduled   = "withdraw_scheduled"
+	EventTypeReclaimableDeposit        = "reclaimable_deposit"
+	EventTypeAttestationTimeout        = "attestation_timeout"
+	EventTypeValsetNonceSkipped        = "valset_nonce_skipped"
+	EventTypeValsetNonceAbandoned      = "valset_nonce_abandoned"
+	EventTypeBatchConfirm              = "batch_confirm"
+	EventTypeObservedEventNonceSet     = "observed_event_nonce_set"
+	EventTypeDepositDiscrepancy        = "deposit_discrepancy"
+	EventTypeModuleResidueSwept        = "module_residue_swept"
+	EventTypeTokenPaused               = "token_paused"
+	EventTypeTokenUnpaused             = "token_unpaused"
+	EventTypeSlashedFundsToRelayers    = "slashed_funds_to_relayers"
+	EventTypeIBCSendToEth              = "ibc_send_to_eth"
+	EventTypeIBCSendToEthFailed        = "ibc_send_to_eth_failed"
+	EventTypeOrchestratorRevoked       = "orchestrator_revoked"
+	EventTypeERC20DeploymentRejected   = "erc20_deployment_rejected"
+	EventTypeCosmosOriginatedERC20Set  = "cosmos_originated_erc20_set"
+	EventTypeObservedNonceLag          = "observed_nonce_lag"
+	EventTypeBridgeContractRotated     = "bridge_contract_rotated"
 
-	AttributeKeyAttestationID     = "attestation_id"
-	AttributeKeyBatchConfirmKey   = "batch_confirm_key"
-	AttributeKeyValsetConfirmKey  = "valset_confirm_key"
-	AttributeKeyMultisigID        = "multisig_id"
-	AttributeKeyOutgoingBatchID   = "batch_id"
-	AttributeKeyOutgoingTXID      = "outgoing_tx_id"
-	AttributeKeyAttestationType   = "attestation_type"
-	AttributeKeyContract          = "bridge_contract"
-	AttributeKeyNonce             = "nonce"
-	AttributeKeyValsetNonce       = "valset_nonce"
-	AttributeKeyBatchNonce        = "batch_nonce"
-	AttributeKeyBridgeChainID     = "bridge_chain_id"
-	AttributeKeySetOperatorAddr   = "set_operator_address"
-	AttributeKeyInvalidationID    = "logic_call_invalidation_id"
-	AttributeKeyInvalidationNonce = "logic_call_invalidation_nonce"
+	AttributeKeyAttestationID       = "attestation_id"
+	AttributeKeyBatchConfirmKey     = "batch_confirm_key"
+	AttributeKeyValsetConfirmKey    = "valset_confirm_key"
+	AttributeKeyMultisigID          = "multisig_id"
+	AttributeKeyOutgoingBatchID     = "batch_id"
+	AttributeKeyOutgoingTXID        = "outgoing_tx_id"
+	AttributeKeyAttestationType     = "attestation_type"
+	AttributeKeyContract            = "bridge_contract"
+	AttributeKeyPreviousContract    = "previous_bridge_contract"
+	AttributeKeyNonce               = "nonce"
+	AttributeKeyPreviousNonce       = "previous_nonce"
+	AttributeKeyValsetNonce         = "valset_nonce"
+	AttributeKeyBatchNonce          = "batch_nonce"
+	AttributeKeyBridgeChainID       = "bridge_chain_id"
+	AttributeKeySetOperatorAddr     = "set_operator_address"
+	AttributeKeyInvalidationID      = "logic_call_invalidation_id"
+	AttributeKeyInvalidationNonce   = "logic_call_invalidation_nonce"
+	AttributeKeyBridgeFee           = "bridge_fee"
+	AttributeKeyTokenContract       = "token_contract"
+	AttributeKeyValidator           = "validator"
+	AttributeKeyPower               = "power"
+	AttributeKeyGrantee             = "grantee"
+	AttributeKeyReceivedAmount      = "received_amount"
+	AttributeKeyModuleAccount       = "module_account"
+	AttributeKeyReceiver            = "receiver"
+	AttributeKeyEthDest             = "eth_dest"
+	AttributeKeyError               = "error"
+	AttributeKeyExecuteAfterHeight  = "execute_after_height"
+	AttributeKeyExecuteAfterTime    = "execute_after_time"
+	AttributeKeyOrchestrator        = "orchestrator"
+	AttributeKeyCosmosDenom         = "cosmos_denom"
+	AttributeKeyExistingERC20       = "existing_erc20"
+	AttributeKeyExistingDenom       = "existing_denom"
+	AttributeKeyHighestClaimedNonce = "highest_claimed_nonce"
+	AttributeKeyLastObservedNonce   = "last_observed_nonce"
+	AttributeKeyNonceLag            = "nonce_lag"
+	AttributeKeyThreshold           = "threshold"
 )
@@ -3,6 +3,7 @@ package types
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -22,8 +23,12 @@ var (
 	// AttestationVotesPowerThreshold threshold of votes power to succeed
 	AttestationVotesPowerThreshold = sdk.NewInt(66)
 
-	// ParamsStoreKeyPeggyID stores the peggy id
-	ParamsStoreKeyPeggyID = []byte("PeggyID")
+	// ParamsStoreKeyGravityID stores the gravity id
+	ParamsStoreKeyGravityID = []byte("GravityID")
+
+	// ParamsStoreKeyLegacyPeggyID is where the gravity id was stored when it
+	// was called peggy id, it is only read by the migration
+	ParamsStoreKeyLegacyPeggyID = []byte("PeggyID")
 
 	// ParamsStoreKeyContractHash stores the contract hash
 	ParamsStoreKeyContractHash = []byte("ContractHash")
@@ -70,6 +75,102 @@ var (
 	//  ParamStoreUnbondSlashingValsetsWindow stores unbond slashing valset window
 	ParamStoreUnbondSlashingValsetsWindow = []byte("UnbondSlashingValsetsWindow")
 
+	// ParamsStoreKeyEthereumHeightWindow stores the ethereum height window
+	ParamsStoreKeyEthereumHeightWindow = []byte("EthereumHeightWindow")
+
+	// ParamsStoreKeyTokenAllowlist stores the erc20 contracts allowed on the bridge
+	ParamsStoreKeyTokenAllowlist = []byte("TokenAllowlist")
+
+	// ParamsStoreKeyTokenDenylist stores the erc20 contracts denied on the bridge
+	ParamsStoreKeyTokenDenylist = []byte("TokenDenylist")
+
+	// ParamsStoreKeyBridgeFeeBasisPoints stores the share of the bridge fee sent to the community pool
+	ParamsStoreKeyBridgeFeeBasisPoints = []byte("BridgeFeeBasisPoints")
+
+	// ParamsStoreKeyBatchRequestMinFee stores the minimum total fee of a requested batch
+	ParamsStoreKeyBatchRequestMinFee = []byte("BatchRequestMinFee")
+
+	// ParamsStoreKeyBatchRequestCooldown stores the blocks between batch requests for a token contract
+	ParamsStoreKeyBatchRequestCooldown = []byte("BatchRequestCooldown")
+
+	// ParamsStoreKeyEthereumBlockConfirmations stores the ethereum blocks a claim has to be below the latest height
+	ParamsStoreKeyEthereumBlockConfirmations = []byte("EthereumBlockConfirmations")
+
+	// ParamsStoreKeyAttestationTimeout stores the blocks after which unobserved attestations are deleted
+	ParamsStoreKeyAttestationTimeout = []byte("AttestationTimeout")
+
+	// ParamsStoreKeySignatureScheme stores how the orchestrators sign checkpoints
+	ParamsStoreKeySignatureScheme = []byte("SignatureScheme")
+
+	// ParamsStoreKeyRestrictVoucherTransfers stores if vouchers can be transferred between accounts
+	ParamsStoreKeyRestrictVoucherTransfers = []byte("RestrictVoucherTransfers")
+
+	// ParamsStoreKeyEndBlockerWorkBudget stores the gas the deferrable EndBlocker work may use per block
+	ParamsStoreKeyEndBlockerWorkBudget = []byte("EndBlockerWorkBudget")
+
+	// ParamsStoreKeyRelayerAllowlist stores if relayer rewards are only paid to registered relayers
+	ParamsStoreKeyRelayerAllowlist = []byte("RelayerAllowlist")
+
+	// ParamsStoreKeyRelayerAllowlistBatchRequests stores if only registered relayers may request batches
+	ParamsStoreKeyRelayerAllowlistBatchRequests = []byte("RelayerAllowlistBatchRequests")
+
+	// ParamsStoreKeyBatchCancelGracePeriod stores the blocks after which the requester of a batch may cancel it
+	ParamsStoreKeyBatchCancelGracePeriod = []byte("BatchCancelGracePeriod")
+
+	// ParamsStoreKeyHexCosmosReceivers stores if deposits may name their receiver as a hex address
+	ParamsStoreKeyHexCosmosReceivers = []byte("HexCosmosReceivers")
+
+	// ParamsStoreKeyResidueSweepInterval stores the blocks between sweeps of module account residue
+	ParamsStoreKeyResidueSweepInterval = []byte("ResidueSweepInterval")
+
+	// ParamsStoreKeyTargetBatchGas stores the estimated Ethereum gas a batch may use
+	ParamsStoreKeyTargetBatchGas = []byte("TargetBatchGas")
+
+	// ParamsStoreKeyBatchBaseGas stores the estimated Ethereum gas of a batch without transfers
+	ParamsStoreKeyBatchBaseGas = []byte("BatchBaseGas")
+
+	// ParamsStoreKeyBatchTxGas stores the estimated Ethereum gas of a transfer in a batch
+	ParamsStoreKeyBatchTxGas = []byte("BatchTxGas")
+
+	// ParamsStoreKeyTokenBatchGas stores the estimated Ethereum gas of a transfer of specific tokens
+	ParamsStoreKeyTokenBatchGas = []byte("TokenBatchGas")
+
+	// ParamsStoreKeySlashedFundsRelayerShare stores the share of slashed tokens paid into the relayer reward pool
+	ParamsStoreKeySlashedFundsRelayerShare = []byte("SlashedFundsRelayerShare")
+
+	// ParamsStoreKeyMaxValsetSize stores the number of validators a valset holds at most
+	ParamsStoreKeyMaxValsetSize = []byte("MaxValsetSize")
+
+	// ParamsStoreKeyMinValsetPower stores the normalized power a validator needs to be a valset member
+	ParamsStoreKeyMinValsetPower = []byte("MinValsetPower")
+
+	// ParamsStoreKeyConfirmGracePeriod stores the blocks added to the signed windows before slashing
+	ParamsStoreKeyConfirmGracePeriod = []byte("ConfirmGracePeriod")
+
+	// ParamsStoreKeyMaxOrchestratorsPerValidator stores the number of orchestrators a validator may register
+	ParamsStoreKeyMaxOrchestratorsPerValidator = []byte("MaxOrchestratorsPerValidator")
+
+	// ParamsStoreKeyERC20InitCodeHash stores the hash of the init code of the ERC20s deployed by the bridge contract
+	ParamsStoreKeyERC20InitCodeHash = []byte("ERC20InitCodeHash")
+
+	// ParamsStoreKeyConfirmFeeExemptGas stores the gas limit up to which orchestrator confirms don't pay the minimum gas price
+	ParamsStoreKeyConfirmFeeExemptGas = []byte("ConfirmFeeExemptGas")
+
+	// ParamsStoreKeyBridgeNativeToken stores if the staking token can be sent to Ethereum
+	ParamsStoreKeyBridgeNativeToken = []byte("BridgeNativeToken")
+
+	// ParamsStoreKeyNativeTokenBridgeCap stores the amount of the staking token the escrow may hold at most
+	ParamsStoreKeyNativeTokenBridgeCap = []byte("NativeTokenBridgeCap")
+
+	// ParamsStoreKeyObservedNonceLagThreshold stores the lag of the observed event nonce that is reported
+	ParamsStoreKeyObservedNonceLagThreshold = []byte("ObservedNonceLagThreshold")
+
+	// ParamsStoreKeyConfirmFeeExemptTxs stores the number of fee exempt transactions a signer may send per block
+	ParamsStoreKeyConfirmFeeExemptTxs = []byte("ConfirmFeeExemptTxs")
+
+	// ParamsStoreKeyAccountActivityRetention stores the blocks the entries of the account ledgers are kept for
+	ParamsStoreKeyAccountActivityRetention = []byte("AccountActivityRetention")
+
 	// Ensure that params implements the proper interface
 	_ paramtypes.ParamSet = &Params{}
 )
@@ -77,7 +178,7 @@ var (
 // ValidateBasic validates genesis state by looping through the params and
 // calling their validation functions
 func (s GenesisState) ValidateBasic() error {
-	if err := s.Params.ValidateBasic(); err != nil {
+	if err := s.Params.Validate(); err != nil {
 		return sdkerrors.Wrap(err, "params")
 	}
 	return nil
@@ -94,7 +195,7 @@ func DefaultGenesisState() *GenesisState {
 // DefaultParams returns a copy of the default params
 func DefaultParams() *Params {
 	return &Params{
-		PeggyId:                       "defaultpeggyid",
+		GravityId:                     "defaultgravityid",
 		SignedValsetsWindow:           10000,
 		SignedBatchesWindow:           10000,
 		SignedClaimsWindow:            10000,
@@ -106,13 +207,37 @@ func DefaultParams() *Params {
 		SlashFractionClaim:            sdk.NewDec(1).Quo(sdk.NewDec(1000)),
 		SlashFractionConflictingClaim: sdk.NewDec(1).Quo(sdk.NewDec(1000)),
 		UnbondSlashingValsetsWindow:   10000,
+		EthereumHeightWindow:          5760,
+		BatchRequestMinFee:            sdk.ZeroInt(),
+		BatchRequestCooldown:          10,
+		AttestationTimeout:            20000,
+		SignatureScheme:               SignatureSchemeEIP191,
+		RestrictVoucherTransfers:      false,
+		EndBlockerWorkBudget:          0,
+		RelayerAllowlist:              false,
+		RelayerAllowlistBatchRequests: false,
+		BatchCancelGracePeriod:        10000,
+		HexCosmosReceivers:            false,
+		ResidueSweepInterval:          0,
+		TargetBatchGas:                7000000,
+		BatchBaseGas:                  500000,
+		BatchTxGas:                    65000,
+		SlashedFundsRelayerShare:      sdk.ZeroDec(),
+		MaxValsetSize:                 100,
+		MaxOrchestratorsPerValidator:  2,
+		ConfirmFeeExemptGas:           500000,
+		NativeTokenBridgeCap:          sdk.ZeroInt(),
+		ObservedNonceLagThreshold:     20,
+		ConfirmFeeExemptTxs:           5,
+		AccountActivityRetention:      1000000,
 	}
 }
 
-// ValidateBasic checks that the parameters have valid values.
-func (p Params) ValidateBasic() error {
-	if err := validatePeggyID(p.PeggyId); err != nil {
-		return sdkerrors.Wrap(err, "peggy id")
+// Validate checks that every parameter has a valid value and that the parameters
+// are consistent with each other. The complete set is validated on every update.
+func (p Params) Validate() error {
+	if err := validateGravityID(p.GravityId); err != nil {
+		return sdkerrors.Wrap(err, "gravity id")
 	}
 	if err := validateContractHash(p.ContractSourceHash); err != nil {
 		return sdkerrors.Wrap(err, "contract hash")
@@ -133,29 +258,119 @@ func (p Params) ValidateBasic() error {
 		return sdkerrors.Wrap(err, "Ethereum block time")
 	}
 	if err := validateSignedValsetsWindow(p.SignedValsetsWindow); err != nil {
-		return sdkerrors.Wrap(err, "signed blocks window")
+		return sdkerrors.Wrap(err, "signed valsets window")
 	}
 	if err := validateSignedBatchesWindow(p.SignedBatchesWindow); err != nil {
-		return sdkerrors.Wrap(err, "signed blocks window")
+		return sdkerrors.Wrap(err, "signed batches window")
 	}
 	if err := validateSignedClaimsWindow(p.SignedClaimsWindow); err != nil {
-		return sdkerrors.Wrap(err, "signed blocks window")
+		return sdkerrors.Wrap(err, "signed claims window")
 	}
 	if err := validateSlashFractionValset(p.SlashFractionValset); err != nil {
 		return sdkerrors.Wrap(err, "slash fraction valset")
 	}
 	if err := validateSlashFractionBatch(p.SlashFractionBatch); err != nil {
-		return sdkerrors.Wrap(err, "slash fraction valset")
+		return sdkerrors.Wrap(err, "slash fraction batch")
 	}
 	if err := validateSlashFractionClaim(p.SlashFractionClaim); err != nil {
-		return sdkerrors.Wrap(err, "slash fraction valset")
+		return sdkerrors.Wrap(err, "slash fraction claim")
 	}
 	if err := validateSlashFractionConflictingClaim(p.SlashFractionConflictingClaim); err != nil {
-		return sdkerrors.Wrap(err, "slash fraction valset")
+		return sdkerrors.Wrap(err, "slash fraction conflicting claim")
 	}
 	if err := validateUnbondSlashingValsetsWindow(p.UnbondSlashingValsetsWindow); err != nil {
 		return sdkerrors.Wrap(err, "unbond Slashing valset window")
 	}
+	if err := validateEthereumHeightWindow(p.EthereumHeightWindow); err != nil {
+		return sdkerrors.Wrap(err, "ethereum height window")
+	}
+	if err := validateTokenList(p.TokenAllowlist); err != nil {
+		return sdkerrors.Wrap(err, "token allowlist")
+	}
+	if err := validateTokenList(p.TokenDenylist); err != nil {
+		return sdkerrors.Wrap(err, "token denylist")
+	}
+	if err := validateBridgeFeeBasisPoints(p.BridgeFeeBasisPoints); err != nil {
+		return sdkerrors.Wrap(err, "bridge fee basis points")
+	}
+	if err := validateBatchRequestMinFee(p.BatchRequestMinFee); err != nil {
+		return sdkerrors.Wrap(err, "batch request min fee")
+	}
+	if err := validateBatchRequestCooldown(p.BatchRequestCooldown); err != nil {
+		return sdkerrors.Wrap(err, "batch request cooldown")
+	}
+	if err := validateEthereumBlockConfirmations(p.EthereumBlockConfirmations); err != nil {
+		return sdkerrors.Wrap(err, "ethereum block confirmations")
+	}
+	if err := validateAttestationTimeout(p.AttestationTimeout); err != nil {
+		return sdkerrors.Wrap(err, "attestation timeout")
+	}
+	if err := validateSignatureScheme(p.SignatureScheme); err != nil {
+		return sdkerrors.Wrap(err, "signature scheme")
+	}
+	denied := make(map[string]bool, len(p.TokenDenylist))
+	for _, token := range p.TokenDenylist {
+		denied[strings.ToLower(token)] = true
+	}
+	for _, token := range p.TokenAllowlist {
+		if denied[strings.ToLower(token)] {
+			return sdkerrors.Wrapf(ErrInvalid, "token %s is both allowed and denied", token)
+		}
+	}
+	if p.RelayerAllowlistBatchRequests && !p.RelayerAllowlist {
+		return sdkerrors.Wrap(ErrInvalid, "relayer allowlist batch requests require the relayer allowlist")
+	}
+	if err := validateTokenBatchGas(p.TokenBatchGas); err != nil {
+		return sdkerrors.Wrap(err, "token batch gas")
+	}
+	if err := validateSlashedFundsRelayerShare(p.SlashedFundsRelayerShare); err != nil {
+		return sdkerrors.Wrap(err, "slashed funds relayer share")
+	}
+	if err := validateMaxValsetSize(p.MaxValsetSize); err != nil {
+		return sdkerrors.Wrap(err, "max valset size")
+	}
+	if err := validateMinValsetPower(p.MinValsetPower); err != nil {
+		return sdkerrors.Wrap(err, "min valset power")
+	}
+	if err := validateConfirmGracePeriod(p.ConfirmGracePeriod); err != nil {
+		return sdkerrors.Wrap(err, "confirm grace period")
+	}
+	if err := validateMaxOrchestratorsPerValidator(p.MaxOrchestratorsPerValidator); err != nil {
+		return sdkerrors.Wrap(err, "max orchestrators per validator")
+	}
+	if err := validateERC20InitCodeHash(p.Erc20InitCodeHash); err != nil {
+		return sdkerrors.Wrap(err, "erc20 init code hash")
+	}
+	if err := validateConfirmFeeExemptGas(p.ConfirmFeeExemptGas); err != nil {
+		return sdkerrors.Wrap(err, "confirm fee exempt gas")
+	}
+	if err := validateBridgeNativeToken(p.BridgeNativeToken); err != nil {
+		return sdkerrors.Wrap(err, "bridge native token")
+	}
+	if err := validateNativeTokenBridgeCap(p.NativeTokenBridgeCap); err != nil {
+		return sdkerrors.Wrap(err, "native token bridge cap")
+	}
+	if err := validateObservedNonceLagThreshold(p.ObservedNonceLagThreshold); err != nil {
+		return sdkerrors.Wrap(err, "observed nonce lag threshold")
+	}
+	if err := validateConfirmFeeExemptTxs(p.ConfirmFeeExemptTxs); err != nil {
+		return sdkerrors.Wrap(err, "confirm fee exempt txs")
+	}
+	if err := validateAccountActivityRetention(p.AccountActivityRetention); err != nil {
+		return sdkerrors.Wrap(err, "account activity retention")
+	}
+	// every token must fit at least one transfer into a batch
+	if p.TargetBatchGas != 0 {
+		txGas := p.BatchTxGas
+		for _, token := range p.TokenBatchGas {
+			if token.TxGas > txGas {
+				txGas = token.TxGas
+			}
+		}
+		if p.BatchBaseGas+txGas > p.TargetBatchGas {
+			return sdkerrors.Wrapf(ErrInvalid, "target batch gas %d below the gas of a batch with one transfer %d", p.TargetBatchGas, p.BatchBaseGas+txGas)
+		}
+	}
 
 	return nil
 }
@@ -169,7 +384,7 @@ func ParamKeyTable() paramtypes.KeyTable {
 // pairs of auth module's parameters.
 func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
 	return paramtypes.ParamSetPairs{
-		paramtypes.NewParamSetPair(ParamsStoreKeyPeggyID, &p.PeggyId, validatePeggyID),
+		paramtypes.NewParamSetPair(ParamsStoreKeyGravityID, &p.GravityId, validateGravityID),
 		paramtypes.NewParamSetPair(ParamsStoreKeyContractHash, &p.ContractSourceHash, validateContractHash),
 		paramtypes.NewParamSetPair(ParamsStoreKeyBridgeContractAddress, &p.BridgeEthereumAddress, validateBridgeContractAddress),
 		paramtypes.NewParamSetPair(ParamsStoreKeyBridgeContractChainID, &p.BridgeChainId, validateBridgeChainID),
@@ -184,6 +399,38 @@ func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
 		paramtypes.NewParamSetPair(ParamsStoreSlashFractionClaim, &p.SlashFractionClaim, validateSlashFractionClaim),
 		paramtypes.NewParamSetPair(ParamsStoreSlashFractionConflictingClaim, &p.SlashFractionConflictingClaim, validateSlashFractionConflictingClaim),
 		paramtypes.NewParamSetPair(ParamStoreUnbondSlashingValsetsWindow, &p.UnbondSlashingValsetsWindow, validateUnbondSlashingValsetsWindow),
+		paramtypes.NewParamSetPair(ParamsStoreKeyEthereumHeightWindow, &p.EthereumHeightWindow, validateEthereumHeightWindow),
+		paramtypes.NewParamSetPair(ParamsStoreKeyTokenAllowlist, &p.TokenAllowlist, validateTokenList),
+		paramtypes.NewParamSetPair(ParamsStoreKeyTokenDenylist, &p.TokenDenylist, validateTokenList),
+		paramtypes.NewParamSetPair(ParamsStoreKeyBridgeFeeBasisPoints, &p.BridgeFeeBasisPoints, validateBridgeFeeBasisPoints),
+		paramtypes.NewParamSetPair(ParamsStoreKeyBatchRequestMinFee, &p.BatchRequestMinFee, validateBatchRequestMinFee),
+		paramtypes.NewParamSetPair(ParamsStoreKeyBatchRequestCooldown, &p.BatchRequestCooldown, validateBatchRequestCooldown),
+		paramtypes.NewParamSetPair(ParamsStoreKeyEthereumBlockConfirmations, &p.EthereumBlockConfirmations, validateEthereumBlockConfirmations),
+		paramtypes.NewParamSetPair(ParamsStoreKeyAttestationTimeout, &p.AttestationTimeout, validateAttestationTimeout),
+		paramtypes.NewParamSetPair(ParamsStoreKeySignatureScheme, &p.SignatureScheme, validateSignatureScheme),
+		paramtypes.NewParamSetPair(ParamsStoreKeyRestrictVoucherTransfers, &p.RestrictVoucherTransfers, validateRestrictVoucherTransfers),
+		paramtypes.NewParamSetPair(ParamsStoreKeyEndBlockerWorkBudget, &p.EndBlockerWorkBudget, validateEndBlockerWorkBudget),
+		paramtypes.NewParamSetPair(ParamsStoreKeyRelayerAllowlist, &p.RelayerAllowlist, validateRelayerAllowlist),
+		paramtypes.NewParamSetPair(ParamsStoreKeyRelayerAllowlistBatchRequests, &p.RelayerAllowlistBatchRequests, validateRelayerAllowlist),
+		paramtypes.NewParamSetPair(ParamsStoreKeyBatchCancelGracePeriod, &p.BatchCancelGracePeriod, validateBatchCancelGracePeriod),
+		paramtypes.NewParamSetPair(ParamsStoreKeyHexCosmosReceivers, &p.HexCosmosReceivers, validateHexCosmosReceivers),
+		paramtypes.NewParamSetPair(ParamsStoreKeyResidueSweepInterval, &p.ResidueSweepInterval, validateResidueSweepInterval),
+		paramtypes.NewParamSetPair(ParamsStoreKeyTargetBatchGas, &p.TargetBatchGas, validateBatchGas),
+		paramtypes.NewParamSetPair(ParamsStoreKeyBatchBaseGas, &p.BatchBaseGas, validateBatchGas),
+		paramtypes.NewParamSetPair(ParamsStoreKeyBatchTxGas, &p.BatchTxGas, validateBatchGas),
+		paramtypes.NewParamSetPair(ParamsStoreKeyTokenBatchGas, &p.TokenBatchGas, validateTokenBatchGas),
+		paramtypes.NewParamSetPair(ParamsStoreKeySlashedFundsRelayerShare, &p.SlashedFundsRelayerShare, validateSlashedFundsRelayerShare),
+		paramtypes.NewParamSetPair(ParamsStoreKeyMaxValsetSize, &p.MaxValsetSize, validateMaxValsetSize),
+		paramtypes.NewParamSetPair(ParamsStoreKeyMinValsetPower, &p.MinValsetPower, validateMinValsetPower),
+		paramtypes.NewParamSetPair(ParamsStoreKeyConfirmGracePeriod, &p.ConfirmGracePeriod, validateConfirmGracePeriod),
+		paramtypes.NewParamSetPair(ParamsStoreKeyMaxOrchestratorsPerValidator, &p.MaxOrchestratorsPerValidator, validateMaxOrchestratorsPerValidator),
+		paramtypes.NewParamSetPair(ParamsStoreKeyERC20InitCodeHash, &p.Erc20InitCodeHash, validateERC20InitCodeHash),
+		paramtypes.NewParamSetPair(ParamsStoreKeyConfirmFeeExemptGas, &p.ConfirmFeeExemptGas, validateConfirmFeeExemptGas),
+		paramtypes.NewParamSetPair(ParamsStoreKeyBridgeNativeToken, &p.BridgeNativeToken, validateBridgeNativeToken),
+		paramtypes.NewParamSetPair(ParamsStoreKeyNativeTokenBridgeCap, &p.NativeTokenBridgeCap, validateNativeTokenBridgeCap),
+		paramtypes.NewParamSetPair(ParamsStoreKeyObservedNonceLagThreshold, &p.ObservedNonceLagThreshold, validateObservedNonceLagThreshold),
+		paramtypes.NewParamSetPair(ParamsStoreKeyConfirmFeeExemptTxs, &p.ConfirmFeeExemptTxs, validateConfirmFeeExemptTxs),
+		paramtypes.NewParamSetPair(ParamsStoreKeyAccountActivityRetention, &p.AccountActivityRetention, validateAccountActivityRetention),
 	}
 }
 
@@ -194,11 +441,36 @@ func (p Params) Equal(p2 Params) bool {
 	return bytes.Equal(bz1, bz2)
 }
 
-func validatePeggyID(i interface{}) error {
+// ValsetSlashingWindow returns the blocks after the creation of a valset in which its confirms can
+// be submitted before the missing ones are slashed
+func (p Params) ValsetSlashingWindow() uint64 {
+	return p.SignedValsetsWindow + p.ConfirmGracePeriod
+}
+
+// BatchSlashingWindow returns the blocks after the creation of a batch in which its confirms can be
+// submitted before the missing ones are slashed
+func (p Params) BatchSlashingWindow() uint64 {
+	return p.SignedBatchesWindow + p.ConfirmGracePeriod
+}
+
+// OrchestratorsPerValidator returns the number of orchestrators a validator may register, chains
+// that upgraded without setting the param allow a single one
+func (p Params) OrchestratorsPerValidator() uint64 {
+	if p.MaxOrchestratorsPerValidator == 0 {
+		return 1
+	}
+	return p.MaxOrchestratorsPerValidator
+}
+
+func validateGravityID(i interface{}) error {
 	v, ok := i.(string)
 	if !ok {
 		return fmt.Errorf("invalid parameter type: %T", i)
 	}
+	// an empty id is the same on every chain, signatures made for one could be replayed on another
+	if len(v) == 0 {
+		return fmt.Errorf("gravity id cannot be empty")
+	}
 	if _, err := strToFixByteArray(v); err != nil {
 		return err
 	}
@@ -256,74 +528,269 @@ func validateBridgeContractAddress(i interface{}) error {
 	if !ok {
 		return fmt.Errorf("invalid parameter type: %T", i)
 	}
-	if err := ValidateEthAddress(v); err != nil {
-		// TODO: ensure that empty addresses are valid in params
-		if !strings.Contains(err.Error(), "empty") {
+	// the address is empty until the contract is deployed, claims are rejected until then
+	if v == "" {
+		return nil
+	}
+	return ValidateChecksumEthAddress(v)
+}
+
+func validateSignedValsetsWindow(i interface{}) error {
+	return validateWindow(i)
+}
+
+func validateUnbondSlashingValsetsWindow(i interface{}) error {
+	return validateWindow(i)
+}
+
+func validateEthereumHeightWindow(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateTokenList(i interface{}) error {
+	v, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	seen := make(map[string]bool, len(v))
+	for _, token := range v {
+		if err := ValidateChecksumEthAddress(token); err != nil {
 			return err
 		}
+		if seen[strings.ToLower(token)] {
+			return fmt.Errorf("duplicate token %s", token)
+		}
+		seen[strings.ToLower(token)] = true
 	}
 	return nil
 }
 
-func validateSignedValsetsWindow(i interface{}) error {
-	// TODO: do we want to set some bounds on this value?
+func validateBridgeFeeBasisPoints(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if v > 10000 {
+		return fmt.Errorf("bridge fee basis points can't exceed 10000: %d", v)
+	}
+	return nil
+}
+
+func validateBatchRequestMinFee(i interface{}) error {
+	v, ok := i.(sdk.Int)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if v.IsNil() || v.IsNegative() {
+		return fmt.Errorf("batch request min fee must not be negative: %s", v)
+	}
+	return nil
+}
+
+func validateBatchRequestCooldown(i interface{}) error {
 	if _, ok := i.(uint64); !ok {
 		return fmt.Errorf("invalid parameter type: %T", i)
 	}
 	return nil
 }
 
-func validateUnbondSlashingValsetsWindow(i interface{}) error {
-	// TODO: do we want to set some bounds on this value?
+func validateEthereumBlockConfirmations(i interface{}) error {
 	if _, ok := i.(uint64); !ok {
 		return fmt.Errorf("invalid parameter type: %T", i)
 	}
 	return nil
 }
 
-func validateSlashFractionValset(i interface{}) error {
-	// TODO: do we want to set some bounds on this value?
-	if _, ok := i.(sdk.Dec); !ok {
+func validateAttestationTimeout(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
 		return fmt.Errorf("invalid parameter type: %T", i)
 	}
 	return nil
 }
 
-func validateSignedBatchesWindow(i interface{}) error {
-	// TODO: do we want to set some bounds on this value?
+func validateSignatureScheme(i interface{}) error {
+	v, ok := i.(string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	// an empty scheme is EIP-191
+	if v != "" && v != SignatureSchemeEIP191 && v != SignatureSchemeEIP712 {
+		return fmt.Errorf("unknown signature scheme %q, expected %s or %s", v, SignatureSchemeEIP191, SignatureSchemeEIP712)
+	}
+	return nil
+}
+
+func validateRestrictVoucherTransfers(i interface{}) error {
+	if _, ok := i.(bool); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateEndBlockerWorkBudget(i interface{}) error {
 	if _, ok := i.(uint64); !ok {
 		return fmt.Errorf("invalid parameter type: %T", i)
 	}
 	return nil
 }
 
+func validateRelayerAllowlist(i interface{}) error {
+	if _, ok := i.(bool); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateSlashFractionValset(i interface{}) error {
+	return validateSlashFraction(i)
+}
+
+func validateSignedBatchesWindow(i interface{}) error {
+	return validateWindow(i)
+}
+
 func validateSignedClaimsWindow(i interface{}) error {
-	// TODO: do we want to set some bounds on this value?
+	return validateWindow(i)
+}
+
+func validateSlashFractionBatch(i interface{}) error {
+	return validateSlashFraction(i)
+}
+
+func validateSlashFractionClaim(i interface{}) error {
+	return validateSlashFraction(i)
+}
+
+func validateSlashFractionConflictingClaim(i interface{}) error {
+	return validateSlashFraction(i)
+}
+
+// validateWindow requires a window of at least one block, a zero window would slash
+// validators for confirms they had no chance to submit
+func validateWindow(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if v == 0 {
+		return fmt.Errorf("window must be positive")
+	}
+	return nil
+}
+
+// validateSlashFraction requires a fraction in [0, 1)
+func validateSlashFraction(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if v.IsNil() || v.IsNegative() || v.GTE(sdk.OneDec()) {
+		return fmt.Errorf("slash fraction must be in [0, 1): %s", v)
+	}
+	return nil
+}
+
+func validateSlashedFundsRelayerShare(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if v.IsNil() || v.IsNegative() || v.GT(sdk.OneDec()) {
+		return fmt.Errorf("share must be in [0, 1]: %s", v)
+	}
+	return nil
+}
+
+func validateMaxValsetSize(i interface{}) error {
 	if _, ok := i.(uint64); !ok {
 		return fmt.Errorf("invalid parameter type: %T", i)
 	}
 	return nil
 }
 
-func validateSlashFractionBatch(i interface{}) error {
-	// TODO: do we want to set some bounds on this value?
-	if _, ok := i.(sdk.Dec); !ok {
+func validateMinValsetPower(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
 		return fmt.Errorf("invalid parameter type: %T", i)
 	}
+	// the members left out may hold no more than the power the signatures of a checkpoint can do without
+	if v > math.MaxUint32-BridgePowerThreshold {
+		return fmt.Errorf("min valset power above the power outside of the bridge threshold: %d", v)
+	}
 	return nil
 }
 
-func validateSlashFractionClaim(i interface{}) error {
-	// TODO: do we want to set some bounds on this value?
-	if _, ok := i.(sdk.Dec); !ok {
+func validateConfirmGracePeriod(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
 		return fmt.Errorf("invalid parameter type: %T", i)
 	}
 	return nil
 }
 
-func validateSlashFractionConflictingClaim(i interface{}) error {
-	// TODO: do we want to set some bounds on this value?
-	if _, ok := i.(sdk.Dec); !ok {
+func validateMaxOrchestratorsPerValidator(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateERC20InitCodeHash(i interface{}) error {
+	v, ok := i.(string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	// empty leaves the ERC20 addresses unpredicted
+	if v == "" {
+		return nil
+	}
+	_, err := decodeInitCodeHash(v)
+	return err
+}
+
+func validateConfirmFeeExemptGas(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateBridgeNativeToken(i interface{}) error {
+	if _, ok := i.(bool); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateNativeTokenBridgeCap(i interface{}) error {
+	v, ok := i.(sdk.Int)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	if v.IsNil() || v.IsNegative() {
+		return fmt.Errorf("native token bridge cap must not be negative: %s", v)
+	}
+	return nil
+}
+
+func validateObservedNonceLagThreshold(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateConfirmFeeExemptTxs(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateAccountActivityRetention(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
 		return fmt.Errorf("invalid parameter type: %T", i)
 	}
 	return nil
@@ -337,3 +804,52 @@ func strToFixByteArray(s string) ([32]byte, error) {
 	copy(out[:], s)
 	return out, nil
 }
+
+func validateBatchCancelGracePeriod(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateHexCosmosReceivers(i interface{}) error {
+	if _, ok := i.(bool); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateResidueSweepInterval(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateBatchGas(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
+
+func validateTokenBatchGas(i interface{}) error {
+	v, ok := i.([]TokenBatchGas)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	seen := make(map[string]bool, len(v))
+	for _, token := range v {
+		if err := ValidateChecksumEthAddress(token.TokenContract); err != nil {
+			return err
+		}
+		if seen[strings.ToLower(token.TokenContract)] {
+			return fmt.Errorf("duplicate token %s", token.TokenContract)
+		}
+		seen[strings.ToLower(token.TokenContract)] = true
+		if token.TxGas == 0 {
+			return fmt.Errorf("zero gas for token %s", token.TokenContract)
+		}
+	}
+	return nil
+}
@@ -1,5 +1,5 @@
 // Code generated by protoc-gen-gogo. DO NOT EDIT.
-// source: peggy/v1/genesis.proto
+// source: gravity/v1/genesis.proto
 
 package types
 
@@ -24,96 +24,57 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
-// Params represent the peggy genesis and store parameters
-// peggy_id:
-// a random 32 byte value to prevent signature reuse, for example if the
-// cosmos validators decided to use the same Ethereum keys for another chain
-// also running Peggy we would not want it to be possible to play a deposit
-// from chain A back on chain B's peggy. This value IS USED ON ETHEREUM so
-// it must be set in your genesis.json before launch and not changed after
-// deploying Peggy
-//
-// contract_hash:
-// the code hash of a known good version of the Peggy contract
-// solidity code. This can be used to verify the correct version
-// of the contract has been deployed. This is a reference value for
-// goernance action only it is never read by any Peggy code
-//
-// bridge_ethereum_address:
-// is address of the bridge contract on the Ethereum side, this is a
-// reference value for governance only and is not actually used by any
-// Peggy code
-//
-// bridge_chain_id:
-// the unique identifier of the Ethereum chain, this is a reference value
-// only and is not actually used by any Peggy code
-//
-// These reference values may be used by future Peggy client implemetnations
-// to allow for saftey features or convenience features like the peggy address
-// in your relayer. A relayer would require a configured peggy address if
-// governance had not set the address on the chain it was relaying for.
-//
-// signed_valsets_window
-// signed_batches_window
-// signed_claims_window
-//
-// These values represent the time in blocks that a validator has to submit
-// a signature for a batch or valset, or to submit a claim for a particular
-// attestation nonce. In the case of attestations this clock starts when the
-// attestation is created, but only allows for slashing once the event has passed
-//
-// target_batch_timeout:
-//
-// This is the 'target' value for when batches time out, this is a target becuase
-// Ethereum is a probabalistic chain and you can't say for sure what the block
-// frequency is ahead of time.
-//
-// average_block_time
-// average_ethereum_block_time
-//
-// These values are the average Cosmos block time and Ethereum block time repsectively
-// and they are used to copute what the target batch timeout is. It is important that
-// governance updates these in case of any major, prolonged change in the time it takes
-// to produce a block
-//
-// slash_fraction_valset
-// slash_fraction_batch
-// slash_fraction_claim
-// slash_fraction_conflicting_claim
-//
-// The slashing fractions for the various peggy related slashing conditions. The first three
-// refer to not submitting a particular message, the third for submitting a different claim
-// for the same Ethereum event
-type Params struct {
-	PeggyId                       string                                 `protobuf:"bytes,1,opt,name=peggy_id,json=peggyId,proto3" json:"peggy_id,omitempty"`
-	ContractSourceHash            string                                 `protobuf:"bytes,2,opt,name=contract_source_hash,json=contractSourceHash,proto3" json:"contract_source_hash,omitempty"`
-	BridgeEthereumAddress         string                                 `protobuf:"bytes,4,opt,name=bridge_ethereum_address,json=bridgeEthereumAddress,proto3" json:"bridge_ethereum_address,omitempty"`
-	BridgeChainId                 uint64                                 `protobuf:"varint,5,opt,name=bridge_chain_id,json=bridgeChainId,proto3" json:"bridge_chain_id,omitempty"`
-	SignedValsetsWindow           uint64                                 `protobuf:"varint,6,opt,name=signed_valsets_window,json=signedValsetsWindow,proto3" json:"signed_valsets_window,omitempty"`
-	SignedBatchesWindow           uint64                                 `protobuf:"varint,7,opt,name=signed_batches_window,json=signedBatchesWindow,proto3" json:"signed_batches_window,omitempty"`
-	SignedClaimsWindow            uint64                                 `protobuf:"varint,8,opt,name=signed_claims_window,json=signedClaimsWindow,proto3" json:"signed_claims_window,omitempty"`
-	TargetBatchTimeout            uint64                                 `protobuf:"varint,10,opt,name=target_batch_timeout,json=targetBatchTimeout,proto3" json:"target_batch_timeout,omitempty"`
-	AverageBlockTime              uint64                                 `protobuf:"varint,11,opt,name=average_block_time,json=averageBlockTime,proto3" json:"average_block_time,omitempty"`
-	AverageEthereumBlockTime      uint64                                 `protobuf:"varint,12,opt,name=average_ethereum_block_time,json=averageEthereumBlockTime,proto3" json:"average_ethereum_block_time,omitempty"`
-	SlashFractionValset           github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,13,opt,name=slash_fraction_valset,json=slashFractionValset,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"slash_fraction_valset"`
-	SlashFractionBatch            github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,14,opt,name=slash_fraction_batch,json=slashFractionBatch,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"slash_fraction_batch"`
-	SlashFractionClaim            github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,15,opt,name=slash_fraction_claim,json=slashFractionClaim,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"slash_fraction_claim"`
-	SlashFractionConflictingClaim github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,16,opt,name=slash_fraction_conflicting_claim,json=slashFractionConflictingClaim,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"slash_fraction_conflicting_claim"`
-	UnbondSlashingValsetsWindow   uint64                                 `protobuf:"varint,17,opt,name=unbond_slashing_valsets_window,json=unbondSlashingValsetsWindow,proto3" json:"unbond_slashing_valsets_window,omitempty"`
+// GenesisState struct
+type GenesisState struct {
+	Params                     *Params                         `protobuf:"bytes,1,opt,name=params,proto3" json:"params,omitempty"`
+	LastObservedNonce          uint64                          `protobuf:"varint,2,opt,name=last_observed_nonce,json=lastObservedNonce,proto3" json:"last_observed_nonce,omitempty"`
+	Valsets                    []*Valset                       `protobuf:"bytes,3,rep,name=valsets,proto3" json:"valsets,omitempty"`
+	ValsetConfirms             []*MsgValsetConfirm             `protobuf:"bytes,4,rep,name=valset_confirms,json=valsetConfirms,proto3" json:"valset_confirms,omitempty"`
+	Batches                    []*OutgoingTxBatch              `protobuf:"bytes,5,rep,name=batches,proto3" json:"batches,omitempty"`
+	BatchConfirms              []MsgConfirmBatch               `protobuf:"bytes,6,rep,name=batch_confirms,json=batchConfirms,proto3" json:"batch_confirms"`
+	LogicCalls                 []*OutgoingLogicCall            `protobuf:"bytes,7,rep,name=logic_calls,json=logicCalls,proto3" json:"logic_calls,omitempty"`
+	LogicCallConfirms          []MsgConfirmLogicCall           `protobuf:"bytes,8,rep,name=logic_call_confirms,json=logicCallConfirms,proto3" json:"logic_call_confirms"`
+	Attestations               []Attestation                   `protobuf:"bytes,9,rep,name=attestations,proto3" json:"attestations"`
+	DelegateKeys               []*MsgSetOrchestratorAddress    `protobuf:"bytes,10,rep,name=delegate_keys,json=delegateKeys,proto3" json:"delegate_keys,omitempty"`
+	Erc20ToDenoms              []*ERC20ToDenom                 `protobuf:"bytes,11,rep,name=erc20_to_denoms,json=erc20ToDenoms,proto3" json:"erc20_to_denoms,omitempty"`
+	UnbatchedTransfers         []*OutgoingTransferTx           `protobuf:"bytes,12,rep,name=unbatched_transfers,json=unbatchedTransfers,proto3" json:"unbatched_transfers,omitempty"`
+	ReclaimableDeposits        []ReclaimableDeposit            `protobuf:"bytes,13,rep,name=reclaimable_deposits,json=reclaimableDeposits,proto3" json:"reclaimable_deposits"`
+	ProcessedTxIds             []uint64                        `protobuf:"varint,14,rep,packed,name=processed_tx_ids,json=processedTxIds,proto3" json:"processed_tx_ids,omitempty"`
+	ObservedDeposits           []ObservedDeposit               `protobuf:"bytes,15,rep,name=observed_deposits,json=observedDeposits,proto3" json:"observed_deposits"`
+	BatchExecutions            []BatchExecution                `protobuf:"bytes,16,rep,name=batch_executions,json=batchExecutions,proto3" json:"batch_executions"`
+	LastObservedValsetNonce    uint64                          `protobuf:"varint,17,opt,name=last_observed_valset_nonce,json=lastObservedValsetNonce,proto3" json:"last_observed_valset_nonce,omitempty"`
+	SkippedValsetNonces        []uint64                        `protobuf:"varint,18,rep,packed,name=skipped_valset_nonces,json=skippedValsetNonces,proto3" json:"skipped_valset_nonces,omitempty"`
+	BridgeStats                []BridgeStats                   `protobuf:"bytes,19,rep,name=bridge_stats,json=bridgeStats,proto3" json:"bridge_stats"`
+	Relayers                   []RegisteredRelayer             `protobuf:"bytes,20,rep,name=relayers,proto3" json:"relayers"`
+	LastObservedEthereumHeight LastObservedEthereumBlockHeight `protobuf:"bytes,21,opt,name=last_observed_ethereum_height,json=lastObservedEthereumHeight,proto3" json:"last_observed_ethereum_height"`
+	CancelledBatches           []OutgoingTxBatch               `protobuf:"bytes,22,rep,name=cancelled_batches,json=cancelledBatches,proto3" json:"cancelled_batches"`
+	Grants                     []GrantAuthorization            `protobuf:"bytes,23,rep,name=grants,proto3" json:"grants"`
+	OmnibusAccounts            []string                        `protobuf:"bytes,24,rep,name=omnibus_accounts,json=omnibusAccounts,proto3" json:"omnibus_accounts,omitempty"`
+	TokenQuirks                []TokenQuirk                    `protobuf:"bytes,25,rep,name=token_quirks,json=tokenQuirks,proto3" json:"token_quirks"`
+	PausedTokens               []PausedToken                   `protobuf:"bytes,26,rep,name=paused_tokens,json=pausedTokens,proto3" json:"paused_tokens"`
+	BatchedTxs                 []BatchedTx                     `protobuf:"bytes,27,rep,name=batched_txs,json=batchedTxs,proto3" json:"batched_txs"`
+	ScheduledTransfers         []ScheduledTransfer             `protobuf:"bytes,28,rep,name=scheduled_transfers,json=scheduledTransfers,proto3" json:"scheduled_transfers"`
+	// the event nonce of the latest claim applied, it trails last_observed_nonce
+	// after the observed nonce was rewound. Zero falls back to last_observed_nonce.
+	LastAppliedEventNonce uint64            `protobuf:"varint,29,opt,name=last_applied_event_nonce,json=lastAppliedEventNonce,proto3" json:"last_applied_event_nonce,omitempty"`
+	AccountActivities     []AccountActivity `protobuf:"bytes,30,rep,name=account_activities,json=accountActivities,proto3" json:"account_activities"`
+	// the amount of the staking token escrowed for Ethereum, it is bounded by
+	// the native token bridge cap
+	NativeTokenEscrow github_com_cosmos_cosmos_sdk_types.Int `protobuf:"bytes,31,opt,name=native_token_escrow,json=nativeTokenEscrow,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Int" json:"native_token_escrow"`
 }
 
-func (m *Params) Reset()         { *m = Params{} }
-func (m *Params) String() string { return proto.CompactTextString(m) }
-func (*Params) ProtoMessage()    {}
-func (*Params) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84231c3b3f050761, []int{0}
+func (m *GenesisState) Reset()         { *m = GenesisState{} }
+func (m *GenesisState) String() string { return proto.CompactTextString(m) }
+func (*GenesisState) ProtoMessage()    {}
+func (*GenesisState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_387b0aba880adb60, []int{0}
 }
-func (m *Params) XXX_Unmarshal(b []byte) error {
+func (m *GenesisState) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Params) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GenesisState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Params.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GenesisState.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -123,297 +84,307 @@ func (m *Params) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Params) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Params.Merge(m, src)
+func (m *GenesisState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GenesisState.Merge(m, src)
 }
-func (m *Params) XXX_Size() int {
+func (m *GenesisState) XXX_Size() int {
 	return m.Size()
 }
-func (m *Params) XXX_DiscardUnknown() {
-	xxx_messageInfo_Params.DiscardUnknown(m)
+func (m *GenesisState) XXX_DiscardUnknown() {
+	xxx_messageInfo_GenesisState.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Params proto.InternalMessageInfo
+var xxx_messageInfo_GenesisState proto.InternalMessageInfo
 
-func (m *Params) GetPeggyId() string {
+func (m *GenesisState) GetParams() *Params {
 	if m != nil {
-		return m.PeggyId
+		return m.Params
 	}
-	return ""
+	return nil
 }
 
-func (m *Params) GetContractSourceHash() string {
+func (m *GenesisState) GetLastObservedNonce() uint64 {
 	if m != nil {
-		return m.ContractSourceHash
+		return m.LastObservedNonce
 	}
-	return ""
+	return 0
 }
 
-func (m *Params) GetBridgeEthereumAddress() string {
+func (m *GenesisState) GetValsets() []*Valset {
 	if m != nil {
-		return m.BridgeEthereumAddress
+		return m.Valsets
 	}
-	return ""
+	return nil
 }
 
-func (m *Params) GetBridgeChainId() uint64 {
+func (m *GenesisState) GetValsetConfirms() []*MsgValsetConfirm {
 	if m != nil {
-		return m.BridgeChainId
+		return m.ValsetConfirms
 	}
-	return 0
+	return nil
 }
 
-func (m *Params) GetSignedValsetsWindow() uint64 {
+func (m *GenesisState) GetBatches() []*OutgoingTxBatch {
 	if m != nil {
-		return m.SignedValsetsWindow
+		return m.Batches
 	}
-	return 0
+	return nil
 }
 
-func (m *Params) GetSignedBatchesWindow() uint64 {
+func (m *GenesisState) GetBatchConfirms() []MsgConfirmBatch {
 	if m != nil {
-		return m.SignedBatchesWindow
+		return m.BatchConfirms
 	}
-	return 0
+	return nil
 }
 
-func (m *Params) GetSignedClaimsWindow() uint64 {
+func (m *GenesisState) GetLogicCalls() []*OutgoingLogicCall {
 	if m != nil {
-		return m.SignedClaimsWindow
+		return m.LogicCalls
 	}
-	return 0
+	return nil
 }
 
-func (m *Params) GetTargetBatchTimeout() uint64 {
+func (m *GenesisState) GetLogicCallConfirms() []MsgConfirmLogicCall {
 	if m != nil {
-		return m.TargetBatchTimeout
+		return m.LogicCallConfirms
 	}
-	return 0
+	return nil
 }
 
-func (m *Params) GetAverageBlockTime() uint64 {
+func (m *GenesisState) GetAttestations() []Attestation {
 	if m != nil {
-		return m.AverageBlockTime
+		return m.Attestations
 	}
-	return 0
+	return nil
 }
 
-func (m *Params) GetAverageEthereumBlockTime() uint64 {
+func (m *GenesisState) GetDelegateKeys() []*MsgSetOrchestratorAddress {
 	if m != nil {
-		return m.AverageEthereumBlockTime
+		return m.DelegateKeys
 	}
-	return 0
+	return nil
 }
 
-func (m *Params) GetUnbondSlashingValsetsWindow() uint64 {
+func (m *GenesisState) GetErc20ToDenoms() []*ERC20ToDenom {
 	if m != nil {
-		return m.UnbondSlashingValsetsWindow
+		return m.Erc20ToDenoms
 	}
-	return 0
+	return nil
 }
 
-// GenesisState struct
-type GenesisState struct {
-	Params             *Params                      `protobuf:"bytes,1,opt,name=params,proto3" json:"params,omitempty"`
-	LastObservedNonce  uint64                       `protobuf:"varint,2,opt,name=last_observed_nonce,json=lastObservedNonce,proto3" json:"last_observed_nonce,omitempty"`
-	Valsets            []*Valset                    `protobuf:"bytes,3,rep,name=valsets,proto3" json:"valsets,omitempty"`
-	ValsetConfirms     []*MsgValsetConfirm          `protobuf:"bytes,4,rep,name=valset_confirms,json=valsetConfirms,proto3" json:"valset_confirms,omitempty"`
-	Batches            []*OutgoingTxBatch           `protobuf:"bytes,5,rep,name=batches,proto3" json:"batches,omitempty"`
-	BatchConfirms      []MsgConfirmBatch            `protobuf:"bytes,6,rep,name=batch_confirms,json=batchConfirms,proto3" json:"batch_confirms"`
-	LogicCalls         []*OutgoingLogicCall         `protobuf:"bytes,7,rep,name=logic_calls,json=logicCalls,proto3" json:"logic_calls,omitempty"`
-	LogicCallConfirms  []MsgConfirmLogicCall        `protobuf:"bytes,8,rep,name=logic_call_confirms,json=logicCallConfirms,proto3" json:"logic_call_confirms"`
-	Attestations       []Attestation                `protobuf:"bytes,9,rep,name=attestations,proto3" json:"attestations"`
-	DelegateKeys       []*MsgSetOrchestratorAddress `protobuf:"bytes,10,rep,name=delegate_keys,json=delegateKeys,proto3" json:"delegate_keys,omitempty"`
-	Erc20ToDenoms      []*ERC20ToDenom              `protobuf:"bytes,11,rep,name=erc20_to_denoms,json=erc20ToDenoms,proto3" json:"erc20_to_denoms,omitempty"`
-	UnbatchedTransfers []*OutgoingTransferTx        `protobuf:"bytes,12,rep,name=unbatched_transfers,json=unbatchedTransfers,proto3" json:"unbatched_transfers,omitempty"`
+func (m *GenesisState) GetUnbatchedTransfers() []*OutgoingTransferTx {
+	if m != nil {
+		return m.UnbatchedTransfers
+	}
+	return nil
 }
 
-func (m *GenesisState) Reset()         { *m = GenesisState{} }
-func (m *GenesisState) String() string { return proto.CompactTextString(m) }
-func (*GenesisState) ProtoMessage()    {}
-func (*GenesisState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_84231c3b3f050761, []int{1}
-}
-func (m *GenesisState) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *GenesisState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_GenesisState.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+func (m *GenesisState) GetReclaimableDeposits() []ReclaimableDeposit {
+	if m != nil {
+		return m.ReclaimableDeposits
 	}
+	return nil
 }
-func (m *GenesisState) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GenesisState.Merge(m, src)
-}
-func (m *GenesisState) XXX_Size() int {
-	return m.Size()
-}
-func (m *GenesisState) XXX_DiscardUnknown() {
-	xxx_messageInfo_GenesisState.DiscardUnknown(m)
+
+func (m *GenesisState) GetProcessedTxIds() []uint64 {
+	if m != nil {
+		return m.ProcessedTxIds
+	}
+	return nil
 }
 
-var xxx_messageInfo_GenesisState proto.InternalMessageInfo
+func (m *GenesisState) GetObservedDeposits() []ObservedDeposit {
+	if m != nil {
+		return m.ObservedDeposits
+	}
+	return nil
+}
 
-func (m *GenesisState) GetParams() *Params {
+func (m *GenesisState) GetBatchExecutions() []BatchExecution {
 	if m != nil {
-		return m.Params
+		return m.BatchExecutions
 	}
 	return nil
 }
 
-func (m *GenesisState) GetLastObservedNonce() uint64 {
+func (m *GenesisState) GetLastObservedValsetNonce() uint64 {
 	if m != nil {
-		return m.LastObservedNonce
+		return m.LastObservedValsetNonce
 	}
 	return 0
 }
 
-func (m *GenesisState) GetValsets() []*Valset {
+func (m *GenesisState) GetSkippedValsetNonces() []uint64 {
 	if m != nil {
-		return m.Valsets
+		return m.SkippedValsetNonces
 	}
 	return nil
 }
 
-func (m *GenesisState) GetValsetConfirms() []*MsgValsetConfirm {
+func (m *GenesisState) GetBridgeStats() []BridgeStats {
 	if m != nil {
-		return m.ValsetConfirms
+		return m.BridgeStats
 	}
 	return nil
 }
 
-func (m *GenesisState) GetBatches() []*OutgoingTxBatch {
+func (m *GenesisState) GetRelayers() []RegisteredRelayer {
 	if m != nil {
-		return m.Batches
+		return m.Relayers
 	}
 	return nil
 }
 
-func (m *GenesisState) GetBatchConfirms() []MsgConfirmBatch {
+func (m *GenesisState) GetLastObservedEthereumHeight() LastObservedEthereumBlockHeight {
 	if m != nil {
-		return m.BatchConfirms
+		return m.LastObservedEthereumHeight
+	}
+	return LastObservedEthereumBlockHeight{}
+}
+
+func (m *GenesisState) GetCancelledBatches() []OutgoingTxBatch {
+	if m != nil {
+		return m.CancelledBatches
 	}
 	return nil
 }
 
-func (m *GenesisState) GetLogicCalls() []*OutgoingLogicCall {
+func (m *GenesisState) GetGrants() []GrantAuthorization {
 	if m != nil {
-		return m.LogicCalls
+		return m.Grants
 	}
 	return nil
 }
 
-func (m *GenesisState) GetLogicCallConfirms() []MsgConfirmLogicCall {
+func (m *GenesisState) GetOmnibusAccounts() []string {
 	if m != nil {
-		return m.LogicCallConfirms
+		return m.OmnibusAccounts
 	}
 	return nil
 }
 
-func (m *GenesisState) GetAttestations() []Attestation {
+func (m *GenesisState) GetTokenQuirks() []TokenQuirk {
 	if m != nil {
-		return m.Attestations
+		return m.TokenQuirks
 	}
 	return nil
 }
 
-func (m *GenesisState) GetDelegateKeys() []*MsgSetOrchestratorAddress {
+func (m *GenesisState) GetPausedTokens() []PausedToken {
 	if m != nil {
-		return m.DelegateKeys
+		return m.PausedTokens
 	}
 	return nil
 }
 
-func (m *GenesisState) GetErc20ToDenoms() []*ERC20ToDenom {
+func (m *GenesisState) GetBatchedTxs() []BatchedTx {
 	if m != nil {
-		return m.Erc20ToDenoms
+		return m.BatchedTxs
 	}
 	return nil
 }
 
-func (m *GenesisState) GetUnbatchedTransfers() []*OutgoingTransferTx {
+func (m *GenesisState) GetScheduledTransfers() []ScheduledTransfer {
 	if m != nil {
-		return m.UnbatchedTransfers
+		return m.ScheduledTransfers
+	}
+	return nil
+}
+
+func (m *GenesisState) GetLastAppliedEventNonce() uint64 {
+	if m != nil {
+		return m.LastAppliedEventNonce
+	}
+	return 0
+}
+
+func (m *GenesisState) GetAccountActivities() []AccountActivity {
+	if m != nil {
+		return m.AccountActivities
 	}
 	return nil
 }
 
 func init() {
-	proto.RegisterType((*Params)(nil), "peggy.v1.Params")
-	proto.RegisterType((*GenesisState)(nil), "peggy.v1.GenesisState")
+	proto.RegisterType((*GenesisState)(nil), "gravity.v1.GenesisState")
 }
 
-func init() { proto.RegisterFile("peggy/v1/genesis.proto", fileDescriptor_84231c3b3f050761) }
+func init() { proto.RegisterFile("gravity/v1/genesis.proto", fileDescriptor_387b0aba880adb60) }
 
-var fileDescriptor_84231c3b3f050761 = []byte{
-	// 912 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0x5b, 0x6f, 0xdb, 0x36,
-	0x14, 0xb6, 0x57, 0xd7, 0x4e, 0x19, 0x3b, 0x17, 0xda, 0xe9, 0x54, 0x67, 0x75, 0x8d, 0x0e, 0x28,
-	0x82, 0x61, 0xb5, 0xd3, 0x14, 0xdb, 0xc3, 0xb0, 0x0b, 0x6a, 0xb7, 0x5d, 0x8b, 0x2d, 0xcb, 0x20,
-	0x07, 0x1b, 0xb0, 0x17, 0x8e, 0x96, 0x18, 0x5a, 0x88, 0x44, 0x06, 0x24, 0xed, 0xc4, 0x6f, 0x03,
-	0xf6, 0x07, 0xf6, 0xb3, 0xfa, 0xd8, 0xc7, 0xa1, 0x18, 0x8a, 0x21, 0xf9, 0x23, 0x83, 0x0e, 0x29,
-	0xc9, 0x76, 0xf2, 0x54, 0xec, 0xc9, 0xd2, 0xf9, 0x6e, 0xc7, 0x87, 0x17, 0xa1, 0xbb, 0x67, 0x8c,
-	0xf3, 0x79, 0x7f, 0xf6, 0xa4, 0xcf, 0x99, 0x60, 0x3a, 0xd2, 0xbd, 0x33, 0x25, 0x8d, 0xc4, 0x6b,
-	0x50, 0xef, 0xcd, 0x9e, 0xb4, 0x5b, 0x5c, 0x72, 0x09, 0xc5, 0x7e, 0xfa, 0x64, 0xf1, 0x76, 0x2b,
-	0xd7, 0x99, 0xf9, 0x19, 0x73, 0xaa, 0x76, 0x33, 0xaf, 0x26, 0x9a, 0xeb, 0x6b, 0xd4, 0x31, 0x35,
-	0xc1, 0xc4, 0x55, 0xdb, 0x79, 0x95, 0x1a, 0xc3, 0xb4, 0xa1, 0x26, 0x92, 0xc2, 0x62, 0x0f, 0xdf,
-	0xd5, 0x50, 0xf5, 0x67, 0xaa, 0x68, 0xa2, 0xf1, 0x3d, 0x64, 0x3b, 0x21, 0x51, 0xe8, 0x95, 0xbb,
-	0xe5, 0xbd, 0x3b, 0x7e, 0x0d, 0xde, 0x5f, 0x87, 0x78, 0x1f, 0xb5, 0x02, 0x29, 0x8c, 0xa2, 0x81,
-	0x21, 0x5a, 0x4e, 0x55, 0xc0, 0xc8, 0x84, 0xea, 0x89, 0xf7, 0x11, 0xd0, 0x70, 0x86, 0x8d, 0x00,
-	0x7a, 0x45, 0xf5, 0x04, 0x7f, 0x89, 0x3e, 0x1e, 0xab, 0x28, 0xe4, 0x8c, 0x30, 0x33, 0x61, 0x8a,
-	0x4d, 0x13, 0x42, 0xc3, 0x50, 0x31, 0xad, 0xbd, 0x0a, 0x88, 0x76, 0x2c, 0xfc, 0xc2, 0xa1, 0xcf,
-	0x2c, 0x88, 0x1f, 0xa1, 0x4d, 0xa7, 0x0b, 0x26, 0x34, 0x12, 0x69, 0x2f, 0xb7, 0xbb, 0xe5, 0xbd,
-	0x8a, 0xdf, 0xb0, 0xe5, 0x61, 0x5a, 0x7d, 0x1d, 0xe2, 0x03, 0xb4, 0xa3, 0x23, 0x2e, 0x58, 0x48,
-	0x66, 0x34, 0xd6, 0xcc, 0x68, 0x72, 0x1e, 0x89, 0x50, 0x9e, 0x7b, 0x55, 0x60, 0x37, 0x2d, 0xf8,
-	0x8b, 0xc5, 0x7e, 0x05, 0x68, 0x41, 0x03, 0xd3, 0x61, 0xb9, 0xa6, 0xb6, 0xa8, 0x19, 0x58, 0xcc,
-	0x69, 0xf6, 0x51, 0xcb, 0x69, 0x82, 0x98, 0x46, 0x49, 0x2e, 0x59, 0x03, 0x09, 0xb6, 0xd8, 0x10,
-	0xa0, 0x42, 0x61, 0xa8, 0xe2, 0xcc, 0xd8, 0x14, 0x62, 0xa2, 0x84, 0xc9, 0xa9, 0xf1, 0x90, 0x55,
-	0x58, 0x0c, 0x42, 0x8e, 0x2d, 0x82, 0x3f, 0x47, 0x98, 0xce, 0x98, 0xa2, 0x9c, 0x91, 0x71, 0x2c,
-	0x83, 0x53, 0x90, 0x78, 0xeb, 0xc0, 0xdf, 0x72, 0xc8, 0x20, 0x05, 0x52, 0x01, 0xfe, 0x06, 0xed,
-	0x66, 0xec, 0x7c, 0xb4, 0x0b, 0xb2, 0x3a, 0xc8, 0x3c, 0x47, 0xc9, 0xc6, 0x5b, 0xc8, 0xc7, 0x68,
-	0x47, 0xc7, 0x54, 0x4f, 0xc8, 0x49, 0xba, 0x62, 0x91, 0x14, 0x6e, 0x80, 0x5e, 0xa3, 0x5b, 0xde,
-	0xab, 0x0f, 0x7a, 0x6f, 0xde, 0x3f, 0x28, 0xbd, 0x7b, 0xff, 0xe0, 0x11, 0x8f, 0xcc, 0x64, 0x3a,
-	0xee, 0x05, 0x32, 0xe9, 0x07, 0x52, 0x27, 0x52, 0xbb, 0x9f, 0xc7, 0x3a, 0x3c, 0x75, 0x1b, 0xf1,
-	0x39, 0x0b, 0xfc, 0x26, 0x98, 0xbd, 0x74, 0x5e, 0x76, 0xde, 0xf8, 0x77, 0xd4, 0x5a, 0xc9, 0x80,
-	0x51, 0x78, 0x1b, 0x1f, 0x14, 0x81, 0x97, 0x22, 0x60, 0x72, 0x37, 0x24, 0xc0, 0xf2, 0x78, 0x9b,
-	0xff, 0x43, 0x02, 0xac, 0x26, 0x3e, 0x47, 0xdd, 0xd5, 0x04, 0x29, 0x4e, 0xe2, 0x28, 0x30, 0x91,
-	0xe0, 0x2e, 0x6d, 0xeb, 0x83, 0xd2, 0xee, 0x2f, 0xa7, 0x15, 0xae, 0x36, 0x78, 0x88, 0x3a, 0x53,
-	0x31, 0x96, 0x22, 0x24, 0xc0, 0x4b, 0xd3, 0x56, 0xb6, 0xf8, 0x36, 0x2c, 0xf1, 0xae, 0x65, 0x8d,
-	0x1c, 0x69, 0x69, 0xab, 0x7f, 0x55, 0xf9, 0xe3, 0x9f, 0x6e, 0xe9, 0xe1, 0x9f, 0x55, 0x54, 0xff,
-	0xde, 0xde, 0x35, 0x23, 0x43, 0x0d, 0xc3, 0x7b, 0xa8, 0x7a, 0x06, 0x87, 0x1d, 0x0e, 0xf8, 0xfa,
-	0xc1, 0x56, 0x2f, 0xbb, 0x7b, 0x7a, 0xf6, 0x12, 0xf0, 0x1d, 0x8e, 0x7b, 0xa8, 0x19, 0x53, 0x6d,
-	0x88, 0x1c, 0x6b, 0xa6, 0x66, 0x2c, 0x24, 0x42, 0x8a, 0x80, 0xc1, 0x81, 0xaf, 0xf8, 0xdb, 0x29,
-	0x74, 0xe4, 0x90, 0x9f, 0x52, 0x00, 0x7f, 0x86, 0x6a, 0xae, 0x4b, 0xef, 0x56, 0xf7, 0xd6, 0xb2,
-	0xb5, 0x6d, 0xcd, 0xcf, 0x08, 0x78, 0x88, 0x36, 0xed, 0x23, 0x8c, 0x34, 0x52, 0x49, 0x7a, 0x27,
-	0xa4, 0x9a, 0x76, 0xa1, 0x39, 0xd4, 0xee, 0x1f, 0x0d, 0x2d, 0xc5, 0xdf, 0x98, 0x2d, 0xbe, 0x6a,
-	0xfc, 0x14, 0xd5, 0xdc, 0x29, 0xf6, 0x6e, 0x83, 0xf8, 0x5e, 0x21, 0x3e, 0x9a, 0x1a, 0x2e, 0x23,
-	0xc1, 0x8f, 0x2f, 0x60, 0xb7, 0xf8, 0x19, 0x13, 0xbf, 0x44, 0x1b, 0xf6, 0x50, 0xe6, 0xc1, 0xd5,
-	0x55, 0xed, 0xa1, 0xe6, 0x2e, 0x03, 0xb4, 0x83, 0x4a, 0xba, 0xba, 0x7e, 0x03, 0x64, 0x79, 0xf8,
-	0xd7, 0x68, 0x3d, 0x96, 0x3c, 0x0a, 0x48, 0x40, 0xe3, 0x58, 0x7b, 0x35, 0x30, 0xd9, 0xbd, 0xde,
-	0xc0, 0x8f, 0x29, 0x69, 0x48, 0xe3, 0xd8, 0x47, 0x71, 0xf6, 0xa8, 0xf1, 0x08, 0x35, 0x0b, 0x75,
-	0xd1, 0xca, 0x1a, 0xb8, 0xdc, 0xbf, 0xa9, 0x95, 0xdc, 0xc7, 0xb5, 0xb3, 0x9d, 0xbb, 0xe5, 0x2d,
-	0x7d, 0x87, 0xea, 0x0b, 0xb7, 0xbb, 0xf6, 0xee, 0x80, 0xdb, 0x4e, 0xe1, 0xf6, 0xac, 0x40, 0x9d,
-	0xcb, 0x92, 0x00, 0xbf, 0x42, 0x8d, 0x90, 0xc5, 0x8c, 0x53, 0xc3, 0xc8, 0x29, 0x9b, 0x6b, 0x0f,
-	0x81, 0xc3, 0xa7, 0x4b, 0xfd, 0x8c, 0x98, 0x39, 0x52, 0xe9, 0x28, 0x8d, 0xa2, 0x46, 0x2a, 0x77,
-	0x6b, 0xfb, 0xf5, 0x4c, 0xf9, 0x03, 0x9b, 0x6b, 0xfc, 0x2d, 0xda, 0x64, 0x2a, 0x38, 0xd8, 0x27,
-	0x46, 0x92, 0x90, 0x09, 0x99, 0x68, 0x6f, 0x1d, 0xbc, 0xee, 0x16, 0x5e, 0x2f, 0xfc, 0xe1, 0xc1,
-	0xfe, 0xb1, 0x7c, 0x9e, 0xc2, 0x7e, 0x03, 0xe8, 0xee, 0x4d, 0xe3, 0x43, 0xd4, 0x9c, 0x0a, 0xbb,
-	0x64, 0x21, 0x31, 0x8a, 0x0a, 0x7d, 0xc2, 0x94, 0xf6, 0xea, 0xe0, 0xf1, 0xc9, 0x0d, 0xcb, 0xec,
-	0x28, 0xc7, 0x17, 0x3e, 0xce, 0x85, 0x59, 0x51, 0x0f, 0x8e, 0xde, 0x5c, 0x76, 0xca, 0x6f, 0x2f,
-	0x3b, 0xe5, 0x7f, 0x2f, 0x3b, 0xe5, 0xbf, 0xae, 0x3a, 0xa5, 0xb7, 0x57, 0x9d, 0xd2, 0xdf, 0x57,
-	0x9d, 0xd2, 0x6f, 0x5f, 0x5c, 0x3f, 0xb1, 0x5c, 0xd1, 0x59, 0x64, 0xe6, 0x8f, 0xed, 0xd7, 0xa6,
-	0x9f, 0xc8, 0x70, 0x1a, 0xb3, 0xfe, 0x45, 0xdf, 0x7e, 0x42, 0xe1, 0x10, 0x8f, 0xab, 0xf0, 0xe9,
-	0x7c, 0xfa, 0x5f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x78, 0x5e, 0xb8, 0xdb, 0xd1, 0x07, 0x00, 0x00,
+var fileDescriptor_387b0aba880adb60 = []byte{
+	// 1083 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x56, 0x5b, 0x6f, 0xdb, 0x36,
+	0x14, 0x8e, 0x97, 0x2c, 0x6d, 0x19, 0x27, 0x71, 0xe8, 0xa6, 0xe1, 0xdc, 0xc4, 0x31, 0x06, 0x6c,
+	0xf0, 0x2e, 0xb5, 0xdb, 0x0c, 0xc5, 0x1e, 0x36, 0xac, 0xb3, 0x53, 0xa3, 0xed, 0x7a, 0x49, 0xa6,
+	0x78, 0x1b, 0xb0, 0x87, 0x09, 0xb4, 0xc4, 0xca, 0x82, 0x65, 0x51, 0xe3, 0xa1, 0x3c, 0xbb, 0xbf,
+	0x62, 0x3f, 0xab, 0x8f, 0x7d, 0x1c, 0xf6, 0x50, 0x14, 0xc9, 0x1f, 0x19, 0x78, 0x91, 0x2c, 0xc7,
+	0x06, 0xf6, 0x14, 0xfa, 0x7c, 0x17, 0x92, 0x87, 0xe7, 0x9c, 0x08, 0x91, 0x40, 0xd0, 0x49, 0x28,
+	0x67, 0xed, 0xc9, 0x83, 0x76, 0xc0, 0x62, 0x06, 0x21, 0xb4, 0x12, 0xc1, 0x25, 0xc7, 0xc8, 0x22,
+	0xad, 0xc9, 0x83, 0xda, 0xed, 0x80, 0x07, 0x5c, 0x87, 0xdb, 0x6a, 0x65, 0x18, 0xb5, 0x3b, 0x05,
+	0xad, 0x9c, 0x25, 0xcc, 0x2a, 0x6b, 0xfb, 0x85, 0xf8, 0x18, 0x02, 0x58, 0x41, 0x1f, 0x50, 0xe9,
+	0x0d, 0x6d, 0xfc, 0xb0, 0x10, 0xa7, 0x52, 0x32, 0x90, 0x54, 0x86, 0x3c, 0xb6, 0xe8, 0x41, 0x01,
+	0x4d, 0xa8, 0xa0, 0xe3, 0x55, 0x76, 0x34, 0x95, 0xc3, 0x37, 0x26, 0xfe, 0xe9, 0x87, 0x0a, 0x2a,
+	0x3f, 0x31, 0x37, 0xb9, 0x90, 0x54, 0x32, 0xfc, 0x25, 0xda, 0x34, 0x42, 0x52, 0x6a, 0x94, 0x9a,
+	0x5b, 0x27, 0xb8, 0x35, 0xbf, 0x59, 0xeb, 0x5c, 0x23, 0x8e, 0x65, 0xe0, 0x16, 0xaa, 0x46, 0x14,
+	0xa4, 0xcb, 0x07, 0xc0, 0xc4, 0x84, 0xf9, 0x6e, 0xcc, 0x63, 0x8f, 0x91, 0x8f, 0x1a, 0xa5, 0xe6,
+	0x86, 0xb3, 0xa7, 0xa0, 0x33, 0x8b, 0xbc, 0x52, 0x00, 0xfe, 0x1a, 0xdd, 0x98, 0xd0, 0x08, 0x98,
+	0x04, 0xb2, 0xde, 0x58, 0xbf, 0x6e, 0xfe, 0xab, 0x86, 0x9c, 0x8c, 0x82, 0x7b, 0x68, 0xd7, 0x2c,
+	0x5d, 0x8f, 0xc7, 0xaf, 0x43, 0x31, 0x06, 0xb2, 0xa1, 0x55, 0x87, 0x45, 0xd5, 0x4b, 0x08, 0x8c,
+	0xf0, 0xd4, 0x90, 0x9c, 0x9d, 0x49, 0xf1, 0x27, 0xe0, 0x87, 0xe8, 0x86, 0xce, 0x1f, 0x03, 0xf2,
+	0xb1, 0x96, 0xdf, 0x2d, 0xca, 0xcf, 0x52, 0x19, 0xf0, 0x30, 0x0e, 0xfa, 0xd3, 0xae, 0x22, 0x39,
+	0x19, 0x17, 0x3f, 0x45, 0x3b, 0x7a, 0x39, 0xdf, 0x7c, 0x73, 0x59, 0xfd, 0x12, 0x02, 0xbb, 0x8f,
+	0x56, 0x77, 0x37, 0xde, 0xbe, 0x3f, 0x5e, 0x73, 0xb6, 0xb5, 0x30, 0x3f, 0xc0, 0x0f, 0x68, 0x2b,
+	0xe2, 0x41, 0xe8, 0xb9, 0x1e, 0x8d, 0x22, 0x20, 0x37, 0xb4, 0xcd, 0xd1, 0xaa, 0x43, 0xbc, 0x50,
+	0xb4, 0x53, 0x1a, 0x45, 0x0e, 0x8a, 0xb2, 0x25, 0xe0, 0x5f, 0x50, 0x75, 0xae, 0x9f, 0x1f, 0xe7,
+	0xa6, 0xf6, 0x39, 0x5e, 0x7d, 0x9c, 0xdc, 0xc9, 0x1e, 0x69, 0x2f, 0xf7, 0xcb, 0x8f, 0xd5, 0x41,
+	0xe5, 0x42, 0xfd, 0x00, 0xb9, 0xa5, 0xfd, 0x0e, 0x8a, 0x7e, 0x9d, 0x39, 0x6e, 0x7d, 0x16, 0x24,
+	0xf8, 0x27, 0xb4, 0xed, 0xb3, 0x88, 0x05, 0x54, 0x32, 0x77, 0xc4, 0x66, 0x40, 0x90, 0xf6, 0xf8,
+	0xec, 0xda, 0x99, 0x2e, 0x98, 0x3c, 0x13, 0x2a, 0xa9, 0x52, 0x50, 0xc9, 0x45, 0xc7, 0xf7, 0x05,
+	0x03, 0x70, 0xca, 0x99, 0xf6, 0x39, 0x9b, 0x01, 0xfe, 0x11, 0xed, 0x32, 0xe1, 0x9d, 0xdc, 0x77,
+	0x25, 0x77, 0x7d, 0x16, 0xf3, 0x31, 0x90, 0x2d, 0xed, 0x46, 0x8a, 0x6e, 0x3d, 0xe7, 0xf4, 0xe4,
+	0x7e, 0x9f, 0x3f, 0x56, 0x04, 0x67, 0x5b, 0x0b, 0xec, 0x2f, 0xc0, 0x67, 0xa8, 0x9a, 0xc6, 0xe6,
+	0xf9, 0x7c, 0x57, 0x0a, 0x1a, 0xc3, 0x6b, 0x26, 0x80, 0x94, 0xb5, 0x4b, 0x7d, 0xe5, 0xa3, 0x5b,
+	0x52, 0x7f, 0xea, 0xe0, 0x5c, 0x9a, 0x05, 0x01, 0xff, 0x86, 0x6e, 0x0b, 0xe6, 0x45, 0x34, 0x1c,
+	0xd3, 0x41, 0xc4, 0x5c, 0x9f, 0x25, 0x1c, 0x42, 0x09, 0x64, 0x7b, 0xd9, 0xd1, 0x99, 0xf3, 0x1e,
+	0x1b, 0x9a, 0x4d, 0x58, 0x55, 0x2c, 0x21, 0x80, 0x9b, 0xa8, 0x92, 0x08, 0xee, 0x31, 0x00, 0x75,
+	0xd2, 0xa9, 0x1b, 0xfa, 0x40, 0x76, 0x1a, 0xeb, 0xcd, 0x0d, 0x67, 0x27, 0x8f, 0xf7, 0xa7, 0xcf,
+	0x7c, 0xc0, 0xaf, 0xd0, 0x5e, 0xde, 0x5c, 0xf9, 0xfe, 0xbb, 0x2b, 0xca, 0xd8, 0x92, 0x16, 0x37,
+	0xaf, 0xf0, 0xc5, 0x30, 0xe0, 0xe7, 0xa8, 0x62, 0xaa, 0x9a, 0x4d, 0x99, 0x97, 0x9a, 0x87, 0xaf,
+	0x68, 0xbb, 0x5a, 0xd1, 0x4e, 0x57, 0x73, 0x2f, 0xa3, 0x58, 0xb7, 0xdd, 0xc1, 0x42, 0x14, 0xf0,
+	0x77, 0xa8, 0xb6, 0xd8, 0xfe, 0xb6, 0x5d, 0xcd, 0x14, 0xd8, 0xd3, 0x53, 0xe0, 0xa0, 0x38, 0x05,
+	0x4c, 0xa3, 0x9a, 0x59, 0x70, 0x82, 0xf6, 0x61, 0x14, 0x26, 0xc9, 0x35, 0x19, 0x10, 0xac, 0x13,
+	0x51, 0xb5, 0x60, 0x41, 0xa2, 0x6a, 0xa4, 0x3c, 0x10, 0xa1, 0x1f, 0x30, 0x57, 0x95, 0x20, 0x90,
+	0xea, 0x72, 0xc9, 0x76, 0x35, 0xae, 0x46, 0x19, 0xd8, 0x63, 0x6f, 0x0d, 0xe6, 0x21, 0xfc, 0x08,
+	0xdd, 0x14, 0x2c, 0xa2, 0x33, 0x55, 0x18, 0xb7, 0x97, 0x1b, 0xd1, 0x61, 0x41, 0x08, 0x92, 0x09,
+	0xe6, 0x3b, 0x86, 0x65, 0x3d, 0x72, 0x11, 0x96, 0xe8, 0x68, 0xf1, 0xce, 0x4c, 0x0e, 0x99, 0x60,
+	0xe9, 0xd8, 0x1d, 0xb2, 0x30, 0x18, 0x4a, 0xb2, 0xaf, 0xa7, 0xe6, 0x57, 0x45, 0xd7, 0x17, 0x85,
+	0x14, 0xf4, 0x2c, 0xbd, 0x1b, 0x71, 0x6f, 0xf4, 0x54, 0x4b, 0xec, 0x1e, 0xb5, 0x68, 0x05, 0xcd,
+	0x30, 0x54, 0x19, 0x78, 0x34, 0xf6, 0x58, 0x14, 0x31, 0xdf, 0xcd, 0xa6, 0xd9, 0x9d, 0xff, 0x9d,
+	0x66, 0x59, 0x19, 0xe4, 0xda, 0xae, 0x1d, 0x6e, 0xdf, 0xa3, 0xcd, 0x40, 0xd0, 0x58, 0x02, 0x39,
+	0x58, 0xae, 0xe5, 0x27, 0x0a, 0xe9, 0xa4, 0x72, 0xc8, 0x45, 0xf8, 0xa6, 0xd8, 0xfc, 0x56, 0x83,
+	0xbf, 0x40, 0x15, 0x3e, 0x8e, 0xc3, 0x41, 0x0a, 0x2e, 0xf5, 0x3c, 0x9e, 0x2a, 0x1f, 0xd2, 0x58,
+	0x6f, 0xde, 0x72, 0x76, 0x6d, 0xbc, 0x63, 0xc3, 0xf8, 0x11, 0x2a, 0x4b, 0x3e, 0x62, 0xb1, 0xfb,
+	0x67, 0x1a, 0x8a, 0x11, 0x90, 0x4f, 0xf4, 0x76, 0x77, 0x8a, 0xdb, 0xf5, 0x15, 0xfe, 0xb3, 0x82,
+	0xb3, 0x07, 0x93, 0x79, 0x04, 0x70, 0x17, 0x6d, 0x27, 0x34, 0xd5, 0x7d, 0xa2, 0xa2, 0x40, 0x6a,
+	0xcb, 0x6f, 0x7e, 0xae, 0x09, 0xda, 0x27, 0x1b, 0x53, 0xc9, 0x3c, 0xa4, 0x6e, 0xbb, 0x95, 0x8f,
+	0x85, 0x29, 0x90, 0xbb, 0xda, 0x61, 0x7f, 0xa9, 0xde, 0x55, 0xcf, 0x59, 0x3d, 0xca, 0x66, 0xc1,
+	0x14, 0x70, 0x1f, 0x55, 0x41, 0xad, 0xd3, 0x68, 0x61, 0xac, 0x1c, 0x2e, 0x57, 0xcf, 0x45, 0x46,
+	0xcb, 0x46, 0x88, 0x75, 0xc3, 0x70, 0x1d, 0x00, 0xfc, 0x2d, 0x22, 0xba, 0x8e, 0x68, 0x92, 0x44,
+	0xa1, 0x2a, 0xa3, 0x09, 0x8b, 0xb3, 0xce, 0x39, 0xd2, 0x9d, 0xb3, 0xaf, 0xf0, 0x8e, 0x81, 0x7b,
+	0x0a, 0x35, 0x7d, 0x73, 0x8e, 0xb0, 0x4d, 0xba, 0x4b, 0x3d, 0x19, 0x4e, 0x42, 0x19, 0x32, 0x20,
+	0xf5, 0xe5, 0x5a, 0xb0, 0x6f, 0xd0, 0x31, 0xa4, 0x59, 0xf6, 0x8f, 0x80, 0x2e, 0x84, 0x43, 0x06,
+	0xf8, 0x0f, 0x54, 0x8d, 0xa9, 0x0c, 0x27, 0xcc, 0xa4, 0xd8, 0x65, 0xe0, 0x09, 0xfe, 0x17, 0x39,
+	0x6e, 0x94, 0x9a, 0xb7, 0xba, 0x2d, 0xa5, 0xfa, 0xf7, 0xfd, 0xf1, 0xe7, 0x41, 0x28, 0x87, 0xe9,
+	0xa0, 0xe5, 0xf1, 0x71, 0xdb, 0xe3, 0x30, 0xe6, 0x60, 0xff, 0xdc, 0x03, 0x7f, 0x64, 0xbf, 0x67,
+	0x9e, 0xc5, 0xd2, 0xd9, 0x33, 0x56, 0x3a, 0xf3, 0x3d, 0x6d, 0xd4, 0x3d, 0x7b, 0x7b, 0x59, 0x2f,
+	0xbd, 0xbb, 0xac, 0x97, 0x3e, 0x5c, 0xd6, 0x4b, 0x7f, 0x5f, 0xd5, 0xd7, 0xde, 0x5d, 0xd5, 0xd7,
+	0xfe, 0xb9, 0xaa, 0xaf, 0xfd, 0xfe, 0x70, 0xd9, 0xd4, 0x5e, 0xe0, 0x9e, 0x69, 0xda, 0xf6, 0x98,
+	0xab, 0xbc, 0xb5, 0xa7, 0xed, 0x84, 0x05, 0xc1, 0xcc, 0xec, 0x33, 0xd8, 0xd4, 0x9f, 0x2e, 0xdf,
+	0xfc, 0x37, 0x00, 0x96, 0xe2, 0x52, 0xfb, 0x8e, 0x09, 0x00, 0x00,
 }
 
-func (m *Params) Marshal() (dAtA []byte, err error) {
+func (m *GenesisState) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -423,27 +394,20 @@ func (m *Params) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Params) MarshalTo(dAtA []byte) (int, error) {
+func (m *GenesisState) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *GenesisState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.UnbondSlashingValsetsWindow != 0 {
-		i = encodeVarintGenesis(dAtA, i, uint64(m.UnbondSlashingValsetsWindow))
-		i--
-		dAtA[i] = 0x1
-		i--
-		dAtA[i] = 0x88
-	}
 	{
-		size := m.SlashFractionConflictingClaim.Size()
+		size := m.NativeTokenEscrow.Size()
 		i -= size
-		if _, err := m.SlashFractionConflictingClaim.MarshalTo(dAtA[i:]); err != nil {
+		if _, err := m.NativeTokenEscrow.MarshalTo(dAtA[i:]); err != nil {
 			return 0, err
 		}
 		i = encodeVarintGenesis(dAtA, i, uint64(size))
@@ -451,116 +415,270 @@ func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i--
 	dAtA[i] = 0x1
 	i--
-	dAtA[i] = 0x82
-	{
-		size := m.SlashFractionClaim.Size()
-		i -= size
-		if _, err := m.SlashFractionClaim.MarshalTo(dAtA[i:]); err != nil {
-			return 0, err
+	dAtA[i] = 0xfa
+	if len(m.AccountActivities) > 0 {
+		for iNdEx := len(m.AccountActivities) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.AccountActivities[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xf2
 		}
-		i = encodeVarintGenesis(dAtA, i, uint64(size))
 	}
-	i--
-	dAtA[i] = 0x7a
-	{
-		size := m.SlashFractionBatch.Size()
-		i -= size
-		if _, err := m.SlashFractionBatch.MarshalTo(dAtA[i:]); err != nil {
-			return 0, err
+	if m.LastAppliedEventNonce != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.LastAppliedEventNonce))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xe8
+	}
+	if len(m.ScheduledTransfers) > 0 {
+		for iNdEx := len(m.ScheduledTransfers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ScheduledTransfers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xe2
+		}
+	}
+	if len(m.BatchedTxs) > 0 {
+		for iNdEx := len(m.BatchedTxs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.BatchedTxs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xda
+		}
+	}
+	if len(m.PausedTokens) > 0 {
+		for iNdEx := len(m.PausedTokens) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.PausedTokens[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xd2
+		}
+	}
+	if len(m.TokenQuirks) > 0 {
+		for iNdEx := len(m.TokenQuirks) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.TokenQuirks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xca
+		}
+	}
+	if len(m.OmnibusAccounts) > 0 {
+		for iNdEx := len(m.OmnibusAccounts) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.OmnibusAccounts[iNdEx])
+			copy(dAtA[i:], m.OmnibusAccounts[iNdEx])
+			i = encodeVarintGenesis(dAtA, i, uint64(len(m.OmnibusAccounts[iNdEx])))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xc2
+		}
+	}
+	if len(m.Grants) > 0 {
+		for iNdEx := len(m.Grants) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Grants[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xba
+		}
+	}
+	if len(m.CancelledBatches) > 0 {
+		for iNdEx := len(m.CancelledBatches) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.CancelledBatches[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xb2
 		}
-		i = encodeVarintGenesis(dAtA, i, uint64(size))
 	}
-	i--
-	dAtA[i] = 0x72
 	{
-		size := m.SlashFractionValset.Size()
-		i -= size
-		if _, err := m.SlashFractionValset.MarshalTo(dAtA[i:]); err != nil {
+		size, err := m.LastObservedEthereumHeight.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
 			return 0, err
 		}
+		i -= size
 		i = encodeVarintGenesis(dAtA, i, uint64(size))
 	}
 	i--
-	dAtA[i] = 0x6a
-	if m.AverageEthereumBlockTime != 0 {
-		i = encodeVarintGenesis(dAtA, i, uint64(m.AverageEthereumBlockTime))
-		i--
-		dAtA[i] = 0x60
-	}
-	if m.AverageBlockTime != 0 {
-		i = encodeVarintGenesis(dAtA, i, uint64(m.AverageBlockTime))
-		i--
-		dAtA[i] = 0x58
-	}
-	if m.TargetBatchTimeout != 0 {
-		i = encodeVarintGenesis(dAtA, i, uint64(m.TargetBatchTimeout))
-		i--
-		dAtA[i] = 0x50
-	}
-	if m.SignedClaimsWindow != 0 {
-		i = encodeVarintGenesis(dAtA, i, uint64(m.SignedClaimsWindow))
-		i--
-		dAtA[i] = 0x40
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0xaa
+	if len(m.Relayers) > 0 {
+		for iNdEx := len(m.Relayers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Relayers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xa2
+		}
 	}
-	if m.SignedBatchesWindow != 0 {
-		i = encodeVarintGenesis(dAtA, i, uint64(m.SignedBatchesWindow))
-		i--
-		dAtA[i] = 0x38
+	if len(m.BridgeStats) > 0 {
+		for iNdEx := len(m.BridgeStats) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.BridgeStats[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x9a
+		}
 	}
-	if m.SignedValsetsWindow != 0 {
-		i = encodeVarintGenesis(dAtA, i, uint64(m.SignedValsetsWindow))
+	if len(m.SkippedValsetNonces) > 0 {
+		dAtA3 := make([]byte, len(m.SkippedValsetNonces)*10)
+		var j2 int
+		for _, num := range m.SkippedValsetNonces {
+			for num >= 1<<7 {
+				dAtA3[j2] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j2++
+			}
+			dAtA3[j2] = uint8(num)
+			j2++
+		}
+		i -= j2
+		copy(dAtA[i:], dAtA3[:j2])
+		i = encodeVarintGenesis(dAtA, i, uint64(j2))
 		i--
-		dAtA[i] = 0x30
-	}
-	if m.BridgeChainId != 0 {
-		i = encodeVarintGenesis(dAtA, i, uint64(m.BridgeChainId))
+		dAtA[i] = 0x1
 		i--
-		dAtA[i] = 0x28
+		dAtA[i] = 0x92
 	}
-	if len(m.BridgeEthereumAddress) > 0 {
-		i -= len(m.BridgeEthereumAddress)
-		copy(dAtA[i:], m.BridgeEthereumAddress)
-		i = encodeVarintGenesis(dAtA, i, uint64(len(m.BridgeEthereumAddress)))
+	if m.LastObservedValsetNonce != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.LastObservedValsetNonce))
 		i--
-		dAtA[i] = 0x22
-	}
-	if len(m.ContractSourceHash) > 0 {
-		i -= len(m.ContractSourceHash)
-		copy(dAtA[i:], m.ContractSourceHash)
-		i = encodeVarintGenesis(dAtA, i, uint64(len(m.ContractSourceHash)))
+		dAtA[i] = 0x1
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x88
 	}
-	if len(m.PeggyId) > 0 {
-		i -= len(m.PeggyId)
-		copy(dAtA[i:], m.PeggyId)
-		i = encodeVarintGenesis(dAtA, i, uint64(len(m.PeggyId)))
-		i--
-		dAtA[i] = 0xa
+	if len(m.BatchExecutions) > 0 {
+		for iNdEx := len(m.BatchExecutions) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.BatchExecutions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x82
+		}
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *GenesisState) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if len(m.ObservedDeposits) > 0 {
+		for iNdEx := len(m.ObservedDeposits) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ObservedDeposits[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x7a
+		}
+	}
+	if len(m.ProcessedTxIds) > 0 {
+		dAtA5 := make([]byte, len(m.ProcessedTxIds)*10)
+		var j4 int
+		for _, num := range m.ProcessedTxIds {
+			for num >= 1<<7 {
+				dAtA5[j4] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j4++
+			}
+			dAtA5[j4] = uint8(num)
+			j4++
+		}
+		i -= j4
+		copy(dAtA[i:], dAtA5[:j4])
+		i = encodeVarintGenesis(dAtA, i, uint64(j4))
+		i--
+		dAtA[i] = 0x72
+	}
+	if len(m.ReclaimableDeposits) > 0 {
+		for iNdEx := len(m.ReclaimableDeposits) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ReclaimableDeposits[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x6a
+		}
 	}
-	return dAtA[:n], nil
-}
-
-func (m *GenesisState) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *GenesisState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
 	if len(m.UnbatchedTransfers) > 0 {
 		for iNdEx := len(m.UnbatchedTransfers) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -732,59 +850,6 @@ func encodeVarintGenesis(dAtA []byte, offset int, v uint64) int {
 	dAtA[offset] = uint8(v)
 	return base
 }
-func (m *Params) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.PeggyId)
-	if l > 0 {
-		n += 1 + l + sovGenesis(uint64(l))
-	}
-	l = len(m.ContractSourceHash)
-	if l > 0 {
-		n += 1 + l + sovGenesis(uint64(l))
-	}
-	l = len(m.BridgeEthereumAddress)
-	if l > 0 {
-		n += 1 + l + sovGenesis(uint64(l))
-	}
-	if m.BridgeChainId != 0 {
-		n += 1 + sovGenesis(uint64(m.BridgeChainId))
-	}
-	if m.SignedValsetsWindow != 0 {
-		n += 1 + sovGenesis(uint64(m.SignedValsetsWindow))
-	}
-	if m.SignedBatchesWindow != 0 {
-		n += 1 + sovGenesis(uint64(m.SignedBatchesWindow))
-	}
-	if m.SignedClaimsWindow != 0 {
-		n += 1 + sovGenesis(uint64(m.SignedClaimsWindow))
-	}
-	if m.TargetBatchTimeout != 0 {
-		n += 1 + sovGenesis(uint64(m.TargetBatchTimeout))
-	}
-	if m.AverageBlockTime != 0 {
-		n += 1 + sovGenesis(uint64(m.AverageBlockTime))
-	}
-	if m.AverageEthereumBlockTime != 0 {
-		n += 1 + sovGenesis(uint64(m.AverageEthereumBlockTime))
-	}
-	l = m.SlashFractionValset.Size()
-	n += 1 + l + sovGenesis(uint64(l))
-	l = m.SlashFractionBatch.Size()
-	n += 1 + l + sovGenesis(uint64(l))
-	l = m.SlashFractionClaim.Size()
-	n += 1 + l + sovGenesis(uint64(l))
-	l = m.SlashFractionConflictingClaim.Size()
-	n += 2 + l + sovGenesis(uint64(l))
-	if m.UnbondSlashingValsetsWindow != 0 {
-		n += 2 + sovGenesis(uint64(m.UnbondSlashingValsetsWindow))
-	}
-	return n
-}
-
 func (m *GenesisState) Size() (n int) {
 	if m == nil {
 		return 0
@@ -858,6 +923,108 @@ func (m *GenesisState) Size() (n int) {
 			n += 1 + l + sovGenesis(uint64(l))
 		}
 	}
+	if len(m.ReclaimableDeposits) > 0 {
+		for _, e := range m.ReclaimableDeposits {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.ProcessedTxIds) > 0 {
+		l = 0
+		for _, e := range m.ProcessedTxIds {
+			l += sovGenesis(uint64(e))
+		}
+		n += 1 + sovGenesis(uint64(l)) + l
+	}
+	if len(m.ObservedDeposits) > 0 {
+		for _, e := range m.ObservedDeposits {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.BatchExecutions) > 0 {
+		for _, e := range m.BatchExecutions {
+			l = e.Size()
+			n += 2 + l + sovGenesis(uint64(l))
+		}
+	}
+	if m.LastObservedValsetNonce != 0 {
+		n += 2 + sovGenesis(uint64(m.LastObservedValsetNonce))
+	}
+	if len(m.SkippedValsetNonces) > 0 {
+		l = 0
+		for _, e := range m.SkippedValsetNonces {
+			l += sovGenesis(uint64(e))
+		}
+		n += 2 + sovGenesis(uint64(l)) + l
+	}
+	if len(m.BridgeStats) > 0 {
+		for _, e := range m.BridgeStats {
+			l = e.Size()
+			n += 2 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.Relayers) > 0 {
+		for _, e := range m.Relayers {
+			l = e.Size()
+			n += 2 + l + sovGenesis(uint64(l))
+		}
+	}
+	l = m.LastObservedEthereumHeight.Size()
+	n += 2 + l + sovGenesis(uint64(l))
+	if len(m.CancelledBatches) > 0 {
+		for _, e := range m.CancelledBatches {
+			l = e.Size()
+			n += 2 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.Grants) > 0 {
+		for _, e := range m.Grants {
+			l = e.Size()
+			n += 2 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.OmnibusAccounts) > 0 {
+		for _, s := range m.OmnibusAccounts {
+			l = len(s)
+			n += 2 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.TokenQuirks) > 0 {
+		for _, e := range m.TokenQuirks {
+			l = e.Size()
+			n += 2 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.PausedTokens) > 0 {
+		for _, e := range m.PausedTokens {
+			l = e.Size()
+			n += 2 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.BatchedTxs) > 0 {
+		for _, e := range m.BatchedTxs {
+			l = e.Size()
+			n += 2 + l + sovGenesis(uint64(l))
+		}
+	}
+	if len(m.ScheduledTransfers) > 0 {
+		for _, e := range m.ScheduledTransfers {
+			l = e.Size()
+			n += 2 + l + sovGenesis(uint64(l))
+		}
+	}
+	if m.LastAppliedEventNonce != 0 {
+		n += 2 + sovGenesis(uint64(m.LastAppliedEventNonce))
+	}
+	if len(m.AccountActivities) > 0 {
+		for _, e := range m.AccountActivities {
+			l = e.Size()
+			n += 2 + l + sovGenesis(uint64(l))
+		}
+	}
+	l = m.NativeTokenEscrow.Size()
+	n += 2 + l + sovGenesis(uint64(l))
 	return n
 }
 
@@ -867,7 +1034,7 @@ func sovGenesis(x uint64) (n int) {
 func sozGenesis(x uint64) (n int) {
 	return sovGenesis(uint64((x << 1) ^ uint64((int64(x) >> 63))))
 }
-func (m *Params) Unmarshal(dAtA []byte) error {
+func (m *GenesisState) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -890,17 +1057,17 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Params: wiretype end group for non-group")
+			return fmt.Errorf("proto: GenesisState: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Params: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GenesisState: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PeggyId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Params", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenesis
@@ -910,29 +1077,52 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthGenesis
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenesis
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.PeggyId = string(dAtA[iNdEx:postIndex])
+			if m.Params == nil {
+				m.Params = &Params{}
+			}
+			if err := m.Params.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastObservedNonce", wireType)
+			}
+			m.LastObservedNonce = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastObservedNonce |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ContractSourceHash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Valsets", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenesis
@@ -942,29 +1132,31 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthGenesis
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenesis
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ContractSourceHash = string(dAtA[iNdEx:postIndex])
+			m.Valsets = append(m.Valsets, &Valset{})
+			if err := m.Valsets[len(m.Valsets)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BridgeEthereumAddress", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ValsetConfirms", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenesis
@@ -974,29 +1166,31 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthGenesis
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenesis
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.BridgeEthereumAddress = string(dAtA[iNdEx:postIndex])
+			m.ValsetConfirms = append(m.ValsetConfirms, &MsgValsetConfirm{})
+			if err := m.ValsetConfirms[len(m.ValsetConfirms)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BridgeChainId", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Batches", wireType)
 			}
-			m.BridgeChainId = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenesis
@@ -1006,16 +1200,31 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.BridgeChainId |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Batches = append(m.Batches, &OutgoingTxBatch{})
+			if err := m.Batches[len(m.Batches)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SignedValsetsWindow", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BatchConfirms", wireType)
 			}
-			m.SignedValsetsWindow = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenesis
@@ -1025,16 +1234,65 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SignedValsetsWindow |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BatchConfirms = append(m.BatchConfirms, MsgConfirmBatch{})
+			if err := m.BatchConfirms[len(m.BatchConfirms)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 7:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SignedBatchesWindow", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogicCalls", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
 			}
-			m.SignedBatchesWindow = 0
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LogicCalls = append(m.LogicCalls, &OutgoingLogicCall{})
+			if err := m.LogicCalls[len(m.LogicCalls)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogicCallConfirms", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenesis
@@ -1044,16 +1302,31 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SignedBatchesWindow |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 8:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SignedClaimsWindow", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LogicCallConfirms = append(m.LogicCallConfirms, MsgConfirmLogicCall{})
+			if err := m.LogicCallConfirms[len(m.LogicCallConfirms)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attestations", wireType)
 			}
-			m.SignedClaimsWindow = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenesis
@@ -1063,16 +1336,31 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SignedClaimsWindow |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Attestations = append(m.Attestations, Attestation{})
+			if err := m.Attestations[len(m.Attestations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 10:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TargetBatchTimeout", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DelegateKeys", wireType)
 			}
-			m.TargetBatchTimeout = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenesis
@@ -1082,16 +1370,31 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TargetBatchTimeout |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DelegateKeys = append(m.DelegateKeys, &MsgSetOrchestratorAddress{})
+			if err := m.DelegateKeys[len(m.DelegateKeys)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 11:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AverageBlockTime", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Erc20ToDenoms", wireType)
 			}
-			m.AverageBlockTime = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenesis
@@ -1101,35 +1404,31 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.AverageBlockTime |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 12:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AverageEthereumBlockTime", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
 			}
-			m.AverageEthereumBlockTime = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowGenesis
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.AverageEthereumBlockTime |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
 			}
-		case 13:
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Erc20ToDenoms = append(m.Erc20ToDenoms, &ERC20ToDenom{})
+			if err := m.Erc20ToDenoms[len(m.Erc20ToDenoms)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SlashFractionValset", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UnbatchedTransfers", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenesis
@@ -1139,30 +1438,31 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthGenesis
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenesis
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.SlashFractionValset.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.UnbatchedTransfers = append(m.UnbatchedTransfers, &OutgoingTransferTx{})
+			if err := m.UnbatchedTransfers[len(m.UnbatchedTransfers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 14:
+		case 13:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SlashFractionBatch", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ReclaimableDeposits", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenesis
@@ -1172,30 +1472,107 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthGenesis
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenesis
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.SlashFractionBatch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.ReclaimableDeposits = append(m.ReclaimableDeposits, ReclaimableDeposit{})
+			if err := m.ReclaimableDeposits[len(m.ReclaimableDeposits)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 14:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowGenesis
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.ProcessedTxIds = append(m.ProcessedTxIds, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowGenesis
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthGenesis
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthGenesis
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.ProcessedTxIds) == 0 {
+					m.ProcessedTxIds = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowGenesis
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.ProcessedTxIds = append(m.ProcessedTxIds, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProcessedTxIds", wireType)
+			}
 		case 15:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SlashFractionClaim", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ObservedDeposits", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenesis
@@ -1205,30 +1582,31 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthGenesis
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenesis
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.SlashFractionClaim.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.ObservedDeposits = append(m.ObservedDeposits, ObservedDeposit{})
+			if err := m.ObservedDeposits[len(m.ObservedDeposits)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 16:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SlashFractionConflictingClaim", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BatchExecutions", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenesis
@@ -1238,30 +1616,31 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthGenesis
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenesis
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.SlashFractionConflictingClaim.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.BatchExecutions = append(m.BatchExecutions, BatchExecution{})
+			if err := m.BatchExecutions[len(m.BatchExecutions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 17:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UnbondSlashingValsetsWindow", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LastObservedValsetNonce", wireType)
 			}
-			m.UnbondSlashingValsetsWindow = 0
+			m.LastObservedValsetNonce = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenesis
@@ -1271,64 +1650,90 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.UnbondSlashingValsetsWindow |= uint64(b&0x7F) << shift
+				m.LastObservedValsetNonce |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipGenesis(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthGenesis
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *GenesisState) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowGenesis
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: GenesisState: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GenesisState: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 18:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowGenesis
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.SkippedValsetNonces = append(m.SkippedValsetNonces, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowGenesis
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthGenesis
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthGenesis
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.SkippedValsetNonces) == 0 {
+					m.SkippedValsetNonces = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowGenesis
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.SkippedValsetNonces = append(m.SkippedValsetNonces, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field SkippedValsetNonces", wireType)
+			}
+		case 19:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Params", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BridgeStats", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1355,18 +1760,16 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Params == nil {
-				m.Params = &Params{}
-			}
-			if err := m.Params.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.BridgeStats = append(m.BridgeStats, BridgeStats{})
+			if err := m.BridgeStats[len(m.BridgeStats)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LastObservedNonce", wireType)
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Relayers", wireType)
 			}
-			m.LastObservedNonce = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenesis
@@ -1376,14 +1779,29 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.LastObservedNonce |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Relayers = append(m.Relayers, RegisteredRelayer{})
+			if err := m.Relayers[len(m.Relayers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 21:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Valsets", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LastObservedEthereumHeight", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1410,14 +1828,13 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Valsets = append(m.Valsets, &Valset{})
-			if err := m.Valsets[len(m.Valsets)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.LastObservedEthereumHeight.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
+		case 22:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ValsetConfirms", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CancelledBatches", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1444,14 +1861,14 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ValsetConfirms = append(m.ValsetConfirms, &MsgValsetConfirm{})
-			if err := m.ValsetConfirms[len(m.ValsetConfirms)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.CancelledBatches = append(m.CancelledBatches, OutgoingTxBatch{})
+			if err := m.CancelledBatches[len(m.CancelledBatches)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 5:
+		case 23:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Batches", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Grants", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1478,16 +1895,16 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Batches = append(m.Batches, &OutgoingTxBatch{})
-			if err := m.Batches[len(m.Batches)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Grants = append(m.Grants, GrantAuthorization{})
+			if err := m.Grants[len(m.Grants)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
+		case 24:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BatchConfirms", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OmnibusAccounts", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenesis
@@ -1497,29 +1914,27 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthGenesis
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenesis
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.BatchConfirms = append(m.BatchConfirms, MsgConfirmBatch{})
-			if err := m.BatchConfirms[len(m.BatchConfirms)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.OmnibusAccounts = append(m.OmnibusAccounts, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 7:
+		case 25:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LogicCalls", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenQuirks", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1546,14 +1961,14 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LogicCalls = append(m.LogicCalls, &OutgoingLogicCall{})
-			if err := m.LogicCalls[len(m.LogicCalls)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.TokenQuirks = append(m.TokenQuirks, TokenQuirk{})
+			if err := m.TokenQuirks[len(m.TokenQuirks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 8:
+		case 26:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LogicCallConfirms", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PausedTokens", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1580,14 +1995,14 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LogicCallConfirms = append(m.LogicCallConfirms, MsgConfirmLogicCall{})
-			if err := m.LogicCallConfirms[len(m.LogicCallConfirms)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.PausedTokens = append(m.PausedTokens, PausedToken{})
+			if err := m.PausedTokens[len(m.PausedTokens)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 9:
+		case 27:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Attestations", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BatchedTxs", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1614,14 +2029,14 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Attestations = append(m.Attestations, Attestation{})
-			if err := m.Attestations[len(m.Attestations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.BatchedTxs = append(m.BatchedTxs, BatchedTx{})
+			if err := m.BatchedTxs[len(m.BatchedTxs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 10:
+		case 28:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DelegateKeys", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ScheduledTransfers", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1648,14 +2063,33 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.DelegateKeys = append(m.DelegateKeys, &MsgSetOrchestratorAddress{})
-			if err := m.DelegateKeys[len(m.DelegateKeys)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.ScheduledTransfers = append(m.ScheduledTransfers, ScheduledTransfer{})
+			if err := m.ScheduledTransfers[len(m.ScheduledTransfers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 11:
+		case 29:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastAppliedEventNonce", wireType)
+			}
+			m.LastAppliedEventNonce = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastAppliedEventNonce |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 30:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Erc20ToDenoms", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AccountActivities", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1682,16 +2116,16 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Erc20ToDenoms = append(m.Erc20ToDenoms, &ERC20ToDenom{})
-			if err := m.Erc20ToDenoms[len(m.Erc20ToDenoms)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.AccountActivities = append(m.AccountActivities, AccountActivity{})
+			if err := m.AccountActivities[len(m.AccountActivities)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 12:
+		case 31:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UnbatchedTransfers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NativeTokenEscrow", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowGenesis
@@ -1701,23 +2135,23 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthGenesis
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthGenesis
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.UnbatchedTransfers = append(m.UnbatchedTransfers, &OutgoingTransferTx{})
-			if err := m.UnbatchedTransfers[len(m.UnbatchedTransfers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.NativeTokenEscrow.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -1,8 +1,10 @@
 package types
 
 import (
+	"math"
 	"testing"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -15,12 +17,162 @@ func TestGenesisStateValidate(t *testing.T) {
 		"empty params":   {src: &GenesisState{Params: &Params{}}, expErr: true},
 		"invalid params": {src: &GenesisState{
 			Params: &Params{
-				PeggyId:               "foo",
+				GravityId:             "foo",
 				ContractSourceHash:    "laksdjflasdkfja",
 				BridgeEthereumAddress: "invalid-eth-address",
 				BridgeChainId:         3279089,
 			},
 		}, expErr: true},
+		"invalid token allowlist": {src: &GenesisState{
+			Params: func() *Params {
+				p := DefaultParams()
+				p.TokenAllowlist = []string{"invalid-eth-address"}
+				return p
+			}(),
+		}, expErr: true},
+		"duplicate token denylist": {src: &GenesisState{
+			Params: func() *Params {
+				p := DefaultParams()
+				p.TokenDenylist = []string{"0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5", "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"}
+				return p
+			}(),
+		}, expErr: true},
+		"checksummed bridge contract address": {src: &GenesisState{
+			Params: func() *Params {
+				p := DefaultParams()
+				p.BridgeEthereumAddress = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+				return p
+			}(),
+		}, expErr: false},
+		"bridge contract address not checksummed": {src: &GenesisState{
+			Params: func() *Params {
+				p := DefaultParams()
+				p.BridgeEthereumAddress = "0x429881672b9ae42b8eba0e26cd9c73711b891ca5"
+				return p
+			}(),
+		}, expErr: true},
+		"token allowlist not checksummed": {src: &GenesisState{
+			Params: func() *Params {
+				p := DefaultParams()
+				p.TokenAllowlist = []string{"0x429881672b9ae42b8eba0e26cd9c73711b891ca5"}
+				return p
+			}(),
+		}, expErr: true},
+		"empty signature scheme": {src: &GenesisState{
+			Params: func() *Params {
+				p := DefaultParams()
+				p.SignatureScheme = ""
+				return p
+			}(),
+		}},
+		"unknown signature scheme": {src: &GenesisState{
+			Params: func() *Params {
+				p := DefaultParams()
+				p.SignatureScheme = "eip1271"
+				return p
+			}(),
+		}, expErr: true},
+		"token allowed and denied": {src: &GenesisState{
+			Params: func() *Params {
+				p := DefaultParams()
+				p.TokenAllowlist = []string{"0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"}
+				p.TokenDenylist = []string{"0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"}
+				return p
+			}(),
+		}, expErr: true},
+		"empty gravity id": {src: &GenesisState{
+			Params: func() *Params {
+				p := DefaultParams()
+				p.GravityId = ""
+				return p
+			}(),
+		}, expErr: true},
+		"zero signed valsets window": {src: &GenesisState{
+			Params: func() *Params {
+				p := DefaultParams()
+				p.SignedValsetsWindow = 0
+				return p
+			}(),
+		}, expErr: true},
+		"zero unbond slashing valsets window": {src: &GenesisState{
+			Params: func() *Params {
+				p := DefaultParams()
+				p.UnbondSlashingValsetsWindow = 0
+				return p
+			}(),
+		}, expErr: true},
+		"slash fraction of one": {src: &GenesisState{
+			Params: func() *Params {
+				p := DefaultParams()
+				p.SlashFractionClaim = sdk.OneDec()
+				return p
+			}(),
+		}, expErr: true},
+		"negative slash fraction": {src: &GenesisState{
+			Params: func() *Params {
+				p := DefaultParams()
+				p.SlashFractionBatch = sdk.NewDec(-1)
+				return p
+			}(),
+		}, expErr: true},
+		"token batch gas over target": {src: &GenesisState{
+			Params: func() *Params {
+				p := DefaultParams()
+				p.TokenBatchGas = []TokenBatchGas{{TokenContract: "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5", TxGas: p.TargetBatchGas}}
+				return p
+			}(),
+		}, expErr: true},
+		"zero token batch gas": {src: &GenesisState{
+			Params: func() *Params {
+				p := DefaultParams()
+				p.TokenBatchGas = []TokenBatchGas{{TokenContract: "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"}}
+				return p
+			}(),
+		}, expErr: true},
+		"batch gas not limited": {src: &GenesisState{
+			Params: func() *Params {
+				p := DefaultParams()
+				p.TargetBatchGas = 0
+				p.TokenBatchGas = []TokenBatchGas{{TokenContract: "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5", TxGas: 1 << 40}}
+				return p
+			}(),
+		}, expErr: false},
+		"all slashed funds to relayers": {src: &GenesisState{
+			Params: func() *Params {
+				p := DefaultParams()
+				p.SlashedFundsRelayerShare = sdk.OneDec()
+				return p
+			}(),
+		}, expErr: false},
+		"slashed funds relayer share above one": {src: &GenesisState{
+			Params: func() *Params {
+				p := DefaultParams()
+				p.SlashedFundsRelayerShare = sdk.NewDecWithPrec(11, 1)
+				return p
+			}(),
+		}, expErr: true},
+		"min valset power above the valset total": {src: &GenesisState{
+			Params: func() *Params {
+				p := DefaultParams()
+				p.MinValsetPower = math.MaxUint32 + 1
+				return p
+			}(),
+		}, expErr: true},
+		"min valset power above the power outside of the threshold": {src: &GenesisState{
+			Params: func() *Params {
+				p := DefaultParams()
+				p.MinValsetPower = math.MaxUint32 - BridgePowerThreshold + 1
+				return p
+			}(),
+		}, expErr: true},
+		"negative native token bridge cap": {src: &GenesisState{
+			Params: func() *Params {
+				p := DefaultParams()
+				p.BridgeNativeToken = true
+				p.NativeTokenBridgeCap = sdk.NewInt(-1)
+				return p
+			}(),
+		}, expErr: true},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
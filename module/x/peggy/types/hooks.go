@@ -0,0 +1,60 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// PeggyHooks are called by the peggy keeper on bridge lifecycle events so that
+// other modules can react to them, for example by staking deposited tokens
+type PeggyHooks interface {
+	// AfterDepositObserved is called once a deposit has been observed and credited to its cosmos receiver
+	AfterDepositObserved(ctx sdk.Context, eventNonce uint64, ethereumSender string, cosmosReceiver sdk.AccAddress, amount sdk.Coin)
+	// AfterWithdrawExecuted is called once a batch has been observed as executed on Ethereum
+	AfterWithdrawExecuted(ctx sdk.Context, tokenContract string, batchNonce uint64)
+	// AfterValsetUpdated is called once a new validator set has been stored for signing
+	AfterValsetUpdated(ctx sdk.Context, valset *Valset)
+	// AfterBatchCreated is called once a new outgoing batch has been stored for signing
+	AfterBatchCreated(ctx sdk.Context, batch *OutgoingTxBatch)
+	// AfterGenericEventObserved is called once an event of an arbitrary Ethereum contract has been observed
+	AfterGenericEventObserved(ctx sdk.Context, eventNonce uint64, contractAddress string, topic []byte, data []byte)
+}
+
+var _ PeggyHooks = MultiPeggyHooks{}
+
+// MultiPeggyHooks combines multiple peggy hooks, all hook functions are run in array sequence
+type MultiPeggyHooks []PeggyHooks
+
+// NewMultiPeggyHooks returns the combination of the given hooks
+func NewMultiPeggyHooks(hooks ...PeggyHooks) MultiPeggyHooks {
+	return hooks
+}
+
+func (h MultiPeggyHooks) AfterDepositObserved(ctx sdk.Context, eventNonce uint64, ethereumSender string, cosmosReceiver sdk.AccAddress, amount sdk.Coin) {
+	for i := range h {
+		h[i].AfterDepositObserved(ctx, eventNonce, ethereumSender, cosmosReceiver, amount)
+	}
+}
+
+func (h MultiPeggyHooks) AfterWithdrawExecuted(ctx sdk.Context, tokenContract string, batchNonce uint64) {
+	for i := range h {
+		h[i].AfterWithdrawExecuted(ctx, tokenContract, batchNonce)
+	}
+}
+
+func (h MultiPeggyHooks) AfterValsetUpdated(ctx sdk.Context, valset *Valset) {
+	for i := range h {
+		h[i].AfterValsetUpdated(ctx, valset)
+	}
+}
+
+func (h MultiPeggyHooks) AfterBatchCreated(ctx sdk.Context, batch *OutgoingTxBatch) {
+	for i := range h {
+		h[i].AfterBatchCreated(ctx, batch)
+	}
+}
+
+func (h MultiPeggyHooks) AfterGenericEventObserved(ctx sdk.Context, eventNonce uint64, contractAddress string, topic []byte, data []byte) {
+	for i := range h {
+		h[i].AfterGenericEventObserved(ctx, eventNonce, contractAddress, topic, data)
+	}
+}
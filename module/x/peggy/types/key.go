@@ -1,13 +1,26 @@
 package types
 
 import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 const (
-	// ModuleName is the name of the module
+	// ModuleName is the name of the module, its module account escrows the cosmos originated
+	// coins bridged to Ethereum and mints and burns the ethereum originated vouchers
 	ModuleName = "peggy"
 
+	// FeeCollectorName is the module account holding the fees of pending transfers and logic
+	// calls until they are executed on Ethereum or refunded
+	FeeCollectorName = ModuleName + "_fees"
+
+	// RelayerRewardPoolName is the module account funding rewards paid to relayers on Cosmos
+	RelayerRewardPoolName = ModuleName + "_relayer_rewards"
+
 	// StoreKey to be used when creating the KVStore
 	StoreKey = ModuleName
 
@@ -16,6 +29,14 @@ const (
 
 	// QuerierRoute to be used for querierer msgs
 	QuerierRoute = ModuleName
+
+	// QuerierLegacyAmino is the optional first querier path element that selects the
+	// amino JSON encoding used before the querier returned the gRPC response types
+	QuerierLegacyAmino = "amino"
+
+	// StoreVersion is the version of the peggy store written by this binary, the number of store
+	// migrations run by its upgrade handler
+	StoreVersion = 13
 )
 
 var (
@@ -73,6 +94,9 @@ var (
 	// LastObservedEventNonceKey indexes the latest event nonce
 	LastObservedEventNonceKey = []byte{0xf2}
 
+	// LastAppliedEventNonceKey indexes the event nonce of the latest claim handed to the attestation handler
+	LastAppliedEventNonceKey = []byte{0xfb}
+
 	// SequenceKeyPrefix indexes different txids
 	SequenceKeyPrefix = []byte{0x7}
 
@@ -82,6 +106,9 @@ var (
 	// KeyLastOutgoingBatchID indexes the lastBatchID
 	KeyLastOutgoingBatchID = append(SequenceKeyPrefix, []byte("lastBatchId")...)
 
+	// KeyLastAccountActivityID indexes the id of the last entry of the account ledgers
+	KeyLastAccountActivityID = append(SequenceKeyPrefix, []byte("lastAccountActivityId")...)
+
 	// KeyOrchestratorAddress indexes the validator keys for an orchestrator
 	KeyOrchestratorAddress = []byte{0xe8}
 
@@ -111,8 +138,224 @@ var (
 
 	// LastUnBondingBlockHeight indexes the last validator unbonding block height
 	LastUnBondingBlockHeight = []byte{0xf8}
+
+	// LastSlashBlockHeight indexes the last block height a validator was slashed at
+	LastSlashBlockHeight = []byte{0xfa}
+
+	// ReclaimableDepositKey indexes deposits with an invalid cosmos receiver by event nonce
+	ReclaimableDepositKey = []byte{0xc}
+
+	// ProcessedTxIDKey indexes the ids of outgoing transfers that were executed on Ethereum
+	ProcessedTxIDKey = []byte{0xd}
+
+	// LastBatchRequestHeightKey indexes the height of the last requested batch by token contract
+	LastBatchRequestHeightKey = []byte{0xe}
+
+	// DepositByEthSenderKey indexes observed deposits by ethereum sender and event nonce
+	DepositByEthSenderKey = []byte{0x10}
+
+	// BatchExecutionKey indexes where executed batches landed on Ethereum by token contract and batch nonce
+	BatchExecutionKey = []byte{0x11}
+
+	// LatestEthereumBlockHeightKey indexes the highest Ethereum block height claimed by an attestation
+	// that reached the vote threshold, it is used to count the confirmations of pending attestations
+	LatestEthereumBlockHeightKey = []byte{0x12}
+
+	// LastObservedValsetNonceKey indexes the nonce of the last valset the bridge contract was observed switching to
+	LastObservedValsetNonceKey = []byte{0x13}
+
+	// SkippedValsetNonceKey indexes the valset nonces that were skipped on Ethereum and not abandoned by governance
+	SkippedValsetNonceKey = []byte{0x14}
+
+	// BridgeStatsKey indexes the volume bridged of every token by token contract
+	BridgeStatsKey = []byte{0x15}
+
+	// WorkQueueKey indexes the cursor of the EndBlocker tasks that ran out of work budget by task, the
+	// next block resumes the task at the cursor
+	WorkQueueKey = []byte{0x16}
+
+	// PendingVoteResetKey indexes the validators whose votes are withdrawn from an event nonce on once
+	// the pass over the timed out attestations that deleted their votes completes
+	PendingVoteResetKey = []byte{0x17}
+
+	// ParamsKey indexes the params of the module, they used to be kept in the peggy subspace of the
+	// params module
+	ParamsKey = []byte{0x18}
+
+	// RelayerKey indexes the cosmos account of the registered relayers by ethereum address
+	RelayerKey = []byte{0x19}
+
+	// RelayerByAccountKey indexes the ethereum address of the registered relayers by cosmos account
+	RelayerByAccountKey = []byte{0x1a}
+
+	// CancelledBatchKey indexes the cancelled batches that may still be executed on Ethereum, their
+	// transactions are held until the batch times out or a later batch of the token is executed
+	CancelledBatchKey = []byte{0x1b}
+
+	// GrantKey indexes the grants that let a grantee execute bridge messages on behalf of a granter
+	GrantKey = []byte{0x1c}
+
+	// OmnibusAccountKey indexes the omnibus accounts whose tagged deposits go to sub-accounts
+	OmnibusAccountKey = []byte{0x1d}
+
+	// TokenQuirkKey indexes the tokens whose deposits moved a different amount than reported by token contract
+	TokenQuirkKey = []byte{0x1e}
+
+	// PausedTokenKey indexes the tokens whose bridging is paused by token contract
+	PausedTokenKey = []byte{0x1f}
+
+	// BatchedTxKey indexes the batch of outgoing transfers by tx id
+	BatchedTxKey = []byte{0x20}
+
+	// ScheduledTransferKey indexes the transfers waiting for their earliest execution by tx id
+	ScheduledTransferKey = []byte{0x21}
+
+	// ValidatorOrchestratorKey indexes the orchestrators of a validator, it is the reverse of
+	// KeyOrchestratorAddress
+	ValidatorOrchestratorKey = []byte{0x22}
+
+	// ClaimResubmissionsKey counts the claims a validator resubmitted after they were already stored
+	ClaimResubmissionsKey = []byte{0x23}
+
+	// ERC20DecimalsKey indexes the decimals of the cosmos originated ERC20s whose decimals differ from
+	// the decimals of their denom by token contract
+	ERC20DecimalsKey = []byte{0x24}
+
+	// AccountActivityKey indexes the bridge ledger of the accounts by account, height and id
+	AccountActivityKey = []byte{0x25}
+
+	// StoreVersionKey holds the number of store migrations applied to the peggy store
+	StoreVersionKey = []byte{0x26}
+
+	// AccountActivityByHeightKey indexes the account of the entries of the bridge ledgers by height and id,
+	// the ledger entries are pruned in this order
+	AccountActivityByHeightKey = []byte{0x27}
+
+	// NativeTokenEscrowKey holds the amount of the staking token escrowed for Ethereum
+	NativeTokenEscrowKey = []byte{0x28}
+
+	// ScheduledTransferByHeightKey indexes the scheduled transfers by the height they wait for
+	ScheduledTransferByHeightKey = []byte{0x29}
+
+	// ScheduledTransferByTimeKey indexes the scheduled transfers that reached their height by the time they
+	// wait for
+	ScheduledTransferByTimeKey = []byte{0x2a}
+
+	// OutgoingTxByExpirationHeightKey indexes the unbatched transfers by the height they expire at
+	OutgoingTxByExpirationHeightKey = []byte{0x2b}
+
+	// OutgoingTxByExpirationTimeKey indexes the unbatched transfers by the time they expire at
+	OutgoingTxByExpirationTimeKey = []byte{0x2c}
+
+	// PendingOutgoingTxByFeeKey indexes the batched and unbatched transfers that were not executed yet by
+	// their fee
+	PendingOutgoingTxByFeeKey = []byte{0x2d}
+
+	// ClaimEthereumHeightByValidatorKey indexes the Ethereum height of the last claim of a validator, along
+	// with the Cosmos height it was claimed at
+	ClaimEthereumHeightByValidatorKey = []byte{0x2e}
 )
 
+// KeyPrefix names a prefix of the peggy store
+type KeyPrefix struct {
+	Name   string
+	Prefix []byte
+}
+
+// KeyPrefixes registers every prefix of the peggy store. No prefix may be a prefix of another one,
+// iterating over the entries of a prefix would otherwise walk into the entries of the other one.
+// Prefixes that are no longer written stay registered so they are never reused for new state.
+// The keys below a prefix are built with the Get...Key functions of this file.
+var KeyPrefixes = []KeyPrefix{
+	{"EthAddressKey", EthAddressKey},
+	{"ValsetRequestKey", ValsetRequestKey},
+	{"ValsetConfirmKey", ValsetConfirmKey},
+	{"OracleClaimKey", OracleClaimKey},
+	{"OracleAttestationKey", OracleAttestationKey},
+	{"OutgoingTXPoolKey", OutgoingTXPoolKey},
+	{"SequenceKeyPrefix", SequenceKeyPrefix},
+	{"DenomiatorPrefix", DenomiatorPrefix},
+	{"SecondIndexOutgoingTXFeeKey", SecondIndexOutgoingTXFeeKey},
+	{"OutgoingTXBatchKey", OutgoingTXBatchKey},
+	{"OutgoingTXBatchBlockKey", OutgoingTXBatchBlockKey},
+	{"ReclaimableDepositKey", ReclaimableDepositKey},
+	{"ProcessedTxIDKey", ProcessedTxIDKey},
+	{"LastBatchRequestHeightKey", LastBatchRequestHeightKey},
+	{"SecondIndexNonceByClaimKey", SecondIndexNonceByClaimKey},
+	{"DepositByEthSenderKey", DepositByEthSenderKey},
+	{"BatchExecutionKey", BatchExecutionKey},
+	{"LatestEthereumBlockHeightKey", LatestEthereumBlockHeightKey},
+	{"LastObservedValsetNonceKey", LastObservedValsetNonceKey},
+	{"SkippedValsetNonceKey", SkippedValsetNonceKey},
+	{"BridgeStatsKey", BridgeStatsKey},
+	{"WorkQueueKey", WorkQueueKey},
+	{"PendingVoteResetKey", PendingVoteResetKey},
+	{"ParamsKey", ParamsKey},
+	{"RelayerKey", RelayerKey},
+	{"RelayerByAccountKey", RelayerByAccountKey},
+	{"CancelledBatchKey", CancelledBatchKey},
+	{"GrantKey", GrantKey},
+	{"OmnibusAccountKey", OmnibusAccountKey},
+	{"TokenQuirkKey", TokenQuirkKey},
+	{"PausedTokenKey", PausedTokenKey},
+	{"BatchedTxKey", BatchedTxKey},
+	{"ScheduledTransferKey", ScheduledTransferKey},
+	{"ValidatorOrchestratorKey", ValidatorOrchestratorKey},
+	{"ClaimResubmissionsKey", ClaimResubmissionsKey},
+	{"ERC20DecimalsKey", ERC20DecimalsKey},
+	{"AccountActivityKey", AccountActivityKey},
+	{"StoreVersionKey", StoreVersionKey},
+	{"AccountActivityByHeightKey", AccountActivityByHeightKey},
+	{"NativeTokenEscrowKey", NativeTokenEscrowKey},
+	{"ScheduledTransferByHeightKey", ScheduledTransferByHeightKey},
+	{"ScheduledTransferByTimeKey", ScheduledTransferByTimeKey},
+	{"OutgoingTxByExpirationHeightKey", OutgoingTxByExpirationHeightKey},
+	{"OutgoingTxByExpirationTimeKey", OutgoingTxByExpirationTimeKey},
+	{"PendingOutgoingTxByFeeKey", PendingOutgoingTxByFeeKey},
+	{"ClaimEthereumHeightByValidatorKey", ClaimEthereumHeightByValidatorKey},
+	{"KeyOutgoingLogicConfirm", KeyOutgoingLogicConfirm},
+	{"KeyOutgoingLogicCall", KeyOutgoingLogicCall},
+	{"BatchConfirmKey", BatchConfirmKey},
+	{"KeyOrchestratorAddress", KeyOrchestratorAddress},
+	{"LastEventNonceByValidatorKey", LastEventNonceByValidatorKey},
+	{"LastObservedEventNonceKey", LastObservedEventNonceKey},
+	{"LastAppliedEventNonceKey", LastAppliedEventNonceKey},
+	{"DenomToERC20Key", DenomToERC20Key},
+	{"ERC20ToDenomKey", ERC20ToDenomKey},
+	{"LastSlashedValsetNonce", LastSlashedValsetNonce},
+	{"LatestValsetNonce", LatestValsetNonce},
+	{"LastSlashedBatchBlock", LastSlashedBatchBlock},
+	{"LastUnBondingBlockHeight", LastUnBondingBlockHeight},
+	{"LastSlashBlockHeight", LastSlashBlockHeight},
+	{"LastObservedEthereumBlockHeightKey", LastObservedEthereumBlockHeightKey},
+}
+
+// KeyPrefixName returns the name of the registered prefix the key is stored under, or an empty string
+// if the key is not under any registered prefix
+func KeyPrefixName(key []byte) string {
+	for _, p := range KeyPrefixes {
+		if bytes.HasPrefix(key, p.Prefix) {
+			return p.Name
+		}
+	}
+	return ""
+}
+
+// ValidateKeyPrefixes returns an error if a prefix is empty or a prefix of another one
+func ValidateKeyPrefixes(prefixes []KeyPrefix) error {
+	for i, a := range prefixes {
+		if len(a.Prefix) == 0 {
+			return fmt.Errorf("empty key prefix %s", a.Name)
+		}
+		for _, b := range prefixes[i+1:] {
+			if bytes.HasPrefix(a.Prefix, b.Prefix) || bytes.HasPrefix(b.Prefix, a.Prefix) {
+				return fmt.Errorf("key prefix %s %X overlaps with %s %X", a.Name, a.Prefix, b.Name, b.Prefix)
+			}
+		}
+	}
+	return nil
+}
+
 // GetOrchestratorAddressKey returns the following key format
 // prefix
 // [0xe8][cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn]
@@ -122,21 +365,21 @@ func GetOrchestratorAddressKey(orc sdk.AccAddress) []byte {
 
 // GetEthAddressKey returns the following key format
 // prefix              cosmos-validator
-// [0x0][cosmosvaloper1ahx7f8wyertuus9r20284ej0asrs085case3kn]
+// [0x1][cosmosvaloper1ahx7f8wyertuus9r20284ej0asrs085case3kn]
 func GetEthAddressKey(validator sdk.ValAddress) []byte {
 	return append(EthAddressKey, validator.Bytes()...)
 }
 
 // GetValsetKey returns the following key format
 // prefix    nonce
-// [0x0][0 0 0 0 0 0 0 1]
+// [0x2][0 0 0 0 0 0 0 1]
 func GetValsetKey(nonce uint64) []byte {
 	return append(ValsetRequestKey, UInt64Bytes(nonce)...)
 }
 
 // GetValsetConfirmKey returns the following key format
 // prefix   nonce                    validator-address
-// [0x0][0 0 0 0 0 0 0 1][cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn]
+// [0x3][0 0 0 0 0 0 0 1][cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn]
 // MARK finish-batches: this is where the key is created in the old (presumed working) code
 func GetValsetConfirmKey(nonce uint64, validator sdk.AccAddress) []byte {
 	return append(ValsetConfirmKey, append(UInt64Bytes(nonce), validator.Bytes()...)...)
@@ -144,7 +387,7 @@ func GetValsetConfirmKey(nonce uint64, validator sdk.AccAddress) []byte {
 
 // GetClaimKey returns the following key format
 // prefix type               cosmos-validator-address                       nonce                             attestation-details-hash
-// [0x0][0 0 0 1][cosmosvaloper1ahx7f8wyertuus9r20284ej0asrs085case3kn][0 0 0 0 0 0 0 1][fd1af8cec6c67fcf156f1b61fdf91ebc04d05484d007436e75342fc05bbff35a]
+// [0x4][0 0 0 1][cosmosvaloper1ahx7f8wyertuus9r20284ej0asrs085case3kn][0 0 0 0 0 0 0 1][fd1af8cec6c67fcf156f1b61fdf91ebc04d05484d007436e75342fc05bbff35a]
 // The Claim hash identifies a unique event, for example it would have a event nonce, a sender and a receiver. Or an event nonce and a batch nonce. But
 // the Claim is stored indexed with the claimer key to make sure that it is unique.
 func GetClaimKey(details EthereumClaim) []byte {
@@ -204,8 +447,8 @@ func GetOutgoingTxPoolKey(id uint64) []byte {
 }
 
 // GetOutgoingTxBatchKey returns the following key format
-// prefix     nonce                     eth-contract-address
-// [0xa][0 0 0 0 0 0 0 1][0xc783df8a850f42e7F7e57013759C285caa701eB6]
+// prefix     eth-contract-address                     nonce
+// [0xa][0xc783df8a850f42e7F7e57013759C285caa701eB6][0 0 0 0 0 0 0 1]
 func GetOutgoingTxBatchKey(tokenContract string, nonce uint64) []byte {
 	return append(append(OutgoingTXBatchKey, []byte(tokenContract)...), UInt64Bytes(nonce)...)
 }
@@ -228,45 +471,307 @@ func GetBatchConfirmKey(tokenContract string, batchNonce uint64, validator sdk.A
 	return c
 }
 
+// GetBatchConfirmContractPrefix returns the prefix of the confirmations of all batches of a token contract
+func GetBatchConfirmContractPrefix(tokenContract string) []byte {
+	return append(append([]byte{}, BatchConfirmKey...), tokenContract...)
+}
+
 // GetFeeSecondIndexKey returns the following key format
-// prefix            eth-contract-address            fee_amount
-// [0x9][0xc783df8a850f42e7F7e57013759C285caa701eB6][1000000000]
-func GetFeeSecondIndexKey(fee ERC20Token) []byte {
-	r := make([]byte, 1+ETHContractAddressLen+32)
+// prefix            eth-contract-address            fee_amount (inverted)   tx-id
+// [0x9][0xc783df8a850f42e7F7e57013759C285caa701eB6][1000000000][0 0 0 0 0 0 0 1]
+// the fee bytes are inverted so that a forward iteration over a contract returns the
+// highest fee first, transactions with the same fee are ordered by id
+func GetFeeSecondIndexKey(fee ERC20Token, txID uint64) []byte {
+	r := make([]byte, 1+ETHContractAddressLen+32+8)
 	// sdkInts have a size limit of 255 bits or 32 bytes
 	// therefore this will never panic and is always safe
 	amount := make([]byte, 32)
 	amount = fee.Amount.BigInt().FillBytes(amount)
-	// TODO this won't ever work fix it
+	for i := range amount {
+		amount[i] = ^amount[i]
+	}
 	copy(r[0:], SecondIndexOutgoingTXFeeKey)
 	copy(r[len(SecondIndexOutgoingTXFeeKey):], []byte(fee.Contract))
 	copy(r[len(SecondIndexOutgoingTXFeeKey)+len(fee.Contract):], amount)
+	copy(r[len(SecondIndexOutgoingTXFeeKey)+len(fee.Contract)+len(amount):], UInt64Bytes(txID))
 	return r
 }
 
+// ParseFeeSecondIndexKey splits a fee index key without the prefix into its contract,
+// fee amount and tx id, it is the inverse of GetFeeSecondIndexKey
+func ParseFeeSecondIndexKey(key []byte) (contract string, fee sdk.Int, txID uint64) {
+	contract = string(key[:ETHContractAddressLen])
+	amount := make([]byte, 32)
+	copy(amount, key[ETHContractAddressLen:ETHContractAddressLen+32])
+	for i := range amount {
+		amount[i] = ^amount[i]
+	}
+	fee = sdk.NewIntFromBigInt(new(big.Int).SetBytes(amount))
+	txID = UInt64FromBytes(key[ETHContractAddressLen+32:])
+	return contract, fee, txID
+}
+
 // GetLastEventNonceByValidatorKey indexes lateset event nonce by validator
 // GetLastEventNonceByValidatorKey returns the following key format
 // prefix              cosmos-validator
-// [0x0][cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn]
+// [0xf1][cosmosvaloper1ahx7f8wyertuus9r20284ej0asrs085ceqtfnm]
 func GetLastEventNonceByValidatorKey(validator sdk.ValAddress) []byte {
 	return append(LastEventNonceByValidatorKey, validator.Bytes()...)
 }
 
+// GetDenomToERC20Key returns the following key format
+// prefix     denom
+// [0xf3][uatom]
 func GetDenomToERC20Key(denom string) []byte {
 	return append(DenomToERC20Key, []byte(denom)...)
 }
 
+// GetERC20ToDenomKey returns the following key format
+// prefix     eth-contract-address
+// [0xf4][0xc783df8a850f42e7F7e57013759C285caa701eB6]
 func GetERC20ToDenomKey(erc20 string) []byte {
 	return append(ERC20ToDenomKey, []byte(erc20)...)
 }
 
+// GetReclaimableDepositKey returns the following key format
+// prefix     event-nonce
+// [0xc][0 0 0 0 0 0 0 1]
+func GetReclaimableDepositKey(eventNonce uint64) []byte {
+	return append(ReclaimableDepositKey, UInt64Bytes(eventNonce)...)
+}
+
+// GetProcessedTxIDKey returns the following key format
+// prefix     tx-id
+// [0xd][0 0 0 0 0 0 0 1]
+func GetProcessedTxIDKey(txID uint64) []byte {
+	return append(ProcessedTxIDKey, UInt64Bytes(txID)...)
+}
+
+// GetOutgoingLogicCallKey returns the following key format
+// prefix     invalidation-id        invalidation-nonce
+// [0xde][0x1a2b3c...][0 0 0 0 0 0 0 1]
 func GetOutgoingLogicCallKey(invalidationId []byte, invalidationNonce uint64) []byte {
 	a := append(KeyOutgoingLogicCall, invalidationId...)
 	return append(a, UInt64Bytes(invalidationNonce)...)
 }
 
+// GetLogicConfirmKey returns the following key format
+// prefix     invalidation-id        invalidation-nonce      validator-address
+// [0xae][0x1a2b3c...][0 0 0 0 0 0 0 1][cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn]
 func GetLogicConfirmKey(invalidationId []byte, invalidationNonce uint64, validator sdk.AccAddress) []byte {
 	interm := append(KeyOutgoingLogicConfirm, invalidationId...)
 	interm = append(interm, UInt64Bytes(invalidationNonce)...)
 	return append(interm, validator.Bytes()...)
 }
+
+// GetLastBatchRequestHeightKey returns the following key format
+// prefix     eth-contract-address
+// [0xe][0xc783df8a850f42e7F7e57013759C285caa701eB6]
+func GetLastBatchRequestHeightKey(tokenContract string) []byte {
+	return append(LastBatchRequestHeightKey, []byte(tokenContract)...)
+}
+
+// GetDepositByEthSenderKey returns the following key format, the sender is lower cased
+// as ethereum addresses are not case sensitive
+// prefix      eth-sender                                   event-nonce
+// [0x10][0xc783df8a850f42e7f7e57013759c285caa701eb6][0 0 0 0 0 0 0 1]
+func GetDepositByEthSenderKey(ethSender string, eventNonce uint64) []byte {
+	return append(GetDepositByEthSenderPrefix(ethSender), UInt64Bytes(eventNonce)...)
+}
+
+// GetDepositByEthSenderPrefix returns the prefix of all deposits of the given ethereum sender
+func GetDepositByEthSenderPrefix(ethSender string) []byte {
+	return append(DepositByEthSenderKey, []byte(strings.ToLower(ethSender))...)
+}
+
+// GetBatchExecutionKey returns the following key format
+// prefix     eth-contract-address                     nonce
+// [0x11][0xc783df8a850f42e7F7e57013759C285caa701eB6][0 0 0 0 0 0 0 1]
+func GetBatchExecutionKey(tokenContract string, nonce uint64) []byte {
+	return append(append(BatchExecutionKey, []byte(tokenContract)...), UInt64Bytes(nonce)...)
+}
+
+// GetSkippedValsetNonceKey returns the following key format
+// prefix     nonce
+// [0x14][0 0 0 0 0 0 0 1]
+func GetSkippedValsetNonceKey(nonce uint64) []byte {
+	return append(SkippedValsetNonceKey, UInt64Bytes(nonce)...)
+}
+
+// GetBridgeStatsKey returns the following key format
+// prefix     eth-contract-address
+// [0x15][0xc783df8a850f42e7F7e57013759C285caa701eB6]
+func GetBridgeStatsKey(tokenContract string) []byte {
+	return append(BridgeStatsKey, []byte(tokenContract)...)
+}
+
+// GetWorkQueueKey returns the following key format
+// prefix task
+// [0x16][0x1]
+func GetWorkQueueKey(task byte) []byte {
+	return append(WorkQueueKey, task)
+}
+
+// GetPendingVoteResetKey returns the following key format
+// prefix     cosmos-validator
+// [0x17][cosmosvaloper1ahx7f8wyertuus9r20284ej0asrs085ceqtfnm]
+func GetPendingVoteResetKey(validator sdk.ValAddress) []byte {
+	return append(PendingVoteResetKey, validator.Bytes()...)
+}
+
+// GetRelayerKey returns the following key format, the address is lower cased as ethereum
+// addresses are not case sensitive
+// prefix     eth-address
+// [0x19][0xd041c41ea1bf0f006adbb6d2c9ef9d425de5ead7]
+func GetRelayerKey(ethAddress string) []byte {
+	return append(RelayerKey, []byte(strings.ToLower(ethAddress))...)
+}
+
+// GetRelayerByAccountKey returns the following key format
+// prefix     cosmos-account
+// [0x1a][cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn]
+func GetRelayerByAccountKey(relayer sdk.AccAddress) []byte {
+	return append(RelayerByAccountKey, relayer.Bytes()...)
+}
+
+// GetCancelledBatchKey returns the following key format
+// prefix     eth-contract-address                     nonce
+// [0x1b][0xc783df8a850f42e7F7e57013759C285caa701eB6][0 0 0 0 0 0 0 1]
+func GetCancelledBatchKey(tokenContract string, nonce uint64) []byte {
+	return append(append(CancelledBatchKey, []byte(tokenContract)...), UInt64Bytes(nonce)...)
+}
+
+// GetGrantKey returns the following key format
+// prefix   granter                                        grantee                                        msg-type-url
+// [0x1c][cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn][cosmos1990z7dqsvh8gthw9pa5sn4wuy2xrsd80mg5z6y][/gravity.v1.MsgSendToEth]
+// The addresses have a fixed length, an empty type url gives the prefix of the grants between the accounts.
+func GetGrantKey(granter, grantee sdk.AccAddress, msgTypeURL string) []byte {
+	return append(append(append(GrantKey, granter.Bytes()...), grantee.Bytes()...), []byte(msgTypeURL)...)
+}
+
+// GetOmnibusAccountKey returns the following key format
+// prefix     omnibus-account
+// [0x1d][cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn]
+func GetOmnibusAccountKey(account sdk.AccAddress) []byte {
+	return append(OmnibusAccountKey, account.Bytes()...)
+}
+
+// GetTokenQuirkKey returns the following key format
+// prefix     erc20 contract
+// [0x1e][0xc783df8a850f42e7F7e57013759C285caa701eB6]
+func GetTokenQuirkKey(tokenContract string) []byte {
+	return append(TokenQuirkKey, []byte(tokenContract)...)
+}
+
+// GetPausedTokenKey returns the following key format
+// prefix     lowercase erc20 contract
+// [0x1f][0xc783df8a850f42e7f7e57013759c285caa701eb6]
+func GetPausedTokenKey(tokenContract string) []byte {
+	return append(PausedTokenKey, []byte(strings.ToLower(tokenContract))...)
+}
+
+// GetBatchedTxKey returns the following key format
+// prefix     id
+// [0x20][0 0 0 0 0 0 0 1]
+func GetBatchedTxKey(txID uint64) []byte {
+	return append(BatchedTxKey, UInt64Bytes(txID)...)
+}
+
+// GetScheduledTransferKey returns the following key format
+// prefix     id
+// [0x21][0 0 0 0 0 0 0 1]
+func GetScheduledTransferKey(txID uint64) []byte {
+	return append(ScheduledTransferKey, UInt64Bytes(txID)...)
+}
+
+// GetValidatorOrchestratorKey returns the following key format
+// prefix     validator                    orchestrator
+// [0x22][cosmosvaloper1ahx7f8wyertuus9r20284ej0asrs085ceqtfnm][cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn]
+func GetValidatorOrchestratorKey(validator sdk.ValAddress, orchestrator sdk.AccAddress) []byte {
+	return append(GetValidatorOrchestratorPrefix(validator), orchestrator.Bytes()...)
+}
+
+// GetValidatorOrchestratorPrefix returns the prefix of the orchestrators of a validator
+func GetValidatorOrchestratorPrefix(validator sdk.ValAddress) []byte {
+	return append(append([]byte{}, ValidatorOrchestratorKey...), validator.Bytes()...)
+}
+
+// GetClaimResubmissionsKey returns the following key format
+// prefix     validator
+// [0x23][cosmosvaloper1ahx7f8wyertuus9r20284ej0asrs085ceqtfnm]
+func GetClaimResubmissionsKey(validator sdk.ValAddress) []byte {
+	return append(append([]byte{}, ClaimResubmissionsKey...), validator.Bytes()...)
+}
+
+// GetERC20DecimalsKey returns the following key format
+// prefix     erc20 contract
+// [0x24][0xc783df8a850f42e7F7e57013759C285caa701eB6]
+func GetERC20DecimalsKey(tokenContract string) []byte {
+	return append(append([]byte{}, ERC20DecimalsKey...), []byte(tokenContract)...)
+}
+
+// GetAccountActivityKey returns the following key format
+// prefix     account                                        height             id
+// [0x25][cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn][0 0 0 0 0 0 0 1][0 0 0 0 0 0 0 1]
+func GetAccountActivityKey(account sdk.AccAddress, height, id uint64) []byte {
+	return append(append(GetAccountActivityPrefix(account), UInt64Bytes(height)...), UInt64Bytes(id)...)
+}
+
+// GetAccountActivityByHeightKey returns the following key format
+// prefix     height                id
+// [0x27][0 0 0 0 0 0 0 1][0 0 0 0 0 0 0 1]
+func GetAccountActivityByHeightKey(height, id uint64) []byte {
+	return append(append(append([]byte{}, AccountActivityByHeightKey...), UInt64Bytes(height)...), UInt64Bytes(id)...)
+}
+
+// GetScheduledTransferByHeightKey returns the following key format
+// prefix     height                tx id
+// [0x29][0 0 0 0 0 0 0 1][0 0 0 0 0 0 0 1]
+func GetScheduledTransferByHeightKey(height, txID uint64) []byte {
+	return append(append(append([]byte{}, ScheduledTransferByHeightKey...), UInt64Bytes(height)...), UInt64Bytes(txID)...)
+}
+
+// GetScheduledTransferByTimeKey returns the following key format
+// prefix     unix time             tx id
+// [0x2a][0 0 0 0 0 0 0 1][0 0 0 0 0 0 0 1]
+func GetScheduledTransferByTimeKey(time, txID uint64) []byte {
+	return append(append(append([]byte{}, ScheduledTransferByTimeKey...), UInt64Bytes(time)...), UInt64Bytes(txID)...)
+}
+
+// GetOutgoingTxByExpirationHeightKey returns the following key format
+// prefix     height                tx id
+// [0x2b][0 0 0 0 0 0 0 1][0 0 0 0 0 0 0 1]
+func GetOutgoingTxByExpirationHeightKey(height, txID uint64) []byte {
+	return append(append(append([]byte{}, OutgoingTxByExpirationHeightKey...), UInt64Bytes(height)...), UInt64Bytes(txID)...)
+}
+
+// GetOutgoingTxByExpirationTimeKey returns the following key format
+// prefix     unix time             tx id
+// [0x2c][0 0 0 0 0 0 0 1][0 0 0 0 0 0 0 1]
+func GetOutgoingTxByExpirationTimeKey(time, txID uint64) []byte {
+	return append(append(append([]byte{}, OutgoingTxByExpirationTimeKey...), UInt64Bytes(time)...), UInt64Bytes(txID)...)
+}
+
+// GetPendingOutgoingTxByFeeKey returns the following key format, the fee amount has every byte
+// inverted so that the highest fee comes first
+// prefix     ^fee amount (32 bytes)   tx id
+// [0x2d][0xff 0xff ... 0xff 0xfe][0 0 0 0 0 0 0 1]
+func GetPendingOutgoingTxByFeeKey(fee sdk.Int, txID uint64) []byte {
+	amount := fee.BigInt().FillBytes(make([]byte, 32))
+	for i := range amount {
+		amount[i] = ^amount[i]
+	}
+	return append(append(append([]byte{}, PendingOutgoingTxByFeeKey...), amount...), UInt64Bytes(txID)...)
+}
+
+// GetClaimEthereumHeightByValidatorKey returns the following key format
+// prefix     validator address
+// [0x2e][cosmosvaloper1ahx7f8wyertuus9r20284ej0asrs085case3kn]
+func GetClaimEthereumHeightByValidatorKey(validator sdk.ValAddress) []byte {
+	return append(append([]byte{}, ClaimEthereumHeightByValidatorKey...), validator.Bytes()...)
+}
+
+// GetAccountActivityPrefix returns the prefix of the ledger of an account
+func GetAccountActivityPrefix(account sdk.AccAddress) []byte {
+	return append(append([]byte{}, AccountActivityKey...), account.Bytes()...)
+}
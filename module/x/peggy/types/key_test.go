@@ -0,0 +1,87 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyPrefixesDisjoint(t *testing.T) {
+	require.NoError(t, ValidateKeyPrefixes(KeyPrefixes))
+
+	// a single byte prefix shadows every longer prefix starting with it
+	err := ValidateKeyPrefixes(append(KeyPrefixes, KeyPrefix{"Shadowed", []byte{0xf1, 0x1}}))
+	assert.Error(t, err)
+	assert.Error(t, ValidateKeyPrefixes([]KeyPrefix{{"Empty", nil}}))
+}
+
+func TestKeysUseRegisteredPrefix(t *testing.T) {
+	var (
+		valAddr       = sdk.ValAddress(bytes.Repeat([]byte{1}, sdk.AddrLen))
+		accAddr       = sdk.AccAddress(bytes.Repeat([]byte{2}, sdk.AddrLen))
+		tokenContract = "0xc783df8a850f42e7F7e57013759C285caa701eB6"
+		claim         = &MsgDepositClaim{EventNonce: 1, TokenContract: tokenContract, Amount: sdk.NewInt(1)}
+	)
+	keys := map[string][]byte{
+		"KeyOrchestratorAddress":            GetOrchestratorAddressKey(accAddr),
+		"EthAddressKey":                     GetEthAddressKey(valAddr),
+		"ValsetRequestKey":                  GetValsetKey(1),
+		"ValsetConfirmKey":                  GetValsetConfirmKey(1, accAddr),
+		"OracleAttestationKey":              GetAttestationKey(1, claim.ClaimHash()),
+		"OutgoingTXPoolKey":                 GetOutgoingTxPoolKey(1),
+		"OutgoingTXBatchKey":                GetOutgoingTxBatchKey(tokenContract, 1),
+		"OutgoingTXBatchBlockKey":           GetOutgoingTxBatchBlockKey(1),
+		"BatchConfirmKey":                   GetBatchConfirmKey(tokenContract, 1, accAddr),
+		"SecondIndexOutgoingTXFeeKey":       GetFeeSecondIndexKey(*NewERC20Token(1, tokenContract), 1),
+		"LastEventNonceByValidatorKey":      GetLastEventNonceByValidatorKey(valAddr),
+		"DenomToERC20Key":                   GetDenomToERC20Key("uatom"),
+		"ERC20ToDenomKey":                   GetERC20ToDenomKey(tokenContract),
+		"ReclaimableDepositKey":             GetReclaimableDepositKey(1),
+		"ProcessedTxIDKey":                  GetProcessedTxIDKey(1),
+		"KeyOutgoingLogicCall":              GetOutgoingLogicCallKey([]byte{1}, 1),
+		"KeyOutgoingLogicConfirm":           GetLogicConfirmKey([]byte{1}, 1, accAddr),
+		"LastBatchRequestHeightKey":         GetLastBatchRequestHeightKey(tokenContract),
+		"DepositByEthSenderKey":             GetDepositByEthSenderKey(tokenContract, 1),
+		"BatchExecutionKey":                 GetBatchExecutionKey(tokenContract, 1),
+		"SkippedValsetNonceKey":             GetSkippedValsetNonceKey(1),
+		"BridgeStatsKey":                    GetBridgeStatsKey(tokenContract),
+		"WorkQueueKey":                      GetWorkQueueKey(1),
+		"PendingVoteResetKey":               GetPendingVoteResetKey(valAddr),
+		"RelayerKey":                        GetRelayerKey(tokenContract),
+		"RelayerByAccountKey":               GetRelayerByAccountKey(accAddr),
+		"CancelledBatchKey":                 GetCancelledBatchKey(tokenContract, 1),
+		"GrantKey":                          GetGrantKey(accAddr, accAddr, "/gravity.v1.MsgSendToEth"),
+		"OmnibusAccountKey":                 GetOmnibusAccountKey(accAddr),
+		"TokenQuirkKey":                     GetTokenQuirkKey(tokenContract),
+		"PausedTokenKey":                    GetPausedTokenKey(tokenContract),
+		"BatchedTxKey":                      GetBatchedTxKey(1),
+		"ScheduledTransferKey":              GetScheduledTransferKey(1),
+		"ERC20DecimalsKey":                  GetERC20DecimalsKey(tokenContract),
+		"AccountActivityKey":                GetAccountActivityKey(accAddr, 1, 1),
+		"AccountActivityByHeightKey":        GetAccountActivityByHeightKey(1, 1),
+		"ScheduledTransferByHeightKey":      GetScheduledTransferByHeightKey(1, 1),
+		"ScheduledTransferByTimeKey":        GetScheduledTransferByTimeKey(1, 1),
+		"OutgoingTxByExpirationHeightKey":   GetOutgoingTxByExpirationHeightKey(1, 1),
+		"OutgoingTxByExpirationTimeKey":     GetOutgoingTxByExpirationTimeKey(1, 1),
+		"PendingOutgoingTxByFeeKey":         GetPendingOutgoingTxByFeeKey(sdk.NewInt(1), 1),
+		"ClaimEthereumHeightByValidatorKey": GetClaimEthereumHeightByValidatorKey(valAddr),
+		"SequenceKeyPrefix":                 KeyLastTXPoolID,
+	}
+	assert.True(t, bytes.HasPrefix(KeyLastOutgoingBatchID, SequenceKeyPrefix))
+	assert.True(t, bytes.HasPrefix(KeyLastAccountActivityID, SequenceKeyPrefix))
+
+	registered := make(map[string][]byte)
+	for _, p := range KeyPrefixes {
+		registered[p.Name] = p.Prefix
+	}
+	for name, key := range keys {
+		prefix, ok := registered[name]
+		require.True(t, ok, name)
+		assert.True(t, bytes.HasPrefix(key, prefix), name)
+		assert.Equal(t, name, KeyPrefixName(key))
+	}
+	assert.Empty(t, KeyPrefixName(nil))
+}
@@ -3,9 +3,13 @@ package types
 import (
 	"encoding/hex"
 	"fmt"
+	"math"
+	"strings"
 
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 )
 
@@ -18,8 +22,26 @@ var (
 	_ sdk.Msg = &MsgERC20DeployedClaim{}
 	_ sdk.Msg = &MsgConfirmLogicCall{}
 	_ sdk.Msg = &MsgLogicCallExecutedClaim{}
+	_ sdk.Msg = &MsgGenericEventClaim{}
 	_ sdk.Msg = &MsgDepositClaim{}
 	_ sdk.Msg = &MsgWithdrawClaim{}
+	_ sdk.Msg = &MsgUpdateParams{}
+	_ sdk.Msg = &MsgRegisterRelayer{}
+	_ sdk.Msg = &MsgCancelBatch{}
+	_ sdk.Msg = &MsgGrant{}
+	_ sdk.Msg = &MsgRevoke{}
+	_ sdk.Msg = &MsgExec{}
+	_ sdk.Msg = &MsgSetLastObservedEventNonce{}
+	_ sdk.Msg = &MsgMultiSendToEth{}
+	_ sdk.Msg = &MsgRegisterOmnibusAccount{}
+	_ sdk.Msg = &MsgSweepOmnibusSubAccounts{}
+	_ sdk.Msg = &MsgPauseToken{}
+	_ sdk.Msg = &MsgUnpauseToken{}
+	_ sdk.Msg = &MsgRevokeOrchestratorAddress{}
+	_ sdk.Msg = &MsgSetCosmosOriginatedERC20{}
+
+	_ codectypes.UnpackInterfacesMessage = &MsgGrant{}
+	_ codectypes.UnpackInterfacesMessage = &MsgExec{}
 )
 
 // NewMsgSetOrchestratorAddress returns a new msgSetOrchestratorAddress
@@ -89,6 +111,9 @@ func (msg *MsgValsetConfirm) ValidateBasic() (err error) {
 	if err := ValidateEthAddress(msg.EthAddress); err != nil {
 		return sdkerrors.Wrap(err, "ethereum address")
 	}
+	if _, err := hex.DecodeString(msg.Signature); err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "Could not decode hex string %s", msg.Signature)
+	}
 	return nil
 }
 
@@ -144,6 +169,13 @@ func (msg MsgSendToEth) ValidateBasic() error {
 	if err := ValidateEthAddress(msg.EthDest); err != nil {
 		return sdkerrors.Wrap(err, "ethereum address")
 	}
+	// a transfer expiring before it may be batched would only be refunded
+	if msg.ExpirationHeight != 0 && msg.ExpirationHeight <= msg.ExecuteAfterHeight {
+		return sdkerrors.Wrap(ErrInvalid, "expiration height is not after the execute after height")
+	}
+	if msg.ExpirationTime != 0 && msg.ExpirationTime <= msg.ExecuteAfterTime {
+		return sdkerrors.Wrap(ErrInvalid, "expiration time is not after the execute after time")
+	}
 	// TODO validate fee is sufficient, fixed fee to start
 	return nil
 }
@@ -163,6 +195,57 @@ func (msg MsgSendToEth) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{acc}
 }
 
+// NewMsgMultiSendToEth returns a new MsgMultiSendToEth
+func NewMsgMultiSendToEth(sender sdk.AccAddress, sends []SendToEthEntry) *MsgMultiSendToEth {
+	return &MsgMultiSendToEth{
+		Sender: sender.String(),
+		Sends:  sends,
+	}
+}
+
+// Route should return the name of the module
+func (msg *MsgMultiSendToEth) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg *MsgMultiSendToEth) Type() string { return "multi_send_to_eth" }
+
+// ValidateBasic runs the checks of MsgSendToEth on every entry
+func (msg *MsgMultiSendToEth) ValidateBasic() error {
+	if len(msg.Sends) == 0 {
+		return sdkerrors.Wrap(ErrInvalid, "no transfers")
+	}
+	for i, send := range msg.Sends {
+		if err := msg.SendToEth(send).ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "transfer %d", i)
+		}
+	}
+	return nil
+}
+
+// SendToEth returns the entry as a MsgSendToEth of the sender
+func (msg *MsgMultiSendToEth) SendToEth(send SendToEthEntry) *MsgSendToEth {
+	return &MsgSendToEth{
+		Sender:    msg.Sender,
+		EthDest:   send.EthDest,
+		Amount:    send.Amount,
+		BridgeFee: send.BridgeFee,
+	}
+}
+
+// GetSignBytes encodes the message for signing
+func (msg *MsgMultiSendToEth) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg *MsgMultiSendToEth) GetSigners() []sdk.AccAddress {
+	acc, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{acc}
+}
+
 // NewMsgRequestBatch returns a new msgRequestBatch
 func NewMsgRequestBatch(orchestrator sdk.AccAddress) *MsgRequestBatch {
 	return &MsgRequestBatch{
@@ -181,6 +264,9 @@ func (msg MsgRequestBatch) ValidateBasic() error {
 	if _, err := sdk.AccAddressFromBech32(msg.Orchestrator); err != nil {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Orchestrator)
 	}
+	if err := sdk.ValidateDenom(msg.Denom); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, msg.Denom)
+	}
 	return nil
 }
 
@@ -294,15 +380,43 @@ type EthereumClaim interface {
 	GetClaimer() sdk.AccAddress
 	// Which type of claim this is
 	GetType() ClaimType
+	// The bridge contract and Ethereum chain the orchestrator observed the event on, claims
+	// are rejected unless they match the bridge params so that an orchestrator watching the
+	// wrong contract or chain can't attest to its events
+	GetBridgeContractAddress() string
+	GetBridgeChainId() uint64
 	ValidateBasic() error
 	ClaimHash() []byte
 }
 
+// claimHash hashes the type and the consensus relevant fields of a claim, it is the key of the
+// attestation the claim votes on. The orchestrator submitting the claim is left out so that all
+// orchestrators reporting the same event vote on the same attestation, Ethereum addresses and
+// hashes must be passed lower cased since their case carries no meaning. Strings are quoted so
+// that separators within a field can't make two different claims hash the same.
+func claimHash(claimType ClaimType, fields ...interface{}) []byte {
+	var path strings.Builder
+	path.WriteString(claimType.String())
+	for _, field := range fields {
+		switch field := field.(type) {
+		case string:
+			fmt.Fprintf(&path, "/%q", field)
+		case []byte:
+			fmt.Fprintf(&path, "/%x", field)
+		default:
+			fmt.Fprintf(&path, "/%v", field)
+		}
+	}
+	return tmhash.Sum([]byte(path.String()))
+}
+
 var (
 	_ EthereumClaim = &MsgDepositClaim{}
 	_ EthereumClaim = &MsgWithdrawClaim{}
 	_ EthereumClaim = &MsgERC20DeployedClaim{}
 	_ EthereumClaim = &MsgLogicCallExecutedClaim{}
+	_ EthereumClaim = &MsgGenericEventClaim{}
+	_ EthereumClaim = &MsgValsetUpdatedClaim{}
 )
 
 // GetType returns the type of the claim
@@ -311,10 +425,9 @@ func (e *MsgDepositClaim) GetType() ClaimType {
 }
 
 // ValidateBasic performs stateless checks
+// The cosmos receiver is not checked here, deposits to an invalid receiver
+// still happened on Ethereum and must be attested so they can be reclaimed
 func (e *MsgDepositClaim) ValidateBasic() error {
-	if _, err := sdk.AccAddressFromBech32(e.CosmosReceiver); err != nil {
-		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, e.CosmosReceiver)
-	}
 	if err := ValidateEthAddress(e.EthereumSender); err != nil {
 		return sdkerrors.Wrap(err, "eth sender")
 	}
@@ -327,9 +440,32 @@ func (e *MsgDepositClaim) ValidateBasic() error {
 	if e.EventNonce == 0 {
 		return fmt.Errorf("nonce == 0")
 	}
+	if e.DestinationTag != "" {
+		if err := ValidateDestinationTag(e.DestinationTag); err != nil {
+			return err
+		}
+	}
+	if e.ReceivedAmount != nil && e.ReceivedAmount.IsNegative() {
+		return sdkerrors.Wrap(ErrInvalid, "negative received amount")
+	}
 	return nil
 }
 
+// HasDiscrepancy returns true if the bridge contract received a different amount than the deposit event
+// reported
+func (e *MsgDepositClaim) HasDiscrepancy() bool {
+	return e.ReceivedAmount != nil && !e.ReceivedAmount.Equal(e.Amount)
+}
+
+// CreditedAmount returns the amount credited for the deposit, the received amount if it is below the
+// reported one. More than reported is never credited.
+func (e *MsgDepositClaim) CreditedAmount() sdk.Int {
+	if e.HasDiscrepancy() && e.ReceivedAmount.LT(e.Amount) {
+		return *e.ReceivedAmount
+	}
+	return e.Amount
+}
+
 // GetSignBytes encodes the message for signing
 func (msg MsgDepositClaim) GetSignBytes() []byte {
 	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
@@ -367,8 +503,19 @@ const (
 
 // Hash implements BridgeDeposit.Hash
 func (b *MsgDepositClaim) ClaimHash() []byte {
-	path := fmt.Sprintf("%s/%s/%s/", b.TokenContract, string(b.EthereumSender), b.CosmosReceiver)
-	return tmhash.Sum([]byte(path))
+	return claimHash(b.GetType(), b.EventNonce, b.BlockHeight, strings.ToLower(b.TokenContract), b.Amount,
+		strings.ToLower(b.EthereumSender), b.CosmosReceiver, strings.ToLower(b.BridgeContractAddress), b.BridgeChainId, b.DestinationTag,
+		b.hashedReceivedAmount())
+}
+
+// hashedReceivedAmount returns the received amount as part of the claim hash. A received amount equal to
+// the amount is hashed like a missing one so that orchestrators that don't check the Transfer logs attest
+// the same deposit for ordinary tokens.
+func (b *MsgDepositClaim) hashedReceivedAmount() string {
+	if !b.HasDiscrepancy() {
+		return ""
+	}
+	return b.ReceivedAmount.String()
 }
 
 // GetType returns the claim type
@@ -387,16 +534,22 @@ func (e *MsgWithdrawClaim) ValidateBasic() error {
 	if err := ValidateEthAddress(e.TokenContract); err != nil {
 		return sdkerrors.Wrap(err, "erc20 token")
 	}
+	if e.EthTxHash != "" {
+		if err := ValidateEthTxHash(e.EthTxHash); err != nil {
+			return sdkerrors.Wrap(err, "eth tx hash")
+		}
+	}
 	if _, err := sdk.AccAddressFromBech32(e.Orchestrator); err != nil {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, e.Orchestrator)
 	}
 	return nil
 }
 
-// Hash implements WithdrawBatch.Hash
+// Hash implements WithdrawBatch.Hash, the optional ethereum tx hash is left out so that
+// orchestrators that don't report it vote for the same claim as the ones that do
 func (b *MsgWithdrawClaim) ClaimHash() []byte {
-	path := fmt.Sprintf("%s/%d/", b.TokenContract, b.BatchNonce)
-	return tmhash.Sum([]byte(path))
+	return claimHash(b.GetType(), b.EventNonce, b.BlockHeight, b.BatchNonce, strings.ToLower(b.TokenContract),
+		strings.ToLower(b.BridgeContractAddress), b.BridgeChainId)
 }
 
 // GetSignBytes encodes the message for signing
@@ -488,8 +641,8 @@ func (msg MsgERC20DeployedClaim) Route() string { return RouterKey }
 
 // Hash implements BridgeDeposit.Hash
 func (b *MsgERC20DeployedClaim) ClaimHash() []byte {
-	path := fmt.Sprintf("%s/%s/%s/%s/%d/", b.CosmosDenom, b.TokenContract, b.Name, b.Symbol, b.Decimals)
-	return tmhash.Sum([]byte(path))
+	return claimHash(b.GetType(), b.EventNonce, b.BlockHeight, b.CosmosDenom, strings.ToLower(b.TokenContract),
+		b.Name, b.Symbol, b.Decimals, strings.ToLower(b.BridgeContractAddress), b.BridgeChainId)
 }
 
 // EthereumClaim implementation for MsgLogicCallExecutedClaim
@@ -544,14 +697,142 @@ func (msg MsgLogicCallExecutedClaim) Route() string { return RouterKey }
 
 // Hash implements BridgeDeposit.Hash
 func (b *MsgLogicCallExecutedClaim) ClaimHash() []byte {
-	path := fmt.Sprintf("%s/%d/", b.InvalidationId, b.InvalidationNonce)
-	return tmhash.Sum([]byte(path))
+	return claimHash(b.GetType(), b.EventNonce, b.BlockHeight, b.InvalidationId, b.InvalidationNonce,
+		strings.ToLower(b.BridgeContractAddress), b.BridgeChainId)
 }
 
-// NewMsgSetOrchestratorAddress returns a new msgSetOrchestratorAddress
-func NewMsgCancelSendToEth(val sdk.ValAddress, id uint64) *MsgCancelSendToEth {
+// EthereumClaim implementation for MsgGenericEventClaim
+// ======================================================
+
+// GetType returns the type of the claim
+func (e *MsgGenericEventClaim) GetType() ClaimType {
+	return CLAIM_TYPE_GENERIC_EVENT
+}
+
+// ValidateBasic performs stateless checks
+func (e *MsgGenericEventClaim) ValidateBasic() error {
+	if err := ValidateEthAddress(e.ContractAddress); err != nil {
+		return sdkerrors.Wrap(err, "contract address")
+	}
+	if len(e.Topic) != 32 {
+		return sdkerrors.Wrap(ErrInvalid, "topic must be 32 bytes")
+	}
+	if _, err := sdk.AccAddressFromBech32(e.Orchestrator); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, e.Orchestrator)
+	}
+	if e.EventNonce == 0 {
+		return fmt.Errorf("nonce == 0")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgGenericEventClaim) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgGenericEventClaim) GetClaimer() sdk.AccAddress {
+	err := msg.ValidateBasic()
+	if err != nil {
+		panic("MsgGenericEventClaim failed ValidateBasic! Should have been handled earlier")
+	}
+
+	val, _ := sdk.AccAddressFromBech32(msg.Orchestrator)
+	return val
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgGenericEventClaim) GetSigners() []sdk.AccAddress {
+	acc, err := sdk.AccAddressFromBech32(msg.Orchestrator)
+	if err != nil {
+		panic(err)
+	}
+
+	return []sdk.AccAddress{acc}
+}
+
+// Type should return the action
+func (msg MsgGenericEventClaim) Type() string { return "generic_event_claim" }
+
+// Route should return the name of the module
+func (msg MsgGenericEventClaim) Route() string { return RouterKey }
+
+// ClaimHash implements EthereumClaim.ClaimHash, the contract address is not case sensitive
+func (b *MsgGenericEventClaim) ClaimHash() []byte {
+	return claimHash(b.GetType(), b.EventNonce, b.BlockHeight, strings.ToLower(b.ContractAddress), b.Topic, b.Data,
+		strings.ToLower(b.BridgeContractAddress), b.BridgeChainId)
+}
+
+// EthereumClaim implementation for MsgValsetUpdatedClaim
+// ======================================================
+
+// GetType returns the type of the claim
+func (e *MsgValsetUpdatedClaim) GetType() ClaimType {
+	return CLAIM_TYPE_VALSET_UPDATED
+}
+
+// ValidateBasic performs stateless checks
+func (e *MsgValsetUpdatedClaim) ValidateBasic() error {
+	for _, member := range e.Members {
+		if err := member.ValidateBasic(); err != nil {
+			return sdkerrors.Wrap(err, "member")
+		}
+	}
+	if _, err := sdk.AccAddressFromBech32(e.Orchestrator); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, e.Orchestrator)
+	}
+	if e.EventNonce == 0 {
+		return fmt.Errorf("nonce == 0")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgValsetUpdatedClaim) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+func (msg MsgValsetUpdatedClaim) GetClaimer() sdk.AccAddress {
+	err := msg.ValidateBasic()
+	if err != nil {
+		panic("MsgValsetUpdatedClaim failed ValidateBasic! Should have been handled earlier")
+	}
+
+	val, _ := sdk.AccAddressFromBech32(msg.Orchestrator)
+	return val
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgValsetUpdatedClaim) GetSigners() []sdk.AccAddress {
+	acc, err := sdk.AccAddressFromBech32(msg.Orchestrator)
+	if err != nil {
+		panic(err)
+	}
+
+	return []sdk.AccAddress{acc}
+}
+
+// Type should return the action
+func (msg MsgValsetUpdatedClaim) Type() string { return "valset_updated_claim" }
+
+// Route should return the name of the module
+func (msg MsgValsetUpdatedClaim) Route() string { return RouterKey }
+
+// ClaimHash implements EthereumClaim.ClaimHash, the members are hashed in their order on the contract
+func (b *MsgValsetUpdatedClaim) ClaimHash() []byte {
+	fields := []interface{}{b.EventNonce, b.BlockHeight, b.ValsetNonce}
+	for _, member := range b.Members {
+		fields = append(fields, strings.ToLower(member.EthereumAddress), member.Power)
+	}
+	fields = append(fields, strings.ToLower(b.BridgeContractAddress), b.BridgeChainId)
+	return claimHash(b.GetType(), fields...)
+}
+
+// NewMsgCancelSendToEth returns a new MsgCancelSendToEth
+func NewMsgCancelSendToEth(sender sdk.AccAddress, id uint64) *MsgCancelSendToEth {
 	return &MsgCancelSendToEth{
 		TransactionId: id,
+		Sender:        sender.String(),
 	}
 }
 
@@ -563,9 +844,9 @@ func (msg *MsgCancelSendToEth) Type() string { return "cancel_send_to_eth" }
 
 // ValidateBasic performs stateless checks
 func (msg *MsgCancelSendToEth) ValidateBasic() (err error) {
-	_, err = sdk.ValAddressFromBech32(msg.Sender)
+	_, err = sdk.AccAddressFromBech32(msg.Sender)
 	if err != nil {
-		return err
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Sender)
 	}
 	return nil
 }
@@ -577,9 +858,656 @@ func (msg *MsgCancelSendToEth) GetSignBytes() []byte {
 
 // GetSigners defines whose signature is required
 func (msg *MsgCancelSendToEth) GetSigners() []sdk.AccAddress {
-	acc, err := sdk.ValAddressFromBech32(msg.Sender)
+	acc, err := sdk.AccAddressFromBech32(msg.Sender)
 	if err != nil {
 		panic(err)
 	}
-	return []sdk.AccAddress{sdk.AccAddress(acc)}
+	return []sdk.AccAddress{acc}
+}
+
+// NewMsgBumpSendToEthFee returns a new MsgBumpSendToEthFee
+func NewMsgBumpSendToEthFee(sender sdk.AccAddress, id uint64, additionalFee sdk.Coin) *MsgBumpSendToEthFee {
+	return &MsgBumpSendToEthFee{
+		TransactionId: id,
+		Sender:        sender.String(),
+		AdditionalFee: additionalFee,
+	}
+}
+
+// Route should return the name of the module
+func (msg *MsgBumpSendToEthFee) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg *MsgBumpSendToEthFee) Type() string { return "bump_send_to_eth_fee" }
+
+// ValidateBasic performs stateless checks
+func (msg *MsgBumpSendToEthFee) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Sender)
+	}
+	if !msg.AdditionalFee.IsValid() || msg.AdditionalFee.IsZero() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "additional fee")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg *MsgBumpSendToEthFee) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg *MsgBumpSendToEthFee) GetSigners() []sdk.AccAddress {
+	acc, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{acc}
+}
+
+// NewMsgUpdateParams returns a new MsgUpdateParams
+func NewMsgUpdateParams(authority sdk.AccAddress, params Params) *MsgUpdateParams {
+	return &MsgUpdateParams{
+		Authority: authority.String(),
+		Params:    params,
+	}
+}
+
+// Route should return the name of the module
+func (msg *MsgUpdateParams) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg *MsgUpdateParams) Type() string { return "update_params" }
+
+// ValidateBasic performs stateless checks
+func (msg *MsgUpdateParams) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Authority)
+	}
+	return sdkerrors.Wrap(msg.Params.Validate(), "params")
+}
+
+// GetSignBytes encodes the message for signing
+func (msg *MsgUpdateParams) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg *MsgUpdateParams) GetSigners() []sdk.AccAddress {
+	acc, err := sdk.AccAddressFromBech32(msg.Authority)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{acc}
+}
+
+// NewMsgRegisterRelayer returns a new MsgRegisterRelayer
+func NewMsgRegisterRelayer(relayer sdk.AccAddress, ethAddress string, ethSignature []byte) *MsgRegisterRelayer {
+	return &MsgRegisterRelayer{
+		Relayer:      relayer.String(),
+		EthAddress:   ethAddress,
+		EthSignature: hex.EncodeToString(ethSignature),
+	}
+}
+
+// Route should return the name of the module
+func (msg *MsgRegisterRelayer) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg *MsgRegisterRelayer) Type() string { return "register_relayer" }
+
+// ValidateBasic performs stateless checks
+func (msg *MsgRegisterRelayer) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Relayer); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Relayer)
+	}
+	if err := ValidateEthAddress(msg.EthAddress); err != nil {
+		return sdkerrors.Wrap(err, "ethereum address")
+	}
+	if sig, err := hex.DecodeString(msg.EthSignature); err != nil || len(sig) == 0 {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "Could not decode hex string %s", msg.EthSignature)
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg *MsgRegisterRelayer) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg *MsgRegisterRelayer) GetSigners() []sdk.AccAddress {
+	acc, err := sdk.AccAddressFromBech32(msg.Relayer)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{acc}
+}
+
+// NewMsgCancelBatch returns a new MsgCancelBatch
+func NewMsgCancelBatch(sender sdk.AccAddress, tokenContract string, nonce uint64) *MsgCancelBatch {
+	return &MsgCancelBatch{
+		Sender:        sender.String(),
+		TokenContract: tokenContract,
+		Nonce:         nonce,
+	}
+}
+
+// Route should return the name of the module
+func (msg *MsgCancelBatch) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg *MsgCancelBatch) Type() string { return "cancel_batch" }
+
+// ValidateBasic performs stateless checks
+func (msg *MsgCancelBatch) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Sender)
+	}
+	if err := ValidateEthAddress(msg.TokenContract); err != nil {
+		return sdkerrors.Wrap(err, "token contract")
+	}
+	if msg.Nonce == 0 {
+		return sdkerrors.Wrap(ErrInvalid, "batch nonce")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg *MsgCancelBatch) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg *MsgCancelBatch) GetSigners() []sdk.AccAddress {
+	acc, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{acc}
+}
+
+// NewMsgGrant returns a new MsgGrant
+func NewMsgGrant(granter, grantee sdk.AccAddress, authorization Authorization, expirationTime uint64) (*MsgGrant, error) {
+	grant, err := NewGrant(authorization, expirationTime)
+	if err != nil {
+		return nil, err
+	}
+	return &MsgGrant{
+		Granter: granter.String(),
+		Grantee: grantee.String(),
+		Grant:   grant,
+	}, nil
+}
+
+// Route should return the name of the module
+func (msg *MsgGrant) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg *MsgGrant) Type() string { return "grant" }
+
+// ValidateBasic performs stateless checks
+func (msg *MsgGrant) ValidateBasic() error {
+	granter, err := sdk.AccAddressFromBech32(msg.Granter)
+	if err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Granter)
+	}
+	grantee, err := sdk.AccAddressFromBech32(msg.Grantee)
+	if err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Grantee)
+	}
+	if granter.Equals(grantee) {
+		return sdkerrors.Wrap(ErrInvalid, "granter and grantee can't be the same")
+	}
+	return msg.Grant.ValidateBasic()
+}
+
+// GetSignBytes encodes the message for signing
+func (msg *MsgGrant) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg *MsgGrant) GetSigners() []sdk.AccAddress {
+	acc, err := sdk.AccAddressFromBech32(msg.Granter)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{acc}
+}
+
+// UnpackInterfaces implements UnpackInterfacesMessage
+func (msg *MsgGrant) UnpackInterfaces(unpacker codectypes.AnyUnpacker) error {
+	return msg.Grant.UnpackInterfaces(unpacker)
+}
+
+// NewMsgRevoke returns a new MsgRevoke
+func NewMsgRevoke(granter, grantee sdk.AccAddress, msgTypeURL string) *MsgRevoke {
+	return &MsgRevoke{
+		Granter:    granter.String(),
+		Grantee:    grantee.String(),
+		MsgTypeUrl: msgTypeURL,
+	}
+}
+
+// Route should return the name of the module
+func (msg *MsgRevoke) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg *MsgRevoke) Type() string { return "revoke" }
+
+// ValidateBasic performs stateless checks
+func (msg *MsgRevoke) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Granter); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Granter)
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Grantee); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Grantee)
+	}
+	if msg.MsgTypeUrl == "" {
+		return sdkerrors.Wrap(ErrInvalid, "msg type url")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg *MsgRevoke) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg *MsgRevoke) GetSigners() []sdk.AccAddress {
+	acc, err := sdk.AccAddressFromBech32(msg.Granter)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{acc}
+}
+
+// NewMsgExec returns a new MsgExec
+func NewMsgExec(grantee sdk.AccAddress, msgs []sdk.Msg) (*MsgExec, error) {
+	anys := make([]*codectypes.Any, len(msgs))
+	for i, m := range msgs {
+		any, err := codectypes.NewAnyWithValue(m)
+		if err != nil {
+			return nil, err
+		}
+		anys[i] = any
+	}
+	return &MsgExec{
+		Grantee: grantee.String(),
+		Msgs:    anys,
+	}, nil
+}
+
+// GetMessages returns the unpacked messages to execute
+func (msg *MsgExec) GetMessages() ([]sdk.Msg, error) {
+	msgs := make([]sdk.Msg, len(msg.Msgs))
+	for i, any := range msg.Msgs {
+		m, ok := any.GetCachedValue().(sdk.Msg)
+		if !ok {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidType, "message %d is not a sdk.Msg", i)
+		}
+		msgs[i] = m
+	}
+	return msgs, nil
+}
+
+// Route should return the name of the module
+func (msg *MsgExec) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg *MsgExec) Type() string { return "exec" }
+
+// ValidateBasic performs stateless checks
+func (msg *MsgExec) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Grantee); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Grantee)
+	}
+	if len(msg.Msgs) == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "messages")
+	}
+	msgs, err := msg.GetMessages()
+	if err != nil {
+		return err
+	}
+	for i, m := range msgs {
+		if _, ok := m.(*MsgExec); ok {
+			return sdkerrors.Wrap(ErrInvalid, "nested exec")
+		}
+		// GetSigners panics on the malformed addresses ValidateBasic rejects
+		if err := m.ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "message %d", i)
+		}
+		if len(m.GetSigners()) != 1 {
+			return sdkerrors.Wrapf(ErrInvalid, "message %d must have a single signer", i)
+		}
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg *MsgExec) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg *MsgExec) GetSigners() []sdk.AccAddress {
+	acc, err := sdk.AccAddressFromBech32(msg.Grantee)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{acc}
+}
+
+// UnpackInterfaces implements UnpackInterfacesMessage
+func (msg *MsgExec) UnpackInterfaces(unpacker codectypes.AnyUnpacker) error {
+	for _, any := range msg.Msgs {
+		var m sdk.Msg
+		if err := unpacker.UnpackAny(any, &m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RelayerRegistrationHash returns the hash the ethereum key of a relayer personal_signs to register
+// with the cosmos account, the gravity id salts it so a registration can't be replayed on another bridge
+func RelayerRegistrationHash(gravityID string, relayer sdk.AccAddress) []byte {
+	return crypto.Keccak256([]byte(gravityID), []byte("registerRelayer"), relayer.Bytes())
+}
+
+// NewMsgSetLastObservedEventNonce returns a new MsgSetLastObservedEventNonce
+func NewMsgSetLastObservedEventNonce(authority sdk.AccAddress, nonce uint64) *MsgSetLastObservedEventNonce {
+	return &MsgSetLastObservedEventNonce{
+		Authority: authority.String(),
+		Nonce:     nonce,
+	}
+}
+
+// Route should return the name of the module
+func (msg *MsgSetLastObservedEventNonce) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg *MsgSetLastObservedEventNonce) Type() string { return "set_last_observed_event_nonce" }
+
+// ValidateBasic performs stateless checks
+func (msg *MsgSetLastObservedEventNonce) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Authority)
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg *MsgSetLastObservedEventNonce) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg *MsgSetLastObservedEventNonce) GetSigners() []sdk.AccAddress {
+	acc, err := sdk.AccAddressFromBech32(msg.Authority)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{acc}
+}
+
+// NewMsgRegisterOmnibusAccount returns a new MsgRegisterOmnibusAccount
+func NewMsgRegisterOmnibusAccount(account sdk.AccAddress, deregister bool) *MsgRegisterOmnibusAccount {
+	return &MsgRegisterOmnibusAccount{
+		Account:    account.String(),
+		Deregister: deregister,
+	}
+}
+
+// Route should return the name of the module
+func (msg *MsgRegisterOmnibusAccount) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg *MsgRegisterOmnibusAccount) Type() string { return "register_omnibus_account" }
+
+// ValidateBasic performs stateless checks
+func (msg *MsgRegisterOmnibusAccount) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Account); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Account)
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg *MsgRegisterOmnibusAccount) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg *MsgRegisterOmnibusAccount) GetSigners() []sdk.AccAddress {
+	acc, err := sdk.AccAddressFromBech32(msg.Account)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{acc}
+}
+
+// NewMsgSweepOmnibusSubAccounts returns a new MsgSweepOmnibusSubAccounts
+func NewMsgSweepOmnibusSubAccounts(omnibus sdk.AccAddress, tags []string) *MsgSweepOmnibusSubAccounts {
+	return &MsgSweepOmnibusSubAccounts{
+		Omnibus: omnibus.String(),
+		Tags:    tags,
+	}
+}
+
+// Route should return the name of the module
+func (msg *MsgSweepOmnibusSubAccounts) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg *MsgSweepOmnibusSubAccounts) Type() string { return "sweep_omnibus_sub_accounts" }
+
+// ValidateBasic performs stateless checks
+func (msg *MsgSweepOmnibusSubAccounts) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Omnibus); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Omnibus)
+	}
+	if len(msg.Tags) == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "tags")
+	}
+	for _, tag := range msg.Tags {
+		if err := ValidateDestinationTag(tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg *MsgSweepOmnibusSubAccounts) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg *MsgSweepOmnibusSubAccounts) GetSigners() []sdk.AccAddress {
+	acc, err := sdk.AccAddressFromBech32(msg.Omnibus)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{acc}
+}
+
+// NewMsgPauseToken returns a new MsgPauseToken
+func NewMsgPauseToken(authority sdk.AccAddress, tokenContract string) *MsgPauseToken {
+	return &MsgPauseToken{
+		Authority:     authority.String(),
+		TokenContract: tokenContract,
+	}
+}
+
+// Route should return the name of the module
+func (msg *MsgPauseToken) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg *MsgPauseToken) Type() string { return "pause_token" }
+
+// ValidateBasic performs stateless checks
+func (msg *MsgPauseToken) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Authority)
+	}
+	if err := ValidateEthAddress(msg.TokenContract); err != nil {
+		return sdkerrors.Wrap(err, "token contract")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg *MsgPauseToken) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg *MsgPauseToken) GetSigners() []sdk.AccAddress {
+	acc, err := sdk.AccAddressFromBech32(msg.Authority)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{acc}
+}
+
+// NewMsgUnpauseToken returns a new MsgUnpauseToken
+func NewMsgUnpauseToken(authority sdk.AccAddress, tokenContract string) *MsgUnpauseToken {
+	return &MsgUnpauseToken{
+		Authority:     authority.String(),
+		TokenContract: tokenContract,
+	}
+}
+
+// Route should return the name of the module
+func (msg *MsgUnpauseToken) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg *MsgUnpauseToken) Type() string { return "unpause_token" }
+
+// ValidateBasic performs stateless checks
+func (msg *MsgUnpauseToken) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Authority)
+	}
+	if err := ValidateEthAddress(msg.TokenContract); err != nil {
+		return sdkerrors.Wrap(err, "token contract")
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg *MsgUnpauseToken) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg *MsgUnpauseToken) GetSigners() []sdk.AccAddress {
+	acc, err := sdk.AccAddressFromBech32(msg.Authority)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{acc}
+}
+
+// NewMsgRevokeOrchestratorAddress returns a new MsgRevokeOrchestratorAddress
+func NewMsgRevokeOrchestratorAddress(val sdk.ValAddress, orch sdk.AccAddress) *MsgRevokeOrchestratorAddress {
+	return &MsgRevokeOrchestratorAddress{
+		Validator:    val.String(),
+		Orchestrator: orch.String(),
+	}
+}
+
+// Route should return the name of the module
+func (msg *MsgRevokeOrchestratorAddress) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg *MsgRevokeOrchestratorAddress) Type() string { return "revoke_orchestrator_address" }
+
+// ValidateBasic performs stateless checks
+func (msg *MsgRevokeOrchestratorAddress) ValidateBasic() error {
+	if _, err := sdk.ValAddressFromBech32(msg.Validator); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Validator)
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Orchestrator); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Orchestrator)
+	}
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg *MsgRevokeOrchestratorAddress) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg *MsgRevokeOrchestratorAddress) GetSigners() []sdk.AccAddress {
+	acc, err := sdk.ValAddressFromBech32(msg.Validator)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{sdk.AccAddress(acc)}
+}
+
+// NewMsgSetCosmosOriginatedERC20 returns a new MsgSetCosmosOriginatedERC20
+func NewMsgSetCosmosOriginatedERC20(authority sdk.AccAddress, denom, tokenContract string, decimals uint32) *MsgSetCosmosOriginatedERC20 {
+	return &MsgSetCosmosOriginatedERC20{
+		Authority:     authority.String(),
+		Denom:         denom,
+		TokenContract: tokenContract,
+		Decimals:      decimals,
+	}
+}
+
+// Route should return the name of the module
+func (msg *MsgSetCosmosOriginatedERC20) Route() string { return RouterKey }
+
+// Type should return the action
+func (msg *MsgSetCosmosOriginatedERC20) Type() string { return "set_cosmos_originated_erc20" }
+
+// ValidateBasic performs stateless checks
+func (msg *MsgSetCosmosOriginatedERC20) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, msg.Authority)
+	}
+	return validateCosmosOriginatedERC20(msg.Denom, msg.TokenContract, msg.Decimals)
+}
+
+// GetSignBytes encodes the message for signing
+func (msg *MsgSetCosmosOriginatedERC20) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg *MsgSetCosmosOriginatedERC20) GetSigners() []sdk.AccAddress {
+	acc, err := sdk.AccAddressFromBech32(msg.Authority)
+	if err != nil {
+		panic(err)
+	}
+	return []sdk.AccAddress{acc}
+}
+
+// validateCosmosOriginatedERC20 checks the denom, the token contract and its decimals of a correction of
+// the ERC20 of a cosmos originated denom, an empty token contract removes the ERC20 of the denom
+func validateCosmosOriginatedERC20(denom, tokenContract string, decimals uint32) error {
+	if err := sdk.ValidateDenom(denom); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, denom)
+	}
+	if _, err := PeggyDenomToERC20(denom); err == nil {
+		return sdkerrors.Wrapf(ErrInvalid, "%s is ethereum originated", denom)
+	}
+	if decimals > math.MaxUint8 {
+		return sdkerrors.Wrapf(ErrInvalid, "%d decimals don't fit an ERC20", decimals)
+	}
+	if tokenContract == "" {
+		if decimals != 0 {
+			return sdkerrors.Wrap(ErrInvalid, "decimals without token contract")
+		}
+		return nil
+	}
+	if err := ValidateEthAddress(tokenContract); err != nil {
+		return sdkerrors.Wrap(err, "token contract")
+	}
+	return nil
 }
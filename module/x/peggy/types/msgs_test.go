@@ -2,10 +2,13 @@ package types
 
 import (
 	"bytes"
+	"reflect"
+	"strings"
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestValidateMsgSetOrchestratorAddress(t *testing.T) {
@@ -73,3 +76,219 @@ func TestValidateMsgSetOrchestratorAddress(t *testing.T) {
 	}
 
 }
+
+func TestLegacyNames(t *testing.T) {
+	// amino JSON sign bytes still use the legacy peggy/ names
+	msg := &MsgSendToEth{Sender: "cosmos1", EthDest: "0x1"}
+	bz, err := ModuleCdc.MarshalJSON(msg)
+	assert.NoError(t, err)
+	assert.Contains(t, string(bz), `"type":"peggy/MsgSendToEth"`)
+
+	assert.Equal(t, "/gravity.v1.MsgSendToEth", MigrateLegacyTypeURL("/peggy.v1.MsgSendToEth"))
+	assert.Equal(t, "/gravity.v1.MsgSendToEth", MigrateLegacyTypeURL("/gravity.v1.MsgSendToEth"))
+	assert.Equal(t, "/cosmos.bank.v1beta1.MsgSend", MigrateLegacyTypeURL("/cosmos.bank.v1beta1.MsgSend"))
+}
+
+// claimHashFixtures returns one claim of every type with all fields set
+func claimHashFixtures() []EthereumClaim {
+	const (
+		orchestrator   = "cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn"
+		bridgeContract = "0x8858eeB3DfffA017D4BCE9801D340D36Cf895CCf"
+		tokenContract  = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+	)
+	received := sdk.NewInt(90)
+	return []EthereumClaim{
+		&MsgDepositClaim{
+			EventNonce: 1, BlockHeight: 10, TokenContract: tokenContract, Amount: sdk.NewInt(100),
+			EthereumSender: "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7", CosmosReceiver: orchestrator,
+			Orchestrator: orchestrator, BridgeContractAddress: bridgeContract, BridgeChainId: 11,
+			DestinationTag: "42", ReceivedAmount: &received,
+		},
+		&MsgWithdrawClaim{
+			EventNonce: 1, BlockHeight: 10, BatchNonce: 2, TokenContract: tokenContract,
+			Orchestrator: orchestrator, BridgeContractAddress: bridgeContract, BridgeChainId: 11,
+			EthTxHash: "0x" + strings.Repeat("aB", 32),
+		},
+		&MsgERC20DeployedClaim{
+			EventNonce: 1, BlockHeight: 10, CosmosDenom: "ibc/atom", TokenContract: tokenContract,
+			Name: "Atom", Symbol: "ATOM", Decimals: 6,
+			Orchestrator: orchestrator, BridgeContractAddress: bridgeContract, BridgeChainId: 11,
+		},
+		&MsgLogicCallExecutedClaim{
+			EventNonce: 1, BlockHeight: 10, InvalidationId: []byte("invalidationId"), InvalidationNonce: 3,
+			Orchestrator: orchestrator, BridgeContractAddress: bridgeContract, BridgeChainId: 11,
+		},
+		&MsgGenericEventClaim{
+			EventNonce: 1, BlockHeight: 10, ContractAddress: tokenContract, Topic: bytes.Repeat([]byte{1}, 32), Data: []byte{2},
+			Orchestrator: orchestrator, BridgeContractAddress: bridgeContract, BridgeChainId: 11,
+		},
+		&MsgValsetUpdatedClaim{
+			EventNonce: 1, BlockHeight: 10, ValsetNonce: 4,
+			Members:      []*BridgeValidator{{Power: 100, EthereumAddress: "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7"}},
+			Orchestrator: orchestrator, BridgeContractAddress: bridgeContract, BridgeChainId: 11,
+		},
+	}
+}
+
+// cloneClaim returns a copy of the claim whose fields can be set without touching the original
+func cloneClaim(c EthereumClaim) (EthereumClaim, reflect.Value) {
+	v := reflect.New(reflect.TypeOf(c).Elem())
+	v.Elem().Set(reflect.ValueOf(c).Elem())
+	return v.Interface().(EthereumClaim), v.Elem()
+}
+
+// Two orchestrators reporting the same event must vote on the same attestation, also when only one
+// of them reports the optional ethereum tx hash, while claims disagreeing in any other field must not
+func TestClaimHash(t *testing.T) {
+	for _, claim := range claimHashFixtures() {
+		t.Run(claim.GetType().String(), func(t *testing.T) {
+			exp := claim.ClaimHash()
+			require.Len(t, exp, 32)
+
+			other, v := cloneClaim(claim)
+			v.FieldByName("Orchestrator").SetString("cosmos16ahjkfqxpp6lvfy9fpfnfjg39xr96qett0alj5")
+			assert.Equal(t, exp, other.ClaimHash(), "orchestrator must not be hashed")
+			if f := v.FieldByName("EthTxHash"); f.IsValid() {
+				f.SetString("")
+				assert.Equal(t, exp, other.ClaimHash(), "eth tx hash must not be hashed")
+			}
+
+			for i := 0; i < v.NumField(); i++ {
+				field := v.Type().Field(i)
+				if field.Name == "Orchestrator" || field.Name == "EthTxHash" || strings.HasPrefix(field.Name, "XXX_") {
+					continue
+				}
+				t.Run(field.Name, func(t *testing.T) {
+					mutated, mv := cloneClaim(claim)
+					f := mv.Field(i)
+					switch val := f.Interface().(type) {
+					case string:
+						if strings.HasPrefix(val, "0x") {
+							// the case of ethereum addresses and hashes carries no meaning
+							f.SetString("0x" + strings.ToUpper(val[2:]))
+							assert.Equal(t, exp, mutated.ClaimHash())
+						}
+						f.SetString(val + "1")
+					case uint64:
+						f.SetUint(val + 1)
+					case []byte:
+						f.SetBytes(append(append([]byte{}, val...), 1))
+					case sdk.Int:
+						f.Set(reflect.ValueOf(val.AddRaw(1)))
+					case *sdk.Int:
+						mutated := val.AddRaw(1)
+						f.Set(reflect.ValueOf(&mutated))
+					case []*BridgeValidator:
+						f.Set(reflect.ValueOf(append(append([]*BridgeValidator{}, val...), &BridgeValidator{Power: 1})))
+					default:
+						t.Fatalf("unhandled field type %T", val)
+					}
+					assert.NotEqual(t, exp, mutated.ClaimHash())
+				})
+			}
+		})
+	}
+}
+
+func TestDepositClaimReceivedAmount(t *testing.T) {
+	claim := claimHashFixtures()[0].(*MsgDepositClaim)
+	assert.True(t, claim.HasDiscrepancy())
+	assert.Equal(t, sdk.NewInt(90), claim.CreditedAmount())
+
+	// a received amount equal to the amount is attested like a missing one
+	unreported, _ := cloneClaim(claim)
+	unreported.(*MsgDepositClaim).ReceivedAmount = nil
+	equal, _ := cloneClaim(claim)
+	amount := claim.Amount
+	equal.(*MsgDepositClaim).ReceivedAmount = &amount
+	assert.False(t, equal.(*MsgDepositClaim).HasDiscrepancy())
+	assert.Equal(t, unreported.ClaimHash(), equal.ClaimHash())
+	assert.Equal(t, claim.Amount, unreported.(*MsgDepositClaim).CreditedAmount())
+
+	// more than the amount is never credited
+	more := sdk.NewInt(110)
+	claim.ReceivedAmount = &more
+	assert.True(t, claim.HasDiscrepancy())
+	assert.Equal(t, claim.Amount, claim.CreditedAmount())
+	negative := sdk.NewInt(-1)
+	claim.ReceivedAmount = &negative
+	assert.Error(t, claim.ValidateBasic())
+}
+
+func TestClaimHashDeterministic(t *testing.T) {
+	seen := make(map[string]ClaimType)
+	for _, claim := range claimHashFixtures() {
+		// a round trip through the wire format must not change the hash
+		bz, err := ModuleCdc.MarshalJSON(claim)
+		require.NoError(t, err)
+		decoded, _ := cloneClaim(claim)
+		reflect.ValueOf(decoded).Elem().Set(reflect.Zero(reflect.TypeOf(decoded).Elem()))
+		require.NoError(t, ModuleCdc.UnmarshalJSON(bz, decoded))
+		assert.Equal(t, claim.ClaimHash(), decoded.ClaimHash())
+
+		// claims of different types never share an attestation
+		hash := string(claim.ClaimHash())
+		_, exists := seen[hash]
+		require.False(t, exists, claim.GetType().String())
+		seen[hash] = claim.GetType()
+	}
+
+	// separators within a field can't shift the field boundaries
+	a := &MsgERC20DeployedClaim{Name: "a/b", Symbol: "c"}
+	b := &MsgERC20DeployedClaim{Name: "a", Symbol: "b/c"}
+	assert.NotEqual(t, a.ClaimHash(), b.ClaimHash())
+}
+
+func TestMsgExecSignBytes(t *testing.T) {
+	var (
+		granter sdk.AccAddress = bytes.Repeat([]byte{0x1}, sdk.AddrLen)
+		grantee sdk.AccAddress = bytes.Repeat([]byte{0x2}, sdk.AddrLen)
+		send                   = &MsgSendToEth{
+			Sender:    granter.String(),
+			EthDest:   "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7",
+			Amount:    sdk.NewInt64Coin("stake", 10),
+			BridgeFee: sdk.NewInt64Coin("stake", 1),
+		}
+	)
+	// the messages executed for another account are part of the amino JSON sign bytes
+	msg, err := NewMsgExec(grantee, []sdk.Msg{send})
+	require.NoError(t, err)
+	require.NoError(t, msg.ValidateBasic())
+	assert.Contains(t, string(msg.GetSignBytes()), `"type":"peggy/MsgSendToEth"`)
+	assert.Equal(t, []sdk.AccAddress{grantee}, msg.GetSigners())
+
+	nested, err := NewMsgExec(grantee, []sdk.Msg{msg})
+	require.NoError(t, err)
+	assert.Error(t, nested.ValidateBasic())
+
+	// a malformed message is rejected rather than panicking on its signers
+	malformed, err := NewMsgExec(grantee, []sdk.Msg{&MsgSendToEth{Sender: "invalid", EthDest: send.EthDest, Amount: send.Amount, BridgeFee: send.BridgeFee}})
+	require.NoError(t, err)
+	assert.NotPanics(t, func() { assert.Error(t, malformed.ValidateBasic()) })
+
+	grant, err := NewMsgGrant(granter, grantee, NewSendToEthAuthorization(sdk.Coins{sdk.NewInt64Coin("stake", 10)}, nil), 0)
+	require.NoError(t, err)
+	require.NoError(t, grant.ValidateBasic())
+	assert.Contains(t, string(grant.GetSignBytes()), `"type":"peggy/SendToEthAuthorization"`)
+	grant, err = NewMsgGrant(granter, grantee, NewGenericAuthorization("/cosmos.bank.v1beta1.MsgSend"), 0)
+	require.NoError(t, err)
+	assert.Error(t, grant.ValidateBasic())
+}
+
+func TestMsgSendToEthScheduleValidation(t *testing.T) {
+	msg := MsgSendToEth{
+		Sender:             "cosmos1ahx7f8wyertuus9r20284ej0asrs085case3kn",
+		EthDest:            "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7",
+		Amount:             NewERC20Token(100, "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5").PeggyCoin(),
+		BridgeFee:          NewERC20Token(2, "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5").PeggyCoin(),
+		ExecuteAfterHeight: 20,
+		ExecuteAfterTime:   2000,
+		ExpirationTime:     2000,
+	}
+	// the transfer would expire before it may be batched
+	assert.Error(t, msg.ValidateBasic())
+	msg.ExpirationTime = 2001
+	assert.NoError(t, msg.ValidateBasic())
+	msg.ExpirationHeight = 20
+	assert.Error(t, msg.ValidateBasic())
+}
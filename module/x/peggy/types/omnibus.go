@@ -0,0 +1,33 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/tendermint/tendermint/crypto"
+)
+
+// MaxDestinationTagLength is the length limit of a destination tag, the orchestrators report the
+// 12 bytes of a bytes32 destination above the address as a decimal number of at most 29 digits
+const MaxDestinationTagLength = 64
+
+// omnibusSubAccountPrefix separates the derivation of sub-accounts from other derived addresses
+const omnibusSubAccountPrefix = "peggy/omnibus/"
+
+// OmnibusSubAccount returns the account that deposits with the destination tag to the omnibus
+// account are credited to, the first 20 bytes of the SHA-256 hash of the prefix, the omnibus
+// account and the tag. Nobody holds a key of it, the omnibus account sweeps its balance.
+func OmnibusSubAccount(omnibus sdk.AccAddress, tag string) sdk.AccAddress {
+	preimage := append(append([]byte(omnibusSubAccountPrefix), omnibus.Bytes()...), []byte(tag)...)
+	return sdk.AccAddress(crypto.AddressHash(preimage))
+}
+
+// ValidateDestinationTag checks that a tag is set and within MaxDestinationTagLength
+func ValidateDestinationTag(tag string) error {
+	if tag == "" {
+		return sdkerrors.Wrap(ErrEmpty, "destination tag")
+	}
+	if len(tag) > MaxDestinationTagLength {
+		return sdkerrors.Wrapf(ErrInvalid, "destination tag longer than %d", MaxDestinationTagLength)
+	}
+	return nil
+}
@@ -0,0 +1,2731 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: gravity/v1/params.proto
+
+package types
+
+import (
+	fmt "fmt"
+	github_com_cosmos_cosmos_sdk_types "github.com/cosmos/cosmos-sdk/types"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// Params represent the peggy genesis and store parameters
+// gravity_id:
+// a random 32 byte value to prevent signature reuse, for example if the
+// cosmos validators decided to use the same Ethereum keys for another chain
+// also running Peggy we would not want it to be possible to play a deposit
+// from chain A back on chain B's peggy. It is mixed into every valset, batch
+// and logic call checkpoint, so signatures made for a testnet or another
+// bridge are rejected both here and by the contract. This value IS USED ON
+// ETHEREUM (as the contract's peggyId) so it must be set in your genesis.json
+// before launch and not changed after deploying Peggy
+//
+// contract_hash:
+// the code hash of a known good version of the Peggy contract
+// solidity code. This can be used to verify the correct version
+// of the contract has been deployed. This is a reference value for
+// goernance action only it is never read by any Peggy code
+//
+// bridge_ethereum_address:
+// is address of the bridge contract on the Ethereum side, this is a
+// reference value for governance only and is not actually used by any
+// Peggy code
+//
+// bridge_chain_id:
+// the unique identifier of the Ethereum chain, this is a reference value
+// only and is not actually used by any Peggy code
+//
+// These reference values may be used by future Peggy client implemetnations
+// to allow for saftey features or convenience features like the peggy address
+// in your relayer. A relayer would require a configured peggy address if
+// governance had not set the address on the chain it was relaying for.
+//
+// signed_valsets_window
+// signed_batches_window
+// signed_claims_window
+//
+// These values represent the time in blocks that a validator has to submit
+// a signature for a batch or valset, or to submit a claim for a particular
+// attestation nonce. In the case of attestations this clock starts when the
+// attestation is created, but only allows for slashing once the event has passed
+//
+// target_batch_timeout:
+//
+// This is the 'target' value for when batches time out, this is a target becuase
+// Ethereum is a probabalistic chain and you can't say for sure what the block
+// frequency is ahead of time.
+//
+// average_block_time
+// average_ethereum_block_time
+//
+// These values are the average Cosmos block time and Ethereum block time repsectively
+// and they are used to copute what the target batch timeout is. It is important that
+// governance updates these in case of any major, prolonged change in the time it takes
+// to produce a block
+//
+// slash_fraction_valset
+// slash_fraction_batch
+// slash_fraction_claim
+// slash_fraction_conflicting_claim
+//
+// The slashing fractions for the various peggy related slashing conditions. The first three
+// refer to not submitting a particular message, the third for submitting a different claim
+// for the same Ethereum event
+//
+// ethereum_height_window
+//
+// The number of Ethereum blocks a claim may reference beyond the current Ethereum height
+// as projected from the last observed Ethereum height. Claims further in the future are
+// rejected so that a buggy orchestrator can not push the observed height forward. Zero
+// disables the check
+//
+// ethereum_block_confirmations
+//
+// The number of Ethereum blocks a claim has to be below the latest Ethereum height
+// before its attestation is applied. Attestations that reached the vote threshold wait
+// until then, and orchestrators may resubmit a corrected claim for them after a reorg.
+// Zero applies attestations as soon as they reach the vote threshold
+//
+// token_allowlist
+// token_denylist
+//
+// ERC20 contracts that may or may not be bridged. If the allowlist is not empty only the
+// listed contracts can be sent to Ethereum or deposited, the denylist takes precedence
+// over the allowlist so that governance can quickly block a malicious token
+//
+// bridge_fee_basis_points
+//
+// The share of every SendToEth bridge fee, in basis points, that is sent to the community
+// pool instead of being paid to the relayer on Ethereum
+//
+// batch_request_min_fee
+// batch_request_cooldown
+//
+// Anyone can request a batch with MsgRequestBatch, to prevent griefing the total fee of the
+// transactions that would be batched has to reach batch_request_min_fee (in units of the
+// token) and a token contract can only be requested once every batch_request_cooldown blocks
+//
+// attestation_timeout
+//
+// The number of blocks after which an attestation that did not reach the vote threshold is
+// deleted together with the votes of its validators, who can then claim the event again.
+// Zero keeps unobserved attestations forever
+//
+// signature_scheme
+//
+// How the orchestrators sign the valset, batch and logic call checkpoints, eip191 for
+// personal_sign or eip712 for typed data, it has to match the deployed bridge contract
+//
+// restrict_voucher_transfers
+//
+// Disables transfers of the vouchers of ethereum originated tokens between accounts, the
+// vouchers can then only be received from a deposit and redeemed with SendToEth
+//
+// end_blocker_work_budget
+//
+// The gas that tallying attestations, pruning timed out attestations, cancelling timed out
+// batches and logic calls and refunding expired transfers may use per block, work beyond
+// the budget is deferred to the next blocks. Zero is unlimited
+//
+// relayer_allowlist
+//
+// Restricts the relayer rewards paid from the relayer reward pool to the relayers registered
+// with MsgRegisterRelayer, for chains that want permissioned relaying
+//
+// relayer_allowlist_batch_requests
+//
+// With relayer_allowlist set, only registered relayers may request batches with MsgRequestBatch
+//
+// batch_cancel_grace_period
+//
+// The number of blocks after which the account that requested a batch may cancel it with
+// MsgCancelBatch, zero leaves cancelling batches to the authority of the module
+//
+// hex_cosmos_receivers
+//
+// Lets deposits name their Cosmos receiver as a 20 byte hex address, which is credited to the
+// account with the same bytes. On chains with eth_secp256k1 accounts this is the address users
+// see in Metamask, so they can deposit without converting it to bech32 first.
+//
+// residue_sweep_interval
+//
+// The number of blocks between sweeps of the residue in the escrow and the fee collector, the
+// coins no tracked liability accounts for, into the community pool. Zero disables the sweep
+//
+// target_batch_gas
+//
+// The Ethereum gas a batch may be estimated to use, batches hold as many transfers as fit under
+// it (and never more than 100) so they fit in Ethereum blocks even for expensive tokens. The
+// estimate is batch_base_gas plus the gas of every transfer, batch_tx_gas or the gas configured
+// for the token in token_batch_gas. Zero leaves batches at 100 transfers
+//
+// batch_base_gas
+//
+// The estimated Ethereum gas of submitting a batch without any transfers, mostly the checks of
+// the valset signatures
+//
+// batch_tx_gas
+//
+// # The estimated Ethereum gas of a single transfer in a batch
+//
+// token_batch_gas
+//
+// The estimated Ethereum gas of a single transfer of the listed tokens, overriding batch_tx_gas
+// for token contracts whose transfers cost more
+//
+// slashed_funds_relayer_share
+//
+// The share of the tokens burned when a validator is slashed for not signing valsets or batches
+// that is minted into the relayer reward pool instead, so bridge misbehaviour funds the relayers
+// keeping the bridge live. Zero burns everything like any other slash
+//
+// max_valset_size
+//
+// The number of validators a valset holds at most, the validators with the most power are taken.
+// Every member of a valset costs gas on Ethereum when the signatures are checked, so the size is
+// bounded by the Ethereum block gas limit. Zero takes all bonded validators
+//
+// min_valset_power
+//
+// The normalized power, out of 2^32 for the whole valset, a validator needs to be a member of a
+// valset. Members below it barely count towards the signature threshold but cost calldata on
+// Ethereum. Members whose power rounds to zero are always left out
+//
+// confirm_grace_period
+//
+// The blocks added to signed_valsets_window and signed_batches_window before missing
+// confirms of a valset or batch count towards slashing, for chains that want to give the
+// orchestrators more time without changing the windows, e.g. around upgrades
+//
+// max_orchestrators_per_validator
+//
+// The number of orchestrators a validator may register at once, e.g. a hot and a cold key or
+// a high availability pair. Zero allows a single one
+//
+// erc20_init_code_hash
+//
+// The hex encoded keccak256 hash of the init code of the CosmosERC20 contract deployed by the
+// Peggy contract, with it the CREATE2 address of the ERC20 of a cosmos originated denom is known
+// before it is deployed. Empty if the address can't be predicted
+//
+// confirm_fee_exempt_gas
+//
+// The gas limit up to which a transaction of registered orchestrators holding only confirms
+// and claims is let into the mempool without paying the minimum gas price of the node, so
+// that the orchestrators aren't priced out when the chain is congested and slashed for
+// confirms they could not get included. Zero disables the exemption
+//
+// bridge_native_token
+//
+// If the staking token of the chain can be sent to Ethereum, while it is unset a MsgSendToEth of
+// the bond denom is rejected so that the stake securing the bridge can't be moved out of reach
+// of slashing without a governance decision
+//
+// native_token_bridge_cap
+//
+// The amount of the staking token the escrow of the module may hold at most, a MsgSendToEth of
+// the bond denom is rejected once the escrowed amount would exceed it. Zero doesn't cap it
+type Params struct {
+	GravityId                     string                                 `protobuf:"bytes,1,opt,name=gravity_id,json=gravityId,proto3" json:"gravity_id,omitempty"`
+	ContractSourceHash            string                                 `protobuf:"bytes,2,opt,name=contract_source_hash,json=contractSourceHash,proto3" json:"contract_source_hash,omitempty"`
+	BridgeEthereumAddress         string                                 `protobuf:"bytes,4,opt,name=bridge_ethereum_address,json=bridgeEthereumAddress,proto3" json:"bridge_ethereum_address,omitempty"`
+	BridgeChainId                 uint64                                 `protobuf:"varint,5,opt,name=bridge_chain_id,json=bridgeChainId,proto3" json:"bridge_chain_id,omitempty"`
+	SignedValsetsWindow           uint64                                 `protobuf:"varint,6,opt,name=signed_valsets_window,json=signedValsetsWindow,proto3" json:"signed_valsets_window,omitempty"`
+	SignedBatchesWindow           uint64                                 `protobuf:"varint,7,opt,name=signed_batches_window,json=signedBatchesWindow,proto3" json:"signed_batches_window,omitempty"`
+	SignedClaimsWindow            uint64                                 `protobuf:"varint,8,opt,name=signed_claims_window,json=signedClaimsWindow,proto3" json:"signed_claims_window,omitempty"`
+	TargetBatchTimeout            uint64                                 `protobuf:"varint,10,opt,name=target_batch_timeout,json=targetBatchTimeout,proto3" json:"target_batch_timeout,omitempty"`
+	AverageBlockTime              uint64                                 `protobuf:"varint,11,opt,name=average_block_time,json=averageBlockTime,proto3" json:"average_block_time,omitempty"`
+	AverageEthereumBlockTime      uint64                                 `protobuf:"varint,12,opt,name=average_ethereum_block_time,json=averageEthereumBlockTime,proto3" json:"average_ethereum_block_time,omitempty"`
+	SlashFractionValset           github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,13,opt,name=slash_fraction_valset,json=slashFractionValset,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"slash_fraction_valset"`
+	SlashFractionBatch            github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,14,opt,name=slash_fraction_batch,json=slashFractionBatch,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"slash_fraction_batch"`
+	SlashFractionClaim            github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,15,opt,name=slash_fraction_claim,json=slashFractionClaim,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"slash_fraction_claim"`
+	SlashFractionConflictingClaim github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,16,opt,name=slash_fraction_conflicting_claim,json=slashFractionConflictingClaim,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"slash_fraction_conflicting_claim"`
+	UnbondSlashingValsetsWindow   uint64                                 `protobuf:"varint,17,opt,name=unbond_slashing_valsets_window,json=unbondSlashingValsetsWindow,proto3" json:"unbond_slashing_valsets_window,omitempty"`
+	EthereumHeightWindow          uint64                                 `protobuf:"varint,18,opt,name=ethereum_height_window,json=ethereumHeightWindow,proto3" json:"ethereum_height_window,omitempty"`
+	TokenAllowlist                []string                               `protobuf:"bytes,19,rep,name=token_allowlist,json=tokenAllowlist,proto3" json:"token_allowlist,omitempty"`
+	TokenDenylist                 []string                               `protobuf:"bytes,20,rep,name=token_denylist,json=tokenDenylist,proto3" json:"token_denylist,omitempty"`
+	BridgeFeeBasisPoints          uint64                                 `protobuf:"varint,21,opt,name=bridge_fee_basis_points,json=bridgeFeeBasisPoints,proto3" json:"bridge_fee_basis_points,omitempty"`
+	BatchRequestMinFee            github_com_cosmos_cosmos_sdk_types.Int `protobuf:"bytes,22,opt,name=batch_request_min_fee,json=batchRequestMinFee,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Int" json:"batch_request_min_fee"`
+	BatchRequestCooldown          uint64                                 `protobuf:"varint,23,opt,name=batch_request_cooldown,json=batchRequestCooldown,proto3" json:"batch_request_cooldown,omitempty"`
+	EthereumBlockConfirmations    uint64                                 `protobuf:"varint,24,opt,name=ethereum_block_confirmations,json=ethereumBlockConfirmations,proto3" json:"ethereum_block_confirmations,omitempty"`
+	AttestationTimeout            uint64                                 `protobuf:"varint,25,opt,name=attestation_timeout,json=attestationTimeout,proto3" json:"attestation_timeout,omitempty"`
+	SignatureScheme               string                                 `protobuf:"bytes,26,opt,name=signature_scheme,json=signatureScheme,proto3" json:"signature_scheme,omitempty"`
+	RestrictVoucherTransfers      bool                                   `protobuf:"varint,27,opt,name=restrict_voucher_transfers,json=restrictVoucherTransfers,proto3" json:"restrict_voucher_transfers,omitempty"`
+	EndBlockerWorkBudget          uint64                                 `protobuf:"varint,28,opt,name=end_blocker_work_budget,json=endBlockerWorkBudget,proto3" json:"end_blocker_work_budget,omitempty"`
+	RelayerAllowlist              bool                                   `protobuf:"varint,29,opt,name=relayer_allowlist,json=relayerAllowlist,proto3" json:"relayer_allowlist,omitempty"`
+	RelayerAllowlistBatchRequests bool                                   `protobuf:"varint,30,opt,name=relayer_allowlist_batch_requests,json=relayerAllowlistBatchRequests,proto3" json:"relayer_allowlist_batch_requests,omitempty"`
+	BatchCancelGracePeriod        uint64                                 `protobuf:"varint,31,opt,name=batch_cancel_grace_period,json=batchCancelGracePeriod,proto3" json:"batch_cancel_grace_period,omitempty"`
+	HexCosmosReceivers            bool                                   `protobuf:"varint,32,opt,name=hex_cosmos_receivers,json=hexCosmosReceivers,proto3" json:"hex_cosmos_receivers,omitempty"`
+	ResidueSweepInterval          uint64                                 `protobuf:"varint,33,opt,name=residue_sweep_interval,json=residueSweepInterval,proto3" json:"residue_sweep_interval,omitempty"`
+	TargetBatchGas                uint64                                 `protobuf:"varint,34,opt,name=target_batch_gas,json=targetBatchGas,proto3" json:"target_batch_gas,omitempty"`
+	BatchBaseGas                  uint64                                 `protobuf:"varint,35,opt,name=batch_base_gas,json=batchBaseGas,proto3" json:"batch_base_gas,omitempty"`
+	BatchTxGas                    uint64                                 `protobuf:"varint,36,opt,name=batch_tx_gas,json=batchTxGas,proto3" json:"batch_tx_gas,omitempty"`
+	TokenBatchGas                 []TokenBatchGas                        `protobuf:"bytes,37,rep,name=token_batch_gas,json=tokenBatchGas,proto3" json:"token_batch_gas"`
+	SlashedFundsRelayerShare      github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,38,opt,name=slashed_funds_relayer_share,json=slashedFundsRelayerShare,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"slashed_funds_relayer_share"`
+	MaxValsetSize                 uint64                                 `protobuf:"varint,39,opt,name=max_valset_size,json=maxValsetSize,proto3" json:"max_valset_size,omitempty"`
+	MinValsetPower                uint64                                 `protobuf:"varint,40,opt,name=min_valset_power,json=minValsetPower,proto3" json:"min_valset_power,omitempty"`
+	ConfirmGracePeriod            uint64                                 `protobuf:"varint,41,opt,name=confirm_grace_period,json=confirmGracePeriod,proto3" json:"confirm_grace_period,omitempty"`
+	MaxOrchestratorsPerValidator  uint64                                 `protobuf:"varint,42,opt,name=max_orchestrators_per_validator,json=maxOrchestratorsPerValidator,proto3" json:"max_orchestrators_per_validator,omitempty"`
+	Erc20InitCodeHash             string                                 `protobuf:"bytes,43,opt,name=erc20_init_code_hash,json=erc20InitCodeHash,proto3" json:"erc20_init_code_hash,omitempty"`
+	ConfirmFeeExemptGas           uint64                                 `protobuf:"varint,44,opt,name=confirm_fee_exempt_gas,json=confirmFeeExemptGas,proto3" json:"confirm_fee_exempt_gas,omitempty"`
+	BridgeNativeToken             bool                                   `protobuf:"varint,45,opt,name=bridge_native_token,json=bridgeNativeToken,proto3" json:"bridge_native_token,omitempty"`
+	NativeTokenBridgeCap          github_com_cosmos_cosmos_sdk_types.Int `protobuf:"bytes,46,opt,name=native_token_bridge_cap,json=nativeTokenBridgeCap,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Int" json:"native_token_bridge_cap"`
+	ObservedNonceLagThreshold     uint64                                 `protobuf:"varint,47,opt,name=observed_nonce_lag_threshold,json=observedNonceLagThreshold,proto3" json:"observed_nonce_lag_threshold,omitempty"`
+	ConfirmFeeExemptTxs           uint64                                 `protobuf:"varint,48,opt,name=confirm_fee_exempt_txs,json=confirmFeeExemptTxs,proto3" json:"confirm_fee_exempt_txs,omitempty"`
+	AccountActivityRetention      uint64                                 `protobuf:"varint,49,opt,name=account_activity_retention,json=accountActivityRetention,proto3" json:"account_activity_retention,omitempty"`
+}
+
+func (m *Params) Reset()         { *m = Params{} }
+func (m *Params) String() string { return proto.CompactTextString(m) }
+func (*Params) ProtoMessage()    {}
+func (*Params) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8772bac9489530eb, []int{0}
+}
+func (m *Params) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Params) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Params.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Params) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Params.Merge(m, src)
+}
+func (m *Params) XXX_Size() int {
+	return m.Size()
+}
+func (m *Params) XXX_DiscardUnknown() {
+	xxx_messageInfo_Params.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Params proto.InternalMessageInfo
+
+func (m *Params) GetGravityId() string {
+	if m != nil {
+		return m.GravityId
+	}
+	return ""
+}
+
+func (m *Params) GetContractSourceHash() string {
+	if m != nil {
+		return m.ContractSourceHash
+	}
+	return ""
+}
+
+func (m *Params) GetBridgeEthereumAddress() string {
+	if m != nil {
+		return m.BridgeEthereumAddress
+	}
+	return ""
+}
+
+func (m *Params) GetBridgeChainId() uint64 {
+	if m != nil {
+		return m.BridgeChainId
+	}
+	return 0
+}
+
+func (m *Params) GetSignedValsetsWindow() uint64 {
+	if m != nil {
+		return m.SignedValsetsWindow
+	}
+	return 0
+}
+
+func (m *Params) GetSignedBatchesWindow() uint64 {
+	if m != nil {
+		return m.SignedBatchesWindow
+	}
+	return 0
+}
+
+func (m *Params) GetSignedClaimsWindow() uint64 {
+	if m != nil {
+		return m.SignedClaimsWindow
+	}
+	return 0
+}
+
+func (m *Params) GetTargetBatchTimeout() uint64 {
+	if m != nil {
+		return m.TargetBatchTimeout
+	}
+	return 0
+}
+
+func (m *Params) GetAverageBlockTime() uint64 {
+	if m != nil {
+		return m.AverageBlockTime
+	}
+	return 0
+}
+
+func (m *Params) GetAverageEthereumBlockTime() uint64 {
+	if m != nil {
+		return m.AverageEthereumBlockTime
+	}
+	return 0
+}
+
+func (m *Params) GetUnbondSlashingValsetsWindow() uint64 {
+	if m != nil {
+		return m.UnbondSlashingValsetsWindow
+	}
+	return 0
+}
+
+func (m *Params) GetEthereumHeightWindow() uint64 {
+	if m != nil {
+		return m.EthereumHeightWindow
+	}
+	return 0
+}
+
+func (m *Params) GetTokenAllowlist() []string {
+	if m != nil {
+		return m.TokenAllowlist
+	}
+	return nil
+}
+
+func (m *Params) GetTokenDenylist() []string {
+	if m != nil {
+		return m.TokenDenylist
+	}
+	return nil
+}
+
+func (m *Params) GetBridgeFeeBasisPoints() uint64 {
+	if m != nil {
+		return m.BridgeFeeBasisPoints
+	}
+	return 0
+}
+
+func (m *Params) GetBatchRequestCooldown() uint64 {
+	if m != nil {
+		return m.BatchRequestCooldown
+	}
+	return 0
+}
+
+func (m *Params) GetEthereumBlockConfirmations() uint64 {
+	if m != nil {
+		return m.EthereumBlockConfirmations
+	}
+	return 0
+}
+
+func (m *Params) GetAttestationTimeout() uint64 {
+	if m != nil {
+		return m.AttestationTimeout
+	}
+	return 0
+}
+
+func (m *Params) GetSignatureScheme() string {
+	if m != nil {
+		return m.SignatureScheme
+	}
+	return ""
+}
+
+func (m *Params) GetRestrictVoucherTransfers() bool {
+	if m != nil {
+		return m.RestrictVoucherTransfers
+	}
+	return false
+}
+
+func (m *Params) GetEndBlockerWorkBudget() uint64 {
+	if m != nil {
+		return m.EndBlockerWorkBudget
+	}
+	return 0
+}
+
+func (m *Params) GetRelayerAllowlist() bool {
+	if m != nil {
+		return m.RelayerAllowlist
+	}
+	return false
+}
+
+func (m *Params) GetRelayerAllowlistBatchRequests() bool {
+	if m != nil {
+		return m.RelayerAllowlistBatchRequests
+	}
+	return false
+}
+
+func (m *Params) GetBatchCancelGracePeriod() uint64 {
+	if m != nil {
+		return m.BatchCancelGracePeriod
+	}
+	return 0
+}
+
+func (m *Params) GetHexCosmosReceivers() bool {
+	if m != nil {
+		return m.HexCosmosReceivers
+	}
+	return false
+}
+
+func (m *Params) GetResidueSweepInterval() uint64 {
+	if m != nil {
+		return m.ResidueSweepInterval
+	}
+	return 0
+}
+
+func (m *Params) GetTargetBatchGas() uint64 {
+	if m != nil {
+		return m.TargetBatchGas
+	}
+	return 0
+}
+
+func (m *Params) GetBatchBaseGas() uint64 {
+	if m != nil {
+		return m.BatchBaseGas
+	}
+	return 0
+}
+
+func (m *Params) GetBatchTxGas() uint64 {
+	if m != nil {
+		return m.BatchTxGas
+	}
+	return 0
+}
+
+func (m *Params) GetTokenBatchGas() []TokenBatchGas {
+	if m != nil {
+		return m.TokenBatchGas
+	}
+	return nil
+}
+
+func (m *Params) GetMaxValsetSize() uint64 {
+	if m != nil {
+		return m.MaxValsetSize
+	}
+	return 0
+}
+
+func (m *Params) GetMinValsetPower() uint64 {
+	if m != nil {
+		return m.MinValsetPower
+	}
+	return 0
+}
+
+func (m *Params) GetConfirmGracePeriod() uint64 {
+	if m != nil {
+		return m.ConfirmGracePeriod
+	}
+	return 0
+}
+
+func (m *Params) GetMaxOrchestratorsPerValidator() uint64 {
+	if m != nil {
+		return m.MaxOrchestratorsPerValidator
+	}
+	return 0
+}
+
+func (m *Params) GetErc20InitCodeHash() string {
+	if m != nil {
+		return m.Erc20InitCodeHash
+	}
+	return ""
+}
+
+func (m *Params) GetConfirmFeeExemptGas() uint64 {
+	if m != nil {
+		return m.ConfirmFeeExemptGas
+	}
+	return 0
+}
+
+func (m *Params) GetBridgeNativeToken() bool {
+	if m != nil {
+		return m.BridgeNativeToken
+	}
+	return false
+}
+
+func (m *Params) GetObservedNonceLagThreshold() uint64 {
+	if m != nil {
+		return m.ObservedNonceLagThreshold
+	}
+	return 0
+}
+
+func (m *Params) GetConfirmFeeExemptTxs() uint64 {
+	if m != nil {
+		return m.ConfirmFeeExemptTxs
+	}
+	return 0
+}
+
+func (m *Params) GetAccountActivityRetention() uint64 {
+	if m != nil {
+		return m.AccountActivityRetention
+	}
+	return 0
+}
+
+// TokenBatchGas is the estimated Ethereum gas of a single transfer of a token in a batch
+type TokenBatchGas struct {
+	TokenContract string `protobuf:"bytes,1,opt,name=token_contract,json=tokenContract,proto3" json:"token_contract,omitempty"`
+	TxGas         uint64 `protobuf:"varint,2,opt,name=tx_gas,json=txGas,proto3" json:"tx_gas,omitempty"`
+}
+
+func (m *TokenBatchGas) Reset()         { *m = TokenBatchGas{} }
+func (m *TokenBatchGas) String() string { return proto.CompactTextString(m) }
+func (*TokenBatchGas) ProtoMessage()    {}
+func (*TokenBatchGas) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8772bac9489530eb, []int{1}
+}
+func (m *TokenBatchGas) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TokenBatchGas) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TokenBatchGas.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TokenBatchGas) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenBatchGas.Merge(m, src)
+}
+func (m *TokenBatchGas) XXX_Size() int {
+	return m.Size()
+}
+func (m *TokenBatchGas) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenBatchGas.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenBatchGas proto.InternalMessageInfo
+
+func (m *TokenBatchGas) GetTokenContract() string {
+	if m != nil {
+		return m.TokenContract
+	}
+	return ""
+}
+
+func (m *TokenBatchGas) GetTxGas() uint64 {
+	if m != nil {
+		return m.TxGas
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*Params)(nil), "gravity.v1.Params")
+	proto.RegisterType((*TokenBatchGas)(nil), "gravity.v1.TokenBatchGas")
+}
+
+func init() { proto.RegisterFile("gravity/v1/params.proto", fileDescriptor_8772bac9489530eb) }
+
+var fileDescriptor_8772bac9489530eb = []byte{
+	// 1355 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x5b, 0x6f, 0x14, 0x47,
+	0x16, 0xf6, 0x80, 0xf1, 0x42, 0x81, 0x6f, 0xed, 0xb1, 0x5d, 0xbe, 0x8d, 0x67, 0x59, 0x2e, 0xc3,
+	0x02, 0x33, 0x06, 0x96, 0x95, 0x76, 0xb5, 0xab, 0x84, 0x19, 0xb0, 0xb1, 0x14, 0xc0, 0x1a, 0x5b,
+	0x20, 0xe5, 0xa5, 0x52, 0xd3, 0x7d, 0xdc, 0x5d, 0xf2, 0x74, 0xd7, 0xa4, 0xaa, 0xe6, 0x62, 0x9e,
+	0xf2, 0x94, 0xe7, 0xfc, 0x2c, 0x1e, 0x79, 0x8c, 0xa2, 0x08, 0x45, 0xf0, 0x47, 0xa2, 0x3a, 0x55,
+	0x3d, 0x17, 0x43, 0x1e, 0x62, 0xe5, 0xc9, 0xee, 0xf3, 0x7d, 0x5f, 0x9d, 0x9a, 0x73, 0xab, 0x43,
+	0x56, 0x63, 0xc5, 0x7b, 0xc2, 0x9c, 0xd6, 0x7a, 0x0f, 0x6a, 0x1d, 0xae, 0x78, 0xaa, 0xab, 0x1d,
+	0x25, 0x8d, 0x0c, 0x88, 0x07, 0xaa, 0xbd, 0x07, 0xeb, 0xc5, 0x58, 0xc6, 0x12, 0xcd, 0x35, 0xfb,
+	0x9f, 0x63, 0x5c, 0xff, 0x71, 0x85, 0xcc, 0x1c, 0xa0, 0x24, 0xd8, 0x22, 0x39, 0x9d, 0x89, 0x88,
+	0x16, 0xca, 0x85, 0xca, 0x95, 0xe6, 0x15, 0x6f, 0xd9, 0x8f, 0x82, 0x1d, 0x52, 0x0c, 0x65, 0x66,
+	0x14, 0x0f, 0x0d, 0xd3, 0xb2, 0xab, 0x42, 0x60, 0x09, 0xd7, 0x09, 0xbd, 0x80, 0xc4, 0x20, 0xc7,
+	0x0e, 0x11, 0x7a, 0xce, 0x75, 0x12, 0xfc, 0x9b, 0xac, 0xb6, 0x94, 0x88, 0x62, 0x60, 0x60, 0x12,
+	0x50, 0xd0, 0x4d, 0x19, 0x8f, 0x22, 0x05, 0x5a, 0xd3, 0x69, 0x14, 0x2d, 0x3b, 0xf8, 0x99, 0x47,
+	0x9f, 0x38, 0x30, 0xb8, 0x45, 0xe6, 0xbd, 0x2e, 0x4c, 0xb8, 0xc8, 0xec, 0x6d, 0x2e, 0x95, 0x0b,
+	0x95, 0xe9, 0xe6, 0xac, 0x33, 0x37, 0xac, 0x75, 0x3f, 0x0a, 0x1e, 0x92, 0x65, 0x2d, 0xe2, 0x0c,
+	0x22, 0xd6, 0xe3, 0x6d, 0x0d, 0x46, 0xb3, 0xbe, 0xc8, 0x22, 0xd9, 0xa7, 0x33, 0xc8, 0x5e, 0x72,
+	0xe0, 0x6b, 0x87, 0xbd, 0x41, 0x68, 0x4c, 0xd3, 0xe2, 0x26, 0x4c, 0x60, 0xa8, 0xf9, 0xdb, 0xb8,
+	0xa6, 0xee, 0x30, 0xaf, 0xd9, 0x21, 0x45, 0xaf, 0x09, 0xdb, 0x5c, 0xa4, 0x43, 0xc9, 0x65, 0x94,
+	0x04, 0x0e, 0x6b, 0x20, 0x34, 0x52, 0x18, 0xae, 0x62, 0x30, 0xce, 0x0b, 0x33, 0x22, 0x05, 0xd9,
+	0x35, 0x94, 0x38, 0x85, 0xc3, 0xd0, 0xc9, 0x91, 0x43, 0x82, 0x7b, 0x24, 0xe0, 0x3d, 0x50, 0x3c,
+	0x06, 0xd6, 0x6a, 0xcb, 0xf0, 0x04, 0x25, 0xf4, 0x2a, 0xf2, 0x17, 0x3c, 0x52, 0xb7, 0x80, 0x15,
+	0x04, 0xff, 0x27, 0x1b, 0x39, 0x7b, 0x18, 0xda, 0x31, 0xd9, 0x35, 0x94, 0x51, 0x4f, 0xc9, 0xc3,
+	0x3b, 0x92, 0xb7, 0xc8, 0xb2, 0x6e, 0x73, 0x9d, 0xb0, 0x63, 0x9b, 0x31, 0x21, 0x33, 0x1f, 0x40,
+	0x3a, 0x5b, 0x2e, 0x54, 0xae, 0xd5, 0xab, 0xef, 0x3e, 0x6c, 0x4f, 0xfd, 0xf2, 0x61, 0xfb, 0x56,
+	0x2c, 0x4c, 0xd2, 0x6d, 0x55, 0x43, 0x99, 0xd6, 0x42, 0xa9, 0x53, 0xa9, 0xfd, 0x9f, 0xfb, 0x3a,
+	0x3a, 0xa9, 0x99, 0xd3, 0x0e, 0xe8, 0xea, 0x53, 0x08, 0x9b, 0x4b, 0x78, 0xd8, 0xae, 0x3f, 0xcb,
+	0xc5, 0x3b, 0xf8, 0x8e, 0x14, 0xcf, 0xf8, 0xc0, 0x50, 0xd0, 0xb9, 0x73, 0xb9, 0x08, 0x26, 0x5c,
+	0x60, 0xe4, 0xbe, 0xe0, 0x01, 0xd3, 0x43, 0xe7, 0xff, 0x02, 0x0f, 0x98, 0xcd, 0xa0, 0x4f, 0xca,
+	0x67, 0x3d, 0xc8, 0xec, 0xb8, 0x2d, 0x42, 0x23, 0xb2, 0xd8, 0x7b, 0x5b, 0x38, 0x97, 0xb7, 0xad,
+	0x49, 0x6f, 0xa3, 0x53, 0x9d, 0xe3, 0x06, 0x29, 0x75, 0xb3, 0x96, 0xcc, 0x22, 0x86, 0x3c, 0xeb,
+	0xed, 0x4c, 0x89, 0x2f, 0x62, 0x8a, 0x37, 0x1c, 0xeb, 0xd0, 0x93, 0x26, 0x4b, 0xfd, 0x5f, 0x64,
+	0x65, 0x58, 0x1c, 0x09, 0x88, 0x38, 0x31, 0xb9, 0x38, 0x40, 0x71, 0x31, 0x47, 0x9f, 0x23, 0xe8,
+	0x55, 0xb7, 0xc9, 0xbc, 0x91, 0x27, 0x90, 0x31, 0xde, 0x6e, 0xcb, 0x7e, 0x5b, 0x68, 0x43, 0x97,
+	0xca, 0x17, 0x2b, 0x57, 0x9a, 0x73, 0x68, 0x7e, 0x92, 0x5b, 0x83, 0x9b, 0xc4, 0x59, 0x58, 0x04,
+	0xd9, 0x29, 0xf2, 0x8a, 0xc8, 0x9b, 0x45, 0xeb, 0x53, 0x6f, 0x0c, 0x1e, 0x0f, 0x87, 0xc0, 0x31,
+	0x00, 0x6b, 0x71, 0x2d, 0x34, 0xeb, 0x48, 0x91, 0x19, 0x4d, 0x97, 0xdd, 0x35, 0x1c, 0xbc, 0x0b,
+	0x50, 0xb7, 0xe0, 0x01, 0x62, 0x01, 0x27, 0xcb, 0xae, 0x75, 0x14, 0x7c, 0xdf, 0x05, 0x6d, 0x58,
+	0x2a, 0x32, 0x7b, 0x02, 0x5d, 0xb1, 0x93, 0xe3, 0x4f, 0xc5, 0x7b, 0x3f, 0x33, 0xcd, 0x00, 0x0f,
+	0x6b, 0xba, 0xb3, 0x5e, 0x88, 0x6c, 0x17, 0xc0, 0xc6, 0x67, 0xd2, 0x45, 0x28, 0x65, 0x3b, 0x92,
+	0xfd, 0x8c, 0xae, 0xfa, 0x8b, 0x8d, 0x69, 0x1a, 0x1e, 0x0b, 0xbe, 0x26, 0x9b, 0x67, 0x5a, 0xce,
+	0xd6, 0x84, 0x50, 0x29, 0xb7, 0x99, 0xd4, 0x94, 0xa2, 0x76, 0x1d, 0xc6, 0x9b, 0xae, 0x31, 0xce,
+	0x08, 0x6a, 0x64, 0x89, 0x1b, 0x03, 0xda, 0xe0, 0xf7, 0x70, 0x36, 0xac, 0xb9, 0xd9, 0x30, 0x06,
+	0xe5, 0xb3, 0xe1, 0x0e, 0x59, 0xb0, 0x33, 0x86, 0x9b, 0xae, 0x02, 0xa6, 0xc3, 0x04, 0x52, 0xa0,
+	0xeb, 0x38, 0x40, 0xe7, 0x87, 0xf6, 0x43, 0x34, 0x07, 0xff, 0x23, 0xeb, 0x0a, 0xb4, 0x51, 0x22,
+	0x34, 0xac, 0x27, 0xbb, 0x61, 0x02, 0x8a, 0x19, 0xc5, 0x33, 0x7d, 0x0c, 0x4a, 0xd3, 0x8d, 0x72,
+	0xa1, 0x72, 0xb9, 0x49, 0x73, 0xc6, 0x6b, 0x47, 0x38, 0xca, 0x71, 0x9b, 0x2b, 0xc8, 0x22, 0xf7,
+	0xb3, 0x40, 0xb1, 0xbe, 0x54, 0x27, 0xac, 0xd5, 0x8d, 0x62, 0x30, 0x74, 0xd3, 0x97, 0x4c, 0x16,
+	0xd5, 0x1d, 0xfa, 0x46, 0xaa, 0x93, 0x3a, 0x62, 0xc1, 0x5d, 0xb2, 0xa8, 0xa0, 0xcd, 0x4f, 0x41,
+	0x8d, 0x15, 0xcd, 0x16, 0xfa, 0x5a, 0xf0, 0xc0, 0xa8, 0x6c, 0xf6, 0x48, 0xf9, 0x33, 0x32, 0x9b,
+	0xc8, 0x83, 0xa6, 0x25, 0xd4, 0x6e, 0x9d, 0xd5, 0xd6, 0xc7, 0xf2, 0xa1, 0x83, 0xff, 0x90, 0x35,
+	0x27, 0x0b, 0x79, 0x16, 0x42, 0x9b, 0xc5, 0x8a, 0x87, 0xc0, 0x3a, 0xa0, 0x84, 0x8c, 0xe8, 0x36,
+	0x5e, 0xd7, 0xe5, 0xb7, 0x81, 0xf8, 0x9e, 0x85, 0x0f, 0x10, 0xb5, 0xe3, 0x39, 0x81, 0x01, 0x73,
+	0x95, 0xc2, 0x14, 0x84, 0x20, 0x7a, 0x36, 0x3e, 0x65, 0xf4, 0x1b, 0x24, 0x30, 0x68, 0x20, 0xd4,
+	0xcc, 0x11, 0x5b, 0x2b, 0x0a, 0xb4, 0x88, 0xba, 0xc0, 0x74, 0x1f, 0xa0, 0xc3, 0x44, 0x66, 0x40,
+	0xf5, 0x78, 0x9b, 0xfe, 0xdd, 0x05, 0xc6, 0xa3, 0x87, 0x16, 0xdc, 0xf7, 0x58, 0x50, 0x21, 0x0b,
+	0x13, 0xcf, 0x40, 0xcc, 0x35, 0xbd, 0x8e, 0xfc, 0xb9, 0xb1, 0x27, 0x60, 0x8f, 0xeb, 0xe0, 0x06,
+	0x99, 0x73, 0x94, 0x16, 0xd7, 0x80, 0xbc, 0x7f, 0x20, 0xef, 0x1a, 0x5a, 0xeb, 0x5c, 0x83, 0x65,
+	0x95, 0x89, 0xfb, 0x66, 0x66, 0x80, 0x9c, 0x1b, 0xc8, 0x21, 0x68, 0x3b, 0x1a, 0x58, 0xc6, 0x5e,
+	0xde, 0xbd, 0x23, 0x87, 0x37, 0xcb, 0x17, 0x2b, 0x57, 0x1f, 0xae, 0x55, 0x47, 0xab, 0x40, 0xf5,
+	0xc8, 0x52, 0x72, 0xdf, 0xf5, 0x69, 0xdb, 0x4b, 0xbe, 0x6d, 0x87, 0x17, 0x4a, 0xc9, 0x06, 0x8e,
+	0x1e, 0x88, 0xd8, 0x71, 0x37, 0x8b, 0x34, 0xf3, 0xc9, 0x60, 0x3a, 0xe1, 0x0a, 0xe8, 0xad, 0x73,
+	0x4d, 0x3d, 0xea, 0x8f, 0xdc, 0xb5, 0x27, 0x36, 0xdd, 0x81, 0x87, 0xf6, 0x3c, 0xfb, 0xe4, 0xa7,
+	0x7c, 0xe0, 0x87, 0x1c, 0xd3, 0xe2, 0x2d, 0xd0, 0xdb, 0xee, 0xc9, 0x4f, 0xf9, 0xc0, 0x8d, 0xb5,
+	0x43, 0xf1, 0x16, 0x6c, 0x44, 0xed, 0x20, 0xf0, 0xbc, 0x8e, 0xec, 0x83, 0xa2, 0x15, 0x17, 0xd1,
+	0x54, 0xf8, 0xa7, 0xe7, 0xc0, 0x5a, 0xfd, 0xba, 0x62, 0xdb, 0x6e, 0xb2, 0x32, 0xee, 0xb8, 0x36,
+	0xf3, 0xd8, 0x78, 0x55, 0x3c, 0x23, 0xdb, 0xf6, 0x0e, 0x52, 0xd9, 0xa7, 0xdf, 0x28, 0x6e, 0xa4,
+	0xd2, 0x56, 0x65, 0xbd, 0x89, 0xc8, 0x7e, 0xd2, 0x7f, 0xa2, 0x78, 0x33, 0xe5, 0x83, 0x57, 0xe3,
+	0xac, 0x03, 0x50, 0xaf, 0x73, 0x4e, 0x50, 0x23, 0x45, 0x50, 0xe1, 0xc3, 0x1d, 0x26, 0x32, 0x61,
+	0x67, 0x4a, 0xe4, 0xf7, 0xa4, 0xbb, 0xd8, 0xb1, 0x8b, 0x88, 0xed, 0x67, 0xc2, 0x34, 0x64, 0xe4,
+	0xd6, 0xa4, 0x47, 0x64, 0x25, 0xbf, 0xa9, 0x1d, 0x91, 0x30, 0x80, 0xb4, 0x63, 0x30, 0x75, 0xf7,
+	0xdc, 0x4e, 0xe2, 0xd1, 0x5d, 0x80, 0x67, 0x88, 0xd9, 0xfc, 0x54, 0xc9, 0x92, 0x1f, 0xab, 0x19,
+	0x37, 0xa2, 0x07, 0x0c, 0xd3, 0x47, 0xef, 0x63, 0x05, 0x2f, 0x3a, 0xe8, 0x25, 0x22, 0x98, 0xec,
+	0x00, 0xc8, 0xea, 0x38, 0x91, 0x79, 0x71, 0xc8, 0x3b, 0xb4, 0x7a, 0xae, 0x89, 0x5a, 0xcc, 0x46,
+	0xa7, 0xd7, 0xdd, 0x5a, 0xc6, 0x3b, 0xc1, 0x57, 0x64, 0x53, 0xb6, 0x34, 0xa8, 0x1e, 0x44, 0x2c,
+	0x93, 0x59, 0x08, 0xac, 0xcd, 0x63, 0x66, 0x12, 0x05, 0x3a, 0x91, 0xed, 0x88, 0xd6, 0xf0, 0x17,
+	0xad, 0xe5, 0x9c, 0x97, 0x96, 0xf2, 0x0d, 0x8f, 0x8f, 0x72, 0xc2, 0x1f, 0x04, 0xc3, 0x0c, 0x34,
+	0xdd, 0xf9, 0x72, 0x30, 0x8e, 0x06, 0xda, 0x4e, 0x3d, 0x1e, 0x86, 0xb2, 0x9b, 0x19, 0x66, 0x1f,
+	0x54, 0x5c, 0x61, 0x15, 0x18, 0xc8, 0xec, 0x14, 0xa5, 0x0f, 0xfc, 0x36, 0xe4, 0x18, 0x4f, 0x3c,
+	0xa1, 0x99, 0xe3, 0xff, 0x9d, 0xfe, 0xe1, 0xd7, 0xf2, 0xd4, 0xf5, 0x17, 0x64, 0x76, 0xa2, 0x2d,
+	0x46, 0xef, 0x5b, 0xbe, 0xd9, 0xfa, 0x95, 0xd8, 0x35, 0x4a, 0xc3, 0x1b, 0x83, 0x65, 0x32, 0xe3,
+	0xbb, 0xf1, 0x02, 0xfa, 0xb9, 0x64, 0x6c, 0x23, 0xd6, 0x5f, 0xbd, 0xfb, 0x58, 0x2a, 0xbc, 0xff,
+	0x58, 0x2a, 0xfc, 0xf6, 0xb1, 0x54, 0xf8, 0xe9, 0x53, 0x69, 0xea, 0xfd, 0xa7, 0xd2, 0xd4, 0xcf,
+	0x9f, 0x4a, 0x53, 0xdf, 0x3e, 0xfe, 0x3c, 0xc0, 0xbe, 0x35, 0xef, 0xbb, 0xa4, 0xd4, 0x52, 0x19,
+	0x75, 0xdb, 0x50, 0x1b, 0xd4, 0x3a, 0x10, 0xc7, 0xa7, 0x2e, 0xe6, 0xad, 0x19, 0xdc, 0xd7, 0x1f,
+	0xfd, 0x3e, 0x00, 0x20, 0x3d, 0x20, 0x8f, 0xec, 0x0b, 0x00, 0x00,
+}
+
+func (m *Params) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Params) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.AccountActivityRetention != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.AccountActivityRetention))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0x88
+	}
+	if m.ConfirmFeeExemptTxs != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.ConfirmFeeExemptTxs))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0x80
+	}
+	if m.ObservedNonceLagThreshold != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.ObservedNonceLagThreshold))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xf8
+	}
+	{
+		size := m.NativeTokenBridgeCap.Size()
+		i -= size
+		if _, err := m.NativeTokenBridgeCap.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintParams(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x2
+	i--
+	dAtA[i] = 0xf2
+	if m.BridgeNativeToken {
+		i--
+		if m.BridgeNativeToken {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xe8
+	}
+	if m.ConfirmFeeExemptGas != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.ConfirmFeeExemptGas))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xe0
+	}
+	if len(m.Erc20InitCodeHash) > 0 {
+		i -= len(m.Erc20InitCodeHash)
+		copy(dAtA[i:], m.Erc20InitCodeHash)
+		i = encodeVarintParams(dAtA, i, uint64(len(m.Erc20InitCodeHash)))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xda
+	}
+	if m.MaxOrchestratorsPerValidator != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.MaxOrchestratorsPerValidator))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xd0
+	}
+	if m.ConfirmGracePeriod != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.ConfirmGracePeriod))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xc8
+	}
+	if m.MinValsetPower != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.MinValsetPower))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xc0
+	}
+	if m.MaxValsetSize != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.MaxValsetSize))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xb8
+	}
+	{
+		size := m.SlashedFundsRelayerShare.Size()
+		i -= size
+		if _, err := m.SlashedFundsRelayerShare.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintParams(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x2
+	i--
+	dAtA[i] = 0xb2
+	if len(m.TokenBatchGas) > 0 {
+		for iNdEx := len(m.TokenBatchGas) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.TokenBatchGas[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintParams(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2
+			i--
+			dAtA[i] = 0xaa
+		}
+	}
+	if m.BatchTxGas != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.BatchTxGas))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xa0
+	}
+	if m.BatchBaseGas != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.BatchBaseGas))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0x98
+	}
+	if m.TargetBatchGas != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.TargetBatchGas))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0x90
+	}
+	if m.ResidueSweepInterval != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.ResidueSweepInterval))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0x88
+	}
+	if m.HexCosmosReceivers {
+		i--
+		if m.HexCosmosReceivers {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0x80
+	}
+	if m.BatchCancelGracePeriod != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.BatchCancelGracePeriod))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xf8
+	}
+	if m.RelayerAllowlistBatchRequests {
+		i--
+		if m.RelayerAllowlistBatchRequests {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xf0
+	}
+	if m.RelayerAllowlist {
+		i--
+		if m.RelayerAllowlist {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xe8
+	}
+	if m.EndBlockerWorkBudget != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.EndBlockerWorkBudget))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xe0
+	}
+	if m.RestrictVoucherTransfers {
+		i--
+		if m.RestrictVoucherTransfers {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xd8
+	}
+	if len(m.SignatureScheme) > 0 {
+		i -= len(m.SignatureScheme)
+		copy(dAtA[i:], m.SignatureScheme)
+		i = encodeVarintParams(dAtA, i, uint64(len(m.SignatureScheme)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xd2
+	}
+	if m.AttestationTimeout != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.AttestationTimeout))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xc8
+	}
+	if m.EthereumBlockConfirmations != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.EthereumBlockConfirmations))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xc0
+	}
+	if m.BatchRequestCooldown != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.BatchRequestCooldown))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xb8
+	}
+	{
+		size := m.BatchRequestMinFee.Size()
+		i -= size
+		if _, err := m.BatchRequestMinFee.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintParams(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0xb2
+	if m.BridgeFeeBasisPoints != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.BridgeFeeBasisPoints))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa8
+	}
+	if len(m.TokenDenylist) > 0 {
+		for iNdEx := len(m.TokenDenylist) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.TokenDenylist[iNdEx])
+			copy(dAtA[i:], m.TokenDenylist[iNdEx])
+			i = encodeVarintParams(dAtA, i, uint64(len(m.TokenDenylist[iNdEx])))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xa2
+		}
+	}
+	if len(m.TokenAllowlist) > 0 {
+		for iNdEx := len(m.TokenAllowlist) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.TokenAllowlist[iNdEx])
+			copy(dAtA[i:], m.TokenAllowlist[iNdEx])
+			i = encodeVarintParams(dAtA, i, uint64(len(m.TokenAllowlist[iNdEx])))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x9a
+		}
+	}
+	if m.EthereumHeightWindow != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.EthereumHeightWindow))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x90
+	}
+	if m.UnbondSlashingValsetsWindow != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.UnbondSlashingValsetsWindow))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x88
+	}
+	{
+		size := m.SlashFractionConflictingClaim.Size()
+		i -= size
+		if _, err := m.SlashFractionConflictingClaim.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintParams(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0x82
+	{
+		size := m.SlashFractionClaim.Size()
+		i -= size
+		if _, err := m.SlashFractionClaim.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintParams(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x7a
+	{
+		size := m.SlashFractionBatch.Size()
+		i -= size
+		if _, err := m.SlashFractionBatch.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintParams(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x72
+	{
+		size := m.SlashFractionValset.Size()
+		i -= size
+		if _, err := m.SlashFractionValset.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintParams(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x6a
+	if m.AverageEthereumBlockTime != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.AverageEthereumBlockTime))
+		i--
+		dAtA[i] = 0x60
+	}
+	if m.AverageBlockTime != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.AverageBlockTime))
+		i--
+		dAtA[i] = 0x58
+	}
+	if m.TargetBatchTimeout != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.TargetBatchTimeout))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.SignedClaimsWindow != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.SignedClaimsWindow))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.SignedBatchesWindow != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.SignedBatchesWindow))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.SignedValsetsWindow != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.SignedValsetsWindow))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.BridgeChainId != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.BridgeChainId))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.BridgeEthereumAddress) > 0 {
+		i -= len(m.BridgeEthereumAddress)
+		copy(dAtA[i:], m.BridgeEthereumAddress)
+		i = encodeVarintParams(dAtA, i, uint64(len(m.BridgeEthereumAddress)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.ContractSourceHash) > 0 {
+		i -= len(m.ContractSourceHash)
+		copy(dAtA[i:], m.ContractSourceHash)
+		i = encodeVarintParams(dAtA, i, uint64(len(m.ContractSourceHash)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.GravityId) > 0 {
+		i -= len(m.GravityId)
+		copy(dAtA[i:], m.GravityId)
+		i = encodeVarintParams(dAtA, i, uint64(len(m.GravityId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TokenBatchGas) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TokenBatchGas) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TokenBatchGas) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TxGas != 0 {
+		i = encodeVarintParams(dAtA, i, uint64(m.TxGas))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.TokenContract) > 0 {
+		i -= len(m.TokenContract)
+		copy(dAtA[i:], m.TokenContract)
+		i = encodeVarintParams(dAtA, i, uint64(len(m.TokenContract)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintParams(dAtA []byte, offset int, v uint64) int {
+	offset -= sovParams(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Params) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.GravityId)
+	if l > 0 {
+		n += 1 + l + sovParams(uint64(l))
+	}
+	l = len(m.ContractSourceHash)
+	if l > 0 {
+		n += 1 + l + sovParams(uint64(l))
+	}
+	l = len(m.BridgeEthereumAddress)
+	if l > 0 {
+		n += 1 + l + sovParams(uint64(l))
+	}
+	if m.BridgeChainId != 0 {
+		n += 1 + sovParams(uint64(m.BridgeChainId))
+	}
+	if m.SignedValsetsWindow != 0 {
+		n += 1 + sovParams(uint64(m.SignedValsetsWindow))
+	}
+	if m.SignedBatchesWindow != 0 {
+		n += 1 + sovParams(uint64(m.SignedBatchesWindow))
+	}
+	if m.SignedClaimsWindow != 0 {
+		n += 1 + sovParams(uint64(m.SignedClaimsWindow))
+	}
+	if m.TargetBatchTimeout != 0 {
+		n += 1 + sovParams(uint64(m.TargetBatchTimeout))
+	}
+	if m.AverageBlockTime != 0 {
+		n += 1 + sovParams(uint64(m.AverageBlockTime))
+	}
+	if m.AverageEthereumBlockTime != 0 {
+		n += 1 + sovParams(uint64(m.AverageEthereumBlockTime))
+	}
+	l = m.SlashFractionValset.Size()
+	n += 1 + l + sovParams(uint64(l))
+	l = m.SlashFractionBatch.Size()
+	n += 1 + l + sovParams(uint64(l))
+	l = m.SlashFractionClaim.Size()
+	n += 1 + l + sovParams(uint64(l))
+	l = m.SlashFractionConflictingClaim.Size()
+	n += 2 + l + sovParams(uint64(l))
+	if m.UnbondSlashingValsetsWindow != 0 {
+		n += 2 + sovParams(uint64(m.UnbondSlashingValsetsWindow))
+	}
+	if m.EthereumHeightWindow != 0 {
+		n += 2 + sovParams(uint64(m.EthereumHeightWindow))
+	}
+	if len(m.TokenAllowlist) > 0 {
+		for _, s := range m.TokenAllowlist {
+			l = len(s)
+			n += 2 + l + sovParams(uint64(l))
+		}
+	}
+	if len(m.TokenDenylist) > 0 {
+		for _, s := range m.TokenDenylist {
+			l = len(s)
+			n += 2 + l + sovParams(uint64(l))
+		}
+	}
+	if m.BridgeFeeBasisPoints != 0 {
+		n += 2 + sovParams(uint64(m.BridgeFeeBasisPoints))
+	}
+	l = m.BatchRequestMinFee.Size()
+	n += 2 + l + sovParams(uint64(l))
+	if m.BatchRequestCooldown != 0 {
+		n += 2 + sovParams(uint64(m.BatchRequestCooldown))
+	}
+	if m.EthereumBlockConfirmations != 0 {
+		n += 2 + sovParams(uint64(m.EthereumBlockConfirmations))
+	}
+	if m.AttestationTimeout != 0 {
+		n += 2 + sovParams(uint64(m.AttestationTimeout))
+	}
+	l = len(m.SignatureScheme)
+	if l > 0 {
+		n += 2 + l + sovParams(uint64(l))
+	}
+	if m.RestrictVoucherTransfers {
+		n += 3
+	}
+	if m.EndBlockerWorkBudget != 0 {
+		n += 2 + sovParams(uint64(m.EndBlockerWorkBudget))
+	}
+	if m.RelayerAllowlist {
+		n += 3
+	}
+	if m.RelayerAllowlistBatchRequests {
+		n += 3
+	}
+	if m.BatchCancelGracePeriod != 0 {
+		n += 2 + sovParams(uint64(m.BatchCancelGracePeriod))
+	}
+	if m.HexCosmosReceivers {
+		n += 3
+	}
+	if m.ResidueSweepInterval != 0 {
+		n += 2 + sovParams(uint64(m.ResidueSweepInterval))
+	}
+	if m.TargetBatchGas != 0 {
+		n += 2 + sovParams(uint64(m.TargetBatchGas))
+	}
+	if m.BatchBaseGas != 0 {
+		n += 2 + sovParams(uint64(m.BatchBaseGas))
+	}
+	if m.BatchTxGas != 0 {
+		n += 2 + sovParams(uint64(m.BatchTxGas))
+	}
+	if len(m.TokenBatchGas) > 0 {
+		for _, e := range m.TokenBatchGas {
+			l = e.Size()
+			n += 2 + l + sovParams(uint64(l))
+		}
+	}
+	l = m.SlashedFundsRelayerShare.Size()
+	n += 2 + l + sovParams(uint64(l))
+	if m.MaxValsetSize != 0 {
+		n += 2 + sovParams(uint64(m.MaxValsetSize))
+	}
+	if m.MinValsetPower != 0 {
+		n += 2 + sovParams(uint64(m.MinValsetPower))
+	}
+	if m.ConfirmGracePeriod != 0 {
+		n += 2 + sovParams(uint64(m.ConfirmGracePeriod))
+	}
+	if m.MaxOrchestratorsPerValidator != 0 {
+		n += 2 + sovParams(uint64(m.MaxOrchestratorsPerValidator))
+	}
+	l = len(m.Erc20InitCodeHash)
+	if l > 0 {
+		n += 2 + l + sovParams(uint64(l))
+	}
+	if m.ConfirmFeeExemptGas != 0 {
+		n += 2 + sovParams(uint64(m.ConfirmFeeExemptGas))
+	}
+	if m.BridgeNativeToken {
+		n += 3
+	}
+	l = m.NativeTokenBridgeCap.Size()
+	n += 2 + l + sovParams(uint64(l))
+	if m.ObservedNonceLagThreshold != 0 {
+		n += 2 + sovParams(uint64(m.ObservedNonceLagThreshold))
+	}
+	if m.ConfirmFeeExemptTxs != 0 {
+		n += 2 + sovParams(uint64(m.ConfirmFeeExemptTxs))
+	}
+	if m.AccountActivityRetention != 0 {
+		n += 2 + sovParams(uint64(m.AccountActivityRetention))
+	}
+	return n
+}
+
+func (m *TokenBatchGas) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TokenContract)
+	if l > 0 {
+		n += 1 + l + sovParams(uint64(l))
+	}
+	if m.TxGas != 0 {
+		n += 1 + sovParams(uint64(m.TxGas))
+	}
+	return n
+}
+
+func sovParams(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozParams(x uint64) (n int) {
+	return sovParams(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Params) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Params: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Params: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GravityId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GravityId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContractSourceHash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContractSourceHash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BridgeEthereumAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BridgeEthereumAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BridgeChainId", wireType)
+			}
+			m.BridgeChainId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BridgeChainId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignedValsetsWindow", wireType)
+			}
+			m.SignedValsetsWindow = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SignedValsetsWindow |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignedBatchesWindow", wireType)
+			}
+			m.SignedBatchesWindow = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SignedBatchesWindow |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignedClaimsWindow", wireType)
+			}
+			m.SignedClaimsWindow = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SignedClaimsWindow |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetBatchTimeout", wireType)
+			}
+			m.TargetBatchTimeout = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TargetBatchTimeout |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AverageBlockTime", wireType)
+			}
+			m.AverageBlockTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AverageBlockTime |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AverageEthereumBlockTime", wireType)
+			}
+			m.AverageEthereumBlockTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AverageEthereumBlockTime |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SlashFractionValset", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.SlashFractionValset.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SlashFractionBatch", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.SlashFractionBatch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SlashFractionClaim", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.SlashFractionClaim.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SlashFractionConflictingClaim", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.SlashFractionConflictingClaim.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UnbondSlashingValsetsWindow", wireType)
+			}
+			m.UnbondSlashingValsetsWindow = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UnbondSlashingValsetsWindow |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 18:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EthereumHeightWindow", wireType)
+			}
+			m.EthereumHeightWindow = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EthereumHeightWindow |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenAllowlist", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenAllowlist = append(m.TokenAllowlist, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenDenylist", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenDenylist = append(m.TokenDenylist, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 21:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BridgeFeeBasisPoints", wireType)
+			}
+			m.BridgeFeeBasisPoints = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BridgeFeeBasisPoints |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 22:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BatchRequestMinFee", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.BatchRequestMinFee.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 23:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BatchRequestCooldown", wireType)
+			}
+			m.BatchRequestCooldown = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BatchRequestCooldown |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 24:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EthereumBlockConfirmations", wireType)
+			}
+			m.EthereumBlockConfirmations = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EthereumBlockConfirmations |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 25:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AttestationTimeout", wireType)
+			}
+			m.AttestationTimeout = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AttestationTimeout |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 26:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignatureScheme", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SignatureScheme = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 27:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RestrictVoucherTransfers", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RestrictVoucherTransfers = bool(v != 0)
+		case 28:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndBlockerWorkBudget", wireType)
+			}
+			m.EndBlockerWorkBudget = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EndBlockerWorkBudget |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 29:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RelayerAllowlist", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RelayerAllowlist = bool(v != 0)
+		case 30:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RelayerAllowlistBatchRequests", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RelayerAllowlistBatchRequests = bool(v != 0)
+		case 31:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BatchCancelGracePeriod", wireType)
+			}
+			m.BatchCancelGracePeriod = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BatchCancelGracePeriod |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 32:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HexCosmosReceivers", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.HexCosmosReceivers = bool(v != 0)
+		case 33:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResidueSweepInterval", wireType)
+			}
+			m.ResidueSweepInterval = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ResidueSweepInterval |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 34:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetBatchGas", wireType)
+			}
+			m.TargetBatchGas = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TargetBatchGas |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 35:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BatchBaseGas", wireType)
+			}
+			m.BatchBaseGas = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BatchBaseGas |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 36:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BatchTxGas", wireType)
+			}
+			m.BatchTxGas = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BatchTxGas |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 37:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenBatchGas", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenBatchGas = append(m.TokenBatchGas, TokenBatchGas{})
+			if err := m.TokenBatchGas[len(m.TokenBatchGas)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 38:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SlashedFundsRelayerShare", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.SlashedFundsRelayerShare.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 39:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxValsetSize", wireType)
+			}
+			m.MaxValsetSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxValsetSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 40:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinValsetPower", wireType)
+			}
+			m.MinValsetPower = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinValsetPower |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 41:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfirmGracePeriod", wireType)
+			}
+			m.ConfirmGracePeriod = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ConfirmGracePeriod |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 42:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxOrchestratorsPerValidator", wireType)
+			}
+			m.MaxOrchestratorsPerValidator = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxOrchestratorsPerValidator |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 43:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Erc20InitCodeHash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Erc20InitCodeHash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 44:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfirmFeeExemptGas", wireType)
+			}
+			m.ConfirmFeeExemptGas = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ConfirmFeeExemptGas |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 45:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BridgeNativeToken", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.BridgeNativeToken = bool(v != 0)
+		case 46:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NativeTokenBridgeCap", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.NativeTokenBridgeCap.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 47:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObservedNonceLagThreshold", wireType)
+			}
+			m.ObservedNonceLagThreshold = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ObservedNonceLagThreshold |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 48:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfirmFeeExemptTxs", wireType)
+			}
+			m.ConfirmFeeExemptTxs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ConfirmFeeExemptTxs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 49:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AccountActivityRetention", wireType)
+			}
+			m.AccountActivityRetention = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AccountActivityRetention |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipParams(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TokenBatchGas) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TokenBatchGas: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TokenBatchGas: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenContract", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenContract = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxGas", wireType)
+			}
+			m.TxGas = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TxGas |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipParams(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthParams
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipParams(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowParams
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthParams
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupParams
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthParams
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthParams        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowParams          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupParams = fmt.Errorf("proto: unexpected end of group")
+)
@@ -1,5 +1,5 @@
 // Code generated by protoc-gen-gogo. DO NOT EDIT.
-// source: peggy/v1/pool.proto
+// source: gravity/v1/pool.proto
 
 package types
 
@@ -33,7 +33,7 @@ func (m *IDSet) Reset()         { *m = IDSet{} }
 func (m *IDSet) String() string { return proto.CompactTextString(m) }
 func (*IDSet) ProtoMessage()    {}
 func (*IDSet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_de0a859def4c189a, []int{0}
+	return fileDescriptor_18d107f7cfc31f22, []int{0}
 }
 func (m *IDSet) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -78,7 +78,7 @@ func (m *BatchFees) Reset()         { *m = BatchFees{} }
 func (m *BatchFees) String() string { return proto.CompactTextString(m) }
 func (*BatchFees) ProtoMessage()    {}
 func (*BatchFees) Descriptor() ([]byte, []int) {
-	return fileDescriptor_de0a859def4c189a, []int{1}
+	return fileDescriptor_18d107f7cfc31f22, []int{1}
 }
 func (m *BatchFees) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -115,31 +115,31 @@ func (m *BatchFees) GetToken() string {
 }
 
 func init() {
-	proto.RegisterType((*IDSet)(nil), "peggy.v1.IDSet")
-	proto.RegisterType((*BatchFees)(nil), "peggy.v1.BatchFees")
+	proto.RegisterType((*IDSet)(nil), "gravity.v1.IDSet")
+	proto.RegisterType((*BatchFees)(nil), "gravity.v1.BatchFees")
 }
 
-func init() { proto.RegisterFile("peggy/v1/pool.proto", fileDescriptor_de0a859def4c189a) }
+func init() { proto.RegisterFile("gravity/v1/pool.proto", fileDescriptor_18d107f7cfc31f22) }
 
-var fileDescriptor_de0a859def4c189a = []byte{
-	// 263 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x2e, 0x48, 0x4d, 0x4f,
-	0xaf, 0xd4, 0x2f, 0x33, 0xd4, 0x2f, 0xc8, 0xcf, 0xcf, 0xd1, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17,
-	0xe2, 0x00, 0x0b, 0xea, 0x95, 0x19, 0x4a, 0x89, 0xa4, 0xe7, 0xa7, 0xe7, 0x83, 0x05, 0xf5, 0x41,
-	0x2c, 0x88, 0xbc, 0x92, 0x24, 0x17, 0xab, 0xa7, 0x4b, 0x70, 0x6a, 0x89, 0x90, 0x00, 0x17, 0x73,
-	0x66, 0x4a, 0xb1, 0x04, 0xa3, 0x02, 0xb3, 0x06, 0x4b, 0x10, 0x88, 0xa9, 0x54, 0xc9, 0xc5, 0xe9,
-	0x94, 0x58, 0x92, 0x9c, 0xe1, 0x96, 0x9a, 0x5a, 0x2c, 0x24, 0xc2, 0xc5, 0x5a, 0x92, 0x9f, 0x9d,
-	0x9a, 0x27, 0xc1, 0xa8, 0xc0, 0xa8, 0xc1, 0x19, 0x04, 0xe1, 0x08, 0x85, 0x71, 0xf1, 0x97, 0xe4,
-	0x17, 0xc4, 0xe7, 0xe7, 0xa5, 0xc6, 0x67, 0x94, 0xe6, 0xa5, 0x14, 0xa5, 0xa6, 0x48, 0x30, 0x81,
-	0xe4, 0x9d, 0xf4, 0x4e, 0xdc, 0x93, 0x67, 0xb8, 0x75, 0x4f, 0x5e, 0x2d, 0x3d, 0xb3, 0x24, 0xa3,
-	0x34, 0x49, 0x2f, 0x39, 0x3f, 0x57, 0x3f, 0x39, 0xbf, 0x38, 0x37, 0xbf, 0x18, 0x4a, 0xe9, 0x16,
-	0xa7, 0x64, 0xeb, 0x97, 0x54, 0x16, 0xa4, 0x16, 0xeb, 0x79, 0xe6, 0x95, 0x04, 0xf1, 0x96, 0xe4,
-	0x17, 0xf8, 0xe7, 0xa5, 0x7a, 0x40, 0x0c, 0x71, 0xf2, 0x3f, 0xf1, 0x48, 0x8e, 0xf1, 0xc2, 0x23,
-	0x39, 0xc6, 0x07, 0x8f, 0xe4, 0x18, 0x27, 0x3c, 0x96, 0x63, 0xb8, 0xf0, 0x58, 0x8e, 0xe1, 0xc6,
-	0x63, 0x39, 0x86, 0x28, 0x53, 0x4c, 0x03, 0xd3, 0x8b, 0x12, 0xcb, 0x32, 0x4b, 0x2a, 0x75, 0x93,
-	0x8a, 0x32, 0x53, 0xd2, 0x53, 0xf5, 0x73, 0xf3, 0x53, 0x4a, 0x73, 0x52, 0xf5, 0x2b, 0xf4, 0x21,
-	0xc1, 0x01, 0xb6, 0x23, 0x89, 0x0d, 0xec, 0x5b, 0x63, 0x40, 0x00, 0x00, 0x00, 0xff, 0xff, 0x7a,
-	0x44, 0x80, 0xdf, 0x24, 0x01, 0x00, 0x00,
+var fileDescriptor_18d107f7cfc31f22 = []byte{
+	// 259 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x4d, 0x2f, 0x4a, 0x2c,
+	0xcb, 0x2c, 0xa9, 0xd4, 0x2f, 0x33, 0xd4, 0x2f, 0xc8, 0xcf, 0xcf, 0xd1, 0x2b, 0x28, 0xca, 0x2f,
+	0xc9, 0x17, 0xe2, 0x82, 0x0a, 0xeb, 0x95, 0x19, 0x4a, 0x89, 0xa4, 0xe7, 0xa7, 0xe7, 0x83, 0x85,
+	0xf5, 0x41, 0x2c, 0x88, 0x0a, 0x25, 0x49, 0x2e, 0x56, 0x4f, 0x97, 0xe0, 0xd4, 0x12, 0x21, 0x01,
+	0x2e, 0xe6, 0xcc, 0x94, 0x62, 0x09, 0x46, 0x05, 0x66, 0x0d, 0x96, 0x20, 0x10, 0x53, 0xa9, 0x92,
+	0x8b, 0xd3, 0x29, 0xb1, 0x24, 0x39, 0xc3, 0x2d, 0x35, 0xb5, 0x58, 0x48, 0x84, 0x8b, 0xb5, 0x24,
+	0x3f, 0x3b, 0x35, 0x4f, 0x82, 0x51, 0x81, 0x51, 0x83, 0x33, 0x08, 0xc2, 0x11, 0x0a, 0xe3, 0xe2,
+	0x2f, 0xc9, 0x2f, 0x88, 0xcf, 0xcf, 0x4b, 0x8d, 0xcf, 0x28, 0xcd, 0x4b, 0x29, 0x4a, 0x4d, 0x91,
+	0x60, 0x02, 0xc9, 0x3b, 0xe9, 0x9d, 0xb8, 0x27, 0xcf, 0x70, 0xeb, 0x9e, 0xbc, 0x5a, 0x7a, 0x66,
+	0x49, 0x46, 0x69, 0x92, 0x5e, 0x72, 0x7e, 0xae, 0x7e, 0x72, 0x7e, 0x71, 0x6e, 0x7e, 0x31, 0x94,
+	0xd2, 0x2d, 0x4e, 0xc9, 0xd6, 0x2f, 0xa9, 0x2c, 0x48, 0x2d, 0xd6, 0xf3, 0xcc, 0x2b, 0x09, 0xe2,
+	0x2d, 0xc9, 0x2f, 0xf0, 0xcf, 0x4b, 0xf5, 0x80, 0x18, 0xe2, 0xe4, 0x7f, 0xe2, 0x91, 0x1c, 0xe3,
+	0x85, 0x47, 0x72, 0x8c, 0x0f, 0x1e, 0xc9, 0x31, 0x4e, 0x78, 0x2c, 0xc7, 0x70, 0xe1, 0xb1, 0x1c,
+	0xc3, 0x8d, 0xc7, 0x72, 0x0c, 0x51, 0xa6, 0x98, 0x06, 0x42, 0xfd, 0xa8, 0x9b, 0x54, 0x94, 0x99,
+	0x92, 0x9e, 0xaa, 0x9f, 0x9b, 0x9f, 0x52, 0x9a, 0x93, 0xaa, 0x5f, 0xa1, 0x5f, 0x90, 0x9a, 0x9e,
+	0x5e, 0x09, 0xb1, 0x23, 0x89, 0x0d, 0xec, 0x5b, 0x63, 0xc0, 0x00, 0x5d, 0xe2, 0xf2, 0xac, 0x28,
+	0x01, 0x00, 0x00,
 }
 
 func (m *IDSet) Marshal() (dAtA []byte, err error) {
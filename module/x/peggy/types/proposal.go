@@ -0,0 +1,467 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+const (
+	// ProposalTypeReturnReclaimableDeposit defines the type for a ReturnReclaimableDepositProposal
+	ProposalTypeReturnReclaimableDeposit = "ReturnReclaimableDeposit"
+	// ProposalTypeUpdateBridgeContract defines the type for a UpdateBridgeContractProposal
+	ProposalTypeUpdateBridgeContract = "UpdateBridgeContract"
+	// ProposalTypeAbandonValsetNonce defines the type for a AbandonValsetNonceProposal
+	ProposalTypeAbandonValsetNonce = "AbandonValsetNonce"
+	// ProposalTypeUpdateParams defines the type for a UpdateParamsProposal
+	ProposalTypeUpdateParams = "UpdateParams"
+	// ProposalTypeCancelBatch defines the type for a CancelBatchProposal
+	ProposalTypeCancelBatch = "CancelBatch"
+	// ProposalTypeSetLastObservedEventNonce defines the type for a SetLastObservedEventNonceProposal
+	ProposalTypeSetLastObservedEventNonce = "SetLastObservedEventNonce"
+	// ProposalTypePauseToken defines the type for a PauseTokenProposal
+	ProposalTypePauseToken = "PauseToken"
+	// ProposalTypeUnpauseToken defines the type for a UnpauseTokenProposal
+	ProposalTypeUnpauseToken = "UnpauseToken"
+	// ProposalTypeSetCosmosOriginatedERC20 defines the type for a SetCosmosOriginatedERC20Proposal
+	ProposalTypeSetCosmosOriginatedERC20 = "SetCosmosOriginatedERC20"
+)
+
+var (
+	_ govtypes.Content = &ReturnReclaimableDepositProposal{}
+	_ govtypes.Content = &UpdateBridgeContractProposal{}
+	_ govtypes.Content = &AbandonValsetNonceProposal{}
+	_ govtypes.Content = &UpdateParamsProposal{}
+	_ govtypes.Content = &CancelBatchProposal{}
+	_ govtypes.Content = &SetLastObservedEventNonceProposal{}
+	_ govtypes.Content = &PauseTokenProposal{}
+	_ govtypes.Content = &UnpauseTokenProposal{}
+	_ govtypes.Content = &SetCosmosOriginatedERC20Proposal{}
+)
+
+func init() {
+	govtypes.RegisterProposalType(ProposalTypeReturnReclaimableDeposit)
+	govtypes.RegisterProposalTypeCodec(&ReturnReclaimableDepositProposal{}, "peggy/ReturnReclaimableDepositProposal")
+	govtypes.RegisterProposalType(ProposalTypeUpdateBridgeContract)
+	govtypes.RegisterProposalTypeCodec(&UpdateBridgeContractProposal{}, "peggy/UpdateBridgeContractProposal")
+	govtypes.RegisterProposalType(ProposalTypeAbandonValsetNonce)
+	govtypes.RegisterProposalTypeCodec(&AbandonValsetNonceProposal{}, "peggy/AbandonValsetNonceProposal")
+	govtypes.RegisterProposalType(ProposalTypeUpdateParams)
+	govtypes.RegisterProposalTypeCodec(&UpdateParamsProposal{}, "peggy/UpdateParamsProposal")
+	govtypes.RegisterProposalType(ProposalTypeCancelBatch)
+	govtypes.RegisterProposalTypeCodec(&CancelBatchProposal{}, "peggy/CancelBatchProposal")
+	govtypes.RegisterProposalType(ProposalTypeSetLastObservedEventNonce)
+	govtypes.RegisterProposalTypeCodec(&SetLastObservedEventNonceProposal{}, "peggy/SetLastObservedEventNonceProposal")
+	govtypes.RegisterProposalType(ProposalTypePauseToken)
+	govtypes.RegisterProposalTypeCodec(&PauseTokenProposal{}, "peggy/PauseTokenProposal")
+	govtypes.RegisterProposalType(ProposalTypeUnpauseToken)
+	govtypes.RegisterProposalTypeCodec(&UnpauseTokenProposal{}, "peggy/UnpauseTokenProposal")
+	govtypes.RegisterProposalType(ProposalTypeSetCosmosOriginatedERC20)
+	govtypes.RegisterProposalTypeCodec(&SetCosmosOriginatedERC20Proposal{}, "peggy/SetCosmosOriginatedERC20Proposal")
+}
+
+// NewReturnReclaimableDepositProposal returns a new proposal to return the reclaimable deposit
+// with the given event nonce to destination
+func NewReturnReclaimableDepositProposal(title, description string, eventNonce uint64, destination sdk.AccAddress) *ReturnReclaimableDepositProposal {
+	return &ReturnReclaimableDepositProposal{
+		Title:       title,
+		Description: description,
+		EventNonce:  eventNonce,
+		Destination: destination.String(),
+	}
+}
+
+// GetTitle returns the title of the proposal
+func (p *ReturnReclaimableDepositProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the description of the proposal
+func (p *ReturnReclaimableDepositProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute returns the routing key of the proposal
+func (p *ReturnReclaimableDepositProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of the proposal
+func (p *ReturnReclaimableDepositProposal) ProposalType() string {
+	return ProposalTypeReturnReclaimableDeposit
+}
+
+// ValidateBasic performs stateless checks
+func (p *ReturnReclaimableDepositProposal) ValidateBasic() error {
+	if err := govtypes.ValidateAbstract(p); err != nil {
+		return err
+	}
+	if p.EventNonce == 0 {
+		return sdkerrors.Wrap(ErrInvalid, "event nonce")
+	}
+	if _, err := sdk.AccAddressFromBech32(p.Destination); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, p.Destination)
+	}
+	return nil
+}
+
+// String implements the Stringer interface
+func (p ReturnReclaimableDepositProposal) String() string {
+	return fmt.Sprintf(`Return Reclaimable Deposit Proposal:
+  Title:       %s
+  Description: %s
+  Event Nonce: %d
+  Destination: %s
+`, p.Title, p.Description, p.EventNonce, p.Destination)
+}
+
+// NewUpdateBridgeContractProposal returns a new proposal to move the bridge to the contract
+// deployed at bridgeContractAddress once the final event of the old contract was observed
+func NewUpdateBridgeContractProposal(title, description, bridgeContractAddress string, finalEventNonce, lastObservedEventNonce uint64, erc20InitCodeHash string) *UpdateBridgeContractProposal {
+	return &UpdateBridgeContractProposal{
+		Title:                  title,
+		Description:            description,
+		BridgeContractAddress:  bridgeContractAddress,
+		FinalEventNonce:        finalEventNonce,
+		LastObservedEventNonce: lastObservedEventNonce,
+		Erc20InitCodeHash:      erc20InitCodeHash,
+	}
+}
+
+// Rotation returns the contract rotation the proposal executes
+func (p *UpdateBridgeContractProposal) Rotation() ContractRotation {
+	return ContractRotation{
+		BridgeEthereumAddress:  p.BridgeContractAddress,
+		FinalEventNonce:        p.FinalEventNonce,
+		LastObservedEventNonce: p.LastObservedEventNonce,
+		Erc20InitCodeHash:      p.Erc20InitCodeHash,
+	}
+}
+
+// GetTitle returns the title of the proposal
+func (p *UpdateBridgeContractProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the description of the proposal
+func (p *UpdateBridgeContractProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute returns the routing key of the proposal
+func (p *UpdateBridgeContractProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of the proposal
+func (p *UpdateBridgeContractProposal) ProposalType() string {
+	return ProposalTypeUpdateBridgeContract
+}
+
+// ValidateBasic performs stateless checks
+func (p *UpdateBridgeContractProposal) ValidateBasic() error {
+	if err := govtypes.ValidateAbstract(p); err != nil {
+		return err
+	}
+	if err := p.Rotation().ValidateBasic(); err != nil {
+		return sdkerrors.Wrap(err, "contract rotation")
+	}
+	return nil
+}
+
+// String implements the Stringer interface
+func (p UpdateBridgeContractProposal) String() string {
+	return fmt.Sprintf(`Update Bridge Contract Proposal:
+  Title:                     %s
+  Description:               %s
+  Bridge Contract Address:   %s
+  Final Event Nonce:         %d
+  Last Observed Event Nonce: %d
+  ERC20 Init Code Hash:      %s
+`, p.Title, p.Description, p.BridgeContractAddress, p.FinalEventNonce, p.LastObservedEventNonce, p.Erc20InitCodeHash)
+}
+
+// NewAbandonValsetNonceProposal returns a new proposal to accept the skipped valset nonce as abandoned
+func NewAbandonValsetNonceProposal(title, description string, valsetNonce uint64) *AbandonValsetNonceProposal {
+	return &AbandonValsetNonceProposal{
+		Title:       title,
+		Description: description,
+		ValsetNonce: valsetNonce,
+	}
+}
+
+// GetTitle returns the title of the proposal
+func (p *AbandonValsetNonceProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the description of the proposal
+func (p *AbandonValsetNonceProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute returns the routing key of the proposal
+func (p *AbandonValsetNonceProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of the proposal
+func (p *AbandonValsetNonceProposal) ProposalType() string {
+	return ProposalTypeAbandonValsetNonce
+}
+
+// ValidateBasic performs stateless checks
+func (p *AbandonValsetNonceProposal) ValidateBasic() error {
+	if err := govtypes.ValidateAbstract(p); err != nil {
+		return err
+	}
+	if p.ValsetNonce == 0 {
+		return sdkerrors.Wrap(ErrInvalid, "valset nonce must be set")
+	}
+	return nil
+}
+
+// String implements the Stringer interface
+func (p AbandonValsetNonceProposal) String() string {
+	return fmt.Sprintf(`Abandon Valset Nonce Proposal:
+  Title:        %s
+  Description:  %s
+  Valset Nonce: %d
+`, p.Title, p.Description, p.ValsetNonce)
+}
+
+// NewUpdateParamsProposal returns a new proposal to replace the params
+func NewUpdateParamsProposal(title, description string, params Params) *UpdateParamsProposal {
+	return &UpdateParamsProposal{
+		Title:       title,
+		Description: description,
+		Params:      params,
+	}
+}
+
+// GetTitle returns the title of the proposal
+func (p *UpdateParamsProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the description of the proposal
+func (p *UpdateParamsProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute returns the routing key of the proposal
+func (p *UpdateParamsProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of the proposal
+func (p *UpdateParamsProposal) ProposalType() string {
+	return ProposalTypeUpdateParams
+}
+
+// ValidateBasic performs stateless checks
+func (p *UpdateParamsProposal) ValidateBasic() error {
+	if err := govtypes.ValidateAbstract(p); err != nil {
+		return err
+	}
+	return sdkerrors.Wrap(p.Params.Validate(), "params")
+}
+
+// String implements the Stringer interface
+func (p UpdateParamsProposal) String() string {
+	return fmt.Sprintf(`Update Params Proposal:
+  Title:        %s
+  Description:  %s
+  Params:
+%s
+`, p.Title, p.Description, p.Params.String())
+}
+
+// NewCancelBatchProposal returns a new proposal to cancel a batch
+func NewCancelBatchProposal(title, description, tokenContract string, nonce uint64) *CancelBatchProposal {
+	return &CancelBatchProposal{
+		Title:         title,
+		Description:   description,
+		TokenContract: tokenContract,
+		Nonce:         nonce,
+	}
+}
+
+// GetTitle returns the title of the proposal
+func (p *CancelBatchProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the description of the proposal
+func (p *CancelBatchProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute returns the routing key of the proposal
+func (p *CancelBatchProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of the proposal
+func (p *CancelBatchProposal) ProposalType() string {
+	return ProposalTypeCancelBatch
+}
+
+// ValidateBasic performs stateless checks
+func (p *CancelBatchProposal) ValidateBasic() error {
+	if err := govtypes.ValidateAbstract(p); err != nil {
+		return err
+	}
+	if err := ValidateEthAddress(p.TokenContract); err != nil {
+		return sdkerrors.Wrap(err, "token contract")
+	}
+	if p.Nonce == 0 {
+		return sdkerrors.Wrap(ErrInvalid, "batch nonce must be set")
+	}
+	return nil
+}
+
+// String implements the Stringer interface
+func (p CancelBatchProposal) String() string {
+	return fmt.Sprintf(`Cancel Batch Proposal:
+  Title:          %s
+  Description:    %s
+  Token Contract: %s
+  Batch Nonce:    %d
+`, p.Title, p.Description, p.TokenContract, p.Nonce)
+}
+
+// NewSetLastObservedEventNonceProposal returns a new proposal to set the last observed event nonce
+func NewSetLastObservedEventNonceProposal(title, description string, nonce uint64) *SetLastObservedEventNonceProposal {
+	return &SetLastObservedEventNonceProposal{
+		Title:       title,
+		Description: description,
+		Nonce:       nonce,
+	}
+}
+
+// GetTitle returns the title of the proposal
+func (p *SetLastObservedEventNonceProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the description of the proposal
+func (p *SetLastObservedEventNonceProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute returns the routing key of the proposal
+func (p *SetLastObservedEventNonceProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of the proposal
+func (p *SetLastObservedEventNonceProposal) ProposalType() string {
+	return ProposalTypeSetLastObservedEventNonce
+}
+
+// ValidateBasic performs stateless checks
+func (p *SetLastObservedEventNonceProposal) ValidateBasic() error {
+	return govtypes.ValidateAbstract(p)
+}
+
+// String implements the Stringer interface
+func (p SetLastObservedEventNonceProposal) String() string {
+	return fmt.Sprintf(`Set Last Observed Event Nonce Proposal:
+  Title:       %s
+  Description: %s
+  Event Nonce: %d
+`, p.Title, p.Description, p.Nonce)
+}
+
+// NewPauseTokenProposal returns a new proposal to pause the bridging of a token
+func NewPauseTokenProposal(title, description, tokenContract string) *PauseTokenProposal {
+	return &PauseTokenProposal{
+		Title:         title,
+		Description:   description,
+		TokenContract: tokenContract,
+	}
+}
+
+// GetTitle returns the title of the proposal
+func (p *PauseTokenProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the description of the proposal
+func (p *PauseTokenProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute returns the routing key of the proposal
+func (p *PauseTokenProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of the proposal
+func (p *PauseTokenProposal) ProposalType() string { return ProposalTypePauseToken }
+
+// ValidateBasic performs stateless checks
+func (p *PauseTokenProposal) ValidateBasic() error {
+	if err := govtypes.ValidateAbstract(p); err != nil {
+		return err
+	}
+	if err := ValidateEthAddress(p.TokenContract); err != nil {
+		return sdkerrors.Wrap(err, "token contract")
+	}
+	return nil
+}
+
+// String implements the Stringer interface
+func (p PauseTokenProposal) String() string {
+	return fmt.Sprintf(`Pause Token Proposal:
+  Title:          %s
+  Description:    %s
+  Token Contract: %s
+`, p.Title, p.Description, p.TokenContract)
+}
+
+// NewUnpauseTokenProposal returns a new proposal to unpause the bridging of a token
+func NewUnpauseTokenProposal(title, description, tokenContract string) *UnpauseTokenProposal {
+	return &UnpauseTokenProposal{
+		Title:         title,
+		Description:   description,
+		TokenContract: tokenContract,
+	}
+}
+
+// GetTitle returns the title of the proposal
+func (p *UnpauseTokenProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the description of the proposal
+func (p *UnpauseTokenProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute returns the routing key of the proposal
+func (p *UnpauseTokenProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of the proposal
+func (p *UnpauseTokenProposal) ProposalType() string { return ProposalTypeUnpauseToken }
+
+// ValidateBasic performs stateless checks
+func (p *UnpauseTokenProposal) ValidateBasic() error {
+	if err := govtypes.ValidateAbstract(p); err != nil {
+		return err
+	}
+	if err := ValidateEthAddress(p.TokenContract); err != nil {
+		return sdkerrors.Wrap(err, "token contract")
+	}
+	return nil
+}
+
+// String implements the Stringer interface
+func (p UnpauseTokenProposal) String() string {
+	return fmt.Sprintf(`Unpause Token Proposal:
+  Title:          %s
+  Description:    %s
+  Token Contract: %s
+`, p.Title, p.Description, p.TokenContract)
+}
+
+// NewSetCosmosOriginatedERC20Proposal returns a new proposal to correct the ERC20 of a cosmos originated denom
+func NewSetCosmosOriginatedERC20Proposal(title, description, denom, tokenContract string, decimals uint32) *SetCosmosOriginatedERC20Proposal {
+	return &SetCosmosOriginatedERC20Proposal{
+		Title:         title,
+		Description:   description,
+		Denom:         denom,
+		TokenContract: tokenContract,
+		Decimals:      decimals,
+	}
+}
+
+// GetTitle returns the title of the proposal
+func (p *SetCosmosOriginatedERC20Proposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the description of the proposal
+func (p *SetCosmosOriginatedERC20Proposal) GetDescription() string { return p.Description }
+
+// ProposalRoute returns the routing key of the proposal
+func (p *SetCosmosOriginatedERC20Proposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of the proposal
+func (p *SetCosmosOriginatedERC20Proposal) ProposalType() string {
+	return ProposalTypeSetCosmosOriginatedERC20
+}
+
+// ValidateBasic performs stateless checks
+func (p *SetCosmosOriginatedERC20Proposal) ValidateBasic() error {
+	if err := govtypes.ValidateAbstract(p); err != nil {
+		return err
+	}
+	return validateCosmosOriginatedERC20(p.Denom, p.TokenContract, p.Decimals)
+}
+
+// String implements the Stringer interface
+func (p SetCosmosOriginatedERC20Proposal) String() string {
+	return fmt.Sprintf(`Set Cosmos Originated ERC20 Proposal:
+  Title:          %s
+  Description:    %s
+  Denom:          %s
+  Token Contract: %s
+  Decimals:       %d
+`, p.Title, p.Description, p.Denom, p.TokenContract, p.Decimals)
+}
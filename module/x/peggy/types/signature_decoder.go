@@ -0,0 +1,130 @@
+package types
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"strings"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// SignatureSchemeEIP191 has the orchestrators sign checkpoints with personal_sign
+	SignatureSchemeEIP191 = "eip191"
+	// SignatureSchemeEIP712 has the orchestrators sign checkpoints as EIP-712 typed data of the
+	// bridge contract domain
+	SignatureSchemeEIP712 = "eip712"
+
+	// EIP712DomainName is the name of the bridge contract EIP-712 domain
+	EIP712DomainName = "Gravity Bridge"
+	// EIP712DomainVersion is the version of the bridge contract EIP-712 domain
+	EIP712DomainVersion = "1"
+)
+
+// eip712DomainTypeHash is the type hash of an EIP-712 domain with name, version, chain id and
+// verifying contract
+var eip712DomainTypeHash = crypto.Keccak256([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+
+// SignatureDecoder turns the checkpoints signed by the orchestrators into the digest their Ethereum
+// key signs, the digest depends on how the bridge contract verifies signatures
+type SignatureDecoder interface {
+	// Digest returns the hash that is signed for the checkpoint
+	Digest(checkpoint []byte) []byte
+}
+
+// NewSignatureDecoder returns the SignatureDecoder of the signature scheme in the params, an empty
+// scheme is the EIP-191 scheme the bridge contract used before the scheme could be chosen
+func NewSignatureDecoder(p Params) (SignatureDecoder, error) {
+	switch p.SignatureScheme {
+	case SignatureSchemeEIP191, "":
+		return PersonalSignDecoder{}, nil
+	case SignatureSchemeEIP712:
+		return EIP712Decoder{
+			Name:              EIP712DomainName,
+			Version:           EIP712DomainVersion,
+			ChainID:           p.BridgeChainId,
+			VerifyingContract: p.BridgeEthereumAddress,
+		}, nil
+	default:
+		return nil, sdkerrors.Wrapf(ErrInvalid, "signature scheme %q", p.SignatureScheme)
+	}
+}
+
+// PersonalSignDecoder is the EIP-191 personal_sign scheme, the checkpoint is prefixed with
+// "\x19Ethereum Signed Message:\n32" before hashing
+type PersonalSignDecoder struct{}
+
+// Digest implements SignatureDecoder
+func (PersonalSignDecoder) Digest(checkpoint []byte) []byte {
+	return crypto.Keccak256(append([]uint8(signaturePrefix), checkpoint...))
+}
+
+// EIP712Decoder is the EIP-712 typed data scheme, the checkpoint is the hash of the struct
+// signed within the domain of the bridge contract
+type EIP712Decoder struct {
+	Name              string
+	Version           string
+	ChainID           uint64
+	VerifyingContract string
+}
+
+// DomainSeparator returns the EIP-712 domain separator of the bridge contract
+func (d EIP712Decoder) DomainSeparator() []byte {
+	return crypto.Keccak256(
+		eip712DomainTypeHash,
+		crypto.Keccak256([]byte(d.Name)),
+		crypto.Keccak256([]byte(d.Version)),
+		common.LeftPadBytes(new(big.Int).SetUint64(d.ChainID).Bytes(), 32),
+		common.LeftPadBytes(common.HexToAddress(d.VerifyingContract).Bytes(), 32),
+	)
+}
+
+// Digest implements SignatureDecoder
+func (d EIP712Decoder) Digest(checkpoint []byte) []byte {
+	return crypto.Keccak256([]byte("\x19\x01"), d.DomainSeparator(), checkpoint)
+}
+
+// SignCheckpoint signs the checkpoint with the given key as the decoder expects it
+func SignCheckpoint(decoder SignatureDecoder, checkpoint []byte, privateKey *ecdsa.PrivateKey) ([]byte, error) {
+	if privateKey == nil {
+		return nil, sdkerrors.Wrap(ErrEmpty, "private key")
+	}
+	return crypto.Sign(decoder.Digest(checkpoint), privateKey)
+}
+
+// RecoverCheckpointSigner returns the Ethereum address that made the signature over the checkpoint
+// in the scheme of the decoder
+func RecoverCheckpointSigner(decoder SignatureDecoder, checkpoint []byte, signature []byte) (common.Address, error) {
+	if len(signature) != 65 {
+		return common.Address{}, sdkerrors.Wrap(ErrInvalid, "signature must be 65 bytes")
+	}
+	// for backwards compatibility reasons the V value of an Ethereum sig is presented as 27 or
+	// 28 while go-ethereum expects 0 or 1, the signature is copied so the caller's bytes are
+	// left untouched
+	signature = append([]byte{}, signature...)
+	if signature[64] == 27 || signature[64] == 28 {
+		signature[64] -= 27
+	}
+
+	pubkey, err := crypto.SigToPub(decoder.Digest(checkpoint), signature)
+	if err != nil {
+		return common.Address{}, sdkerrors.Wrap(err, "signature to public key")
+	}
+	return crypto.PubkeyToAddress(*pubkey), nil
+}
+
+// VerifyCheckpointSignature returns an error unless the signature over the checkpoint, in the scheme
+// of the decoder, was made by the given Ethereum address
+func VerifyCheckpointSignature(decoder SignatureDecoder, checkpoint []byte, signature []byte, ethAddress string) error {
+	addr, err := RecoverCheckpointSigner(decoder, checkpoint, signature)
+	if err != nil {
+		return err
+	}
+	// addresses are compared case insensitive since the EIP-55 checksum is optional
+	if !strings.EqualFold(addr.Hex(), ethAddress) {
+		return sdkerrors.Wrap(ErrInvalid, "signature not matching")
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package types
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSignatureDecoder(t *testing.T) {
+	specs := map[string]struct {
+		src    Params
+		exp    SignatureDecoder
+		expErr bool
+	}{
+		"eip191": {
+			src: Params{SignatureScheme: SignatureSchemeEIP191},
+			exp: PersonalSignDecoder{},
+		},
+		"eip712": {
+			src: Params{
+				SignatureScheme:       SignatureSchemeEIP712,
+				BridgeChainId:         5,
+				BridgeEthereumAddress: "0x8858eeB3DfffA017D4BCE9801D340D36Cf895CCf",
+			},
+			exp: EIP712Decoder{
+				Name:              EIP712DomainName,
+				Version:           EIP712DomainVersion,
+				ChainID:           5,
+				VerifyingContract: "0x8858eeB3DfffA017D4BCE9801D340D36Cf895CCf",
+			},
+		},
+		"unknown": {
+			src:    Params{SignatureScheme: "eip1271"},
+			expErr: true,
+		},
+		"empty is eip191": {
+			exp: PersonalSignDecoder{},
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			got, err := NewSignatureDecoder(spec.src)
+			if spec.expErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestCheckpointSignatureSchemes(t *testing.T) {
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	ethAddress := crypto.PubkeyToAddress(privKey.PublicKey).Hex()
+	checkpoint, err := hex.DecodeString("88165860d955aee7dc3e83d9d1156a5864b708841965585d206dbef6e9e1a499")
+	require.NoError(t, err)
+
+	personal := PersonalSignDecoder{}
+	typed := EIP712Decoder{
+		Name:              EIP712DomainName,
+		Version:           EIP712DomainVersion,
+		ChainID:           5,
+		VerifyingContract: "0x8858eeB3DfffA017D4BCE9801D340D36Cf895CCf",
+	}
+	otherChain := typed
+	otherChain.ChainID = 1
+	otherContract := typed
+	otherContract.VerifyingContract = "0xc783df8a850f42e7F7e57013759C285caa701eB6"
+
+	// the personal_sign decoder keeps the signatures of the legacy helpers valid
+	legacySig, err := NewEthereumSignature(checkpoint, privKey)
+	require.NoError(t, err)
+	require.NoError(t, VerifyCheckpointSignature(personal, checkpoint, legacySig, ethAddress))
+
+	typedSig, err := SignCheckpoint(typed, checkpoint, privKey)
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		decoder   SignatureDecoder
+		signature []byte
+		expErr    bool
+	}{
+		"eip712 signature": {
+			decoder:   typed,
+			signature: typedSig,
+		},
+		"eip712 signature with 27/28 v value": {
+			decoder:   typed,
+			signature: append(append([]byte{}, typedSig[:64]...), typedSig[64]+27),
+		},
+		"eip191 signature checked as eip712": {
+			decoder:   typed,
+			signature: legacySig,
+			expErr:    true,
+		},
+		"eip712 signature checked as eip191": {
+			decoder:   personal,
+			signature: typedSig,
+			expErr:    true,
+		},
+		"eip712 signature of another chain": {
+			decoder:   otherChain,
+			signature: typedSig,
+			expErr:    true,
+		},
+		"eip712 signature of another contract": {
+			decoder:   otherContract,
+			signature: typedSig,
+			expErr:    true,
+		},
+		"signature too short": {
+			decoder:   typed,
+			signature: typedSig[:64],
+			expErr:    true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := VerifyCheckpointSignature(spec.decoder, checkpoint, spec.signature, ethAddress)
+			if spec.expErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
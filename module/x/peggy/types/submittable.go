@@ -0,0 +1,53 @@
+package types
+
+import (
+	"strings"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// NewCheckpointSignature splits the 65 byte Ethereum signature of a confirm into the v, r and s the
+// bridge contract takes. A v of 0 or 1, as go-ethereum signs, is shifted to the 27 or 28 ecrecover
+// expects.
+func NewCheckpointSignature(signature []byte) (CheckpointSignature, error) {
+	if len(signature) != 65 {
+		return CheckpointSignature{}, sdkerrors.Wrap(ErrInvalid, "signature must be 65 bytes")
+	}
+	v := uint32(signature[64])
+	if v < 27 {
+		v += 27
+	}
+	return CheckpointSignature{
+		V: v,
+		R: append([]byte{}, signature[:32]...),
+		S: append([]byte{}, signature[32:64]...),
+	}, nil
+}
+
+// SubmittableSignatures orders the signatures of a checkpoint like the members of the valset that
+// has to approve it, the signatures are keyed by the Ethereum address of their signer. Members
+// without a signature get an all zero one, which the bridge contract skips. The power of the members
+// that signed is returned along with them.
+func SubmittableSignatures(valset Valset, signatures map[string][]byte) ([]CheckpointSignature, uint64, error) {
+	// addresses are compared case insensitive since the EIP-55 checksum is optional
+	bySigner := make(map[string][]byte, len(signatures))
+	for signer, signature := range signatures {
+		bySigner[strings.ToLower(signer)] = signature
+	}
+	out := make([]CheckpointSignature, len(valset.Members))
+	var signed uint64
+	for i, member := range valset.Members {
+		signature, ok := bySigner[strings.ToLower(member.EthereumAddress)]
+		if !ok {
+			out[i] = CheckpointSignature{R: make([]byte, 32), S: make([]byte, 32)}
+			continue
+		}
+		sig, err := NewCheckpointSignature(signature)
+		if err != nil {
+			return nil, 0, sdkerrors.Wrapf(err, "signature of %s", member.EthereumAddress)
+		}
+		out[i] = sig
+		signed += member.Power
+	}
+	return out, signed, nil
+}
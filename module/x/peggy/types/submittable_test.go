@@ -0,0 +1,25 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCheckpointSignature(t *testing.T) {
+	sig := append(append(bytes.Repeat([]byte{1}, 32), bytes.Repeat([]byte{2}, 32)...), 0)
+	got, err := NewCheckpointSignature(sig)
+	require.NoError(t, err)
+	assert.Equal(t, CheckpointSignature{V: 27, R: bytes.Repeat([]byte{1}, 32), S: bytes.Repeat([]byte{2}, 32)}, got)
+
+	// a v already shifted is kept
+	sig[64] = 28
+	got, err = NewCheckpointSignature(sig)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(28), got.V)
+
+	_, err = NewCheckpointSignature(sig[:64])
+	assert.Error(t, err)
+}
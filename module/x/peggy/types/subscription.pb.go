@@ -0,0 +1,786 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: gravity/v1/subscription.proto
+
+package types
+
+import (
+	context "context"
+	fmt "fmt"
+	grpc1 "github.com/gogo/protobuf/grpc"
+	proto "github.com/gogo/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type SubscribePendingWorkRequest struct {
+	Orchestrator string `protobuf:"bytes,1,opt,name=orchestrator,proto3" json:"orchestrator,omitempty"`
+}
+
+func (m *SubscribePendingWorkRequest) Reset()         { *m = SubscribePendingWorkRequest{} }
+func (m *SubscribePendingWorkRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribePendingWorkRequest) ProtoMessage()    {}
+func (*SubscribePendingWorkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cce93029df78286a, []int{0}
+}
+func (m *SubscribePendingWorkRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubscribePendingWorkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubscribePendingWorkRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SubscribePendingWorkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribePendingWorkRequest.Merge(m, src)
+}
+func (m *SubscribePendingWorkRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubscribePendingWorkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribePendingWorkRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribePendingWorkRequest proto.InternalMessageInfo
+
+func (m *SubscribePendingWorkRequest) GetOrchestrator() string {
+	if m != nil {
+		return m.Orchestrator
+	}
+	return ""
+}
+
+// SubscribePendingWorkResponse is the work that became pending up to height,
+// in the same order as the PendingWork query
+type SubscribePendingWorkResponse struct {
+	Height  int64                `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Valsets []*Valset            `protobuf:"bytes,2,rep,name=valsets,proto3" json:"valsets,omitempty"`
+	Batches []*OutgoingTxBatch   `protobuf:"bytes,3,rep,name=batches,proto3" json:"batches,omitempty"`
+	Calls   []*OutgoingLogicCall `protobuf:"bytes,4,rep,name=calls,proto3" json:"calls,omitempty"`
+}
+
+func (m *SubscribePendingWorkResponse) Reset()         { *m = SubscribePendingWorkResponse{} }
+func (m *SubscribePendingWorkResponse) String() string { return proto.CompactTextString(m) }
+func (*SubscribePendingWorkResponse) ProtoMessage()    {}
+func (*SubscribePendingWorkResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cce93029df78286a, []int{1}
+}
+func (m *SubscribePendingWorkResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubscribePendingWorkResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubscribePendingWorkResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SubscribePendingWorkResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribePendingWorkResponse.Merge(m, src)
+}
+func (m *SubscribePendingWorkResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubscribePendingWorkResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribePendingWorkResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribePendingWorkResponse proto.InternalMessageInfo
+
+func (m *SubscribePendingWorkResponse) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *SubscribePendingWorkResponse) GetValsets() []*Valset {
+	if m != nil {
+		return m.Valsets
+	}
+	return nil
+}
+
+func (m *SubscribePendingWorkResponse) GetBatches() []*OutgoingTxBatch {
+	if m != nil {
+		return m.Batches
+	}
+	return nil
+}
+
+func (m *SubscribePendingWorkResponse) GetCalls() []*OutgoingLogicCall {
+	if m != nil {
+		return m.Calls
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*SubscribePendingWorkRequest)(nil), "gravity.v1.SubscribePendingWorkRequest")
+	proto.RegisterType((*SubscribePendingWorkResponse)(nil), "gravity.v1.SubscribePendingWorkResponse")
+}
+
+func init() { proto.RegisterFile("gravity/v1/subscription.proto", fileDescriptor_cce93029df78286a) }
+
+var fileDescriptor_cce93029df78286a = []byte{
+	// 342 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0xc1, 0x4e, 0xc2, 0x30,
+	0x1c, 0xc6, 0xa9, 0x28, 0xc4, 0xca, 0xa9, 0x31, 0x64, 0x01, 0x59, 0x08, 0x17, 0x39, 0xe8, 0x2a,
+	0x10, 0x1e, 0x40, 0xbc, 0x9a, 0x60, 0x86, 0xd1, 0xc4, 0xdb, 0x56, 0x9a, 0xae, 0x61, 0xac, 0xb3,
+	0xff, 0x6e, 0x81, 0xf8, 0x12, 0x3e, 0x96, 0x47, 0x8e, 0x1e, 0x0d, 0xbc, 0x88, 0x71, 0x83, 0x38,
+	0x12, 0xa2, 0xc7, 0x7e, 0xff, 0xdf, 0xf7, 0xa5, 0xff, 0xaf, 0xc5, 0x2d, 0xa1, 0xbd, 0x54, 0x9a,
+	0x25, 0x4d, 0x7b, 0x14, 0x12, 0x1f, 0x98, 0x96, 0xb1, 0x91, 0x2a, 0x72, 0x62, 0xad, 0x8c, 0x22,
+	0x78, 0x3b, 0x76, 0xd2, 0x5e, 0xa3, 0x5e, 0x40, 0xcd, 0x32, 0xe6, 0x90, 0x33, 0x7b, 0xba, 0xef,
+	0x19, 0x16, 0xe4, 0x7a, 0xe7, 0x16, 0x37, 0x27, 0x79, 0xa2, 0xcf, 0x1f, 0x78, 0x34, 0x95, 0x91,
+	0x78, 0x56, 0x7a, 0xe6, 0xf2, 0xd7, 0x84, 0x83, 0x21, 0x1d, 0x5c, 0x53, 0x9a, 0x05, 0x1c, 0x8c,
+	0xf6, 0x8c, 0xd2, 0x16, 0x6a, 0xa3, 0xee, 0xa9, 0xbb, 0xa7, 0x75, 0x56, 0x08, 0x5f, 0x1c, 0xce,
+	0x80, 0x58, 0x45, 0xc0, 0x49, 0x1d, 0x57, 0x02, 0x2e, 0x45, 0x60, 0x32, 0x7b, 0xd9, 0xdd, 0x9e,
+	0xc8, 0x15, 0xae, 0xa6, 0x5e, 0x08, 0xdc, 0x80, 0x75, 0xd4, 0x2e, 0x77, 0xcf, 0xfa, 0xc4, 0xf9,
+	0xdd, 0xc4, 0x79, 0xca, 0x46, 0xee, 0x0e, 0x21, 0x43, 0x5c, 0xcd, 0x2e, 0xce, 0xc1, 0x2a, 0x67,
+	0x74, 0xb3, 0x48, 0x8f, 0x13, 0x23, 0x94, 0x8c, 0xc4, 0xe3, 0x62, 0xf4, 0x03, 0xb9, 0x3b, 0x96,
+	0x0c, 0xf0, 0x09, 0xf3, 0xc2, 0x10, 0xac, 0xe3, 0xcc, 0xd4, 0x3a, 0x64, 0xba, 0x57, 0x42, 0xb2,
+	0x3b, 0x2f, 0x0c, 0xdd, 0x9c, 0xed, 0xbf, 0xe1, 0xda, 0xa4, 0xd0, 0x33, 0x99, 0xe1, 0xf3, 0x43,
+	0x1b, 0x92, 0xcb, 0x62, 0xda, 0x1f, 0x3d, 0x36, 0xba, 0xff, 0x83, 0x79, 0x59, 0x37, 0x68, 0x34,
+	0xfe, 0x58, 0xdb, 0x68, 0xb5, 0xb6, 0xd1, 0xd7, 0xda, 0x46, 0xef, 0x1b, 0xbb, 0xb4, 0xda, 0xd8,
+	0xa5, 0xcf, 0x8d, 0x5d, 0x7a, 0x19, 0x0a, 0x69, 0x82, 0xc4, 0x77, 0x98, 0x9a, 0x53, 0xa6, 0x60,
+	0xae, 0x80, 0x6e, 0x63, 0xaf, 0x7d, 0x2d, 0xa7, 0x82, 0xd3, 0xb9, 0x9a, 0x26, 0x21, 0xa7, 0x0b,
+	0x1a, 0x73, 0x21, 0x96, 0xf9, 0x0f, 0xf0, 0x2b, 0xd9, 0x53, 0x0f, 0xbe, 0x07, 0x00, 0x34, 0xfa,
+	0xf6, 0xf0, 0x47, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// SubscriptionClient is the client API for Subscription service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SubscriptionClient interface {
+	// SubscribePendingWork streams the valsets, batches and logic calls the
+	// orchestrator has not confirmed yet. The first response carries all pending
+	// work, the following ones the work that became pending in a new block.
+	SubscribePendingWork(ctx context.Context, in *SubscribePendingWorkRequest, opts ...grpc.CallOption) (Subscription_SubscribePendingWorkClient, error)
+}
+
+type subscriptionClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewSubscriptionClient(cc grpc1.ClientConn) SubscriptionClient {
+	return &subscriptionClient{cc}
+}
+
+func (c *subscriptionClient) SubscribePendingWork(ctx context.Context, in *SubscribePendingWorkRequest, opts ...grpc.CallOption) (Subscription_SubscribePendingWorkClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Subscription_serviceDesc.Streams[0], "/gravity.v1.Subscription/SubscribePendingWork", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &subscriptionSubscribePendingWorkClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Subscription_SubscribePendingWorkClient interface {
+	Recv() (*SubscribePendingWorkResponse, error)
+	grpc.ClientStream
+}
+
+type subscriptionSubscribePendingWorkClient struct {
+	grpc.ClientStream
+}
+
+func (x *subscriptionSubscribePendingWorkClient) Recv() (*SubscribePendingWorkResponse, error) {
+	m := new(SubscribePendingWorkResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SubscriptionServer is the server API for Subscription service.
+type SubscriptionServer interface {
+	// SubscribePendingWork streams the valsets, batches and logic calls the
+	// orchestrator has not confirmed yet. The first response carries all pending
+	// work, the following ones the work that became pending in a new block.
+	SubscribePendingWork(*SubscribePendingWorkRequest, Subscription_SubscribePendingWorkServer) error
+}
+
+// UnimplementedSubscriptionServer can be embedded to have forward compatible implementations.
+type UnimplementedSubscriptionServer struct {
+}
+
+func (*UnimplementedSubscriptionServer) SubscribePendingWork(req *SubscribePendingWorkRequest, srv Subscription_SubscribePendingWorkServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribePendingWork not implemented")
+}
+
+func RegisterSubscriptionServer(s grpc1.Server, srv SubscriptionServer) {
+	s.RegisterService(&_Subscription_serviceDesc, srv)
+}
+
+func _Subscription_SubscribePendingWork_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribePendingWorkRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SubscriptionServer).SubscribePendingWork(m, &subscriptionSubscribePendingWorkServer{stream})
+}
+
+type Subscription_SubscribePendingWorkServer interface {
+	Send(*SubscribePendingWorkResponse) error
+	grpc.ServerStream
+}
+
+type subscriptionSubscribePendingWorkServer struct {
+	grpc.ServerStream
+}
+
+func (x *subscriptionSubscribePendingWorkServer) Send(m *SubscribePendingWorkResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Subscription_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "gravity.v1.Subscription",
+	HandlerType: (*SubscriptionServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribePendingWork",
+			Handler:       _Subscription_SubscribePendingWork_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "gravity/v1/subscription.proto",
+}
+
+func (m *SubscribePendingWorkRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscribePendingWorkRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SubscribePendingWorkRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Orchestrator) > 0 {
+		i -= len(m.Orchestrator)
+		copy(dAtA[i:], m.Orchestrator)
+		i = encodeVarintSubscription(dAtA, i, uint64(len(m.Orchestrator)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SubscribePendingWorkResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscribePendingWorkResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SubscribePendingWorkResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Calls) > 0 {
+		for iNdEx := len(m.Calls) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Calls[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSubscription(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Batches) > 0 {
+		for iNdEx := len(m.Batches) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Batches[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSubscription(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Valsets) > 0 {
+		for iNdEx := len(m.Valsets) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Valsets[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintSubscription(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Height != 0 {
+		i = encodeVarintSubscription(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintSubscription(dAtA []byte, offset int, v uint64) int {
+	offset -= sovSubscription(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *SubscribePendingWorkRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Orchestrator)
+	if l > 0 {
+		n += 1 + l + sovSubscription(uint64(l))
+	}
+	return n
+}
+
+func (m *SubscribePendingWorkResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovSubscription(uint64(m.Height))
+	}
+	if len(m.Valsets) > 0 {
+		for _, e := range m.Valsets {
+			l = e.Size()
+			n += 1 + l + sovSubscription(uint64(l))
+		}
+	}
+	if len(m.Batches) > 0 {
+		for _, e := range m.Batches {
+			l = e.Size()
+			n += 1 + l + sovSubscription(uint64(l))
+		}
+	}
+	if len(m.Calls) > 0 {
+		for _, e := range m.Calls {
+			l = e.Size()
+			n += 1 + l + sovSubscription(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovSubscription(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozSubscription(x uint64) (n int) {
+	return sovSubscription(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *SubscribePendingWorkRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSubscription
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubscribePendingWorkRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubscribePendingWorkRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Orchestrator", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Orchestrator = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSubscription(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubscribePendingWorkResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSubscription
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubscribePendingWorkResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubscribePendingWorkResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Valsets", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Valsets = append(m.Valsets, &Valset{})
+			if err := m.Valsets[len(m.Valsets)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Batches", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Batches = append(m.Batches, &OutgoingTxBatch{})
+			if err := m.Batches[len(m.Batches)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Calls", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Calls = append(m.Calls, &OutgoingLogicCall{})
+			if err := m.Calls[len(m.Calls)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSubscription(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSubscription
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipSubscription(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowSubscription
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowSubscription
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthSubscription
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupSubscription
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthSubscription
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthSubscription        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowSubscription          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupSubscription = fmt.Errorf("proto: unexpected end of group")
+)
@@ -2,18 +2,12 @@ package types
 
 import (
 	"encoding/binary"
-	"fmt"
 	math "math"
-	"math/big"
 	"sort"
 	"strconv"
-	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
-	"github.com/ethereum/go-ethereum/accounts/abi"
-	gethcommon "github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 )
 
 // UInt64FromBytes create uint from binary big endian representation
@@ -68,9 +62,9 @@ func (b BridgeValidators) Sort() {
 func (b BridgeValidators) PowerDiff(c BridgeValidators) float64 {
 	powers := map[string]int64{}
 	var totalB int64
-	// loop over b and initialize the map with their powers
+	// loop over b and initialize the map with their powers, members sharing an address add up
 	for _, bv := range b {
-		powers[bv.EthereumAddress] = int64(bv.Power)
+		powers[bv.EthereumAddress] += int64(bv.Power)
 		totalB += int64(bv.Power)
 	}
 
@@ -93,6 +87,50 @@ func (b BridgeValidators) PowerDiff(c BridgeValidators) float64 {
 	return math.Abs(delta / float64(totalB))
 }
 
+// DropsMemberOf returns true if a member of the previous set with an Ethereum address is not in b
+func (b BridgeValidators) DropsMemberOf(previous BridgeValidators) bool {
+	members := make(map[string]struct{}, len(b))
+	for _, bv := range b {
+		members[bv.EthereumAddress] = struct{}{}
+	}
+	for _, bv := range previous {
+		if _, ok := members[bv.EthereumAddress]; !ok && bv.EthereumAddress != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// ValsetPowerDiffThresholdBasisPoints is the power difference between the current valset and the
+// latest requested one above which the EndBlocker requests a new valset
+const ValsetPowerDiffThresholdBasisPoints = 500
+
+// PowerDiffBasisPoints returns the difference in power between two bridge validator sets like
+// PowerDiff, in basis points of the power of b and rounded down. It uses integers only, so it can
+// be compared across nodes.
+func (b BridgeValidators) PowerDiffBasisPoints(c BridgeValidators) uint64 {
+	powers := make(map[string]int64, len(b))
+	for _, bv := range b {
+		powers[bv.EthereumAddress] += int64(bv.Power)
+	}
+	for _, bv := range c {
+		powers[bv.EthereumAddress] -= int64(bv.Power)
+	}
+
+	totalB := b.TotalPower()
+	if totalB == 0 {
+		return 0
+	}
+	var delta uint64
+	for _, v := range powers {
+		if v < 0 {
+			v = -v
+		}
+		delta += uint64(v)
+	}
+	return sdk.NewUint(delta).MulUint64(10000).QuoUint64(totalB).Uint64()
+}
+
 // TotalPower returns the total power in the bridge validator set
 func (b BridgeValidators) TotalPower() (out uint64) {
 	for _, v := range b {
@@ -147,52 +185,29 @@ func NewValset(nonce, height uint64, members BridgeValidators) *Valset {
 }
 
 // GetCheckpoint returns the checkpoint
-func (v Valset) GetCheckpoint(peggyIDstring string) []byte {
-	// TODO replace hardcoded "foo" here with a getter to retrieve the correct PeggyID from the store
-	// this will work for now because 'foo' is the test Peggy ID we are using
-	// var peggyIDString = "foo"
-
-	// error case here should not occur outside of testing since the above is a constant
-	contractAbi, abiErr := abi.JSON(strings.NewReader(ValsetCheckpointABIJSON))
-	if abiErr != nil {
-		panic("Bad ABI constant!")
-	}
-
-	// the contract argument is not a arbitrary length array but a fixed length 32 byte
-	// array, therefore we have to utf8 encode the string (the default in this case) and
-	// then copy the variable length encoded data into a fixed length array. This function
-	// will panic if peggyId is too long to fit in 32 bytes
-	peggyID, err := strToFixByteArray(peggyIDstring)
+func (v Valset) GetCheckpoint(gravityIDstring string) []byte {
+	args := v.Args()
+	// the error case should not occur outside of testing since the gravity id is validated
+	// with the params
+	checkpoint, err := ValsetCheckpoint(gravityIDstring, args.ValsetNonce, args.Validators, args.Powers)
 	if err != nil {
 		panic(err)
 	}
+	return checkpoint
+}
 
-	checkpointBytes := []uint8("checkpoint")
-	var checkpoint [32]uint8
-	copy(checkpoint[:], checkpointBytes[:])
-
-	memberAddresses := make([]gethcommon.Address, len(v.Members))
-	convertedPowers := make([]*big.Int, len(v.Members))
-	for i, m := range v.Members {
-		memberAddresses[i] = gethcommon.HexToAddress(m.EthereumAddress)
-		convertedPowers[i] = big.NewInt(int64(m.Power))
+// Args returns the valset as the bridge contract takes it
+func (v Valset) Args() ValsetArgs {
+	args := ValsetArgs{
+		Validators:  make([]string, len(v.Members)),
+		Powers:      make([]uint64, len(v.Members)),
+		ValsetNonce: v.Nonce,
 	}
-	// the word 'checkpoint' needs to be the same as the 'name' above in the checkpointAbiJson
-	// but other than that it's a constant that has no impact on the output. This is because
-	// it gets encoded as a function name which we must then discard.
-	bytes, packErr := contractAbi.Pack("checkpoint", peggyID, checkpoint, big.NewInt(int64(v.Nonce)), memberAddresses, convertedPowers)
-
-	// this should never happen outside of test since any case that could crash on encoding
-	// should be filtered above.
-	if packErr != nil {
-		panic(fmt.Sprintf("Error packing checkpoint! %s/n", packErr))
+	for i, m := range v.Members {
+		args.Validators[i] = m.EthereumAddress
+		args.Powers[i] = m.Power
 	}
-
-	// we hash the resulting encoded bytes discarding the first 4 bytes these 4 bytes are the constant
-	// method name 'checkpoint'. If you where to replace the checkpoint constant in this code you would
-	// then need to adjust how many bytes you truncate off the front to get the output of abi.encode()
-	hash := crypto.Keccak256Hash(bytes[4:])
-	return hash.Bytes()
+	return args
 }
 
 // WithoutEmptyMembers returns a new Valset without member that have 0 power or an empty Ethereum address.
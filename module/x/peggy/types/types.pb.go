@@ -1,10 +1,13 @@
 // Code generated by protoc-gen-gogo. DO NOT EDIT.
-// source: peggy/v1/types.proto
+// source: gravity/v1/types.proto
 
 package types
 
 import (
 	fmt "fmt"
+	github_com_cosmos_cosmos_sdk_types "github.com/cosmos/cosmos-sdk/types"
+	types "github.com/cosmos/cosmos-sdk/types"
+	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
 	io "io"
 	math "math"
@@ -22,6 +25,47 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+// AccountActivityType is the kind of an entry of the bridge ledger of an account
+type AccountActivityType int32
+
+const (
+	ACCOUNT_ACTIVITY_TYPE_UNSPECIFIED AccountActivityType = 0
+	// a deposit from Ethereum credited to the account
+	ACCOUNT_ACTIVITY_TYPE_DEPOSIT AccountActivityType = 1
+	// an amount the account sent to Ethereum
+	ACCOUNT_ACTIVITY_TYPE_WITHDRAWAL AccountActivityType = 2
+	// an amount or fee given back for a transfer or logic call that was not
+	// executed
+	ACCOUNT_ACTIVITY_TYPE_REFUND AccountActivityType = 3
+	// a fee the account paid for a transfer or logic call, including the cut
+	// going to the community pool
+	ACCOUNT_ACTIVITY_TYPE_FEE AccountActivityType = 4
+)
+
+var AccountActivityType_name = map[int32]string{
+	0: "ACCOUNT_ACTIVITY_TYPE_UNSPECIFIED",
+	1: "ACCOUNT_ACTIVITY_TYPE_DEPOSIT",
+	2: "ACCOUNT_ACTIVITY_TYPE_WITHDRAWAL",
+	3: "ACCOUNT_ACTIVITY_TYPE_REFUND",
+	4: "ACCOUNT_ACTIVITY_TYPE_FEE",
+}
+
+var AccountActivityType_value = map[string]int32{
+	"ACCOUNT_ACTIVITY_TYPE_UNSPECIFIED": 0,
+	"ACCOUNT_ACTIVITY_TYPE_DEPOSIT":     1,
+	"ACCOUNT_ACTIVITY_TYPE_WITHDRAWAL":  2,
+	"ACCOUNT_ACTIVITY_TYPE_REFUND":      3,
+	"ACCOUNT_ACTIVITY_TYPE_FEE":         4,
+}
+
+func (x AccountActivityType) String() string {
+	return proto.EnumName(AccountActivityType_name, int32(x))
+}
+
+func (AccountActivityType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_163831c23fcc179f, []int{0}
+}
+
 // BridgeValidator represents a validator's ETH address and its power
 type BridgeValidator struct {
 	Power           uint64 `protobuf:"varint,1,opt,name=power,proto3" json:"power,omitempty"`
@@ -32,7 +76,7 @@ func (m *BridgeValidator) Reset()         { *m = BridgeValidator{} }
 func (m *BridgeValidator) String() string { return proto.CompactTextString(m) }
 func (*BridgeValidator) ProtoMessage()    {}
 func (*BridgeValidator) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1488ca6080c6185d, []int{0}
+	return fileDescriptor_163831c23fcc179f, []int{0}
 }
 func (m *BridgeValidator) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -88,7 +132,7 @@ func (m *Valset) Reset()         { *m = Valset{} }
 func (m *Valset) String() string { return proto.CompactTextString(m) }
 func (*Valset) ProtoMessage()    {}
 func (*Valset) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1488ca6080c6185d, []int{1}
+	return fileDescriptor_163831c23fcc179f, []int{1}
 }
 func (m *Valset) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -152,7 +196,7 @@ func (m *LastObservedEthereumBlockHeight) Reset()         { *m = LastObservedEth
 func (m *LastObservedEthereumBlockHeight) String() string { return proto.CompactTextString(m) }
 func (*LastObservedEthereumBlockHeight) ProtoMessage()    {}
 func (*LastObservedEthereumBlockHeight) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1488ca6080c6185d, []int{2}
+	return fileDescriptor_163831c23fcc179f, []int{2}
 }
 func (m *LastObservedEthereumBlockHeight) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -196,17 +240,22 @@ func (m *LastObservedEthereumBlockHeight) GetEthereumBlockHeight() uint64 {
 }
 
 // This records the relationship between an ERC20 token and the denom
-// of the corresponding Cosmos originated asset
+// of the corresponding Cosmos originated asset. The decimals of the ERC20 are
+// only set when they differ from the decimals of the denom. A retired ERC20 was
+// replaced by governance, deposits of it still release the denom but transfers
+// to Ethereum use the current ERC20 of the denom.
 type ERC20ToDenom struct {
-	Erc20 string `protobuf:"bytes,1,opt,name=erc20,proto3" json:"erc20,omitempty"`
-	Denom string `protobuf:"bytes,2,opt,name=denom,proto3" json:"denom,omitempty"`
+	Erc20    string `protobuf:"bytes,1,opt,name=erc20,proto3" json:"erc20,omitempty"`
+	Denom    string `protobuf:"bytes,2,opt,name=denom,proto3" json:"denom,omitempty"`
+	Decimals uint32 `protobuf:"varint,3,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	Retired  bool   `protobuf:"varint,4,opt,name=retired,proto3" json:"retired,omitempty"`
 }
 
 func (m *ERC20ToDenom) Reset()         { *m = ERC20ToDenom{} }
 func (m *ERC20ToDenom) String() string { return proto.CompactTextString(m) }
 func (*ERC20ToDenom) ProtoMessage()    {}
 func (*ERC20ToDenom) Descriptor() ([]byte, []int) {
-	return fileDescriptor_1488ca6080c6185d, []int{3}
+	return fileDescriptor_163831c23fcc179f, []int{3}
 }
 func (m *ERC20ToDenom) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -249,281 +298,3253 @@ func (m *ERC20ToDenom) GetDenom() string {
 	return ""
 }
 
-func init() {
-	proto.RegisterType((*BridgeValidator)(nil), "peggy.v1.BridgeValidator")
-	proto.RegisterType((*Valset)(nil), "peggy.v1.Valset")
-	proto.RegisterType((*LastObservedEthereumBlockHeight)(nil), "peggy.v1.LastObservedEthereumBlockHeight")
-	proto.RegisterType((*ERC20ToDenom)(nil), "peggy.v1.ERC20ToDenom")
-}
-
-func init() { proto.RegisterFile("peggy/v1/types.proto", fileDescriptor_1488ca6080c6185d) }
-
-var fileDescriptor_1488ca6080c6185d = []byte{
-	// 354 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x51, 0x4d, 0x4f, 0xc2, 0x40,
-	0x10, 0xa5, 0xa0, 0x28, 0xab, 0x09, 0x5a, 0xd0, 0xe0, 0xa5, 0x92, 0x9e, 0xf0, 0x60, 0x0b, 0x25,
-	0x5e, 0xbc, 0x89, 0x92, 0x78, 0x30, 0x21, 0x69, 0x0c, 0x07, 0x2f, 0xa4, 0xed, 0x4e, 0xda, 0x86,
-	0x2e, 0xdb, 0xec, 0x2e, 0x55, 0x7e, 0x80, 0x77, 0x7f, 0x96, 0x47, 0x8e, 0x1e, 0x0d, 0xfc, 0x11,
-	0xd3, 0xdd, 0xd6, 0xf8, 0x71, 0x7c, 0x6f, 0x66, 0xde, 0xbc, 0x99, 0x87, 0xda, 0x29, 0x84, 0xe1,
-	0xca, 0xce, 0x06, 0xb6, 0x58, 0xa5, 0xc0, 0xad, 0x94, 0x51, 0x41, 0xf5, 0x7d, 0xc9, 0x5a, 0xd9,
-	0xc0, 0x74, 0x51, 0x73, 0xc4, 0x62, 0x1c, 0xc2, 0xd4, 0x4b, 0x62, 0xec, 0x09, 0xca, 0xf4, 0x36,
-	0xda, 0x4d, 0xe9, 0x33, 0xb0, 0x8e, 0xd6, 0xd5, 0x7a, 0x3b, 0xae, 0x02, 0xfa, 0x05, 0x3a, 0x02,
-	0x11, 0x01, 0x83, 0x25, 0x99, 0x79, 0x18, 0x33, 0xe0, 0xbc, 0x53, 0xed, 0x6a, 0xbd, 0x86, 0xdb,
-	0x2c, 0xf9, 0x1b, 0x45, 0x9b, 0x73, 0x54, 0x9f, 0x7a, 0x09, 0x07, 0x91, 0x4b, 0x2d, 0xe8, 0x22,
-	0x80, 0x52, 0x4a, 0x02, 0x7d, 0x88, 0xf6, 0x08, 0x10, 0x1f, 0x58, 0xae, 0x50, 0xeb, 0x1d, 0x38,
-	0x67, 0x56, 0xe9, 0xc7, 0xfa, 0x63, 0xc6, 0x2d, 0x3b, 0xf5, 0x53, 0x54, 0x8f, 0x20, 0x0e, 0x23,
-	0xd1, 0xa9, 0x49, 0xad, 0x02, 0x99, 0xaf, 0x1a, 0x3a, 0x7f, 0xf0, 0xb8, 0x98, 0xf8, 0x1c, 0x58,
-	0x06, 0x78, 0x5c, 0x98, 0x19, 0x25, 0x34, 0x98, 0xdf, 0xcb, 0x1e, 0xdd, 0x42, 0xad, 0x80, 0x72,
-	0x42, 0xf9, 0xcc, 0xcf, 0xd9, 0x59, 0x21, 0xa4, 0x4c, 0x1d, 0xab, 0xd2, 0xcf, 0x7e, 0x07, 0x9d,
-	0x7c, 0xdf, 0xfa, 0x6b, 0xa2, 0x2a, 0x27, 0x5a, 0xf0, 0x7f, 0x87, 0x79, 0x8d, 0x0e, 0xc7, 0xee,
-	0xad, 0xd3, 0x7f, 0xa4, 0x77, 0xb0, 0xa0, 0x24, 0x3f, 0x1d, 0x58, 0xe0, 0xf4, 0xe5, 0x96, 0x86,
-	0xab, 0x40, 0xce, 0xe2, 0xbc, 0x5c, 0xbc, 0x4e, 0x81, 0xd1, 0xe4, 0x7d, 0x63, 0x68, 0xeb, 0x8d,
-	0xa1, 0x7d, 0x6e, 0x0c, 0xed, 0x6d, 0x6b, 0x54, 0xd6, 0x5b, 0xa3, 0xf2, 0xb1, 0x35, 0x2a, 0x4f,
-	0x57, 0x61, 0x2c, 0xa2, 0xa5, 0x6f, 0x05, 0x94, 0xd8, 0xca, 0xa7, 0x1d, 0x32, 0x2f, 0x8b, 0xc5,
-	0xea, 0xd2, 0x97, 0x9f, 0xb2, 0x09, 0xc5, 0xcb, 0x04, 0xec, 0x17, 0x5b, 0x05, 0x2d, 0x53, 0xf6,
-	0xeb, 0x32, 0xe6, 0xe1, 0x57, 0x00, 0x00, 0x00, 0xff, 0xff, 0x7b, 0xf2, 0x9f, 0x5e, 0xfe, 0x01,
-	0x00, 0x00,
+func (m *ERC20ToDenom) GetDecimals() uint32 {
+	if m != nil {
+		return m.Decimals
+	}
+	return 0
 }
 
-func (m *BridgeValidator) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ERC20ToDenom) GetRetired() bool {
+	if m != nil {
+		return m.Retired
 	}
-	return dAtA[:n], nil
+	return false
 }
 
-func (m *BridgeValidator) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// ObservedDeposit records a deposit from Ethereum once it is observed, indexed
+// by the Ethereum sender so that the Cosmos accounts funded by an Ethereum
+// address can be traced. Deposits to an invalid receiver are recorded too.
+type ObservedDeposit struct {
+	EventNonce     uint64     `protobuf:"varint,1,opt,name=event_nonce,json=eventNonce,proto3" json:"event_nonce,omitempty"`
+	BlockHeight    uint64     `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	TokenContract  string     `protobuf:"bytes,3,opt,name=token_contract,json=tokenContract,proto3" json:"token_contract,omitempty"`
+	EthereumSender string     `protobuf:"bytes,4,opt,name=ethereum_sender,json=ethereumSender,proto3" json:"ethereum_sender,omitempty"`
+	CosmosReceiver string     `protobuf:"bytes,5,opt,name=cosmos_receiver,json=cosmosReceiver,proto3" json:"cosmos_receiver,omitempty"`
+	Amount         types.Coin `protobuf:"bytes,6,opt,name=amount,proto3" json:"amount"`
 }
 
-func (m *BridgeValidator) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.EthereumAddress) > 0 {
-		i -= len(m.EthereumAddress)
-		copy(dAtA[i:], m.EthereumAddress)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.EthereumAddress)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.Power != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.Power))
-		i--
-		dAtA[i] = 0x8
+func (m *ObservedDeposit) Reset()         { *m = ObservedDeposit{} }
+func (m *ObservedDeposit) String() string { return proto.CompactTextString(m) }
+func (*ObservedDeposit) ProtoMessage()    {}
+func (*ObservedDeposit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_163831c23fcc179f, []int{4}
+}
+func (m *ObservedDeposit) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ObservedDeposit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ObservedDeposit.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *ObservedDeposit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ObservedDeposit.Merge(m, src)
+}
+func (m *ObservedDeposit) XXX_Size() int {
+	return m.Size()
+}
+func (m *ObservedDeposit) XXX_DiscardUnknown() {
+	xxx_messageInfo_ObservedDeposit.DiscardUnknown(m)
 }
 
-func (m *Valset) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ObservedDeposit proto.InternalMessageInfo
+
+func (m *ObservedDeposit) GetEventNonce() uint64 {
+	if m != nil {
+		return m.EventNonce
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *Valset) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ObservedDeposit) GetBlockHeight() uint64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
 }
 
-func (m *Valset) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Height != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
-		i--
-		dAtA[i] = 0x18
+func (m *ObservedDeposit) GetTokenContract() string {
+	if m != nil {
+		return m.TokenContract
 	}
-	if len(m.Members) > 0 {
-		for iNdEx := len(m.Members) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Members[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintTypes(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x12
-		}
+	return ""
+}
+
+func (m *ObservedDeposit) GetEthereumSender() string {
+	if m != nil {
+		return m.EthereumSender
 	}
-	if m.Nonce != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.Nonce))
-		i--
-		dAtA[i] = 0x8
+	return ""
+}
+
+func (m *ObservedDeposit) GetCosmosReceiver() string {
+	if m != nil {
+		return m.CosmosReceiver
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *LastObservedEthereumBlockHeight) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ObservedDeposit) GetAmount() types.Coin {
+	if m != nil {
+		return m.Amount
 	}
-	return dAtA[:n], nil
+	return types.Coin{}
 }
 
-func (m *LastObservedEthereumBlockHeight) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// AccountActivity is an entry of the bridge ledger of an account, recorded
+// when the coins moved in or out of the account
+type AccountActivity struct {
+	// orders the entries of a block
+	Id      uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Account string `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
+	Height  uint64 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	// the block time in unix seconds
+	Time   uint64              `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+	Type   AccountActivityType `protobuf:"varint,5,opt,name=type,proto3,enum=gravity.v1.AccountActivityType" json:"type,omitempty"`
+	Amount types.Coin          `protobuf:"bytes,6,opt,name=amount,proto3" json:"amount"`
+	// the id of the transfer to Ethereum, zero for deposits and logic calls
+	TxId uint64 `protobuf:"varint,7,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	// the event nonce of a deposit
+	EventNonce uint64 `protobuf:"varint,8,opt,name=event_nonce,json=eventNonce,proto3" json:"event_nonce,omitempty"`
+	// the Ethereum sender of a deposit or the receiver of a transfer
+	EthereumAddress string `protobuf:"bytes,9,opt,name=ethereum_address,json=ethereumAddress,proto3" json:"ethereum_address,omitempty"`
 }
 
-func (m *LastObservedEthereumBlockHeight) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.EthereumBlockHeight != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.EthereumBlockHeight))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.CosmosBlockHeight != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.CosmosBlockHeight))
-		i--
-		dAtA[i] = 0x8
+func (m *AccountActivity) Reset()         { *m = AccountActivity{} }
+func (m *AccountActivity) String() string { return proto.CompactTextString(m) }
+func (*AccountActivity) ProtoMessage()    {}
+func (*AccountActivity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_163831c23fcc179f, []int{5}
+}
+func (m *AccountActivity) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AccountActivity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AccountActivity.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *AccountActivity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AccountActivity.Merge(m, src)
+}
+func (m *AccountActivity) XXX_Size() int {
+	return m.Size()
+}
+func (m *AccountActivity) XXX_DiscardUnknown() {
+	xxx_messageInfo_AccountActivity.DiscardUnknown(m)
 }
 
-func (m *ERC20ToDenom) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_AccountActivity proto.InternalMessageInfo
+
+func (m *AccountActivity) GetId() uint64 {
+	if m != nil {
+		return m.Id
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *ERC20ToDenom) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *AccountActivity) GetAccount() string {
+	if m != nil {
+		return m.Account
+	}
+	return ""
 }
 
-func (m *ERC20ToDenom) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Denom) > 0 {
-		i -= len(m.Denom)
-		copy(dAtA[i:], m.Denom)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Denom)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Erc20) > 0 {
-		i -= len(m.Erc20)
-		copy(dAtA[i:], m.Erc20)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Erc20)))
-		i--
-		dAtA[i] = 0xa
+func (m *AccountActivity) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
-	offset -= sovTypes(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *AccountActivity) GetTime() uint64 {
+	if m != nil {
+		return m.Time
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return 0
 }
-func (m *BridgeValidator) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Power != 0 {
-		n += 1 + sovTypes(uint64(m.Power))
-	}
-	l = len(m.EthereumAddress)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+
+func (m *AccountActivity) GetType() AccountActivityType {
+	if m != nil {
+		return m.Type
 	}
-	return n
+	return ACCOUNT_ACTIVITY_TYPE_UNSPECIFIED
 }
 
-func (m *Valset) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Nonce != 0 {
-		n += 1 + sovTypes(uint64(m.Nonce))
-	}
-	if len(m.Members) > 0 {
-		for _, e := range m.Members {
-			l = e.Size()
-			n += 1 + l + sovTypes(uint64(l))
-		}
-	}
-	if m.Height != 0 {
-		n += 1 + sovTypes(uint64(m.Height))
+func (m *AccountActivity) GetAmount() types.Coin {
+	if m != nil {
+		return m.Amount
 	}
-	return n
+	return types.Coin{}
 }
 
-func (m *LastObservedEthereumBlockHeight) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *AccountActivity) GetTxId() uint64 {
+	if m != nil {
+		return m.TxId
 	}
-	var l int
-	_ = l
-	if m.CosmosBlockHeight != 0 {
-		n += 1 + sovTypes(uint64(m.CosmosBlockHeight))
+	return 0
+}
+
+func (m *AccountActivity) GetEventNonce() uint64 {
+	if m != nil {
+		return m.EventNonce
 	}
-	if m.EthereumBlockHeight != 0 {
-		n += 1 + sovTypes(uint64(m.EthereumBlockHeight))
+	return 0
+}
+
+func (m *AccountActivity) GetEthereumAddress() string {
+	if m != nil {
+		return m.EthereumAddress
 	}
-	return n
+	return ""
+}
+
+// BatchExecution records where an executed batch landed on Ethereum as reported
+// by the observed withdraw claim, letting wallets link a withdrawal to its
+// Ethereum transaction
+type BatchExecution struct {
+	TokenContract       string `protobuf:"bytes,1,opt,name=token_contract,json=tokenContract,proto3" json:"token_contract,omitempty"`
+	BatchNonce          uint64 `protobuf:"varint,2,opt,name=batch_nonce,json=batchNonce,proto3" json:"batch_nonce,omitempty"`
+	EventNonce          uint64 `protobuf:"varint,3,opt,name=event_nonce,json=eventNonce,proto3" json:"event_nonce,omitempty"`
+	EthereumBlockHeight uint64 `protobuf:"varint,4,opt,name=ethereum_block_height,json=ethereumBlockHeight,proto3" json:"ethereum_block_height,omitempty"`
+	EthTxHash           string `protobuf:"bytes,5,opt,name=eth_tx_hash,json=ethTxHash,proto3" json:"eth_tx_hash,omitempty"`
+}
+
+func (m *BatchExecution) Reset()         { *m = BatchExecution{} }
+func (m *BatchExecution) String() string { return proto.CompactTextString(m) }
+func (*BatchExecution) ProtoMessage()    {}
+func (*BatchExecution) Descriptor() ([]byte, []int) {
+	return fileDescriptor_163831c23fcc179f, []int{6}
+}
+func (m *BatchExecution) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BatchExecution) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BatchExecution.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BatchExecution) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchExecution.Merge(m, src)
+}
+func (m *BatchExecution) XXX_Size() int {
+	return m.Size()
+}
+func (m *BatchExecution) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchExecution.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchExecution proto.InternalMessageInfo
+
+func (m *BatchExecution) GetTokenContract() string {
+	if m != nil {
+		return m.TokenContract
+	}
+	return ""
+}
+
+func (m *BatchExecution) GetBatchNonce() uint64 {
+	if m != nil {
+		return m.BatchNonce
+	}
+	return 0
+}
+
+func (m *BatchExecution) GetEventNonce() uint64 {
+	if m != nil {
+		return m.EventNonce
+	}
+	return 0
+}
+
+func (m *BatchExecution) GetEthereumBlockHeight() uint64 {
+	if m != nil {
+		return m.EthereumBlockHeight
+	}
+	return 0
+}
+
+func (m *BatchExecution) GetEthTxHash() string {
+	if m != nil {
+		return m.EthTxHash
+	}
+	return ""
+}
+
+// RegisteredRelayer binds the Ethereum address a relayer submits transactions to the
+// bridge contract from to the Cosmos account it is rewarded on
+type RegisteredRelayer struct {
+	EthAddress string `protobuf:"bytes,1,opt,name=eth_address,json=ethAddress,proto3" json:"eth_address,omitempty"`
+	Relayer    string `protobuf:"bytes,2,opt,name=relayer,proto3" json:"relayer,omitempty"`
+}
+
+func (m *RegisteredRelayer) Reset()         { *m = RegisteredRelayer{} }
+func (m *RegisteredRelayer) String() string { return proto.CompactTextString(m) }
+func (*RegisteredRelayer) ProtoMessage()    {}
+func (*RegisteredRelayer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_163831c23fcc179f, []int{7}
+}
+func (m *RegisteredRelayer) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RegisteredRelayer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RegisteredRelayer.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RegisteredRelayer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisteredRelayer.Merge(m, src)
+}
+func (m *RegisteredRelayer) XXX_Size() int {
+	return m.Size()
+}
+func (m *RegisteredRelayer) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisteredRelayer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisteredRelayer proto.InternalMessageInfo
+
+func (m *RegisteredRelayer) GetEthAddress() string {
+	if m != nil {
+		return m.EthAddress
+	}
+	return ""
+}
+
+func (m *RegisteredRelayer) GetRelayer() string {
+	if m != nil {
+		return m.Relayer
+	}
+	return ""
+}
+
+// BridgeStats holds the volume of a token bridged since the chain started,
+// updated whenever a deposit or an executed batch is observed so explorers
+// don't have to replay the chain. The amounts are in units of the ERC20.
+type BridgeStats struct {
+	TokenContract        string                                 `protobuf:"bytes,1,opt,name=token_contract,json=tokenContract,proto3" json:"token_contract,omitempty"`
+	TotalDeposited       github_com_cosmos_cosmos_sdk_types.Int `protobuf:"bytes,2,opt,name=total_deposited,json=totalDeposited,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Int" json:"total_deposited"`
+	DepositCount         uint64                                 `protobuf:"varint,3,opt,name=deposit_count,json=depositCount,proto3" json:"deposit_count,omitempty"`
+	TotalWithdrawn       github_com_cosmos_cosmos_sdk_types.Int `protobuf:"bytes,4,opt,name=total_withdrawn,json=totalWithdrawn,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Int" json:"total_withdrawn"`
+	WithdrawalCount      uint64                                 `protobuf:"varint,5,opt,name=withdrawal_count,json=withdrawalCount,proto3" json:"withdrawal_count,omitempty"`
+	WithdrawalBatchCount uint64                                 `protobuf:"varint,6,opt,name=withdrawal_batch_count,json=withdrawalBatchCount,proto3" json:"withdrawal_batch_count,omitempty"`
+}
+
+func (m *BridgeStats) Reset()         { *m = BridgeStats{} }
+func (m *BridgeStats) String() string { return proto.CompactTextString(m) }
+func (*BridgeStats) ProtoMessage()    {}
+func (*BridgeStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_163831c23fcc179f, []int{8}
+}
+func (m *BridgeStats) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BridgeStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BridgeStats.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BridgeStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BridgeStats.Merge(m, src)
+}
+func (m *BridgeStats) XXX_Size() int {
+	return m.Size()
+}
+func (m *BridgeStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_BridgeStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BridgeStats proto.InternalMessageInfo
+
+func (m *BridgeStats) GetTokenContract() string {
+	if m != nil {
+		return m.TokenContract
+	}
+	return ""
+}
+
+func (m *BridgeStats) GetDepositCount() uint64 {
+	if m != nil {
+		return m.DepositCount
+	}
+	return 0
+}
+
+func (m *BridgeStats) GetWithdrawalCount() uint64 {
+	if m != nil {
+		return m.WithdrawalCount
+	}
+	return 0
+}
+
+func (m *BridgeStats) GetWithdrawalBatchCount() uint64 {
+	if m != nil {
+		return m.WithdrawalBatchCount
+	}
+	return 0
+}
+
+// TokenQuirk flags a token whose deposits moved a different amount to the
+// bridge contract than the deposit event reported, so front-ends can warn
+// before a transfer. The shortfall is the sum of the amounts reported but not
+// received, in units of the ERC20.
+type TokenQuirk struct {
+	TokenContract string `protobuf:"bytes,1,opt,name=token_contract,json=tokenContract,proto3" json:"token_contract,omitempty"`
+	// set once a deposit received less than its amount, a token taking a fee on transfer
+	FeeOnTransfer bool `protobuf:"varint,2,opt,name=fee_on_transfer,json=feeOnTransfer,proto3" json:"fee_on_transfer,omitempty"`
+	// set once a deposit received more than its amount, a token rebasing balances
+	Rebasing         bool                                   `protobuf:"varint,3,opt,name=rebasing,proto3" json:"rebasing,omitempty"`
+	DiscrepancyCount uint64                                 `protobuf:"varint,4,opt,name=discrepancy_count,json=discrepancyCount,proto3" json:"discrepancy_count,omitempty"`
+	LastEventNonce   uint64                                 `protobuf:"varint,5,opt,name=last_event_nonce,json=lastEventNonce,proto3" json:"last_event_nonce,omitempty"`
+	Shortfall        github_com_cosmos_cosmos_sdk_types.Int `protobuf:"bytes,6,opt,name=shortfall,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Int" json:"shortfall"`
+}
+
+func (m *TokenQuirk) Reset()         { *m = TokenQuirk{} }
+func (m *TokenQuirk) String() string { return proto.CompactTextString(m) }
+func (*TokenQuirk) ProtoMessage()    {}
+func (*TokenQuirk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_163831c23fcc179f, []int{9}
+}
+func (m *TokenQuirk) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TokenQuirk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TokenQuirk.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TokenQuirk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TokenQuirk.Merge(m, src)
+}
+func (m *TokenQuirk) XXX_Size() int {
+	return m.Size()
+}
+func (m *TokenQuirk) XXX_DiscardUnknown() {
+	xxx_messageInfo_TokenQuirk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TokenQuirk proto.InternalMessageInfo
+
+func (m *TokenQuirk) GetTokenContract() string {
+	if m != nil {
+		return m.TokenContract
+	}
+	return ""
+}
+
+func (m *TokenQuirk) GetFeeOnTransfer() bool {
+	if m != nil {
+		return m.FeeOnTransfer
+	}
+	return false
+}
+
+func (m *TokenQuirk) GetRebasing() bool {
+	if m != nil {
+		return m.Rebasing
+	}
+	return false
+}
+
+func (m *TokenQuirk) GetDiscrepancyCount() uint64 {
+	if m != nil {
+		return m.DiscrepancyCount
+	}
+	return 0
+}
+
+func (m *TokenQuirk) GetLastEventNonce() uint64 {
+	if m != nil {
+		return m.LastEventNonce
+	}
+	return 0
+}
+
+// PausedToken is a token whose bridging was halted by a MsgPauseToken at the
+// given block height
+type PausedToken struct {
+	TokenContract string `protobuf:"bytes,1,opt,name=token_contract,json=tokenContract,proto3" json:"token_contract,omitempty"`
+	Height        uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *PausedToken) Reset()         { *m = PausedToken{} }
+func (m *PausedToken) String() string { return proto.CompactTextString(m) }
+func (*PausedToken) ProtoMessage()    {}
+func (*PausedToken) Descriptor() ([]byte, []int) {
+	return fileDescriptor_163831c23fcc179f, []int{10}
+}
+func (m *PausedToken) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PausedToken) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PausedToken.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PausedToken) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PausedToken.Merge(m, src)
+}
+func (m *PausedToken) XXX_Size() int {
+	return m.Size()
+}
+func (m *PausedToken) XXX_DiscardUnknown() {
+	xxx_messageInfo_PausedToken.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PausedToken proto.InternalMessageInfo
+
+func (m *PausedToken) GetTokenContract() string {
+	if m != nil {
+		return m.TokenContract
+	}
+	return ""
+}
+
+func (m *PausedToken) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// BatchedTx records the batch an outgoing transfer was put in and its sender,
+// it is kept after the batch executed so the transfer can still be traced
+type BatchedTx struct {
+	TxId          uint64 `protobuf:"varint,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	TokenContract string `protobuf:"bytes,2,opt,name=token_contract,json=tokenContract,proto3" json:"token_contract,omitempty"`
+	BatchNonce    uint64 `protobuf:"varint,3,opt,name=batch_nonce,json=batchNonce,proto3" json:"batch_nonce,omitempty"`
+	Sender        string `protobuf:"bytes,4,opt,name=sender,proto3" json:"sender,omitempty"`
+}
+
+func (m *BatchedTx) Reset()         { *m = BatchedTx{} }
+func (m *BatchedTx) String() string { return proto.CompactTextString(m) }
+func (*BatchedTx) ProtoMessage()    {}
+func (*BatchedTx) Descriptor() ([]byte, []int) {
+	return fileDescriptor_163831c23fcc179f, []int{11}
+}
+func (m *BatchedTx) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BatchedTx) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BatchedTx.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BatchedTx) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchedTx.Merge(m, src)
+}
+func (m *BatchedTx) XXX_Size() int {
+	return m.Size()
+}
+func (m *BatchedTx) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchedTx.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchedTx proto.InternalMessageInfo
+
+func (m *BatchedTx) GetTxId() uint64 {
+	if m != nil {
+		return m.TxId
+	}
+	return 0
+}
+
+func (m *BatchedTx) GetTokenContract() string {
+	if m != nil {
+		return m.TokenContract
+	}
+	return ""
+}
+
+func (m *BatchedTx) GetBatchNonce() uint64 {
+	if m != nil {
+		return m.BatchNonce
+	}
+	return 0
+}
+
+func (m *BatchedTx) GetSender() string {
+	if m != nil {
+		return m.Sender
+	}
+	return ""
+}
+
+// ReclaimableDeposit records an observed deposit whose cosmos receiver could
+// not be parsed as a bech32 address. The deposited amount is sent to the
+// community pool instead and this record lets governance return it later.
+type ReclaimableDeposit struct {
+	EventNonce     uint64     `protobuf:"varint,1,opt,name=event_nonce,json=eventNonce,proto3" json:"event_nonce,omitempty"`
+	BlockHeight    uint64     `protobuf:"varint,2,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	TokenContract  string     `protobuf:"bytes,3,opt,name=token_contract,json=tokenContract,proto3" json:"token_contract,omitempty"`
+	EthereumSender string     `protobuf:"bytes,4,opt,name=ethereum_sender,json=ethereumSender,proto3" json:"ethereum_sender,omitempty"`
+	CosmosReceiver string     `protobuf:"bytes,5,opt,name=cosmos_receiver,json=cosmosReceiver,proto3" json:"cosmos_receiver,omitempty"`
+	Amount         types.Coin `protobuf:"bytes,6,opt,name=amount,proto3" json:"amount"`
+}
+
+func (m *ReclaimableDeposit) Reset()         { *m = ReclaimableDeposit{} }
+func (m *ReclaimableDeposit) String() string { return proto.CompactTextString(m) }
+func (*ReclaimableDeposit) ProtoMessage()    {}
+func (*ReclaimableDeposit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_163831c23fcc179f, []int{12}
+}
+func (m *ReclaimableDeposit) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReclaimableDeposit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReclaimableDeposit.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReclaimableDeposit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReclaimableDeposit.Merge(m, src)
+}
+func (m *ReclaimableDeposit) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReclaimableDeposit) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReclaimableDeposit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReclaimableDeposit proto.InternalMessageInfo
+
+func (m *ReclaimableDeposit) GetEventNonce() uint64 {
+	if m != nil {
+		return m.EventNonce
+	}
+	return 0
+}
+
+func (m *ReclaimableDeposit) GetBlockHeight() uint64 {
+	if m != nil {
+		return m.BlockHeight
+	}
+	return 0
+}
+
+func (m *ReclaimableDeposit) GetTokenContract() string {
+	if m != nil {
+		return m.TokenContract
+	}
+	return ""
+}
+
+func (m *ReclaimableDeposit) GetEthereumSender() string {
+	if m != nil {
+		return m.EthereumSender
+	}
+	return ""
+}
+
+func (m *ReclaimableDeposit) GetCosmosReceiver() string {
+	if m != nil {
+		return m.CosmosReceiver
+	}
+	return ""
+}
+
+func (m *ReclaimableDeposit) GetAmount() types.Coin {
+	if m != nil {
+		return m.Amount
+	}
+	return types.Coin{}
+}
+
+func init() {
+	proto.RegisterEnum("gravity.v1.AccountActivityType", AccountActivityType_name, AccountActivityType_value)
+	proto.RegisterType((*BridgeValidator)(nil), "gravity.v1.BridgeValidator")
+	proto.RegisterType((*Valset)(nil), "gravity.v1.Valset")
+	proto.RegisterType((*LastObservedEthereumBlockHeight)(nil), "gravity.v1.LastObservedEthereumBlockHeight")
+	proto.RegisterType((*ERC20ToDenom)(nil), "gravity.v1.ERC20ToDenom")
+	proto.RegisterType((*ObservedDeposit)(nil), "gravity.v1.ObservedDeposit")
+	proto.RegisterType((*AccountActivity)(nil), "gravity.v1.AccountActivity")
+	proto.RegisterType((*BatchExecution)(nil), "gravity.v1.BatchExecution")
+	proto.RegisterType((*RegisteredRelayer)(nil), "gravity.v1.RegisteredRelayer")
+	proto.RegisterType((*BridgeStats)(nil), "gravity.v1.BridgeStats")
+	proto.RegisterType((*TokenQuirk)(nil), "gravity.v1.TokenQuirk")
+	proto.RegisterType((*PausedToken)(nil), "gravity.v1.PausedToken")
+	proto.RegisterType((*BatchedTx)(nil), "gravity.v1.BatchedTx")
+	proto.RegisterType((*ReclaimableDeposit)(nil), "gravity.v1.ReclaimableDeposit")
+}
+
+func init() { proto.RegisterFile("gravity/v1/types.proto", fileDescriptor_163831c23fcc179f) }
+
+var fileDescriptor_163831c23fcc179f = []byte{
+	// 1153 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x56, 0xc1, 0x6e, 0xdb, 0x46,
+	0x13, 0x36, 0x69, 0x59, 0xb1, 0x46, 0xb1, 0xa4, 0xac, 0xf3, 0x1b, 0x8a, 0xff, 0x46, 0x76, 0xd4,
+	0x26, 0x75, 0x5b, 0x44, 0x8a, 0x9d, 0x06, 0x3d, 0xcb, 0x92, 0x82, 0x08, 0x30, 0x6c, 0x97, 0x66,
+	0x62, 0xa4, 0x17, 0x62, 0x49, 0x8e, 0x45, 0xc2, 0x24, 0x57, 0x58, 0xae, 0x14, 0xf9, 0xd6, 0x4b,
+	0xd1, 0xa2, 0xa7, 0x3e, 0x40, 0x6f, 0x7d, 0x87, 0x3e, 0x43, 0x0e, 0x2d, 0x90, 0x63, 0xd1, 0x43,
+	0x50, 0x24, 0xd7, 0x3e, 0x44, 0xc1, 0xdd, 0x95, 0xa5, 0xd8, 0x0a, 0x60, 0xa4, 0xc7, 0x9e, 0xc4,
+	0xf9, 0x38, 0x9c, 0x9d, 0xf9, 0xe6, 0x9b, 0x59, 0xc1, 0x5a, 0x9f, 0xd3, 0x51, 0x28, 0xce, 0x9a,
+	0xa3, 0xed, 0xa6, 0x38, 0x1b, 0x60, 0xda, 0x18, 0x70, 0x26, 0x18, 0x01, 0x8d, 0x37, 0x46, 0xdb,
+	0xeb, 0x35, 0x8f, 0xa5, 0x31, 0x4b, 0x9b, 0x2e, 0x4d, 0xb1, 0x39, 0xda, 0x76, 0x51, 0xd0, 0xed,
+	0xa6, 0xc7, 0xc2, 0x44, 0xf9, 0xae, 0xdf, 0xec, 0xb3, 0x3e, 0x93, 0x8f, 0xcd, 0xec, 0x49, 0xa1,
+	0x75, 0x0b, 0xca, 0xbb, 0x3c, 0xf4, 0xfb, 0xf8, 0x8c, 0x46, 0xa1, 0x4f, 0x05, 0xe3, 0xe4, 0x26,
+	0x2c, 0x0d, 0xd8, 0x0b, 0xe4, 0x55, 0x63, 0xd3, 0xd8, 0xca, 0x59, 0xca, 0x20, 0x9f, 0x41, 0x05,
+	0x45, 0x80, 0x1c, 0x87, 0xb1, 0x43, 0x7d, 0x9f, 0x63, 0x9a, 0x56, 0xcd, 0x4d, 0x63, 0xab, 0x60,
+	0x95, 0x27, 0x78, 0x4b, 0xc1, 0xf5, 0x18, 0xf2, 0xcf, 0x68, 0x94, 0xa2, 0xc8, 0x42, 0x25, 0x2c,
+	0xf1, 0x70, 0x12, 0x4a, 0x1a, 0xe4, 0x11, 0x5c, 0x8b, 0x31, 0x76, 0x91, 0x67, 0x11, 0x16, 0xb7,
+	0x8a, 0x3b, 0xff, 0x6f, 0x4c, 0xeb, 0x68, 0x5c, 0x48, 0xc7, 0x9a, 0xf8, 0x92, 0x35, 0xc8, 0x07,
+	0x18, 0xf6, 0x03, 0x51, 0x5d, 0x94, 0xd1, 0xb4, 0x55, 0xff, 0xce, 0x80, 0x8d, 0x3d, 0x9a, 0x8a,
+	0x03, 0x37, 0x45, 0x3e, 0x42, 0xbf, 0xab, 0xd3, 0xd9, 0x8d, 0x98, 0x77, 0xfa, 0x44, 0xfa, 0x90,
+	0x06, 0xac, 0x2a, 0x7a, 0x1c, 0x37, 0x43, 0x1d, 0x1d, 0x48, 0xa5, 0x75, 0x43, 0xbd, 0x9a, 0xf5,
+	0xdf, 0x81, 0xff, 0x9d, 0x57, 0xfb, 0xce, 0x17, 0xa6, 0xfc, 0x62, 0x15, 0x2f, 0x9f, 0x51, 0x1f,
+	0xc0, 0xf5, 0xae, 0xd5, 0xde, 0x79, 0x60, 0xb3, 0x0e, 0x26, 0x2c, 0xce, 0x8a, 0x47, 0xee, 0xed,
+	0x3c, 0x90, 0xa7, 0x14, 0x2c, 0x65, 0x64, 0xa8, 0x9f, 0xbd, 0xd6, 0xe4, 0x29, 0x83, 0xac, 0xc3,
+	0xb2, 0x8f, 0x5e, 0x18, 0xd3, 0x28, 0x95, 0xd5, 0xad, 0x58, 0xe7, 0x36, 0xa9, 0xc2, 0x35, 0x8e,
+	0x22, 0xe4, 0xe8, 0x57, 0x73, 0x9b, 0xc6, 0xd6, 0xb2, 0x35, 0x31, 0xeb, 0xdf, 0x9b, 0x50, 0x9e,
+	0x54, 0xdd, 0xc1, 0x01, 0x4b, 0x43, 0x41, 0x36, 0xa0, 0x88, 0x23, 0x4c, 0x84, 0x33, 0x4b, 0x3c,
+	0x48, 0x68, 0x5f, 0xb2, 0x7f, 0x07, 0xae, 0xcf, 0xa9, 0xa8, 0xe8, 0xce, 0x54, 0x7f, 0x17, 0x4a,
+	0x82, 0x9d, 0x62, 0xe2, 0x78, 0x2c, 0x11, 0x9c, 0x7a, 0x8a, 0xf1, 0x82, 0xb5, 0x22, 0xd1, 0xb6,
+	0x06, 0xc9, 0xa7, 0x70, 0xde, 0x7a, 0x27, 0xc5, 0xc4, 0x47, 0x2e, 0x13, 0x2c, 0x58, 0xa5, 0x09,
+	0x7c, 0x24, 0xd1, 0xcc, 0x51, 0xb3, 0xcf, 0xd1, 0xc3, 0x70, 0x84, 0xbc, 0xba, 0xa4, 0x1c, 0x15,
+	0x6c, 0x69, 0x94, 0x7c, 0x05, 0x79, 0x1a, 0xb3, 0x61, 0x22, 0xaa, 0xf9, 0x4d, 0x63, 0xab, 0xb8,
+	0x73, 0xab, 0xa1, 0x1c, 0x1a, 0x99, 0xa8, 0x1b, 0x5a, 0xd4, 0x8d, 0x36, 0x0b, 0x93, 0xdd, 0xdc,
+	0xcb, 0xd7, 0x1b, 0x0b, 0x96, 0x76, 0xaf, 0xff, 0x6a, 0x42, 0xb9, 0xe5, 0x79, 0xd9, 0x73, 0xcb,
+	0x13, 0x61, 0xa6, 0x25, 0x52, 0x02, 0x33, 0xf4, 0x35, 0x01, 0x66, 0xe8, 0x67, 0x3c, 0x52, 0xe5,
+	0xa2, 0xb9, 0x9f, 0x98, 0xef, 0x53, 0x16, 0x21, 0x90, 0x13, 0x61, 0x8c, 0xb2, 0xaa, 0x9c, 0x25,
+	0x9f, 0xc9, 0x43, 0xc8, 0x65, 0x13, 0x28, 0x0b, 0x28, 0xed, 0x6c, 0xcc, 0x2a, 0xf7, 0x42, 0x02,
+	0xf6, 0xd9, 0x00, 0x2d, 0xe9, 0xfc, 0xc1, 0x75, 0x91, 0x55, 0x58, 0x12, 0x63, 0x27, 0xf4, 0xab,
+	0xd7, 0x74, 0x0a, 0xe3, 0x9e, 0x7f, 0xb1, 0xc5, 0xcb, 0x97, 0x5a, 0x3c, 0x6f, 0x56, 0x0b, 0xf3,
+	0x67, 0xf5, 0x77, 0x03, 0x4a, 0xbb, 0x54, 0x78, 0x41, 0x77, 0x8c, 0xde, 0x50, 0x84, 0x2c, 0x99,
+	0xd3, 0x7d, 0x63, 0x5e, 0xf7, 0x37, 0xa0, 0xe8, 0x66, 0x1f, 0xea, 0x2c, 0x94, 0x8c, 0x40, 0x42,
+	0x2a, 0x8b, 0x0b, 0x69, 0x2e, 0x5e, 0x4a, 0xf3, 0xbd, 0x43, 0x96, 0x7b, 0xef, 0x90, 0x91, 0x1a,
+	0x14, 0x51, 0x04, 0x8e, 0x18, 0x3b, 0x01, 0x4d, 0x03, 0x2d, 0xa3, 0x02, 0x8a, 0xc0, 0x1e, 0x3f,
+	0xa1, 0x69, 0x50, 0xdf, 0x87, 0x1b, 0x16, 0xf6, 0xc3, 0x54, 0x20, 0x47, 0xdf, 0xc2, 0x88, 0x9e,
+	0x21, 0x97, 0x99, 0x88, 0xe0, 0x9c, 0x0a, 0x55, 0x0e, 0xa0, 0x08, 0x34, 0x0b, 0x6a, 0xc4, 0xa4,
+	0xef, 0x44, 0x1a, 0xda, 0xac, 0xff, 0x6d, 0x42, 0x51, 0x6d, 0xa4, 0x23, 0x41, 0x45, 0x7a, 0x55,
+	0x72, 0x8e, 0xa1, 0x2c, 0x98, 0xa0, 0x91, 0xe3, 0xab, 0xb1, 0x44, 0x5f, 0x05, 0xde, 0x6d, 0x64,
+	0xed, 0xfd, 0xf3, 0xf5, 0xc6, 0xbd, 0x7e, 0x28, 0x82, 0xa1, 0xdb, 0xf0, 0x58, 0xdc, 0xd4, 0x8b,
+	0x5b, 0xfd, 0xdc, 0x4f, 0xfd, 0x53, 0xbd, 0xe3, 0x7b, 0x89, 0xb0, 0x4a, 0x32, 0x4c, 0x67, 0x12,
+	0x85, 0x7c, 0x0c, 0x2b, 0x3a, 0xa4, 0xa3, 0xa4, 0xac, 0x68, 0xbd, 0xae, 0xc1, 0x76, 0x86, 0x4d,
+	0x4f, 0x7f, 0x11, 0x8a, 0xc0, 0xe7, 0xf4, 0x45, 0x52, 0xcd, 0xfd, 0x8b, 0xd3, 0x8f, 0x27, 0x51,
+	0x32, 0x61, 0x4d, 0x42, 0xd2, 0x48, 0x27, 0xb0, 0x24, 0x13, 0x28, 0x4f, 0x71, 0x95, 0xc3, 0x97,
+	0xb0, 0x36, 0xe3, 0xaa, 0x94, 0xe2, 0x9d, 0x8f, 0x40, 0xce, 0xba, 0x39, 0x7d, 0x2b, 0xf5, 0x27,
+	0xbf, 0xaa, 0xff, 0x6c, 0x02, 0xd8, 0x19, 0x93, 0x5f, 0x0f, 0x43, 0x7e, 0x7a, 0x55, 0xb6, 0xef,
+	0x41, 0xf9, 0x04, 0xd1, 0x61, 0x89, 0x23, 0x38, 0x4d, 0xd2, 0x13, 0xdd, 0xc6, 0x65, 0x6b, 0xe5,
+	0x04, 0xf1, 0x20, 0xb1, 0x35, 0x98, 0x6d, 0x59, 0x8e, 0x2e, 0x4d, 0xc3, 0xa4, 0x2f, 0x79, 0x5b,
+	0xb6, 0xce, 0x6d, 0xf2, 0x05, 0xdc, 0xf0, 0xc3, 0xd4, 0xe3, 0x38, 0xa0, 0x89, 0x77, 0xa6, 0x53,
+	0x55, 0x42, 0xac, 0xcc, 0xbc, 0x50, 0xc5, 0x6d, 0x41, 0x25, 0xa2, 0xa9, 0x70, 0x66, 0xf5, 0xad,
+	0x78, 0x28, 0x65, 0x78, 0x77, 0xaa, 0xf1, 0x3d, 0x28, 0xa4, 0x01, 0xe3, 0xe2, 0x84, 0x46, 0x51,
+	0x35, 0xff, 0x41, 0x4d, 0x98, 0x06, 0xa8, 0xef, 0x41, 0xf1, 0x90, 0x0e, 0x53, 0xf4, 0x25, 0x47,
+	0x57, 0xa5, 0x67, 0xba, 0xde, 0xcc, 0x77, 0x2e, 0xce, 0x6f, 0x0d, 0x28, 0x48, 0xee, 0xd1, 0xb7,
+	0xc7, 0xd3, 0x55, 0x63, 0xcc, 0xac, 0x9a, 0xcb, 0x27, 0x98, 0x57, 0xd8, 0x05, 0x8b, 0x97, 0x76,
+	0xc1, 0x1a, 0xe4, 0xdf, 0xb9, 0x21, 0xb4, 0x55, 0xff, 0xd1, 0x04, 0x62, 0xa1, 0x17, 0xd1, 0x30,
+	0xa6, 0x6e, 0x84, 0xff, 0xe9, 0x4b, 0xec, 0xf3, 0xdf, 0x0c, 0x58, 0x9d, 0x73, 0x87, 0x90, 0xbb,
+	0x70, 0xa7, 0xd5, 0x6e, 0x1f, 0x3c, 0xdd, 0xb7, 0x9d, 0x56, 0xdb, 0xee, 0x3d, 0xeb, 0xd9, 0xcf,
+	0x1d, 0xfb, 0xf9, 0x61, 0xd7, 0x79, 0xba, 0x7f, 0x74, 0xd8, 0x6d, 0xf7, 0x1e, 0xf7, 0xba, 0x9d,
+	0xca, 0x02, 0xb9, 0x03, 0xb7, 0xe7, 0xbb, 0x75, 0xba, 0x87, 0x07, 0x47, 0x3d, 0xbb, 0x62, 0x90,
+	0x4f, 0x60, 0x73, 0xbe, 0xcb, 0x71, 0xcf, 0x7e, 0xd2, 0xb1, 0x5a, 0xc7, 0xad, 0xbd, 0x8a, 0x49,
+	0x36, 0xe1, 0xa3, 0xf9, 0x5e, 0x56, 0xf7, 0xf1, 0xd3, 0xfd, 0x4e, 0x65, 0x91, 0xdc, 0x86, 0x5b,
+	0xf3, 0x3d, 0x1e, 0x77, 0xbb, 0x95, 0xdc, 0x7a, 0xee, 0x87, 0x5f, 0x6a, 0x0b, 0xbb, 0x07, 0x2f,
+	0xdf, 0xd4, 0x8c, 0x57, 0x6f, 0x6a, 0xc6, 0x5f, 0x6f, 0x6a, 0xc6, 0x4f, 0x6f, 0x6b, 0x0b, 0xaf,
+	0xde, 0xd6, 0x16, 0xfe, 0x78, 0x5b, 0x5b, 0xf8, 0xe6, 0xd1, 0x65, 0xe5, 0xeb, 0x6b, 0xf4, 0xbe,
+	0x2b, 0x77, 0x6d, 0x33, 0x66, 0xfe, 0x30, 0xc2, 0xe6, 0xb8, 0x39, 0xc0, 0x7e, 0xff, 0x4c, 0x0d,
+	0x83, 0x9b, 0x97, 0x7f, 0x59, 0x1f, 0xfe, 0x33, 0x00, 0x15, 0x8d, 0x2d, 0x4d, 0x0e, 0x0b, 0x00,
+	0x00,
+}
+
+func (m *BridgeValidator) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BridgeValidator) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BridgeValidator) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.EthereumAddress) > 0 {
+		i -= len(m.EthereumAddress)
+		copy(dAtA[i:], m.EthereumAddress)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.EthereumAddress)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Power != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Power))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Valset) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Valset) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Valset) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Members) > 0 {
+		for iNdEx := len(m.Members) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Members[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Nonce != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Nonce))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LastObservedEthereumBlockHeight) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LastObservedEthereumBlockHeight) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LastObservedEthereumBlockHeight) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.EthereumBlockHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.EthereumBlockHeight))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.CosmosBlockHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.CosmosBlockHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ERC20ToDenom) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ERC20ToDenom) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ERC20ToDenom) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Retired {
+		i--
+		if m.Retired {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Decimals != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Decimals))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Denom) > 0 {
+		i -= len(m.Denom)
+		copy(dAtA[i:], m.Denom)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Denom)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Erc20) > 0 {
+		i -= len(m.Erc20)
+		copy(dAtA[i:], m.Erc20)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Erc20)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ObservedDeposit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ObservedDeposit) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ObservedDeposit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Amount.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x32
+	if len(m.CosmosReceiver) > 0 {
+		i -= len(m.CosmosReceiver)
+		copy(dAtA[i:], m.CosmosReceiver)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.CosmosReceiver)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.EthereumSender) > 0 {
+		i -= len(m.EthereumSender)
+		copy(dAtA[i:], m.EthereumSender)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.EthereumSender)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.TokenContract) > 0 {
+		i -= len(m.TokenContract)
+		copy(dAtA[i:], m.TokenContract)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.TokenContract)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.BlockHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.BlockHeight))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.EventNonce != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.EventNonce))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AccountActivity) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AccountActivity) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AccountActivity) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.EthereumAddress) > 0 {
+		i -= len(m.EthereumAddress)
+		copy(dAtA[i:], m.EthereumAddress)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.EthereumAddress)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.EventNonce != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.EventNonce))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.TxId != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.TxId))
+		i--
+		dAtA[i] = 0x38
+	}
+	{
+		size, err := m.Amount.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x32
+	if m.Type != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Time != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Time))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Account) > 0 {
+		i -= len(m.Account)
+		copy(dAtA[i:], m.Account)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Account)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Id != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Id))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BatchExecution) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BatchExecution) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BatchExecution) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.EthTxHash) > 0 {
+		i -= len(m.EthTxHash)
+		copy(dAtA[i:], m.EthTxHash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.EthTxHash)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.EthereumBlockHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.EthereumBlockHeight))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.EventNonce != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.EventNonce))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.BatchNonce != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.BatchNonce))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.TokenContract) > 0 {
+		i -= len(m.TokenContract)
+		copy(dAtA[i:], m.TokenContract)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.TokenContract)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RegisteredRelayer) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RegisteredRelayer) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RegisteredRelayer) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Relayer) > 0 {
+		i -= len(m.Relayer)
+		copy(dAtA[i:], m.Relayer)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Relayer)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.EthAddress) > 0 {
+		i -= len(m.EthAddress)
+		copy(dAtA[i:], m.EthAddress)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.EthAddress)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BridgeStats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BridgeStats) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BridgeStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.WithdrawalBatchCount != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.WithdrawalBatchCount))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.WithdrawalCount != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.WithdrawalCount))
+		i--
+		dAtA[i] = 0x28
+	}
+	{
+		size := m.TotalWithdrawn.Size()
+		i -= size
+		if _, err := m.TotalWithdrawn.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x22
+	if m.DepositCount != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.DepositCount))
+		i--
+		dAtA[i] = 0x18
+	}
+	{
+		size := m.TotalDeposited.Size()
+		i -= size
+		if _, err := m.TotalDeposited.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if len(m.TokenContract) > 0 {
+		i -= len(m.TokenContract)
+		copy(dAtA[i:], m.TokenContract)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.TokenContract)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TokenQuirk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TokenQuirk) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TokenQuirk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size := m.Shortfall.Size()
+		i -= size
+		if _, err := m.Shortfall.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x32
+	if m.LastEventNonce != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.LastEventNonce))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.DiscrepancyCount != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.DiscrepancyCount))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Rebasing {
+		i--
+		if m.Rebasing {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.FeeOnTransfer {
+		i--
+		if m.FeeOnTransfer {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.TokenContract) > 0 {
+		i -= len(m.TokenContract)
+		copy(dAtA[i:], m.TokenContract)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.TokenContract)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PausedToken) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PausedToken) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PausedToken) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.TokenContract) > 0 {
+		i -= len(m.TokenContract)
+		copy(dAtA[i:], m.TokenContract)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.TokenContract)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BatchedTx) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BatchedTx) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BatchedTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Sender) > 0 {
+		i -= len(m.Sender)
+		copy(dAtA[i:], m.Sender)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Sender)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.BatchNonce != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.BatchNonce))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.TokenContract) > 0 {
+		i -= len(m.TokenContract)
+		copy(dAtA[i:], m.TokenContract)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.TokenContract)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.TxId != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.TxId))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReclaimableDeposit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReclaimableDeposit) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReclaimableDeposit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Amount.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x32
+	if len(m.CosmosReceiver) > 0 {
+		i -= len(m.CosmosReceiver)
+		copy(dAtA[i:], m.CosmosReceiver)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.CosmosReceiver)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.EthereumSender) > 0 {
+		i -= len(m.EthereumSender)
+		copy(dAtA[i:], m.EthereumSender)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.EthereumSender)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.TokenContract) > 0 {
+		i -= len(m.TokenContract)
+		copy(dAtA[i:], m.TokenContract)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.TokenContract)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.BlockHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.BlockHeight))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.EventNonce != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.EventNonce))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
+	offset -= sovTypes(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *BridgeValidator) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Power != 0 {
+		n += 1 + sovTypes(uint64(m.Power))
+	}
+	l = len(m.EthereumAddress)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *Valset) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Nonce != 0 {
+		n += 1 + sovTypes(uint64(m.Nonce))
+	}
+	if len(m.Members) > 0 {
+		for _, e := range m.Members {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	return n
+}
+
+func (m *LastObservedEthereumBlockHeight) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CosmosBlockHeight != 0 {
+		n += 1 + sovTypes(uint64(m.CosmosBlockHeight))
+	}
+	if m.EthereumBlockHeight != 0 {
+		n += 1 + sovTypes(uint64(m.EthereumBlockHeight))
+	}
+	return n
 }
 
 func (m *ERC20ToDenom) Size() (n int) {
 	if m == nil {
 		return 0
 	}
-	var l int
-	_ = l
-	l = len(m.Erc20)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	var l int
+	_ = l
+	l = len(m.Erc20)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Denom)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Decimals != 0 {
+		n += 1 + sovTypes(uint64(m.Decimals))
+	}
+	if m.Retired {
+		n += 2
+	}
+	return n
+}
+
+func (m *ObservedDeposit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.EventNonce != 0 {
+		n += 1 + sovTypes(uint64(m.EventNonce))
+	}
+	if m.BlockHeight != 0 {
+		n += 1 + sovTypes(uint64(m.BlockHeight))
+	}
+	l = len(m.TokenContract)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.EthereumSender)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.CosmosReceiver)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = m.Amount.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	return n
+}
+
+func (m *AccountActivity) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != 0 {
+		n += 1 + sovTypes(uint64(m.Id))
+	}
+	l = len(m.Account)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Time != 0 {
+		n += 1 + sovTypes(uint64(m.Time))
+	}
+	if m.Type != 0 {
+		n += 1 + sovTypes(uint64(m.Type))
+	}
+	l = m.Amount.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if m.TxId != 0 {
+		n += 1 + sovTypes(uint64(m.TxId))
+	}
+	if m.EventNonce != 0 {
+		n += 1 + sovTypes(uint64(m.EventNonce))
+	}
+	l = len(m.EthereumAddress)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *BatchExecution) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TokenContract)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.BatchNonce != 0 {
+		n += 1 + sovTypes(uint64(m.BatchNonce))
+	}
+	if m.EventNonce != 0 {
+		n += 1 + sovTypes(uint64(m.EventNonce))
+	}
+	if m.EthereumBlockHeight != 0 {
+		n += 1 + sovTypes(uint64(m.EthereumBlockHeight))
+	}
+	l = len(m.EthTxHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *RegisteredRelayer) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.EthAddress)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Relayer)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *BridgeStats) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TokenContract)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = m.TotalDeposited.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if m.DepositCount != 0 {
+		n += 1 + sovTypes(uint64(m.DepositCount))
+	}
+	l = m.TotalWithdrawn.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if m.WithdrawalCount != 0 {
+		n += 1 + sovTypes(uint64(m.WithdrawalCount))
+	}
+	if m.WithdrawalBatchCount != 0 {
+		n += 1 + sovTypes(uint64(m.WithdrawalBatchCount))
+	}
+	return n
+}
+
+func (m *TokenQuirk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TokenContract)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.FeeOnTransfer {
+		n += 2
+	}
+	if m.Rebasing {
+		n += 2
+	}
+	if m.DiscrepancyCount != 0 {
+		n += 1 + sovTypes(uint64(m.DiscrepancyCount))
+	}
+	if m.LastEventNonce != 0 {
+		n += 1 + sovTypes(uint64(m.LastEventNonce))
+	}
+	l = m.Shortfall.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	return n
+}
+
+func (m *PausedToken) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TokenContract)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	return n
+}
+
+func (m *BatchedTx) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TxId != 0 {
+		n += 1 + sovTypes(uint64(m.TxId))
+	}
+	l = len(m.TokenContract)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.BatchNonce != 0 {
+		n += 1 + sovTypes(uint64(m.BatchNonce))
+	}
+	l = len(m.Sender)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *ReclaimableDeposit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.EventNonce != 0 {
+		n += 1 + sovTypes(uint64(m.EventNonce))
+	}
+	if m.BlockHeight != 0 {
+		n += 1 + sovTypes(uint64(m.BlockHeight))
+	}
+	l = len(m.TokenContract)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.EthereumSender)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.CosmosReceiver)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = m.Amount.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	return n
+}
+
+func sovTypes(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozTypes(x uint64) (n int) {
+	return sovTypes(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *BridgeValidator) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BridgeValidator: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BridgeValidator: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Power", wireType)
+			}
+			m.Power = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Power |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EthereumAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EthereumAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Valset) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Valset: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Valset: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nonce", wireType)
+			}
+			m.Nonce = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Nonce |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Members = append(m.Members, &BridgeValidator{})
+			if err := m.Members[len(m.Members)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LastObservedEthereumBlockHeight) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LastObservedEthereumBlockHeight: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LastObservedEthereumBlockHeight: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CosmosBlockHeight", wireType)
+			}
+			m.CosmosBlockHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CosmosBlockHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EthereumBlockHeight", wireType)
+			}
+			m.EthereumBlockHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EthereumBlockHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ERC20ToDenom) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ERC20ToDenom: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ERC20ToDenom: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Erc20", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Erc20 = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Denom", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Denom = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Decimals", wireType)
+			}
+			m.Decimals = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Decimals |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Retired", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Retired = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ObservedDeposit) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ObservedDeposit: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ObservedDeposit: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventNonce", wireType)
+			}
+			m.EventNonce = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EventNonce |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHeight", wireType)
+			}
+			m.BlockHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenContract", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenContract = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EthereumSender", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EthereumSender = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CosmosReceiver", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CosmosReceiver = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Amount", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Amount.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AccountActivity) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AccountActivity: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AccountActivity: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			m.Id = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Id |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Account", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Account = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			m.Time = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Time |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= AccountActivityType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Amount", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Amount.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxId", wireType)
+			}
+			m.TxId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TxId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventNonce", wireType)
+			}
+			m.EventNonce = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EventNonce |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EthereumAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EthereumAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BatchExecution) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BatchExecution: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BatchExecution: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenContract", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenContract = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BatchNonce", wireType)
+			}
+			m.BatchNonce = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BatchNonce |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventNonce", wireType)
+			}
+			m.EventNonce = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EventNonce |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EthereumBlockHeight", wireType)
+			}
+			m.EthereumBlockHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EthereumBlockHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EthTxHash", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EthTxHash = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	l = len(m.Denom)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	return nil
+}
+func (m *RegisteredRelayer) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RegisteredRelayer: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RegisteredRelayer: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EthAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EthAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Relayer", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Relayer = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BridgeStats) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BridgeStats: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BridgeStats: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenContract", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenContract = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalDeposited", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.TotalDeposited.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DepositCount", wireType)
+			}
+			m.DepositCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DepositCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalWithdrawn", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.TotalWithdrawn.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WithdrawalCount", wireType)
+			}
+			m.WithdrawalCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WithdrawalCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WithdrawalBatchCount", wireType)
+			}
+			m.WithdrawalBatchCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WithdrawalBatchCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func sovTypes(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozTypes(x uint64) (n int) {
-	return sovTypes(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *BridgeValidator) Unmarshal(dAtA []byte) error {
+func (m *TokenQuirk) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -533,30 +3554,121 @@ func (m *BridgeValidator) Unmarshal(dAtA []byte) error {
 			if shift >= 64 {
 				return ErrIntOverflowTypes
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TokenQuirk: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TokenQuirk: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenContract", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenContract = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FeeOnTransfer", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.FeeOnTransfer = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rebasing", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Rebasing = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DiscrepancyCount", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.DiscrepancyCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DiscrepancyCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: BridgeValidator: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BridgeValidator: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Power", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LastEventNonce", wireType)
 			}
-			m.Power = 0
+			m.LastEventNonce = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -566,14 +3678,14 @@ func (m *BridgeValidator) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Power |= uint64(b&0x7F) << shift
+				m.LastEventNonce |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EthereumAddress", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Shortfall", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -601,7 +3713,9 @@ func (m *BridgeValidator) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.EthereumAddress = string(dAtA[iNdEx:postIndex])
+			if err := m.Shortfall.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -624,7 +3738,7 @@ func (m *BridgeValidator) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Valset) Unmarshal(dAtA []byte) error {
+func (m *PausedToken) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -647,36 +3761,17 @@ func (m *Valset) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Valset: wiretype end group for non-group")
+			return fmt.Errorf("proto: PausedToken: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Valset: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PausedToken: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Nonce", wireType)
-			}
-			m.Nonce = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Nonce |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenContract", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -686,27 +3781,25 @@ func (m *Valset) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Members = append(m.Members, &BridgeValidator{})
-			if err := m.Members[len(m.Members)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.TokenContract = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 2:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
 			}
@@ -746,7 +3839,7 @@ func (m *Valset) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LastObservedEthereumBlockHeight) Unmarshal(dAtA []byte) error {
+func (m *BatchedTx) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -769,17 +3862,17 @@ func (m *LastObservedEthereumBlockHeight) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LastObservedEthereumBlockHeight: wiretype end group for non-group")
+			return fmt.Errorf("proto: BatchedTx: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LastObservedEthereumBlockHeight: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BatchedTx: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CosmosBlockHeight", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TxId", wireType)
 			}
-			m.CosmosBlockHeight = 0
+			m.TxId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -789,16 +3882,48 @@ func (m *LastObservedEthereumBlockHeight) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CosmosBlockHeight |= uint64(b&0x7F) << shift
+				m.TxId |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenContract", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TokenContract = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EthereumBlockHeight", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BatchNonce", wireType)
 			}
-			m.EthereumBlockHeight = 0
+			m.BatchNonce = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -808,11 +3933,43 @@ func (m *LastObservedEthereumBlockHeight) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.EthereumBlockHeight |= uint64(b&0x7F) << shift
+				m.BatchNonce |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sender = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -834,7 +3991,7 @@ func (m *LastObservedEthereumBlockHeight) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ERC20ToDenom) Unmarshal(dAtA []byte) error {
+func (m *ReclaimableDeposit) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -857,15 +4014,53 @@ func (m *ERC20ToDenom) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ERC20ToDenom: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReclaimableDeposit: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ERC20ToDenom: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReclaimableDeposit: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventNonce", wireType)
+			}
+			m.EventNonce = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EventNonce |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHeight", wireType)
+			}
+			m.BlockHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Erc20", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TokenContract", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -893,11 +4088,11 @@ func (m *ERC20ToDenom) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Erc20 = string(dAtA[iNdEx:postIndex])
+			m.TokenContract = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Denom", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EthereumSender", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -925,7 +4120,72 @@ func (m *ERC20ToDenom) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Denom = string(dAtA[iNdEx:postIndex])
+			m.EthereumSender = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CosmosReceiver", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CosmosReceiver = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Amount", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Amount.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
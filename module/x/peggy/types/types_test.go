@@ -53,9 +53,10 @@ func TestValsetCheckpointGold1(t *testing.T) {
 
 func TestValsetPowerDiff(t *testing.T) {
 	specs := map[string]struct {
-		start BridgeValidators
-		diff  BridgeValidators
-		exp   float64
+		start  BridgeValidators
+		diff   BridgeValidators
+		exp    float64
+		expBps uint64
 	}{
 		"no diff": {
 			start: BridgeValidators{
@@ -68,7 +69,8 @@ func TestValsetPowerDiff(t *testing.T) {
 				{Power: 2, EthereumAddress: "0x8E91960d704Df3fF24ECAb78AB9df1B5D9144140"},
 				{Power: 3, EthereumAddress: "0xF14879a175A2F1cEFC7c616f35b6d9c2b0Fd8326"},
 			},
-			exp: 0.0,
+			exp:    0.0,
+			expBps: 0,
 		},
 		"one": {
 			start: BridgeValidators{
@@ -81,7 +83,21 @@ func TestValsetPowerDiff(t *testing.T) {
 				{Power: 1, EthereumAddress: "0x8E91960d704Df3fF24ECAb78AB9df1B5D9144140"},
 				{Power: 3, EthereumAddress: "0xF14879a175A2F1cEFC7c616f35b6d9c2b0Fd8326"},
 			},
-			exp: 0.25,
+			exp:    0.25,
+			expBps: 2500,
+		},
+		"shared address": {
+			start: BridgeValidators{
+				{Power: 1, EthereumAddress: "0x479FFc856Cdfa0f5D1AE6Fa61915b01351A7773D"},
+				{Power: 1, EthereumAddress: "0x479FFc856Cdfa0f5D1AE6Fa61915b01351A7773D"},
+				{Power: 2, EthereumAddress: "0xF14879a175A2F1cEFC7c616f35b6d9c2b0Fd8326"},
+			},
+			diff: BridgeValidators{
+				{Power: 2, EthereumAddress: "0x479FFc856Cdfa0f5D1AE6Fa61915b01351A7773D"},
+				{Power: 2, EthereumAddress: "0xF14879a175A2F1cEFC7c616f35b6d9c2b0Fd8326"},
+			},
+			exp:    0.0,
+			expBps: 0,
 		},
 		"real world": {
 			start: BridgeValidators{
@@ -104,16 +120,36 @@ func TestValsetPowerDiff(t *testing.T) {
 				{Power: 291759231, EthereumAddress: "0xF14879a175A2F1cEFC7c616f35b6d9c2b0Fd8326"},
 				{Power: 6785098, EthereumAddress: "0x37A0603dA2ff6377E5C7f75698dabA8EE4Ba97B8"},
 			},
-			exp: 0.010000000023283065,
+			exp:    0.010000000023283065,
+			expBps: 100,
 		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
 			assert.Equal(t, spec.exp, spec.start.PowerDiff(spec.diff))
+			assert.Equal(t, spec.expBps, spec.start.PowerDiffBasisPoints(spec.diff))
 		})
 	}
 }
 
+func TestValsetDropsMemberOf(t *testing.T) {
+	previous := BridgeValidators{
+		{Power: 1, EthereumAddress: "0x479FFc856Cdfa0f5D1AE6Fa61915b01351A7773D"},
+		{Power: 2, EthereumAddress: "0x8E91960d704Df3fF24ECAb78AB9df1B5D9144140"},
+		{Power: 3},
+	}
+	assert.False(t, previous.DropsMemberOf(previous))
+	// power changes and new members don't drop anyone, neither do members without an Ethereum address
+	assert.False(t, BridgeValidators{
+		{Power: 5, EthereumAddress: "0x479FFc856Cdfa0f5D1AE6Fa61915b01351A7773D"},
+		{Power: 2, EthereumAddress: "0x8E91960d704Df3fF24ECAb78AB9df1B5D9144140"},
+		{Power: 3, EthereumAddress: "0xF14879a175A2F1cEFC7c616f35b6d9c2b0Fd8326"},
+	}.DropsMemberOf(previous))
+	assert.True(t, BridgeValidators{
+		{Power: 2, EthereumAddress: "0x8E91960d704Df3fF24ECAb78AB9df1B5D9144140"},
+	}.DropsMemberOf(previous))
+}
+
 func TestValsetSort(t *testing.T) {
 	specs := map[string]struct {
 		src BridgeValidators
@@ -186,3 +222,22 @@ func shuffled(v BridgeValidators) BridgeValidators {
 	})
 	return v
 }
+
+func TestFeeSecondIndexKeyOrder(t *testing.T) {
+	const contract = "0xc783df8a850f42e7F7e57013759C285caa701eB6"
+	keys := [][]byte{
+		GetFeeSecondIndexKey(*NewERC20Token(1000, contract), 3),
+		GetFeeSecondIndexKey(*NewERC20Token(1000, contract), 7),
+		GetFeeSecondIndexKey(*NewERC20Token(999, contract), 1),
+		GetFeeSecondIndexKey(*NewERC20Token(0, contract), 2),
+	}
+	// higher fees sort first, equal fees are ordered by id
+	for i := 1; i < len(keys); i++ {
+		assert.Equal(t, -1, bytes.Compare(keys[i-1], keys[i]))
+	}
+
+	gotContract, gotFee, gotID := ParseFeeSecondIndexKey(keys[1][len(SecondIndexOutgoingTXFeeKey):])
+	assert.Equal(t, contract, gotContract)
+	assert.Equal(t, "1000", gotFee.String())
+	assert.Equal(t, uint64(7), gotID)
+}
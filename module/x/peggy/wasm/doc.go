@@ -0,0 +1,14 @@
+/*
+Package wasm exposes the peggy module to CosmWasm contracts.
+
+The package has no dependency on wasmd, EncodeMessage and Querier have the signatures of the wasmd
+custom message encoder and custom query plugin so a chain embedding wasmd can wire them up with
+
+	wasmkeeper.MessageEncoders{Custom: wasm.EncodeMessage}
+	wasmkeeper.QueryPlugins{Custom: wasm.Querier(app.PeggyKeeper)}
+
+Contracts then send a PeggyMsg as a custom CosmosMsg and a PeggyQuery as a custom QueryRequest.
+Messages are converted into the regular peggy messages with the contract as sender, so they go
+through the same validation and msg server as transactions signed by users.
+*/
+package wasm
@@ -0,0 +1,75 @@
+package wasm
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// PeggyMsg is the custom message a contract sends to the peggy module, exactly one field must be set
+type PeggyMsg struct {
+	SendToEth       *SendToEth       `json:"send_to_eth,omitempty"`
+	CancelSendToEth *CancelSendToEth `json:"cancel_send_to_eth,omitempty"`
+}
+
+// SendToEth adds a transfer to Ethereum to the outgoing pool, the amount and fee are taken from the contract
+type SendToEth struct {
+	EthDest   string `json:"eth_dest"`
+	Amount    Coin   `json:"amount"`
+	BridgeFee Coin   `json:"bridge_fee"`
+}
+
+// CancelSendToEth removes a transfer of the contract from the outgoing pool and refunds it
+type CancelSendToEth struct {
+	TransactionID uint64 `json:"transaction_id"`
+}
+
+// Coin is the CosmWasm coin representation, the amount is a decimal string
+type Coin struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+func (c Coin) toSdk() (sdk.Coin, error) {
+	amount, ok := sdk.NewIntFromString(c.Amount)
+	if !ok {
+		return sdk.Coin{}, sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "amount %q", c.Amount)
+	}
+	coin := sdk.Coin{Denom: c.Denom, Amount: amount}
+	if err := coin.Validate(); err != nil {
+		return sdk.Coin{}, sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, err.Error())
+	}
+	return coin, nil
+}
+
+// EncodeMessage converts a PeggyMsg sent by a contract into peggy messages with the contract as sender
+func EncodeMessage(sender sdk.AccAddress, raw json.RawMessage) ([]sdk.Msg, error) {
+	var msg PeggyMsg
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	var out sdk.Msg
+	switch {
+	case msg.SendToEth != nil && msg.CancelSendToEth == nil:
+		amount, err := msg.SendToEth.Amount.toSdk()
+		if err != nil {
+			return nil, sdkerrors.Wrap(err, "amount")
+		}
+		fee, err := msg.SendToEth.BridgeFee.toSdk()
+		if err != nil {
+			return nil, sdkerrors.Wrap(err, "bridge fee")
+		}
+		out = types.NewMsgSendToEth(sender, msg.SendToEth.EthDest, amount, fee)
+	case msg.CancelSendToEth != nil && msg.SendToEth == nil:
+		out = types.NewMsgCancelSendToEth(sender, msg.CancelSendToEth.TransactionID)
+	default:
+		return nil, sdkerrors.Wrap(types.ErrInvalid, "exactly one peggy message must be set")
+	}
+	if err := out.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	return []sdk.Msg{out}, nil
+}
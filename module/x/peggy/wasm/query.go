@@ -0,0 +1,95 @@
+package wasm
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/keeper"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+)
+
+// PeggyQuery is the custom query a contract sends to the peggy module, one field must be set
+type PeggyQuery struct {
+	DenomToERC20 *DenomToERC20Query `json:"denom_to_erc20,omitempty"`
+	ERC20ToDenom *ERC20ToDenomQuery `json:"erc20_to_denom,omitempty"`
+	BridgeStatus *BridgeStatusQuery `json:"bridge_status,omitempty"`
+}
+
+// DenomToERC20Query looks up the ERC20 contract a denom is bridged as
+type DenomToERC20Query struct {
+	Denom string `json:"denom"`
+}
+
+// DenomToERC20Response is the response to a DenomToERC20Query
+type DenomToERC20Response struct {
+	ERC20            string `json:"erc20"`
+	CosmosOriginated bool   `json:"cosmos_originated"`
+}
+
+// ERC20ToDenomQuery looks up the denom an ERC20 contract is bridged as
+type ERC20ToDenomQuery struct {
+	ERC20 string `json:"erc20"`
+}
+
+// ERC20ToDenomResponse is the response to an ERC20ToDenomQuery
+type ERC20ToDenomResponse struct {
+	Denom            string `json:"denom"`
+	CosmosOriginated bool   `json:"cosmos_originated"`
+}
+
+// BridgeStatusQuery returns the bridge contract and how far the chain has observed it
+type BridgeStatusQuery struct{}
+
+// BridgeStatusResponse is the response to a BridgeStatusQuery
+type BridgeStatusResponse struct {
+	GravityID                  string `json:"gravity_id"`
+	BridgeContractAddress      string `json:"bridge_contract_address"`
+	BridgeChainID              uint64 `json:"bridge_chain_id"`
+	LastObservedEventNonce     uint64 `json:"last_observed_event_nonce"`
+	LastObservedEthereumHeight uint64 `json:"last_observed_ethereum_height"`
+	LatestValsetNonce          uint64 `json:"latest_valset_nonce"`
+}
+
+// Querier returns the handler for PeggyQuery requests of contracts
+func Querier(k keeper.Keeper) func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+	return func(ctx sdk.Context, request json.RawMessage) ([]byte, error) {
+		var query PeggyQuery
+		if err := json.Unmarshal(request, &query); err != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+		}
+
+		var res interface{}
+		switch {
+		case query.DenomToERC20 != nil:
+			cosmosOriginated, erc20, err := k.DenomToERC20Lookup(ctx, query.DenomToERC20.Denom)
+			if err != nil {
+				return nil, err
+			}
+			res = DenomToERC20Response{ERC20: erc20, CosmosOriginated: cosmosOriginated}
+		case query.ERC20ToDenom != nil:
+			if err := types.ValidateEthAddress(query.ERC20ToDenom.ERC20); err != nil {
+				return nil, sdkerrors.Wrap(err, "erc20")
+			}
+			cosmosOriginated, denom := k.ERC20ToDenomLookup(ctx, query.ERC20ToDenom.ERC20)
+			res = ERC20ToDenomResponse{Denom: denom, CosmosOriginated: cosmosOriginated}
+		case query.BridgeStatus != nil:
+			res = BridgeStatusResponse{
+				GravityID:                  k.GetGravityID(ctx),
+				BridgeContractAddress:      k.GetBridgeContractAddress(ctx),
+				BridgeChainID:              k.GetBridgeChainID(ctx),
+				LastObservedEventNonce:     k.GetLastObservedEventNonce(ctx),
+				LastObservedEthereumHeight: k.GetLastObservedEthereumBlockHeight(ctx).EthereumBlockHeight,
+				LatestValsetNonce:          k.GetLatestValsetNonce(ctx),
+			}
+		default:
+			return nil, sdkerrors.Wrap(types.ErrInvalid, "unknown peggy query")
+		}
+
+		bz, err := json.Marshal(res)
+		if err != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+		}
+		return bz, nil
+	}
+}
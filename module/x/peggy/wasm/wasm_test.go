@@ -0,0 +1,94 @@
+package wasm
+
+import (
+	"encoding/json"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/keeper"
+	"github.com/cosmos/gravity-bridge/module/x/peggy/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeMessage(t *testing.T) {
+	contract := sdk.AccAddress([]byte("contract____________"))
+	specs := map[string]struct {
+		src    string
+		expMsg sdk.Msg
+		expErr bool
+	}{
+		"send to eth": {
+			src: `{"send_to_eth":{"eth_dest":"0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7","amount":{"denom":"stake","amount":"100"},"bridge_fee":{"denom":"stake","amount":"2"}}}`,
+			expMsg: types.NewMsgSendToEth(contract, "0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7",
+				sdk.NewInt64Coin("stake", 100), sdk.NewInt64Coin("stake", 2)),
+		},
+		"cancel send to eth": {
+			src:    `{"cancel_send_to_eth":{"transaction_id":7}}`,
+			expMsg: &types.MsgCancelSendToEth{TransactionId: 7, Sender: contract.String()},
+		},
+		"invalid eth destination": {
+			src:    `{"send_to_eth":{"eth_dest":"0xinvalid","amount":{"denom":"stake","amount":"100"},"bridge_fee":{"denom":"stake","amount":"2"}}}`,
+			expErr: true,
+		},
+		"invalid amount": {
+			src:    `{"send_to_eth":{"eth_dest":"0xd041c41EA1bf0F006ADBb6d2c9ef9D425dE5eaD7","amount":{"denom":"stake","amount":"1.5"},"bridge_fee":{"denom":"stake","amount":"2"}}}`,
+			expErr: true,
+		},
+		"no message": {
+			src:    `{}`,
+			expErr: true,
+		},
+		"two messages": {
+			src:    `{"send_to_eth":{},"cancel_send_to_eth":{"transaction_id":7}}`,
+			expErr: true,
+		},
+		"invalid json": {
+			src:    `{`,
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			msgs, err := EncodeMessage(contract, json.RawMessage(spec.src))
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []sdk.Msg{spec.expMsg}, msgs)
+		})
+	}
+}
+
+func TestQuerier(t *testing.T) {
+	input := keeper.CreateTestEnv(t)
+	ctx := input.Context
+	querier := Querier(input.PeggyKeeper)
+	const myTokenContractAddr = "0x429881672B9AE42b8EbA0E26cD9C73711b891Ca5"
+
+	bz, err := querier(ctx, json.RawMessage(`{"erc20_to_denom":{"erc20":"`+myTokenContractAddr+`"}}`))
+	require.NoError(t, err)
+	var denomRes ERC20ToDenomResponse
+	require.NoError(t, json.Unmarshal(bz, &denomRes))
+	assert.Equal(t, ERC20ToDenomResponse{Denom: types.PeggyDenom(myTokenContractAddr)}, denomRes)
+
+	bz, err = querier(ctx, json.RawMessage(`{"denom_to_erc20":{"denom":"`+denomRes.Denom+`"}}`))
+	require.NoError(t, err)
+	var erc20Res DenomToERC20Response
+	require.NoError(t, json.Unmarshal(bz, &erc20Res))
+	assert.Equal(t, DenomToERC20Response{ERC20: myTokenContractAddr}, erc20Res)
+
+	bz, err = querier(ctx, json.RawMessage(`{"bridge_status":{}}`))
+	require.NoError(t, err)
+	var statusRes BridgeStatusResponse
+	require.NoError(t, json.Unmarshal(bz, &statusRes))
+	assert.Equal(t, keeper.TestingPeggyParams.GravityId, statusRes.GravityID)
+	assert.Equal(t, keeper.TestingPeggyParams.BridgeEthereumAddress, statusRes.BridgeContractAddress)
+	assert.Equal(t, keeper.TestingPeggyParams.BridgeChainId, statusRes.BridgeChainID)
+
+	_, err = querier(ctx, json.RawMessage(`{"erc20_to_denom":{"erc20":"0xinvalid"}}`))
+	assert.Error(t, err)
+	_, err = querier(ctx, json.RawMessage(`{}`))
+	assert.Error(t, err)
+}
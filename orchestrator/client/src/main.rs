@@ -148,7 +148,7 @@ async fn main() {
     if args.cmd_cosmos_to_eth {
         let peggy_denom = args.flag_cosmos_denom;
         // todo actually query metadata for this
-        let is_cosmos_originated = !peggy_denom.starts_with("peggy");
+        let is_cosmos_originated = !peggy_denom.starts_with("gravity0x");
         let amount = if is_cosmos_originated {
             fraction_to_exponent(args.flag_amount.unwrap(), 6)
         } else {
@@ -121,10 +121,18 @@ pub struct WithdrawClaimMsg {
     pub batch_nonce: Uint256,
     pub token_contract: EthAddress,
     pub orchestrator: Address,
+    pub bridge_contract_address: EthAddress,
+    pub bridge_chain_id: Uint256,
+    pub eth_tx_hash: String,
 }
 
 impl WithdrawClaimMsg {
-    pub fn from_event(input: TransactionBatchExecutedEvent, sender: Address) -> Self {
+    pub fn from_event(
+        input: TransactionBatchExecutedEvent,
+        sender: Address,
+        bridge_contract_address: EthAddress,
+        bridge_chain_id: Uint256,
+    ) -> Self {
         WithdrawClaimMsg {
             event_nonce: downcast_uint256(input.event_nonce)
                 .expect("Event nonce overflow! Bridge Halt!")
@@ -137,6 +145,9 @@ impl WithdrawClaimMsg {
                 .into(),
             token_contract: input.erc20,
             orchestrator: sender,
+            bridge_contract_address,
+            bridge_chain_id,
+            eth_tx_hash: input.tx_hash,
         }
     }
 }
@@ -150,10 +161,23 @@ pub struct DepositClaimMsg {
     pub ethereum_sender: EthAddress,
     pub cosmos_receiver: Address,
     pub orchestrator: Address,
+    pub bridge_contract_address: EthAddress,
+    pub bridge_chain_id: Uint256,
+    /// omitted when empty, like the amino JSON the chain signs
+    #[serde(skip_serializing_if = "String::is_empty", default)]
+    pub destination_tag: String,
+    /// omitted when not checked, like the amino JSON the chain signs
+    #[serde(skip_serializing_if = "Option::is_none", default)]
+    pub received_amount: Option<Uint256>,
 }
 
 impl DepositClaimMsg {
-    pub fn from_event(input: SendToCosmosEvent, sender: Address) -> Self {
+    pub fn from_event(
+        input: SendToCosmosEvent,
+        sender: Address,
+        bridge_contract_address: EthAddress,
+        bridge_chain_id: Uint256,
+    ) -> Self {
         DepositClaimMsg {
             event_nonce: downcast_uint256(input.event_nonce)
                 .expect("Event nonce overflow! Bridge Halt!")
@@ -166,6 +190,10 @@ impl DepositClaimMsg {
             ethereum_sender: input.sender,
             cosmos_receiver: input.destination,
             orchestrator: sender,
+            bridge_contract_address,
+            bridge_chain_id,
+            destination_tag: input.destination_tag,
+            received_amount: input.received_amount,
         }
     }
 }
@@ -180,10 +208,17 @@ pub struct ERC20DeployedClaimMsg {
     pub symbol: String,
     pub decimals: Uint256,
     pub orchestrator: Address,
+    pub bridge_contract_address: EthAddress,
+    pub bridge_chain_id: Uint256,
 }
 
 impl ERC20DeployedClaimMsg {
-    pub fn from_event(input: ERC20DeployedEvent, sender: Address) -> Self {
+    pub fn from_event(
+        input: ERC20DeployedEvent,
+        sender: Address,
+        bridge_contract_address: EthAddress,
+        bridge_chain_id: Uint256,
+    ) -> Self {
         ERC20DeployedClaimMsg {
             event_nonce: downcast_uint256(input.event_nonce)
                 .expect("Event nonce overflow! Bridge Halt!")
@@ -197,6 +232,8 @@ impl ERC20DeployedClaimMsg {
             symbol: input.symbol,
             decimals: input.decimals.into(),
             orchestrator: sender,
+            bridge_contract_address,
+            bridge_chain_id,
         }
     }
 }
@@ -208,10 +245,17 @@ pub struct LogicCallExecutedClaim {
     pub invalidation_id: Vec<u8>,
     pub invalidation_nonce: Uint256,
     pub orchestrator: Address,
+    pub bridge_contract_address: EthAddress,
+    pub bridge_chain_id: Uint256,
 }
 
 impl LogicCallExecutedClaim {
-    pub fn from_event(input: LogicCallExecutedEvent, sender: Address) -> Self {
+    pub fn from_event(
+        input: LogicCallExecutedEvent,
+        sender: Address,
+        bridge_contract_address: EthAddress,
+        bridge_chain_id: Uint256,
+    ) -> Self {
         LogicCallExecutedClaim {
             event_nonce: downcast_uint256(input.event_nonce)
                 .expect("Event nonce overflow! Bridge Halt!")
@@ -224,6 +268,8 @@ impl LogicCallExecutedClaim {
                 .into(),
             invalidation_id: input.invalidation_id,
             orchestrator: sender,
+            bridge_contract_address,
+            bridge_chain_id,
         }
     }
 }
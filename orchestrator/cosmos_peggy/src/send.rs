@@ -1,6 +1,7 @@
 use crate::messages::*;
 use clarity::Address as EthAddress;
 use clarity::PrivateKey as EthPrivateKey;
+use clarity::Uint256;
 use contact::jsonrpc::error::JsonRpcError;
 use contact::types::TXSendResponse;
 use contact::{client::Contact, utils::maybe_get_optional_tx_info};
@@ -215,6 +216,8 @@ pub async fn send_logic_call_confirm(
 pub async fn send_ethereum_claims(
     contact: &Contact,
     private_key: PrivateKey,
+    bridge_contract_address: EthAddress,
+    bridge_chain_id: Uint256,
     deposits: Vec<SendToCosmosEvent>,
     withdraws: Vec<TransactionBatchExecutedEvent>,
     erc20_deploys: Vec<ERC20DeployedEvent>,
@@ -240,25 +243,45 @@ pub async fn send_ethereum_claims(
     for deposit in deposits {
         unordered_msgs.insert(
             deposit.event_nonce.clone(),
-            PeggyMsg::DepositClaimMsg(DepositClaimMsg::from_event(deposit, our_address)),
+            PeggyMsg::DepositClaimMsg(DepositClaimMsg::from_event(
+                deposit,
+                our_address,
+                bridge_contract_address,
+                bridge_chain_id.clone(),
+            )),
         );
     }
     for withdraw in withdraws {
         unordered_msgs.insert(
             withdraw.event_nonce.clone(),
-            PeggyMsg::WithdrawClaimMsg(WithdrawClaimMsg::from_event(withdraw, our_address)),
+            PeggyMsg::WithdrawClaimMsg(WithdrawClaimMsg::from_event(
+                withdraw,
+                our_address,
+                bridge_contract_address,
+                bridge_chain_id.clone(),
+            )),
         );
     }
     for deploy in erc20_deploys {
         unordered_msgs.insert(
             deploy.event_nonce.clone(),
-            PeggyMsg::ERC20DeployedClaimMsg(ERC20DeployedClaimMsg::from_event(deploy, our_address)),
+            PeggyMsg::ERC20DeployedClaimMsg(ERC20DeployedClaimMsg::from_event(
+                deploy,
+                our_address,
+                bridge_contract_address,
+                bridge_chain_id.clone(),
+            )),
         );
     }
     for call in logic_calls {
         unordered_msgs.insert(
             call.event_nonce.clone(),
-            PeggyMsg::LogicCallExecutedClaim(LogicCallExecutedClaim::from_event(call, our_address)),
+            PeggyMsg::LogicCallExecutedClaim(LogicCallExecutedClaim::from_event(
+                call,
+                our_address,
+                bridge_contract_address,
+                bridge_chain_id.clone(),
+            )),
         );
     }
     let mut keys = Vec::new();
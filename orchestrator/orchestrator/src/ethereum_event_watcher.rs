@@ -22,6 +22,52 @@ use slog::{info as sinfo};
 use crate::get_with_retry::get_block_number_with_retry;
 use crate::get_with_retry::get_net_version_with_retry;
 
+/// Fills in the amount the Peggy contract received for every deposit from the
+/// Transfer logs of the deposited tokens, so the chain only credits what arrived
+/// for tokens taking a fee on transfer. Deposits of the same token and sender
+/// within one transaction can't be told apart and are left unchecked.
+async fn with_received_amounts(
+    web3: &Web3,
+    peggy_contract_address: EthAddress,
+    starting_block: Uint256,
+    latest_block: Uint256,
+    mut deposits: Vec<SendToCosmosEvent>,
+) -> Result<Vec<SendToCosmosEvent>, PeggyError> {
+    if deposits.is_empty() {
+        return Ok(deposits);
+    }
+    let mut tokens: Vec<EthAddress> = Vec::new();
+    for deposit in deposits.iter() {
+        if !tokens.contains(&deposit.erc20) {
+            tokens.push(deposit.erc20);
+        }
+    }
+    let transfers = web3
+        .check_for_events(
+            starting_block,
+            Some(latest_block),
+            tokens,
+            vec!["Transfer(address,address,uint256)"],
+        )
+        .await?;
+    for i in 0..deposits.len() {
+        let shared = deposits
+            .iter()
+            .filter(|d| {
+                d.tx_hash == deposits[i].tx_hash
+                    && d.erc20 == deposits[i].erc20
+                    && d.sender == deposits[i].sender
+            })
+            .count()
+            > 1;
+        if !shared {
+            deposits[i].received_amount =
+                deposits[i].received_amount(&transfers, peggy_contract_address);
+        }
+    }
+    Ok(deposits)
+}
+
 pub async fn check_for_events(
     web3: &Web3,
     contact: &Contact,
@@ -97,6 +143,14 @@ pub async fn check_for_events(
         let withdraws = TransactionBatchExecutedEvent::from_logs(&batches)?;
         trace!("parsed batches {:?}", batches);
         let deposits = SendToCosmosEvent::from_logs(&deposits)?;
+        let deposits = with_received_amounts(
+            web3,
+            peggy_contract_address,
+            starting_block.clone(),
+            latest_block.clone(),
+            deposits,
+        )
+        .await?;
         trace!("parsed deposits {:?}", deposits);
         let erc20_deploys = ERC20DeployedEvent::from_logs(&deploys)?;
         trace!("parsed erc20 deploys {:?}", erc20_deploys);
@@ -175,9 +229,12 @@ pub async fn check_for_events(
             || !erc20_deploys.is_empty()
             || !logic_calls.is_empty()
         {
+            let bridge_chain_id = get_net_version_with_retry(web3).await;
             let res = send_ethereum_claims(
                 contact,
                 our_private_key,
+                peggy_contract_address,
+                bridge_chain_id.into(),
                 deposits,
                 withdraws,
                 erc20_deploys,
@@ -6,5 +6,5 @@
 
 pub use cosmos_sdk_proto;
 pub mod peggy {
-    include!("prost/peggy.v1.rs");
+    include!("prost/gravity.v1.rs");
 }
@@ -2,6 +2,7 @@ use std::unimplemented;
 
 use super::ValsetMember;
 use crate::error::PeggyError;
+use clarity::utils::bytes_to_hex_str;
 use clarity::Address as EthAddress;
 use deep_space::address::Address as CosmosAddress;
 use num256::Uint256;
@@ -126,6 +127,9 @@ pub struct TransactionBatchExecutedEvent {
     /// of the Peggy solidity contract. Ensuring that these events can only be played
     /// back in order
     pub event_nonce: Uint256,
+    /// the hex encoded hash of the Ethereum transaction that executed the batch,
+    /// empty if the node did not report it
+    pub tx_hash: String,
 }
 
 impl TransactionBatchExecutedEvent {
@@ -144,6 +148,10 @@ impl TransactionBatchExecutedEvent {
                         .to_string(),
                 ));
             };
+            let tx_hash = match &input.transaction_hash {
+                Some(hash) => format!("0x{}", bytes_to_hex_str(hash)),
+                None => String::new(),
+            };
             if event_nonce > u64::MAX.into()
                 || batch_nonce > u64::MAX.into()
                 || block_height > u64::MAX.into()
@@ -157,6 +165,7 @@ impl TransactionBatchExecutedEvent {
                     block_height,
                     erc20,
                     event_nonce,
+                    tx_hash,
                 })
             }
         } else {
@@ -195,12 +204,23 @@ pub struct SendToCosmosEvent {
     pub sender: EthAddress,
     /// The Cosmos destination
     pub destination: CosmosAddress,
+    /// The destination tag routing the deposit to a sub-account of an omnibus
+    /// account, carried in the 12 bytes of the destination above the address.
+    /// Empty when those bytes are zero
+    pub destination_tag: String,
     /// The amount of the erc20 token that is being sent
     pub amount: Uint256,
+    /// The amount the Peggy contract actually received according to the Transfer
+    /// logs of the deposit transaction, below amount for tokens taking a fee on
+    /// transfer. None if it was not checked
+    pub received_amount: Option<Uint256>,
     /// The transaction's nonce, used to make sure there can be no accidental duplication
     pub event_nonce: Uint256,
     /// The block height this event occurred at
     pub block_height: Uint256,
+    /// the hex encoded hash of the Ethereum transaction of the deposit,
+    /// empty if the node did not report it
+    pub tx_hash: String,
 }
 
 impl SendToCosmosEvent {
@@ -218,8 +238,18 @@ impl SendToCosmosEvent {
             let mut c_address_bytes: [u8; 20] = [0; 20];
             c_address_bytes.copy_from_slice(&destination_data[12..32]);
             let destination = CosmosAddress::from_bytes(c_address_bytes);
+            let tag = Uint256::from_bytes_be(&destination_data[..12]);
+            let destination_tag = if tag == 0u8.into() {
+                String::new()
+            } else {
+                tag.to_string()
+            };
             let amount = Uint256::from_bytes_be(&input.data[..32]);
             let event_nonce = Uint256::from_bytes_be(&input.data[32..]);
+            let tx_hash = match &input.transaction_hash {
+                Some(hash) => format!("0x{}", bytes_to_hex_str(hash)),
+                None => String::new(),
+            };
             let block_height = if let Some(bn) = input.block_number.clone() {
                 bn
             } else {
@@ -237,9 +267,12 @@ impl SendToCosmosEvent {
                     erc20,
                     sender,
                     destination,
+                    destination_tag,
                     amount,
+                    received_amount: None,
                     event_nonce,
                     block_height,
+                    tx_hash,
                 })
             }
         } else {
@@ -255,6 +288,38 @@ impl SendToCosmosEvent {
         }
         Ok(res)
     }
+    /// Sums the ERC20 Transfer logs moving the token of the deposit from its sender
+    /// to the Peggy contract within the transaction of the deposit. Returns None if
+    /// the transaction is unknown or has no such transfer
+    pub fn received_amount(&self, transfers: &[Log], peggy_contract: EthAddress) -> Option<Uint256> {
+        if self.tx_hash.is_empty() {
+            return None;
+        }
+        let mut received: Option<Uint256> = None;
+        for log in transfers {
+            let tx_hash = match &log.transaction_hash {
+                Some(hash) => format!("0x{}", bytes_to_hex_str(hash)),
+                None => continue,
+            };
+            if log.address != self.erc20 || tx_hash != self.tx_hash || log.data.len() < 32 {
+                continue;
+            }
+            if let (Some(from_data), Some(to_data)) = (log.topics.get(1), log.topics.get(2)) {
+                let from = EthAddress::from_slice(&from_data[12..32]);
+                let to = EthAddress::from_slice(&to_data[12..32]);
+                if let (Ok(from), Ok(to)) = (from, to) {
+                    if from == self.sender && to == peggy_contract {
+                        let value = Uint256::from_bytes_be(&log.data[..32]);
+                        received = Some(match received {
+                            Some(total) => total + value,
+                            None => value,
+                        });
+                    }
+                }
+            }
+        }
+        received
+    }
     /// returns all values in the array with event nonces greater
     /// than the provided value
     pub fn filter_by_event_nonce(event_nonce: u64, input: &[Self]) -> Vec<Self> {
@@ -131,6 +131,8 @@ pub async fn happy_path_test(
     submit_duplicate_erc20_send(
         1u64.into(),
         &contact,
+        peggy_address,
+        web30.net_version().await.unwrap().into(),
         erc20_address,
         1u64.into(),
         dest_cosmos_address,
@@ -318,7 +320,7 @@ async fn test_erc20_deposit(
     erc20_address: EthAddress,
     amount: Uint256,
 ) {
-    let start_coin = check_cosmos_balance("peggy", dest, &contact).await;
+    let start_coin = check_cosmos_balance("gravity", dest, &contact).await;
     info!(
         "Sending to Cosmos from {} to {} with amount {}",
         *MINER_ADDRESS, dest, amount
@@ -352,7 +354,7 @@ async fn test_erc20_deposit(
     while Instant::now() - start < TOTAL_TIMEOUT {
         match (
             start_coin.clone(),
-            check_cosmos_balance("peggy", dest, &contact).await,
+            check_cosmos_balance("gravity", dest, &contact).await,
         ) {
             (Some(start_coin), Some(end_coin)) => {
                 if start_coin.amount + amount.clone() == end_coin.amount
@@ -413,7 +415,7 @@ async fn test_batch(
         .to_public_key()
         .unwrap()
         .to_address();
-    let coin = check_cosmos_balance("peggy", dest_cosmos_address, &contact)
+    let coin = check_cosmos_balance("gravity", dest_cosmos_address, &contact)
         .await
         .unwrap();
     let token_name = coin.denom;
@@ -541,12 +543,14 @@ async fn test_batch(
 async fn submit_duplicate_erc20_send(
     nonce: Uint256,
     contact: &Contact,
+    peggy_address: EthAddress,
+    bridge_chain_id: Uint256,
     erc20_address: EthAddress,
     amount: Uint256,
     receiver: CosmosAddress,
     keys: Vec<(CosmosPrivateKey, EthPrivateKey)>,
 ) {
-    let start_coin = check_cosmos_balance("peggy", receiver, &contact)
+    let start_coin = check_cosmos_balance("gravity", receiver, &contact)
         .await
         .expect("Did not find coins!");
 
@@ -560,6 +564,9 @@ async fn submit_duplicate_erc20_send(
         erc20: erc20_address,
         sender: ethereum_sender,
         destination: receiver,
+        destination_tag: String::new(),
+        received_amount: None,
+        tx_hash: String::new(),
         amount,
     };
 
@@ -568,6 +575,8 @@ async fn submit_duplicate_erc20_send(
         let res = send_ethereum_claims(
             contact,
             *c_key,
+            peggy_address,
+            bridge_chain_id.clone(),
             vec![event.clone()],
             vec![],
             vec![],
@@ -579,7 +588,7 @@ async fn submit_duplicate_erc20_send(
         trace!("Submitted duplicate sendToCosmos event: {:?}", res);
     }
 
-    if let Some(end_coin) = check_cosmos_balance("peggy", receiver, &contact).await {
+    if let Some(end_coin) = check_cosmos_balance("gravity", receiver, &contact).await {
         if start_coin.amount == end_coin.amount && start_coin.denom == end_coin.denom {
             info!("Successfully failed to duplicate ERC20!");
             sinfo!(&LOGGING.logger, "SUCCESSFULLY_FAILED_TO_DUPLICATE_ERC20";"function" => "submit_duplicate_erc20_send()");
@@ -1,16 +1,28 @@
 pragma solidity ^0.6.6;
 import "@openzeppelin/contracts/token/ERC20/ERC20.sol";
 
+// The contract deploying a CosmosERC20 holds the parameters of the token while it is deployed
+interface CosmosERC20Deployer {
+	function state_erc20Name() external view returns (string memory);
+
+	function state_erc20Symbol() external view returns (string memory);
+
+	function state_erc20Decimals() external view returns (uint8);
+}
+
 contract CosmosERC20 is ERC20 {
 	uint256 MAX_UINT = 2**256 - 1;
 
-	constructor(
-		address _peggyAddress,
-		string memory _name,
-		string memory _symbol,
-		uint8 _decimals
-	) public ERC20(_name, _symbol) {
-		_setupDecimals(_decimals);
-		_mint(_peggyAddress, MAX_UINT);
+	// The parameters are read from the deployer rather than passed to the constructor, so the
+	// init code is the same for every token and its CREATE2 address only depends on the salt
+	constructor()
+		public
+		ERC20(
+			CosmosERC20Deployer(msg.sender).state_erc20Name(),
+			CosmosERC20Deployer(msg.sender).state_erc20Symbol()
+		)
+	{
+		_setupDecimals(CosmosERC20Deployer(msg.sender).state_erc20Decimals());
+		_mint(msg.sender, MAX_UINT);
 	}
 }
@@ -41,6 +41,11 @@ contract Peggy is ReentrancyGuard {
 	bytes32 public state_peggyId;
 	uint256 public state_powerThreshold;
 
+	// These are only set while deployERC20 deploys a token, see CosmosERC20
+	string public state_erc20Name;
+	string public state_erc20Symbol;
+	uint8 public state_erc20Decimals;
+
 	// TransactionBatchExecutedEvent and SendToCosmosEvent both include the field _eventNonce.
 	// This is incremented every time one of these events is emitted. It is checked by the
 	// Cosmos module to ensure that all events are received in order, and that none are lost.
@@ -539,8 +544,17 @@ contract Peggy is ReentrancyGuard {
 		string memory _symbol,
 		uint8 _decimals
 	) public {
-		// Deploy an ERC20 with entire supply granted to Peggy.sol
-		CosmosERC20 erc20 = new CosmosERC20(address(this), _name, _symbol, _decimals);
+		// Deploy an ERC20 with entire supply granted to Peggy.sol. The address is derived with
+		// CREATE2 from the denom and the token parameters, so the Cosmos module can tell the
+		// address before the token is deployed. A second deployment of the same token reverts.
+		state_erc20Name = _name;
+		state_erc20Symbol = _symbol;
+		state_erc20Decimals = _decimals;
+		bytes32 salt = keccak256(abi.encode(_cosmosDenom, _name, _symbol, _decimals));
+		CosmosERC20 erc20 = new CosmosERC20{ salt: salt }();
+		delete state_erc20Name;
+		delete state_erc20Symbol;
+		delete state_erc20Decimals;
 
 		// Fire an event to let the Cosmos module know
 		state_lastEventNonce = state_lastEventNonce.add(1);
@@ -57,15 +57,29 @@ async function runTest(opts: {}) {
   // ===============================================
   const eventArgs = await parseEvent(peggy, peggy.deployERC20('uatom', 'Atom', 'ATOM', 6), 1)
 
+  // The token is deployed with CREATE2, its address is known ahead of time
+  const erc20Factory = await ethers.getContractFactory("CosmosERC20");
+  const expectedAddress = ethers.utils.getCreate2Address(
+    peggy.address,
+    ethers.utils.keccak256(ethers.utils.defaultAbiCoder.encode(
+      ["string", "string", "string", "uint8"],
+      ['uatom', 'Atom', 'ATOM', 6]
+    )),
+    ethers.utils.keccak256(erc20Factory.bytecode)
+  )
+
   expect(eventArgs).to.deep.equal({
     _cosmosDenom: 'uatom',
-    _tokenContract: eventArgs._tokenContract, // We don't know this ahead of time
+    _tokenContract: expectedAddress,
     _name: 'Atom',
     _symbol: 'ATOM',
     _decimals: 6,
     _eventNonce: BigNumber.from(1)
   })
 
+  // The same token can't be deployed twice
+  await expect(peggy.deployERC20('uatom', 'Atom', 'ATOM', 6)).to.be.reverted
+
 
 
 